@@ -0,0 +1,115 @@
+package analysis
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// Attribute describes a quasi-identifier extracted from a record's extraction result
+type Attribute struct {
+	// Name identifies the attribute in reports (e.g. "zip_code", "dob")
+	Name string
+	// Value returns the attribute value for a record, or an empty string when absent
+	Value func(record *pii.PiiExtractionResult) string
+}
+
+// TypeAttribute creates a quasi-identifier from all values of a PII type found in a record.
+// Multiple values are sorted and joined so the attribute is independent of extraction order.
+func TypeAttribute(piiType pii.PiiType) Attribute {
+	return Attribute{
+		Name: piiType.String(),
+		Value: func(record *pii.PiiExtractionResult) string {
+			if record == nil {
+				return ""
+			}
+			var values []string
+			for _, entity := range record.GetEntitiesByType(piiType) {
+				values = append(values, entity.GetValue())
+			}
+			sort.Strings(values)
+			return strings.Join(values, "|")
+		},
+	}
+}
+
+// RiskReport summarizes the re-identification risk of a dataset for a set of quasi-identifiers
+type RiskReport struct {
+	QuasiIdentifiers      []string    `json:"quasi_identifiers"`
+	Records               int         `json:"records"`
+	EquivalenceClasses    int         `json:"equivalence_classes"`
+	K                     int         `json:"k"`                // Smallest equivalence class size (k-anonymity level)
+	UniqueRecords         int         `json:"unique_records"`   // Records alone in their equivalence class
+	UniquenessRatio       float64     `json:"uniqueness_ratio"` // UniqueRecords / Records
+	AverageClassSize      float64     `json:"average_class_size"`
+	TargetK               int         `json:"target_k"`
+	RecordsAtRisk         int         `json:"records_at_risk"` // Records in classes smaller than TargetK
+	ProsecutorRisk        float64     `json:"prosecutor_risk"` // Maximum re-identification probability (1/K)
+	AverageRisk           float64     `json:"average_risk"`    // Mean re-identification probability across records
+	ClassSizeDistribution map[int]int `json:"class_size_distribution"`
+}
+
+// MeetsTarget returns true if every record is in an equivalence class of at least TargetK records
+func (r *RiskReport) MeetsTarget() bool {
+	return r.Records > 0 && r.K >= r.TargetK
+}
+
+// KAnonymity groups records into equivalence classes by their quasi-identifier values and
+// computes uniqueness statistics. Each extraction result represents one record of the dataset.
+func KAnonymity(records []*pii.PiiExtractionResult, quasiIdentifiers []Attribute, targetK int) *RiskReport {
+	report := &RiskReport{
+		Records:               len(records),
+		TargetK:               targetK,
+		ClassSizeDistribution: make(map[int]int),
+	}
+	for _, attribute := range quasiIdentifiers {
+		report.QuasiIdentifiers = append(report.QuasiIdentifiers, attribute.Name)
+	}
+
+	if len(records) == 0 {
+		return report
+	}
+
+	classes := make(map[string]int)
+	for _, record := range records {
+		classes[classKey(record, quasiIdentifiers)]++
+	}
+
+	report.EquivalenceClasses = len(classes)
+	report.K = len(records)
+	for _, size := range classes {
+		report.ClassSizeDistribution[size]++
+		if size < report.K {
+			report.K = size
+		}
+		if size == 1 {
+			report.UniqueRecords++
+		}
+		if size < targetK {
+			report.RecordsAtRisk += size
+		}
+	}
+
+	report.UniquenessRatio = float64(report.UniqueRecords) / float64(report.Records)
+	report.AverageClassSize = float64(report.Records) / float64(report.EquivalenceClasses)
+	report.ProsecutorRisk = 1 / float64(report.K)
+	// Each record's risk is 1/classSize, so the sum over records equals the number of classes
+	report.AverageRisk = float64(report.EquivalenceClasses) / float64(report.Records)
+
+	return report
+}
+
+// classKey builds the equivalence class key of a record
+func classKey(record *pii.PiiExtractionResult, quasiIdentifiers []Attribute) string {
+	var builder strings.Builder
+	for i, attribute := range quasiIdentifiers {
+		if i > 0 {
+			builder.WriteByte(0)
+		}
+		if attribute.Value != nil {
+			builder.WriteString(attribute.Value(record))
+		}
+	}
+	return builder.String()
+}
@@ -0,0 +1,66 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+func record(zip, phone string) *pii.PiiExtractionResult {
+	entities := []pii.PiiEntity{
+		{Type: pii.PiiTypeZipCode, Value: pii.NewZipCode(zip, "US")},
+	}
+	if phone != "" {
+		entities = append(entities, pii.PiiEntity{Type: pii.PiiTypePhone, Value: pii.NewPhoneUS(phone)})
+	}
+	return pii.NewPiiExtractionResult(entities)
+}
+
+func TestKAnonymity(t *testing.T) {
+	records := []*pii.PiiExtractionResult{
+		record("10001", ""),
+		record("10001", ""),
+		record("10001", ""),
+		record("90210", ""),
+		record("90210", ""),
+		record("60601", ""),
+	}
+
+	report := KAnonymity(records, []Attribute{TypeAttribute(pii.PiiTypeZipCode)}, 2)
+
+	if report.EquivalenceClasses != 3 {
+		t.Errorf("Expected 3 equivalence classes, got %d", report.EquivalenceClasses)
+	}
+	if report.K != 1 {
+		t.Errorf("Expected k=1, got %d", report.K)
+	}
+	if report.UniqueRecords != 1 || report.RecordsAtRisk != 1 {
+		t.Errorf("Expected 1 unique record at risk, got unique=%d atRisk=%d", report.UniqueRecords, report.RecordsAtRisk)
+	}
+	if report.MeetsTarget() {
+		t.Error("Expected dataset not to meet k=2")
+	}
+	if report.AverageRisk != 0.5 {
+		t.Errorf("Expected average risk 0.5, got %f", report.AverageRisk)
+	}
+}
+
+func TestKAnonymity_CombinedQuasiIdentifiers(t *testing.T) {
+	records := []*pii.PiiExtractionResult{
+		record("10001", "555-123-4567"),
+		record("10001", "555-987-6543"),
+	}
+
+	zipOnly := KAnonymity(records, []Attribute{TypeAttribute(pii.PiiTypeZipCode)}, 2)
+	if !zipOnly.MeetsTarget() {
+		t.Errorf("Expected zip-only quasi-identifier to meet k=2, got k=%d", zipOnly.K)
+	}
+
+	combined := KAnonymity(records, []Attribute{
+		TypeAttribute(pii.PiiTypeZipCode),
+		TypeAttribute(pii.PiiTypePhone),
+	}, 2)
+	if combined.UniqueRecords != 2 {
+		t.Errorf("Expected every record to be unique, got %d", combined.UniqueRecords)
+	}
+}
@@ -0,0 +1,144 @@
+package piiextractor
+
+import (
+	"flag"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+var updateAPI = flag.Bool("update-api", false, "record the current stable API in api/stable.txt")
+
+// stableAPIFile records the exported identifiers of the stable packages
+const stableAPIFile = "api/stable.txt"
+
+// TestStableAPI fails when an exported identifier of a stable package is removed. New
+// identifiers are recorded with go test -run TestStableAPI -update-api.
+func TestStableAPI(t *testing.T) {
+	current := make(map[string]bool)
+	for _, dir := range StablePackages {
+		for _, identifier := range exportedIdentifiers(t, dir) {
+			current[identifier] = true
+		}
+	}
+
+	if *updateAPI {
+		lines := make([]string, 0, len(current))
+		for identifier := range current {
+			lines = append(lines, identifier)
+		}
+		sort.Strings(lines)
+		if err := os.WriteFile(stableAPIFile, []byte(strings.Join(lines, "\n")+"\n"), 0o644); err != nil {
+			t.Fatalf("Failed to write %s: %v", stableAPIFile, err)
+		}
+		return
+	}
+
+	data, err := os.ReadFile(stableAPIFile)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", stableAPIFile, err)
+	}
+	recorded := make(map[string]bool)
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		recorded[line] = true
+		if !current[line] {
+			t.Errorf("Stable API removed: %s (keep it as a deprecated alias or wrapper)", line)
+		}
+	}
+	for identifier := range current {
+		if !recorded[identifier] {
+			t.Errorf("Stable API added but not recorded: %s (run go test -run TestStableAPI -update-api)", identifier)
+		}
+	}
+}
+
+func TestPackageStability(t *testing.T) {
+	tests := map[string]StabilityTier{
+		ModulePath:                              TierStable,
+		ModulePath + "/pii":                     TierStable,
+		"extractors/regex/patterns":             TierStable,
+		"scanner":                               TierBeta,
+		ModulePath + "/experimental/projection": TierExperimental,
+		"experimental":                          TierExperimental,
+	}
+	for path, expected := range tests {
+		if tier := PackageStability(path); tier != expected {
+			t.Errorf("PackageStability(%q) = %s, expected %s", path, tier, expected)
+		}
+	}
+}
+
+// exportedIdentifiers returns the exported declarations of the package in dir, such as
+// "pii: func NewEmail" or "pii: method PiiExtractionResult.Occurrences"
+func exportedIdentifiers(t *testing.T, dir string) []string {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		t.Fatalf("Failed to list %s: %v", dir, err)
+	}
+
+	var identifiers []string
+	fset := token.NewFileSet()
+	for _, path := range files {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			t.Fatalf("Failed to parse %s: %v", path, err)
+		}
+		add := func(kind, name string) {
+			identifiers = append(identifiers, dir+": "+kind+" "+name)
+		}
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if !decl.Name.IsExported() {
+					continue
+				}
+				if decl.Recv == nil {
+					add("func", decl.Name.Name)
+					continue
+				}
+				if receiver := receiverName(decl.Recv.List[0].Type); ast.IsExported(receiver) {
+					add("method", receiver+"."+decl.Name.Name)
+				}
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					switch spec := spec.(type) {
+					case *ast.TypeSpec:
+						if spec.Name.IsExported() {
+							add("type", spec.Name.Name)
+						}
+					case *ast.ValueSpec:
+						for _, name := range spec.Names {
+							if name.IsExported() {
+								add(strings.ToLower(decl.Tok.String()), name.Name)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	return identifiers
+}
+
+// receiverName returns the type name of a method receiver
+func receiverName(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.StarExpr:
+		return receiverName(expr.X)
+	case *ast.IndexExpr:
+		return receiverName(expr.X)
+	case *ast.IndexListExpr:
+		return receiverName(expr.X)
+	case *ast.Ident:
+		return expr.Name
+	}
+	return ""
+}
@@ -0,0 +1,231 @@
+// Package calibration maps the heterogeneous confidence signals of the extraction
+// backends (pattern matches, checksum results, LLM validation confidence) onto a single
+// calibrated probability that an entity is real PII, so that a threshold means the same
+// thing whatever backend produced the entity.
+//
+// Calibrators are fitted with Platt scaling on entities labelled by a human review,
+// per signal and, when enough samples exist, per signal and type.
+package calibration
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/intMeric/pii-extractor/extractors"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// Signal identifies where the raw score of an entity comes from
+type Signal string
+
+const (
+	SignalPattern  Signal = "pattern"  // Pattern match without stronger evidence, scored 1
+	SignalChecksum Signal = "checksum" // Luhn or IBAN mod 97 check, scored 1 when it passes and 0 otherwise
+	SignalLLM      Signal = "llm"      // LLM validation, scored by its confidence that the value is PII
+)
+
+// Annotations set on calibrated entities
+const (
+	AnnotationProbability = "calibration.probability"
+	AnnotationSignal      = "calibration.signal"
+)
+
+// DefaultMinSamples is the number of labelled samples needed to fit a per-type model
+const DefaultMinSamples = 30
+
+// Scorer returns the raw score of an entity and the signal it comes from. Scores of a
+// signal must be comparable across types; they do not need to be probabilities.
+type Scorer func(entity pii.PiiEntity) (Signal, float64)
+
+// RawScore is the default Scorer. LLM validation takes precedence over checksums, which
+// take precedence over the pattern match itself.
+func RawScore(entity pii.PiiEntity) (Signal, float64) {
+	if entity.Validation != nil {
+		if entity.Validation.Valid {
+			return SignalLLM, entity.Validation.Confidence
+		}
+		return SignalLLM, 1 - entity.Validation.Confidence
+	}
+
+	switch entity.Type {
+	case pii.PiiTypeCreditCard:
+		return SignalChecksum, passed(luhn(entity.GetValue()))
+	case pii.PiiTypeIBAN:
+		return SignalChecksum, passed(ibanChecksum(entity.GetValue()))
+	}
+	return SignalPattern, 1
+}
+
+// Sample is an entity labelled by a reviewer
+type Sample struct {
+	Entity pii.PiiEntity
+	PII    bool // Whether the entity is real PII, false for a false positive
+}
+
+// Config controls how calibrators are fitted
+type Config struct {
+	Scorer     Scorer // Nil uses RawScore
+	MinSamples int    // Samples needed for a per-type model, zero uses DefaultMinSamples
+}
+
+// Calibrator maps entities onto calibrated probabilities. It is JSON-serializable, so
+// a calibrator fitted once can be stored and loaded by the scanning jobs; set Scorer
+// again after loading if a custom one was used.
+type Calibrator struct {
+	// Models are keyed by signal ("llm") and by signal and type ("llm/email")
+	Models map[string]Platt `json:"models"`
+
+	Scorer Scorer `json:"-"` // Nil uses RawScore
+}
+
+// Fit fits a calibrator on labelled samples. Signals without samples are left
+// uncalibrated (see Probability).
+func Fit(samples []Sample, config *Config) *Calibrator {
+	if config == nil {
+		config = &Config{}
+	}
+	minSamples := config.MinSamples
+	if minSamples <= 0 {
+		minSamples = DefaultMinSamples
+	}
+	calibrator := &Calibrator{Models: make(map[string]Platt), Scorer: config.Scorer}
+
+	type group struct {
+		scores []float64
+		labels []bool
+	}
+	groups := make(map[string]*group)
+	add := func(key string, score float64, label bool) {
+		g, ok := groups[key]
+		if !ok {
+			g = &group{}
+			groups[key] = g
+		}
+		g.scores = append(g.scores, score)
+		g.labels = append(g.labels, label)
+	}
+	for _, sample := range samples {
+		signal, score := calibrator.score(sample.Entity)
+		add(string(signal), score, sample.PII)
+		add(modelKey(signal, sample.Entity.Type), score, sample.PII)
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(groups)) {
+		g := groups[key]
+		if strings.Contains(key, "/") && len(g.scores) < minSamples {
+			continue
+		}
+		calibrator.Models[key] = FitPlatt(g.scores, g.labels)
+	}
+	return calibrator
+}
+
+// Probability returns the calibrated probability that an entity is PII, using the model
+// of its signal and type, else of its signal. Entities of an uncalibrated signal keep
+// their raw score, clamped to [0, 1].
+func (c *Calibrator) Probability(entity pii.PiiEntity) float64 {
+	signal, score := c.score(entity)
+	if model, ok := c.Models[modelKey(signal, entity.Type)]; ok {
+		return model.Probability(score)
+	}
+	if model, ok := c.Models[string(signal)]; ok {
+		return model.Probability(score)
+	}
+	return min(max(score, 0), 1)
+}
+
+// Calibrate returns a copy of the result whose entities are annotated with their
+// calibrated probability and signal
+func (c *Calibrator) Calibrate(result *pii.PiiExtractionResult) *pii.PiiExtractionResult {
+	calibrated := *result
+	calibrated.Entities = make([]pii.PiiEntity, len(result.Entities))
+	for i, entity := range result.Entities {
+		signal, _ := c.score(entity)
+		// Copy the annotations so that the source result is left untouched
+		entity.Annotations = maps.Clone(entity.Annotations)
+		entity.Annotate(AnnotationProbability, c.Probability(entity))
+		entity.Annotate(AnnotationSignal, string(signal))
+		calibrated.Entities[i] = entity
+	}
+	return &calibrated
+}
+
+// PostProcessor returns a middleware post-processor calibrating results and dropping
+// the entities whose probability is below minProbability
+func (c *Calibrator) PostProcessor(minProbability float64) extractors.PostProcessor {
+	filter := extractors.FilterEntities(func(entity pii.PiiEntity) bool {
+		probability, _ := entity.Annotation(AnnotationProbability)
+		return probability.(float64) >= minProbability
+	})
+	return func(ctx context.Context, text string, result *pii.PiiExtractionResult) (*pii.PiiExtractionResult, error) {
+		return filter(ctx, text, c.Calibrate(result))
+	}
+}
+
+func (c *Calibrator) score(entity pii.PiiEntity) (Signal, float64) {
+	if c.Scorer != nil {
+		return c.Scorer(entity)
+	}
+	return RawScore(entity)
+}
+
+func modelKey(signal Signal, piiType pii.PiiType) string {
+	return string(signal) + "/" + piiType.String()
+}
+
+func passed(ok bool) float64 {
+	if ok {
+		return 1
+	}
+	return 0
+}
+
+// luhn reports whether the digits of value pass the Luhn check
+func luhn(value string) bool {
+	sum, digits := 0, 0
+	for i := len(value) - 1; i >= 0; i-- {
+		c := value[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		digit := int(c - '0')
+		if digits%2 == 1 {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		digits++
+	}
+	return digits > 0 && sum%10 == 0
+}
+
+// ibanChecksum reports whether an IBAN passes the ISO 7064 mod 97 check
+func ibanChecksum(value string) bool {
+	var compact []rune
+	for _, r := range strings.ToUpper(value) {
+		if !unicode.IsSpace(r) && r != '-' {
+			compact = append(compact, r)
+		}
+	}
+	if len(compact) < 5 {
+		return false
+	}
+
+	remainder := 0
+	for _, r := range slices.Concat(compact[4:], compact[:4]) {
+		switch {
+		case r >= '0' && r <= '9':
+			remainder = (remainder*10 + int(r-'0')) % 97
+		case r >= 'A' && r <= 'Z':
+			remainder = (remainder*100 + int(r-'A') + 10) % 97
+		default:
+			return false
+		}
+	}
+	return remainder == 1
+}
@@ -0,0 +1,109 @@
+package calibration
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+func validated(value string, valid bool, confidence float64) pii.PiiEntity {
+	return pii.PiiEntity{
+		Type:       pii.PiiTypeEmail,
+		Value:      pii.NewEmail(value),
+		Validation: &pii.ValidationResult{Valid: valid, Confidence: confidence},
+	}
+}
+
+func TestFitPlatt(t *testing.T) {
+	// Scores are well ordered but overconfident: a 0.9 score is right 3 times out of 4
+	var scores []float64
+	var labels []bool
+	for i := range 400 {
+		scores = append(scores, 0.9)
+		labels = append(labels, i%4 != 0)
+		scores = append(scores, 0.1)
+		labels = append(labels, i%4 == 0)
+	}
+
+	model := FitPlatt(scores, labels)
+	if p := model.Probability(0.9); math.Abs(p-0.75) > 0.01 {
+		t.Errorf("Probability(0.9) = %.3f, expected about 0.75", p)
+	}
+	if p := model.Probability(0.1); math.Abs(p-0.25) > 0.01 {
+		t.Errorf("Probability(0.1) = %.3f, expected about 0.25", p)
+	}
+}
+
+func TestRawScore(t *testing.T) {
+	tests := []struct {
+		name   string
+		entity pii.PiiEntity
+		signal Signal
+		score  float64
+	}{
+		{"valid card", pii.PiiEntity{Type: pii.PiiTypeCreditCard, Value: pii.NewCreditCard("4111 1111 1111 1111", "visa")}, SignalChecksum, 1},
+		{"invalid card", pii.PiiEntity{Type: pii.PiiTypeCreditCard, Value: pii.NewCreditCard("4111 1111 1111 1112", "visa")}, SignalChecksum, 0},
+		{"valid iban", pii.PiiEntity{Type: pii.PiiTypeIBAN, Value: pii.NewIBAN("DE89 3704 0044 0532 0130 00", pii.CountryDE)}, SignalChecksum, 1},
+		{"invalid iban", pii.PiiEntity{Type: pii.PiiTypeIBAN, Value: pii.NewIBAN("DE89 3704 0044 0532 0130 01", pii.CountryDE)}, SignalChecksum, 0},
+		{"pattern", pii.PiiEntity{Type: pii.PiiTypeEmail, Value: pii.NewEmail("a@b.io")}, SignalPattern, 1},
+		{"rejected by llm", validated("a@b.io", false, 0.8), SignalLLM, 0.2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signal, score := RawScore(tt.entity)
+			if signal != tt.signal || math.Abs(score-tt.score) > 1e-9 {
+				t.Errorf("RawScore() = %s, %v, want %s, %v", signal, score, tt.signal, tt.score)
+			}
+		})
+	}
+}
+
+func TestCalibrator(t *testing.T) {
+	var samples []Sample
+	for i := range 100 {
+		// The LLM is overconfident and pattern matches are right 9 times out of 10
+		samples = append(samples,
+			Sample{Entity: validated("a@b.io", true, 0.95), PII: i%5 != 0},
+			Sample{Entity: validated("a@b.io", false, 0.95), PII: i%10 == 0},
+			Sample{Entity: pii.PiiEntity{Type: pii.PiiTypePhone, Value: pii.NewPhoneUS("555-0100")}, PII: i%10 != 0})
+	}
+	calibrator := Fit(samples, &Config{MinSamples: 50})
+
+	if _, ok := calibrator.Models["llm/email"]; !ok {
+		t.Errorf("Expected a per-type model, got %v", calibrator.Models)
+	}
+	if p := calibrator.Probability(validated("x@y.io", true, 0.95)); math.Abs(p-0.8) > 0.02 {
+		t.Errorf("Expected a 0.95 LLM confidence to calibrate to about 0.8, got %.3f", p)
+	}
+
+	// Calibrators survive a JSON round trip
+	data, err := json.Marshal(calibrator)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var loaded Calibrator
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	result := pii.NewPiiExtractionResult([]pii.PiiEntity{
+		{Type: pii.PiiTypePhone, Value: pii.NewPhoneUS("555-0100")},
+		validated("spam@b.io", false, 0.9),
+	})
+	filtered, err := loaded.PostProcessor(0.5)(context.Background(), "", result)
+	if err != nil {
+		t.Fatalf("PostProcessor() error = %v", err)
+	}
+	if filtered.Total != 1 || filtered.Entities[0].Type != pii.PiiTypePhone {
+		t.Fatalf("Expected only the phone to pass 0.5, got %+v", filtered.Entities)
+	}
+	if p, _ := filtered.Entities[0].Annotation(AnnotationProbability); math.Abs(p.(float64)-0.9) > 0.02 {
+		t.Errorf("Expected the phone probability to be about 0.9, got %v", p)
+	}
+	if len(result.Entities[0].Annotations) != 0 {
+		t.Error("Expected the source result to be left untouched")
+	}
+}
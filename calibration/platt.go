@@ -0,0 +1,113 @@
+package calibration
+
+import "math"
+
+// Platt is a sigmoid mapping a raw score s onto the probability 1 / (1 + exp(A*s + B))
+type Platt struct {
+	A       float64 `json:"a"`
+	B       float64 `json:"b"`
+	Samples int     `json:"samples"` // Labelled samples the parameters were fitted on
+}
+
+// Probability returns the calibrated probability of a raw score
+func (p Platt) Probability(score float64) float64 {
+	z := p.A*score + p.B
+	// Written for both signs of z so that exp never overflows
+	if z >= 0 {
+		return math.Exp(-z) / (1 + math.Exp(-z))
+	}
+	return 1 / (1 + math.Exp(z))
+}
+
+// FitPlatt fits a sigmoid to raw scores and their labels, with the regularized targets
+// and Newton's method of Lin, Lin and Weng's "A note on Platt's probabilistic outputs
+// for support vector machines"
+func FitPlatt(scores []float64, labels []bool) Platt {
+	const (
+		maxIterations = 100
+		minStep       = 1e-10
+		sigma         = 1e-12 // Keeps the Hessian positive definite
+		epsilon       = 1e-5
+	)
+
+	var positives, negatives float64
+	for _, label := range labels {
+		if label {
+			positives++
+		} else {
+			negatives++
+		}
+	}
+
+	// Targets are pulled away from 0 and 1 so that small samples do not overfit
+	highTarget := (positives + 1) / (positives + 2)
+	lowTarget := 1 / (negatives + 2)
+	targets := make([]float64, len(labels))
+	for i, label := range labels {
+		targets[i] = lowTarget
+		if label {
+			targets[i] = highTarget
+		}
+	}
+
+	a, b := 0.0, math.Log((negatives+1)/(positives+1))
+	objective := func(a, b float64) float64 {
+		value := 0.0
+		for i, score := range scores {
+			z := a*score + b
+			if z >= 0 {
+				value += targets[i]*z + math.Log1p(math.Exp(-z))
+			} else {
+				value += (targets[i]-1)*z + math.Log1p(math.Exp(z))
+			}
+		}
+		return value
+	}
+	value := objective(a, b)
+
+	for range maxIterations {
+		// Gradient and Hessian of the negative log-likelihood
+		h11, h22, h21 := sigma, sigma, 0.0
+		g1, g2 := 0.0, 0.0
+		for i, score := range scores {
+			z := a*score + b
+			var p, q float64
+			if z >= 0 {
+				p = math.Exp(-z) / (1 + math.Exp(-z))
+				q = 1 / (1 + math.Exp(-z))
+			} else {
+				p = 1 / (1 + math.Exp(z))
+				q = math.Exp(z) / (1 + math.Exp(z))
+			}
+			d2 := p * q
+			h11 += score * score * d2
+			h22 += d2
+			h21 += score * d2
+			d1 := targets[i] - p
+			g1 += score * d1
+			g2 += d1
+		}
+		if math.Abs(g1) < epsilon && math.Abs(g2) < epsilon {
+			break
+		}
+
+		det := h11*h22 - h21*h21
+		dA := -(h22*g1 - h21*g2) / det
+		dB := -(-h21*g1 + h11*g2) / det
+		gd := g1*dA + g2*dB
+
+		step := 1.0
+		for step >= minStep {
+			newA, newB := a+step*dA, b+step*dB
+			if newValue := objective(newA, newB); newValue < value+0.0001*step*gd {
+				a, b, value = newA, newB, newValue
+				break
+			}
+			step /= 2
+		}
+		if step < minStep {
+			break
+		}
+	}
+	return Platt{A: a, B: b, Samples: len(scores)}
+}
@@ -0,0 +1,62 @@
+// Command pii-daemon keeps a warm extractor serving newline-delimited JSON requests on a
+// UNIX domain socket, so that scripts and short-lived tools avoid the startup cost of
+// compiling patterns on every invocation.
+//
+// It can be started by systemd socket activation, in which case the socket passed by
+// systemd is used and -socket is ignored:
+//
+//	echo '{"method":"redact","text":"Call 555-123-4567"}' | nc -U $XDG_RUNTIME_DIR/pii-extractor.sock
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/intMeric/pii-extractor/daemon"
+	"github.com/intMeric/pii-extractor/extractors"
+	"github.com/intMeric/pii-extractor/extractors/regex"
+	"github.com/intMeric/pii-extractor/pii"
+	"github.com/intMeric/pii-extractor/redact"
+)
+
+func main() {
+	socket := flag.String("socket", daemon.DefaultSocketPath(), "path of the UNIX socket")
+	countries := flag.String("countries", "", "comma-separated country codes to detect (default all)")
+	preset := flag.String("preset", redact.PresetStandard.Name, "default redaction preset")
+	flag.Parse()
+
+	log.SetPrefix("pii-daemon: ")
+
+	config := &extractors.ExtractorConfig{Method: extractors.MethodRegex}
+	if *countries != "" {
+		for _, code := range strings.Split(*countries, ",") {
+			country, ok := pii.ParseCountry(strings.TrimSpace(code))
+			if !ok {
+				log.Fatalf("unknown country %q", code)
+			}
+			config.Countries = append(config.Countries, country)
+		}
+	}
+	defaultPreset, ok := redact.Presets()[*preset]
+	if !ok {
+		log.Fatalf("unknown preset %q", *preset)
+	}
+
+	listener, err := daemon.Listen(*socket)
+	if err != nil {
+		log.Fatal(err)
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	server := &daemon.Server{Extractor: regex.NewExtractor(config), Preset: defaultPreset}
+	log.Printf("listening on %s", listener.Addr())
+	if err := server.Serve(ctx, listener); err != nil {
+		log.Fatal(err)
+	}
+}
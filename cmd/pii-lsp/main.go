@@ -0,0 +1,62 @@
+// Command pii-lsp is a Language Server Protocol server over stdio that publishes
+// diagnostics for the PII detected in open documents.
+//
+// Configure it as a generic language server in the editor, for example with a VS Code
+// extension launching "pii-lsp -min-severity medium" for plaintext and markdown files.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/intMeric/pii-extractor/extractors"
+	"github.com/intMeric/pii-extractor/extractors/regex"
+	"github.com/intMeric/pii-extractor/lsp"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+func main() {
+	countries := flag.String("countries", "", "comma-separated country codes to detect (default all)")
+	minSeverity := flag.String("min-severity", "low", "lowest severity reported: low, medium, high or critical")
+	flag.Parse()
+
+	// stdout carries the protocol, so logs go to stderr
+	log.SetOutput(os.Stderr)
+	log.SetPrefix("pii-lsp: ")
+
+	config := &extractors.ExtractorConfig{Method: extractors.MethodRegex}
+	if *countries != "" {
+		for _, code := range strings.Split(*countries, ",") {
+			country, ok := pii.ParseCountry(strings.TrimSpace(code))
+			if !ok {
+				log.Fatalf("unknown country %q", code)
+			}
+			config.Countries = append(config.Countries, country)
+		}
+	}
+
+	severity, err := parseSeverity(*minSeverity)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	server := lsp.NewServer(regex.NewExtractor(config))
+	server.MinSeverity = severity
+	if err := server.Serve(context.Background(), os.Stdin, os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// parseSeverity parses a severity name
+func parseSeverity(name string) (pii.Severity, error) {
+	for _, severity := range []pii.Severity{pii.SeverityLow, pii.SeverityMedium, pii.SeverityHigh, pii.SeverityCritical} {
+		if strings.EqualFold(name, severity.String()) {
+			return severity, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown severity %q", name)
+}
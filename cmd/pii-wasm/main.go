@@ -0,0 +1,17 @@
+//go:build js && wasm
+
+// Command pii-wasm is the WebAssembly build of the regex extractor for browsers:
+//
+//	GOOS=js GOARCH=wasm go build -o pii.wasm ./cmd/pii-wasm
+//
+// Load it with wasm/pii.js, which needs the wasm_exec.js support file of the Go
+// distribution ($(go env GOROOT)/lib/wasm/wasm_exec.js).
+package main
+
+import "github.com/intMeric/pii-extractor/wasm"
+
+func main() {
+	wasm.Register()
+	// Keep the exported functions alive
+	select {}
+}
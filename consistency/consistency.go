@@ -0,0 +1,210 @@
+// Package consistency checks that entities found together in one record agree with each
+// other, such as an IBAN, a postal code and a phone number of the same customer record
+// all pointing to the same country. Inconsistencies are not errors of the extraction: in
+// fraud and KYC workflows, a German IBAN next to a French address and a UK phone number is
+// itself a signal, so the entities involved are flagged on the result.
+package consistency
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/intMeric/pii-extractor/extractors"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// Annotations set on the entities involved in an inconsistency
+const (
+	AnnotationRules    = "consistency.rules"    // sorted names of the rules the entity breaks
+	AnnotationMessages = "consistency.messages" // description of each inconsistency, in rule order
+)
+
+// Inconsistency is a disagreement between entities of one record
+type Inconsistency struct {
+	Rule     string      `json:"rule"`
+	Message  string      `json:"message"`
+	Entities []EntityRef `json:"entities"`
+}
+
+// EntityRef identifies an entity involved in an inconsistency without its value
+type EntityRef struct {
+	ID      string      `json:"id,omitempty"`
+	Type    pii.PiiType `json:"type"`
+	Country pii.Country `json:"country,omitempty"`
+
+	key string // type and value, matching the entity when flagging results
+}
+
+// Rule checks the entities of one record
+type Rule struct {
+	Name  string
+	Check func(record []pii.PiiEntity) []Inconsistency
+}
+
+// Scope sets which entities form a record
+type Scope int
+
+const (
+	ScopeDocument  Scope = iota // the whole text is one record (default)
+	ScopeLine                   // each line is a record, as in CSV exports and logs
+	ScopeParagraph              // each block of text between blank lines is a record
+)
+
+// Checker runs consistency rules over the records of a text
+type Checker struct {
+	rules []Rule
+	scope Scope
+}
+
+// Option configures a Checker
+type Option func(*Checker)
+
+// WithRules sets the rules to run (DefaultRules by default)
+func WithRules(rules ...Rule) Option {
+	return func(c *Checker) {
+		c.rules = rules
+	}
+}
+
+// WithScope sets which entities form a record (ScopeDocument by default)
+func WithScope(scope Scope) Option {
+	return func(c *Checker) {
+		c.scope = scope
+	}
+}
+
+// NewChecker creates a checker
+func NewChecker(opts ...Option) *Checker {
+	c := &Checker{rules: DefaultRules()}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Check runs the rules over the entities of one record
+func (c *Checker) Check(record []pii.PiiEntity) []Inconsistency {
+	var inconsistencies []Inconsistency
+	for _, rule := range c.rules {
+		for _, inconsistency := range rule.Check(record) {
+			if inconsistency.Rule == "" {
+				inconsistency.Rule = rule.Name
+			}
+			inconsistencies = append(inconsistencies, inconsistency)
+		}
+	}
+	return inconsistencies
+}
+
+// Records splits the entities of a result into the records of the checker scope, following
+// their occurrences in text. An entity occurring in several records belongs to each.
+func (c *Checker) Records(text string, result *pii.PiiExtractionResult) [][]pii.PiiEntity {
+	if c.scope == ScopeDocument {
+		return [][]pii.PiiEntity{result.Entities}
+	}
+
+	separator := "\n"
+	if c.scope == ScopeParagraph {
+		separator = "\n\n"
+	}
+	var records [][]pii.PiiEntity
+	var record []pii.PiiEntity
+	seen := make(map[string]bool)
+	offset := 0
+	for _, occurrence := range result.Occurrences(text) {
+		if strings.Contains(text[offset:occurrence.Start], separator) && len(record) > 0 {
+			records = append(records, record)
+			record, seen = nil, make(map[string]bool)
+		}
+		offset = occurrence.End
+		key := entityKey(occurrence.Entity)
+		if !seen[key] {
+			seen[key] = true
+			record = append(record, occurrence.Entity)
+		}
+	}
+	if len(record) > 0 {
+		records = append(records, record)
+	}
+	return records
+}
+
+// Inconsistencies returns the inconsistencies of every record of the text
+func (c *Checker) Inconsistencies(text string, result *pii.PiiExtractionResult) []Inconsistency {
+	var inconsistencies []Inconsistency
+	for _, record := range c.Records(text, result) {
+		inconsistencies = append(inconsistencies, c.Check(record)...)
+	}
+	return inconsistencies
+}
+
+// Flag returns a copy of the result whose entities involved in an inconsistency are
+// annotated with the rules they break and the description of each inconsistency
+func (c *Checker) Flag(text string, result *pii.PiiExtractionResult) *pii.PiiExtractionResult {
+	flagged := *result
+	flagged.Entities = slices.Clone(result.Entities)
+
+	rules := make(map[string]map[string]bool)
+	messages := make(map[string][]string)
+	for _, inconsistency := range c.Inconsistencies(text, result) {
+		for _, ref := range inconsistency.Entities {
+			key := ref.key
+			if rules[key] == nil {
+				rules[key] = make(map[string]bool)
+			}
+			rules[key][inconsistency.Rule] = true
+			if !slices.Contains(messages[key], inconsistency.Message) {
+				messages[key] = append(messages[key], inconsistency.Message)
+			}
+		}
+	}
+
+	for i, entity := range flagged.Entities {
+		key := entityKey(entity)
+		if len(rules[key]) == 0 {
+			continue
+		}
+		names := slices.Collect(maps.Keys(rules[key]))
+		sort.Strings(names)
+		// Copy the annotations so that the source result is left untouched
+		entity.Annotations = maps.Clone(entity.Annotations)
+		entity.Annotate(AnnotationRules, names)
+		entity.Annotate(AnnotationMessages, messages[key])
+		flagged.Entities[i] = entity
+	}
+	return &flagged
+}
+
+// PostProcessor returns a middleware post-processor flagging the results
+func (c *Checker) PostProcessor() extractors.PostProcessor {
+	return func(_ context.Context, text string, result *pii.PiiExtractionResult) (*pii.PiiExtractionResult, error) {
+		return c.Flag(text, result), nil
+	}
+}
+
+// Flagged returns the entities of a result involved in an inconsistency
+func Flagged(result *pii.PiiExtractionResult) []pii.PiiEntity {
+	return result.GetAnnotated(AnnotationRules)
+}
+
+// Ref returns the reference of an entity, to report it in an inconsistency
+func Ref(entity pii.PiiEntity) EntityRef {
+	return EntityRef{ID: entity.ID, Type: entity.Type, Country: pii.EntityCountry(entity), key: entityKey(entity)}
+}
+
+// entityKey identifies an entity by type and value
+func entityKey(entity pii.PiiEntity) string {
+	return entity.Type.String() + ":" + entity.GetValue()
+}
+
+// describe returns a short description of an entity reference, such as "iban (DE)"
+func describe(ref EntityRef) string {
+	if ref.Country == "" {
+		return ref.Type.String()
+	}
+	return fmt.Sprintf("%s (%s)", ref.Type, ref.Country)
+}
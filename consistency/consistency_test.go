@@ -0,0 +1,78 @@
+package consistency
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+func TestCountryAgreement(t *testing.T) {
+	record := []pii.PiiEntity{
+		{Type: pii.PiiTypeIBAN, Value: pii.NewIBAN("DE89370400440532013000", pii.CountryDE)},
+		{Type: pii.PiiTypeZipCode, Value: pii.NewZipCode("10115", pii.CountryDE)},
+		{Type: pii.PiiTypePhone, Value: pii.NewPhone("+33 1 23 45 67 89", pii.CountryFR)},
+		{Type: pii.PiiTypeEmail, Value: pii.NewEmail("max@example.de")},
+	}
+
+	inconsistencies := NewChecker().Check(record)
+	if len(inconsistencies) != 1 {
+		t.Fatalf("Expected 1 inconsistency, got %+v", inconsistencies)
+	}
+	inconsistency := inconsistencies[0]
+	if inconsistency.Rule != RuleCountryAgreement || inconsistency.Message != "countries disagree: iban (DE), phone (FR)" {
+		t.Errorf("Unexpected inconsistency %+v", inconsistency)
+	}
+	if len(inconsistency.Entities) != 2 || inconsistency.Entities[1].Type != pii.PiiTypePhone {
+		t.Errorf("Expected the IBAN and the phone involved, got %+v", inconsistency.Entities)
+	}
+}
+
+func TestCountryAgreement_ArabicRegion(t *testing.T) {
+	record := []pii.PiiEntity{
+		{Type: pii.PiiTypeIBAN, Value: pii.NewIBAN("SA0380000000608010167519", "SA")},
+		{Type: pii.PiiTypePhone, Value: pii.NewPhone("+966 50 123 4567", pii.RegionArabic)},
+	}
+	if inconsistencies := NewChecker().Check(record); len(inconsistencies) != 0 {
+		t.Errorf("Expected a Saudi IBAN to agree with the Arabic region, got %+v", inconsistencies)
+	}
+}
+
+func TestSingleValue(t *testing.T) {
+	record := []pii.PiiEntity{
+		{Type: pii.PiiTypeSSN, Value: pii.NewSSN("123-45-6789")},
+		{Type: pii.PiiTypeSSN, Value: pii.NewSSN("987-65-4321")},
+	}
+	inconsistencies := NewChecker(WithRules(SingleValue(pii.PiiTypeSSN))).Check(record)
+	if len(inconsistencies) != 1 || inconsistencies[0].Message != "2 distinct ssn values in one record" {
+		t.Errorf("Unexpected inconsistencies %+v", inconsistencies)
+	}
+}
+
+func TestFlag_ScopeLine(t *testing.T) {
+	text := "row1: DE89370400440532013000, +33 1 23 45 67 89\nrow2: DE89370400440532013000, 10115"
+	result := pii.NewPiiExtractionResult([]pii.PiiEntity{
+		{Type: pii.PiiTypeIBAN, Value: pii.NewIBAN("DE89370400440532013000", pii.CountryDE)},
+		{Type: pii.PiiTypePhone, Value: pii.NewPhone("+33 1 23 45 67 89", pii.CountryFR)},
+		{Type: pii.PiiTypeZipCode, Value: pii.NewZipCode("10115", pii.CountryDE)},
+	})
+
+	checker := NewChecker(WithScope(ScopeLine))
+	if records := checker.Records(text, result); len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(records))
+	}
+
+	flagged := checker.Flag(text, result)
+	entities := Flagged(flagged)
+	if len(entities) != 2 {
+		t.Fatalf("Expected the IBAN and the phone flagged, got %+v", entities)
+	}
+	for _, entity := range entities {
+		if rules := entity.Annotations[AnnotationRules].([]string); !slices.Equal(rules, []string{RuleCountryAgreement}) {
+			t.Errorf("Unexpected rules %v on %s", rules, entity.Type)
+		}
+	}
+	if len(Flagged(result)) != 0 {
+		t.Error("Expected the source result to be left untouched")
+	}
+}
@@ -0,0 +1,145 @@
+package consistency
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// Names of the built-in rules
+const (
+	RuleCountryAgreement = "country_agreement"
+	RuleSingleValue      = "single_value"
+)
+
+// DefaultCountryTypes are the types whose country must agree in CountryAgreement when no
+// types are given. IP addresses are left out since VPNs and travel routinely place them
+// in another country than their owner.
+var DefaultCountryTypes = []pii.PiiType{
+	pii.PiiTypeIBAN,
+	pii.PiiTypeZipCode,
+	pii.PiiTypeStreetAddress,
+	pii.PiiTypePoBox,
+	pii.PiiTypePhone,
+	pii.PiiTypeNationalID,
+	pii.PiiTypeSSN,
+}
+
+// DefaultRules returns the built-in rules: country agreement of DefaultCountryTypes, and a
+// single SSN per record
+func DefaultRules() []Rule {
+	return []Rule{
+		CountryAgreement(),
+		SingleValue(pii.PiiTypeSSN),
+	}
+}
+
+// arabicCountries are the countries covered by pii.RegionArabic
+var arabicCountries = map[pii.Country]bool{
+	"AE": true, "BH": true, "DZ": true, "EG": true, "IQ": true, "JO": true, "KW": true,
+	"LB": true, "LY": true, "MA": true, "MR": true, "OM": true, "PS": true, "QA": true,
+	"SA": true, "SD": true, "SY": true, "TN": true, "YE": true,
+}
+
+// compatible reports whether two countries may name the same place: the same country, or
+// an Arabic-speaking country and the Arabic region of the shared pattern set
+func compatible(a, b pii.Country) bool {
+	switch {
+	case a == b:
+		return true
+	case a == pii.RegionArabic:
+		return arabicCountries[b]
+	case b == pii.RegionArabic:
+		return arabicCountries[a]
+	}
+	return false
+}
+
+// CountryAgreement returns a rule checking that the entities of the given types
+// (DefaultCountryTypes when none) carrying a country all carry compatible ones. The
+// inconsistency lists every entity outside the most common country of the record, along
+// with one entity of that country.
+func CountryAgreement(types ...pii.PiiType) Rule {
+	if len(types) == 0 {
+		types = DefaultCountryTypes
+	}
+	return Rule{
+		Name: RuleCountryAgreement,
+		Check: func(record []pii.PiiEntity) []Inconsistency {
+			var refs []EntityRef
+			counts := make(map[pii.Country]int)
+			for _, entity := range record {
+				if !slices.Contains(types, entity.Type) {
+					continue
+				}
+				ref := Ref(entity)
+				if ref.Country == "" {
+					continue
+				}
+				refs = append(refs, ref)
+				counts[ref.Country]++
+			}
+
+			// The majority country is the reference; ties go to the first seen
+			var majority pii.Country
+			for _, ref := range refs {
+				if counts[ref.Country] > counts[majority] {
+					majority = ref.Country
+				}
+			}
+
+			var involved []EntityRef
+			var anchor *EntityRef
+			for i, ref := range refs {
+				switch {
+				case !compatible(ref.Country, majority):
+					involved = append(involved, ref)
+				case anchor == nil:
+					anchor = &refs[i]
+				}
+			}
+			if len(involved) == 0 {
+				return nil
+			}
+
+			parts := []string{describe(*anchor)}
+			for _, ref := range involved {
+				parts = append(parts, describe(ref))
+			}
+			return []Inconsistency{{
+				Rule:     RuleCountryAgreement,
+				Message:  "countries disagree: " + strings.Join(parts, ", "),
+				Entities: append([]EntityRef{*anchor}, involved...),
+			}}
+		},
+	}
+}
+
+// SingleValue returns a rule checking that a record holds at most one distinct value of a
+// type, such as one SSN or one date of birth per person
+func SingleValue(piiType pii.PiiType) Rule {
+	return Rule{
+		Name: RuleSingleValue,
+		Check: func(record []pii.PiiEntity) []Inconsistency {
+			var refs []EntityRef
+			seen := make(map[string]bool)
+			for _, entity := range record {
+				if entity.Type != piiType || seen[entity.GetValue()] {
+					continue
+				}
+				seen[entity.GetValue()] = true
+				refs = append(refs, Ref(entity))
+			}
+			if len(refs) < 2 {
+				return nil
+			}
+			return []Inconsistency{{
+				Rule:     RuleSingleValue,
+				Message:  fmt.Sprintf("%d distinct %s values in one record", len(refs), piiType),
+				Entities: refs,
+			}}
+		},
+	}
+}
@@ -0,0 +1,140 @@
+package daemon
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// Deadlines of the connection
+var (
+	aLongTimeAgo = time.Unix(1, 0) // interrupts pending I/O at once
+	noDeadline   time.Time
+)
+
+// Client sends requests to a daemon over one connection. Requests are sent one at a
+// time, so a client is safe for concurrent use but does not pipeline. An I/O error,
+// including one caused by a done context, closes the connection, since the responses
+// would no longer match the requests.
+type Client struct {
+	mu     sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+	nextID int
+}
+
+// Dial connects to the daemon listening on the UNIX socket at path
+func Dial(ctx context.Context, path string) (*Client, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "unix", path)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(conn), nil
+}
+
+// NewClient creates a client over an open connection to a daemon
+func NewClient(conn net.Conn) *Client {
+	return &Client{conn: conn, reader: bufio.NewReader(conn)}
+}
+
+// Extract returns the PII of text
+func (c *Client) Extract(ctx context.Context, text string) (*pii.PiiExtractionResult, error) {
+	response, err := c.Do(ctx, Request{Method: MethodExtract, Text: text})
+	if err != nil {
+		return nil, err
+	}
+	return response.Result, nil
+}
+
+// Redact returns text redacted with the named preset (the daemon default when empty) and
+// the PII found
+func (c *Client) Redact(ctx context.Context, text, preset string) (string, *pii.PiiExtractionResult, error) {
+	response, err := c.Do(ctx, Request{Method: MethodRedact, Text: text, Preset: preset})
+	if err != nil {
+		return "", nil, err
+	}
+	if response.Redacted == nil {
+		return "", nil, errors.New("daemon returned no redacted text")
+	}
+	return *response.Redacted, response.Result, nil
+}
+
+// Ping checks the daemon answers
+func (c *Client) Ping(ctx context.Context) error {
+	_, err := c.Do(ctx, Request{Method: MethodPing})
+	return err
+}
+
+// Do sends a request and waits for its response. The request ID is set by the client.
+// An error reported by the daemon is returned as an error.
+func (c *Client) Do(ctx context.Context, request Request) (*Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.nextID++
+	request.ID = json.RawMessage(strconv.Itoa(c.nextID))
+	line, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	// Interrupt the blocking read and write when ctx is done
+	stop := context.AfterFunc(ctx, func() { c.conn.SetDeadline(aLongTimeAgo) })
+	defer stop()
+	if deadline, ok := ctx.Deadline(); ok {
+		c.conn.SetDeadline(deadline)
+		defer c.conn.SetDeadline(noDeadline)
+	}
+
+	if _, err := c.conn.Write(append(line, '\n')); err != nil {
+		c.conn.Close()
+		return nil, contextError(ctx, err)
+	}
+	data, err := c.reader.ReadBytes('\n')
+	if err != nil {
+		c.conn.Close()
+		return nil, contextError(ctx, err)
+	}
+
+	var response Response
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, err
+	}
+	if response.Error != "" {
+		return &response, &Error{Message: response.Error}
+	}
+	if string(response.ID) != string(request.ID) {
+		return nil, errors.New("daemon response out of order")
+	}
+	return &response, nil
+}
+
+// Close closes the connection
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Error is an error reported by the daemon
+type Error struct {
+	Message string
+}
+
+func (e *Error) Error() string {
+	return "daemon: " + e.Message
+}
+
+// contextError prefers the context error over the I/O error it caused
+func contextError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
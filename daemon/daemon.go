@@ -0,0 +1,176 @@
+// Package daemon serves extraction over a UNIX domain socket, so that short-lived CLI tools
+// and scripts on the same host reuse a warm process, with compiled patterns and open LLM
+// connections, instead of paying the startup cost on every invocation.
+//
+// The protocol is newline-delimited JSON: each line a client writes is a Request, and the
+// daemon answers every request with one Response line, in order. A connection can send any
+// number of requests.
+package daemon
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/intMeric/pii-extractor/extractors"
+	"github.com/intMeric/pii-extractor/pii"
+	"github.com/intMeric/pii-extractor/redact"
+)
+
+// Request methods
+const (
+	MethodExtract = "extract" // extract the PII of Text
+	MethodRedact  = "redact"  // extract and redact Text with the Preset
+	MethodPing    = "ping"    // check the daemon is alive
+)
+
+// DefaultMaxRequestSize is the default limit on the size of one request line
+const DefaultMaxRequestSize = 16 << 20
+
+// Request is one line sent by a client
+type Request struct {
+	ID     json.RawMessage `json:"id,omitempty"` // echoed in the response
+	Method string          `json:"method"`
+	Text   string          `json:"text,omitempty"`
+	Preset string          `json:"preset,omitempty"` // redaction preset name, the server default when empty
+}
+
+// Response is the line answering a request
+type Response struct {
+	ID       json.RawMessage          `json:"id,omitempty"`
+	Result   *pii.PiiExtractionResult `json:"result,omitempty"`
+	Redacted *string                  `json:"redacted,omitempty"`
+	Error    string                   `json:"error,omitempty"`
+}
+
+// Server answers the requests of the clients connected to a listener
+type Server struct {
+	Extractor extractors.PiiExtractor
+
+	// Preset is the redaction preset used when a request names none
+	// (redact.PresetStandard when zero)
+	Preset redact.Preset
+
+	// MaxRequestSize is the limit on the size of one request line
+	// (DefaultMaxRequestSize when zero)
+	MaxRequestSize int
+}
+
+// Serve accepts connections on the listener and serves each in its own goroutine until
+// ctx is done, then closes the listener and the open connections. It returns nil when
+// stopped by ctx.
+func (s *Server) Serve(ctx context.Context, listener net.Listener) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			stop := context.AfterFunc(ctx, func() { conn.Close() })
+			defer stop()
+			defer conn.Close()
+			s.ServeConn(ctx, conn)
+		}()
+	}
+}
+
+// ServeConn reads requests from conn and writes their responses until the client closes
+// it or ctx is done. Malformed lines are answered with an error and do not end the
+// connection; a line above MaxRequestSize does.
+func (s *Server) ServeConn(ctx context.Context, conn io.ReadWriter) error {
+	limit := s.MaxRequestSize
+	if limit <= 0 {
+		limit = DefaultMaxRequestSize
+	}
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 0, 64<<10), limit)
+	writer := bufio.NewWriter(conn)
+	encoder := json.NewEncoder(writer)
+
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if err := encoder.Encode(s.handle(ctx, line)); err != nil {
+			return err
+		}
+		if err := writer.Flush(); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			encoder.Encode(Response{Error: fmt.Sprintf("request above %d bytes", limit)})
+			writer.Flush()
+		}
+		return err
+	}
+	return nil
+}
+
+// handle answers one request line
+func (s *Server) handle(ctx context.Context, line []byte) Response {
+	var request Request
+	if err := json.Unmarshal(line, &request); err != nil {
+		return Response{Error: "invalid request: " + err.Error()}
+	}
+	response := Response{ID: request.ID}
+
+	switch request.Method {
+	case MethodPing:
+		return response
+	case MethodExtract, MethodRedact:
+	default:
+		response.Error = fmt.Sprintf("unknown method %q", request.Method)
+		return response
+	}
+
+	preset := s.Preset
+	if request.Preset != "" {
+		named, ok := redact.Presets()[request.Preset]
+		if !ok {
+			response.Error = fmt.Sprintf("unknown preset %q", request.Preset)
+			return response
+		}
+		preset = named
+	} else if preset.Name == "" {
+		preset = redact.PresetStandard
+	}
+
+	result, err := extractors.ExtractWithContext(ctx, s.Extractor, request.Text)
+	if err != nil {
+		response.Error = err.Error()
+		return response
+	}
+	response.Result = result
+	if request.Method == MethodRedact {
+		redacted := redact.New(preset).Redact(request.Text, result)
+		response.Redacted = &redacted
+	}
+	return response
+}
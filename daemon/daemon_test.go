@@ -0,0 +1,187 @@
+package daemon
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/intMeric/pii-extractor/extractors/regex"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// startDaemon serves a regex extractor on a socket in a temporary directory
+func startDaemon(t *testing.T) string {
+	t.Helper()
+	// Socket paths are limited to about 100 bytes, shorter than some test directories
+	dir, err := os.MkdirTemp("", "piid")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	path := filepath.Join(dir, "d.sock")
+
+	listener, err := Listen(path)
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- (&Server{Extractor: regex.NewDefaultExtractor()}).Serve(ctx, listener)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("Serve() error = %v", err)
+		}
+	})
+	return path
+}
+
+func TestClient(t *testing.T) {
+	path := startDaemon(t)
+	ctx := context.Background()
+
+	client, err := Dial(ctx, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	if err := client.Ping(ctx); err != nil {
+		t.Fatalf("Ping() error = %v", err)
+	}
+
+	result, err := client.Extract(ctx, "Mail john.doe@acme.com")
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if result.Total != 1 || result.Entities[0].Type != pii.PiiTypeEmail || result.Entities[0].GetValue() != "john.doe@acme.com" {
+		t.Errorf("Unexpected result %+v", result)
+	}
+
+	redacted, _, err := client.Redact(ctx, "SSN 123-45-6789", "strict")
+	if err != nil {
+		t.Fatalf("Redact() error = %v", err)
+	}
+	if strings.Contains(redacted, "123-45") {
+		t.Errorf("Expected the SSN redacted, got %q", redacted)
+	}
+
+	var daemonErr *Error
+	if _, _, err := client.Redact(ctx, "text", "unknown"); !errors.As(err, &daemonErr) {
+		t.Errorf("Expected a daemon error for an unknown preset, got %v", err)
+	}
+	// The connection keeps serving after an error
+	if err := client.Ping(ctx); err != nil {
+		t.Errorf("Ping() after an error = %v", err)
+	}
+}
+
+func TestServer_RawProtocol(t *testing.T) {
+	path := startDaemon(t)
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	conn.Write([]byte("not json\n\n{\"id\":\"a\",\"method\":\"shout\"}\n{\"id\":7,\"method\":\"redact\",\"text\":\"Call 555-123-4567\"}\n"))
+	reader := bufio.NewReader(conn)
+	var responses []Response
+	for range 3 {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+		var response Response
+		if err := json.Unmarshal(line, &response); err != nil {
+			t.Fatalf("Invalid response line %q: %v", line, err)
+		}
+		responses = append(responses, response)
+	}
+
+	if !strings.HasPrefix(responses[0].Error, "invalid request") {
+		t.Errorf("Expected an invalid request error, got %+v", responses[0])
+	}
+	if string(responses[1].ID) != `"a"` || !strings.Contains(responses[1].Error, "unknown method") {
+		t.Errorf("Expected an unknown method error for id a, got %+v", responses[1])
+	}
+	if string(responses[2].ID) != "7" || responses[2].Redacted == nil || strings.Contains(*responses[2].Redacted, "4567") && strings.Contains(*responses[2].Redacted, "123") {
+		t.Errorf("Expected the phone redacted for id 7, got %+v", responses[2])
+	}
+}
+
+func TestListen_Existing(t *testing.T) {
+	path := startDaemon(t)
+	if _, err := Listen(path); err == nil || !strings.Contains(err.Error(), "already listening") {
+		t.Errorf("Expected a running daemon to be detected, got %v", err)
+	}
+
+	file := filepath.Join(t.TempDir(), "regular")
+	os.WriteFile(file, nil, 0o600)
+	if _, err := Listen(file); err == nil {
+		t.Error("Expected an error on a regular file")
+	}
+}
+
+func TestListen_Private(t *testing.T) {
+	dir, err := os.MkdirTemp("", "piid")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "run", "d.sock")
+
+	listener, err := Listen(path)
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	defer listener.Close()
+
+	if info, err := os.Stat(filepath.Dir(path)); err != nil || info.Mode().Perm() != 0o700 {
+		t.Errorf("Expected the missing directory to be created owner-only, got %v (%v)", info.Mode(), err)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("Expected an owner-only socket, got %v (%v)", info.Mode(), err)
+	}
+	if _, err := Listen(""); err == nil {
+		t.Error("Expected an error without a socket path")
+	}
+}
+
+func TestDefaultSocketPath(t *testing.T) {
+	t.Setenv("XDG_RUNTIME_DIR", "/run/user/1000")
+	if got := DefaultSocketPath(); got != "/run/user/1000/pii-extractor.sock" {
+		t.Errorf("Expected the socket in XDG_RUNTIME_DIR, got %s", got)
+	}
+
+	t.Setenv("XDG_RUNTIME_DIR", "")
+	t.Setenv("HOME", t.TempDir())
+	cache, _ := os.UserCacheDir()
+	if got := DefaultSocketPath(); got != filepath.Join(cache, "pii-extractor", "pii-extractor.sock") {
+		t.Errorf("Expected the socket in the user cache directory, got %s", got)
+	}
+}
+
+func TestClient_ContextCanceled(t *testing.T) {
+	server, conn := net.Pipe()
+	defer server.Close()
+	client := NewClient(conn)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		// Read the request but never answer
+		bufio.NewReader(server).ReadBytes('\n')
+		cancel()
+	}()
+	if err := client.Ping(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// listenFDsStart is the first file descriptor passed by systemd socket activation
+const listenFDsStart = 3
+
+// Listen returns the listener of the daemon. When the process was started by systemd
+// socket activation (LISTEN_PID and LISTEN_FDS set for this process), it uses the first
+// passed socket and ignores path. Otherwise it listens on the UNIX socket at path,
+// replacing a stale socket file, and restricts the socket to the current user from its
+// creation on. A missing directory of path is created accessible to the current user only.
+func Listen(path string) (net.Listener, error) {
+	if listener, err := activated(); listener != nil || err != nil {
+		return listener, err
+	}
+	if path == "" {
+		return nil, errors.New("no socket path: set XDG_RUNTIME_DIR or HOME, or pass a path")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+
+	if info, err := os.Lstat(path); err == nil {
+		if info.Mode()&fs.ModeSocket == 0 {
+			return nil, fmt.Errorf("%s exists and is not a socket", path)
+		}
+		// A socket answering connections belongs to a running daemon
+		if conn, err := net.Dial("unix", path); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("a daemon is already listening on %s", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+	}
+
+	listener, err := listenPrivate(path)
+	if err != nil {
+		return nil, err
+	}
+	// Already the mode of the socket on Unix systems, where listenPrivate sets the umask
+	if err := os.Chmod(path, 0o600); err != nil {
+		listener.Close()
+		return nil, err
+	}
+	return listener, nil
+}
+
+// activated returns the socket passed by systemd socket activation, or nil when the
+// process was not socket-activated
+func activated() (net.Listener, error) {
+	pid, err := strconv.Atoi(os.Getenv("LISTEN_PID"))
+	if err != nil || pid != os.Getpid() {
+		return nil, nil
+	}
+	fds, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || fds < 1 {
+		return nil, errors.New("socket activation without LISTEN_FDS")
+	}
+	// Children must not inherit the activation
+	os.Unsetenv("LISTEN_PID")
+	os.Unsetenv("LISTEN_FDS")
+	os.Unsetenv("LISTEN_FDNAMES")
+
+	file := os.NewFile(listenFDsStart, "LISTEN_FD_3")
+	defer file.Close()
+	return net.FileListener(file)
+}
+
+// DefaultSocketPath returns the socket path shared by the daemon and its clients:
+// pii-extractor.sock in $XDG_RUNTIME_DIR, or in a pii-extractor directory of the user
+// cache directory when unset. It never falls back to a directory other users can write
+// to, where they could create the socket first and receive the texts of clients, and
+// returns an empty string when neither XDG_RUNTIME_DIR nor HOME is set.
+func DefaultSocketPath() string {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return filepath.Join(dir, "pii-extractor.sock")
+	}
+	cache, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(cache, "pii-extractor", "pii-extractor.sock")
+}
@@ -0,0 +1,11 @@
+//go:build !unix
+
+package daemon
+
+import "net"
+
+// listenPrivate listens on the UNIX socket at path; outside Unix systems there is no
+// umask, and Listen restricts the socket once created
+func listenPrivate(path string) (net.Listener, error) {
+	return net.Listen("unix", path)
+}
@@ -0,0 +1,17 @@
+//go:build unix
+
+package daemon
+
+import (
+	"net"
+	"syscall"
+)
+
+// listenPrivate listens on the UNIX socket at path, created with owner-only permissions
+// so that no other user can connect before Listen restricts it. The umask is process-wide,
+// so files created meanwhile by other goroutines are owner-only as well.
+func listenPrivate(path string) (net.Listener, error) {
+	umask := syscall.Umask(0o177)
+	defer syscall.Umask(umask)
+	return net.Listen("unix", path)
+}
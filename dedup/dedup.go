@@ -0,0 +1,92 @@
+// Package dedup reports each distinct entity once across the documents scanned by one or
+// many processes, so that alerts, review queues and LLM validation are not repeated for
+// values already seen elsewhere. The state lives in a Store, in memory for a single
+// process or in a shared backend such as the redis package for a fleet of scanners.
+package dedup
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// Store records the keys already seen
+type Store interface {
+	// Add records key and reports whether it was not recorded yet. A zero ttl never
+	// forgets the key.
+	Add(ctx context.Context, key string, ttl time.Duration) (bool, error)
+}
+
+// ErrNoSalt is returned by Filter when no salt is given
+var ErrNoSalt = errors.New("dedup: no entity ID salt given")
+
+// Key returns the key of an entity: its entity ID computed with salt (see pii.EntityID).
+// Keys only protect values with a secret salt: without one, the keys of SSNs or phone
+// numbers are recovered by hashing every possible value.
+func Key(entity pii.PiiEntity, salt string) string {
+	return pii.EntityID(entity.Type, entity.GetValue(), salt)
+}
+
+// Filter returns a result holding only the entities of result not seen before, recording
+// their keys computed with salt in the store for ttl. Every instance sharing the store
+// must use the same secret salt. It refuses to record keys without a salt (ErrNoSalt),
+// and on a store error, it returns the entities filtered so far with the error.
+func Filter(ctx context.Context, store Store, result *pii.PiiExtractionResult, salt string, ttl time.Duration) (*pii.PiiExtractionResult, error) {
+	if salt == "" {
+		return nil, ErrNoSalt
+	}
+
+	var fresh []pii.PiiEntity
+	var err error
+	for _, entity := range result.Entities {
+		var added bool
+		if added, err = store.Add(ctx, Key(entity, salt), ttl); err != nil {
+			break
+		}
+		if added {
+			fresh = append(fresh, entity)
+		}
+	}
+	filtered := pii.NewPiiExtractionResult(fresh)
+	filtered.PatternSetVersion = result.PatternSetVersion
+	return filtered, err
+}
+
+// MemoryStore is an in-process Store
+type MemoryStore struct {
+	keys map[string]time.Time // expiry, zero for never
+	now  func() time.Time
+	mu   sync.Mutex
+}
+
+// NewMemoryStore creates an empty memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{keys: make(map[string]time.Time), now: time.Now}
+}
+
+// Add implements Store
+func (s *MemoryStore) Add(_ context.Context, key string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if expires, ok := s.keys[key]; ok && (expires.IsZero() || now.Before(expires)) {
+		return false, nil
+	}
+	var expires time.Time
+	if ttl > 0 {
+		expires = now.Add(ttl)
+	}
+	s.keys[key] = expires
+	return true, nil
+}
+
+// Len returns the number of recorded keys, expired ones included until added again
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.keys)
+}
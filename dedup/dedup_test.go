@@ -0,0 +1,78 @@
+package dedup
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+func TestFilter_ReportsEachEntityOnce(t *testing.T) {
+	email := pii.PiiEntity{Type: pii.PiiTypeEmail, Value: pii.NewEmail("john@acme.com")}
+	phone := pii.PiiEntity{Type: pii.PiiTypePhone, Value: pii.NewPhone("555-123-4567", "US")}
+	store := NewMemoryStore()
+
+	first, err := Filter(context.Background(), store, pii.NewPiiExtractionResult([]pii.PiiEntity{email}), "tenant-secret", 0)
+	if err != nil || first.Total != 1 {
+		t.Fatalf("Expected the email reported, got %+v (%v)", first, err)
+	}
+	second, err := Filter(context.Background(), store, pii.NewPiiExtractionResult([]pii.PiiEntity{email, phone}), "tenant-secret", 0)
+	if err != nil {
+		t.Fatalf("Filter() error = %v", err)
+	}
+	if second.Total != 1 || second.Entities[0].Type != pii.PiiTypePhone {
+		t.Errorf("Expected only the phone reported, got %+v", second.Entities)
+	}
+}
+
+func TestMemoryStore_TTL(t *testing.T) {
+	now := time.Now()
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+
+	if added, _ := store.Add(context.Background(), "key", time.Minute); !added {
+		t.Fatal("Expected a new key to be added")
+	}
+	if added, _ := store.Add(context.Background(), "key", time.Minute); added {
+		t.Error("Expected a recorded key not to be added again")
+	}
+	now = now.Add(time.Minute)
+	if added, _ := store.Add(context.Background(), "key", time.Minute); !added {
+		t.Error("Expected an expired key to be added again")
+	}
+}
+
+type failingStore struct{}
+
+func (failingStore) Add(context.Context, string, time.Duration) (bool, error) {
+	return false, errors.New("unavailable")
+}
+
+func TestFilter_StoreError(t *testing.T) {
+	result := pii.NewPiiExtractionResult([]pii.PiiEntity{{Type: pii.PiiTypeEmail, Value: pii.NewEmail("john@acme.com")}})
+	filtered, err := Filter(context.Background(), failingStore{}, result, "tenant-secret", 0)
+	if err == nil || filtered.Total != 0 {
+		t.Errorf("Expected an error and no entities, got %+v (%v)", filtered, err)
+	}
+}
+
+func TestFilter_NoSalt(t *testing.T) {
+	result := pii.NewPiiExtractionResult([]pii.PiiEntity{{Type: pii.PiiTypeSSN, Value: pii.NewSSN("123-45-6789")}})
+	store := NewMemoryStore()
+	if _, err := Filter(context.Background(), store, result, "", 0); !errors.Is(err, ErrNoSalt) {
+		t.Errorf("Expected ErrNoSalt, got %v", err)
+	}
+	if len(store.keys) != 0 {
+		t.Errorf("Expected no key recorded without a salt, got %v", store.keys)
+	}
+}
+
+func TestKey_Salted(t *testing.T) {
+	result := pii.NewPiiExtractionResult([]pii.PiiEntity{{Type: pii.PiiTypeSSN, Value: pii.NewSSN("123-45-6789")}})
+	entity := result.Entities[0]
+	if Key(entity, "tenant-a") == entity.ID || Key(entity, "tenant-a") == Key(entity, "tenant-b") {
+		t.Error("Expected keys computed with the given salt, not the unsalted entity ID")
+	}
+}
@@ -0,0 +1,22 @@
+// Package piiextractor extracts personally identifiable information from text with
+// regular expressions, LLMs or both, and re-exports the most used types and constructors
+// of its sub-packages.
+//
+// # API stability
+//
+// Every package of the module belongs to a stability tier, reported by PackageStability:
+//
+//   - Stable: this package, pii, extractors, extractors/regex, extractors/regex/patterns,
+//     extractors/llm, extractors/hybrid and redact. Exported identifiers are not removed
+//     or changed incompatibly within a major version. TestStableAPI enforces this against
+//     the recorded API in api/stable.txt.
+//   - Beta: the other packages outside experimental/. Incompatible changes may happen in
+//     minor releases, after at least one minor release where the old API is kept and
+//     marked Deprecated.
+//   - Experimental: packages under experimental/. They may change or disappear in any
+//     release.
+//
+// Identifiers that move between packages, such as the re-exports of this package, are
+// kept as type aliases or wrappers with a "Deprecated:" notice naming their replacement
+// until the next major version.
+package piiextractor
@@ -0,0 +1,390 @@
+// Package evidence packages the outcome of a batch scan into an evidence bundle for
+// compliance audits such as ISO 27701 or SOC 2.
+//
+// A bundle is a zip archive holding the extractor configuration, a summary of the
+// findings, the findings themselves scrubbed of their PII and a manifest listing the
+// SHA-256 hash of every file. The manifest can be signed, so auditors can check the
+// bundle was neither altered nor produced by someone else.
+package evidence
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/intMeric/pii-extractor/extractors"
+	"github.com/intMeric/pii-extractor/pii"
+	"github.com/intMeric/pii-extractor/scanner"
+)
+
+// Names of the files of a bundle
+const (
+	FileConfig    = "config.json"
+	FileSummary   = "summary.json"
+	FileFindings  = "findings.json"
+	FileManifest  = "manifest.json"
+	FileSignature = "manifest.sig"
+)
+
+// FormatVersion is the version of the bundle layout recorded in manifests
+const FormatVersion = 1
+
+// RedactedOption replaces the values of secret extractor options, such as API keys, in the
+// bundled configuration
+const RedactedOption = "[REDACTED]"
+
+var (
+	// ErrUnsigned is returned by Verify when a public key is given for an unsigned bundle
+	ErrUnsigned = errors.New("evidence: bundle is not signed")
+	// ErrBadSignature is returned by Verify when the manifest signature does not match
+	ErrBadSignature = errors.New("evidence: invalid manifest signature")
+)
+
+// Summary is the results summary of a bundle
+type Summary struct {
+	Root              string              `json:"root"`
+	FilesScanned      int                 `json:"files_scanned"`
+	FilesWithPII      int                 `json:"files_with_pii"`
+	FilesSkipped      int                 `json:"files_skipped"`
+	BytesScanned      int64               `json:"bytes_scanned"`
+	Duration          time.Duration       `json:"duration"`
+	Total             int                 `json:"total"`       // Distinct values found
+	Stats             map[pii.PiiType]int `json:"stats"`       // Distinct values by type
+	BySeverity        map[string]int      `json:"by_severity"` // Distinct values by severity
+	PatternSetVersion string              `json:"pattern_set_version,omitempty"`
+}
+
+// ManifestFile is the audit hash of a file of the bundle
+type ManifestFile struct {
+	Name   string `json:"name"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Manifest lists the files of a bundle with their hashes
+type Manifest struct {
+	Version           int            `json:"version"`
+	CreatedAt         time.Time      `json:"created_at"`
+	PatternSetVersion string         `json:"pattern_set_version,omitempty"`
+	ScrubLevel        pii.ScrubLevel `json:"scrub_level"`
+	Files             []ManifestFile `json:"files"`
+
+	// KeyID is the SHA-256 of the PKIX public key that signed the manifest, empty when
+	// the bundle is unsigned
+	KeyID string `json:"key_id,omitempty"`
+}
+
+// Option configures an export
+type Option func(*exporter)
+
+// WithSigner signs the manifest with signer, such as an ed25519.PrivateKey or a KMS-backed
+// key. Ed25519 keys sign the manifest itself, ECDSA and RSA keys its SHA-256 hash.
+func WithSigner(signer crypto.Signer) Option {
+	return func(e *exporter) {
+		e.signer = signer
+	}
+}
+
+// WithScrubLevel sets how much of the findings is removed before they are bundled
+// (default pii.ScrubValues). pii.ScrubContexts keeps the raw values in the bundle.
+func WithScrubLevel(level pii.ScrubLevel) Option {
+	return func(e *exporter) {
+		e.scrubLevel = level
+	}
+}
+
+// WithCreatedAt sets the creation time recorded in the manifest (default time.Now)
+func WithCreatedAt(createdAt time.Time) Option {
+	return func(e *exporter) {
+		e.createdAt = createdAt
+	}
+}
+
+// exporter holds the options of an export
+type exporter struct {
+	signer     crypto.Signer
+	scrubLevel pii.ScrubLevel
+	createdAt  time.Time
+}
+
+// Export writes the evidence bundle of a scan report to w and returns its manifest.
+// config is the configuration of the extractor that produced the report; nil records an
+// empty configuration. Findings are scrubbed with its EntityIDSalt, which is required
+// from pii.ScrubValues on (pii.ErrNoSalt) and never bundled.
+func Export(w io.Writer, report *scanner.Report, config *extractors.ExtractorConfig, options ...Option) (*Manifest, error) {
+	e := &exporter{scrubLevel: pii.ScrubValues}
+	for _, option := range options {
+		option(e)
+	}
+	if e.createdAt.IsZero() {
+		e.createdAt = time.Now()
+	}
+	if config == nil {
+		config = &extractors.ExtractorConfig{}
+	}
+
+	findings, err := scrubReport(report, e.scrubLevel, config.EntityIDSalt)
+	if err != nil {
+		return nil, fmt.Errorf("evidence: %w", err)
+	}
+	summary := Summarize(report)
+	files := []struct {
+		name  string
+		value any
+	}{
+		{FileConfig, bundledConfig(config)},
+		{FileSummary, summary},
+		{FileFindings, findings},
+	}
+
+	manifest := &Manifest{
+		Version:           FormatVersion,
+		CreatedAt:         e.createdAt.UTC(),
+		PatternSetVersion: summary.PatternSetVersion,
+		ScrubLevel:        e.scrubLevel,
+	}
+	archive := zip.NewWriter(w)
+	for _, file := range files {
+		data, err := json.MarshalIndent(file.value, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("evidence: failed to encode %s: %w", file.name, err)
+		}
+		if err := writeFile(archive, file.name, data, manifest.CreatedAt); err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256(data)
+		manifest.Files = append(manifest.Files, ManifestFile{Name: file.name, Size: int64(len(data)), SHA256: hex.EncodeToString(sum[:])})
+	}
+
+	if e.signer != nil {
+		keyID, err := KeyID(e.signer.Public())
+		if err != nil {
+			return nil, err
+		}
+		manifest.KeyID = keyID
+	}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("evidence: failed to encode the manifest: %w", err)
+	}
+	if err := writeFile(archive, FileManifest, data, manifest.CreatedAt); err != nil {
+		return nil, err
+	}
+	if e.signer != nil {
+		signature, err := sign(e.signer, data)
+		if err != nil {
+			return nil, fmt.Errorf("evidence: failed to sign the manifest: %w", err)
+		}
+		if err := writeFile(archive, FileSignature, signature, manifest.CreatedAt); err != nil {
+			return nil, err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// bundledConfig returns a copy of an extractor configuration with the values of its secret
+// options, and of secret keys of nested options, replaced by RedactedOption
+func bundledConfig(config *extractors.ExtractorConfig) *extractors.ExtractorConfig {
+	bundled := *config
+	bundled.Options = redactOptions(config.Options)
+	return &bundled
+}
+
+// redactOptions returns a copy of options with the values of secret keys redacted
+func redactOptions(options map[string]any) map[string]any {
+	if options == nil {
+		return nil
+	}
+	redacted := make(map[string]any, len(options))
+	for key, value := range options {
+		if isSecretOption(key) {
+			redacted[key] = RedactedOption
+			continue
+		}
+		if nested, ok := value.(map[string]any); ok {
+			value = redactOptions(nested)
+		}
+		redacted[key] = value
+	}
+	return redacted
+}
+
+// isSecretOption reports whether an option name denotes a credential, such as api_key,
+// password, client_secret or access_token
+func isSecretOption(key string) bool {
+	key = strings.ToLower(key)
+	for _, word := range []string{"password", "passwd", "secret", "credential"} {
+		if strings.Contains(key, word) {
+			return true
+		}
+	}
+	for _, suffix := range []string{"key", "token", "auth", "authorization"} {
+		if strings.HasSuffix(key, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Verify checks the hashes of a bundle against its manifest and returns the manifest.
+// When publicKey is not nil, the manifest signature is checked with it too.
+func Verify(r io.ReaderAt, size int64, publicKey crypto.PublicKey) (*Manifest, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("evidence: invalid bundle: %w", err)
+	}
+	contents := make(map[string][]byte)
+	for _, file := range archive.File {
+		rc, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		contents[file.Name] = data
+	}
+
+	data, ok := contents[FileManifest]
+	if !ok {
+		return nil, errors.New("evidence: bundle has no manifest")
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("evidence: invalid manifest: %w", err)
+	}
+	for _, file := range manifest.Files {
+		content, ok := contents[file.Name]
+		if !ok {
+			return nil, fmt.Errorf("evidence: %s is missing", file.Name)
+		}
+		sum := sha256.Sum256(content)
+		if hex.EncodeToString(sum[:]) != file.SHA256 {
+			return nil, fmt.Errorf("evidence: %s does not match its hash", file.Name)
+		}
+	}
+
+	if publicKey != nil {
+		signature, ok := contents[FileSignature]
+		if !ok {
+			return nil, ErrUnsigned
+		}
+		if err := verify(publicKey, data, signature); err != nil {
+			return nil, err
+		}
+	}
+	return &manifest, nil
+}
+
+// Summarize returns the results summary of a scan report
+func Summarize(report *scanner.Report) Summary {
+	summary := Summary{
+		Root:         report.Root,
+		FilesScanned: len(report.Files),
+		FilesWithPII: len(report.FilesWithPII()),
+		FilesSkipped: len(report.Skipped),
+		BytesScanned: report.BytesScanned,
+		Duration:     report.Duration,
+		Stats:        make(map[pii.PiiType]int),
+		BySeverity:   make(map[string]int),
+	}
+	if report.Result != nil {
+		summary.Total = report.Result.Total
+		summary.PatternSetVersion = report.Result.PatternSetVersion
+		for _, entity := range report.Result.Entities {
+			summary.Stats[entity.Type]++
+			summary.BySeverity[entity.GetSeverity().String()]++
+		}
+	}
+	return summary
+}
+
+// KeyID returns the identifier of a public key recorded in manifests: the hex SHA-256 of
+// its PKIX encoding
+func KeyID(publicKey crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return "", fmt.Errorf("evidence: unsupported public key: %w", err)
+	}
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// scrubReport returns a copy of the report with its results scrubbed at level with salt
+func scrubReport(report *scanner.Report, level pii.ScrubLevel, salt string) (*scanner.Report, error) {
+	scrubbed := *report
+	scrubbed.Files = make([]scanner.FileReport, len(report.Files))
+	for i, file := range report.Files {
+		if file.Result != nil {
+			var err error
+			if file.Result, err = file.Result.Scrub(level, salt); err != nil {
+				return nil, err
+			}
+		}
+		scrubbed.Files[i] = file
+	}
+	if report.Result != nil {
+		var err error
+		if scrubbed.Result, err = report.Result.Scrub(level, salt); err != nil {
+			return nil, err
+		}
+	}
+	return &scrubbed, nil
+}
+
+// writeFile adds a file to the archive
+func writeFile(archive *zip.Writer, name string, data []byte, modified time.Time) error {
+	w, err := archive.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modified})
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, bytes.NewReader(data))
+	return err
+}
+
+// sign signs the manifest with signer
+func sign(signer crypto.Signer, manifest []byte) ([]byte, error) {
+	if _, ok := signer.Public().(ed25519.PublicKey); ok {
+		return signer.Sign(rand.Reader, manifest, crypto.Hash(0))
+	}
+	digest := sha256.Sum256(manifest)
+	return signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+}
+
+// verify checks the signature of the manifest
+func verify(publicKey crypto.PublicKey, manifest, signature []byte) error {
+	digest := sha256.Sum256(manifest)
+	valid := false
+	switch key := publicKey.(type) {
+	case ed25519.PublicKey:
+		valid = ed25519.Verify(key, manifest, signature)
+	case *ecdsa.PublicKey:
+		valid = ecdsa.VerifyASN1(key, digest[:], signature)
+	case *rsa.PublicKey:
+		valid = rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature) == nil
+	default:
+		return fmt.Errorf("evidence: unsupported public key type %T", publicKey)
+	}
+	if !valid {
+		return ErrBadSignature
+	}
+	return nil
+}
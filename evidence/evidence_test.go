@@ -0,0 +1,173 @@
+package evidence
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/ed25519"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/intMeric/pii-extractor/extractors"
+	"github.com/intMeric/pii-extractor/pii"
+	"github.com/intMeric/pii-extractor/scanner"
+)
+
+func scanFixture(t *testing.T) *scanner.Report {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"notes.txt": "Contact john.doe@acme.com or 123-45-6789",
+		"empty.txt": "nothing to see",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	report, err := scanner.Scan(dir, scanner.Options{})
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	return report
+}
+
+func TestExport(t *testing.T) {
+	report := scanFixture(t)
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := &extractors.ExtractorConfig{Countries: []pii.Country{pii.CountryUS}, EntityIDSalt: "tenant-salt"}
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	var buf bytes.Buffer
+	manifest, err := Export(&buf, report, config, WithSigner(privateKey), WithCreatedAt(createdAt))
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if len(manifest.Files) != 3 || manifest.KeyID == "" || !manifest.CreatedAt.Equal(createdAt) {
+		t.Errorf("Unexpected manifest %+v", manifest)
+	}
+	if manifest.PatternSetVersion == "" || manifest.ScrubLevel != pii.ScrubValues {
+		t.Errorf("Expected the pattern set version and default scrub level, got %+v", manifest)
+	}
+
+	verified, err := Verify(bytes.NewReader(buf.Bytes()), int64(buf.Len()), publicKey)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if verified.KeyID != manifest.KeyID {
+		t.Errorf("Expected the exported manifest, got %+v", verified)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range archive.File {
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		if strings.Contains(string(data), "john.doe@acme.com") || strings.Contains(string(data), "123-45-6789") {
+			t.Errorf("Expected %s to be scrubbed of PII", file.Name)
+		}
+	}
+
+	otherKey, _, _ := ed25519.GenerateKey(nil)
+	if _, err := Verify(bytes.NewReader(buf.Bytes()), int64(buf.Len()), otherKey); !errors.Is(err, ErrBadSignature) {
+		t.Errorf("Expected ErrBadSignature with another key, got %v", err)
+	}
+}
+
+func TestExport_RedactsSecretOptions(t *testing.T) {
+	config := &extractors.ExtractorConfig{EntityIDSalt: "tenant-salt", Options: map[string]any{
+		"api_key":    "sk-live-SECRET123",
+		"max_tokens": 512,
+		"llm":        map[string]any{"password": "hunter2", "model": "gpt-4o"},
+	}}
+
+	var buf bytes.Buffer
+	if _, err := Export(&buf, scanFixture(t), config); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rc, err := archive.Open(FileConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := io.ReadAll(rc)
+	rc.Close()
+	for _, secret := range []string{"sk-live-SECRET123", "hunter2"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("Expected %s to leave %q out, got %s", FileConfig, secret, data)
+		}
+	}
+	for _, kept := range []string{`"max_tokens": 512`, `"model": "gpt-4o"`, RedactedOption} {
+		if !strings.Contains(string(data), kept) {
+			t.Errorf("Expected %s to keep %s, got %s", FileConfig, kept, data)
+		}
+	}
+	if config.Options["api_key"] != "sk-live-SECRET123" {
+		t.Error("Expected the given configuration to be left untouched")
+	}
+}
+
+func TestVerify_Tampered(t *testing.T) {
+	report := scanFixture(t)
+
+	var buf bytes.Buffer
+	if _, err := Export(&buf, report, nil); !errors.Is(err, pii.ErrNoSalt) {
+		t.Fatalf("Expected ErrNoSalt without a salt, got %v", err)
+	}
+	buf.Reset()
+	if _, err := Export(&buf, report, &extractors.ExtractorConfig{EntityIDSalt: "tenant-salt"}); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if _, err := Verify(bytes.NewReader(buf.Bytes()), int64(buf.Len()), nil); err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	publicKey, _, _ := ed25519.GenerateKey(nil)
+	if _, err := Verify(bytes.NewReader(buf.Bytes()), int64(buf.Len()), publicKey); !errors.Is(err, ErrUnsigned) {
+		t.Errorf("Expected ErrUnsigned, got %v", err)
+	}
+
+	// Rewrite the bundle with an altered summary
+	archive, _ := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	var tampered bytes.Buffer
+	w := zip.NewWriter(&tampered)
+	for _, file := range archive.File {
+		rc, _ := file.Open()
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		if file.Name == FileSummary {
+			data = bytes.Replace(data, []byte(`"files_with_pii": 1`), []byte(`"files_with_pii": 0`), 1)
+		}
+		fw, _ := w.Create(file.Name)
+		fw.Write(data)
+	}
+	w.Close()
+	if _, err := Verify(bytes.NewReader(tampered.Bytes()), int64(tampered.Len()), nil); err == nil || !strings.Contains(err.Error(), FileSummary) {
+		t.Errorf("Expected the altered summary to be detected, got %v", err)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	summary := Summarize(scanFixture(t))
+	if summary.FilesScanned != 2 || summary.FilesWithPII != 1 {
+		t.Errorf("Unexpected file counts %+v", summary)
+	}
+	if summary.Stats[pii.PiiTypeEmail] != 1 || summary.Stats[pii.PiiTypeSSN] != 1 || summary.BySeverity[pii.SeverityCritical.String()] == 0 {
+		t.Errorf("Unexpected stats %+v", summary)
+	}
+}
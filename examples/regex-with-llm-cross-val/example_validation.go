@@ -7,9 +7,13 @@ import (
 	"time"
 
 	pii "github.com/intMeric/pii-extractor"
+	"github.com/intMeric/pii-extractor/extractors/llm/gollm"
 )
 
 func main() {
+	// Reach the LLM providers through gollm
+	gollm.Install()
+
 	// Example text containing PII
 	text := `
 	Dear John,
@@ -205,6 +209,9 @@ func printValidationResults(result *pii.PiiExtractionResult) {
 		fmt.Printf("- Total validated: %d\n", stats.TotalValidated)
 		fmt.Printf("- Valid: %d, Invalid: %d\n", stats.ValidCount, stats.InvalidCount)
 		fmt.Printf("- Average confidence: %.2f\n", stats.AverageConfidence)
+		for piiType, typeStats := range stats.ByType {
+			fmt.Printf("  - %s: %d validated, %.0f%% rejected\n", piiType, typeStats.TotalValidated, typeStats.RejectionRate()*100)
+		}
 	}
 	fmt.Println()
 }
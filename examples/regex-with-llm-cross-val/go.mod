@@ -2,7 +2,10 @@ module example-regex-with-llm-cross-val
 
 go 1.23.0
 
-require github.com/intMeric/pii-extractor v0.0.0
+require (
+	github.com/intMeric/pii-extractor v0.0.0
+	github.com/intMeric/pii-extractor/extractors/llm/gollm v0.0.0
+)
 
 require (
 	github.com/bahlo/generic-list-go v0.2.0 // indirect
@@ -31,4 +34,7 @@ require (
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
 
-replace github.com/intMeric/pii-extractor => ../..
+replace (
+	github.com/intMeric/pii-extractor => ../..
+	github.com/intMeric/pii-extractor/extractors/llm/gollm => ../../extractors/llm/gollm
+)
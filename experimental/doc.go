@@ -0,0 +1,9 @@
+// Package experimental is the namespace of features whose API is not settled yet. Packages
+// under experimental/ are not covered by the module's compatibility guarantees: they may
+// change or be removed in any release, including patch releases. See the API stability
+// policy of the root package.
+//
+// A feature graduates by moving out of experimental/ into its own package. The
+// experimental package then keeps type aliases and wrappers to the new location, marked
+// Deprecated, for one more minor release, so importers can migrate at their pace.
+package experimental
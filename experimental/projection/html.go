@@ -0,0 +1,207 @@
+package projection
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+
+	"github.com/intMeric/pii-extractor/pii"
+	"github.com/intMeric/pii-extractor/redact"
+)
+
+// NodeSpan is a byte range of the data of a DOM text node. Offsets are in the decoded data
+// of the node (character references already resolved), as held by html.Node.Data.
+type NodeSpan struct {
+	Node  *html.Node `json:"-"`
+	Path  string     `json:"path"` // e.g. /html/body/p[2]/text()[1]
+	Start int        `json:"start"`
+	End   int        `json:"end"`
+}
+
+// HTMLProjection is an entity occurrence in the plain text of an HTML document along with
+// the text node ranges it covers, in document order
+type HTMLProjection struct {
+	pii.Occurrence
+	Spans []NodeSpan `json:"spans"`
+}
+
+// HTMLDocument is a parsed HTML document and its plain text, as given to an extractor.
+// Text nodes of scripts, styles and templates are left out, and block elements are
+// separated by line breaks.
+type HTMLDocument struct {
+	Root *html.Node
+	Text string
+
+	nodes  []*html.Node
+	pieces pieceMap
+}
+
+// skippedElements are elements whose text is not content
+var skippedElements = map[atom.Atom]bool{
+	atom.Script:   true,
+	atom.Style:    true,
+	atom.Noscript: true,
+	atom.Template: true,
+}
+
+// blockElements are elements whose text is separated from their neighbours by a line break
+var blockElements = map[atom.Atom]bool{
+	atom.Address: true, atom.Article: true, atom.Aside: true, atom.Blockquote: true,
+	atom.Br: true, atom.Dd: true, atom.Div: true, atom.Dl: true, atom.Dt: true,
+	atom.Fieldset: true, atom.Figcaption: true, atom.Figure: true, atom.Footer: true,
+	atom.Form: true, atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true,
+	atom.H5: true, atom.H6: true, atom.Header: true, atom.Hr: true, atom.Li: true,
+	atom.Main: true, atom.Nav: true, atom.Ol: true, atom.P: true, atom.Pre: true,
+	atom.Section: true, atom.Table: true, atom.Td: true, atom.Th: true, atom.Title: true,
+	atom.Tr: true, atom.Ul: true,
+}
+
+// ParseHTML parses an HTML document and builds its plain text
+func ParseHTML(r io.Reader) (*HTMLDocument, error) {
+	root, err := html.Parse(r)
+	if err != nil {
+		return nil, fmt.Errorf("parsing HTML: %w", err)
+	}
+	return NewHTMLDocument(root), nil
+}
+
+// NewHTMLDocument builds the plain text of an already parsed HTML tree
+func NewHTMLDocument(root *html.Node) *HTMLDocument {
+	d := &HTMLDocument{Root: root}
+	var b strings.Builder
+	lineBreak := func() {
+		if b.Len() > 0 && !strings.HasSuffix(b.String(), "\n") {
+			b.WriteByte('\n')
+		}
+	}
+
+	var walk func(node *html.Node)
+	walk = func(node *html.Node) {
+		switch node.Type {
+		case html.TextNode:
+			start := b.Len()
+			b.WriteString(node.Data)
+			d.pieces.add(start, b.Len(), len(d.nodes))
+			d.nodes = append(d.nodes, node)
+			return
+		case html.ElementNode:
+			if skippedElements[node.DataAtom] {
+				return
+			}
+			if blockElements[node.DataAtom] {
+				lineBreak()
+				defer lineBreak()
+			}
+		case html.CommentNode, html.DoctypeNode:
+			return
+		}
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(root)
+
+	d.Text = b.String()
+	return d
+}
+
+// Project returns the text node ranges covered by Text[start:end], in document order
+func (d *HTMLDocument) Project(start, end int) []NodeSpan {
+	var spans []NodeSpan
+	for _, p := range d.pieces.overlapping(start, end) {
+		node := d.nodes[p.unit]
+		spans = append(spans, NodeSpan{Node: node, Path: NodePath(node), Start: p.start - p.origin, End: p.end - p.origin})
+	}
+	return spans
+}
+
+// ProjectResult projects the occurrences of the result entities in Text (see redact.Spans)
+// onto the text nodes of the document
+func (d *HTMLDocument) ProjectResult(result *pii.PiiExtractionResult) []HTMLProjection {
+	var projections []HTMLProjection
+	for _, occurrence := range redact.Spans(d.Text, result) {
+		projections = append(projections, HTMLProjection{
+			Occurrence: occurrence,
+			Spans:      d.Project(occurrence.Start, occurrence.End),
+		})
+	}
+	return projections
+}
+
+// Redact replaces the occurrences of the result entities in the text nodes of the tree with
+// mask(entity). An occurrence spread over several nodes is masked in the first one and
+// removed from the others, so markup between its parts is kept. The tree is modified in
+// place and Text is not updated; render the tree with html.Render.
+func (d *HTMLDocument) Redact(result *pii.PiiExtractionResult, mask func(pii.PiiEntity) string) {
+	type edit struct {
+		start, end  int
+		replacement string
+	}
+	edits := make(map[*html.Node][]edit)
+	for _, projection := range d.ProjectResult(result) {
+		replacement := mask(projection.Entity)
+		for i, span := range projection.Spans {
+			e := edit{start: span.Start, end: span.End}
+			if i == 0 {
+				e.replacement = replacement
+			}
+			edits[span.Node] = append(edits[span.Node], e)
+		}
+	}
+
+	for node, nodeEdits := range edits {
+		sort.Slice(nodeEdits, func(i, j int) bool { return nodeEdits[i].start > nodeEdits[j].start })
+		data := node.Data
+		for _, e := range nodeEdits {
+			data = data[:e.start] + e.replacement + data[e.end:]
+		}
+		node.Data = data
+	}
+}
+
+// NodePath returns an XPath-like path of a node, such as /html/body/p[2]/text()[1].
+// Indexes are 1-based among siblings of the same name; element steps omit the index
+// when the element has no sibling of the same name.
+func NodePath(node *html.Node) string {
+	var steps []string
+	for n := node; n != nil && n.Type != html.DocumentNode; n = n.Parent {
+		name := n.Data
+		if n.Type == html.TextNode {
+			name = "text()"
+		}
+		index, count := 0, 0
+		if n.Parent != nil {
+			for sibling := n.Parent.FirstChild; sibling != nil; sibling = sibling.NextSibling {
+				if sameStep(sibling, n) {
+					count++
+					if sibling == n {
+						index = count
+					}
+				}
+			}
+		}
+		if count > 1 || n.Type == html.TextNode {
+			name = fmt.Sprintf("%s[%d]", name, index)
+		}
+		steps = append(steps, name)
+	}
+
+	var b strings.Builder
+	for i := len(steps) - 1; i >= 0; i-- {
+		b.WriteByte('/')
+		b.WriteString(steps[i])
+	}
+	return b.String()
+}
+
+// sameStep reports whether two sibling nodes share a path step name
+func sameStep(a, b *html.Node) bool {
+	if a.Type != b.Type {
+		return false
+	}
+	return a.Type == html.TextNode || a.Data == b.Data
+}
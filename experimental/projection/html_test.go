@@ -0,0 +1,62 @@
+package projection
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+func TestHTMLDocumentText(t *testing.T) {
+	doc, err := ParseHTML(strings.NewReader(`<html><head><style>p{}</style></head><body><p>Call <b>John</b></p><p>SSN 123-45-6789</p><script>var x = 1;</script></body></html>`))
+	if err != nil {
+		t.Fatalf("ParseHTML() error = %v", err)
+	}
+	if doc.Text != "Call John\nSSN 123-45-6789\n" {
+		t.Errorf("Expected scripts and styles skipped and paragraphs on their own line, got %q", doc.Text)
+	}
+}
+
+func TestHTMLDocumentProject(t *testing.T) {
+	doc, err := ParseHTML(strings.NewReader(`<p>Mail <i>john@</i>acme.com &amp; more</p>`))
+	if err != nil {
+		t.Fatalf("ParseHTML() error = %v", err)
+	}
+	start := strings.Index(doc.Text, "john@acme.com")
+	spans := doc.Project(start, start+len("john@acme.com"))
+	if len(spans) != 2 {
+		t.Fatalf("Expected the email split over 2 text nodes, got %+v", spans)
+	}
+	if spans[0].Path != "/html/body/p/i/text()[1]" || spans[0].Node.Data[spans[0].Start:spans[0].End] != "john@" {
+		t.Errorf("Unexpected first span %+v", spans[0])
+	}
+	if spans[1].Path != "/html/body/p/text()[2]" || spans[1].Start != 0 || spans[1].End != len("acme.com") {
+		t.Errorf("Unexpected second span %+v", spans[1])
+	}
+}
+
+func TestHTMLDocumentRedact(t *testing.T) {
+	doc, err := ParseHTML(strings.NewReader(`<p>Mail <i>john@</i>acme.com, SSN 123-45-6789 &amp; 123-45-6789</p>`))
+	if err != nil {
+		t.Fatalf("ParseHTML() error = %v", err)
+	}
+	result := pii.NewPiiExtractionResult([]pii.PiiEntity{
+		{Type: pii.PiiTypeEmail, Value: pii.NewEmail("john@acme.com")},
+		{Type: pii.PiiTypeSSN, Value: pii.NewSSN("123-45-6789")},
+	})
+	if projections := doc.ProjectResult(result); len(projections) != 3 {
+		t.Fatalf("Expected 3 projected occurrences, got %d", len(projections))
+	}
+
+	doc.Redact(result, func(entity pii.PiiEntity) string { return "[" + entity.Type.String() + "]" })
+	var out strings.Builder
+	if err := html.Render(&out, doc.Root); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	expected := `<p>Mail <i>[email]</i>, SSN [ssn] &amp; [ssn]</p>`
+	if !strings.Contains(out.String(), expected) {
+		t.Errorf("Expected %q in the redacted document, got %q", expected, out.String())
+	}
+}
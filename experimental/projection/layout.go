@@ -0,0 +1,143 @@
+package projection
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/intMeric/pii-extractor/pii"
+	"github.com/intMeric/pii-extractor/redact"
+)
+
+// Rect is a bounding box in page coordinates, with (X0, Y0) its lower left corner and
+// (X1, Y1) its upper right corner, in the unit of the layout source (PDF points for PDF
+// text layers, pixels for OCR of scanned pages)
+type Rect struct {
+	X0 float64 `json:"x0"`
+	Y0 float64 `json:"y0"`
+	X1 float64 `json:"x1"`
+	Y1 float64 `json:"y1"`
+}
+
+// Union returns the smallest box containing both boxes
+func (r Rect) Union(other Rect) Rect {
+	return Rect{X0: min(r.X0, other.X0), Y0: min(r.Y0, other.Y0), X1: max(r.X1, other.X1), Y1: max(r.Y1, other.Y1)}
+}
+
+// Word is a word of a laid out page, as produced by a PDF text layer reader or by OCR
+type Word struct {
+	Page int    `json:"page"` // 1-based page number
+	Line int    `json:"line"` // line number within the page, any increasing numbering
+	Text string `json:"text"`
+	Box  *Rect  `json:"box,omitempty"` // nil when the source provides no geometry
+}
+
+// Region is the part of an entity occurrence on one line of a page
+type Region struct {
+	Page int   `json:"page"`
+	Line int   `json:"line"`
+	Box  *Rect `json:"box,omitempty"` // nil when none of the covered words has a box
+}
+
+// LayoutProjection is an entity occurrence in the plain text of a layout along with the
+// page regions it covers, in reading order
+type LayoutProjection struct {
+	pii.Occurrence
+	Regions []Region `json:"regions"`
+}
+
+// Layout is the plain text of a sequence of laid out words, as given to an extractor.
+// Words of a line are separated by a space, lines by a line break and pages by a form feed.
+type Layout struct {
+	Text  string
+	Words []Word
+
+	pieces pieceMap
+	starts []int
+}
+
+// NewLayout builds the plain text of words given in reading order
+func NewLayout(words []Word) *Layout {
+	l := &Layout{Words: words}
+	var b strings.Builder
+	for i, word := range words {
+		if i > 0 {
+			previous := words[i-1]
+			switch {
+			case word.Page != previous.Page:
+				b.WriteByte('\f')
+			case word.Line != previous.Line:
+				b.WriteByte('\n')
+			default:
+				b.WriteByte(' ')
+			}
+		}
+		start := b.Len()
+		b.WriteString(word.Text)
+		l.pieces.add(start, b.Len(), i)
+		l.starts = append(l.starts, start)
+	}
+	l.Text = b.String()
+	return l
+}
+
+// WordRange returns the byte offsets of the i-th word in Text
+func (l *Layout) WordRange(i int) (start, end int) {
+	return l.starts[i], l.starts[i] + len(l.Words[i].Text)
+}
+
+// Project returns the page regions covered by Text[start:end], one per page line. Boxes
+// of partially covered words are narrowed in proportion to the covered characters, which
+// is exact for monospaced text and a close estimate otherwise.
+func (l *Layout) Project(start, end int) []Region {
+	var regions []Region
+	for _, p := range l.pieces.overlapping(start, end) {
+		word := l.Words[p.unit]
+		var box *Rect
+		if word.Box != nil {
+			narrowed := narrow(*word.Box, word.Text, p.start-p.origin, p.end-p.origin)
+			box = &narrowed
+		}
+
+		if n := len(regions); n > 0 && regions[n-1].Page == word.Page && regions[n-1].Line == word.Line {
+			last := &regions[n-1]
+			switch {
+			case last.Box == nil:
+				last.Box = box
+			case box != nil:
+				union := last.Box.Union(*box)
+				last.Box = &union
+			}
+			continue
+		}
+		regions = append(regions, Region{Page: word.Page, Line: word.Line, Box: box})
+	}
+	return regions
+}
+
+// ProjectResult projects the occurrences of the result entities in Text (see redact.Spans)
+// onto the pages of the layout
+func (l *Layout) ProjectResult(result *pii.PiiExtractionResult) []LayoutProjection {
+	var projections []LayoutProjection
+	for _, occurrence := range redact.Spans(l.Text, result) {
+		projections = append(projections, LayoutProjection{
+			Occurrence: occurrence,
+			Regions:    l.Project(occurrence.Start, occurrence.End),
+		})
+	}
+	return projections
+}
+
+// narrow returns the horizontal slice of a word box covering text[start:end], assuming
+// characters of equal width
+func narrow(box Rect, text string, start, end int) Rect {
+	total := utf8.RuneCountInString(text)
+	if total == 0 || (start == 0 && end == len(text)) {
+		return box
+	}
+	width := (box.X1 - box.X0) / float64(total)
+	before := utf8.RuneCountInString(text[:start])
+	covered := utf8.RuneCountInString(text[start:end])
+	box.X0 += width * float64(before)
+	box.X1 = box.X0 + width*float64(covered)
+	return box
+}
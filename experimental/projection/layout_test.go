@@ -0,0 +1,57 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+func TestLayoutText(t *testing.T) {
+	layout := NewLayout([]Word{
+		{Page: 1, Line: 1, Text: "Name:"},
+		{Page: 1, Line: 1, Text: "John"},
+		{Page: 1, Line: 2, Text: "Done"},
+		{Page: 2, Line: 1, Text: "Next"},
+	})
+	if layout.Text != "Name: John\nDone\fNext" {
+		t.Errorf("Unexpected layout text %q", layout.Text)
+	}
+}
+
+func TestLayoutProjectResult(t *testing.T) {
+	layout := NewLayout([]Word{
+		{Page: 1, Line: 1, Text: "SSN:123-45-6789", Box: &Rect{X0: 0, Y0: 700, X1: 150, Y1: 712}},
+		{Page: 1, Line: 2, Text: "Call", Box: &Rect{X0: 0, Y0: 680, X1: 40, Y1: 692}},
+		{Page: 1, Line: 2, Text: "(555)", Box: &Rect{X0: 50, Y0: 680, X1: 100, Y1: 692}},
+		{Page: 1, Line: 2, Text: "123-4567", Box: &Rect{X0: 110, Y0: 680, X1: 190, Y1: 692}},
+		{Page: 2, Line: 1, Text: "(no", Box: nil},
+	})
+	result := pii.NewPiiExtractionResult([]pii.PiiEntity{
+		{Type: pii.PiiTypeSSN, Value: pii.NewSSN("123-45-6789")},
+		{Type: pii.PiiTypePhone, Value: pii.NewPhone("(555) 123-4567", pii.CountryUS)},
+	})
+
+	projections := layout.ProjectResult(result)
+	if len(projections) != 2 {
+		t.Fatalf("Expected 2 projections, got %+v", projections)
+	}
+	ssn := projections[0].Regions
+	if len(ssn) != 1 || ssn[0].Box == nil || *ssn[0].Box != (Rect{X0: 40, Y0: 700, X1: 150, Y1: 712}) {
+		t.Errorf("Expected the SSN box narrowed to its characters, got %+v", ssn)
+	}
+	phone := projections[1].Regions
+	if len(phone) != 1 || phone[0].Box == nil || *phone[0].Box != (Rect{X0: 50, Y0: 680, X1: 190, Y1: 692}) {
+		t.Errorf("Expected the phone box to span both words, got %+v", phone)
+	}
+}
+
+func TestLayoutProjectAcrossLines(t *testing.T) {
+	layout := NewLayout([]Word{
+		{Page: 1, Line: 1, Text: "123", Box: &Rect{X1: 30, Y1: 10}},
+		{Page: 2, Line: 1, Text: "Main"},
+	})
+	regions := layout.Project(0, len(layout.Text))
+	if len(regions) != 2 || regions[0].Page != 1 || regions[1].Page != 2 || regions[1].Box != nil {
+		t.Errorf("Expected one region per page, without a box for words without geometry, got %+v", regions)
+	}
+}
@@ -0,0 +1,41 @@
+// Package projection maps entity spans found in the plain text of a markup source (HTML,
+// PDF with layout) back to coordinates in that source, such as a DOM text node and offset
+// or a PDF page and bounding box, so redaction can be applied in the original format
+// rather than only in the extracted text.
+package projection
+
+import "sort"
+
+// piece is a run of the plain text produced by one source unit (a text node, a word)
+type piece struct {
+	start, end int // byte offsets of the run in the plain text
+	unit       int // index of the source unit
+	origin     int // offset of the first byte of the unit in the plain text
+}
+
+// pieceMap records which source unit produced each run of the plain text
+type pieceMap struct {
+	pieces []piece
+}
+
+// add records that text[start:end] comes from a source unit
+func (m *pieceMap) add(start, end, unit int) {
+	if end > start {
+		m.pieces = append(m.pieces, piece{start: start, end: end, unit: unit, origin: start})
+	}
+}
+
+// overlapping returns the runs overlapping text[start:end], clipped to it. Offsets in the
+// unit of a clipped run are start-origin and end-origin.
+func (m *pieceMap) overlapping(start, end int) []piece {
+	var clipped []piece
+	first := sort.Search(len(m.pieces), func(i int) bool { return m.pieces[i].end > start })
+	for _, p := range m.pieces[first:] {
+		if p.start >= end {
+			break
+		}
+		p.start, p.end = max(p.start, start), min(p.end, end)
+		clipped = append(clipped, p)
+	}
+	return clipped
+}
@@ -0,0 +1,265 @@
+// Package redactpdf writes sanitized PDFs from the layout of a document: the words of its text
+// layer are redrawn at their position, except the characters of detected entities, which
+// are left out and covered with opaque boxes. The output has a single text layer, rebuilt
+// from the layout, so no redacted value can be recovered by selecting or extracting text.
+//
+// Only the words of the layout are carried over. Images, vector graphics, annotations,
+// form fields and metadata of the original document are not, which is what makes the
+// output safe to share, but also means scanned pages come out as their OCR text only.
+package redactpdf
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/intMeric/pii-extractor/experimental/projection"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// PageSize is the size of a page in PDF points (1/72 inch)
+type PageSize struct {
+	Width  float64
+	Height float64
+}
+
+// Letter and A4 are common page sizes
+var (
+	Letter = PageSize{Width: 612, Height: 792}
+	A4     = PageSize{Width: 595.28, Height: 841.89}
+)
+
+// Options configures the written PDF
+type Options struct {
+	// Pages are the sizes of the pages by page number minus one; pages past the end use
+	// the last size, and Letter when Pages is empty
+	Pages []PageSize
+	// Scale converts layout units to points, such as 72/300 for OCR boxes of 300 dpi
+	// scans; zero means the boxes are already in points. Boxes must have their origin at
+	// the lower left corner of the page, as in PDF.
+	Scale float64
+	// Margin is the padding in points added around redaction boxes; zero uses 1
+	Margin float64
+}
+
+// Report describes a written PDF
+type Report struct {
+	Pages int `json:"pages"`
+	// Redacted is the number of boxes drawn over entity regions
+	Redacted int `json:"redacted"`
+	// Unplaced is the number of entity regions without a box; their characters are left
+	// out like the others, but nothing marks their place on the page
+	Unplaced int `json:"unplaced"`
+	// SkippedWords is the number of words without a box, which cannot be placed and are
+	// left out of the output
+	SkippedWords int `json:"skipped_words"`
+}
+
+// Write writes a sanitized PDF of the layout, redacting the occurrences of the result
+// entities in the layout text
+func Write(w io.Writer, layout *projection.Layout, result *pii.PiiExtractionResult, options Options) (*Report, error) {
+	if options.Scale == 0 {
+		options.Scale = 1
+	}
+	if options.Margin == 0 {
+		options.Margin = 1
+	}
+
+	report := &Report{}
+	pages := make(map[int]*page)
+	lastPage := 0
+	pageOf := func(number int) *page {
+		if p, ok := pages[number]; ok {
+			return p
+		}
+		p := &page{}
+		pages[number] = p
+		lastPage = max(lastPage, number)
+		return p
+	}
+
+	projections := layout.ProjectResult(result)
+	var covered [][2]int
+	for _, projection := range projections {
+		covered = append(covered, [2]int{projection.Start, projection.End})
+		for _, region := range projection.Regions {
+			p := pageOf(region.Page)
+			if region.Box == nil {
+				report.Unplaced++
+				continue
+			}
+			p.boxes = append(p.boxes, scale(*region.Box, options.Scale))
+			report.Redacted++
+		}
+	}
+
+	next := 0
+	for i, word := range layout.Words {
+		p := pageOf(word.Page)
+		if word.Box == nil {
+			report.SkippedWords++
+			continue
+		}
+		start, end := layout.WordRange(i)
+		for next < len(covered) && covered[next][1] <= start {
+			next++
+		}
+		p.words = append(p.words, placedWord{
+			text: blankCovered(word.Text, start, end, covered[next:]),
+			box:  scale(*word.Box, options.Scale),
+		})
+	}
+
+	report.Pages = max(lastPage, 1)
+	if err := writeDocument(w, report.Pages, pages, options); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// page is the content of one output page
+type page struct {
+	words []placedWord
+	boxes []projection.Rect
+}
+
+// placedWord is a word redrawn at its box
+type placedWord struct {
+	text string
+	box  projection.Rect
+}
+
+// scale converts a box to points
+func scale(box projection.Rect, factor float64) projection.Rect {
+	return projection.Rect{X0: box.X0 * factor, Y0: box.Y0 * factor, X1: box.X1 * factor, Y1: box.Y1 * factor}
+}
+
+// blankCovered replaces the characters of a word at text[start:end] that fall in covered
+// spans (sorted, starting with the first one ending after start) with spaces, so the
+// remaining characters keep their position
+func blankCovered(word string, start, end int, covered [][2]int) string {
+	var b []rune
+	offset := start
+	for _, r := range word {
+		size := utf8.RuneLen(r)
+		for _, span := range covered {
+			if span[0] >= end {
+				break
+			}
+			if offset < span[1] && offset+size > span[0] {
+				r = ' '
+				break
+			}
+		}
+		b = append(b, r)
+		offset += size
+	}
+	return string(b)
+}
+
+// writeDocument writes the PDF objects: catalog, page tree, font, then a page and its
+// content stream for each page
+func writeDocument(w io.Writer, pageCount int, pages map[int]*page, options Options) error {
+	out := bufio.NewWriter(w)
+	written := 0
+	var offsets []int
+	write := func(format string, args ...any) {
+		n, _ := fmt.Fprintf(out, format, args...)
+		written += n
+	}
+	object := func(body string) {
+		offsets = append(offsets, written)
+		write("%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	write("%%PDF-1.4\n%%\xe2\xe3\xcf\xd3\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+
+	var kids bytes.Buffer
+	for i := range pageCount {
+		fmt.Fprintf(&kids, "%d 0 R ", 4+2*i)
+	}
+	object(fmt.Sprintf("<< /Type /Pages /Kids [ %s] /Count %d >>", kids.String(), pageCount))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+
+	for i := range pageCount {
+		size := pageSize(options.Pages, i)
+		content := pageContent(pages[i+1], options.Margin)
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+			number(size.Width), number(size.Height), 5+2*i))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content))
+	}
+
+	xref := written
+	write("xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		write("%010d 00000 n \n", offset)
+	}
+	write("trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Flush()
+}
+
+// pageSize returns the size of the i-th page
+func pageSize(sizes []PageSize, i int) PageSize {
+	if len(sizes) == 0 {
+		return Letter
+	}
+	return sizes[min(i, len(sizes)-1)]
+}
+
+// pageContent returns the content stream of a page: its words as text scaled to fit their
+// box, then the redaction boxes filled in black on top
+func pageContent(p *page, margin float64) string {
+	if p == nil {
+		return ""
+	}
+	var b bytes.Buffer
+	for _, word := range p.words {
+		height := word.box.Y1 - word.box.Y0
+		runes := utf8.RuneCountInString(word.text)
+		if height <= 0 || runes == 0 {
+			continue
+		}
+		// Helvetica glyphs average about half an em; stretch horizontally to the box
+		stretch := 100 * (word.box.X1 - word.box.X0) / (0.5 * height * float64(runes))
+		fmt.Fprintf(&b, "BT /F1 %s Tf %s Tz %s %s Td (%s) Tj ET\n",
+			number(height), number(stretch), number(word.box.X0), number(word.box.Y0+0.2*height), escape(word.text))
+	}
+	if len(p.boxes) > 0 {
+		b.WriteString("0 g\n")
+		for _, box := range p.boxes {
+			fmt.Fprintf(&b, "%s %s %s %s re f\n", number(box.X0-margin), number(box.Y0-margin),
+				number(box.X1-box.X0+2*margin), number(box.Y1-box.Y0+2*margin))
+		}
+	}
+	return b.String()
+}
+
+// escape encodes text as the content of a PDF literal string in WinAnsiEncoding; characters
+// outside Latin-1 are replaced with question marks
+func escape(text string) string {
+	var b bytes.Buffer
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r >= 0x20 && r < 0x7f:
+			b.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+// number formats a coordinate with at most two decimals
+func number(value float64) string {
+	return strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64)
+}
@@ -0,0 +1,71 @@
+package redactpdf
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/intMeric/pii-extractor/experimental/projection"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+func TestWrite(t *testing.T) {
+	layout := projection.NewLayout([]projection.Word{
+		{Page: 1, Line: 1, Text: "SSN:123-45-6789", Box: &projection.Rect{X0: 72, Y0: 700, X1: 222, Y1: 712}},
+		{Page: 1, Line: 2, Text: "Mail", Box: &projection.Rect{X0: 72, Y0: 680, X1: 112, Y1: 692}},
+		{Page: 1, Line: 2, Text: "john@acme.com", Box: nil},
+		{Page: 2, Line: 1, Text: "(Thanks)", Box: &projection.Rect{X0: 72, Y0: 700, X1: 152, Y1: 712}},
+	})
+	result := pii.NewPiiExtractionResult([]pii.PiiEntity{
+		{Type: pii.PiiTypeSSN, Value: pii.NewSSN("123-45-6789")},
+		{Type: pii.PiiTypeEmail, Value: pii.NewEmail("john@acme.com")},
+	})
+
+	var out bytes.Buffer
+	report, err := Write(&out, layout, result, Options{Pages: []PageSize{A4}})
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if *report != (Report{Pages: 2, Redacted: 1, Unplaced: 1, SkippedWords: 1}) {
+		t.Errorf("Unexpected report %+v", *report)
+	}
+
+	document := out.String()
+	for _, leaked := range []string{"123-45-6789", "6789", "john@acme.com"} {
+		if strings.Contains(document, leaked) {
+			t.Errorf("Expected %q stripped from the PDF", leaked)
+		}
+	}
+	for _, expected := range []string{"%PDF-1.4", "(SSN:           ) Tj", `(\(Thanks\)) Tj`, "/Count 2", "/MediaBox [0 0 595.28 841.89]", "re f", "%%EOF"} {
+		if !strings.Contains(document, expected) {
+			t.Errorf("Expected %q in the PDF", expected)
+		}
+	}
+}
+
+func TestWriteXref(t *testing.T) {
+	var out bytes.Buffer
+	if _, err := Write(&out, projection.NewLayout(nil), nil, Options{}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	document := out.String()
+
+	// Every xref entry must point at the start of its object
+	xref := strings.Index(document, "xref\n")
+	lines := strings.Split(document[xref:], "\n")[3:]
+	for i := 0; i < 5; i++ {
+		offset := 0
+		for _, c := range lines[i][:10] {
+			offset = offset*10 + int(c-'0')
+		}
+		if prefix := strings.Split(document[offset:], "\n")[0]; !strings.HasSuffix(prefix, " 0 obj") || !strings.HasPrefix(prefix, string(rune('1'+i))) {
+			t.Errorf("Expected xref entry %d to point at its object, got %q", i+1, prefix)
+		}
+	}
+}
+
+func TestEscape(t *testing.T) {
+	if got := escape(`a(b)\é中`); got != `a\(b\)\\\351?` {
+		t.Errorf("escape() = %q", got)
+	}
+}
@@ -0,0 +1,51 @@
+package extractors
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// BatchResult is the outcome of extracting a batch of texts
+type BatchResult struct {
+	// Results holds the result of each input, in input order
+	Results []*pii.PiiExtractionResult `json:"results"`
+
+	// Summary combines the results, merging values found in several inputs
+	// (see pii.MergeResults)
+	Summary *pii.PiiExtractionResult `json:"summary"`
+}
+
+// NewBatchResult creates the batch result of per-input results
+func NewBatchResult(results []*pii.PiiExtractionResult) *BatchResult {
+	return &BatchResult{Results: results, Summary: pii.MergeResults(results...)}
+}
+
+// BatchExtractor is implemented by extractors with a dedicated path for many small
+// inputs, such as chat messages, avoiding per-call setup
+type BatchExtractor interface {
+	// ExtractBatchContext performs PII extraction on each text under the given context
+	ExtractBatchContext(ctx context.Context, texts []string) (*BatchResult, error)
+}
+
+// ExtractBatch runs a batch extraction with ctx when the extractor supports it, falling
+// back to extracting each text in turn otherwise. The first failed input fails the batch.
+func ExtractBatch(ctx context.Context, extractor PiiExtractor, texts []string) (*BatchResult, error) {
+	if batchExtractor, ok := extractor.(BatchExtractor); ok {
+		return batchExtractor.ExtractBatchContext(ctx, texts)
+	}
+
+	results := make([]*pii.PiiExtractionResult, len(texts))
+	for i, text := range texts {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		result, err := ExtractWithContext(ctx, extractor, text)
+		if err != nil {
+			return nil, fmt.Errorf("input %d: %w", i, err)
+		}
+		results[i] = result
+	}
+	return NewBatchResult(results), nil
+}
@@ -0,0 +1,27 @@
+package extractors
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestExtractBatch_Fallback(t *testing.T) {
+	texts := []string{"mail john@acme.com", "nothing here", "john@acme.com and mary@acme.com"}
+	batch, err := ExtractBatch(context.Background(), &emailExtractor{}, texts)
+	if err != nil {
+		t.Fatalf("ExtractBatch() error = %v", err)
+	}
+	if len(batch.Results) != 3 || batch.Results[0].Total != 1 || batch.Results[1].Total != 0 || batch.Results[2].Total != 2 {
+		t.Fatalf("Unexpected per-input results %+v", batch.Results)
+	}
+	if batch.Summary.Total != 2 || batch.Summary.Entities[0].GetCount()+batch.Summary.Entities[1].GetCount() != 3 {
+		t.Errorf("Expected 2 distinct emails found 3 times in the summary, got %+v", batch.Summary.Entities)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := ExtractBatch(ctx, &emailExtractor{}, texts); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected a cancelled batch, got %v", err)
+	}
+}
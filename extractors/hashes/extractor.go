@@ -0,0 +1,124 @@
+package hashes
+
+import (
+	"slices"
+
+	"github.com/intMeric/pii-extractor/extractors"
+	patterns "github.com/intMeric/pii-extractor/extractors/regex/patterns"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// Extractor reports detected hashes as PiiTypeHash entities. Hashes found in the known set
+// have their Known type set and a severity of at least SeverityHigh.
+type Extractor struct {
+	name       string
+	known      *KnownSet
+	algorithms []Algorithm
+	knownOnly  bool
+}
+
+// Option configures an Extractor
+type Option func(*Extractor)
+
+// WithKnownSet cross-references the hashes found against a set of known PII digests
+func WithKnownSet(set *KnownSet) Option {
+	return func(e *Extractor) {
+		e.known = set
+	}
+}
+
+// WithAlgorithms restricts detection to the given formats
+func WithAlgorithms(algorithms ...Algorithm) Option {
+	return func(e *Extractor) {
+		e.algorithms = algorithms
+	}
+}
+
+// WithKnownOnly only reports hashes found in the known set, ignoring checksums and commit
+// IDs that are not personal data
+func WithKnownOnly() Option {
+	return func(e *Extractor) {
+		e.knownOnly = true
+	}
+}
+
+// NewExtractor creates a hash extractor detecting all formats
+func NewExtractor(opts ...Option) *Extractor {
+	e := &Extractor{
+		name:       "hash-extractor",
+		algorithms: Algorithms,
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// Extract performs hash detection on the given text
+func (e *Extractor) Extract(text string) (*pii.PiiExtractionResult, error) {
+	return pii.NewPiiExtractionResult(e.extract(text)), nil
+}
+
+// ExtractByType extracts hashes when piiType is PiiTypeHash
+func (e *Extractor) ExtractByType(text string, piiType pii.PiiType) ([]pii.PiiEntity, error) {
+	if piiType != pii.PiiTypeHash {
+		return []pii.PiiEntity{}, nil
+	}
+	return e.extract(text), nil
+}
+
+// extract converts findings into hash entities, grouping repeated digests
+func (e *Extractor) extract(text string) []pii.PiiEntity {
+	hashMap := make(map[string]*pii.Hash)
+	var order []string
+	locator := pii.NewLocator(text)
+	for _, finding := range Find(text) {
+		if !slices.Contains(e.algorithms, finding.Algorithm) {
+			continue
+		}
+		raw := text[finding.Start:finding.End]
+		context := patterns.ExtractContext(text, finding.Start, finding.End)
+		location := locator.Locate(finding.Start, finding.End)
+
+		if hash, exists := hashMap[raw]; exists {
+			hash.BasePii.IncrementCount()
+			hash.BasePii.AddContext(context)
+			hash.BasePii.AddLocation(location)
+			continue
+		}
+		hash := pii.NewHash(raw, string(finding.Algorithm))
+		if piiType, ok := e.known.Lookup(raw); ok && finding.Algorithm != AlgorithmBcrypt {
+			hash.Known = &piiType
+		} else if e.knownOnly {
+			continue
+		}
+		hash.Contexts = []string{context}
+		hash.Locations = []pii.Location{location}
+		hashMap[raw] = &hash
+		order = append(order, raw)
+	}
+
+	entities := make([]pii.PiiEntity, 0, len(order))
+	for _, raw := range order {
+		entities = append(entities, pii.PiiEntity{
+			Type:  pii.PiiTypeHash,
+			Value: *hashMap[raw],
+		})
+	}
+	return entities
+}
+
+// GetSupportedTypes returns the list of PII types this extractor can handle
+func (e *Extractor) GetSupportedTypes() []pii.PiiType {
+	return []pii.PiiType{pii.PiiTypeHash}
+}
+
+// GetMethod returns the extraction method used by this extractor
+func (e *Extractor) GetMethod() extractors.ExtractionMethod {
+	return extractors.MethodRegex
+}
+
+// GetName returns the name of this extractor
+func (e *Extractor) GetName() string {
+	return e.name
+}
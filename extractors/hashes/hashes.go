@@ -0,0 +1,140 @@
+// Package hashes detects hex digests and bcrypt hashes, such as the "anonymized" columns of
+// exports, and flags those that are the digest of a known PII value. Unsalted hashes of
+// emails and phone numbers are reversible by hashing candidate values, so they remain
+// personal data.
+package hashes
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// Algorithm is a hash format recognized by Find
+type Algorithm string
+
+const (
+	AlgorithmMD5    Algorithm = "md5"
+	AlgorithmSHA1   Algorithm = "sha1"
+	AlgorithmSHA256 Algorithm = "sha256"
+	AlgorithmBcrypt Algorithm = "bcrypt"
+)
+
+// Algorithms are all the recognized formats
+var Algorithms = []Algorithm{AlgorithmMD5, AlgorithmSHA1, AlgorithmSHA256, AlgorithmBcrypt}
+
+// hexAlgorithms maps the length of a hex digest to its algorithm
+var hexAlgorithms = map[int]Algorithm{
+	32: AlgorithmMD5,
+	40: AlgorithmSHA1,
+	64: AlgorithmSHA256,
+}
+
+var (
+	hexPattern    = regexp.MustCompile(`\b[0-9a-fA-F]{32,64}\b`)
+	bcryptPattern = regexp.MustCompile(`\$2[abxy]?\$\d{2}\$[./A-Za-z0-9]{53}`)
+)
+
+// Finding is a hash found at text[Start:End]
+type Finding struct {
+	Start     int
+	End       int
+	Algorithm Algorithm
+}
+
+// Find returns the hashes found in text, in order of appearance. Hex digests must have the
+// length of an MD5, SHA-1 or SHA-256 digest, a single letter case and mix digits and
+// letters, which rules out most long numbers and words.
+func Find(text string) []Finding {
+	var findings []Finding
+	for _, loc := range hexPattern.FindAllStringIndex(text, -1) {
+		value := text[loc[0]:loc[1]]
+		algorithm, ok := hexAlgorithms[len(value)]
+		if !ok || !isDigest(value) {
+			continue
+		}
+		findings = append(findings, Finding{Start: loc[0], End: loc[1], Algorithm: algorithm})
+	}
+	for _, loc := range bcryptPattern.FindAllStringIndex(text, -1) {
+		findings = append(findings, Finding{Start: loc[0], End: loc[1], Algorithm: AlgorithmBcrypt})
+	}
+	// Keep the order of appearance across both patterns
+	slices.SortStableFunc(findings, func(a, b Finding) int {
+		return a.Start - b.Start
+	})
+	return findings
+}
+
+// isDigest reports whether a hex value mixes digits and letters of a single case
+func isDigest(value string) bool {
+	var digit, lower, upper bool
+	for _, r := range value {
+		switch {
+		case r >= '0' && r <= '9':
+			digit = true
+		case r >= 'a' && r <= 'f':
+			lower = true
+		default:
+			upper = true
+		}
+	}
+	return digit && (lower != upper)
+}
+
+// KnownSet is a rainbow set of the digests of known PII values, such as the customer emails
+// and phone numbers of a CRM. Digests are compared case-insensitively. bcrypt hashes are
+// salted and never match.
+type KnownSet struct {
+	digests map[string]pii.PiiType
+}
+
+// NewKnownSet creates an empty set
+func NewKnownSet() *KnownSet {
+	return &KnownSet{digests: make(map[string]pii.PiiType)}
+}
+
+// Add adds the MD5, SHA-1 and SHA-256 digests of a PII value, both as given (trimmed) and in
+// the normalized form of pii.NormalizeValue, such as a lowercase email or the digits of a
+// phone number
+func (s *KnownSet) Add(piiType pii.PiiType, value string) {
+	forms := []string{strings.TrimSpace(value)}
+	if normalized := pii.NormalizeValue(piiType, value); normalized != forms[0] {
+		forms = append(forms, normalized)
+	}
+	for _, form := range forms {
+		md5Sum := md5.Sum([]byte(form))
+		sha1Sum := sha1.Sum([]byte(form))
+		sha256Sum := sha256.Sum256([]byte(form))
+		s.digests[hex.EncodeToString(md5Sum[:])] = piiType
+		s.digests[hex.EncodeToString(sha1Sum[:])] = piiType
+		s.digests[hex.EncodeToString(sha256Sum[:])] = piiType
+	}
+}
+
+// AddDigest adds a precomputed hex digest of a value of the given type
+func (s *KnownSet) AddDigest(digest string, piiType pii.PiiType) {
+	s.digests[strings.ToLower(strings.TrimSpace(digest))] = piiType
+}
+
+// Lookup returns the type of the known value a digest was computed from
+func (s *KnownSet) Lookup(digest string) (pii.PiiType, bool) {
+	if s == nil {
+		return 0, false
+	}
+	piiType, ok := s.digests[strings.ToLower(digest)]
+	return piiType, ok
+}
+
+// Len returns the number of digests in the set
+func (s *KnownSet) Len() int {
+	if s == nil {
+		return 0
+	}
+	return len(s.digests)
+}
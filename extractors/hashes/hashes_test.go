@@ -0,0 +1,97 @@
+package hashes
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+const bcryptHash = "$2b$12$R9h/cIPz0gi.URNNX3kh2OPST9/PgBkqquzi.Ss7KIUgO2t0jWMUW"
+
+func md5Hex(s string) string {
+	sum := md5.Sum([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestFind(t *testing.T) {
+	sha1 := "2fd4e1c67a2d28fced849ee1bb76e7391b93eb12"
+	text := "user_md5=" + md5Hex("john@example.com") + " sha1 " + sha1 + "\n" +
+		"pw " + bcryptHash + " sha256 " + strings.ToUpper(sha256Hex("x")) + "\n" +
+		"order 12345678901234567890123456789012 mixed " + "aB" + md5Hex("y")[2:]
+
+	var algorithms []string
+	for _, finding := range Find(text) {
+		algorithms = append(algorithms, string(finding.Algorithm))
+	}
+	if got := strings.Join(algorithms, ","); got != "md5,sha1,bcrypt,sha256" {
+		t.Errorf("Find() algorithms = %s, want md5,sha1,bcrypt,sha256", got)
+	}
+}
+
+func TestKnownSet(t *testing.T) {
+	set := NewKnownSet()
+	set.Add(pii.PiiTypeEmail, "John@Example.com")
+	set.Add(pii.PiiTypePhone, "+1 (555) 123-4567")
+	set.AddDigest(strings.ToUpper(md5Hex("4111111111111111")), pii.PiiTypeCreditCard)
+
+	tests := []struct {
+		digest string
+		want   pii.PiiType
+	}{
+		{md5Hex("John@Example.com"), pii.PiiTypeEmail},
+		{sha256Hex("john@example.com"), pii.PiiTypeEmail},
+		{sha256Hex("15551234567"), pii.PiiTypePhone},
+		{md5Hex("4111111111111111"), pii.PiiTypeCreditCard},
+	}
+	for _, tt := range tests {
+		if got, ok := set.Lookup(tt.digest); !ok || got != tt.want {
+			t.Errorf("Lookup(%s) = %v, %v, want %v", tt.digest, got, ok, tt.want)
+		}
+	}
+	if _, ok := set.Lookup(md5Hex("jane@example.com")); ok {
+		t.Error("Expected an unknown digest not to match")
+	}
+}
+
+func TestExtractor(t *testing.T) {
+	set := NewKnownSet()
+	set.Add(pii.PiiTypeEmail, "john@example.com")
+	known := sha256Hex("john@example.com")
+	text := "email_hash=" + known + "\nchecksum " + md5Hex("release.tar.gz") + "\nagain " + known
+
+	result, err := NewExtractor(WithKnownSet(set)).Extract(text)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if result.Total != 2 {
+		t.Fatalf("Expected 2 hashes, got %d", result.Total)
+	}
+	hash, ok := result.Entities[0].AsHash()
+	if !ok || hash.Known == nil || *hash.Known != pii.PiiTypeEmail || hash.Count != 2 || hash.Algorithm != "sha256" {
+		t.Errorf("Expected a known sha256 email hash seen twice, got %+v", hash)
+	}
+	if severity := result.Entities[0].GetSeverity(); severity != pii.SeverityHigh {
+		t.Errorf("Expected known hash severity high, got %v", severity)
+	}
+	if severity := result.Entities[1].GetSeverity(); severity != pii.SeverityLow {
+		t.Errorf("Expected unknown hash severity low, got %v", severity)
+	}
+
+	result, _ = NewExtractor(WithKnownSet(set), WithKnownOnly()).Extract(text)
+	if result.Total != 1 {
+		t.Errorf("Expected only the known hash with WithKnownOnly, got %d", result.Total)
+	}
+	result, _ = NewExtractor(WithAlgorithms(AlgorithmMD5)).Extract(text)
+	if result.Total != 1 {
+		t.Errorf("Expected only the md5 hash with WithAlgorithms, got %d", result.Total)
+	}
+}
@@ -0,0 +1,131 @@
+package hybrid
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/intMeric/pii-extractor/extractors/llm"
+)
+
+// Clock tells the time and waits between validation retries. The default is the wall
+// clock; tests and custom schedulers can inject their own, such as a FakeClock.
+type Clock interface {
+	Now() time.Time
+	// Sleep waits for the delay, returning false early when the context is done
+	Sleep(ctx context.Context, delay time.Duration) bool
+}
+
+// systemClock is the wall clock
+type systemClock struct{}
+
+// SystemClock returns the wall clock
+func SystemClock() Clock {
+	return systemClock{}
+}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) Sleep(ctx context.Context, delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// FakeClock is a deterministic clock whose Sleep returns at once, advancing the time by
+// the delay and recording it. It is safe for concurrent use.
+type FakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	sleeps []time.Duration
+}
+
+// NewFakeClock returns a fake clock starting at the given time
+func NewFakeClock(start time.Time) *FakeClock {
+	return &FakeClock{now: start}
+}
+
+// Now returns the current fake time
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Sleep advances the fake time by the delay, unless the context is already done
+func (c *FakeClock) Sleep(ctx context.Context, delay time.Duration) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(delay)
+	c.sleeps = append(c.sleeps, delay)
+	return true
+}
+
+// Advance moves the fake time forward without recording a sleep
+func (c *FakeClock) Advance(delay time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(delay)
+}
+
+// Sleeps returns the delays slept so far, in order
+func (c *FakeClock) Sleeps() []time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]time.Duration(nil), c.sleeps...)
+}
+
+// Backoff strategies of BackoffPolicy
+const (
+	BackoffLinear      = "linear"      // Base, 2×Base, 3×Base, ...
+	BackoffExponential = "exponential" // Base, 2×Base, 4×Base, ...
+	BackoffConstant    = "constant"    // Base between every attempt
+)
+
+// BackoffPolicy sets the delay between validation retries. The delay a rate-limited
+// provider asks for in its Retry-After header takes precedence over the strategy, within
+// Max. The zero value is a linear backoff of one second per attempt.
+type BackoffPolicy struct {
+	Strategy string        `json:"strategy,omitempty"` // BackoffLinear (default), BackoffExponential or BackoffConstant
+	Base     time.Duration `json:"base,omitempty"`     // First delay, zero uses one second
+	Max      time.Duration `json:"max,omitempty"`      // Cap on every delay, zero = none
+
+	// Func, when set, replaces the strategy with a custom delay for the 0-based attempt
+	// that just failed with err
+	Func func(attempt int, err error) time.Duration `json:"-"`
+}
+
+// Delay returns the delay before retrying after the 0-based attempt failed with err
+func (p BackoffPolicy) Delay(attempt int, err error) time.Duration {
+	delay, ok := llm.RetryAfter(err)
+	base := p.Base
+	if base <= 0 {
+		base = time.Second
+	}
+	switch {
+	case ok:
+	case p.Func != nil:
+		delay = p.Func(attempt, err)
+	case p.Strategy == BackoffConstant:
+		delay = base
+	case p.Strategy == BackoffExponential:
+		delay = base << min(attempt, 30)
+	default:
+		delay = time.Duration(attempt+1) * base
+	}
+
+	if p.Max > 0 && delay > p.Max {
+		delay = p.Max
+	}
+	return delay
+}
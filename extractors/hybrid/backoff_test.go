@@ -0,0 +1,78 @@
+package hybrid
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestBackoffPolicy_Strategies(t *testing.T) {
+	err := errors.New("connection reset")
+	tests := []struct {
+		name     string
+		policy   BackoffPolicy
+		expected []time.Duration
+	}{
+		{"default linear", BackoffPolicy{}, []time.Duration{time.Second, 2 * time.Second, 3 * time.Second}},
+		{"exponential capped", BackoffPolicy{Strategy: BackoffExponential, Base: 100 * time.Millisecond, Max: 300 * time.Millisecond},
+			[]time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond}},
+		{"constant", BackoffPolicy{Strategy: BackoffConstant, Base: 50 * time.Millisecond},
+			[]time.Duration{50 * time.Millisecond, 50 * time.Millisecond, 50 * time.Millisecond}},
+		{"custom", BackoffPolicy{Func: func(attempt int, err error) time.Duration { return time.Duration(attempt) * time.Minute }},
+			[]time.Duration{0, time.Minute, 2 * time.Minute}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for attempt, expected := range tt.expected {
+				if delay := tt.policy.Delay(attempt, err); delay != expected {
+					t.Errorf("Delay(%d) = %v, expected %v", attempt, delay, expected)
+				}
+			}
+		})
+	}
+}
+
+func TestValidationRetry_FakeClock(t *testing.T) {
+	config := DefaultValidationConfig()
+	config.MaxRetries = 3
+	config.Backoff = BackoffPolicy{Strategy: BackoffExponential}
+	clock := NewFakeClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	config.Clock = clock
+
+	validator := &fakeValidator{err: errors.New("connection reset")}
+	extractor := newTestValidatedExtractor(config, validator)
+
+	start := time.Now()
+	if _, err := extractor.ExtractWithValidation("Email john@acme.com"); err != nil {
+		t.Fatalf("ExtractWithValidation() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected retries on the fake clock to be instant, took %v", elapsed)
+	}
+	if len(validator.validated) != 4 {
+		t.Errorf("Expected 4 attempts, got %d", len(validator.validated))
+	}
+	if sleeps := clock.Sleeps(); !slices.Equal(sleeps, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second}) {
+		t.Errorf("Unexpected backoff sleeps %v", sleeps)
+	}
+}
+
+func TestValidationBudget_MaxDurationFakeClock(t *testing.T) {
+	config := DefaultValidationConfig()
+	config.MaxRetries = 5
+	config.Budget = ValidationBudget{MaxDuration: 4 * time.Second}
+	clock := NewFakeClock(time.Time{})
+	config.Clock = clock
+
+	validator := &fakeValidator{err: errors.New("connection reset")}
+	extractor := newTestValidatedExtractor(config, validator)
+
+	if _, err := extractor.ExtractWithValidation("Email john@acme.com"); err != nil {
+		t.Fatalf("ExtractWithValidation() error = %v", err)
+	}
+	// 1s then 2s of backoff fit in the budget, a third 3s backoff would exceed it
+	if sleeps := clock.Sleeps(); !slices.Equal(sleeps, []time.Duration{time.Second, 2 * time.Second}) {
+		t.Errorf("Expected the budget to stop the backoffs, got %v", sleeps)
+	}
+}
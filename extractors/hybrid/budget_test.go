@@ -0,0 +1,251 @@
+package hybrid
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/intMeric/pii-extractor/extractors"
+	"github.com/intMeric/pii-extractor/extractors/llm"
+	"github.com/intMeric/pii-extractor/extractors/regex"
+	"github.com/intMeric/pii-extractor/pii"
+	"github.com/intMeric/pii-extractor/progress"
+)
+
+// fakeValidator records validated entities and marks every entity as valid, or fails
+// with err when set
+type fakeValidator struct {
+	validated []pii.PiiEntity
+	err       error
+}
+
+func (f *fakeValidator) ValidateEntity(ctx context.Context, entity pii.PiiEntity, context string) (*pii.ValidationResult, error) {
+	f.validated = append(f.validated, entity)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &pii.ValidationResult{Valid: true, Confidence: 0.9, Provider: "fake", Model: "fake"}, nil
+}
+
+func (f *fakeValidator) ValidateBatch(ctx context.Context, entities []pii.PiiEntity, contexts []string) ([]*pii.ValidationResult, error) {
+	results := make([]*pii.ValidationResult, len(entities))
+	for i, entity := range entities {
+		results[i], _ = f.ValidateEntity(ctx, entity, contexts[i])
+	}
+	return results, nil
+}
+
+func (f *fakeValidator) HealthCheck(ctx context.Context) error {
+	return nil
+}
+
+func (f *fakeValidator) GetProviderInfo() (string, string) {
+	return "fake", "fake"
+}
+
+func newTestValidatedExtractor(config *ValidationConfig, validator LLMValidator) *ValidatedExtractor {
+	config.Enabled = true
+	return &ValidatedExtractor{
+		name:          "validated-extractor",
+		baseExtractor: regex.NewDefaultExtractor(),
+		validator:     validator,
+		config:        config,
+	}
+}
+
+func TestValidationBudget_MaxCallsPrioritizesSeverity(t *testing.T) {
+	config := DefaultValidationConfig()
+	config.MaxRetries = 0
+	config.Budget = ValidationBudget{MaxCalls: 1}
+
+	validator := &fakeValidator{}
+	extractor := newTestValidatedExtractor(config, validator)
+
+	result, err := extractor.ExtractWithValidation("Email john@acme.com, SSN 123-45-6789")
+	if err != nil {
+		t.Fatalf("ExtractWithValidation() error = %v", err)
+	}
+
+	if len(validator.validated) != 1 {
+		t.Fatalf("Expected 1 validation call, got %d", len(validator.validated))
+	}
+	if validator.validated[0].Type != pii.PiiTypeSSN {
+		t.Errorf("Expected the SSN to be validated first, got %s", validator.validated[0].Type)
+	}
+
+	stats := result.ValidationStats
+	if stats.SkippedCount != result.Total-1 {
+		t.Errorf("Expected %d skipped entities, got %d", result.Total-1, stats.SkippedCount)
+	}
+	for _, skipped := range stats.Skipped {
+		if skipped.Reason != BudgetReasonMaxCalls {
+			t.Errorf("Expected max_calls reason, got %s", skipped.Reason)
+		}
+	}
+}
+
+func TestValidationBudget_Unlimited(t *testing.T) {
+	config := DefaultValidationConfig()
+	config.MaxRetries = 0
+
+	validator := &fakeValidator{}
+	extractor := newTestValidatedExtractor(config, validator)
+
+	result, err := extractor.ExtractWithValidation("Email john@acme.com, SSN 123-45-6789")
+	if err != nil {
+		t.Fatalf("ExtractWithValidation() error = %v", err)
+	}
+
+	if result.ValidationStats.SkippedCount != 0 {
+		t.Errorf("Expected no skipped entities, got %d", result.ValidationStats.SkippedCount)
+	}
+	if len(validator.validated) != result.Total {
+		t.Errorf("Expected %d validations, got %d", result.Total, len(validator.validated))
+	}
+}
+
+func TestValidationBudget_MaxTokens(t *testing.T) {
+	config := DefaultValidationConfig()
+	config.MaxRetries = 0
+	config.Budget = ValidationBudget{MaxTokens: 10}
+
+	validator := &fakeValidator{}
+	extractor := newTestValidatedExtractor(config, validator)
+
+	result, err := extractor.ExtractWithValidation("Email john@acme.com")
+	if err != nil {
+		t.Fatalf("ExtractWithValidation() error = %v", err)
+	}
+
+	if len(validator.validated) != 0 {
+		t.Errorf("Expected no validations within a 10 token budget, got %d", len(validator.validated))
+	}
+	if result.ValidationStats.SkippedCount != 1 || result.ValidationStats.Skipped[0].Reason != BudgetReasonMaxTokens {
+		t.Errorf("Expected one entity skipped for max_tokens, got %+v", result.ValidationStats.Skipped)
+	}
+}
+
+func TestValidationProgress(t *testing.T) {
+	config := DefaultValidationConfig()
+	config.MaxRetries = 0
+	config.Budget = ValidationBudget{MaxCalls: 1}
+	var updates []progress.Update
+	config.Progress = func(update progress.Update) {
+		updates = append(updates, update)
+	}
+
+	extractor := newTestValidatedExtractor(config, &fakeValidator{})
+	if _, err := extractor.ExtractWithValidation("Email john@acme.com, SSN 123-45-6789"); err != nil {
+		t.Fatalf("ExtractWithValidation() error = %v", err)
+	}
+
+	// Skipped entities count as processed too
+	if len(updates) != 2 || updates[1].Done != 2 || updates[1].Total != 2 || updates[1].Unit != progress.UnitEntities {
+		t.Errorf("Expected 2 entity updates, got %+v", updates)
+	}
+}
+
+func TestValidationRetry_PermanentErrorNotRetried(t *testing.T) {
+	config := DefaultValidationConfig()
+	config.MaxRetries = 3
+
+	validator := &fakeValidator{err: &llm.StatusError{StatusCode: http.StatusUnauthorized, Message: "invalid API key"}}
+	extractor := newTestValidatedExtractor(config, validator)
+
+	result, err := extractor.ExtractWithValidation("Email john@acme.com")
+	if err != nil {
+		t.Fatalf("ExtractWithValidation() error = %v", err)
+	}
+	if len(validator.validated) != result.Total {
+		t.Errorf("Expected one call per entity without retries, got %d calls for %d entities", len(validator.validated), result.Total)
+	}
+}
+
+func TestValidationRetry_CancellationInterruptsBackoff(t *testing.T) {
+	config := DefaultValidationConfig()
+	config.MaxRetries = 3
+	config.Timeout = 50 * time.Millisecond
+
+	validator := &fakeValidator{err: errors.New("connection reset")}
+	extractor := newTestValidatedExtractor(config, validator)
+
+	start := time.Now()
+	if _, err := extractor.ExtractWithValidation("Email john@acme.com, SSN 123-45-6789"); err != nil {
+		t.Fatalf("ExtractWithValidation() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Expected the timeout to interrupt the retry backoff, took %v", elapsed)
+	}
+	if len(validator.validated) != 1 {
+		t.Errorf("Expected the remaining entities to be abandoned after the timeout, got %d calls", len(validator.validated))
+	}
+}
+
+func TestRetryDelay_RetryAfter(t *testing.T) {
+	if delay := (BackoffPolicy{}).Delay(0, errors.New("connection reset")); delay != time.Second {
+		t.Errorf("Expected the linear backoff, got %v", delay)
+	}
+	err := &llm.StatusError{StatusCode: http.StatusTooManyRequests, RetryAfter: 5 * time.Second}
+	if delay := (BackoffPolicy{}).Delay(0, err); delay != 5*time.Second {
+		t.Errorf("Expected the Retry-After delay, got %v", delay)
+	}
+	if delay := (BackoffPolicy{Max: 2 * time.Second}).Delay(0, err); delay != 2*time.Second {
+		t.Errorf("Expected the Retry-After delay to be capped at Max, got %v", delay)
+	}
+}
+
+// slowExtractor is a base extractor ignoring cancellation
+type slowExtractor struct {
+	extractors.PiiExtractor
+	delay time.Duration
+}
+
+func (s slowExtractor) Extract(text string) (*pii.PiiExtractionResult, error) {
+	time.Sleep(s.delay)
+	return s.PiiExtractor.Extract(text)
+}
+
+func TestExtractWithValidation_BaseExtractionTimeout(t *testing.T) {
+	config := DefaultValidationConfig()
+	config.Timeout = 50 * time.Millisecond
+	extractor := newTestValidatedExtractor(config, &fakeValidator{})
+	extractor.baseExtractor = slowExtractor{PiiExtractor: regex.NewDefaultExtractor(), delay: time.Second}
+
+	start := time.Now()
+	_, err := extractor.ExtractWithValidation("Email john@acme.com")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the base extraction to time out, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Expected the timeout to abandon the base extraction, took %v", elapsed)
+	}
+}
+
+func TestExtractWithValidationContext_CallerCancellation(t *testing.T) {
+	validator := &fakeValidator{}
+	extractor := newTestValidatedExtractor(DefaultValidationConfig(), validator)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := extractor.ExtractWithValidationContext(ctx, "Email john@acme.com"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the caller's cancellation to reach the base extractor, got %v", err)
+	}
+	if len(validator.validated) != 0 {
+		t.Errorf("Expected no validation after cancellation, got %d calls", len(validator.validated))
+	}
+}
+
+func TestExtractSimpleContext(t *testing.T) {
+	v := &ValidatedExtractor{}
+	text := strings.Repeat("a", 150) + "john@acme.com" + strings.Repeat("b", 150)
+	want := strings.Repeat("a", 100) + "john@acme.com" + strings.Repeat("b", 100)
+	if got := v.extractSimpleContext(text, "john@acme.com"); got != want {
+		t.Errorf("Expected 100 characters around the value, got %q", got)
+	}
+	if got := v.extractSimpleContext("short john@acme.com", "john@acme.com"); got != "short john@acme.com" {
+		t.Errorf("Expected the context to stop at the text bounds, got %q", got)
+	}
+}
@@ -2,12 +2,19 @@ package hybrid
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/intMeric/pii-extractor/pii"
 	"github.com/intMeric/pii-extractor/extractors"
-	"github.com/teilomillet/gollm"
+	"github.com/intMeric/pii-extractor/extractors/llm"
+	"github.com/intMeric/pii-extractor/progress"
+	"github.com/intMeric/pii-extractor/telemetry"
 )
 
 // LLMProvider represents the different LLM providers available
@@ -28,12 +35,57 @@ type ValidationConfig struct {
 	Model           string                 `json:"model,omitempty"`
 	APIKey          string                 `json:"api_key,omitempty"`
 	BaseURL         string                 `json:"base_url,omitempty"`
-	Timeout         time.Duration          `json:"timeout"`
+	Timeout         time.Duration          `json:"timeout"` // Applies to the base extraction and to the validation, zero = none
 	MinConfidence   float64                `json:"min_confidence"`
 	MaxRetries      int                    `json:"max_retries"`
+	Backoff         BackoffPolicy          `json:"backoff,omitempty"`
 	ProviderOptions map[string]interface{} `json:"provider_options,omitempty"`
+	Budget          ValidationBudget       `json:"budget,omitempty"`
+
+	// HTTPClient sends the requests of validators, for custom TLS or proxies.
+	// Nil uses a default client configured by the tls_* provider options.
+	HTTPClient *http.Client `json:"-"`
+
+	// Cache answers repeated validation prompts without calling the provider, keeping
+	// responses for CacheTTL (zero never expires). Nil disables caching.
+	Cache    llm.ResponseCache `json:"-"`
+	CacheTTL time.Duration     `json:"cache_ttl,omitempty"`
+
+	// HealthTTL is how long a health check result is reused (zero uses llm.DefaultHealthTTL,
+	// negative checks the provider on every call)
+	HealthTTL time.Duration `json:"health_ttl,omitempty"`
+
+	// Progress is called after every entity validated or skipped, counting entities
+	Progress progress.Func `json:"-"`
+
+	// Clock measures the budget duration and waits between retries. Nil uses the wall
+	// clock; a FakeClock makes retries instant and deterministic in tests.
+	Clock Clock `json:"-"`
+
+	// Validator validates the entities instead of a validator built from Provider and
+	// Model, such as a validator shared by several extractors. Nil builds one.
+	Validator LLMValidator `json:"-"`
+}
+
+// ValidationBudget limits the validation work spent on a single document.
+// Zero values mean unlimited. When the budget runs out, the remaining entities
+// are left unvalidated and reported in ValidationStats.Skipped.
+type ValidationBudget struct {
+	MaxCalls    int           `json:"max_calls,omitempty"`    // Maximum LLM calls, retries included
+	MaxDuration time.Duration `json:"max_duration,omitempty"` // Maximum wall-clock time spent validating
+	MaxTokens   int           `json:"max_tokens,omitempty"`   // Maximum estimated prompt tokens
 }
 
+// Budget exhaustion reasons reported in ValidationStats.Skipped
+const (
+	BudgetReasonMaxCalls    = "max_calls"
+	BudgetReasonMaxDuration = "max_duration"
+	BudgetReasonMaxTokens   = "max_tokens"
+)
+
+// validationPromptOverhead approximates the size of the validation prompt template in characters
+const validationPromptOverhead = 1600
+
 // DefaultValidationConfig returns a default configuration for validation
 func DefaultValidationConfig() *ValidationConfig {
 	return &ValidationConfig{
@@ -62,10 +114,11 @@ type LLMValidator interface {
 	GetProviderInfo() (provider string, model string)
 }
 
-// LLMValidatorImpl implements the LLMValidator interface using gollm
+// LLMValidatorImpl implements the LLMValidator interface with an LLM client
 type LLMValidatorImpl struct {
-	llm    gollm.LLM
+	client llm.Client
 	config *ValidationConfig
+	health *llm.HealthCache
 }
 
 // NewLLMValidator creates a new LLM validator with the given configuration
@@ -74,93 +127,54 @@ func NewLLMValidator(config *ValidationConfig) (*LLMValidatorImpl, error) {
 		config = DefaultValidationConfig()
 	}
 
-	var options []gollm.ConfigOption
-
-	// Set provider-specific configuration
-	switch config.Provider {
-	case ProviderOpenAI:
-		options = append(options, gollm.SetProvider("openai"))
-		if config.Model != "" {
-			options = append(options, gollm.SetModel(config.Model))
-		} else {
-			options = append(options, gollm.SetModel("gpt-4o-mini"))
-		}
-		if config.APIKey != "" {
-			options = append(options, gollm.SetAPIKey(config.APIKey))
-		}
-
-	case ProviderMistral:
-		options = append(options, gollm.SetProvider("mistral"))
-		if config.Model != "" {
-			options = append(options, gollm.SetModel(config.Model))
-		} else {
-			options = append(options, gollm.SetModel("mistral-small-latest"))
-		}
-		if config.APIKey != "" {
-			options = append(options, gollm.SetAPIKey(config.APIKey))
-		}
-
-	case ProviderGemini:
-		options = append(options, gollm.SetProvider("googleai"))
-		if config.Model != "" {
-			options = append(options, gollm.SetModel(config.Model))
-		} else {
-			options = append(options, gollm.SetModel("gemini-1.5-flash"))
-		}
-		if config.APIKey != "" {
-			options = append(options, gollm.SetAPIKey(config.APIKey))
-		}
-
-	case ProviderOllama:
-		options = append(options, gollm.SetProvider("ollama"))
-		if config.Model != "" {
-			options = append(options, gollm.SetModel(config.Model))
-		} else {
-			options = append(options, gollm.SetModel("llama3.2"))
-		}
-
-	case ProviderAnthropic:
-		options = append(options, gollm.SetProvider("anthropic"))
-		if config.Model != "" {
-			options = append(options, gollm.SetModel(config.Model))
-		} else {
-			options = append(options, gollm.SetModel("claude-3-haiku-20240307"))
-		}
-		if config.APIKey != "" {
-			options = append(options, gollm.SetAPIKey(config.APIKey))
-		}
-	}
-
-	// Apply additional provider options like temperature, max tokens, etc.
-	if temp, ok := config.ProviderOptions["temperature"].(float64); ok {
-		options = append(options, gollm.SetTemperature(temp))
-	}
-	if maxTokens, ok := config.ProviderOptions["max_tokens"].(int); ok {
-		options = append(options, gollm.SetMaxTokens(maxTokens))
+	options, err := llm.ParseOptions(config.ProviderOptions)
+	if err != nil {
+		return nil, err
 	}
-
-	llm, err := gollm.NewLLM(options...)
+	client, err := llm.NewClient(llm.ClientConfig{
+		Provider:   llm.Provider(config.Provider),
+		Model:      config.Model,
+		APIKey:     config.APIKey,
+		BaseURL:    config.BaseURL,
+		HTTPClient: config.HTTPClient,
+		Options:    options,
+		Cache:      config.Cache,
+		CacheTTL:   config.CacheTTL,
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	return &LLMValidatorImpl{
-		llm:    llm,
+		client: client,
 		config: config,
+		health: llm.NewClientHealthCache(client, config.HealthTTL),
 	}, nil
 }
 
 // ValidateEntity validates a single PII entity using the configured LLM
 func (v *LLMValidatorImpl) ValidateEntity(ctx context.Context, entity pii.PiiEntity, context string) (*pii.ValidationResult, error) {
-	promptText := v.buildValidationPrompt(entity, context)
-	prompt := gollm.NewPrompt(promptText)
+	ctx, span := telemetry.StartSpan(ctx, telemetry.SpanValidationEntity,
+		telemetry.String("pii.type", entity.Type.String()),
+		telemetry.String("llm.provider", string(v.config.Provider)),
+		telemetry.String("llm.model", v.config.Model))
+	defer span.End()
 
-	response, err := v.llm.Generate(ctx, prompt)
+	prompt := v.buildValidationPrompt(entity, context)
+
+	response, err := v.client.Generate(ctx, prompt)
 	if err != nil {
+		span.RecordError(err)
 		return nil, err
 	}
 
-	return v.parseValidationResponse(response)
+	result, err := v.parseValidationResponse(response)
+	if err == nil {
+		span.SetAttributes(
+			telemetry.Bool("validation.valid", result.Valid),
+			telemetry.Float64("validation.confidence", result.Confidence))
+	}
+	return result, err
 }
 
 // ValidateBatch validates multiple entities in a single request for efficiency
@@ -182,11 +196,20 @@ func (v *LLMValidatorImpl) ValidateBatch(ctx context.Context, entities []pii.Pii
 	return results, nil
 }
 
-// HealthCheck verifies the LLM service is available
+// HealthCheck verifies the LLM service is available, reusing the result for HealthTTL
 func (v *LLMValidatorImpl) HealthCheck(ctx context.Context) error {
-	prompt := gollm.NewPrompt("Respond with 'OK'")
-	_, err := v.llm.Generate(ctx, prompt)
-	return err
+	return v.health.Check(ctx)
+}
+
+// Start refreshes the provider health in the background, so that HealthCheck answers
+// from the cache
+func (v *LLMValidatorImpl) Start(ctx context.Context) error {
+	return v.health.Start(ctx)
+}
+
+// Close stops the background health refresh
+func (v *LLMValidatorImpl) Close() error {
+	return v.health.Close()
 }
 
 // GetProviderInfo returns the provider and model information
@@ -211,7 +234,9 @@ func NewValidatedExtractor(baseExtractor extractors.PiiExtractor, config *Valida
 	var validator LLMValidator
 	var err error
 
-	if config.Enabled {
+	if config.Enabled && config.Validator != nil {
+		validator = config.Validator
+	} else if config.Enabled {
 		validator, err = NewLLMValidator(config)
 		if err != nil {
 			return nil, err
@@ -231,6 +256,11 @@ func (v *ValidatedExtractor) Extract(text string) (*pii.PiiExtractionResult, err
 	return v.baseExtractor.Extract(text)
 }
 
+// ExtractContext performs basic extraction without validation under the given context
+func (v *ValidatedExtractor) ExtractContext(ctx context.Context, text string) (*pii.PiiExtractionResult, error) {
+	return extractors.ExtractWithContext(ctx, v.baseExtractor, text)
+}
+
 // ExtractByType extracts specific PII types
 func (v *ValidatedExtractor) ExtractByType(text string, piiType pii.PiiType) ([]pii.PiiEntity, error) {
 	return v.baseExtractor.ExtractByType(text, piiType)
@@ -241,6 +271,11 @@ func (v *ValidatedExtractor) GetSupportedTypes() []pii.PiiType {
 	return v.baseExtractor.GetSupportedTypes()
 }
 
+// Support returns the support of the base extractor, attributed to the hybrid method
+func (v *ValidatedExtractor) Support() []extractors.Support {
+	return extractors.WithMethod(extractors.SupportOf(v.baseExtractor), extractors.MethodHybrid)
+}
+
 // GetMethod returns the extraction method
 func (v *ValidatedExtractor) GetMethod() extractors.ExtractionMethod {
 	return extractors.MethodHybrid
@@ -253,45 +288,94 @@ func (v *ValidatedExtractor) GetName() string {
 
 // ExtractWithValidation performs extraction with LLM validation
 func (v *ValidatedExtractor) ExtractWithValidation(text string) (*pii.PiiExtractionResult, error) {
-	config := v.config
-
-	// If validation is disabled, just do regular extraction
-	if !config.Enabled {
-		return v.baseExtractor.Extract(text)
-	}
+	return v.ExtractWithValidationContext(context.Background(), text)
+}
 
-	// Perform initial extraction
-	result, err := v.baseExtractor.Extract(text)
+// ExtractWithValidationContext performs extraction with LLM validation under the given
+// context. The base extraction and the validation each get the configured timeout, so an
+// LLM-based base extractor cannot hang the request.
+func (v *ValidatedExtractor) ExtractWithValidationContext(ctx context.Context, text string) (*pii.PiiExtractionResult, error) {
+	result, err := v.extractBase(ctx, text)
 	if err != nil {
 		return nil, err
 	}
+	return v.ValidateResult(ctx, text, result), nil
+}
+
+// ValidateResult validates the entities of a result extracted from text under the
+// configured timeout, as ExtractWithValidationContext does after the base extraction, and
+// returns it with its validation stats. The result is returned as is when validation is
+// disabled. The base extractor is not used, so an extractor created with a nil base
+// extractor validates the results of any extractor.
+func (v *ValidatedExtractor) ValidateResult(ctx context.Context, text string, result *pii.PiiExtractionResult) *pii.PiiExtractionResult {
+	config := v.config
 
-	// If no entities found, return early
-	if result.IsEmpty() {
-		return result, nil
+	// If validation is disabled or no entities were found, there is nothing to validate
+	if !config.Enabled || result.IsEmpty() {
+		return result
 	}
 
 	// Use the configured validator
 	validator := v.validator
 	if validator == nil {
 		// This shouldn't happen if validation is enabled, but handle gracefully
-		return result, nil
+		return result
 	}
 
 	// Validate entities
-	ctx, cancel := context.WithTimeout(context.Background(), config.Timeout)
+	ctx, cancel := withTimeout(ctx, config.Timeout)
 	defer cancel()
 
-	err = v.validateEntities(ctx, result, text, validator, config)
-	if err != nil {
-		// If validation fails, return unvalidated results
-		return result, nil
-	}
+	ctx, span := telemetry.StartSpan(ctx, telemetry.SpanValidationRun, telemetry.Int("pii.entities", result.Total))
+	defer span.End()
+
+	skipped := v.validateEntities(ctx, result, text, validator, config)
 
 	// Calculate validation statistics
 	v.calculateValidationStats(result, validator)
+	result.ValidationStats.Skipped = skipped
+	result.ValidationStats.SkippedCount = len(skipped)
+	span.SetAttributes(
+		telemetry.Int("validation.validated", result.ValidationStats.TotalValidated),
+		telemetry.Int("validation.skipped", len(skipped)))
 
-	return result, nil
+	return result
+}
+
+// extractBase runs the base extraction under the configured timeout. Base extractors that
+// do not take a context run in a goroutine that is abandoned when the timeout expires.
+func (v *ValidatedExtractor) extractBase(ctx context.Context, text string) (*pii.PiiExtractionResult, error) {
+	ctx, cancel := withTimeout(ctx, v.config.Timeout)
+	defer cancel()
+
+	if _, ok := v.baseExtractor.(extractors.ContextExtractor); ok {
+		return extractors.ExtractWithContext(ctx, v.baseExtractor, text)
+	}
+
+	type outcome struct {
+		result *pii.PiiExtractionResult
+		err    error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		result, err := v.baseExtractor.Extract(text)
+		done <- outcome{result, err}
+	}()
+	select {
+	case o := <-done:
+		return o.result, o.err
+	case <-ctx.Done():
+		return nil, fmt.Errorf("base extraction: %w", ctx.Err())
+	}
+}
+
+// withTimeout derives a context cancelled after timeout. A timeout that is not positive
+// sets no deadline.
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
 }
 
 // IsValidationEnabled returns true if LLM validation is enabled
@@ -307,6 +391,22 @@ func (v *ValidatedExtractor) HealthCheck(ctx context.Context) error {
 	return v.validator.HealthCheck(ctx)
 }
 
+// Start starts the validator's background health refresh, if it has one
+func (v *ValidatedExtractor) Start(ctx context.Context) error {
+	if starter, ok := v.validator.(extractors.Starter); ok {
+		return starter.Start(ctx)
+	}
+	return nil
+}
+
+// Close stops the validator's background health refresh, if it has one
+func (v *ValidatedExtractor) Close() error {
+	if closer, ok := v.validator.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
 // EnsembleExtractor combines multiple extraction methods
 type EnsembleExtractor struct {
 	name           string
@@ -350,6 +450,12 @@ func (e *EnsembleExtractor) WithStrategy(strategy CombinationStrategy) *Ensemble
 	return e
 }
 
+// WithExtractor adds a member extractor, such as one loaded from a plugin
+func (e *EnsembleExtractor) WithExtractor(extractor extractors.PiiExtractor) *EnsembleExtractor {
+	e.extractors = append(e.extractors, extractor)
+	return e
+}
+
 // WithValidation sets the validation mode
 func (e *EnsembleExtractor) WithValidation(mode ValidationMode) *EnsembleExtractor {
 	e.validationMode = mode
@@ -358,25 +464,64 @@ func (e *EnsembleExtractor) WithValidation(mode ValidationMode) *EnsembleExtract
 
 // Extract performs PII extraction using multiple methods and combines results
 func (e *EnsembleExtractor) Extract(text string) (*pii.PiiExtractionResult, error) {
+	return e.ExtractContext(context.Background(), text)
+}
+
+// ExtractContext performs ensemble extraction under the given context
+func (e *EnsembleExtractor) ExtractContext(ctx context.Context, text string) (*pii.PiiExtractionResult, error) {
 	if len(e.extractors) == 0 {
 		return nil, fmt.Errorf("no extractors configured")
 	}
 
+	ctx, span := telemetry.StartSpan(ctx, telemetry.SpanEnsembleExtract,
+		telemetry.String("ensemble.strategy", string(e.strategy)),
+		telemetry.Int("ensemble.members", len(e.extractors)))
+	defer span.End()
+
 	// Run all extractors
 	allResults := make([]*pii.PiiExtractionResult, len(e.extractors))
 	for i, extractor := range e.extractors {
-		result, err := extractor.Extract(text)
+		memberCtx, memberSpan := telemetry.StartSpan(ctx, telemetry.SpanEnsembleMember,
+			telemetry.String("extractor.name", extractor.GetName()),
+			telemetry.String("extractor.method", extractor.GetMethod().String()))
+		result, err := extractors.ExtractWithContext(memberCtx, extractor, text)
 		if err != nil {
 			// Continue with other extractors if one fails
+			memberSpan.RecordError(err)
+			memberSpan.End()
 			continue
 		}
+		memberSpan.SetAttributes(telemetry.Int("pii.entities", result.Total))
+		memberSpan.End()
 		allResults[i] = result
 	}
 
-	// Combine results based on strategy
-	combinedEntities := e.combineResults(allResults)
+	return e.Combine(ctx, allResults), nil
+}
 
-	return pii.NewPiiExtractionResult(combinedEntities), nil
+// Combine combines the results of the members with the strategy of the ensemble, skipping
+// nil results of failed members, and keeps their pattern set version and suppressed
+// candidates
+func (e *EnsembleExtractor) Combine(ctx context.Context, results []*pii.PiiExtractionResult) *pii.PiiExtractionResult {
+	_, combineSpan := telemetry.StartSpan(ctx, telemetry.SpanEnsembleCombine,
+		telemetry.String("ensemble.strategy", string(e.strategy)))
+	combinedEntities := e.combineResults(results)
+	combineSpan.SetAttributes(telemetry.Int("pii.entities", len(combinedEntities)))
+	combineSpan.End()
+
+	result := pii.NewPiiExtractionResult(combinedEntities)
+	for _, memberResult := range results {
+		if memberResult != nil && memberResult.PatternSetVersion != "" {
+			result.PatternSetVersion = memberResult.PatternSetVersion
+			break
+		}
+	}
+	for _, memberResult := range results {
+		if memberResult != nil {
+			result.Suppressed = append(result.Suppressed, memberResult.Suppressed...)
+		}
+	}
+	return result
 }
 
 // ExtractByType extracts specific PII types using ensemble approach
@@ -414,6 +559,11 @@ func (e *EnsembleExtractor) GetSupportedTypes() []pii.PiiType {
 	return types
 }
 
+// Support returns the merged support of all extractors, keeping the method of each
+func (e *EnsembleExtractor) Support() []extractors.Support {
+	return extractors.SupportMatrix(e.extractors...)
+}
+
 // GetMethod returns the extraction method
 func (e *EnsembleExtractor) GetMethod() extractors.ExtractionMethod {
 	return extractors.MethodHybrid
@@ -439,38 +589,118 @@ func (e *EnsembleExtractor) GetValidationMode() ValidationMode {
 	return e.validationMode
 }
 
+// HealthCheck verifies every ensemble member that depends on an external service
+func (e *EnsembleExtractor) HealthCheck(ctx context.Context) error {
+	var errs []error
+	for _, extractor := range e.extractors {
+		if checker, ok := extractor.(extractors.HealthChecker); ok {
+			if err := checker.HealthCheck(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", extractor.GetName(), err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // Private helper methods for ValidatedExtractor
 
-// validateEntities validates all entities in the result
-func (v *ValidatedExtractor) validateEntities(ctx context.Context, result *pii.PiiExtractionResult, originalText string, validator LLMValidator, config *ValidationConfig) error {
-	for i := range result.Entities {
+// validateEntities validates all entities in the result, highest severity first,
+// stopping once the configured budget is exhausted
+func (v *ValidatedExtractor) validateEntities(ctx context.Context, result *pii.PiiExtractionResult, originalText string, validator LLMValidator, config *ValidationConfig) []pii.SkippedValidation {
+	order := make([]int, len(result.Entities))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return result.Entities[order[a]].GetSeverity() > result.Entities[order[b]].GetSeverity()
+	})
+
+	budget := config.Budget
+	clock := config.Clock
+	if clock == nil {
+		clock = SystemClock()
+	}
+	start := clock.Now()
+	calls := 0
+	tokens := 0
+	tracker := progress.NewTracker("validate", progress.UnitEntities, int64(len(order)), config.Progress)
+
+	var skipped []pii.SkippedValidation
+	for _, i := range order {
 		entity := &result.Entities[i]
 
 		// Get context for this entity
 		context := v.getEntityContext(originalText, entity)
+		entityTokens := estimateTokens(entity.GetValue(), context)
+
+		if reason := budgetExhausted(budget, calls, tokens+entityTokens, clock.Now().Sub(start)); reason != "" {
+			skipped = append(skipped, pii.SkippedValidation{
+				Type:   entity.Type,
+				Value:  entity.GetValue(),
+				Reason: reason,
+			})
+			tracker.Add(1)
+			continue
+		}
 
 		// Validate with retries
 		var validation *pii.ValidationResult
 		var err error
 
 		for attempt := 0; attempt <= config.MaxRetries; attempt++ {
+			calls++
+			tokens += entityTokens
 			validation, err = validator.ValidateEntity(ctx, *entity, context)
 			if err == nil {
 				break
 			}
 
-			if attempt < config.MaxRetries {
-				time.Sleep(time.Duration(attempt+1) * time.Second)
+			// Permanent errors, such as an invalid API key, are not retried
+			if attempt == config.MaxRetries || !llm.IsRetryable(err) {
+				break
+			}
+			if budgetExhausted(budget, calls, tokens+entityTokens, clock.Now().Sub(start)) != "" {
+				break
+			}
+			delay := config.Backoff.Delay(attempt, err)
+			if budget.MaxDuration > 0 && clock.Now().Sub(start)+delay >= budget.MaxDuration {
+				break
 			}
+			if !clock.Sleep(ctx, delay) {
+				break
+			}
+		}
+		if ctx.Err() != nil {
+			break
 		}
 
 		// If validation succeeded and meets confidence threshold
 		if err == nil && validation.Confidence >= config.MinConfidence {
 			entity.Validation = validation
 		}
+		tracker.Add(1)
 	}
 
-	return nil
+	return skipped
+}
+
+// budgetExhausted returns the reason the budget does not allow another call, or an empty string
+func budgetExhausted(budget ValidationBudget, calls, tokens int, elapsed time.Duration) string {
+	if budget.MaxCalls > 0 && calls >= budget.MaxCalls {
+		return BudgetReasonMaxCalls
+	}
+	if budget.MaxDuration > 0 && elapsed >= budget.MaxDuration {
+		return BudgetReasonMaxDuration
+	}
+	if budget.MaxTokens > 0 && tokens > budget.MaxTokens {
+		return BudgetReasonMaxTokens
+	}
+	return ""
+}
+
+// estimateTokens roughly estimates the prompt tokens needed to validate a value (4 characters per token)
+func estimateTokens(value, context string) int {
+	return (validationPromptOverhead + len(value) + len(context)) / 4
 }
 
 // getEntityContext extracts context around the entity from the original text
@@ -491,32 +721,16 @@ func (v *ValidatedExtractor) getEntityContext(text string, entity *pii.PiiEntity
 	return v.extractSimpleContext(text, value)
 }
 
-// extractSimpleContext extracts context around a value in text
+// extractSimpleContext extracts the context around the first occurrence of a value in text
 func (v *ValidatedExtractor) extractSimpleContext(text, value string) string {
-	// Find the value in the text
-	start := -1
-	for i := 0; i <= len(text)-len(value); i++ {
-		if text[i:i+len(value)] == value {
-			start = i
-			break
-		}
-	}
-
+	start := strings.Index(text, value)
 	if start == -1 {
 		return text // Return full text if value not found
 	}
 
 	// Extract context around the value (100 characters before and after)
-	contextStart := start - 100
-	if contextStart < 0 {
-		contextStart = 0
-	}
-
-	contextEnd := start + len(value) + 100
-	if contextEnd > len(text) {
-		contextEnd = len(text)
-	}
-
+	contextStart := max(start-100, 0)
+	contextEnd := min(start+len(value)+100, len(text))
 	return text[contextStart:contextEnd]
 }
 
@@ -531,27 +745,7 @@ func (v *ValidatedExtractor) calculateValidationStats(result *pii.PiiExtractionR
 	stats.Provider = provider
 	stats.Model = model
 
-	var totalConfidence float64
-	validatedCount := 0
-
-	for _, entity := range result.Entities {
-		if entity.IsValidated() {
-			validatedCount++
-			totalConfidence += entity.GetValidationConfidence()
-
-			if entity.IsValid() {
-				stats.ValidCount++
-			} else {
-				stats.InvalidCount++
-			}
-		}
-	}
-
-	stats.TotalValidated = validatedCount
-	if validatedCount > 0 {
-		stats.AverageConfidence = totalConfidence / float64(validatedCount)
-	}
-
+	stats.Count(result.Entities)
 	result.ValidationStats = stats
 }
 
@@ -996,4 +1190,4 @@ func (v *LLMValidatorImpl) parseSimpleFloat(s string) float64 {
 	}
 	// Add more common values as needed
 	return 0.8 // Default
-}
\ No newline at end of file
+}
@@ -1,6 +1,8 @@
 package extractors
 
 import (
+	"context"
+
 	"github.com/intMeric/pii-extractor/pii"
 )
 
@@ -37,6 +39,37 @@ type PiiExtractor interface {
 	GetName() string
 }
 
+// ContextExtractor is implemented by extractors that accept a caller context,
+// used for trace propagation and cancellation
+type ContextExtractor interface {
+	// ExtractContext performs PII extraction on the given text under the given context
+	ExtractContext(ctx context.Context, text string) (*pii.PiiExtractionResult, error)
+}
+
+// ExtractWithContext runs extraction with ctx when the extractor supports it,
+// falling back to Extract otherwise
+func ExtractWithContext(ctx context.Context, extractor PiiExtractor, text string) (*pii.PiiExtractionResult, error) {
+	if contextExtractor, ok := extractor.(ContextExtractor); ok {
+		return contextExtractor.ExtractContext(ctx, text)
+	}
+	return extractor.Extract(text)
+}
+
+// AggregationMode controls how extraction results are accumulated
+type AggregationMode string
+
+const (
+	// AggregationNone returns every entity of every document (default)
+	AggregationNone AggregationMode = ""
+	// AggregationTopK keeps only the top-K values per type with occurrence counts and
+	// HyperLogLog distinct estimates across all documents, for memory-bounded corpus scans
+	AggregationTopK AggregationMode = "top_k"
+	// AggregationOccurrences returns one entity per occurrence instead of one per distinct
+	// value, annotated with its span (see pii.PiiExtractionResult.PerOccurrence), for
+	// pipelines emitting one event per occurrence
+	AggregationOccurrences AggregationMode = "occurrences"
+)
+
 // ExtractorConfig represents configuration options for extractors
 type ExtractorConfig struct {
 	// Method specifies the extraction method to use
@@ -45,9 +78,39 @@ type ExtractorConfig struct {
 	// Options contains method-specific configuration
 	Options map[string]any `json:"options,omitempty"`
 	
-	// Countries specifies which countries to extract PII for (empty = all)
-	Countries []string `json:"countries,omitempty"`
+	// Countries specifies which countries to extract PII for (empty = all). Legacy names
+	// such as "UK" or "France" are accepted and normalized to ISO 3166-1 codes.
+	Countries []pii.Country `json:"countries,omitempty"`
 	
 	// Types specifies which PII types to extract (empty = all)
 	Types []pii.PiiType `json:"types,omitempty"`
+	
+	// AggregationMode selects memory-bounded aggregation across documents. It applies to
+	// the regex extractor only: LLM, validated and ensemble extractors ignore it.
+	AggregationMode AggregationMode `json:"aggregation_mode,omitempty"`
+	
+	// TopK is the number of most frequent values kept per type in AggregationTopK mode (0 = pii.DefaultTopK), regex extractor only
+	TopK int `json:"top_k,omitempty"`
+	
+	// MaxContexts caps the contexts kept per entity with a deterministic sample (0 = all),
+	// regex extractor only
+	MaxContexts int `json:"max_contexts,omitempty"`
+	
+	// EntityIDSalt is the secret salt of the entity IDs (see pii.EntityID), for instance
+	// one per tenant (empty = unsalted). It is never serialized.
+	EntityIDSalt string `json:"-"`
+	
+	// DisplayMode sets how the String methods of the extracted entities print their values
+	// (pii.DisplayMasked by default), regex and LLM extractors
+	DisplayMode pii.DisplayMode `json:"display_mode,omitempty"`
+	
+	// InternalEmailDomains lists corporate domains whose email addresses are reported as internal
+	InternalEmailDomains []string `json:"internal_email_domains,omitempty"`
+	
+	// RetentionPolicy attaches retention hints to every extracted entity when set
+	RetentionPolicy pii.RetentionPolicy `json:"retention_policy,omitempty"`
+	
+	// PatternSetVersion pins the regex detection rules to an earlier pattern set version
+	// (empty = current). Extraction fails if the pinned version cannot be reproduced.
+	PatternSetVersion string `json:"pattern_set_version,omitempty"`
 }
\ No newline at end of file
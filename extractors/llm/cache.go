@@ -0,0 +1,264 @@
+package llm
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ResponseCache stores LLM responses by key, so that unchanged documents re-scanned by
+// scheduled pipelines do not pay for inference again
+type ResponseCache interface {
+	// Get returns the unexpired response stored under key
+	Get(key string) (string, bool)
+
+	// Set stores a response under key. A zero ttl never expires.
+	Set(key, response string, ttl time.Duration)
+}
+
+// Cache options of the LLM extractor, read from ExtractorConfig.Options
+const (
+	OptionCache    = "cache"     // ResponseCache
+	OptionCacheTTL = "cache_ttl" // time.Duration, or a duration string such as "24h"
+)
+
+// CacheKey returns the cache key of a prompt sent to a model: the hex SHA-256 of both,
+// so that prompts (and the PII they contain) are not stored in clear as keys
+func CacheKey(model, prompt string) string {
+	hash := sha256.New()
+	hash.Write([]byte(model))
+	hash.Write([]byte{0})
+	hash.Write([]byte(prompt))
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// CachedClient answers repeated prompts from a response cache and only calls the
+// wrapped client on misses. Errors are never cached.
+type CachedClient struct {
+	client Client
+	cache  ResponseCache
+	model  string
+	ttl    time.Duration
+}
+
+// NewCachedClient wraps client with a response cache. model namespaces the keys, such
+// as "openai/gpt-4o-mini", so that a model change does not reuse stale responses.
+func NewCachedClient(client Client, cache ResponseCache, model string, ttl time.Duration) *CachedClient {
+	return &CachedClient{client: client, cache: cache, model: model, ttl: ttl}
+}
+
+// Generate implements Client
+func (c *CachedClient) Generate(ctx context.Context, prompt string) (string, error) {
+	key := CacheKey(c.model, prompt)
+	if response, ok := c.cache.Get(key); ok {
+		return response, nil
+	}
+	response, err := c.client.Generate(ctx, prompt)
+	if err != nil {
+		return "", err
+	}
+	c.cache.Set(key, response, c.ttl)
+	return response, nil
+}
+
+// Uncached returns the client a CachedClient wraps, or client itself when it is not
+// cached. Health checks use it, since a cached answer says nothing about the provider.
+func Uncached(client Client) Client {
+	if cached, ok := client.(*CachedClient); ok {
+		return cached.client
+	}
+	return client
+}
+
+// MemoryCache is an in-process ResponseCache evicting the least recently used entries
+type MemoryCache struct {
+	maxEntries int
+	entries    map[string]*list.Element
+	order      *list.List
+	now        func() time.Time
+	mu         sync.Mutex
+}
+
+type memoryEntry struct {
+	key      string
+	response string
+	expires  time.Time
+}
+
+// NewMemoryCache creates a memory cache holding at most maxEntries responses (zero or
+// less is unbounded)
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+		now:        time.Now,
+	}
+}
+
+// Get implements ResponseCache
+func (c *MemoryCache) Get(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return "", false
+	}
+	entry := element.Value.(*memoryEntry)
+	if !entry.expires.IsZero() && !c.now().Before(entry.expires) {
+		c.order.Remove(element)
+		delete(c.entries, key)
+		return "", false
+	}
+	c.order.MoveToFront(element)
+	return entry.response, true
+}
+
+// Set implements ResponseCache
+func (c *MemoryCache) Set(key, response string, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &memoryEntry{key: key, response: response}
+	if ttl > 0 {
+		entry.expires = c.now().Add(ttl)
+	}
+	if element, ok := c.entries[key]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.order.PushFront(entry)
+	if c.maxEntries > 0 && c.order.Len() > c.maxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*memoryEntry).key)
+	}
+}
+
+// Len returns the number of cached responses, expired ones included until accessed
+func (c *MemoryCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// DirCache is a ResponseCache storing one file per response in a directory, shared by
+// successive runs of a pipeline. Responses may contain PII from the prompts: keep the
+// directory as protected as the scanned data.
+type DirCache struct {
+	dir string
+	now func() time.Time
+}
+
+type dirEntry struct {
+	Response string    `json:"response"`
+	Expires  time.Time `json:"expires"`
+}
+
+// NewDirCache creates a directory cache, creating the directory if needed
+func NewDirCache(dir string) (*DirCache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &DirCache{dir: dir, now: time.Now}, nil
+}
+
+// Get implements ResponseCache. Unreadable entries are treated as misses.
+func (c *DirCache) Get(key string) (string, bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return "", false
+	}
+	var entry dirEntry
+	if json.Unmarshal(data, &entry) != nil {
+		return "", false
+	}
+	if !entry.Expires.IsZero() && !c.now().Before(entry.Expires) {
+		os.Remove(c.path(key))
+		return "", false
+	}
+	return entry.Response, true
+}
+
+// Set implements ResponseCache. Write failures are ignored: the response is simply
+// generated again next time.
+func (c *DirCache) Set(key, response string, ttl time.Duration) {
+	entry := dirEntry{Response: response}
+	if ttl > 0 {
+		entry.Expires = c.now().Add(ttl)
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	// Write then rename so that concurrent runs never read a partial entry
+	file, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		return
+	}
+	_, err = file.Write(data)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), c.path(key))
+	}
+	if err != nil {
+		os.Remove(file.Name())
+	}
+}
+
+// Prune removes the expired entries and returns how many were removed
+func (c *DirCache) Prune() (int, error) {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return 0, err
+	}
+	removed := 0
+	for _, file := range entries {
+		key, ok := strings.CutSuffix(file.Name(), ".json")
+		if !ok || file.IsDir() {
+			continue
+		}
+		if _, ok := c.Get(key); !ok {
+			// Get removes expired entries, so a missing file was pruned
+			if _, err := os.Stat(c.path(key)); errors.Is(err, fs.ErrNotExist) {
+				removed++
+			}
+		}
+	}
+	return removed, nil
+}
+
+func (c *DirCache) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+// cacheOptions reads the cache options of an extractor configuration
+func cacheOptions(options map[string]any) (ResponseCache, time.Duration, error) {
+	var cache ResponseCache
+	if value, ok := options[OptionCache]; ok && value != nil {
+		if cache, ok = value.(ResponseCache); !ok {
+			return nil, 0, fmt.Errorf("provider option %q: expected an llm.ResponseCache, got %T", OptionCache, value)
+		}
+	}
+
+	ttl, err := durationOption(options, OptionCacheTTL)
+	if err != nil {
+		return nil, 0, err
+	}
+	return cache, ttl, nil
+}
@@ -0,0 +1,172 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/intMeric/pii-extractor/extractors"
+)
+
+// countingClient counts the prompts it receives
+type countingClient struct {
+	calls int
+	err   error
+}
+
+func (c *countingClient) Generate(ctx context.Context, prompt string) (string, error) {
+	c.calls++
+	if c.err != nil {
+		return "", c.err
+	}
+	return "response to " + prompt, nil
+}
+
+func TestCachedClient(t *testing.T) {
+	inner := &countingClient{}
+	cache := NewMemoryCache(0)
+	client := NewCachedClient(inner, cache, "openai/gpt-4o-mini", time.Hour)
+
+	for range 3 {
+		response, err := client.Generate(context.Background(), "prompt")
+		if err != nil || response != "response to prompt" {
+			t.Fatalf("Generate() = %q, %v", response, err)
+		}
+	}
+	if inner.calls != 1 {
+		t.Errorf("Expected one provider call for a repeated prompt, got %d", inner.calls)
+	}
+
+	// Another model must not reuse the responses
+	other := NewCachedClient(inner, cache, "openai/gpt-4o", time.Hour)
+	if _, err := other.Generate(context.Background(), "prompt"); err != nil || inner.calls != 2 {
+		t.Errorf("Expected a miss for another model, got %d calls (%v)", inner.calls, err)
+	}
+
+	inner.err = errors.New("rate limited")
+	for range 2 {
+		if _, err := client.Generate(context.Background(), "failing"); err == nil {
+			t.Fatal("Expected the client error")
+		}
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Expected errors not to be cached, got %d entries", cache.Len())
+	}
+}
+
+func TestMemoryCache(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := NewMemoryCache(2)
+	cache.now = func() time.Time { return now }
+
+	cache.Set("a", "1", time.Minute)
+	cache.Set("b", "2", 0)
+	cache.Get("a")
+	cache.Set("c", "3", 0)
+	if _, ok := cache.Get("b"); ok {
+		t.Error("Expected the least recently used entry to be evicted")
+	}
+
+	now = now.Add(time.Minute)
+	if _, ok := cache.Get("a"); ok {
+		t.Error("Expected the entry to expire after its TTL")
+	}
+	if response, ok := cache.Get("c"); !ok || response != "3" {
+		t.Errorf("Expected an entry without TTL to be kept, got %q", response)
+	}
+}
+
+func TestDirCache(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache, err := NewDirCache(dir)
+	if err != nil {
+		t.Fatalf("NewDirCache() error = %v", err)
+	}
+	cache.now = func() time.Time { return now }
+
+	key := CacheKey("ollama/llama3.2", "prompt")
+	cache.Set(key, "response", time.Hour)
+	cache.Set(CacheKey("ollama/llama3.2", "other"), "kept", 0)
+
+	// A later run reads the entries written by a previous one
+	reopened, err := NewDirCache(dir)
+	if err != nil {
+		t.Fatalf("NewDirCache() error = %v", err)
+	}
+	reopened.now = cache.now
+	if response, ok := reopened.Get(key); !ok || response != "response" {
+		t.Errorf("Get() = %q, %v", response, ok)
+	}
+
+	now = now.Add(2 * time.Hour)
+	if removed, err := reopened.Prune(); err != nil || removed != 1 {
+		t.Errorf("Prune() = %d, %v, expected 1 expired entry", removed, err)
+	}
+	if _, ok := reopened.Get(key); ok {
+		t.Error("Expected the expired entry to be pruned")
+	}
+}
+
+func TestNewExtractorCacheOptions(t *testing.T) {
+	inner := &fakeClient{response: "[]"}
+	SetClientFactory(func(config ClientConfig) (Client, error) {
+		return inner, nil
+	})
+	defer SetClientFactory(nil)
+
+	cache := NewMemoryCache(0)
+	extractor, err := NewExtractor(ProviderOpenAI, "", &extractors.ExtractorConfig{Options: map[string]any{
+		OptionCache:    cache,
+		OptionCacheTTL: "24h",
+	}})
+	if err != nil {
+		t.Fatalf("NewExtractor() error = %v", err)
+	}
+	if _, ok := extractor.client.(*CachedClient); !ok {
+		t.Fatalf("Expected a cached client, got %T", extractor.client)
+	}
+	if _, err := extractor.Extract("nothing here"); err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if cache.Len() != 1 {
+		t.Errorf("Expected the response to be cached, got %d entries", cache.Len())
+	}
+
+	if _, err := NewExtractor(ProviderOpenAI, "", &extractors.ExtractorConfig{Options: map[string]any{OptionCache: "memory"}}); err == nil {
+		t.Error("Expected an error for a cache option that is not a ResponseCache")
+	}
+}
+
+func TestHealthChecksBypassCache(t *testing.T) {
+	inner := &countingClient{}
+	cache := NewMemoryCache(0)
+	health := NewClientHealthCache(NewCachedClient(inner, cache, "openai/gpt-4o-mini", 0), -1)
+
+	if err := health.Check(context.Background()); err != nil {
+		t.Fatalf("Expected a healthy provider, got %v", err)
+	}
+	inner.err = errors.New("provider down")
+	if err := health.Check(context.Background()); err == nil {
+		t.Error("Expected the dead provider to be reported despite the cached health prompt")
+	}
+	if cache.Len() != 0 {
+		t.Errorf("Expected health checks not to be cached, got %d entries", cache.Len())
+	}
+
+	SetClientFactory(func(config ClientConfig) (Client, error) {
+		return inner, nil
+	})
+	defer SetClientFactory(nil)
+	extractor, err := NewExtractor(ProviderOpenAI, "", &extractors.ExtractorConfig{Options: map[string]any{
+		OptionCache:     cache,
+		OptionHealthTTL: time.Duration(-1),
+	}})
+	if err != nil {
+		t.Fatalf("NewExtractor() error = %v", err)
+	}
+	if err := extractor.HealthCheck(context.Background()); err == nil {
+		t.Error("Expected the extractor health check to reach the provider")
+	}
+}
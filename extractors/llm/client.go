@@ -0,0 +1,124 @@
+package llm
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Client sends prompts to an LLM provider. The LLM extractor and validator create their
+// clients through the installed ClientFactory, so that the core library does not depend
+// on any provider SDK: install an adapter such as the extractors/llm/gollm module.
+type Client interface {
+	// Generate returns the completion of prompt
+	Generate(ctx context.Context, prompt string) (string, error)
+}
+
+// ClientConfig configures a provider client
+type ClientConfig struct {
+	Provider Provider
+	Model    string // filled with the provider's default model when empty
+	APIKey   string
+
+	// BaseURL overrides the provider endpoint, for OpenAI-compatible gateways, Azure
+	// OpenAI deployments, Anthropic proxies or remote Ollama hosts
+	BaseURL string
+
+	// HTTPClient sends the requests of the clients, replacing the TLS options. Nil uses
+	// a default client (see NewHTTPClient).
+	HTTPClient *http.Client
+
+	Options Options
+
+	// Cache answers repeated prompts to the same model without calling the provider,
+	// keeping responses for CacheTTL (zero never expires). Nil disables caching.
+	Cache    ResponseCache
+	CacheTTL time.Duration
+}
+
+// NewHTTPClient returns the HTTP client of a configuration: its HTTPClient, else a client
+// configured by the TLS options, else http.DefaultClient
+func NewHTTPClient(config ClientConfig) (*http.Client, error) {
+	if config.HTTPClient != nil {
+		return config.HTTPClient, nil
+	}
+	tlsConfig, err := config.Options.TLS.Config()
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig == nil {
+		return http.DefaultClient, nil
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &http.Client{Transport: transport}, nil
+}
+
+// ClientFactory creates a client for a configuration
+type ClientFactory func(config ClientConfig) (Client, error)
+
+// DefaultModels are the models used when none is configured, by supported provider
+var DefaultModels = map[Provider]string{
+	ProviderOpenAI:    "gpt-4o-mini",
+	ProviderMistral:   "mistral-small-latest",
+	ProviderGemini:    "gemini-1.5-flash",
+	ProviderOllama:    "llama3.2",
+	ProviderAnthropic: "claude-3-haiku-20240307",
+}
+
+// ErrNoClientFactory is returned when creating a client before an adapter was installed
+var ErrNoClientFactory = errors.New("no LLM client installed: install an adapter such as github.com/intMeric/pii-extractor/extractors/llm/gollm")
+
+var (
+	clientFactory ClientFactory
+	mu            sync.RWMutex
+)
+
+// SetClientFactory installs the factory creating the clients of LLM extractors and
+// validators. Passing nil uninstalls it.
+func SetClientFactory(factory ClientFactory) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	clientFactory = factory
+}
+
+// NewClient creates a client with the installed factory. Ollama clients are created by
+// NewOllamaClient, with or without an installed factory. Clients are wrapped in a
+// CachedClient when the configuration has a cache.
+func NewClient(config ClientConfig) (Client, error) {
+	client, err := newClient(config)
+	if err != nil || config.Cache == nil {
+		return client, err
+	}
+	model := string(config.Provider) + "/" + cmp.Or(config.Model, DefaultModels[config.Provider])
+	return NewCachedClient(client, config.Cache, model, config.CacheTTL), nil
+}
+
+// newClient creates the uncached client of a configuration
+func newClient(config ClientConfig) (Client, error) {
+	defaultModel, ok := DefaultModels[config.Provider]
+	if !ok {
+		return nil, fmt.Errorf("unsupported provider: %s", config.Provider)
+	}
+	if config.Model == "" {
+		config.Model = defaultModel
+	}
+
+	if config.Provider == ProviderOllama {
+		return NewOllamaClient(config)
+	}
+
+	mu.RLock()
+	factory := clientFactory
+	mu.RUnlock()
+
+	if factory == nil {
+		return nil, ErrNoClientFactory
+	}
+	return factory(config)
+}
@@ -0,0 +1,53 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// fakeClient answers every prompt with a fixed response
+type fakeClient struct {
+	config   ClientConfig
+	response string
+}
+
+func (f *fakeClient) Generate(ctx context.Context, prompt string) (string, error) {
+	return f.response, nil
+}
+
+func TestNewClient(t *testing.T) {
+	SetClientFactory(nil)
+	if _, err := NewClient(ClientConfig{Provider: ProviderOpenAI}); !errors.Is(err, ErrNoClientFactory) {
+		t.Errorf("Expected ErrNoClientFactory without an adapter, got %v", err)
+	}
+
+	client := &fakeClient{response: `[{"type": "email", "value": "john@acme.com"}]`}
+	SetClientFactory(func(config ClientConfig) (Client, error) {
+		client.config = config
+		return client, nil
+	})
+	defer SetClientFactory(nil)
+
+	if _, err := NewClient(ClientConfig{Provider: ProviderAzureAI}); err == nil {
+		t.Error("Expected an error for an unsupported provider")
+	}
+
+	extractor, err := NewExtractor(ProviderMistral, "", nil)
+	if err != nil {
+		t.Fatalf("NewExtractor() error = %v", err)
+	}
+	if client.config.Model != "mistral-small-latest" || client.config.Options.MaxTokens != 2048 {
+		t.Errorf("Expected the default model and generation options, got %+v", client.config)
+	}
+
+	result, err := extractor.Extract("Write to john@acme.com")
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if !result.HasType(pii.PiiTypeEmail) {
+		t.Errorf("Expected the email from the client response, got %+v", result.Entities)
+	}
+}
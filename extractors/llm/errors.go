@@ -0,0 +1,92 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StatusError is returned when a provider answers with an HTTP error status
+type StatusError struct {
+	StatusCode int
+	Message    string
+
+	// RetryAfter is the delay requested by the provider's Retry-After header, zero
+	// when absent
+	RetryAfter time.Duration
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("provider returned status %d: %s", e.StatusCode, e.Message)
+}
+
+// Retryable reports whether the request may succeed later: timeouts, conflicts, rate
+// limits and server errors are retryable, while invalid requests, API keys or models are
+// permanent
+func (e *StatusError) Retryable() bool {
+	switch e.StatusCode {
+	case http.StatusRequestTimeout, http.StatusConflict, http.StatusTooEarly, http.StatusTooManyRequests:
+		return true
+	}
+	return e.StatusCode >= 500
+}
+
+// maxErrorMessage caps the response body kept in StatusError messages
+const maxErrorMessage = 512
+
+// NewStatusError creates the error of a response with an error status, reading its
+// Retry-After header. Long messages are truncated.
+func NewStatusError(resp *http.Response, message string) *StatusError {
+	if len(message) > maxErrorMessage {
+		message = message[:maxErrorMessage] + "..."
+	}
+	return &StatusError{
+		StatusCode: resp.StatusCode,
+		Message:    message,
+		RetryAfter: ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+	}
+}
+
+// ParseRetryAfter parses a Retry-After header, in seconds or as an HTTP date. It returns
+// zero for an empty, invalid or past value.
+func ParseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0)
+	}
+	return 0
+}
+
+// IsRetryable reports whether a failed call may succeed when retried. Errors implementing
+// Retryable() bool, such as StatusError, decide for themselves; canceled contexts, missing
+// client factories and unsupported providers are permanent; other errors, such as network
+// failures, are retryable.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrNoClientFactory) {
+		return false
+	}
+	var retryable interface{ Retryable() bool }
+	if errors.As(err, &retryable) {
+		return retryable.Retryable()
+	}
+	return true
+}
+
+// RetryAfter returns the delay a provider asked to wait before retrying, if any
+func RetryAfter(err error) (time.Duration, bool) {
+	var status *StatusError
+	if errors.As(err, &status) && status.RetryAfter > 0 {
+		return status.RetryAfter, true
+	}
+	return 0, false
+}
@@ -0,0 +1,119 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"unauthorized", &StatusError{StatusCode: http.StatusUnauthorized}, false},
+		{"bad request", &StatusError{StatusCode: http.StatusBadRequest}, false},
+		{"not found", &StatusError{StatusCode: http.StatusNotFound}, false},
+		{"rate limited", &StatusError{StatusCode: http.StatusTooManyRequests}, true},
+		{"server error", &StatusError{StatusCode: http.StatusBadGateway}, true},
+		{"wrapped", fmt.Errorf("validation: %w", &StatusError{StatusCode: http.StatusForbidden}), false},
+		{"canceled", context.Canceled, false},
+		{"deadline", fmt.Errorf("request: %w", context.DeadlineExceeded), false},
+		{"no factory", ErrNoClientFactory, false},
+		{"network", errors.New("connection refused"), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryable(tt.err); got != tt.want {
+				t.Errorf("IsRetryable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"30", 30 * time.Second},
+		{"-5", 0},
+		{"soon", 0},
+		{now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0},
+	}
+	for _, tt := range tests {
+		if got := ParseRetryAfter(tt.value, now); got != tt.want {
+			t.Errorf("ParseRetryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+
+	if _, ok := RetryAfter(errors.New("timeout")); ok {
+		t.Error("Expected no delay for errors without Retry-After")
+	}
+}
+
+// flakyClient fails with the given errors before answering
+type flakyClient struct {
+	errs  []error
+	calls int
+}
+
+func (c *flakyClient) Generate(ctx context.Context, prompt string) (string, error) {
+	c.calls++
+	if c.calls <= len(c.errs) {
+		return "", c.errs[c.calls-1]
+	}
+	return "[]", nil
+}
+
+func TestExtractorRetries(t *testing.T) {
+	rateLimited := &StatusError{StatusCode: http.StatusTooManyRequests, RetryAfter: time.Millisecond}
+	tests := []struct {
+		name      string
+		errs      []error
+		wantCalls int
+		wantErr   bool
+	}{
+		{"succeeds", nil, 1, false},
+		{"retries rate limits", []error{rateLimited, rateLimited}, 3, false},
+		{"gives up after the attempts", []error{rateLimited, rateLimited, rateLimited, rateLimited}, 4, true},
+		{"permanent error", []error{&StatusError{StatusCode: http.StatusUnauthorized}}, 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &flakyClient{errs: tt.errs}
+			extractor := &LLMExtractor{client: client, config: LLMConfig{RetryAttempts: 3, Timeout: 30}}
+
+			_, err := extractor.generate(context.Background(), "prompt")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("generate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if client.calls != tt.wantCalls {
+				t.Errorf("Expected %d calls, got %d", tt.wantCalls, client.calls)
+			}
+		})
+	}
+}
+
+func TestExtractorRetries_Cancellation(t *testing.T) {
+	client := &flakyClient{errs: []error{&StatusError{StatusCode: http.StatusServiceUnavailable, RetryAfter: time.Minute}}}
+	extractor := &LLMExtractor{client: client, config: LLMConfig{RetryAttempts: 3, Timeout: 30}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := extractor.generate(ctx, "prompt"); err == nil {
+		t.Error("Expected the cancellation to interrupt the retries")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected the context to interrupt the retry delay, took %v", elapsed)
+	}
+}
@@ -3,9 +3,10 @@ package llm
 import (
 	"context"
 	"fmt"
+	"time"
 	"github.com/intMeric/pii-extractor/pii"
 	"github.com/intMeric/pii-extractor/extractors"
-	"github.com/teilomillet/gollm"
+	"github.com/intMeric/pii-extractor/telemetry"
 )
 
 // Provider represents an LLM provider
@@ -29,7 +30,16 @@ type LLMExtractor struct {
 	apiKey   string
 	baseURL  string
 	config   LLMConfig
-	llm      gollm.LLM
+	client   Client
+	health   *HealthCache
+
+	entityIDSalt string // salt of the entity IDs
+	displayMode  pii.DisplayMode
+
+	// Few-shot examples added to the prompts, nil for none
+	examples     *ExampleLibrary
+	language     string
+	fewShotCount int
 }
 
 // LLMConfig contains LLM-specific configuration
@@ -67,90 +77,77 @@ func NewExtractor(provider Provider, model string, config *extractors.ExtractorC
 		}
 	}
 	
-	// Initialize gollm LLM
-	var options []gollm.ConfigOption
-	
-	// Set provider-specific configuration
-	switch provider {
-	case ProviderOpenAI:
-		options = append(options, gollm.SetProvider("openai"))
-		if model != "" {
-			options = append(options, gollm.SetModel(model))
-		} else {
-			options = append(options, gollm.SetModel("gpt-4o-mini"))
-		}
-		if extractor.apiKey != "" {
-			options = append(options, gollm.SetAPIKey(extractor.apiKey))
-		}
-
-	case ProviderMistral:
-		options = append(options, gollm.SetProvider("mistral"))
-		if model != "" {
-			options = append(options, gollm.SetModel(model))
-		} else {
-			options = append(options, gollm.SetModel("mistral-small-latest"))
-		}
-		if extractor.apiKey != "" {
-			options = append(options, gollm.SetAPIKey(extractor.apiKey))
-		}
-
-	case ProviderGemini:
-		options = append(options, gollm.SetProvider("googleai"))
-		if model != "" {
-			options = append(options, gollm.SetModel(model))
-		} else {
-			options = append(options, gollm.SetModel("gemini-1.5-flash"))
-		}
-		if extractor.apiKey != "" {
-			options = append(options, gollm.SetAPIKey(extractor.apiKey))
-		}
-
-	case ProviderOllama:
-		options = append(options, gollm.SetProvider("ollama"))
-		if model != "" {
-			options = append(options, gollm.SetModel(model))
-		} else {
-			options = append(options, gollm.SetModel("llama3.2"))
-		}
-
-	case ProviderAnthropic:
-		options = append(options, gollm.SetProvider("anthropic"))
-		if model != "" {
-			options = append(options, gollm.SetModel(model))
-		} else {
-			options = append(options, gollm.SetModel("claude-3-haiku-20240307"))
-		}
-		if extractor.apiKey != "" {
-			options = append(options, gollm.SetAPIKey(extractor.apiKey))
+	if config != nil {
+		if err := extractor.setFewShotOptions(config.Options); err != nil {
+			return nil, err
 		}
+		extractor.entityIDSalt = config.EntityIDSalt
+		extractor.displayMode = config.DisplayMode
+	}
 	
-	default:
-		return nil, fmt.Errorf("unsupported provider: %s", provider)
+	// Other options (top_p, stop, organization, headers, ...) are read as provider options
+	var options Options
+	if config != nil {
+		var err error
+		if options, err = ParseOptions(config.Options); err != nil {
+			return nil, err
+		}
+	}
+	if options.Temperature == nil {
+		temperature := float64(extractor.config.Temperature)
+		options.Temperature = &temperature
+	}
+	if options.MaxTokens == 0 {
+		options.MaxTokens = extractor.config.MaxTokens
 	}
 	
-	// Apply LLM configuration
-	options = append(options, gollm.SetTemperature(float64(extractor.config.Temperature)))
-	options = append(options, gollm.SetMaxTokens(extractor.config.MaxTokens))
+	clientConfig := ClientConfig{
+		Provider: provider,
+		Model:    model,
+		APIKey:   extractor.apiKey,
+		BaseURL:  extractor.baseURL,
+		Options:  options,
+	}
+	if config != nil {
+		var err error
+		if clientConfig.Cache, clientConfig.CacheTTL, err = cacheOptions(config.Options); err != nil {
+			return nil, err
+		}
+	}
 	
-	llm, err := gollm.NewLLM(options...)
+	client, err := NewClient(clientConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize LLM: %w", err)
 	}
 	
-	extractor.llm = llm
+	var healthTTL time.Duration
+	if config != nil {
+		if healthTTL, err = durationOption(config.Options, OptionHealthTTL); err != nil {
+			return nil, err
+		}
+	}
+	
+	extractor.client = client
+	extractor.health = NewHealthCache(func(ctx context.Context) error {
+		// Health checks bypass the response cache, which would answer for a dead provider
+		_, err := extractor.generateWith(ctx, Uncached(client), HealthPrompt)
+		return err
+	}, healthTTL)
 	return extractor, nil
 }
 
 // Extract performs PII extraction using LLM
 func (l *LLMExtractor) Extract(text string) (*pii.PiiExtractionResult, error) {
+	return l.ExtractContext(context.Background(), text)
+}
+
+// ExtractContext performs PII extraction using LLM under the given context
+func (l *LLMExtractor) ExtractContext(ctx context.Context, text string) (*pii.PiiExtractionResult, error) {
 	// Prepare prompt for PII extraction
 	prompt := l.buildExtractionPrompt(text)
 	
-	// Create context for LLM call
-	ctx := context.Background()
-	
 	// Call LLM
-	response, err := l.llm.Generate(ctx, gollm.NewPrompt(prompt))
+	response, err := l.generate(ctx, prompt)
 	if err != nil {
 		return nil, fmt.Errorf("LLM extraction failed: %w", err)
 	}
@@ -161,7 +158,14 @@ func (l *LLMExtractor) Extract(text string) (*pii.PiiExtractionResult, error) {
 		return nil, fmt.Errorf("failed to parse LLM response: %w", err)
 	}
 	
-	return pii.NewPiiExtractionResult(entities), nil
+	result := pii.NewPiiExtractionResult(entities)
+	if l.entityIDSalt != "" {
+		result.AssignIDs(l.entityIDSalt)
+	}
+	if l.displayMode != pii.DisplayMasked {
+		result.SetDisplayMode(l.displayMode)
+	}
+	return result, nil
 }
 
 // ExtractByType extracts specific PII types using LLM
@@ -173,7 +177,7 @@ func (l *LLMExtractor) ExtractByType(text string, piiType pii.PiiType) ([]pii.Pi
 	ctx := context.Background()
 	
 	// Call LLM
-	response, err := l.llm.Generate(ctx, gollm.NewPrompt(prompt))
+	response, err := l.generate(ctx, prompt)
 	if err != nil {
 		return nil, fmt.Errorf("LLM type-specific extraction failed: %w", err)
 	}
@@ -195,8 +199,75 @@ func (l *LLMExtractor) ExtractByType(text string, piiType pii.PiiType) ([]pii.Pi
 	return filtered, nil
 }
 
+// generate sends a prompt to the LLM, retrying retryable failures up to RetryAttempts
+// times. It waits the delay asked for by a rate-limited provider, or one second more per
+// attempt, capped at the request timeout.
+func (l *LLMExtractor) generate(ctx context.Context, prompt string) (string, error) {
+	for attempt := 0; ; attempt++ {
+		response, err := l.generateWith(ctx, l.client, prompt)
+		if err == nil || attempt >= l.config.RetryAttempts || !IsRetryable(err) {
+			return response, err
+		}
+
+		delay, ok := RetryAfter(err)
+		if !ok {
+			delay = time.Duration(attempt+1) * time.Second
+		}
+		if limit := time.Duration(l.config.Timeout) * time.Second; limit > 0 && delay > limit {
+			delay = limit
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return "", err
+		}
+	}
+}
+
+// generateWith sends a prompt to the LLM with the given client inside a trace span
+func (l *LLMExtractor) generateWith(ctx context.Context, client Client, prompt string) (string, error) {
+	ctx, span := telemetry.StartSpan(ctx, telemetry.SpanLLMGenerate,
+		telemetry.String("llm.provider", string(l.provider)),
+		telemetry.String("llm.model", l.model),
+		telemetry.Int("llm.prompt_length", len(prompt)))
+	defer span.End()
+
+	response, err := client.Generate(ctx, prompt)
+	if err != nil {
+		span.RecordError(err)
+		return "", err
+	}
+	span.SetAttributes(telemetry.Int("llm.response_length", len(response)))
+	return response, nil
+}
+
+// HealthCheck verifies the LLM provider is reachable. The result is reused for the
+// health_ttl option (DefaultHealthTTL by default).
+func (l *LLMExtractor) HealthCheck(ctx context.Context) error {
+	return l.health.Check(ctx)
+}
+
+// Start refreshes the provider health in the background, so that HealthCheck answers
+// from the cache
+func (l *LLMExtractor) Start(ctx context.Context) error {
+	return l.health.Start(ctx)
+}
+
+// Close stops the background health refresh
+func (l *LLMExtractor) Close() error {
+	return l.health.Close()
+}
+
 // GetSupportedTypes returns PII types this LLM extractor can handle
 func (l *LLMExtractor) GetSupportedTypes() []pii.PiiType {
+	return SupportedTypes()
+}
+
+// SupportedTypes returns the PII types requested from the model by LLM extractors,
+// regardless of country
+func SupportedTypes() []pii.PiiType {
 	// LLM can potentially handle all types, but we'll be conservative
 	return []pii.PiiType{
 		pii.PiiTypePhone,
@@ -235,7 +306,7 @@ func (l *LLMExtractor) GetModel() string {
 // buildExtractionPrompt creates a prompt for general PII extraction
 func (l *LLMExtractor) buildExtractionPrompt(text string) string {
 	return fmt.Sprintf(`You are a PII (Personally Identifiable Information) extraction expert. Analyze the following text and extract all PII entities.
-
+%s
 Text to analyze:
 %s
 
@@ -272,7 +343,7 @@ Example response:
   }
 ]
 
-If no PII is found, respond with an empty array: []`, text)
+If no PII is found, respond with an empty array: []`, l.fewShotExamples(nil), text)
 }
 
 // buildTypeSpecificPrompt creates a prompt for extracting specific PII types
@@ -280,7 +351,7 @@ func (l *LLMExtractor) buildTypeSpecificPrompt(text string, piiType pii.PiiType)
 	typeStr := piiType.String()
 	
 	return fmt.Sprintf(`You are a PII extraction expert. Analyze the following text and extract only %s entities.
-
+%s
 Text to analyze:
 %s
 
@@ -295,7 +366,7 @@ Respond in JSON format with an array of objects:
   }
 ]
 
-If no %s entities are found, respond with an empty array: []`, typeStr, text, typeStr, typeStr, typeStr, typeStr)
+If no %s entities are found, respond with an empty array: []`, typeStr, l.fewShotExamples([]pii.PiiType{piiType}), text, typeStr, typeStr, typeStr, typeStr)
 }
 
 // parseExtractionResponse parses the LLM response into PiiEntity objects
@@ -416,13 +487,13 @@ func (l *LLMExtractor) parseEntityObject(objectStr, originalText string) *pii.Pi
 	case pii.PiiTypeEmail:
 		piiValue = pii.NewEmail(value)
 	case pii.PiiTypePhone:
-		piiValue = pii.NewPhoneUS(value)
+		piiValue = pii.NewPhone(value, pii.CountryUS)
 	case pii.PiiTypeSSN:
 		piiValue = pii.NewSSN(value)
 	case pii.PiiTypeZipCode:
-		piiValue = pii.NewZipCode(value, "US")
+		piiValue = pii.NewZipCode(value, pii.CountryUS)
 	case pii.PiiTypeStreetAddress:
-		piiValue = pii.NewStreetAddress(value, "US")
+		piiValue = pii.NewStreetAddress(value, pii.CountryUS)
 	case pii.PiiTypeCreditCard:
 		piiValue = pii.NewCreditCard(value, "unknown")
 	case pii.PiiTypeIPAddress:
@@ -430,9 +501,14 @@ func (l *LLMExtractor) parseEntityObject(objectStr, originalText string) *pii.Pi
 	case pii.PiiTypeBtcAddress:
 		piiValue = pii.NewBtcAddress(value)
 	case pii.PiiTypeIBAN:
-		piiValue = pii.NewIBAN(value, "unknown")
+		// The IBAN country is its first two letters
+		var country pii.Country
+		if len(value) >= 2 {
+			country, _ = pii.ParseCountry(value[:2])
+		}
+		piiValue = pii.NewIBAN(value, country)
 	case pii.PiiTypePoBox:
-		piiValue = pii.NewPoBox(value, "US")
+		piiValue = pii.NewPoBox(value, pii.CountryUS)
 	default:
 		return nil
 	}
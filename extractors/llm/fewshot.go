@@ -0,0 +1,277 @@
+package llm
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// Few-shot options of the LLM extractor, read from ExtractorConfig.Options
+const (
+	OptionFewShot      = "few_shot"       // bool, adds examples from the built-in library
+	OptionExamples     = "examples"       // *ExampleLibrary, replacing the built-in library
+	OptionLanguage     = "language"       // ISO 639-1 code of the documents, such as "fr"
+	OptionFewShotCount = "few_shot_count" // integer, examples per prompt (default DefaultFewShotCount)
+)
+
+// DefaultFewShotCount is the number of examples added to a prompt by default
+const DefaultFewShotCount = 4
+
+//go:embed examples/builtin.json
+var builtinExamples []byte
+
+// promptTypes maps the type labels of the extraction prompts to PII types
+var promptTypes = map[string]pii.PiiType{
+	"email":      pii.PiiTypeEmail,
+	"phone":      pii.PiiTypePhone,
+	"ssn":        pii.PiiTypeSSN,
+	"zipcode":    pii.PiiTypeZipCode,
+	"address":    pii.PiiTypeStreetAddress,
+	"creditcard": pii.PiiTypeCreditCard,
+	"ip":         pii.PiiTypeIPAddress,
+	"bitcoin":    pii.PiiTypeBtcAddress,
+	"iban":       pii.PiiTypeIBAN,
+	"pobox":      pii.PiiTypePoBox,
+}
+
+// Example is a text and the entities the LLM extractor should find in it, shown to the
+// model in few-shot prompts. An example without entities teaches what not to extract.
+type Example struct {
+	Language string          `json:"language,omitempty"` // ISO 639-1 code, empty for any language
+	Text     string          `json:"text"`
+	Entities []ExampleEntity `json:"entities"`
+}
+
+// ExampleEntity is an entity expected in an example
+type ExampleEntity struct {
+	Type  string `json:"type"` // prompt label: email, phone, ssn, zipcode, address, creditcard, ip, bitcoin, iban or pobox
+	Value string `json:"value"`
+}
+
+// covers reports whether the example has an entity of the given type
+func (e Example) covers(piiType pii.PiiType) bool {
+	for _, entity := range e.Entities {
+		if promptTypes[entity.Type] == piiType {
+			return true
+		}
+	}
+	return false
+}
+
+// validate checks the entity types and that their values appear in the text
+func (e Example) validate() error {
+	if e.Text == "" {
+		return fmt.Errorf("example has no text")
+	}
+	for _, entity := range e.Entities {
+		if _, ok := promptTypes[entity.Type]; !ok {
+			return fmt.Errorf("example %q: unknown type %q", e.Text, entity.Type)
+		}
+		if !strings.Contains(e.Text, entity.Value) {
+			return fmt.Errorf("example %q: value %q not found in the text", e.Text, entity.Value)
+		}
+	}
+	return nil
+}
+
+// ExampleLibrary holds the examples few-shot prompts are built from. It is safe for
+// concurrent use, so examples can be contributed while extractors use the library.
+type ExampleLibrary struct {
+	examples []Example
+	mu       sync.RWMutex
+}
+
+// NewExampleLibrary creates a library with the given examples
+func NewExampleLibrary(examples ...Example) (*ExampleLibrary, error) {
+	library := &ExampleLibrary{}
+	if err := library.Add(examples...); err != nil {
+		return nil, err
+	}
+	return library, nil
+}
+
+// DefaultExampleLibrary returns a new library holding the built-in examples, which
+// covers every prompt type in English, French, German, Spanish, Italian, Dutch and
+// Portuguese. Contributed examples are added to the returned library only.
+func DefaultExampleLibrary() *ExampleLibrary {
+	library := &ExampleLibrary{}
+	if err := library.Load(strings.NewReader(string(builtinExamples))); err != nil {
+		panic(fmt.Sprintf("llm: invalid built-in examples: %v", err))
+	}
+	return library
+}
+
+// Add contributes examples to the library. Examples with unknown types or values
+// missing from their text are rejected, and none of the examples are added.
+func (l *ExampleLibrary) Add(examples ...Example) error {
+	for _, example := range examples {
+		if err := example.validate(); err != nil {
+			return err
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.examples = append(l.examples, examples...)
+	return nil
+}
+
+// Load contributes the examples of a JSON array, in the format of Example
+func (l *ExampleLibrary) Load(r io.Reader) error {
+	var examples []Example
+	if err := json.NewDecoder(r).Decode(&examples); err != nil {
+		return fmt.Errorf("decoding examples: %w", err)
+	}
+	return l.Add(examples...)
+}
+
+// LoadFile contributes the examples of a JSON file
+func (l *ExampleLibrary) LoadFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return l.Load(file)
+}
+
+// Len returns the number of examples in the library
+func (l *ExampleLibrary) Len() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return len(l.examples)
+}
+
+// Select returns at most limit examples for the given types (every type when empty) and
+// language. Examples in the language come before language-neutral ones, types are
+// covered in turn so that each gets an example, and a negative example is added when
+// room is left.
+func (l *ExampleLibrary) Select(types []pii.PiiType, language string, limit int) []Example {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	// Examples in the language first, then language-neutral ones. Without a language,
+	// any example may be used.
+	var candidates []Example
+	for _, example := range l.examples {
+		if language == "" || strings.EqualFold(example.Language, language) {
+			candidates = append(candidates, example)
+		}
+	}
+	if language != "" {
+		for _, example := range l.examples {
+			if example.Language == "" {
+				candidates = append(candidates, example)
+			}
+		}
+	}
+
+	if len(types) == 0 {
+		for _, label := range slices.Sorted(maps.Keys(promptTypes)) {
+			types = append(types, promptTypes[label])
+		}
+	}
+
+	var selected []Example
+	used := make([]bool, len(candidates))
+	for progress := true; progress && len(selected) < limit; {
+		progress = false
+		for _, piiType := range types {
+			if len(selected) == limit {
+				break
+			}
+			for i, example := range candidates {
+				if !used[i] && example.covers(piiType) {
+					used[i] = true
+					selected = append(selected, example)
+					progress = true
+					break
+				}
+			}
+		}
+	}
+
+	if len(selected) < limit {
+		for i, example := range candidates {
+			if !used[i] && len(example.Entities) == 0 {
+				selected = append(selected, example)
+				break
+			}
+		}
+	}
+	return selected
+}
+
+// fewShotSection formats examples for an extraction prompt, keeping only the entities of
+// types (every type when empty)
+func fewShotSection(examples []Example, types []pii.PiiType) string {
+	if len(examples) == 0 {
+		return ""
+	}
+
+	type promptEntity struct {
+		Type    string `json:"type"`
+		Value   string `json:"value"`
+		Context string `json:"context"`
+	}
+
+	var section strings.Builder
+	section.WriteString("\nLearn from these examples of texts and their expected response:\n")
+	for _, example := range examples {
+		entities := []promptEntity{}
+		for _, entity := range example.Entities {
+			if len(types) == 0 || slices.Contains(types, promptTypes[entity.Type]) {
+				entities = append(entities, promptEntity{Type: entity.Type, Value: entity.Value, Context: example.Text})
+			}
+		}
+		response, _ := json.Marshal(entities)
+		fmt.Fprintf(&section, "\nText: %s\nResponse: %s\n", example.Text, response)
+	}
+	return section.String()
+}
+
+// setFewShotOptions reads the few-shot options of an extractor configuration
+func (l *LLMExtractor) setFewShotOptions(options map[string]any) error {
+	if enabled, ok := options[OptionFewShot].(bool); ok && enabled {
+		l.examples = DefaultExampleLibrary()
+	}
+	if value, ok := options[OptionExamples]; ok && value != nil {
+		library, ok := value.(*ExampleLibrary)
+		if !ok {
+			return fmt.Errorf("provider option %q: expected an *llm.ExampleLibrary, got %T", OptionExamples, value)
+		}
+		l.examples = library
+	}
+	if value, ok := options[OptionLanguage]; ok {
+		var err error
+		if l.language, err = stringOption(value); err != nil {
+			return fmt.Errorf("provider option %q: %w", OptionLanguage, err)
+		}
+	}
+
+	l.fewShotCount = DefaultFewShotCount
+	if value, ok := options[OptionFewShotCount]; ok {
+		var err error
+		if l.fewShotCount, err = intOption(value); err != nil {
+			return fmt.Errorf("provider option %q: %w", OptionFewShotCount, err)
+		}
+	}
+	return nil
+}
+
+// fewShotExamples returns the few-shot section of a prompt for types (every type when
+// empty), or an empty string without example library
+func (l *LLMExtractor) fewShotExamples(types []pii.PiiType) string {
+	if l.examples == nil {
+		return ""
+	}
+	return fewShotSection(l.examples.Select(types, l.language, l.fewShotCount), types)
+}
@@ -0,0 +1,106 @@
+package llm
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/intMeric/pii-extractor/extractors"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// promptRecorder records the last prompt it receives
+type promptRecorder struct {
+	prompt string
+}
+
+func (p *promptRecorder) Generate(ctx context.Context, prompt string) (string, error) {
+	p.prompt = prompt
+	return "[]", nil
+}
+
+func TestDefaultExampleLibrary(t *testing.T) {
+	library := DefaultExampleLibrary()
+	for label, piiType := range promptTypes {
+		if len(library.Select([]pii.PiiType{piiType}, "", 1)) == 0 {
+			t.Errorf("Expected a built-in example for %s", label)
+		}
+	}
+
+	examples := library.Select([]pii.PiiType{pii.PiiTypePhone, pii.PiiTypeIBAN}, "fr", 3)
+	if len(examples) != 2 {
+		t.Fatalf("Expected the 2 French examples, got %d", len(examples))
+	}
+	if examples[0].Language != "fr" || !examples[0].covers(pii.PiiTypePhone) || !examples[1].covers(pii.PiiTypeIBAN) {
+		t.Errorf("Expected French phone then IBAN examples, got %+v", examples)
+	}
+}
+
+func TestExampleLibrary_Add(t *testing.T) {
+	library, err := NewExampleLibrary()
+	if err != nil {
+		t.Fatalf("NewExampleLibrary() error = %v", err)
+	}
+
+	invalid := []Example{
+		{Text: "Call 555-0100", Entities: []ExampleEntity{{Type: "telephone", Value: "555-0100"}}},
+		{Text: "Call 555-0100", Entities: []ExampleEntity{{Type: "phone", Value: "555-0199"}}},
+	}
+	for _, example := range invalid {
+		if err := library.Add(example); err == nil {
+			t.Errorf("Expected an error for %+v", example)
+		}
+	}
+
+	contributed := Example{Text: "Ring 0400 123 456 after six", Entities: []ExampleEntity{{Type: "phone", Value: "0400 123 456"}}}
+	if err := library.Add(contributed); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if err := library.Load(strings.NewReader(`[{"language": "en", "text": "Nothing to see", "entities": []}]`)); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	// Language-neutral examples are used for any language, negatives fill the rest
+	examples := library.Select([]pii.PiiType{pii.PiiTypePhone}, "de", 2)
+	if len(examples) != 1 || examples[0].Text != contributed.Text {
+		t.Errorf("Expected only the neutral phone example for German, got %+v", examples)
+	}
+	if examples := library.Select([]pii.PiiType{pii.PiiTypePhone}, "en", 2); len(examples) != 2 {
+		t.Errorf("Expected the phone and negative examples for English, got %+v", examples)
+	}
+}
+
+func TestLLMExtractor_FewShotPrompts(t *testing.T) {
+	recorder := &promptRecorder{}
+	SetClientFactory(func(config ClientConfig) (Client, error) {
+		return recorder, nil
+	})
+	defer SetClientFactory(nil)
+
+	plain, err := NewExtractor(ProviderOpenAI, "", nil)
+	if err != nil {
+		t.Fatalf("NewExtractor() error = %v", err)
+	}
+	plain.Extract("text")
+	if strings.Contains(recorder.prompt, "Learn from these examples") {
+		t.Error("Expected no examples without the few_shot option")
+	}
+
+	extractor, err := NewExtractor(ProviderOpenAI, "", &extractors.ExtractorConfig{Options: map[string]any{
+		OptionFewShot:      true,
+		OptionLanguage:     "de",
+		OptionFewShotCount: 2,
+	}})
+	if err != nil {
+		t.Fatalf("NewExtractor() error = %v", err)
+	}
+	if _, err := extractor.ExtractByType("text", pii.PiiTypeIBAN); err != nil {
+		t.Fatalf("ExtractByType() error = %v", err)
+	}
+	if !strings.Contains(recorder.prompt, `"type":"iban","value":"DE89 3704 0044 0532 0130 00"`) {
+		t.Errorf("Expected the German IBAN example in the prompt, got:\n%s", recorder.prompt)
+	}
+	if strings.Contains(recorder.prompt, `"type":"phone"`) {
+		t.Error("Expected type-specific prompts to only show entities of that type")
+	}
+}
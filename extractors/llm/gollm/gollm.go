@@ -0,0 +1,236 @@
+// Package gollm connects the LLM extractor and validator to their providers through
+// gollm. It lives in its own module so the core library does not depend on gollm.
+package gollm
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/intMeric/pii-extractor/extractors/llm"
+	"github.com/teilomillet/gollm/config"
+	"github.com/teilomillet/gollm/providers"
+)
+
+// gollmProvider is the gollm name and constructor of a provider
+type gollmProvider struct {
+	name        string
+	constructor providers.ProviderConstructor
+}
+
+// gollmProviders maps providers to gollm. Gemini has no native gollm provider and is
+// reached through its OpenAI-compatible endpoint. Ollama clients are built in the core
+// library.
+var gollmProviders = map[llm.Provider]gollmProvider{
+	llm.ProviderOpenAI:    {"openai", providers.NewOpenAIProvider},
+	llm.ProviderMistral:   {"mistral", providers.NewMistralProvider},
+	llm.ProviderGemini:    {"gemini", nil},
+	llm.ProviderAnthropic: {"anthropic", providers.NewAnthropicProvider},
+}
+
+// GeminiBaseURL is the OpenAI-compatible endpoint used for Gemini, which gollm has no
+// native provider for
+const GeminiBaseURL = "https://generativelanguage.googleapis.com/v1beta/openai"
+
+// AzureAPIVersion is the api-version used for Azure OpenAI endpoints when the
+// api_version provider option is not set
+const AzureAPIVersion = "2024-06-01"
+
+// Install makes gollm create the clients of all LLM extractors and validators
+func Install() {
+	llm.SetClientFactory(NewClient)
+}
+
+// Client adapts a gollm provider to llm.Client. It sends the requests itself, without
+// gollm's retries, so that the status and Retry-After header of provider errors reach the
+// validator as an *llm.StatusError.
+type Client struct {
+	provider providers.Provider
+	options  map[string]any
+	http     *http.Client
+}
+
+// NewClient creates a gollm client for the configuration. API keys default to the
+// provider's environment variable, such as OPENAI_API_KEY.
+func NewClient(clientConfig llm.ClientConfig) (llm.Client, error) {
+	target, ok := gollmProviders[clientConfig.Provider]
+	if !ok {
+		return nil, fmt.Errorf("unsupported provider: %s", clientConfig.Provider)
+	}
+	name, constructor := target.name, target.constructor
+	options := clientConfig.Options
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load gollm config: %w", err)
+	}
+	config.SetProvider(name)(cfg)
+	config.SetModel(clientConfig.Model)(cfg)
+	if clientConfig.APIKey != "" {
+		config.SetAPIKey(clientConfig.APIKey)(cfg)
+	}
+	if options.Temperature != nil {
+		config.SetTemperature(*options.Temperature)(cfg)
+	}
+	if options.MaxTokens > 0 {
+		config.SetMaxTokens(options.MaxTokens)(cfg)
+	}
+
+	baseURL := clientConfig.BaseURL
+	if baseURL == "" && clientConfig.Provider == llm.ProviderGemini {
+		baseURL = GeminiBaseURL
+	}
+	if baseURL != "" {
+		// Endpoint overrides go through gollm's generic provider, registered under a
+		// name unique to the endpoint. The API key of the provider applies to it.
+		endpoint := endpointConfig(clientConfig.Provider, baseURL, options.APIVersion)
+		endpoint.Name = "pii-extractor/" + name + "/" + endpoint.Endpoint
+		providers.GetDefaultRegistry().RegisterProviderConfig(endpoint.Name, endpoint)
+		constructor = func(apiKey, model string, extraHeaders map[string]string) providers.Provider {
+			return providers.NewGenericProvider(apiKey, model, endpoint.Name, extraHeaders)
+		}
+		if cfg.APIKeys == nil {
+			cfg.APIKeys = make(map[string]string)
+		}
+		cfg.APIKeys[endpoint.Name] = cfg.APIKeys[name]
+		cfg.Provider = endpoint.Name
+	}
+
+	headers := make(map[string]string)
+	maps.Copy(headers, options.Headers)
+	if options.Organization != "" {
+		headers["OpenAI-Organization"] = options.Organization
+	}
+	if options.Project != "" {
+		headers["OpenAI-Project"] = options.Project
+	}
+
+	// gollm passes only its own headers to providers, so the constructor adds ours
+	registry := providers.NewProviderRegistry()
+	registry.Register(cfg.Provider, func(apiKey, model string, extraHeaders map[string]string) providers.Provider {
+		merged := maps.Clone(headers)
+		maps.Copy(merged, extraHeaders)
+		return constructor(apiKey, model, merged)
+	})
+
+	apiKey := cfg.APIKeys[cfg.Provider]
+	if apiKey == "" {
+		return nil, errors.New("empty API key")
+	}
+	extraHeaders := make(map[string]string)
+	if clientConfig.Provider == llm.ProviderAnthropic && cfg.EnableCaching {
+		extraHeaders["anthropic-beta"] = "prompt-caching-2024-07-31"
+	}
+	provider, err := registry.Get(cfg.Provider, apiKey, cfg.Model, extraHeaders)
+	if err != nil {
+		return nil, err
+	}
+	provider.SetDefaultOptions(cfg)
+
+	httpClient, err := llm.NewHTTPClient(clientConfig)
+	if err != nil {
+		return nil, err
+	}
+	if clientConfig.HTTPClient == nil && cfg.Timeout > 0 {
+		timed := *httpClient
+		timed.Timeout = cfg.Timeout
+		httpClient = &timed
+	}
+
+	requestOptions := make(map[string]any)
+	if options.TopP != nil {
+		requestOptions["top_p"] = *options.TopP
+	}
+	if len(options.Stop) > 0 {
+		if clientConfig.Provider == llm.ProviderAnthropic {
+			requestOptions["stop_sequences"] = options.Stop
+		} else {
+			requestOptions["stop"] = options.Stop
+		}
+	}
+	maps.Copy(requestOptions, options.Extra)
+	return &Client{provider: provider, options: requestOptions, http: httpClient}, nil
+}
+
+// endpointConfig returns the generic provider configuration of a base URL
+func endpointConfig(provider llm.Provider, baseURL, apiVersion string) providers.ProviderConfig {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	headers := map[string]string{"Content-Type": "application/json"}
+
+	if provider == llm.ProviderAnthropic {
+		headers["anthropic-version"] = "2023-06-01"
+		endpoint := baseURL
+		if !strings.HasSuffix(endpoint, "/messages") {
+			endpoint += "/v1/messages"
+		}
+		return providers.ProviderConfig{
+			Type:            providers.TypeAnthropic,
+			Endpoint:        endpoint,
+			AuthHeader:      "x-api-key",
+			RequiredHeaders: headers,
+		}
+	}
+
+	endpoint := baseURL
+	if !strings.HasSuffix(endpoint, "/chat/completions") {
+		endpoint += "/chat/completions"
+	}
+	config := providers.ProviderConfig{
+		Type:            providers.TypeOpenAI,
+		Endpoint:        endpoint,
+		AuthHeader:      "Authorization",
+		AuthPrefix:      "Bearer ",
+		RequiredHeaders: headers,
+	}
+	if isAzure(baseURL) || apiVersion != "" {
+		// Azure OpenAI: https://{resource}.openai.azure.com/openai/deployments/{deployment}
+		if apiVersion == "" {
+			apiVersion = AzureAPIVersion
+		}
+		config.AuthHeader = "api-key"
+		config.AuthPrefix = ""
+		config.EndpointParams = map[string]string{"api-version": apiVersion}
+	}
+	return config
+}
+
+// isAzure reports whether a base URL points to an Azure OpenAI resource
+func isAzure(baseURL string) bool {
+	u, err := url.Parse(baseURL)
+	return err == nil && strings.HasSuffix(u.Hostname(), ".openai.azure.com")
+}
+
+// Generate implements llm.Client
+func (c *Client) Generate(ctx context.Context, prompt string) (string, error) {
+	body, err := c.provider.PrepareRequest(prompt, maps.Clone(c.options))
+	if err != nil {
+		return "", fmt.Errorf("failed to prepare request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.provider.Endpoint(), bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	for name, value := range c.provider.Headers() {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", llm.NewStatusError(resp, strings.TrimSpace(string(data)))
+	}
+	return c.provider.ParseResponse(data)
+}
@@ -0,0 +1,130 @@
+package gollm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/intMeric/pii-extractor/extractors/llm"
+)
+
+func TestNewClient_OpenAICompatibleGateway(t *testing.T) {
+	var request map[string]any
+	var header http.Header
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header, path = r.Header, r.URL.Path
+		json.NewDecoder(r.Body).Decode(&request)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices": [{"message": {"role": "assistant", "content": "OK"}}]}`))
+	}))
+	defer server.Close()
+
+	topP := 0.5
+	client, err := NewClient(llm.ClientConfig{
+		Provider: llm.ProviderOpenAI,
+		Model:    "gpt-4o-mini",
+		APIKey:   "sk-test",
+		BaseURL:  server.URL + "/v1",
+		Options: llm.Options{
+			TopP:         &topP,
+			Stop:         []string{"END"},
+			Organization: "org-1",
+			Project:      "proj-1",
+			Headers:      map[string]string{"X-Gateway-Key": "gw"},
+			Extra:        map[string]any{"seed": 7},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	response, err := client.Generate(context.Background(), "Respond with 'OK'")
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if response != "OK" {
+		t.Errorf("Expected the gateway response, got %q", response)
+	}
+	if path != "/v1/chat/completions" {
+		t.Errorf("Expected the chat completions path under the base URL, got %q", path)
+	}
+	for name, want := range map[string]string{
+		"Authorization":       "Bearer sk-test",
+		"OpenAI-Organization": "org-1",
+		"OpenAI-Project":      "proj-1",
+		"X-Gateway-Key":       "gw",
+	} {
+		if got := header.Get(name); got != want {
+			t.Errorf("Header %s = %q, want %q", name, got, want)
+		}
+	}
+	if request["top_p"] != 0.5 || request["seed"] != 7.0 || request["model"] != "gpt-4o-mini" {
+		t.Errorf("Expected top_p, seed and model in the request, got %v", request)
+	}
+	if stop, _ := request["stop"].([]any); len(stop) != 1 || stop[0] != "END" {
+		t.Errorf("Expected the stop sequences in the request, got %v", request["stop"])
+	}
+}
+
+func TestEndpointConfig(t *testing.T) {
+	azure := endpointConfig(llm.ProviderOpenAI, "https://acme.openai.azure.com/openai/deployments/gpt4o/", "")
+	if azure.Endpoint != "https://acme.openai.azure.com/openai/deployments/gpt4o/chat/completions" ||
+		azure.AuthHeader != "api-key" || azure.EndpointParams["api-version"] != AzureAPIVersion {
+		t.Errorf("Unexpected Azure OpenAI config %+v", azure)
+	}
+
+	anthropic := endpointConfig(llm.ProviderAnthropic, "https://proxy.internal", "")
+	if anthropic.Endpoint != "https://proxy.internal/v1/messages" || anthropic.AuthHeader != "x-api-key" {
+		t.Errorf("Unexpected Anthropic proxy config %+v", anthropic)
+	}
+}
+
+func TestClient_StatusErrors(t *testing.T) {
+	status, retryAfter := http.StatusUnauthorized, ""
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if retryAfter != "" {
+			w.Header().Set("Retry-After", retryAfter)
+		}
+		w.WriteHeader(status)
+		w.Write([]byte(`{"error": {"message": "denied"}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(llm.ClientConfig{
+		Provider: llm.ProviderOpenAI,
+		Model:    "gpt-4o-mini",
+		APIKey:   "sk-invalid",
+		BaseURL:  server.URL + "/v1",
+	})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	_, err = client.Generate(context.Background(), "prompt")
+	var statusErr *llm.StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("Expected a 401 status error, got %v", err)
+	}
+	if llm.IsRetryable(err) {
+		t.Error("Expected an invalid API key not to be retryable")
+	}
+	if calls != 1 {
+		t.Errorf("Expected a single request without gollm retries, got %d", calls)
+	}
+
+	status, retryAfter = http.StatusTooManyRequests, "7"
+	_, err = client.Generate(context.Background(), "prompt")
+	if !llm.IsRetryable(err) {
+		t.Errorf("Expected a rate limit to be retryable, got %v", err)
+	}
+	if delay, ok := llm.RetryAfter(err); !ok || delay != 7*time.Second {
+		t.Errorf("Expected the Retry-After delay, got %v %v", delay, ok)
+	}
+}
@@ -0,0 +1,190 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// DefaultHealthTTL is how long a provider health check result is reused when no TTL is
+// configured
+const DefaultHealthTTL = 30 * time.Second
+
+// OptionHealthTTL is the extractor option setting the health check TTL: a time.Duration, or
+// a duration string such as "1m". A negative TTL checks the provider on every call.
+const OptionHealthTTL = "health_ttl"
+
+// HealthPrompt is the prompt sent to providers by health checks
+const HealthPrompt = "Respond with 'OK'"
+
+// HealthCache reuses the result of a provider health check for a TTL, so that readiness
+// probes hitting a service every few seconds do not each cost a generation call.
+// Concurrent checks of an expired result share a single call. Start refreshes the result
+// in the background before it expires.
+type HealthCache struct {
+	check func(ctx context.Context) error
+	ttl   time.Duration
+
+	mu        sync.Mutex
+	err       error
+	checkedAt time.Time
+	inflight  chan struct{} // closed when the running check completes, nil when idle
+	stop      chan struct{} // closed by Close, nil until Start
+}
+
+// NewHealthCache creates a cache for check. A zero TTL uses DefaultHealthTTL and a negative
+// TTL disables caching.
+func NewHealthCache(check func(ctx context.Context) error, ttl time.Duration) *HealthCache {
+	if ttl == 0 {
+		ttl = DefaultHealthTTL
+	}
+	return &HealthCache{check: check, ttl: ttl}
+}
+
+// NewClientHealthCache creates a cache checking a client with HealthPrompt. The prompt is
+// sent to the provider even when the client is a CachedClient (see Uncached).
+func NewClientHealthCache(client Client, ttl time.Duration) *HealthCache {
+	client = Uncached(client)
+	return NewHealthCache(func(ctx context.Context) error {
+		_, err := client.Generate(ctx, HealthPrompt)
+		return err
+	}, ttl)
+}
+
+// Check returns the cached result if it is younger than the TTL, and checks the provider
+// otherwise
+func (c *HealthCache) Check(ctx context.Context) error {
+	if c.ttl < 0 {
+		return c.check(ctx)
+	}
+
+	c.mu.Lock()
+	if !c.checkedAt.IsZero() && time.Since(c.checkedAt) < c.ttl {
+		err := c.err
+		c.mu.Unlock()
+		return err
+	}
+	inflight := c.inflight
+	if inflight == nil {
+		inflight = make(chan struct{})
+		c.inflight = inflight
+		c.mu.Unlock()
+		return c.refresh(ctx, inflight)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-inflight:
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return c.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// refresh runs the check and stores its result, releasing the callers waiting on inflight
+func (c *HealthCache) refresh(ctx context.Context, inflight chan struct{}) error {
+	err := c.check(ctx)
+
+	c.mu.Lock()
+	// A check interrupted by its caller says nothing about the provider
+	if ctx.Err() == nil {
+		c.err = err
+		c.checkedAt = time.Now()
+	}
+	c.inflight = nil
+	c.mu.Unlock()
+	close(inflight)
+	return err
+}
+
+// CheckedAt returns when the cached result was obtained, zero before the first check
+func (c *HealthCache) CheckedAt() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.checkedAt
+}
+
+// Start refreshes the result in the background until Close is called. ctx only provides
+// values to the checks: startup deadlines do not stop the refresh. Each refresh runs 75 to
+// 100% of the TTL after the previous one, at random, so that replicas started together do
+// not call the provider in bursts. Calling Start again has no effect.
+func (c *HealthCache) Start(ctx context.Context) error {
+	if c.ttl < 0 {
+		return nil
+	}
+	c.mu.Lock()
+	if c.stop != nil {
+		c.mu.Unlock()
+		return nil
+	}
+	stop := make(chan struct{})
+	c.stop = stop
+	c.mu.Unlock()
+
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		for {
+			c.forceRefresh(ctx)
+			timer := time.NewTimer(c.ttl - rand.N(c.ttl/4+1))
+			select {
+			case <-timer.C:
+			case <-stop:
+				timer.Stop()
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// forceRefresh checks the provider unless a check is already running
+func (c *HealthCache) forceRefresh(ctx context.Context) {
+	c.mu.Lock()
+	if c.inflight != nil {
+		c.mu.Unlock()
+		return
+	}
+	inflight := make(chan struct{})
+	c.inflight = inflight
+	c.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, c.ttl)
+	defer cancel()
+	c.refresh(ctx, inflight)
+}
+
+// Close stops the background refresh
+func (c *HealthCache) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stop != nil {
+		select {
+		case <-c.stop:
+		default:
+			close(c.stop)
+		}
+	}
+	return nil
+}
+
+// durationOption reads a duration option: a time.Duration or a duration string
+func durationOption(options map[string]any, key string) (time.Duration, error) {
+	switch value := options[key].(type) {
+	case nil:
+		return 0, nil
+	case time.Duration:
+		return value, nil
+	case string:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, fmt.Errorf("provider option %q: %w", key, err)
+		}
+		return d, nil
+	default:
+		return 0, fmt.Errorf("provider option %q: expected a duration, got %T", key, value)
+	}
+}
@@ -0,0 +1,97 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHealthCache_ReusesResultWithinTTL(t *testing.T) {
+	var calls atomic.Int32
+	failure := errors.New("provider down")
+	cache := NewHealthCache(func(ctx context.Context) error {
+		calls.Add(1)
+		return failure
+	}, 50*time.Millisecond)
+
+	for range 3 {
+		if err := cache.Check(context.Background()); !errors.Is(err, failure) {
+			t.Fatalf("Check() = %v, want %v", err, failure)
+		}
+	}
+	if calls.Load() != 1 {
+		t.Errorf("Expected 1 provider call within the TTL, got %d", calls.Load())
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	cache.Check(context.Background())
+	if calls.Load() != 2 {
+		t.Errorf("Expected a new provider call after the TTL, got %d calls", calls.Load())
+	}
+}
+
+func TestHealthCache_SharesConcurrentChecks(t *testing.T) {
+	var calls atomic.Int32
+	cache := NewHealthCache(func(ctx context.Context) error {
+		calls.Add(1)
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	}, time.Minute)
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := cache.Check(context.Background()); err != nil {
+				t.Errorf("Check() = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if calls.Load() != 1 {
+		t.Errorf("Expected concurrent checks to share 1 provider call, got %d", calls.Load())
+	}
+}
+
+func TestHealthCache_NegativeTTLDisablesCaching(t *testing.T) {
+	var calls atomic.Int32
+	cache := NewHealthCache(func(ctx context.Context) error {
+		calls.Add(1)
+		return nil
+	}, -1)
+	cache.Check(context.Background())
+	cache.Check(context.Background())
+	if calls.Load() != 2 {
+		t.Errorf("Expected every check to call the provider, got %d calls", calls.Load())
+	}
+}
+
+func TestHealthCache_BackgroundRefresh(t *testing.T) {
+	var calls atomic.Int32
+	cache := NewHealthCache(func(ctx context.Context) error {
+		calls.Add(1)
+		return nil
+	}, 40*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cache.Start(ctx)
+	cancel() // startup contexts do not stop the refresh
+	time.Sleep(100 * time.Millisecond)
+	if err := cache.Check(context.Background()); err != nil {
+		t.Fatalf("Check() = %v", err)
+	}
+	refreshed := calls.Load()
+	if refreshed < 2 {
+		t.Errorf("Expected background refreshes, got %d calls", refreshed)
+	}
+
+	cache.Close()
+	time.Sleep(100 * time.Millisecond)
+	if calls.Load() > refreshed+1 {
+		t.Errorf("Expected Close to stop the refresh, got %d more calls", calls.Load()-refreshed)
+	}
+}
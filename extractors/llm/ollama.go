@@ -0,0 +1,126 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// DefaultOllamaURL is the Ollama host used when neither BaseURL nor OLLAMA_HOST is set
+const DefaultOllamaURL = "http://localhost:11434"
+
+// OllamaClient calls the generate API of a local or remote Ollama host. It needs no
+// adapter: Ollama clients are always created by this package.
+type OllamaClient struct {
+	url     string
+	model   string
+	apiKey  string
+	headers map[string]string
+	options map[string]any
+	http    *http.Client
+}
+
+// NewOllamaClient creates a client for an Ollama host. The host is the config's BaseURL,
+// the OLLAMA_HOST environment variable or DefaultOllamaURL. A configured API key is sent
+// as a bearer token, for hosts behind an authenticating proxy.
+func NewOllamaClient(config ClientConfig) (*OllamaClient, error) {
+	baseURL := config.BaseURL
+	if baseURL == "" {
+		baseURL = os.Getenv("OLLAMA_HOST")
+	}
+	if baseURL == "" {
+		baseURL = DefaultOllamaURL
+	}
+	// OLLAMA_HOST is often a bare host:port
+	if !strings.Contains(baseURL, "://") {
+		baseURL = "http://" + baseURL
+	}
+
+	httpClient, err := NewHTTPClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ollama takes generation options under its own names
+	options := make(map[string]any)
+	if config.Options.Temperature != nil {
+		options["temperature"] = *config.Options.Temperature
+	}
+	if config.Options.MaxTokens > 0 {
+		options["num_predict"] = config.Options.MaxTokens
+	}
+	if config.Options.TopP != nil {
+		options["top_p"] = *config.Options.TopP
+	}
+	if len(config.Options.Stop) > 0 {
+		options["stop"] = config.Options.Stop
+	}
+	for key, value := range config.Options.Extra {
+		options[key] = value
+	}
+
+	return &OllamaClient{
+		url:     strings.TrimSuffix(baseURL, "/") + "/api/generate",
+		model:   config.Model,
+		apiKey:  config.APIKey,
+		headers: config.Options.Headers,
+		options: options,
+		http:    httpClient,
+	}, nil
+}
+
+// Generate implements Client
+func (c *OllamaClient) Generate(ctx context.Context, prompt string) (string, error) {
+	body, err := json.Marshal(map[string]any{
+		"model":   c.model,
+		"prompt":  prompt,
+		"stream":  false,
+		"options": c.options,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+	for name, value := range c.headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var reply struct {
+		Response string `json:"response"`
+		Error    string `json:"error"`
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		message := strings.TrimSpace(string(data))
+		if json.Unmarshal(data, &reply) == nil && reply.Error != "" {
+			message = reply.Error
+		}
+		return "", NewStatusError(resp, message)
+	}
+	if err := json.Unmarshal(data, &reply); err != nil {
+		return "", fmt.Errorf("invalid Ollama response: %w", err)
+	}
+	return reply.Response, nil
+}
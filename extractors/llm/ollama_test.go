@@ -0,0 +1,90 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOllamaClient_RemoteTLSHost(t *testing.T) {
+	var request struct {
+		Model   string         `json:"model"`
+		Prompt  string         `json:"prompt"`
+		Stream  bool           `json:"stream"`
+		Options map[string]any `json:"options"`
+	}
+	var header http.Header
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/generate" {
+			http.NotFound(w, r)
+			return
+		}
+		header = r.Header
+		json.NewDecoder(r.Body).Decode(&request)
+		if request.Model == "missing" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "model \"missing\" not found"}`))
+			return
+		}
+		w.Write([]byte(`{"model": "llama3.2", "response": "OK", "done": true}`))
+	}))
+	defer server.Close()
+
+	// Trust the test server through a CA file, as for an on-prem host
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	certificate := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, certificate, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	options, err := ParseOptions(map[string]any{
+		OptionMaxTokens: 128,
+		OptionStop:      "END",
+		OptionHeaders:   map[string]any{"X-Team": "privacy"},
+		OptionTLSCAFile: caFile,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := NewClient(ClientConfig{Provider: ProviderOllama, APIKey: "proxy-token", BaseURL: server.URL + "/", Options: options})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	response, err := client.Generate(context.Background(), "Respond with 'OK'")
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if response != "OK" {
+		t.Errorf("Expected the generated response, got %q", response)
+	}
+	if request.Model != "llama3.2" || request.Stream || request.Options["num_predict"] != 128.0 {
+		t.Errorf("Expected the default model, no streaming and num_predict, got %+v", request)
+	}
+	if header.Get("Authorization") != "Bearer proxy-token" || header.Get("X-Team") != "privacy" {
+		t.Errorf("Expected the auth and custom headers, got %v", header)
+	}
+
+	missing, err := NewClient(ClientConfig{Provider: ProviderOllama, Model: "missing", BaseURL: server.URL, Options: options})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var statusErr *StatusError
+	if _, err := missing.Generate(context.Background(), "hi"); !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusNotFound || statusErr.Message != `model "missing" not found` {
+		t.Errorf("Expected a StatusError with the Ollama message, got %v", err)
+	}
+
+	// Without the CA the host is not trusted
+	untrusted, err := NewClient(ClientConfig{Provider: ProviderOllama, BaseURL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := untrusted.Generate(context.Background(), "hi"); err == nil {
+		t.Error("Expected a certificate error without the CA file")
+	}
+}
@@ -0,0 +1,230 @@
+package llm
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+)
+
+// Provider option keys read by ParseOptions
+const (
+	OptionTemperature  = "temperature"  // number
+	OptionMaxTokens    = "max_tokens"   // integer
+	OptionTopP         = "top_p"        // number
+	OptionStop         = "stop"         // string or list of strings
+	OptionOrganization = "organization" // OpenAI organization ID
+	OptionProject      = "project"      // OpenAI project ID
+	OptionHeaders      = "headers"      // map of extra HTTP headers
+	OptionAPIVersion   = "api_version"  // Azure OpenAI api-version
+	OptionExtra        = "extra"        // map of request fields passed to the provider as is
+
+	OptionTLSCAFile             = "tls_ca_file"              // PEM CA bundle trusted for the host
+	OptionTLSCertFile           = "tls_cert_file"            // PEM client certificate
+	OptionTLSKeyFile            = "tls_key_file"             // PEM client key
+	OptionTLSServerName         = "tls_server_name"          // expected server name
+	OptionTLSInsecureSkipVerify = "tls_insecure_skip_verify" // disables verification (testing only)
+)
+
+// Options are the generation and transport options of a client
+type Options struct {
+	Temperature *float64
+	MaxTokens   int
+	TopP        *float64
+	Stop        []string
+
+	// Organization and Project select the OpenAI organization and project billed for
+	// the requests
+	Organization string
+	Project      string
+
+	// Headers are added to every request, such as gateway authentication headers
+	Headers map[string]string
+
+	// APIVersion is the api-version of Azure OpenAI deployments
+	APIVersion string
+
+	// Extra holds provider request fields without a dedicated option, such as
+	// "frequency_penalty" or "seed"
+	Extra map[string]any
+
+	// TLS configures connections to self-hosted providers (Ollama)
+	TLS TLSOptions
+}
+
+// TLSOptions configure TLS connections to a provider host
+type TLSOptions struct {
+	CAFile             string
+	CertFile           string
+	KeyFile            string
+	ServerName         string
+	InsecureSkipVerify bool
+}
+
+// Config returns the TLS configuration of the options, or nil when they are all empty
+func (t TLSOptions) Config() (*tls.Config, error) {
+	if t == (TLSOptions{}) {
+		return nil, nil
+	}
+
+	config := &tls.Config{ServerName: t.ServerName, InsecureSkipVerify: t.InsecureSkipVerify}
+	if t.CAFile != "" {
+		pem, err := os.ReadFile(t.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading TLS CA file: %w", err)
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificate found in TLS CA file %s", t.CAFile)
+		}
+	}
+	if t.CertFile != "" || t.KeyFile != "" {
+		certificate, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading TLS client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+	return config, nil
+}
+
+// ParseOptions reads the options of a provider options map, such as
+// ValidationConfig.ProviderOptions. Values may have their Go types or the types produced
+// by JSON decoding (float64 numbers, []any lists, map[string]any objects). Unknown keys
+// are ignored.
+func ParseOptions(values map[string]any) (Options, error) {
+	var options Options
+	for key, value := range values {
+		var err error
+		switch key {
+		case OptionTemperature:
+			options.Temperature, err = floatOption(value)
+		case OptionMaxTokens:
+			options.MaxTokens, err = intOption(value)
+		case OptionTopP:
+			options.TopP, err = floatOption(value)
+		case OptionStop:
+			options.Stop, err = stringsOption(value)
+		case OptionOrganization:
+			options.Organization, err = stringOption(value)
+		case OptionProject:
+			options.Project, err = stringOption(value)
+		case OptionHeaders:
+			options.Headers, err = headersOption(value)
+		case OptionAPIVersion:
+			options.APIVersion, err = stringOption(value)
+		case OptionExtra:
+			extra, ok := value.(map[string]any)
+			if !ok {
+				err = fmt.Errorf("expected an object, got %T", value)
+			}
+			options.Extra = extra
+		case OptionTLSCAFile:
+			options.TLS.CAFile, err = stringOption(value)
+		case OptionTLSCertFile:
+			options.TLS.CertFile, err = stringOption(value)
+		case OptionTLSKeyFile:
+			options.TLS.KeyFile, err = stringOption(value)
+		case OptionTLSServerName:
+			options.TLS.ServerName, err = stringOption(value)
+		case OptionTLSInsecureSkipVerify:
+			var ok bool
+			if options.TLS.InsecureSkipVerify, ok = value.(bool); !ok {
+				err = fmt.Errorf("expected a boolean, got %T", value)
+			}
+		}
+		if err != nil {
+			return Options{}, fmt.Errorf("provider option %q: %w", key, err)
+		}
+	}
+	return options, nil
+}
+
+func floatOption(value any) (*float64, error) {
+	var f float64
+	switch v := value.(type) {
+	case float64:
+		f = v
+	case float32:
+		f = float64(v)
+	case int:
+		f = float64(v)
+	case json.Number:
+		var err error
+		if f, err = v.Float64(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("expected a number, got %T", value)
+	}
+	return &f, nil
+}
+
+func intOption(value any) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("expected an integer, got %v", v)
+		}
+		return int(v), nil
+	case json.Number:
+		n, err := v.Int64()
+		return int(n), err
+	default:
+		return 0, fmt.Errorf("expected an integer, got %T", value)
+	}
+}
+
+func stringOption(value any) (string, error) {
+	s, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("expected a string, got %T", value)
+	}
+	return s, nil
+}
+
+func stringsOption(value any) ([]string, error) {
+	switch v := value.(type) {
+	case string:
+		return []string{v}, nil
+	case []string:
+		return v, nil
+	case []any:
+		list := make([]string, len(v))
+		for i, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("expected strings, got %T", item)
+			}
+			list[i] = s
+		}
+		return list, nil
+	default:
+		return nil, fmt.Errorf("expected a string or a list of strings, got %T", value)
+	}
+}
+
+func headersOption(value any) (map[string]string, error) {
+	switch v := value.(type) {
+	case map[string]string:
+		return v, nil
+	case map[string]any:
+		headers := make(map[string]string, len(v))
+		for name, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("header %q: expected a string, got %T", name, item)
+			}
+			headers[name] = s
+		}
+		return headers, nil
+	default:
+		return nil, fmt.Errorf("expected an object, got %T", value)
+	}
+}
@@ -0,0 +1,53 @@
+package llm
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestParseOptions(t *testing.T) {
+	var values map[string]any
+	err := json.Unmarshal([]byte(`{
+		"temperature": 0, "max_tokens": 512, "top_p": 0.9, "stop": ["\n\n", "END"],
+		"organization": "org-1", "project": "proj-1", "api_version": "2024-06-01",
+		"headers": {"X-Gateway-Key": "secret"}, "extra": {"seed": 7}, "unknown": true
+	}`), &values)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	options, err := ParseOptions(values)
+	if err != nil {
+		t.Fatalf("ParseOptions() error = %v", err)
+	}
+	if options.Temperature == nil || *options.Temperature != 0 || options.TopP == nil || *options.TopP != 0.9 {
+		t.Errorf("Expected temperature 0 and top_p 0.9, got %v and %v", options.Temperature, options.TopP)
+	}
+	if options.MaxTokens != 512 || !slices.Equal(options.Stop, []string{"\n\n", "END"}) {
+		t.Errorf("Unexpected max_tokens %d or stop %q", options.MaxTokens, options.Stop)
+	}
+	if options.Organization != "org-1" || options.Project != "proj-1" || options.APIVersion != "2024-06-01" {
+		t.Errorf("Unexpected identifiers %+v", options)
+	}
+	if options.Headers["X-Gateway-Key"] != "secret" || options.Extra["seed"] != 7.0 {
+		t.Errorf("Unexpected headers %v or extra %v", options.Headers, options.Extra)
+	}
+
+	// Go-typed values as set in code
+	options, err = ParseOptions(map[string]any{"temperature": float32(0.5), "stop": "###", "max_tokens": 64})
+	if err != nil || *options.Temperature != 0.5 || options.Stop[0] != "###" || options.MaxTokens != 64 {
+		t.Errorf("Unexpected options %+v (error %v)", options, err)
+	}
+
+	for _, invalid := range []map[string]any{
+		{"max_tokens": 1.5},
+		{"stop": []any{"ok", 3}},
+		{"headers": map[string]any{"X-Retry": 2}},
+		{"top_p": "high"},
+	} {
+		if _, err := ParseOptions(invalid); err == nil {
+			t.Errorf("Expected an error for %v", invalid)
+		}
+	}
+}
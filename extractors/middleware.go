@@ -0,0 +1,222 @@
+package extractors
+
+import (
+	"context"
+	"io"
+	"slices"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// PreProcessor transforms the text before extraction, e.g. to normalize Unicode or strip
+// markup. Entity contexts and offsets then refer to the transformed text.
+type PreProcessor func(ctx context.Context, text string) (string, error)
+
+// PostProcessor transforms the result after extraction, e.g. to filter, enrich or audit
+// entities. text is the (pre-processed) text the result was extracted from.
+type PostProcessor func(ctx context.Context, text string, result *pii.PiiExtractionResult) (*pii.PiiExtractionResult, error)
+
+// MiddlewareOption configures a Middleware
+type MiddlewareOption func(*Middleware)
+
+// WithPreProcessor appends a pre-processor, run in the order they were added
+func WithPreProcessor(fn PreProcessor) MiddlewareOption {
+	return func(m *Middleware) {
+		m.pre = append(m.pre, fn)
+	}
+}
+
+// WithPostProcessor appends a post-processor, run in the order they were added
+func WithPostProcessor(fn PostProcessor) MiddlewareOption {
+	return func(m *Middleware) {
+		m.post = append(m.post, fn)
+	}
+}
+
+// Middleware wraps any extractor with pre- and post-processing steps, applied uniformly
+// to Extract, ExtractContext and ExtractByType. It implements PiiExtractor and forwards
+// the lifecycle interfaces of the wrapped extractor.
+type Middleware struct {
+	extractor PiiExtractor
+	pre       []PreProcessor
+	post      []PostProcessor
+}
+
+// WithMiddleware wraps an extractor with the given processors
+func WithMiddleware(extractor PiiExtractor, options ...MiddlewareOption) *Middleware {
+	m := &Middleware{extractor: extractor}
+
+	// Wrapping a middleware extends its chain instead of nesting
+	if inner, ok := extractor.(*Middleware); ok {
+		m.extractor = inner.extractor
+		m.pre = slices.Clone(inner.pre)
+		m.post = slices.Clone(inner.post)
+	}
+	for _, option := range options {
+		option(m)
+	}
+	return m
+}
+
+// Unwrap returns the wrapped extractor
+func (m *Middleware) Unwrap() PiiExtractor {
+	return m.extractor
+}
+
+// Extract runs the processors around the wrapped extractor
+func (m *Middleware) Extract(text string) (*pii.PiiExtractionResult, error) {
+	return m.ExtractContext(context.Background(), text)
+}
+
+// ExtractContext runs the processors around the wrapped extractor under ctx
+func (m *Middleware) ExtractContext(ctx context.Context, text string) (*pii.PiiExtractionResult, error) {
+	text, err := m.preProcess(ctx, text)
+	if err != nil {
+		return nil, err
+	}
+	result, err := ExtractWithContext(ctx, m.extractor, text)
+	if err != nil {
+		return nil, err
+	}
+	return m.postProcess(ctx, text, result)
+}
+
+// ExtractByType runs the processors around the wrapped extractor's ExtractByType. Post-processors
+// receive a result holding the entities of that type; entities of other types they add are dropped.
+func (m *Middleware) ExtractByType(text string, piiType pii.PiiType) ([]pii.PiiEntity, error) {
+	ctx := context.Background()
+	text, err := m.preProcess(ctx, text)
+	if err != nil {
+		return nil, err
+	}
+	entities, err := m.extractor.ExtractByType(text, piiType)
+	if err != nil {
+		return nil, err
+	}
+	if len(m.post) == 0 {
+		return entities, nil
+	}
+
+	result, err := m.postProcess(ctx, text, pii.NewPiiExtractionResult(entities))
+	if err != nil {
+		return nil, err
+	}
+	return result.GetEntitiesByType(piiType), nil
+}
+
+// preProcess runs the pre-processors in order
+func (m *Middleware) preProcess(ctx context.Context, text string) (string, error) {
+	for _, fn := range m.pre {
+		var err error
+		if text, err = fn(ctx, text); err != nil {
+			return "", err
+		}
+	}
+	return text, nil
+}
+
+// postProcess runs the post-processors in order
+func (m *Middleware) postProcess(ctx context.Context, text string, result *pii.PiiExtractionResult) (*pii.PiiExtractionResult, error) {
+	for _, fn := range m.post {
+		var err error
+		if result, err = fn(ctx, text, result); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// GetSupportedTypes returns the types supported by the wrapped extractor
+func (m *Middleware) GetSupportedTypes() []pii.PiiType {
+	return m.extractor.GetSupportedTypes()
+}
+
+// Support returns the support of the wrapped extractor
+func (m *Middleware) Support() []Support {
+	return SupportOf(m.extractor)
+}
+
+// GetMethod returns the method of the wrapped extractor
+func (m *Middleware) GetMethod() ExtractionMethod {
+	return m.extractor.GetMethod()
+}
+
+// GetName returns the name of the wrapped extractor
+func (m *Middleware) GetName() string {
+	return m.extractor.GetName()
+}
+
+// Start starts the wrapped extractor if it implements Starter
+func (m *Middleware) Start(ctx context.Context) error {
+	if starter, ok := m.extractor.(Starter); ok {
+		return starter.Start(ctx)
+	}
+	return nil
+}
+
+// HealthCheck checks the wrapped extractor if it implements HealthChecker
+func (m *Middleware) HealthCheck(ctx context.Context) error {
+	if checker, ok := m.extractor.(HealthChecker); ok {
+		return checker.HealthCheck(ctx)
+	}
+	return nil
+}
+
+// Close closes the wrapped extractor if it implements io.Closer
+func (m *Middleware) Close() error {
+	if closer, ok := m.extractor.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// ReasonFiltered is the reason of the candidates removed by FilterEntities
+const ReasonFiltered = "filtered"
+
+// FilterEntities returns a post-processor keeping only the entities accepted by keep. The
+// removed entities are recorded in the Suppressed section of the result, once per
+// occurrence in the text, and the validation stats are recounted for the kept entities.
+func FilterEntities(keep func(entity pii.PiiEntity) bool) PostProcessor {
+	return func(_ context.Context, text string, result *pii.PiiExtractionResult) (*pii.PiiExtractionResult, error) {
+		kept := make([]pii.PiiEntity, 0, len(result.Entities))
+		suppressed := slices.Clone(result.Suppressed)
+		for _, entity := range result.Entities {
+			if keep(entity) {
+				kept = append(kept, entity)
+				continue
+			}
+			suppressed = append(suppressed, filteredCandidates(entity, text)...)
+		}
+
+		filtered := pii.NewPiiExtractionResult(kept)
+		filtered.PatternSetVersion = result.PatternSetVersion
+		filtered.Suppressed = suppressed
+		if result.ValidationStats != nil {
+			stats := *result.ValidationStats
+			stats.Count(filtered.Entities)
+			filtered.ValidationStats = &stats
+		}
+		return filtered, nil
+	}
+}
+
+// filteredCandidates returns the suppressed candidates of an entity removed by
+// FilterEntities, one per occurrence in text, or a single one without a span when it
+// cannot be located
+func filteredCandidates(entity pii.PiiEntity, text string) []pii.SuppressedCandidate {
+	occurrences := entity.OccurrencesIn(text)
+	if len(occurrences) == 0 {
+		return []pii.SuppressedCandidate{{Type: entity.Type, Value: entity.GetValue(), Reason: ReasonFiltered}}
+	}
+	candidates := make([]pii.SuppressedCandidate, 0, len(occurrences))
+	for _, occurrence := range occurrences {
+		candidates = append(candidates, pii.SuppressedCandidate{
+			Type:   entity.Type,
+			Value:  entity.GetValue(),
+			Start:  occurrence.Start,
+			End:    occurrence.End,
+			Reason: ReasonFiltered,
+		})
+	}
+	return candidates
+}
@@ -0,0 +1,143 @@
+package extractors
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// emailExtractor reports every whitespace-separated word containing '@' as an email
+type emailExtractor struct {
+	lifecycleExtractor
+	seen string
+}
+
+func (e *emailExtractor) Extract(text string) (*pii.PiiExtractionResult, error) {
+	e.seen = text
+	var entities []pii.PiiEntity
+	for _, word := range strings.Fields(text) {
+		if strings.Contains(word, "@") {
+			entities = append(entities, pii.PiiEntity{Type: pii.PiiTypeEmail, Value: pii.NewEmail(word)})
+		}
+	}
+	return pii.NewPiiExtractionResult(entities), nil
+}
+
+func (e *emailExtractor) ExtractByType(text string, piiType pii.PiiType) ([]pii.PiiEntity, error) {
+	result, err := e.Extract(text)
+	if err != nil {
+		return nil, err
+	}
+	return result.GetEntitiesByType(piiType), nil
+}
+
+func TestMiddleware_Chain(t *testing.T) {
+	base := &emailExtractor{}
+	var audited []int
+
+	extractor := WithMiddleware(base,
+		WithPreProcessor(func(_ context.Context, text string) (string, error) {
+			return strings.ToLower(text), nil
+		}),
+		WithPostProcessor(FilterEntities(func(entity pii.PiiEntity) bool {
+			return !strings.HasSuffix(entity.GetValue(), "@example.com")
+		})),
+		WithPostProcessor(func(_ context.Context, _ string, result *pii.PiiExtractionResult) (*pii.PiiExtractionResult, error) {
+			audited = append(audited, result.Total)
+			return result, nil
+		}),
+	)
+
+	result, err := extractor.Extract("Mail JOHN@ACME.COM or test@example.com")
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if base.seen != "mail john@acme.com or test@example.com" {
+		t.Errorf("Expected the pre-processed text, got %q", base.seen)
+	}
+	if result.Total != 1 || result.Entities[0].GetValue() != "john@acme.com" {
+		t.Errorf("Expected only john@acme.com, got %+v", result.Entities)
+	}
+
+	entities, err := extractor.ExtractByType("test@example.com jane@acme.com", pii.PiiTypeEmail)
+	if err != nil {
+		t.Fatalf("ExtractByType() error = %v", err)
+	}
+	if len(entities) != 1 || entities[0].GetValue() != "jane@acme.com" {
+		t.Errorf("Expected post-processors on ExtractByType, got %+v", entities)
+	}
+	if len(audited) != 2 || audited[0] != 1 {
+		t.Errorf("Expected the audit hook to run after filtering on every call, got %v", audited)
+	}
+}
+
+func TestMiddleware_ErrorsAndLifecycle(t *testing.T) {
+	base := &emailExtractor{}
+	failure := errors.New("normalization failed")
+	extractor := WithMiddleware(base, WithPreProcessor(func(context.Context, string) (string, error) {
+		return "", failure
+	}))
+
+	if _, err := extractor.Extract("john@acme.com"); !errors.Is(err, failure) {
+		t.Errorf("Expected the pre-processor error, got %v", err)
+	}
+
+	// Wrapping twice extends the chain instead of nesting middlewares
+	extended := WithMiddleware(extractor, WithPostProcessor(FilterEntities(func(pii.PiiEntity) bool { return true })))
+	if extended.Unwrap() != PiiExtractor(base) || len(extended.pre) != 1 || len(extended.post) != 1 {
+		t.Errorf("Expected a flattened chain, got %d pre and %d post processors", len(extended.pre), len(extended.post))
+	}
+
+	registry := NewRegistry()
+	if err := registry.Register("wrapped", extended); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if err := registry.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if err := registry.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if !base.started || !base.closed {
+		t.Error("Expected lifecycle calls to reach the wrapped extractor")
+	}
+	if extended.GetName() != "lifecycle" || extended.GetMethod() != MethodLLM {
+		t.Error("Expected metadata to come from the wrapped extractor")
+	}
+}
+
+func TestFilterEntities_SuppressedAndValidationStats(t *testing.T) {
+	text := "john@acme.com test@example.com"
+	valid := pii.NewPiiExtractionResult([]pii.PiiEntity{
+		{Type: pii.PiiTypeEmail, Value: pii.NewEmail("john@acme.com"), Validation: &pii.ValidationResult{Valid: true, Confidence: 0.9}},
+		{Type: pii.PiiTypeEmail, Value: pii.NewEmail("test@example.com"), Validation: &pii.ValidationResult{Valid: false, Confidence: 0.8}},
+	})
+	valid.Suppressed = []pii.SuppressedCandidate{{Type: pii.PiiTypeIPAddress, Value: "999.1.1.1", Reason: "false_positive_filter"}}
+	valid.ValidationStats = &pii.ValidationStats{}
+	valid.ValidationStats.Count(valid.Entities)
+
+	filter := FilterEntities(func(entity pii.PiiEntity) bool {
+		return !strings.HasSuffix(entity.GetValue(), "@example.com")
+	})
+	result, err := filter(context.Background(), text, valid)
+	if err != nil {
+		t.Fatalf("FilterEntities() error = %v", err)
+	}
+
+	if len(result.Suppressed) != 2 || result.Suppressed[0].Value != "999.1.1.1" {
+		t.Fatalf("Expected the suppressed candidates kept and the removed entity added, got %+v", result.Suppressed)
+	}
+	removed := result.Suppressed[1]
+	if removed.Reason != ReasonFiltered || text[removed.Start:removed.End] != "test@example.com" {
+		t.Errorf("Expected the removed entity at its offset, got %+v", removed)
+	}
+	if stats := result.ValidationStats; stats == nil || stats.TotalValidated != 1 || stats.InvalidCount != 0 {
+		t.Errorf("Expected the validation stats of the kept entity, got %+v", stats)
+	}
+	if valid.ValidationStats.TotalValidated != 2 {
+		t.Errorf("Expected the input stats untouched, got %+v", valid.ValidationStats)
+	}
+}
@@ -0,0 +1,95 @@
+// Package plugins loads custom extractor backends built with `go build -buildmode=plugin`.
+//
+// A plugin is a Go `main` package that exports two symbols:
+//
+//	var PiiExtractorABIVersion = plugins.ABIVersion
+//
+//	func NewPiiExtractor(options map[string]any) (extractors.PiiExtractor, error)
+//
+// Go plugins must be built with the same Go toolchain and the same version of this
+// module as the host, and are only supported on Linux, FreeBSD and macOS.
+package plugins
+
+import (
+	"fmt"
+	"plugin"
+
+	"github.com/intMeric/pii-extractor/extractors"
+)
+
+// ABIVersion is the plugin interface version implemented by this host
+const ABIVersion = "v1"
+
+// Symbols looked up in a plugin
+const (
+	VersionSymbol     = "PiiExtractorABIVersion"
+	ConstructorSymbol = "NewPiiExtractor"
+)
+
+// Constructor is the signature of the NewPiiExtractor symbol exported by a plugin
+type Constructor func(options map[string]any) (extractors.PiiExtractor, error)
+
+// Load opens the plugin at path and creates its extractor with the given options
+func Load(path string, options map[string]any) (extractors.PiiExtractor, error) {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open plugin %s: %w", path, err)
+	}
+
+	extractor, err := newFromSymbols(p.Lookup, options)
+	if err != nil {
+		return nil, fmt.Errorf("plugin %s: %w", path, err)
+	}
+	return extractor, nil
+}
+
+// LoadAndRegister loads the plugin at path and registers its extractor under name
+func LoadAndRegister(registry *extractors.Registry, name, path string, options map[string]any) (extractors.PiiExtractor, error) {
+	extractor, err := Load(path, options)
+	if err != nil {
+		return nil, err
+	}
+	if err := registry.Register(name, extractor); err != nil {
+		return nil, err
+	}
+	return extractor, nil
+}
+
+// newFromSymbols checks the plugin ABI version and calls its constructor
+func newFromSymbols(lookup func(string) (plugin.Symbol, error), options map[string]any) (extractors.PiiExtractor, error) {
+	versionSymbol, err := lookup(VersionSymbol)
+	if err != nil {
+		return nil, fmt.Errorf("missing %s symbol: %w", VersionSymbol, err)
+	}
+	version, ok := versionSymbol.(*string)
+	if !ok {
+		return nil, fmt.Errorf("%s must be a string variable, got %T", VersionSymbol, versionSymbol)
+	}
+	if *version != ABIVersion {
+		return nil, fmt.Errorf("unsupported ABI version %q (host supports %q)", *version, ABIVersion)
+	}
+
+	constructorSymbol, err := lookup(ConstructorSymbol)
+	if err != nil {
+		return nil, fmt.Errorf("missing %s symbol: %w", ConstructorSymbol, err)
+	}
+
+	var constructor Constructor
+	switch fn := constructorSymbol.(type) {
+	case func(map[string]any) (extractors.PiiExtractor, error):
+		constructor = fn
+	case *Constructor:
+		constructor = *fn
+	default:
+		return nil, fmt.Errorf("%s has unexpected signature %T", ConstructorSymbol, constructorSymbol)
+	}
+
+	extractor, err := constructor(options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create extractor: %w", err)
+	}
+	if extractor == nil {
+		return nil, fmt.Errorf("%s returned a nil extractor", ConstructorSymbol)
+	}
+	return extractor, nil
+}
@@ -0,0 +1,64 @@
+package plugins
+
+import (
+	"fmt"
+	"plugin"
+	"strings"
+	"testing"
+
+	"github.com/intMeric/pii-extractor/extractors"
+	"github.com/intMeric/pii-extractor/extractors/regex"
+)
+
+func symbols(version string, constructor any) func(string) (plugin.Symbol, error) {
+	return func(name string) (plugin.Symbol, error) {
+		switch name {
+		case VersionSymbol:
+			return &version, nil
+		case ConstructorSymbol:
+			if constructor != nil {
+				return constructor, nil
+			}
+		}
+		return nil, fmt.Errorf("symbol %s not found", name)
+	}
+}
+
+func TestNewFromSymbols(t *testing.T) {
+	constructor := func(options map[string]any) (extractors.PiiExtractor, error) {
+		return regex.NewDefaultExtractor(), nil
+	}
+
+	extractor, err := newFromSymbols(symbols(ABIVersion, constructor), nil)
+	if err != nil {
+		t.Fatalf("newFromSymbols() error = %v", err)
+	}
+	if extractor.GetMethod() != extractors.MethodRegex {
+		t.Errorf("Expected regex extractor, got %s", extractor.GetMethod())
+	}
+}
+
+func TestNewFromSymbolsErrors(t *testing.T) {
+	valid := func(options map[string]any) (extractors.PiiExtractor, error) {
+		return regex.NewDefaultExtractor(), nil
+	}
+
+	tests := []struct {
+		name    string
+		lookup  func(string) (plugin.Symbol, error)
+		wantErr string
+	}{
+		{"version mismatch", symbols("v0", valid), "unsupported ABI version"},
+		{"missing constructor", symbols(ABIVersion, nil), "missing NewPiiExtractor"},
+		{"wrong signature", symbols(ABIVersion, func() {}), "unexpected signature"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newFromSymbols(tt.lookup, nil)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
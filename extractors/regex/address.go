@@ -0,0 +1,36 @@
+package regex
+
+import (
+	"strings"
+
+	patterns "github.com/intMeric/pii-extractor/extractors/regex/patterns"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// addressFollowingLength is how much text after an address is searched for its city and
+// postal code
+const addressFollowingLength = 80
+
+// parseAddresses fills the components of the street addresses in entities with
+// patterns.ParseAddress, reading the city and postal code after the first occurrence of each
+// address in text
+func parseAddresses(entities []pii.PiiEntity, text string) {
+	for i, entity := range entities {
+		address, ok := entity.AsStreetAddress()
+		if !ok {
+			continue
+		}
+		var following string
+		if index := strings.Index(text, address.Value); index >= 0 {
+			end := index + len(address.Value)
+			following = text[end:min(end+addressFollowingLength, len(text))]
+		}
+		parts := patterns.ParseAddress(address.Value, following)
+		address.HouseNumber = parts.HouseNumber
+		address.StreetName = parts.StreetName
+		address.StreetType = parts.StreetType
+		address.City = parts.City
+		address.PostalCode = parts.PostalCode
+		entities[i].Value = address
+	}
+}
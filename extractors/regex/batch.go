@@ -0,0 +1,112 @@
+package regex
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/intMeric/pii-extractor/extractors"
+	"github.com/intMeric/pii-extractor/pii"
+	"github.com/intMeric/pii-extractor/telemetry"
+)
+
+// OptionBatchWorkers is the ExtractorConfig option setting the number of texts of a batch
+// extracted in parallel (see ExtractBatch), 1 by default
+const OptionBatchWorkers = "batch_workers"
+
+// entityBuffers pools the entity slices filled by the scans of a batch text, which are
+// copied into its result
+var entityBuffers = sync.Pool{
+	New: func() any {
+		buffer := make([]pii.PiiEntity, 0, 16)
+		return &buffer
+	},
+}
+
+// ExtractBatch performs PII extraction on each of many small texts, such as chat messages
+func (r *RegexExtractor) ExtractBatch(texts []string) (*extractors.BatchResult, error) {
+	return r.ExtractBatchContext(context.Background(), texts)
+}
+
+// ExtractBatchContext performs PII extraction on each text, returning their results in
+// order and their summary. Unlike calling ExtractContext for each text, the scans are
+// selected once for the batch, trace spans are recorded for the batch only and entity
+// buffers are reused across texts. Texts are scanned independently, so contexts never
+// span two of them, and texts larger than ChunkSize go through ExtractContext. With the
+// batch_workers option, texts are extracted in parallel. Cancellation of ctx is checked
+// between texts; a cancelled batch returns ctx.Err() and no result.
+func (r *RegexExtractor) ExtractBatchContext(ctx context.Context, texts []string) (*extractors.BatchResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, telemetry.SpanRegexBatch, telemetry.Int("batch.size", len(texts)))
+	defer span.End()
+
+	if r.pinErr != nil {
+		span.RecordError(r.pinErr)
+		return nil, r.pinErr
+	}
+
+	scans := r.scansFor(r.types)
+	results := make([]*pii.PiiExtractionResult, len(texts))
+	var next atomic.Int64
+	work := func() {
+		for {
+			i := int(next.Add(1) - 1)
+			if i >= len(texts) || ctx.Err() != nil {
+				return
+			}
+			results[i] = r.extractBatchText(ctx, scans, texts[i])
+		}
+	}
+
+	workers := min(max(r.batchWorkers, 1), len(texts))
+	if workers <= 1 {
+		work()
+	} else {
+		span.SetAttributes(telemetry.Int("batch.workers", workers))
+		var wg sync.WaitGroup
+		for range workers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				work()
+			}()
+		}
+		wg.Wait()
+	}
+	if err := ctx.Err(); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	batch := extractors.NewBatchResult(results)
+	span.SetAttributes(telemetry.Int("pii.entities", batch.Summary.Total))
+	return batch, nil
+}
+
+// extractBatchText extracts a text of a batch with the scans of the batch, or nil when
+// ctx is cancelled
+func (r *RegexExtractor) extractBatchText(ctx context.Context, scans []scan, text string) *pii.PiiExtractionResult {
+	if len(text) > r.chunkSize {
+		result, _ := r.ExtractContext(ctx, text)
+		return result
+	}
+	if r.autoConfigure {
+		scans, _ = profileScans(scans, text)
+	}
+
+	buffer := entityBuffers.Get().(*[]pii.PiiEntity)
+	entities := (*buffer)[:0]
+	for _, s := range scans {
+		entities = append(entities, s.extract(text)...)
+	}
+	var suppressed []pii.SuppressedCandidate
+	if r.recordSuppressed {
+		suppressed = r.rejectedMatches(text, 0, r.filterScans(scans))
+	}
+	result := r.newResult(entities, text, suppressed)
+
+	// The passes filter entities in place, so the whole buffer is cleared to release them
+	clear(entities)
+	*buffer = entities[:0]
+	entityBuffers.Put(buffer)
+	return result
+}
@@ -0,0 +1,82 @@
+package regex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/intMeric/pii-extractor/extractors"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// batchTexts are chat messages, some with PII
+func batchTexts(n int) []string {
+	texts := make([]string, n)
+	for i := range texts {
+		switch i % 3 {
+		case 0:
+			texts[i] = fmt.Sprintf("hi, reach me at user%d@example.com or 555-123-%04d", i, i)
+		case 1:
+			texts[i] = "ok thanks, see you tomorrow"
+		default:
+			texts[i] = "my card is 4111 1111 1111 1111"
+		}
+	}
+	return texts
+}
+
+func TestExtractBatch_MatchesExtract(t *testing.T) {
+	texts := batchTexts(30)
+	for _, workers := range []int{1, 4} {
+		extractor := NewExtractor(&extractors.ExtractorConfig{Options: map[string]any{OptionBatchWorkers: workers}})
+		batch, err := extractor.ExtractBatch(texts)
+		if err != nil {
+			t.Fatalf("ExtractBatch() error = %v", err)
+		}
+		if len(batch.Results) != len(texts) {
+			t.Fatalf("Expected %d results, got %d", len(texts), len(batch.Results))
+		}
+		for i, text := range texts {
+			want, _ := extractor.Extract(text)
+			if got := batch.Results[i]; !sameEntities(got, want) {
+				t.Errorf("workers=%d: result of %q = %v, want %v", workers, text, got.Stats, want.Stats)
+			}
+		}
+		cards := batch.Summary.GetCreditCards()
+		if len(cards) != 1 || cards[0].GetCount() != 10 || len(batch.Summary.GetEmails()) != 10 {
+			t.Errorf("workers=%d: unexpected summary %v", workers, batch.Summary.Stats)
+		}
+	}
+}
+
+// sameEntities reports whether two results have the same values, counts and locations
+func sameEntities(a, b *pii.PiiExtractionResult) bool {
+	key := func(entity pii.PiiEntity) string {
+		return fmt.Sprintf("%s %s %d %v", entity.Type, entity.GetValue(), entity.GetCount(), entity.GetLocations())
+	}
+	var keysA, keysB []string
+	for _, entity := range a.Entities {
+		keysA = append(keysA, key(entity))
+	}
+	for _, entity := range b.Entities {
+		keysB = append(keysB, key(entity))
+	}
+	slices.Sort(keysA)
+	slices.Sort(keysB)
+	return slices.Equal(keysA, keysB)
+}
+
+func TestExtractBatch_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := NewDefaultExtractor().ExtractBatchContext(ctx, batchTexts(5)); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+
+	pinned := NewExtractor(&extractors.ExtractorConfig{PatternSetVersion: "1.0.0"})
+	if _, err := pinned.ExtractBatch(batchTexts(5)); err == nil {
+		t.Error("Expected the pinning error of the extractor")
+	}
+}
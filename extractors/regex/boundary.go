@@ -0,0 +1,96 @@
+package regex
+
+import (
+	"slices"
+
+	patterns "github.com/intMeric/pii-extractor/extractors/regex/patterns"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// boundedTypes are the identifier types whose occurrences must be whole tokens (see
+// patterns.BoundaryRejection). Emails, hostnames, IP addresses, session tokens and street
+// addresses have delimiters of their own.
+var boundedTypes = []pii.PiiType{
+	pii.PiiTypePhone, pii.PiiTypeSSN, pii.PiiTypeZipCode, pii.PiiTypePoBox, pii.PiiTypeCreditCard,
+	pii.PiiTypeBtcAddress, pii.PiiTypeIBAN, pii.PiiTypeTrackData, pii.PiiTypeNationalID,
+}
+
+// enforceBoundaries drops the occurrences of identifiers found inside larger tokens, such
+// as the card number of "ORD-4111111111111111" or digits of a base64 blob, removing their
+// count, contexts and locations. Occurrences of the same type separated by a list
+// separator, such as "555-123-4567/555-987-6543", are kept. Entities left without
+// occurrences are dropped, and entities not recording locations are kept. Dropped
+// occurrences are passed to record, unless it is nil.
+func enforceBoundaries(entities []pii.PiiEntity, text string, record func(pii.SuppressedCandidate)) []pii.PiiEntity {
+	matches := make(map[pii.PiiType][][]int)
+	for _, entity := range entities {
+		for _, location := range entity.GetLocations() {
+			matches[entity.Type] = append(matches[entity.Type], []int{location.Start, location.End})
+		}
+	}
+
+	var contexts *patterns.ContextCache
+	kept := entities[:0]
+	for _, entity := range entities {
+		locations := entity.GetLocations()
+		if !slices.Contains(boundedTypes, entity.Type) || len(locations) == 0 {
+			kept = append(kept, entity)
+			continue
+		}
+
+		var rejected []pii.Location
+		for _, location := range locations {
+			if detail := boundaryRejectionAmong(entity.Type, text, location.Start, location.End, matches[entity.Type]); detail != "" {
+				rejected = append(rejected, location)
+				if record != nil {
+					record(suppressedAt(entity, text, location.Start, location.End, detail))
+				}
+			}
+		}
+		if len(rejected) == 0 {
+			kept = append(kept, entity)
+			continue
+		}
+		if len(rejected) == len(locations) {
+			continue
+		}
+
+		// Contexts are deduplicated, so a context is removed only when no accepted
+		// occurrence has it
+		if contexts == nil {
+			contexts = patterns.NewContextCache(text)
+		}
+		var accepted, rejectedContexts []string
+		for _, location := range locations {
+			context := contexts.ExtractContext(location.Start, location.End)
+			if slices.Contains(rejected, location) {
+				rejectedContexts = append(rejectedContexts, context)
+			} else {
+				accepted = append(accepted, context)
+			}
+		}
+		rejectedContexts = slices.DeleteFunc(rejectedContexts, func(context string) bool {
+			return slices.Contains(accepted, context)
+		})
+		kept = append(kept, entity.WithoutOccurrences(rejected, rejectedContexts))
+	}
+	return kept
+}
+
+// boundaryRejectionAmong explains why a match of a bounded type is inside a larger token,
+// among the matches of its type. Phone numbers end after their extension.
+func boundaryRejectionAmong(piiType pii.PiiType, text string, start, end int, matches [][]int) string {
+	if piiType == pii.PiiTypePhone {
+		end = patterns.PhoneExtensionEnd(text, end)
+	}
+	return patterns.BoundaryRejectionAmong(text, start, end, matches)
+}
+
+// boundaryRejection explains why an identifier match is inside a larger token, from the
+// pattern set enforcing boundaries
+func (r *RegexExtractor) boundaryRejection(piiType pii.PiiType, text string, start, end int, matches [][]int) string {
+	if !r.patternSetAtLeast(boundaryVersion) || !slices.Contains(boundedTypes, piiType) {
+		return ""
+	}
+	return boundaryRejectionAmong(piiType, text, start, end, matches)
+}
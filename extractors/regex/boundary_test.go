@@ -0,0 +1,153 @@
+package regex
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/intMeric/pii-extractor/extractors"
+	patterns "github.com/intMeric/pii-extractor/extractors/regex/patterns"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// boundarySamples are identifiers of every bounded type, each found on its own
+var boundarySamples = []struct {
+	piiType pii.PiiType
+	value   string
+}{
+	{pii.PiiTypeCreditCard, "4111111111111111"},
+	{pii.PiiTypeSSN, "123-45-6789"},
+	{pii.PiiTypePhone, "555-123-4567"},
+	{pii.PiiTypeIBAN, "DE89370400440532013000"},
+	{pii.PiiTypeBtcAddress, "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa"},
+	{pii.PiiTypeNationalID, "1234567897"},
+	{pii.PiiTypeZipCode, "75008"},
+	{pii.PiiTypePoBox, "PO Box 1234"},
+}
+
+// boundaryTokens embed a value in larger tokens
+var boundaryTokens = []string{
+	"Order ORD%s shipped",
+	"Order ORD-2024-%s-A shipped",
+	"Ticket #A%sZ closed",
+	"Payload aGVsbG8+%s/d29ybGQ= received",
+	"Payload aGVsbG8gd29ybGQ+%s== received",
+	"See /archive/%s/scan.pdf",
+	"Key tok_%s_live",
+}
+
+func TestBoundaries_Standalone(t *testing.T) {
+	extractor := NewDefaultExtractor()
+	for _, sample := range boundarySamples {
+		text := fmt.Sprintf("Reference: %s, see above.", sample.value)
+		entities, err := extractor.ExtractByType(text, sample.piiType)
+		if err != nil {
+			t.Fatalf("ExtractByType() error = %v", err)
+		}
+		if !slices.ContainsFunc(entities, func(entity pii.PiiEntity) bool { return entity.GetValue() == sample.value }) {
+			t.Errorf("Expected %s %q in %q, got %+v", sample.piiType, sample.value, text, entities)
+		}
+	}
+}
+
+func TestBoundaries_InsideTokens(t *testing.T) {
+	extractor := NewDefaultExtractor()
+	for _, sample := range boundarySamples {
+		for _, token := range boundaryTokens {
+			text := fmt.Sprintf(token, sample.value)
+			start := strings.Index(text, sample.value)
+			end := start + len(sample.value)
+
+			result, err := extractor.Extract(text)
+			if err != nil {
+				t.Fatalf("Extract() error = %v", err)
+			}
+			for _, entity := range result.Entities {
+				if !slices.Contains(boundedTypes, entity.Type) {
+					continue
+				}
+				for _, location := range entity.GetLocations() {
+					if location.Start < end && start < location.End {
+						t.Errorf("%s %q found inside %q", entity.Type, text[location.Start:location.End], text)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestBoundaries_Occurrences(t *testing.T) {
+	text := "Card 4111111111111111 on file, renewed every year by the billing team on request.\n" +
+		"Order ORD-4111111111111111 shipped."
+	config := &extractors.ExtractorConfig{
+		Types:   []pii.PiiType{pii.PiiTypeCreditCard},
+		Options: map[string]any{OptionRecordSuppressed: true},
+	}
+
+	extractor := NewExtractor(config)
+	result, err := extractor.Extract(text)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	cards := result.GetCreditCards()
+	if len(cards) != 1 || cards[0].GetCount() != 1 || len(cards[0].GetLocations()) != 1 || len(cards[0].GetContexts()) != 1 {
+		t.Fatalf("Expected the card occurrence inside the order ID removed, got %+v", cards)
+	}
+	if strings.Contains(cards[0].GetContexts()[0], "ORD") {
+		t.Errorf("Expected the context of the standalone card, got %q", cards[0].GetContexts()[0])
+	}
+	if !slices.ContainsFunc(result.Suppressed, func(candidate pii.SuppressedCandidate) bool {
+		return candidate.Detail == patterns.BoundaryRejectToken
+	}) {
+		t.Errorf("Expected the rejected occurrence in the suppressed candidates, got %+v", result.Suppressed)
+	}
+
+	// Pattern sets before 1.7.0 do not enforce boundaries
+	config.PatternSetVersion = "1.6.0"
+	result, err = NewExtractor(config).Extract(text)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if cards := result.GetCreditCards(); len(cards) != 1 || cards[0].GetCount() != 2 {
+		t.Errorf("Expected both occurrences with the pinned pattern set, got %+v", cards)
+	}
+}
+
+func TestBoundaries_ListsAndExtensions(t *testing.T) {
+	extractor := NewDefaultExtractor()
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"Tel: 555-123-4567/555-987-6543", []string{"555-123-4567", "555-987-6543"}},
+		{"Call 555-123-4567x12 or 555-987-6543ext.7", []string{"555-123-4567", "555-987-6543"}},
+		{"Call 555-123-4567x12abc", nil},
+	}
+	for _, tt := range tests {
+		entities, err := extractor.ExtractByType(tt.text, pii.PiiTypePhone)
+		if err != nil {
+			t.Fatalf("ExtractByType() error = %v", err)
+		}
+		var got []string
+		for _, entity := range entities {
+			got = append(got, entity.GetValue())
+		}
+		slices.Sort(got)
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("Expected phones %q in %q, got %q", tt.want, tt.text, got)
+		}
+	}
+}
+
+func TestBoundaries_ListsPinned(t *testing.T) {
+	// Pattern sets before 1.7.0 keep rejecting US numbers joined by "/"
+	extractor := NewExtractor(&extractors.ExtractorConfig{Countries: []pii.Country{pii.CountryUS}, PatternSetVersion: "1.6.0"})
+	entities, err := extractor.ExtractByType("Tel: 555-123-4567/555-987-6543", pii.PiiTypePhone)
+	if err != nil {
+		t.Fatalf("ExtractByType() error = %v", err)
+	}
+	if len(entities) != 0 {
+		t.Errorf("Expected no phone with the pinned pattern set, got %+v", entities)
+	}
+}
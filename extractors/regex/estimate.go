@@ -0,0 +1,162 @@
+package regex
+
+import (
+	"runtime"
+	"time"
+
+	"github.com/intMeric/pii-extractor/extractors"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// ScanCost is the calibrated cost of one pattern scan per byte of input text
+type ScanCost struct {
+	NsPerByte         float64 `json:"ns_per_byte"`
+	AllocBytesPerByte float64 `json:"alloc_bytes_per_byte"`
+}
+
+// Throughput maps scan keys ("email", "phone/US", ...) to their calibrated cost
+type Throughput map[string]ScanCost
+
+// DefaultScanCost is used for scans without calibration data, such as community packs
+var DefaultScanCost = ScanCost{NsPerByte: 150, AllocBytesPerByte: 12}
+
+// DefaultThroughput holds per-pattern costs calibrated with MeasureThroughput on the
+// mixed-language benchmark corpus (single core, amd64). The corpus is PII-dense and
+// context extraction dominates the cost of each match, so sparse text scans faster;
+// re-measure on representative documents and the target hardware for tighter estimates.
+var DefaultThroughput = Throughput{
+	"email":             {NsPerByte: 219.6, AllocBytesPerByte: 13.7},
+	"credit_card":       {NsPerByte: 107.7, AllocBytesPerByte: 12.4},
+	"track_data":        {NsPerByte: 92.8, AllocBytesPerByte: 0.0},
+	"ip_address":        {NsPerByte: 476.7, AllocBytesPerByte: 14.1},
+	"btc_address":       {NsPerByte: 97.9, AllocBytesPerByte: 12.1},
+	"iban":              {NsPerByte: 90.6, AllocBytesPerByte: 12.7},
+	"hostname":          {NsPerByte: 71.0, AllocBytesPerByte: 0.9},
+	"session_token":     {NsPerByte: 135.8, AllocBytesPerByte: 0.0},
+	"phone/US":          {NsPerByte: 227.1, AllocBytesPerByte: 13.1},
+	"ssn/US":            {NsPerByte: 57.2, AllocBytesPerByte: 12.1},
+	"zip_code/US":       {NsPerByte: 176.1, AllocBytesPerByte: 13.6},
+	"street_address/US": {NsPerByte: 136.6, AllocBytesPerByte: 12.9},
+	"po_box/US":         {NsPerByte: 26.6, AllocBytesPerByte: 0.0},
+	"zip_code/GB":       {NsPerByte: 65.4, AllocBytesPerByte: 12.1},
+	"street_address/GB": {NsPerByte: 231.0, AllocBytesPerByte: 12.9},
+	"zip_code/FR":       {NsPerByte: 164.9, AllocBytesPerByte: 13.6},
+	"street_address/FR": {NsPerByte: 98.3, AllocBytesPerByte: 12.1},
+	"zip_code/ES":       {NsPerByte: 135.4, AllocBytesPerByte: 12.5},
+	"street_address/ES": {NsPerByte: 109.1, AllocBytesPerByte: 0.0},
+	"zip_code/IT":       {NsPerByte: 160.7, AllocBytesPerByte: 14.2},
+	"street_address/IT": {NsPerByte: 49.8, AllocBytesPerByte: 0.0},
+	"phone/DE":          {NsPerByte: 101.0, AllocBytesPerByte: 12.6},
+	"zip_code/DE":       {NsPerByte: 153.9, AllocBytesPerByte: 13.6},
+	"street_address/DE": {NsPerByte: 88.5, AllocBytesPerByte: 0.0},
+	"phone/CN":          {NsPerByte: 92.4, AllocBytesPerByte: 12.1},
+	"zip_code/CN":       {NsPerByte: 103.9, AllocBytesPerByte: 12.8},
+	"street_address/CN": {NsPerByte: 66.3, AllocBytesPerByte: 12.1},
+	"phone/IN":          {NsPerByte: 276.4, AllocBytesPerByte: 12.6},
+	"zip_code/IN":       {NsPerByte: 116.0, AllocBytesPerByte: 12.8},
+	"street_address/IN": {NsPerByte: 183.1, AllocBytesPerByte: 13.3},
+	"phone/XA":          {NsPerByte: 259.9, AllocBytesPerByte: 14.5},
+	"zip_code/XA":       {NsPerByte: 172.9, AllocBytesPerByte: 13.6},
+	"street_address/XA": {NsPerByte: 37.1, AllocBytesPerByte: 0.0},
+	"phone/RU":          {NsPerByte: 101.0, AllocBytesPerByte: 12.7},
+	"zip_code/RU":       {NsPerByte: 105.4, AllocBytesPerByte: 12.8},
+	"street_address/RU": {NsPerByte: 169.4, AllocBytesPerByte: 0.5},
+}
+
+// ScanEstimate is the predicted cost of extracting a document of a given length
+type ScanEstimate struct {
+	TextLength     int           `json:"text_length"`
+	Scans          int           `json:"scans"`
+	Chunks         int           `json:"chunks"`
+	Parallel       bool          `json:"parallel"`
+	Duration       time.Duration `json:"duration"`
+	AllocatedBytes int64         `json:"allocated_bytes"`   // total bytes allocated by the scans
+	PeakMemory     int64         `json:"peak_memory_bytes"` // input text plus the allocations of one chunk
+}
+
+// Estimate predicts the scan time and memory of extracting a document of textLength
+// bytes with the given config, without scanning anything, using DefaultThroughput
+func Estimate(textLength int, config *extractors.ExtractorConfig) ScanEstimate {
+	return EstimateWith(DefaultThroughput, textLength, config)
+}
+
+// EstimateWith is Estimate with a custom throughput table, such as one measured by
+// MeasureThroughput on the target hardware
+func EstimateWith(throughput Throughput, textLength int, config *extractors.ExtractorConfig) ScanEstimate {
+	extractor := NewExtractor(config)
+	scans := extractor.scansFor(extractor.types)
+
+	estimate := ScanEstimate{TextLength: textLength, Scans: len(scans), Chunks: 1}
+	if textLength > extractor.chunkSize {
+		estimate.Chunks = (textLength + extractor.chunkSize - 1) / extractor.chunkSize
+	}
+	chunkLength := min(textLength, extractor.chunkSize)
+
+	var totalNs, maxNs, allocPerByte float64
+	for _, s := range scans {
+		cost := throughput.cost(s.piiType, s.country)
+		ns := cost.NsPerByte * float64(chunkLength)
+		totalNs += ns
+		maxNs = max(maxNs, ns)
+		allocPerByte += cost.AllocBytesPerByte
+	}
+
+	// Mirrors the parallel execution threshold of ExtractContext
+	chunkNs := totalNs
+	if chunkLength > 10000 && len(scans) > 8 {
+		estimate.Parallel = true
+		workers := min(runtime.NumCPU(), len(scans))
+		chunkNs = max(totalNs/float64(workers), maxNs)
+	}
+
+	estimate.Duration = time.Duration(chunkNs * float64(textLength) / float64(max(chunkLength, 1)))
+	estimate.AllocatedBytes = int64(allocPerByte * float64(textLength))
+	estimate.PeakMemory = int64(textLength) + int64(allocPerByte*float64(chunkLength))
+	return estimate
+}
+
+// MeasureThroughput calibrates the cost of every scan of the given config on a sample
+// text representative of the documents to scan
+func MeasureThroughput(sample string, config *extractors.ExtractorConfig) Throughput {
+	extractor := NewExtractor(config)
+	throughput := make(Throughput)
+	if len(sample) == 0 {
+		return throughput
+	}
+
+	const rounds = 3
+	var before, after runtime.MemStats
+	for _, s := range extractor.scansFor(extractor.types) {
+		runtime.ReadMemStats(&before)
+		start := time.Now()
+		for range rounds {
+			s.extract(sample)
+		}
+		elapsed := time.Since(start)
+		runtime.ReadMemStats(&after)
+
+		bytes := float64(rounds * len(sample))
+		cost := ScanCost{
+			NsPerByte:         float64(elapsed.Nanoseconds()) / bytes,
+			AllocBytesPerByte: float64(after.TotalAlloc-before.TotalAlloc) / bytes,
+		}
+		throughput[scanKey(s.piiType, s.country)] = cost
+	}
+	return throughput
+}
+
+// cost returns the calibrated cost of a scan, or DefaultScanCost without calibration data
+func (t Throughput) cost(piiType pii.PiiType, country pii.Country) ScanCost {
+	if cost, ok := t[scanKey(piiType, country)]; ok {
+		return cost
+	}
+	return DefaultScanCost
+}
+
+// scanKey identifies a scan in a Throughput table
+func scanKey(piiType pii.PiiType, country pii.Country) string {
+	if country == "" {
+		return piiType.String()
+	}
+	return piiType.String() + "/" + country.String()
+}
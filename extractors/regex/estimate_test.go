@@ -0,0 +1,50 @@
+package regex
+
+import (
+	"testing"
+
+	"github.com/intMeric/pii-extractor/extractors"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+func TestEstimate(t *testing.T) {
+	all := Estimate(100_000, nil)
+	if all.Scans == 0 || all.Duration <= 0 || all.PeakMemory <= 100_000 {
+		t.Fatalf("Unexpected estimate: %+v", all)
+	}
+
+	usOnly := Estimate(100_000, &extractors.ExtractorConfig{Countries: []pii.Country{pii.CountryUS}})
+	if usOnly.Scans >= all.Scans || usOnly.AllocatedBytes >= all.AllocatedBytes {
+		t.Errorf("Expected fewer scans and allocations for US only, got %+v vs %+v", usOnly, all)
+	}
+
+	if double := Estimate(200_000, nil); double.Duration < all.Duration || double.AllocatedBytes != 2*all.AllocatedBytes {
+		t.Errorf("Expected the estimate to scale with text length, got %+v vs %+v", double, all)
+	}
+
+	large := Estimate(3*ChunkSize+1, nil)
+	if large.Chunks != 4 {
+		t.Errorf("Expected 4 chunks, got %d", large.Chunks)
+	}
+	if large.PeakMemory >= int64(3*ChunkSize+1)+large.AllocatedBytes {
+		t.Errorf("Expected peak memory to cover a single chunk of allocations, got %+v", large)
+	}
+}
+
+func TestMeasureThroughput(t *testing.T) {
+	config := &extractors.ExtractorConfig{Countries: []pii.Country{pii.CountryGB}}
+	throughput := MeasureThroughput(benchmarkText, config)
+
+	for _, s := range NewExtractor(config).scansFor(nil) {
+		if _, ok := throughput[scanKey(s.piiType, s.country)]; !ok {
+			t.Errorf("Missing throughput for %s", scanKey(s.piiType, s.country))
+		}
+		if _, ok := DefaultThroughput[scanKey(s.piiType, s.country)]; !ok {
+			t.Errorf("Missing default calibration for %s", scanKey(s.piiType, s.country))
+		}
+	}
+
+	if estimate := EstimateWith(Throughput{}, 1000, config); estimate.Duration != EstimateWith(nil, 1000, config).Duration {
+		t.Error("Expected uncalibrated scans to fall back to DefaultScanCost")
+	}
+}
@@ -0,0 +1,219 @@
+package regex
+
+import (
+	"regexp"
+	"slices"
+	"sort"
+
+	patterns "github.com/intMeric/pii-extractor/extractors/regex/patterns"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// ExclusionReason describes why a candidate span was not reported by Extract
+type ExclusionReason string
+
+const (
+	ReasonFalsePositiveFilter ExclusionReason = "false_positive_filter" // Rejected by a false-positive filter
+	ReasonCountryFiltered     ExclusionReason = "country_filtered"      // Country not enabled in the extractor config
+	ReasonTypeFiltered        ExclusionReason = "type_filtered"         // PII type not enabled in the extractor config
+	ReasonNearMiss            ExclusionReason = "near_miss"             // Looks like PII but does not match the strict pattern
+)
+
+// Explanation describes a candidate span that was excluded from the extraction result
+type Explanation struct {
+	Type    pii.PiiType     `json:"type"`
+	Country pii.Country     `json:"country,omitempty"`
+	Value   string          `json:"value"`
+	Start   int             `json:"start"`
+	End     int             `json:"end"`
+	Reason  ExclusionReason `json:"reason"`
+	Detail  string          `json:"detail,omitempty"`
+}
+
+// patternScan describes a built-in pattern scan and the filter applied to its matches
+type patternScan struct {
+	piiType pii.PiiType
+	country pii.Country // empty for international patterns
+	regex   *regexp.Regexp
+	filter  func(r *RegexExtractor, text string, start, end int) string // returns a non-empty reason when the match is rejected
+}
+
+// builtinScans lists the international pattern scans performed by Extract;
+// country-specific scans come from the registered country packs
+var builtinScans = []patternScan{
+	{piiType: pii.PiiTypeEmail, regex: patterns.EmailRegex},
+	{piiType: pii.PiiTypeCreditCard, regex: patterns.VISACreditCardRegex},
+	{piiType: pii.PiiTypeCreditCard, regex: patterns.MCCreditCardRegex},
+	{piiType: pii.PiiTypeCreditCard, regex: patterns.CreditCardRegex},
+	{piiType: pii.PiiTypeTrackData, regex: patterns.Track1Regex, filter: trackDataRejection(patterns.Track1Regex)},
+	{piiType: pii.PiiTypeTrackData, regex: patterns.Track2Regex, filter: trackDataRejection(patterns.Track2Regex)},
+	{piiType: pii.PiiTypeIPAddress, regex: patterns.IPv4Regex, filter: (*RegexExtractor).ipRejection},
+	{piiType: pii.PiiTypeIPAddress, regex: patterns.IPv6Regex, filter: (*RegexExtractor).ipRejection},
+	{piiType: pii.PiiTypeBtcAddress, regex: patterns.BtcAddressRegex},
+	{piiType: pii.PiiTypeIBAN, regex: patterns.IBANRegex},
+	{piiType: pii.PiiTypeHostname, regex: patterns.HostnameRegex, filter: (*RegexExtractor).hostnameRejection},
+}
+
+// nearMissPattern is a relaxed pattern catching values that look like PII but miss the strict pattern
+type nearMissPattern struct {
+	piiType pii.PiiType
+	country pii.Country
+	regex   *regexp.Regexp
+	detail  string
+}
+
+// nearMissPatterns lists relaxed variants of the strict patterns
+var nearMissPatterns = []nearMissPattern{
+	{
+		piiType: pii.PiiTypeSSN,
+		country: pii.CountryUS,
+		regex:   regexp.MustCompile(`\b\d{3}[ .]\d{2}[ .]\d{4}\b`),
+		detail:  "SSN-like number using spaces or dots instead of hyphens",
+	},
+	{
+		piiType: pii.PiiTypeSSN,
+		country: pii.CountryUS,
+		regex:   regexp.MustCompile(`\b\d{2,4}-\d{1,3}-\d{3,5}\b`),
+		detail:  "SSN-like number with wrong digit group lengths (expected 3-2-4)",
+	},
+	{
+		piiType: pii.PiiTypeEmail,
+		regex:   regexp.MustCompile(`\b[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+\b`),
+		detail:  "email-like value without a domain suffix",
+	},
+	{
+		piiType: pii.PiiTypeCreditCard,
+		regex:   regexp.MustCompile(`\b\d(?:[\s-]?\d){12,18}\b`),
+		detail:  "card-length digit sequence not in a recognized grouping",
+	},
+	{
+		piiType: pii.PiiTypeIPAddress,
+		regex:   regexp.MustCompile(`\b\d{1,3}(?:\.\d{1,3}){3}\b`),
+		detail:  "dotted quad with an octet out of range",
+	},
+}
+
+// Explain returns the candidate spans that Extract would not report for the given text,
+// along with the reason each one was excluded. It is intended for debugging patterns
+// and configuration, not for production scanning.
+func (r *RegexExtractor) Explain(text string) []Explanation {
+	var explanations []Explanation
+	matched := make(map[pii.PiiType][][]int)
+
+	for _, scan := range r.explainScans() {
+		indices := patterns.MatchWithIndices(text, scan.regex)
+		if len(indices) == 0 {
+			continue
+		}
+		matched[scan.piiType] = append(matched[scan.piiType], indices...)
+
+		reason, detail := r.configExclusion(scan.piiType, scan.country)
+		for _, idx := range indices {
+			value := text[idx[0]:idx[1]]
+			explanation := Explanation{
+				Type:    scan.piiType,
+				Country: scan.country,
+				Value:   value,
+				Start:   idx[0],
+				End:     idx[1],
+			}
+
+			if reason != "" {
+				explanation.Reason = reason
+				explanation.Detail = detail
+				explanations = append(explanations, explanation)
+				continue
+			}
+
+			filterDetail := r.boundaryRejection(scan.piiType, text, idx[0], idx[1], indices)
+			if filterDetail == "" {
+				filterDetail = r.numericRejection(scan.piiType, text, idx[0], idx[1])
+			}
+			if filterDetail == "" && scan.filter != nil {
+				filterDetail = scan.filter(r, text, idx[0], idx[1])
+			}
+			if filterDetail != "" {
+				explanation.Reason = ReasonFalsePositiveFilter
+				explanation.Detail = filterDetail
+				explanations = append(explanations, explanation)
+			}
+		}
+	}
+
+	for _, nearMiss := range nearMissPatterns {
+		for _, idx := range patterns.MatchWithIndices(text, nearMiss.regex) {
+			if overlapsAny(idx, matched[nearMiss.piiType]) {
+				continue
+			}
+			explanations = append(explanations, Explanation{
+				Type:    nearMiss.piiType,
+				Country: nearMiss.country,
+				Value:   text[idx[0]:idx[1]],
+				Start:   idx[0],
+				End:     idx[1],
+				Reason:  ReasonNearMiss,
+				Detail:  nearMiss.detail,
+			})
+		}
+	}
+
+	sort.SliceStable(explanations, func(i, j int) bool {
+		return explanations[i].Start < explanations[j].Start
+	})
+
+	return explanations
+}
+
+// explainScans returns the built-in scans followed by the scans of every registered country
+// pack and of the external rules
+func (r *RegexExtractor) explainScans() []patternScan {
+	scans := append([]patternScan(nil), builtinScans...)
+	for _, pack := range registeredPacks() {
+		for _, cs := range pack.Scans {
+			if cs.Regex == nil {
+				continue
+			}
+			scans = append(scans, patternScan{piiType: cs.Type, country: pack.Country, regex: cs.Regex, filter: cs.Filter})
+		}
+	}
+	if rules := r.rules.Load(); rules != nil {
+		scans = slices.DeleteFunc(scans, func(s patternScan) bool { return rules.replaces(s.piiType, s.country) })
+		for _, rule := range rules.rules {
+			if rule.regex != nil {
+				scans = append(scans, rule.explainScan())
+			}
+		}
+	}
+	return scans
+}
+
+// configExclusion reports whether the extractor configuration disables a type or country
+func (r *RegexExtractor) configExclusion(piiType pii.PiiType, country pii.Country) (ExclusionReason, string) {
+	if len(r.types) > 0 && !containsType(r.types, piiType) {
+		return ReasonTypeFiltered, piiType.String() + " is not in the configured types"
+	}
+	if country != "" && !r.shouldExtractForCountry(country) {
+		return ReasonCountryFiltered, country.String() + " is not in the configured countries"
+	}
+	return "", ""
+}
+
+// containsType checks if a PII type is present in a list
+func containsType(types []pii.PiiType, piiType pii.PiiType) bool {
+	for _, t := range types {
+		if t == piiType {
+			return true
+		}
+	}
+	return false
+}
+
+// overlapsAny checks if a span overlaps any of the given spans
+func overlapsAny(span []int, spans [][]int) bool {
+	for _, other := range spans {
+		if span[0] < other[1] && other[0] < span[1] {
+			return true
+		}
+	}
+	return false
+}
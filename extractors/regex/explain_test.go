@@ -0,0 +1,145 @@
+package regex
+
+import (
+	"testing"
+
+	"github.com/intMeric/pii-extractor/extractors"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+func findExplanation(explanations []Explanation, value string) (Explanation, bool) {
+	for _, explanation := range explanations {
+		if explanation.Value == value {
+			return explanation, true
+		}
+	}
+	return Explanation{}, false
+}
+
+func TestExplain_FalsePositiveFilter(t *testing.T) {
+	extractor := NewDefaultExtractor()
+
+	explanations := extractor.Explain("Card: 4111-1111-1111-1111")
+
+	found := false
+	for _, explanation := range explanations {
+		if explanation.Type == pii.PiiTypePhone && explanation.Reason == ReasonFalsePositiveFilter {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected a phone candidate rejected by the false-positive filter, got %+v", explanations)
+	}
+}
+
+func TestExplain_NearMissSSN(t *testing.T) {
+	extractor := NewDefaultExtractor()
+
+	explanations := extractor.Explain("My SSN is 123 45 6789")
+
+	explanation, ok := findExplanation(explanations, "123 45 6789")
+	if !ok {
+		t.Fatalf("Expected near-miss explanation for SSN, got %+v", explanations)
+	}
+	if explanation.Reason != ReasonNearMiss || explanation.Type != pii.PiiTypeSSN {
+		t.Errorf("Expected SSN near miss, got %+v", explanation)
+	}
+}
+
+func TestExplain_StrictMatchIsNotNearMiss(t *testing.T) {
+	extractor := NewDefaultExtractor()
+
+	explanations := extractor.Explain("My SSN is 123-45-6789")
+
+	for _, explanation := range explanations {
+		if explanation.Type == pii.PiiTypeSSN {
+			t.Errorf("Did not expect an SSN explanation for a strict match, got %+v", explanation)
+		}
+	}
+}
+
+func TestExplain_ConfigFilters(t *testing.T) {
+	extractor := NewExtractor(&extractors.ExtractorConfig{
+		Countries: []pii.Country{pii.CountryFR},
+	})
+
+	explanations := extractor.Explain("SSN: 123-45-6789")
+
+	explanation, ok := findExplanation(explanations, "123-45-6789")
+	if !ok {
+		t.Fatalf("Expected explanation for filtered SSN, got %+v", explanations)
+	}
+	if explanation.Reason != ReasonCountryFiltered {
+		t.Errorf("Expected country filter reason, got %s", explanation.Reason)
+	}
+
+	extractor = NewExtractor(&extractors.ExtractorConfig{
+		Types: []pii.PiiType{pii.PiiTypeEmail},
+	})
+
+	explanations = extractor.Explain("SSN: 123-45-6789")
+
+	explanation, ok = findExplanation(explanations, "123-45-6789")
+	if !ok || explanation.Reason != ReasonTypeFiltered {
+		t.Errorf("Expected type filter reason, got %+v", explanations)
+	}
+}
+
+func TestRecordSuppressed(t *testing.T) {
+	text := "Card: 4111-1111-1111-1111, host 999.1.1.1"
+
+	plain, err := NewDefaultExtractor().Extract(text)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if len(plain.Suppressed) != 0 {
+		t.Errorf("Expected no suppressed candidates without the option, got %+v", plain.Suppressed)
+	}
+
+	config := &extractors.ExtractorConfig{Options: map[string]any{OptionRecordSuppressed: true}}
+	result, err := NewExtractor(config).Extract(text)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if len(result.Suppressed) == 0 {
+		t.Fatal("Expected suppressed candidates with the option")
+	}
+	for _, candidate := range result.Suppressed {
+		if candidate.Reason != string(ReasonFalsePositiveFilter) || candidate.Detail == "" {
+			t.Errorf("Expected a false-positive filter with its detail, got %+v", candidate)
+		}
+		if text[candidate.Start:candidate.End] != candidate.Value {
+			t.Errorf("Expected the candidate span to hold its value, got %+v", candidate)
+		}
+	}
+	if result.Total != plain.Total {
+		t.Errorf("Expected the same entities with the option, got %d instead of %d", result.Total, plain.Total)
+	}
+}
+
+func TestRecordSuppressed_Passes(t *testing.T) {
+	text := "Order ORD-4111111111111111 paid $75001, ship to 90210"
+	config := &extractors.ExtractorConfig{Options: map[string]any{OptionRecordSuppressed: true, OptionNumericGuard: true}}
+	result, err := NewExtractor(config).Extract(text)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+
+	recorded := make(map[string]pii.SuppressedCandidate)
+	for _, candidate := range result.Suppressed {
+		recorded[candidate.Value] = candidate
+	}
+	for _, value := range []string{"4111111111111111", "75001"} {
+		candidate, ok := recorded[value]
+		if !ok {
+			t.Errorf("Expected %s dropped by a pass to be recorded, got %+v", value, result.Suppressed)
+			continue
+		}
+		if text[candidate.Start:candidate.End] != value || candidate.Detail == "" {
+			t.Errorf("Expected the span and detail of %s, got %+v", value, candidate)
+		}
+	}
+	if _, ok := recorded["90210"]; ok {
+		t.Errorf("Expected the kept postal code not to be recorded, got %+v", result.Suppressed)
+	}
+}
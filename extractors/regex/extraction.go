@@ -1,44 +1,66 @@
 package regex
 
 import (
+	"net/netip"
 	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
 	"github.com/intMeric/pii-extractor/pii"
 	patterns "github.com/intMeric/pii-extractor/extractors/regex/patterns"
 )
 
 // extractWithContext is a generic function for extracting PII with context and counting
 func extractWithContext[T any](text string, regexPattern *regexp.Regexp, createItem func(value string, context string) T, updateItem func(item *T, context string)) []T {
-	indices := patterns.MatchWithIndices(text, regexPattern)
-	if len(indices) == 0 {
+	return extractWithContextFiltered(text, regexPattern, nil, createItem, updateItem)
+}
+
+// extractWithContextFiltered is extractWithContext with a span filter applied to every match
+// before it is counted; accept may be nil to keep all matches
+func extractWithContextFiltered[T any](text string, regexPattern *regexp.Regexp, accept func(text string, start, end int) bool, createItem func(value string, context string) T, updateItem func(item *T, context string)) []T {
+	return collectSpans(text, patterns.Scan(text, regexPattern, accept), createItem, updateItem)
+}
+
+// acceptUnrejected adapts a rejection filter, which explains why a match is rejected, into
+// a span filter for extractWithContextFiltered
+func acceptUnrejected(rejection func(text string, start, end int) string) func(text string, start, end int) bool {
+	return func(text string, start, end int) bool {
+		return rejection(text, start, end) == ""
+	}
+}
+
+// extractWithContextNormalized is extractWithContextFiltered matching against the text with
+// its Arabic-script digits normalized, while reporting the original values (see
+// patterns.ScanNormalized)
+func extractWithContextNormalized[T any](text string, regexPattern *regexp.Regexp, accept func(text string, start, end int) bool, createItem func(value string, context string) T, updateItem func(item *T, context string)) []T {
+	return collectSpans(text, patterns.ScanNormalized(text, regexPattern, accept), createItem, updateItem)
+}
+
+// collectSpans counts the matched spans of text by value, creating an item for the first
+// occurrence of each value and updating it for the following ones. The location of every
+// span is recorded on items embedding pii.BasePii.
+func collectSpans[T any](text string, spans []patterns.Span, createItem func(value string, context string) T, updateItem func(item *T, context string)) []T {
+	if len(spans) == 0 {
 		return []T{}
 	}
 	
 	// Pre-size map based on expected unique matches (typically 70-80% of total matches are unique)
-	expectedUnique := len(indices)*4/5 + 1
+	expectedUnique := len(spans)*4/5 + 1
 	itemMap := make(map[string]*T, expectedUnique)
+	locator := pii.NewLocator(text)
 
-	// Use context cache only if we have many matches (>= 10) to amortize the cost
-	var contextCache *patterns.ContextCache
-	if len(indices) >= 10 {
-		contextCache = patterns.NewContextCache(text)
-	}
-
-	for _, idx := range indices {
-		start, end := idx[0], idx[1]
-		value := text[start:end]
-		
-		var context string
-		if contextCache != nil {
-			context = contextCache.ExtractContext(start, end)
+	for _, span := range spans {
+		item, exists := itemMap[span.Value]
+		if exists {
+			updateItem(item, span.Context)
 		} else {
-			context = patterns.ExtractContext(text, start, end)
+			newItem := createItem(span.Value, span.Context)
+			item = &newItem
+			itemMap[span.Value] = item
 		}
-
-		if item, exists := itemMap[value]; exists {
-			updateItem(item, context)
-		} else {
-			newItem := createItem(value, context)
-			itemMap[value] = &newItem
+		if located, ok := any(item).(locatedItem); ok {
+			located.AddLocation(locator.Locate(span.Start, span.End))
 		}
 	}
 
@@ -50,13 +72,32 @@ func extractWithContext[T any](text string, regexPattern *regexp.Regexp, createI
 	return items
 }
 
+// locatedItem is implemented by pointers to the value objects, through pii.BasePii
+type locatedItem interface {
+	AddLocation(location pii.Location)
+}
+
 // =============================================================================
 // US-SPECIFIC EXTRACTION FUNCTIONS
 // =============================================================================
 
 // ExtractPhonesUS extracts US phone numbers as PiiEntity objects with context
 func ExtractPhonesUS(text string) []pii.PiiEntity {
-	phones := extractWithContext(text, patterns.PhoneUSRegex,
+	return extractPhonesUS(text, false, true)
+}
+
+// extractPhonesUS extracts US phone numbers, optionally validating NANP area and exchange codes.
+// Candidates embedded in longer digit sequences are rejected by PhoneUSRejection, or by
+// PhoneUSListRejection when lists of numbers are separated.
+func extractPhonesUS(text string, validateCodes, lists bool) []pii.PiiEntity {
+	rejection := patterns.PhoneUSRejection
+	if lists {
+		rejection = patterns.PhoneUSListRejection
+	}
+	accept := func(text string, start, end int) bool {
+		return rejection(text, start, end, validateCodes) == ""
+	}
+	phones := extractWithContextFiltered(text, patterns.PhoneUSRegex, accept,
 		func(value, context string) pii.Phone {
 			return pii.Phone{
 				BasePii: pii.BasePii{
@@ -64,7 +105,7 @@ func ExtractPhonesUS(text string) []pii.PiiEntity {
 					Contexts: []string{context},
 					Count:    1,
 				},
-				Country: "US",
+				Country: pii.CountryUS,
 			}
 		},
 		func(phone *pii.Phone, context string) {
@@ -72,99 +113,16 @@ func ExtractPhonesUS(text string) []pii.PiiEntity {
 			phone.BasePii.AddContext(context)
 		})
 
-	var entities []pii.PiiEntity
+	entities := make([]pii.PiiEntity, 0, len(phones))
 	for _, phone := range phones {
-		// Filter out credit card false positives
-		if !isCreditCardFalsePositive(phone.BasePii.Value) {
-			entities = append(entities, pii.PiiEntity{
-				Type:  pii.PiiTypePhone,
-				Value: phone,
-			})
-		}
+		entities = append(entities, pii.PiiEntity{
+			Type:  pii.PiiTypePhone,
+			Value: phone,
+		})
 	}
 	return entities
 }
 
-// isCreditCardFalsePositive checks if a phone number is actually part of a credit card or other PII
-func isCreditCardFalsePositive(value string) bool {
-	// Count digits and extract them without creating strings
-	var digitChars [20]byte // Pre-allocated buffer for digits (max 20 digits)
-	digitCount := 0
-	
-	for i := 0; i < len(value) && digitCount < 20; i++ {
-		c := value[i]
-		if c >= '0' && c <= '9' {
-			digitChars[digitCount] = c
-			digitCount++
-		}
-	}
-	
-	// If it's 14+ digits, it's likely a credit card or IBAN
-	if digitCount >= 14 {
-		return true
-	}
-	
-	// Check if it looks like part of a credit card (starts with common prefixes)
-	if digitCount >= 4 {
-		// Check common credit card prefixes without string allocation
-		prefix := digitChars[:4]
-		if (prefix[0] == '4' && prefix[1] == '1' && prefix[2] == '1' && prefix[3] == '1') ||
-		   (prefix[0] == '4' && prefix[1] == '0' && prefix[2] == '0' && prefix[3] == '0') ||
-		   (prefix[0] == '5' && prefix[1] == '5' && prefix[2] == '5' && prefix[3] == '5') ||
-		   (prefix[0] == '5' && prefix[1] == '1' && prefix[2] == '0' && prefix[3] == '5') ||
-		   (prefix[0] == '1' && prefix[1] == '1' && prefix[2] == '1' && prefix[3] == '1') ||
-		   (prefix[0] == '1' && prefix[1] == '2' && prefix[2] == '3' && prefix[3] == '4') {
-			return true
-		}
-	}
-	
-	// Check if it's all the same digits (likely test data)
-	if digitCount >= 4 {
-		firstDigit := digitChars[0]
-		allSame := true
-		for i := 1; i < digitCount; i++ {
-			if digitChars[i] != firstDigit {
-				allSame = false
-				break
-			}
-		}
-		if allSame {
-			return true
-		}
-	}
-	
-	// Filter out sequences like 1111-1111 which are parts of credit cards
-	if digitCount == 8 {
-		// Check if all digits are the same
-		if digitChars[0] == '1' {
-			allOnes := true
-			for i := 1; i < 8; i++ {
-				if digitChars[i] != '1' {
-					allOnes = false
-					break
-				}
-			}
-			if allOnes {
-				return true
-			}
-		}
-		
-		// Check if first 4 digits match last 4 digits
-		firstHalfSame := true
-		for i := 0; i < 4; i++ {
-			if digitChars[i] != digitChars[i+4] {
-				firstHalfSame = false
-				break
-			}
-		}
-		if firstHalfSame {
-			return true
-		}
-	}
-	
-	return false
-}
-
 // ExtractSSNsUS extracts US SSNs as PiiEntity objects with context
 func ExtractSSNsUS(text string) []pii.PiiEntity {
 	ssns := extractWithContext(text, patterns.SSNUSRegex,
@@ -175,7 +133,7 @@ func ExtractSSNsUS(text string) []pii.PiiEntity {
 					Contexts: []string{context},
 					Count:    1,
 				},
-				Country: "US",
+				Country: pii.CountryUS,
 			}
 		},
 		func(ssn *pii.SSN, context string) {
@@ -203,7 +161,7 @@ func ExtractZipCodesUS(text string) []pii.PiiEntity {
 					Contexts: []string{context},
 					Count:    1,
 				},
-				Country: "US",
+				Country: pii.CountryUS,
 			}
 		},
 		func(zipCode *pii.ZipCode, context string) {
@@ -231,7 +189,7 @@ func ExtractStreetAddressesUS(text string) []pii.PiiEntity {
 					Contexts: []string{context},
 					Count:    1,
 				},
-				Country: "US",
+				Country: pii.CountryUS,
 			}
 		},
 		func(address *pii.StreetAddress, context string) {
@@ -259,7 +217,7 @@ func ExtractPoBoxesUS(text string) []pii.PiiEntity {
 					Contexts: []string{context},
 					Count:    1,
 				},
-				Country: "US",
+				Country: pii.CountryUS,
 			}
 		},
 		func(poBox *pii.PoBox, context string) {
@@ -326,6 +284,7 @@ func ExtractCreditCards(text string) []pii.PiiEntity {
 	if totalMatches >= 5 {
 		contextCache = patterns.NewContextCache(text)
 	}
+	locator := pii.NewLocator(text)
 
 	for _, idx := range visaIndices {
 		start, end := idx[0], idx[1]
@@ -340,12 +299,14 @@ func ExtractCreditCards(text string) []pii.PiiEntity {
 		if card, exists := cardMap[value]; exists {
 			card.BasePii.IncrementCount()
 			card.BasePii.AddContext(context)
+			card.BasePii.AddLocation(locator.Locate(start, end))
 		} else {
 			cardMap[value] = &pii.CreditCard{
 				BasePii: pii.BasePii{
-					Value:    value,
-					Contexts: []string{context},
-					Count:    1,
+					Value:     value,
+					Contexts:  []string{context},
+					Count:     1,
+					Locations: []pii.Location{locator.Locate(start, end)},
 				},
 				Type: "visa",
 			}
@@ -366,12 +327,14 @@ func ExtractCreditCards(text string) []pii.PiiEntity {
 		if card, exists := cardMap[value]; exists {
 			card.BasePii.IncrementCount()
 			card.BasePii.AddContext(context)
+			card.BasePii.AddLocation(locator.Locate(start, end))
 		} else {
 			cardMap[value] = &pii.CreditCard{
 				BasePii: pii.BasePii{
-					Value:    value,
-					Contexts: []string{context},
-					Count:    1,
+					Value:     value,
+					Contexts:  []string{context},
+					Count:     1,
+					Locations: []pii.Location{locator.Locate(start, end)},
 				},
 				Type: "mastercard",
 			}
@@ -393,9 +356,10 @@ func ExtractCreditCards(text string) []pii.PiiEntity {
 		if _, exists := cardMap[value]; !exists {
 			cardMap[value] = &pii.CreditCard{
 				BasePii: pii.BasePii{
-					Value:    value,
-					Contexts: []string{context},
-					Count:    1,
+					Value:     value,
+					Contexts:  []string{context},
+					Count:     1,
+					Locations: []pii.Location{locator.Locate(start, end)},
 				},
 				Type: "generic",
 			}
@@ -404,6 +368,8 @@ func ExtractCreditCards(text string) []pii.PiiEntity {
 
 	entities := make([]pii.PiiEntity, 0, len(cardMap))
 	for _, card := range cardMap {
+		// The VISA, MasterCard and generic passes each locate matches in order
+		slices.SortFunc(card.Locations, func(a, b pii.Location) int { return a.Start - b.Start })
 		entities = append(entities, pii.PiiEntity{
 			Type:  pii.PiiTypeCreditCard,
 			Value: *card,
@@ -412,77 +378,105 @@ func ExtractCreditCards(text string) []pii.PiiEntity {
 	return entities
 }
 
+// ExtractTrackData extracts Track 1 and Track 2 magnetic stripe data with a Luhn-valid PAN
+// as PiiEntity objects with context
+func ExtractTrackData(text string) []pii.PiiEntity {
+	var entities []pii.PiiEntity
+	for i, regex := range []*regexp.Regexp{patterns.Track1Regex, patterns.Track2Regex} {
+		track := i + 1
+		accept := func(text string, start, end int) bool {
+			return patterns.TrackDataRejection(regex, text, start, end) == ""
+		}
+		tracks := extractWithContextFiltered(text, regex, accept,
+			func(value, context string) pii.TrackData {
+				trackData := pii.NewTrackData(value, track)
+				trackData.Contexts = []string{context}
+				return trackData
+			},
+			func(trackData *pii.TrackData, context string) {
+				trackData.BasePii.IncrementCount()
+				trackData.BasePii.AddContext(context)
+			})
+
+		for _, trackData := range tracks {
+			entities = append(entities, pii.PiiEntity{
+				Type:  pii.PiiTypeTrackData,
+				Value: trackData,
+			})
+		}
+	}
+	return entities
+}
+
+// trackDataRejection explains why a track data pattern match is not reported
+func trackDataRejection(regex *regexp.Regexp) func(r *RegexExtractor, text string, start, end int) string {
+	return func(r *RegexExtractor, text string, start, end int) string {
+		return patterns.TrackDataRejection(regex, text, start, end)
+	}
+}
+
 // ExtractIPAddresses extracts IP addresses as PiiEntity objects with context
 func ExtractIPAddresses(text string) []pii.PiiEntity {
 	// Estimate capacity based on typical IP density in text
 	estimatedIPs := len(text)/1500 + 3 // ~1 IP per 1500 chars
 	ipMap := make(map[string]*pii.IPAddress, estimatedIPs)
 
-	// Extract IPv4
-	ipv4Indices := patterns.MatchWithIndices(text, patterns.IPv4Regex)
-	ipv6Indices := patterns.MatchWithIndices(text, patterns.IPv6Regex)
-	
-	// Create context cache only if we have enough total matches to justify it
-	var contextCache *patterns.ContextCache
-	totalMatches := len(ipv4Indices) + len(ipv6Indices)
-	
-	if totalMatches >= 5 {
-		contextCache = patterns.NewContextCache(text)
+	// Validate candidates with net/netip; IPv4 matches inside an IPv6 address are part of it
+	var candidates []ipCandidate
+	var ipv6Spans [][]int
+	for _, idx := range patterns.MatchWithIndices(text, patterns.IPv6Regex) {
+		idx[1] = extendIPv6Span(text, idx[0], idx[1])
+		if candidate, ok := parseIPCandidate(text, idx[0], idx[1]); ok {
+			candidates = append(candidates, candidate)
+			ipv6Spans = append(ipv6Spans, []int{candidate.start, candidate.end})
+		}
 	}
-
-	for _, idx := range ipv4Indices {
-		start, end := idx[0], idx[1]
-		value := text[start:end]
-		var context string
-		if contextCache != nil {
-			context = contextCache.ExtractContext(start, end)
-		} else {
-			context = patterns.ExtractContext(text, start, end)
+	for _, idx := range patterns.MatchWithIndices(text, patterns.IPv4Regex) {
+		if overlapsAny(idx, ipv6Spans) {
+			continue
 		}
-
-		if ip, exists := ipMap[value]; exists {
-			ip.BasePii.IncrementCount()
-			ip.BasePii.AddContext(context)
-		} else {
-			ipMap[value] = &pii.IPAddress{
-				BasePii: pii.BasePii{
-					Value:    value,
-					Contexts: []string{context},
-					Count:    1,
-				},
-				Version: "ipv4",
-			}
+		if candidate, ok := parseIPCandidate(text, idx[0], idx[1]); ok {
+			candidates = append(candidates, candidate)
 		}
 	}
 
-	// Extract IPv6
-	for _, idx := range ipv6Indices {
-		start, end := idx[0], idx[1]
-		value := text[start:end]
+	// Create context cache only if we have enough total matches to justify it
+	var contextCache *patterns.ContextCache
+	if len(candidates) >= 5 {
+		contextCache = patterns.NewContextCache(text)
+	}
+
+	locator := pii.NewLocator(text)
+	for _, candidate := range candidates {
 		var context string
 		if contextCache != nil {
-			context = contextCache.ExtractContext(start, end)
+			context = contextCache.ExtractContext(candidate.start, candidate.end)
 		} else {
-			context = patterns.ExtractContext(text, start, end)
+			context = patterns.ExtractContext(text, candidate.start, candidate.end)
 		}
 
+		value := text[candidate.start:candidate.end]
+		location := locator.Locate(candidate.start, candidate.end)
 		if ip, exists := ipMap[value]; exists {
 			ip.BasePii.IncrementCount()
 			ip.BasePii.AddContext(context)
+			ip.BasePii.AddLocation(location)
 		} else {
-			ipMap[value] = &pii.IPAddress{
-				BasePii: pii.BasePii{
-					Value:    value,
-					Contexts: []string{context},
-					Count:    1,
-				},
-				Version: "ipv6",
+			ip := candidate.ip
+			ip.BasePii = pii.BasePii{
+				Value:     value,
+				Contexts:  []string{context},
+				Count:     1,
+				Locations: []pii.Location{location},
 			}
+			ipMap[value] = &ip
 		}
 	}
 
 	entities := make([]pii.PiiEntity, 0, len(ipMap))
 	for _, ip := range ipMap {
+		// IPv6 candidates are located before IPv4 ones
+		slices.SortFunc(ip.Locations, func(a, b pii.Location) int { return a.Start - b.Start })
 		entities = append(entities, pii.PiiEntity{
 			Type:  pii.PiiTypeIPAddress,
 			Value: *ip,
@@ -491,6 +485,80 @@ func ExtractIPAddresses(text string) []pii.PiiEntity {
 	return entities
 }
 
+// ipCandidate is an IP address match that passed net/netip validation
+type ipCandidate struct {
+	start, end int
+	ip         pii.IPAddress // structured fields, without BasePii
+}
+
+// parseIPCandidate trims a pattern match and validates it with net/netip
+func parseIPCandidate(text string, start, end int) (ipCandidate, bool) {
+	for end > start && unicode.IsSpace(rune(text[end-1])) {
+		end--
+	}
+
+	addr, err := netip.ParseAddr(text[start:end])
+	if err != nil {
+		return ipCandidate{}, false
+	}
+
+	candidate := ipCandidate{start: start, end: end}
+	if addr.Is4() {
+		candidate.ip.Version = "ipv4"
+		return candidate, true
+	}
+
+	candidate.ip.Version = "ipv6"
+	candidate.ip.Zone = addr.Zone()
+	value := text[start:end]
+	if addr.Is4In6() {
+		candidate.ip.EmbeddedIPv4 = addr.Unmap().String()
+	} else if strings.Contains(value, ".") {
+		// Dotted suffix such as a NAT64 address (64:ff9b::192.0.2.33)
+		raw := addr.As16()
+		candidate.ip.EmbeddedIPv4 = netip.AddrFrom4([4]byte{raw[12], raw[13], raw[14], raw[15]}).String()
+	}
+	return candidate, true
+}
+
+// maxIPv6Length bounds the span considered by extendIPv6Span (45 characters plus a zone ID)
+const maxIPv6Length = 64
+
+// extendIPv6Span returns the end of the longest valid address starting at start. The IPv6
+// pattern prefers hex groups over a dotted IPv4 suffix, so ::ffff:192.0.2.1 first matches
+// as ::ffff:192; extending to the full token keeps the embedded IPv4 address.
+func extendIPv6Span(text string, start, end int) int {
+	limit := end
+	for limit < len(text) && limit-start < maxIPv6Length && isIPv6TokenChar(text[limit]) {
+		limit++
+	}
+	for e := limit; e > end; e-- {
+		if _, err := netip.ParseAddr(text[start:e]); err == nil {
+			return e
+		}
+	}
+	return end
+}
+
+// isIPv6TokenChar reports whether b can appear in an IPv6 address, its IPv4 suffix or zone ID
+func isIPv6TokenChar(b byte) bool {
+	return (b >= '0' && b <= '9') || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') ||
+		b == ':' || b == '.' || b == '%' || b == '_' || b == '-'
+}
+
+// ipRejection explains why an IP pattern match is not reported
+func (r *RegexExtractor) ipRejection(text string, start, end int) string {
+	if _, ok := parseIPCandidate(text, start, end); !ok {
+		return "rejected by net/netip address parsing"
+	}
+	return ""
+}
+
+// hostnameRejection explains why a hostname pattern match is not reported
+func (r *RegexExtractor) hostnameRejection(text string, start, end int) string {
+	return patterns.HostnameRejection(text, start, end)
+}
+
 // ExtractBtcAddresses extracts Bitcoin addresses as PiiEntity objects with context
 func ExtractBtcAddresses(text string) []pii.PiiEntity {
 	btcAddresses := extractWithContext(text, patterns.BtcAddressRegex,
@@ -518,13 +586,93 @@ func ExtractBtcAddresses(text string) []pii.PiiEntity {
 	return entities
 }
 
+// ExtractHostnames extracts hostnames and FQDNs with a public or internal suffix as PiiEntity objects with context
+func ExtractHostnames(text string) []pii.PiiEntity {
+	accept := func(text string, start, end int) bool {
+		return patterns.HostnameRejection(text, start, end) == ""
+	}
+	hostnames := extractWithContextFiltered(text, patterns.HostnameRegex, accept,
+		func(value, context string) pii.Hostname {
+			suffix, internal, _ := patterns.HostnameSuffix(value)
+			return pii.Hostname{
+				BasePii: pii.BasePii{
+					Value:    value,
+					Contexts: []string{context},
+					Count:    1,
+				},
+				Suffix:   suffix,
+				Internal: internal,
+			}
+		},
+		func(hostname *pii.Hostname, context string) {
+			hostname.BasePii.IncrementCount()
+			hostname.BasePii.AddContext(context)
+		})
+
+	entities := make([]pii.PiiEntity, 0, len(hostnames))
+	for _, hostname := range hostnames {
+		entities = append(entities, pii.PiiEntity{
+			Type:  pii.PiiTypeHostname,
+			Value: hostname,
+		})
+	}
+	return entities
+}
+
+// ExtractSessionTokens extracts session cookies, bearer tokens and session ID query parameters
+// from HTTP logs as PiiEntity objects. Token values are masked, and every token found is also
+// masked in the contexts so raw tokens never leave the extractor.
+func ExtractSessionTokens(text string) []pii.PiiEntity {
+	matches := patterns.FindSessionTokens(text)
+	if len(matches) == 0 {
+		return []pii.PiiEntity{}
+	}
+
+	rawTokens := make([]string, 0, len(matches))
+	for _, match := range matches {
+		rawTokens = append(rawTokens, text[match.Start:match.End])
+	}
+
+	tokenMap := make(map[string]*pii.SessionToken, len(matches))
+	var order []string
+	locator := pii.NewLocator(text)
+	for i, match := range matches {
+		raw := rawTokens[i]
+		context := pii.MaskSessionTokens(patterns.ExtractContext(text, match.Start, match.End), rawTokens)
+		// The masked value is not in the text, so the locations are the only way back to
+		// the raw token
+		location := locator.Locate(match.Start, match.End)
+
+		if token, exists := tokenMap[raw]; exists {
+			token.BasePii.IncrementCount()
+			token.BasePii.AddContext(context)
+			token.BasePii.AddLocation(location)
+			continue
+		}
+		token := pii.NewSessionToken(raw, match.Source, match.Name)
+		token.Contexts = []string{context}
+		token.Locations = []pii.Location{location}
+		tokenMap[raw] = &token
+		order = append(order, raw)
+	}
+
+	entities := make([]pii.PiiEntity, 0, len(order))
+	for _, raw := range order {
+		entities = append(entities, pii.PiiEntity{
+			Type:  pii.PiiTypeSessionToken,
+			Value: *tokenMap[raw],
+		})
+	}
+	return entities
+}
+
 // ExtractIBANs extracts IBANs as PiiEntity objects with context
 func ExtractIBANs(text string) []pii.PiiEntity {
 	ibans := extractWithContext(text, patterns.IBANRegex,
 		func(value, context string) pii.IBAN {
-			country := ""
+			var country pii.Country
 			if len(value) >= 2 {
-				country = value[:2]
+				country = pii.Country(value[:2])
 			}
 			return pii.IBAN{
 				BasePii: pii.BasePii{
@@ -566,7 +714,7 @@ func ExtractPostalCodesUK(text string) []pii.PiiEntity {
 					Contexts: []string{context},
 					Count:    1,
 				},
-				Country: "UK",
+				Country: pii.CountryGB,
 			}
 		},
 		func(zipCode *pii.ZipCode, context string) {
@@ -594,7 +742,7 @@ func ExtractStreetAddressesUK(text string) []pii.PiiEntity {
 					Contexts: []string{context},
 					Count:    1,
 				},
-				Country: "UK",
+				Country: pii.CountryGB,
 			}
 		},
 		func(address *pii.StreetAddress, context string) {
@@ -624,7 +772,7 @@ func ExtractPostalCodesFrance(text string) []pii.PiiEntity {
 					Contexts: []string{context},
 					Count:    1,
 				},
-				Country: "France",
+				Country: pii.CountryFR,
 			}
 		},
 		func(zipCode *pii.ZipCode, context string) {
@@ -652,7 +800,7 @@ func ExtractStreetAddressesFrance(text string) []pii.PiiEntity {
 					Contexts: []string{context},
 					Count:    1,
 				},
-				Country: "France",
+				Country: pii.CountryFR,
 			}
 		},
 		func(address *pii.StreetAddress, context string) {
@@ -682,7 +830,7 @@ func ExtractPostalCodesSpain(text string) []pii.PiiEntity {
 					Contexts: []string{context},
 					Count:    1,
 				},
-				Country: "Spain",
+				Country: pii.CountryES,
 			}
 		},
 		func(zipCode *pii.ZipCode, context string) {
@@ -710,7 +858,7 @@ func ExtractStreetAddressesSpain(text string) []pii.PiiEntity {
 					Contexts: []string{context},
 					Count:    1,
 				},
-				Country: "Spain",
+				Country: pii.CountryES,
 			}
 		},
 		func(address *pii.StreetAddress, context string) {
@@ -740,7 +888,7 @@ func ExtractPostalCodesItaly(text string) []pii.PiiEntity {
 					Contexts: []string{context},
 					Count:    1,
 				},
-				Country: "Italy",
+				Country: pii.CountryIT,
 			}
 		},
 		func(zipCode *pii.ZipCode, context string) {
@@ -768,7 +916,7 @@ func ExtractStreetAddressesItaly(text string) []pii.PiiEntity {
 					Contexts: []string{context},
 					Count:    1,
 				},
-				Country: "Italy",
+				Country: pii.CountryIT,
 			}
 		},
 		func(address *pii.StreetAddress, context string) {
@@ -802,7 +950,7 @@ func ExtractPostalCodesGermany(text string) []pii.PiiEntity {
 					Contexts: []string{context},
 					Count:    1,
 				},
-				Country: "Germany",
+				Country: pii.CountryDE,
 			}
 		},
 		func(zipCode *pii.ZipCode, context string) {
@@ -830,7 +978,7 @@ func ExtractPhonesGermany(text string) []pii.PiiEntity {
 					Contexts: []string{context},
 					Count:    1,
 				},
-				Country: "Germany",
+				Country: pii.CountryDE,
 			}
 		},
 		func(phone *pii.Phone, context string) {
@@ -858,7 +1006,7 @@ func ExtractStreetAddressesGermany(text string) []pii.PiiEntity {
 					Contexts: []string{context},
 					Count:    1,
 				},
-				Country: "Germany",
+				Country: pii.CountryDE,
 			}
 		},
 		func(address *pii.StreetAddress, context string) {
@@ -888,7 +1036,7 @@ func ExtractPostalCodesChina(text string) []pii.PiiEntity {
 					Contexts: []string{context},
 					Count:    1,
 				},
-				Country: "China",
+				Country: pii.CountryCN,
 			}
 		},
 		func(zipCode *pii.ZipCode, context string) {
@@ -916,7 +1064,7 @@ func ExtractPhonesChina(text string) []pii.PiiEntity {
 					Contexts: []string{context},
 					Count:    1,
 				},
-				Country: "China",
+				Country: pii.CountryCN,
 			}
 		},
 		func(phone *pii.Phone, context string) {
@@ -944,7 +1092,7 @@ func ExtractStreetAddressesChina(text string) []pii.PiiEntity {
 					Contexts: []string{context},
 					Count:    1,
 				},
-				Country: "China",
+				Country: pii.CountryCN,
 			}
 		},
 		func(address *pii.StreetAddress, context string) {
@@ -964,9 +1112,20 @@ func ExtractStreetAddressesChina(text string) []pii.PiiEntity {
 
 // --- India PII ---
 
-// ExtractPostalCodesIndia extracts India postal codes as PiiEntity objects with context
+// ExtractPostalCodesIndia extracts India postal codes, including spaced codes after a PIN
+// keyword, as PiiEntity objects with context
 func ExtractPostalCodesIndia(text string) []pii.PiiEntity {
-	postalCodes := extractWithContext(text, patterns.PostalCodeIndiaRegex,
+	return extractPostalCodesIndia(text, false)
+}
+
+// extractPostalCodesIndia extracts India postal codes, with the rules of pattern sets before
+// 1.5.0 when legacy is true
+func extractPostalCodesIndia(text string, legacy bool) []pii.PiiEntity {
+	regex, accept := patterns.PostalCodeIndiaRegex, acceptUnrejected(patterns.PostalCodeIndiaRejection)
+	if legacy {
+		regex, accept = postalCodeIndiaLegacyRegex, nil
+	}
+	postalCodes := extractWithContextFiltered(text, regex, accept,
 		func(value, context string) pii.ZipCode {
 			return pii.ZipCode{
 				BasePii: pii.BasePii{
@@ -974,7 +1133,7 @@ func ExtractPostalCodesIndia(text string) []pii.PiiEntity {
 					Contexts: []string{context},
 					Count:    1,
 				},
-				Country: "India",
+				Country: pii.CountryIN,
 			}
 		},
 		func(zipCode *pii.ZipCode, context string) {
@@ -992,9 +1151,20 @@ func ExtractPostalCodesIndia(text string) []pii.PiiEntity {
 	return entities
 }
 
-// ExtractPhonesIndia extracts India phone numbers as PiiEntity objects with context
+// ExtractPhonesIndia extracts India phone numbers as PiiEntity objects with context.
+// Numbers embedded in longer digit sequences are rejected by PhoneIndiaRejection.
 func ExtractPhonesIndia(text string) []pii.PiiEntity {
-	phones := extractWithContext(text, patterns.PhoneIndiaRegex,
+	return extractPhonesIndia(text, false)
+}
+
+// extractPhonesIndia extracts India phone numbers, with the rules of pattern sets before
+// 1.5.0 when legacy is true
+func extractPhonesIndia(text string, legacy bool) []pii.PiiEntity {
+	regex, accept := patterns.PhoneIndiaRegex, acceptUnrejected(patterns.PhoneIndiaRejection)
+	if legacy {
+		regex, accept = phoneIndiaLegacyRegex, nil
+	}
+	phones := extractWithContextFiltered(text, regex, accept,
 		func(value, context string) pii.Phone {
 			return pii.Phone{
 				BasePii: pii.BasePii{
@@ -1002,7 +1172,7 @@ func ExtractPhonesIndia(text string) []pii.PiiEntity {
 					Contexts: []string{context},
 					Count:    1,
 				},
-				Country: "India",
+				Country: pii.CountryIN,
 			}
 		},
 		func(phone *pii.Phone, context string) {
@@ -1022,7 +1192,17 @@ func ExtractPhonesIndia(text string) []pii.PiiEntity {
 
 // ExtractStreetAddressesIndia extracts India street addresses as PiiEntity objects with context
 func ExtractStreetAddressesIndia(text string) []pii.PiiEntity {
-	addresses := extractWithContext(text, patterns.StreetAddressIndiaRegex,
+	return extractStreetAddressesIndia(text, false)
+}
+
+// extractStreetAddressesIndia extracts India street addresses, with the rules of pattern
+// sets before 1.5.0 when legacy is true
+func extractStreetAddressesIndia(text string, legacy bool) []pii.PiiEntity {
+	regex := patterns.StreetAddressIndiaRegex
+	if legacy {
+		regex = streetAddressIndiaLegacyRegex
+	}
+	addresses := extractWithContext(text, regex,
 		func(value, context string) pii.StreetAddress {
 			return pii.StreetAddress{
 				BasePii: pii.BasePii{
@@ -1030,7 +1210,7 @@ func ExtractStreetAddressesIndia(text string) []pii.PiiEntity {
 					Contexts: []string{context},
 					Count:    1,
 				},
-				Country: "India",
+				Country: pii.CountryIN,
 			}
 		},
 		func(address *pii.StreetAddress, context string) {
@@ -1050,9 +1230,20 @@ func ExtractStreetAddressesIndia(text string) []pii.PiiEntity {
 
 // --- Arabic Countries PII ---
 
-// ExtractPostalCodesArabic extracts Arabic countries postal codes as PiiEntity objects with context
+// ExtractPostalCodesArabic extracts Arabic countries postal codes as PiiEntity objects with context.
+// Postal codes written with Eastern Arabic digits are matched too and reported as written.
 func ExtractPostalCodesArabic(text string) []pii.PiiEntity {
-	postalCodes := extractWithContext(text, patterns.PostalCodeArabicRegex,
+	return extractPostalCodesArabic(text, true)
+}
+
+// extractPostalCodesArabic extracts Arabic countries postal codes, optionally matching
+// Arabic-script digits
+func extractPostalCodesArabic(text string, arabicDigits bool) []pii.PiiEntity {
+	extract := extractWithContextFiltered[pii.ZipCode]
+	if arabicDigits {
+		extract = extractWithContextNormalized[pii.ZipCode]
+	}
+	postalCodes := extract(text, patterns.PostalCodeArabicRegex, nil,
 		func(value, context string) pii.ZipCode {
 			return pii.ZipCode{
 				BasePii: pii.BasePii{
@@ -1060,7 +1251,7 @@ func ExtractPostalCodesArabic(text string) []pii.PiiEntity {
 					Contexts: []string{context},
 					Count:    1,
 				},
-				Country: "Arabic",
+				Country: pii.RegionArabic,
 			}
 		},
 		func(zipCode *pii.ZipCode, context string) {
@@ -1078,9 +1269,20 @@ func ExtractPostalCodesArabic(text string) []pii.PiiEntity {
 	return entities
 }
 
-// ExtractPhonesArabic extracts Arabic countries phone numbers as PiiEntity objects with context
+// ExtractPhonesArabic extracts Arabic countries phone numbers as PiiEntity objects with context.
+// Phone numbers written with Eastern Arabic digits are matched too and reported as written.
 func ExtractPhonesArabic(text string) []pii.PiiEntity {
-	phones := extractWithContext(text, patterns.PhoneArabicRegex,
+	return extractPhonesArabic(text, true)
+}
+
+// extractPhonesArabic extracts Arabic countries phone numbers, optionally matching
+// Arabic-script digits
+func extractPhonesArabic(text string, arabicDigits bool) []pii.PiiEntity {
+	extract := extractWithContextFiltered[pii.Phone]
+	if arabicDigits {
+		extract = extractWithContextNormalized[pii.Phone]
+	}
+	phones := extract(text, patterns.PhoneArabicRegex, nil,
 		func(value, context string) pii.Phone {
 			return pii.Phone{
 				BasePii: pii.BasePii{
@@ -1088,7 +1290,7 @@ func ExtractPhonesArabic(text string) []pii.PiiEntity {
 					Contexts: []string{context},
 					Count:    1,
 				},
-				Country: "Arabic",
+				Country: pii.RegionArabic,
 			}
 		},
 		func(phone *pii.Phone, context string) {
@@ -1106,6 +1308,44 @@ func ExtractPhonesArabic(text string) []pii.PiiEntity {
 	return entities
 }
 
+// ExtractNationalIDsSaudi extracts Saudi national IDs and Iqama numbers as PiiEntity objects
+// with context. IDs are matched in ASCII or Eastern Arabic digits and must pass their check digit.
+func ExtractNationalIDsSaudi(text string) []pii.PiiEntity {
+	return extractNationalIDsArabic(text, patterns.NationalIDSaudiRegex, "sa_national_id")
+}
+
+// ExtractEmiratesIDs extracts UAE Emirates IDs as PiiEntity objects with context. IDs are
+// matched in ASCII or Eastern Arabic digits and must pass their check digit.
+func ExtractEmiratesIDs(text string) []pii.PiiEntity {
+	return extractNationalIDsArabic(text, patterns.EmiratesIDRegex, "ae_emirates_id")
+}
+
+// extractNationalIDsArabic extracts the national IDs of a scheme matched by regex
+func extractNationalIDsArabic(text string, regex *regexp.Regexp, scheme string) []pii.PiiEntity {
+	accept := func(text string, start, end int) bool {
+		return patterns.NationalIDArabicRejection(text, start, end) == ""
+	}
+	ids := extractWithContextNormalized(text, regex, accept,
+		func(value, context string) pii.NationalID {
+			id := pii.NewNationalID(value, pii.RegionArabic, scheme)
+			id.Contexts = []string{context}
+			return id
+		},
+		func(id *pii.NationalID, context string) {
+			id.BasePii.IncrementCount()
+			id.BasePii.AddContext(context)
+		})
+
+	var entities []pii.PiiEntity
+	for _, id := range ids {
+		entities = append(entities, pii.PiiEntity{
+			Type:  pii.PiiTypeNationalID,
+			Value: id,
+		})
+	}
+	return entities
+}
+
 // ExtractStreetAddressesArabic extracts Arabic countries street addresses as PiiEntity objects with context
 func ExtractStreetAddressesArabic(text string) []pii.PiiEntity {
 	addresses := extractWithContext(text, patterns.StreetAddressArabicRegex,
@@ -1116,7 +1356,7 @@ func ExtractStreetAddressesArabic(text string) []pii.PiiEntity {
 					Contexts: []string{context},
 					Count:    1,
 				},
-				Country: "Arabic",
+				Country: pii.RegionArabic,
 			}
 		},
 		func(address *pii.StreetAddress, context string) {
@@ -1146,7 +1386,7 @@ func ExtractPostalCodesRussia(text string) []pii.PiiEntity {
 					Contexts: []string{context},
 					Count:    1,
 				},
-				Country: "Russia",
+				Country: pii.CountryRU,
 			}
 		},
 		func(zipCode *pii.ZipCode, context string) {
@@ -1164,9 +1404,20 @@ func ExtractPostalCodesRussia(text string) []pii.PiiEntity {
 	return entities
 }
 
-// ExtractPhonesRussia extracts Russia phone numbers as PiiEntity objects with context
+// ExtractPhonesRussia extracts Russia phone numbers as PiiEntity objects with context.
+// Numbers embedded in longer digit sequences are rejected by PhoneRussiaRejection.
 func ExtractPhonesRussia(text string) []pii.PiiEntity {
-	phones := extractWithContext(text, patterns.PhoneRussiaRegex,
+	return extractPhonesRussia(text, false)
+}
+
+// extractPhonesRussia extracts Russia phone numbers, with the rules of pattern sets before
+// 1.4.0 when legacy is true
+func extractPhonesRussia(text string, legacy bool) []pii.PiiEntity {
+	regex, accept := patterns.PhoneRussiaRegex, acceptUnrejected(patterns.PhoneRussiaRejection)
+	if legacy {
+		regex, accept = phoneRussiaLegacyRegex, nil
+	}
+	phones := extractWithContextFiltered(text, regex, accept,
 		func(value, context string) pii.Phone {
 			return pii.Phone{
 				BasePii: pii.BasePii{
@@ -1174,7 +1425,7 @@ func ExtractPhonesRussia(text string) []pii.PiiEntity {
 					Contexts: []string{context},
 					Count:    1,
 				},
-				Country: "Russia",
+				Country: pii.CountryRU,
 			}
 		},
 		func(phone *pii.Phone, context string) {
@@ -1192,9 +1443,44 @@ func ExtractPhonesRussia(text string) []pii.PiiEntity {
 	return entities
 }
 
-// ExtractStreetAddressesRussia extracts Russia street addresses as PiiEntity objects with context
+// ExtractPassportsRussia extracts Russian passport series and numbers introduced by a
+// keyword such as "паспорт" or "серия" as PiiEntity objects with context
+func ExtractPassportsRussia(text string) []pii.PiiEntity {
+	passports := extractWithContextFiltered(text, patterns.PassportRussiaRegex, acceptUnrejected(patterns.PassportRussiaRejection),
+		func(value, context string) pii.NationalID {
+			passport := pii.NewNationalID(value, pii.CountryRU, "ru_passport")
+			passport.Contexts = []string{context}
+			return passport
+		},
+		func(passport *pii.NationalID, context string) {
+			passport.BasePii.IncrementCount()
+			passport.BasePii.AddContext(context)
+		})
+
+	var entities []pii.PiiEntity
+	for _, passport := range passports {
+		entities = append(entities, pii.PiiEntity{
+			Type:  pii.PiiTypeNationalID,
+			Value: passport,
+		})
+	}
+	return entities
+}
+
+// ExtractStreetAddressesRussia extracts Russia street addresses written with Cyrillic or
+// transliterated street keywords as PiiEntity objects with context
 func ExtractStreetAddressesRussia(text string) []pii.PiiEntity {
-	addresses := extractWithContext(text, patterns.StreetAddressRussiaRegex,
+	return extractStreetAddressesRussia(text, false)
+}
+
+// extractStreetAddressesRussia extracts Russia street addresses, with the rules of pattern
+// sets before 1.4.0 when legacy is true
+func extractStreetAddressesRussia(text string, legacy bool) []pii.PiiEntity {
+	regex, accept := patterns.StreetAddressRussiaRegex, acceptUnrejected(patterns.StreetAddressRussiaRejection)
+	if legacy {
+		regex, accept = streetAddressRussiaLegacyRegex, nil
+	}
+	addresses := extractWithContextFiltered(text, regex, accept,
 		func(value, context string) pii.StreetAddress {
 			return pii.StreetAddress{
 				BasePii: pii.BasePii{
@@ -1202,7 +1488,7 @@ func ExtractStreetAddressesRussia(text string) []pii.PiiEntity {
 					Contexts: []string{context},
 					Count:    1,
 				},
-				Country: "Russia",
+				Country: pii.CountryRU,
 			}
 		},
 		func(address *pii.StreetAddress, context string) {
@@ -1218,4 +1504,115 @@ func ExtractStreetAddressesRussia(text string) []pii.PiiEntity {
 		})
 	}
 	return entities
-}
\ No newline at end of file
+}
+// --- Netherlands PII ---
+
+// ExtractBSNs extracts Dutch citizen service numbers (BSN) passing the 11-test as
+// PiiEntity objects with context
+func ExtractBSNs(text string) []pii.PiiEntity {
+	return extractNationalIDs(text, patterns.BSNRegex, patterns.BSNRejection, pii.CountryNL, "nl_bsn")
+}
+
+// ExtractPhonesNetherlands extracts Netherlands phone numbers as PiiEntity objects with
+// context. Numbers embedded in longer digit sequences are rejected.
+func ExtractPhonesNetherlands(text string) []pii.PiiEntity {
+	return extractPhones(text, patterns.PhoneNetherlandsRegex, patterns.PhoneNetherlandsRejection, pii.CountryNL)
+}
+
+// ExtractPostalCodesNetherlands extracts Netherlands postcodes such as "1012 AB" as
+// PiiEntity objects with context
+func ExtractPostalCodesNetherlands(text string) []pii.PiiEntity {
+	return extractPostalCodes(text, patterns.PostalCodeNetherlandsRegex, patterns.PostalCodeNetherlandsRejection, pii.CountryNL)
+}
+
+// --- Belgium PII ---
+
+// ExtractNationalNumbersBelgium extracts Belgian national register numbers with a valid
+// birth date and check number as PiiEntity objects with context
+func ExtractNationalNumbersBelgium(text string) []pii.PiiEntity {
+	return extractNationalIDs(text, patterns.NationalNumberBelgiumRegex, patterns.NationalNumberBelgiumRejection, pii.CountryBE, "be_national_number")
+}
+
+// ExtractPhonesBelgium extracts Belgium phone numbers as PiiEntity objects with context.
+// Numbers embedded in longer digit sequences are rejected.
+func ExtractPhonesBelgium(text string) []pii.PiiEntity {
+	return extractPhones(text, patterns.PhoneBelgiumRegex, patterns.PhoneBelgiumRejection, pii.CountryBE)
+}
+
+// ExtractPostalCodesBelgium extracts Belgium postal codes written with the B- prefix or
+// before a place name as PiiEntity objects with context
+func ExtractPostalCodesBelgium(text string) []pii.PiiEntity {
+	return extractPostalCodes(text, patterns.PostalCodeBelgiumRegex, patterns.PostalCodeBelgiumRejection, pii.CountryBE)
+}
+
+// extractNationalIDs extracts the national IDs of a scheme matched by regex and accepted
+// by rejection
+func extractNationalIDs(text string, regex *regexp.Regexp, rejection func(text string, start, end int) string, country pii.Country, scheme string) []pii.PiiEntity {
+	ids := extractWithContextFiltered(text, regex, acceptUnrejected(rejection),
+		func(value, context string) pii.NationalID {
+			id := pii.NewNationalID(value, country, scheme)
+			id.Contexts = []string{context}
+			return id
+		},
+		func(id *pii.NationalID, context string) {
+			id.BasePii.IncrementCount()
+			id.BasePii.AddContext(context)
+		})
+
+	var entities []pii.PiiEntity
+	for _, id := range ids {
+		entities = append(entities, pii.PiiEntity{
+			Type:  pii.PiiTypeNationalID,
+			Value: id,
+		})
+	}
+	return entities
+}
+
+// extractPhones extracts the phone numbers of a country matched by regex and accepted by
+// rejection
+func extractPhones(text string, regex *regexp.Regexp, rejection func(text string, start, end int) string, country pii.Country) []pii.PiiEntity {
+	phones := extractWithContextFiltered(text, regex, acceptUnrejected(rejection),
+		func(value, context string) pii.Phone {
+			phone := pii.NewPhone(value, country)
+			phone.Contexts = []string{context}
+			return phone
+		},
+		func(phone *pii.Phone, context string) {
+			phone.BasePii.IncrementCount()
+			phone.BasePii.AddContext(context)
+		})
+
+	var entities []pii.PiiEntity
+	for _, phone := range phones {
+		entities = append(entities, pii.PiiEntity{
+			Type:  pii.PiiTypePhone,
+			Value: phone,
+		})
+	}
+	return entities
+}
+
+// extractPostalCodes extracts the postal codes of a country matched by regex and accepted
+// by rejection
+func extractPostalCodes(text string, regex *regexp.Regexp, rejection func(text string, start, end int) string, country pii.Country) []pii.PiiEntity {
+	postalCodes := extractWithContextFiltered(text, regex, acceptUnrejected(rejection),
+		func(value, context string) pii.ZipCode {
+			zipCode := pii.NewZipCode(value, country)
+			zipCode.Contexts = []string{context}
+			return zipCode
+		},
+		func(zipCode *pii.ZipCode, context string) {
+			zipCode.BasePii.IncrementCount()
+			zipCode.BasePii.AddContext(context)
+		})
+
+	var entities []pii.PiiEntity
+	for _, zipCode := range postalCodes {
+		entities = append(entities, pii.PiiEntity{
+			Type:  pii.PiiTypeZipCode,
+			Value: zipCode,
+		})
+	}
+	return entities
+}
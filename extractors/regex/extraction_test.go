@@ -0,0 +1,61 @@
+package regex
+
+import (
+	"testing"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+func findIP(entities []pii.PiiEntity, value string) (pii.IPAddress, bool) {
+	for _, entity := range entities {
+		if ip, ok := entity.AsIPAddress(); ok && ip.Value == value {
+			return ip, true
+		}
+	}
+	return pii.IPAddress{}, false
+}
+
+func TestExtractIPAddresses_TrimsAndValidates(t *testing.T) {
+	entities := ExtractIPAddresses("Server 2001:db8::1 \nfailed, retry 2001:db8::1\tlater")
+
+	if len(entities) != 1 {
+		t.Fatalf("Expected 1 deduplicated IPv6 address, got %d", len(entities))
+	}
+	ip, ok := findIP(entities, "2001:db8::1")
+	if !ok {
+		t.Fatalf("Expected value without trailing whitespace, got %+v", entities)
+	}
+	if ip.Count != 2 || ip.Version != "ipv6" {
+		t.Errorf("Expected count 2 and version ipv6, got %+v", ip)
+	}
+}
+
+func TestExtractIPAddresses_ZoneAndEmbeddedIPv4(t *testing.T) {
+	entities := ExtractIPAddresses("Link fe80::1%eth0 up, mapped ::ffff:192.0.2.128 and NAT64 64:ff9b::198.51.100.7")
+
+	linkLocal, ok := findIP(entities, "fe80::1%eth0")
+	if !ok || linkLocal.Zone != "eth0" {
+		t.Errorf("Expected fe80::1%%eth0 with zone eth0, got %+v", entities)
+	}
+
+	mapped, ok := findIP(entities, "::ffff:192.0.2.128")
+	if !ok || mapped.EmbeddedIPv4 != "192.0.2.128" {
+		t.Errorf("Expected mapped address with embedded IPv4, got %+v", entities)
+	}
+
+	nat64, ok := findIP(entities, "64:ff9b::198.51.100.7")
+	if !ok || nat64.EmbeddedIPv4 != "198.51.100.7" {
+		t.Errorf("Expected NAT64 address with embedded IPv4, got %+v", entities)
+	}
+
+	// Embedded IPv4 addresses are reported as part of the IPv6 address only
+	if _, ok := findIP(entities, "192.0.2.128"); ok {
+		t.Error("Did not expect the embedded IPv4 address as a separate entity")
+	}
+}
+
+func TestExtractIPAddresses_RejectsLeadingZeros(t *testing.T) {
+	if entities := ExtractIPAddresses("Host 010.001.002.003"); len(entities) != 0 {
+		t.Errorf("Expected no IP addresses, got %+v", entities)
+	}
+}
@@ -1,34 +1,114 @@
 package regex
 
 import (
+	"context"
 	"runtime"
 	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"unicode/utf8"
 	
 	"github.com/intMeric/pii-extractor/extractors"
+	patterns "github.com/intMeric/pii-extractor/extractors/regex/patterns"
 	"github.com/intMeric/pii-extractor/pii"
+	"github.com/intMeric/pii-extractor/telemetry"
 )
 
+// OptionValidateUSPhoneCodes is the ExtractorConfig option enabling NANP area and
+// exchange code validation for US phone numbers
+const OptionValidateUSPhoneCodes = "validate_us_phone_codes"
+
+// OptionHTTPLogs is the ExtractorConfig option enabling HTTP-log-aware extraction of
+// session cookies, bearer tokens and session ID query parameters
+const OptionHTTPLogs = "http_logs"
+
+// ChunkSize is the document size above which Extract scans newline-aligned chunks
+// and checks for cancellation between them
+const ChunkSize = 1 << 20
+
 // RegexExtractor implements PII extraction using regular expressions
 type RegexExtractor struct {
-	name      string
-	countries []string
-	types     []pii.PiiType
+	name                 string
+	countries            []pii.Country
+	types                []pii.PiiType
+	validateUSPhoneCodes bool
+	httpLogs             bool
+	numericGuard         bool
+	autoConfigure        bool
+	recordSuppressed     bool
+	batchWorkers         int
+	internalEmailDomains []string
+	retentionPolicy      pii.RetentionPolicy
+	aggregator           *pii.Aggregator // non-nil in AggregationTopK mode
+	perOccurrence        bool            // AggregationOccurrences mode
+	chunkSize            int
+	maxContexts          int    // contexts kept per entity, zero for all
+	entityIDSalt         string // salt of the entity IDs
+	displayMode          pii.DisplayMode
+	patternSetVersion    string
+	pinnedTypes          []pii.PiiType // types of the pinned pattern set, nil when not pinned
+	pinErr               error         // set when the pinned pattern set cannot be reproduced
+	rules                atomic.Pointer[RuleSet] // external rules applied with SetRules
 }
 
 // NewExtractor creates a new regex-based PII extractor
 func NewExtractor(config *extractors.ExtractorConfig) *RegexExtractor {
 	extractor := &RegexExtractor{
-		name: "regex-extractor",
+		name:              "regex-extractor",
+		chunkSize:         ChunkSize,
+		patternSetVersion: PatternSetVersion,
 	}
 
 	if config != nil {
 		if config.Countries != nil {
-			extractor.countries = config.Countries
+			// Legacy names such as "UK" are normalized to their ISO codes
+			extractor.countries = make([]pii.Country, len(config.Countries))
+			for i, country := range config.Countries {
+				extractor.countries[i] = country.Canonical()
+			}
 		}
 		if config.Types != nil {
 			extractor.types = config.Types
 		}
+		if validate, ok := config.Options[OptionValidateUSPhoneCodes].(bool); ok {
+			extractor.validateUSPhoneCodes = validate
+		}
+		if httpLogs, ok := config.Options[OptionHTTPLogs].(bool); ok {
+			extractor.httpLogs = httpLogs
+		}
+		if numericGuard, ok := config.Options[OptionNumericGuard].(bool); ok {
+			extractor.numericGuard = numericGuard
+		}
+		if autoConfigure, ok := config.Options[OptionAutoConfigure].(bool); ok {
+			extractor.autoConfigure = autoConfigure
+		}
+		if recordSuppressed, ok := config.Options[OptionRecordSuppressed].(bool); ok {
+			extractor.recordSuppressed = recordSuppressed
+		}
+		// Numbers decoded from JSON configurations are float64
+		switch workers := config.Options[OptionBatchWorkers].(type) {
+		case int:
+			extractor.batchWorkers = workers
+		case float64:
+			extractor.batchWorkers = int(workers)
+		}
+		extractor.maxContexts = config.MaxContexts
+		extractor.entityIDSalt = config.EntityIDSalt
+		extractor.displayMode = config.DisplayMode
+		extractor.internalEmailDomains = config.InternalEmailDomains
+		extractor.retentionPolicy = config.RetentionPolicy
+		if config.PatternSetVersion != "" && config.PatternSetVersion != PatternSetVersion {
+			extractor.patternSetVersion = config.PatternSetVersion
+			extractor.pinErr = CheckPatternSetVersion(config.PatternSetVersion)
+			extractor.pinnedTypes, _ = PatternSetTypes(config.PatternSetVersion)
+		}
+		switch config.AggregationMode {
+		case extractors.AggregationTopK:
+			extractor.aggregator = pii.NewAggregator(config.TopK)
+		case extractors.AggregationOccurrences:
+			extractor.perOccurrence = true
+		}
 	}
 
 	return extractor
@@ -39,8 +119,30 @@ func NewDefaultExtractor() *RegexExtractor {
 	return NewExtractor(nil)
 }
 
+// scan is a single pattern-based extraction performed by Extract
+type scan struct {
+	piiType pii.PiiType
+	country pii.Country // empty for international patterns
+	extract func(string) []pii.PiiEntity
+}
+
 // Extract performs PII extraction on the given text
 func (r *RegexExtractor) Extract(text string) (*pii.PiiExtractionResult, error) {
+	return r.ExtractContext(context.Background(), text)
+}
+
+// ExtractContext performs PII extraction on the given text, recording trace spans under ctx.
+// Cancellation of ctx is checked between pattern scans and text chunks; a cancelled
+// extraction returns ctx.Err() and no result.
+func (r *RegexExtractor) ExtractContext(ctx context.Context, text string) (*pii.PiiExtractionResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, telemetry.SpanRegexExtract, telemetry.Int("text.length", len(text)))
+	defer span.End()
+
+	if r.pinErr != nil {
+		span.RecordError(r.pinErr)
+		return nil, r.pinErr
+	}
+
 	// Pre-allocate slice with estimated capacity based on text length
 	// Rough estimation: 1 PII entity per 200 characters
 	estimatedCapacity := len(text)/200 + 10
@@ -49,239 +151,350 @@ func (r *RegexExtractor) Extract(text string) (*pii.PiiExtractionResult, error)
 	}
 	allEntities := make([]pii.PiiEntity, 0, estimatedCapacity)
 
-	// If specific types are configured, extract only those
-	if len(r.types) > 0 {
-		for _, piiType := range r.types {
-			entities, err := r.ExtractByType(text, piiType)
-			if err != nil {
-				return nil, err
+	// Only the scans for the configured types and countries are run
+	scans := r.scansFor(r.types)
+	if r.autoConfigure {
+		var profile Profile
+		scans, profile = profileScans(scans, text)
+		span.SetAttributes(telemetry.String("auto.countries", profileAttribute(profile)), telemetry.Int("auto.scans", len(scans)))
+	}
+
+	// Matches rejected by the false-positive filters of the scans are recorded on request
+	var filters []patternScan
+	var suppressed []pii.SuppressedCandidate
+	if r.recordSuppressed {
+		filters = r.filterScans(scans)
+	}
+
+	// Large documents are scanned chunk by chunk so that cancellation is honoured
+	// between chunks as well as between pattern scans
+	chunkBytes, chunkRunes := 0, 0
+	for _, chunk := range splitChunks(text, r.chunkSize) {
+		if err := ctx.Err(); err != nil {
+			span.RecordError(err)
+			return nil, err
+		}
+		chunkStart := len(allEntities)
+
+		// Use parallel execution for large text or many extractors
+		if len(chunk) > 10000 && len(scans) > 8 {
+			span.SetAttributes(telemetry.Bool("parallel", true))
+			allEntities = r.executeExtractorsParallel(ctx, chunk, scans, allEntities)
+		} else {
+			// Sequential execution for smaller workloads
+			for _, s := range scans {
+				if ctx.Err() != nil {
+					break
+				}
+				entities := runScan(ctx, s, chunk)
+				if len(entities) > 0 {
+					allEntities = append(allEntities, entities...)
+				}
 			}
-			allEntities = append(allEntities, entities...)
-		}
-	} else {
-		// Collect all extraction operations and batch them
-		var extractorFuncs []func(string) []pii.PiiEntity
-		
-		// Generic/International extractors
-		extractorFuncs = append(extractorFuncs,
-			ExtractEmails,
-			ExtractCreditCards,
-			ExtractIPAddresses,
-			ExtractBtcAddresses,
-			ExtractIBANs,
-		)
-
-		// Country-specific extractors
-		if r.shouldExtractForCountry("US") {
-			extractorFuncs = append(extractorFuncs,
-				ExtractPhonesUS,
-				ExtractSSNsUS,
-				ExtractZipCodesUS,
-				ExtractStreetAddressesUS,
-				ExtractPoBoxesUS,
-			)
 		}
 
-		if r.shouldExtractForCountry("UK") {
-			extractorFuncs = append(extractorFuncs,
-				ExtractPostalCodesUK,
-				ExtractStreetAddressesUK,
-			)
-		}
+		suppressed = append(suppressed, r.rejectedMatches(chunk, chunkBytes, filters)...)
 
-		if r.shouldExtractForCountry("France") {
-			extractorFuncs = append(extractorFuncs,
-				ExtractPostalCodesFrance,
-				ExtractStreetAddressesFrance,
-			)
+		// Locations are found in the chunk and reported in the whole text
+		if chunkBytes > 0 {
+			for i := chunkStart; i < len(allEntities); i++ {
+				allEntities[i] = allEntities[i].ShiftLocations(chunkBytes, chunkRunes)
+			}
 		}
+		chunkBytes += len(chunk)
+		chunkRunes += utf8.RuneCountInString(chunk)
+	}
+	if err := ctx.Err(); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
 
-		if r.shouldExtractForCountry("Spain") {
-			extractorFuncs = append(extractorFuncs,
-				ExtractPostalCodesSpain,
-				ExtractStreetAddressesSpain,
-			)
-		}
+	result := r.newResult(allEntities, text, suppressed)
+	span.SetAttributes(telemetry.Int("pii.entities", result.Total))
+	return result, nil
+}
 
-		if r.shouldExtractForCountry("Italy") {
-			extractorFuncs = append(extractorFuncs,
-				ExtractPostalCodesItaly,
-				ExtractStreetAddressesItaly,
-			)
+// newResult runs the passes over the entities of the whole text and builds its result,
+// folding it into the running summary in aggregation mode. Suppressed holds the matches
+// rejected by the scans, when they are recorded; the passes add the occurrences they drop.
+func (r *RegexExtractor) newResult(entities []pii.PiiEntity, text string, suppressed []pii.SuppressedCandidate) *pii.PiiExtractionResult {
+	var record func(pii.SuppressedCandidate)
+	if r.recordSuppressed {
+		record = func(candidate pii.SuppressedCandidate) {
+			suppressed = append(suppressed, candidate)
 		}
+	}
+	if r.patternSetAtLeast(boundaryVersion) {
+		entities = enforceBoundaries(entities, text, record)
+	}
+	if r.numericGuard {
+		entities = guardNumbers(entities, text, record)
+	}
+	pii.MarkInternalEmails(entities, r.internalEmailDomains)
+	parseAddresses(entities, text)
+	r.sampleContexts(entities)
+	result := pii.NewPiiExtractionResult(entities)
+	result.PatternSetVersion = r.patternSetVersion
+	if r.entityIDSalt != "" {
+		result.AssignIDs(r.entityIDSalt)
+	}
+	if r.displayMode != pii.DisplayMasked {
+		result.SetDisplayMode(r.displayMode)
+	}
+	if len(suppressed) > 0 {
+		slices.SortStableFunc(suppressed, func(a, b pii.SuppressedCandidate) int { return a.Start - b.Start })
+		result.Suppressed = suppressed
+	}
+	if r.retentionPolicy != nil {
+		result.ApplyRetentionPolicy(r.retentionPolicy)
+	}
+	// In occurrences mode each occurrence gets its own entity, span and context
+	if r.perOccurrence {
+		result = result.PerOccurrence(text, patterns.NewContextCache(text).ExtractContext)
+	}
+	// In aggregation mode entities are folded into the running summary (see Aggregate)
+	// and only the per-document counts are returned
+	if r.aggregator != nil {
+		r.aggregator.AddResult(result)
+		result.Entities = []pii.PiiEntity{}
+	}
+	return result
+}
 
-		if r.shouldExtractForCountry("Germany") {
-			extractorFuncs = append(extractorFuncs,
-				ExtractPostalCodesGermany,
-				ExtractPhonesGermany,
-				ExtractStreetAddressesGermany,
-			)
-		}
+// Aggregate returns the running summary of all documents extracted in AggregationTopK mode,
+// or nil if aggregation is disabled
+func (r *RegexExtractor) Aggregate() *pii.Aggregate {
+	if r.aggregator == nil {
+		return nil
+	}
+	return r.aggregator.Snapshot()
+}
 
-		if r.shouldExtractForCountry("China") {
-			extractorFuncs = append(extractorFuncs,
-				ExtractPostalCodesChina,
-				ExtractPhonesChina,
-				ExtractStreetAddressesChina,
-			)
-		}
+// DistinctCounts returns the mergeable distinct value sketches per type of all documents
+// extracted in AggregationTopK mode, or nil if aggregation is disabled
+func (r *RegexExtractor) DistinctCounts() *pii.DistinctCounts {
+	if r.aggregator == nil {
+		return nil
+	}
+	return r.aggregator.Distinct()
+}
 
-		if r.shouldExtractForCountry("India") {
-			extractorFuncs = append(extractorFuncs,
-				ExtractPostalCodesIndia,
-				ExtractPhonesIndia,
-				ExtractStreetAddressesIndia,
-			)
-		}
+// ResetAggregate clears the running summary in AggregationTopK mode
+func (r *RegexExtractor) ResetAggregate() {
+	if r.aggregator != nil {
+		r.aggregator.Reset()
+	}
+}
 
-		if r.shouldExtractForCountry("Arabic") {
-			extractorFuncs = append(extractorFuncs,
-				ExtractPostalCodesArabic,
-				ExtractPhonesArabic,
-				ExtractStreetAddressesArabic,
-			)
-		}
+// runScan executes a single pattern scan inside its own trace span
+func runScan(ctx context.Context, s scan, text string) []pii.PiiEntity {
+	attrs := []telemetry.Attribute{telemetry.String("pii.type", s.piiType.String())}
+	if s.country != "" {
+		attrs = append(attrs, telemetry.String("pii.country", s.country.String()))
+	}
+	_, span := telemetry.StartSpan(ctx, telemetry.SpanRegexScan, attrs...)
+	defer span.End()
 
-		if r.shouldExtractForCountry("Russia") {
-			extractorFuncs = append(extractorFuncs,
-				ExtractPostalCodesRussia,
-				ExtractPhonesRussia,
-				ExtractStreetAddressesRussia,
-			)
-		}
+	entities := s.extract(text)
+	span.SetAttributes(telemetry.Int("pii.matches", len(entities)))
+	return entities
+}
 
-		// Use parallel execution for large text or many extractors
-		if len(text) > 10000 && len(extractorFuncs) > 8 {
-			allEntities = r.executeExtractorsParallel(text, extractorFuncs, allEntities)
-		} else {
-			// Sequential execution for smaller workloads
-			for _, extractorFunc := range extractorFuncs {
-				entities := extractorFunc(text)
-				if len(entities) > 0 {
-					allEntities = append(allEntities, entities...)
-				}
+// splitChunks splits text into chunks of at most size bytes, cutting after the last
+// newline (or else whitespace) of each chunk so that matches are not split
+func splitChunks(text string, size int) []string {
+	if len(text) <= size {
+		return []string{text}
+	}
+
+	var chunks []string
+	for len(text) > size {
+		cut := strings.LastIndexByte(text[:size], '\n') + 1
+		if cut == 0 {
+			cut = strings.LastIndexAny(text[:size], " \t\r") + 1
+		}
+		if cut == 0 {
+			cut = size
+			for cut > 0 && !utf8.RuneStart(text[cut]) {
+				cut--
+			}
+			if cut == 0 {
+				cut = size
 			}
 		}
+		chunks = append(chunks, text[:cut])
+		text = text[cut:]
 	}
-
-	return pii.NewPiiExtractionResult(allEntities), nil
+	if len(text) > 0 {
+		chunks = append(chunks, text)
+	}
+	return chunks
 }
 
-// ExtractByType extracts only specific types of PII from the text
-func (r *RegexExtractor) ExtractByType(text string, piiType pii.PiiType) ([]pii.PiiEntity, error) {
-	switch piiType {
-	case pii.PiiTypeEmail:
-		return ExtractEmails(text), nil
-	case pii.PiiTypeCreditCard:
-		return ExtractCreditCards(text), nil
-	case pii.PiiTypeIPAddress:
-		return ExtractIPAddresses(text), nil
-	case pii.PiiTypeBtcAddress:
-		return ExtractBtcAddresses(text), nil
-	case pii.PiiTypeIBAN:
-		return ExtractIBANs(text), nil
-	case pii.PiiTypePhone:
-		entities := make([]pii.PiiEntity, 0, 20) // Pre-allocate for typical phone count
-		if r.shouldExtractForCountry("US") {
-			entities = append(entities, ExtractPhonesUS(text)...)
-		}
-		if r.shouldExtractForCountry("Germany") {
-			entities = append(entities, ExtractPhonesGermany(text)...)
-		}
-		if r.shouldExtractForCountry("China") {
-			entities = append(entities, ExtractPhonesChina(text)...)
-		}
-		if r.shouldExtractForCountry("India") {
-			entities = append(entities, ExtractPhonesIndia(text)...)
-		}
-		if r.shouldExtractForCountry("Arabic") {
-			entities = append(entities, ExtractPhonesArabic(text)...)
-		}
-		if r.shouldExtractForCountry("Russia") {
-			entities = append(entities, ExtractPhonesRussia(text)...)
-		}
-		return entities, nil
-	case pii.PiiTypeSSN:
-		if r.shouldExtractForCountry("US") {
-			return ExtractSSNsUS(text), nil
-		}
-	case pii.PiiTypeZipCode:
-		entities := make([]pii.PiiEntity, 0, 30) // Pre-allocate for typical postal code count
-		if r.shouldExtractForCountry("US") {
-			entities = append(entities, ExtractZipCodesUS(text)...)
-		}
-		if r.shouldExtractForCountry("UK") {
-			entities = append(entities, ExtractPostalCodesUK(text)...)
-		}
-		if r.shouldExtractForCountry("France") {
-			entities = append(entities, ExtractPostalCodesFrance(text)...)
-		}
-		if r.shouldExtractForCountry("Spain") {
-			entities = append(entities, ExtractPostalCodesSpain(text)...)
-		}
-		if r.shouldExtractForCountry("Italy") {
-			entities = append(entities, ExtractPostalCodesItaly(text)...)
-		}
-		if r.shouldExtractForCountry("Germany") {
-			entities = append(entities, ExtractPostalCodesGermany(text)...)
-		}
-		if r.shouldExtractForCountry("China") {
-			entities = append(entities, ExtractPostalCodesChina(text)...)
-		}
-		if r.shouldExtractForCountry("India") {
-			entities = append(entities, ExtractPostalCodesIndia(text)...)
-		}
-		if r.shouldExtractForCountry("Arabic") {
-			entities = append(entities, ExtractPostalCodesArabic(text)...)
-		}
-		if r.shouldExtractForCountry("Russia") {
-			entities = append(entities, ExtractPostalCodesRussia(text)...)
-		}
-		return entities, nil
-	case pii.PiiTypeStreetAddress:
-		entities := make([]pii.PiiEntity, 0, 25) // Pre-allocate for typical address count
-		if r.shouldExtractForCountry("US") {
-			entities = append(entities, ExtractStreetAddressesUS(text)...)
-		}
-		if r.shouldExtractForCountry("UK") {
-			entities = append(entities, ExtractStreetAddressesUK(text)...)
-		}
-		if r.shouldExtractForCountry("France") {
-			entities = append(entities, ExtractStreetAddressesFrance(text)...)
-		}
-		if r.shouldExtractForCountry("Spain") {
-			entities = append(entities, ExtractStreetAddressesSpain(text)...)
-		}
-		if r.shouldExtractForCountry("Italy") {
-			entities = append(entities, ExtractStreetAddressesItaly(text)...)
-		}
-		if r.shouldExtractForCountry("Germany") {
-			entities = append(entities, ExtractStreetAddressesGermany(text)...)
-		}
-		if r.shouldExtractForCountry("China") {
-			entities = append(entities, ExtractStreetAddressesChina(text)...)
-		}
-		if r.shouldExtractForCountry("India") {
-			entities = append(entities, ExtractStreetAddressesIndia(text)...)
+// scansFor returns the pattern scans for the given types (all types if empty)
+// restricted to the configured countries
+func (r *RegexExtractor) scansFor(types []pii.PiiType) []scan {
+	var scans []scan
+
+	// Generic/International extractors
+	scans = append(scans,
+		scan{pii.PiiTypeEmail, "", ExtractEmails},
+		scan{pii.PiiTypeCreditCard, "", ExtractCreditCards},
+		scan{pii.PiiTypeTrackData, "", ExtractTrackData},
+		scan{pii.PiiTypeIPAddress, "", ExtractIPAddresses},
+		scan{pii.PiiTypeBtcAddress, "", ExtractBtcAddresses},
+		scan{pii.PiiTypeIBAN, "", ExtractIBANs},
+		scan{pii.PiiTypeHostname, "", ExtractHostnames},
+	)
+	if r.httpLogs {
+		scans = append(scans, scan{pii.PiiTypeSessionToken, "", ExtractSessionTokens})
+	}
+
+	// Country-specific extractors from the registered packs
+	for _, pack := range registeredPacks() {
+		if !r.shouldExtractForCountry(pack.Country) {
+			continue
 		}
-		if r.shouldExtractForCountry("Arabic") {
-			entities = append(entities, ExtractStreetAddressesArabic(text)...)
+		for _, cs := range pack.Scans {
+			extract := cs.Extract
+			scans = append(scans, scan{cs.Type, pack.Country, func(text string) []pii.PiiEntity {
+				return extract(r, text)
+			}})
 		}
-		if r.shouldExtractForCountry("Russia") {
-			entities = append(entities, ExtractStreetAddressesRussia(text)...)
+	}
+
+	// External rules replace built-in scans and add their own
+	if rules := r.rules.Load(); rules != nil {
+		scans = slices.DeleteFunc(scans, func(s scan) bool { return rules.replaces(s.piiType, s.country) })
+		scans = append(scans, r.ruleScans(rules)...)
+	}
+
+	if len(types) == 0 && r.pinnedTypes == nil {
+		return scans
+	}
+
+	filtered := scans[:0]
+	for _, s := range scans {
+		if len(types) > 0 && !slices.Contains(types, s.piiType) {
+			continue
 		}
-		return entities, nil
-	case pii.PiiTypePoBox:
-		if r.shouldExtractForCountry("US") {
-			return ExtractPoBoxesUS(text), nil
+		// Types added after a pinned pattern set are not scanned
+		if r.pinnedTypes != nil && !slices.Contains(r.pinnedTypes, s.piiType) {
+			continue
 		}
+		filtered = append(filtered, s)
+	}
+	return filtered
+}
+
+// ExtractByType extracts only specific types of PII from the text
+func (r *RegexExtractor) ExtractByType(text string, piiType pii.PiiType) ([]pii.PiiEntity, error) {
+	if r.pinErr != nil {
+		return nil, r.pinErr
+	}
+
+	// Run only the scans for this type instead of a full extraction
+	entities := make([]pii.PiiEntity, 0, 20)
+	for _, s := range r.scansFor([]pii.PiiType{piiType}) {
+		entities = append(entities, s.extract(text)...)
+	}
+	if r.patternSetAtLeast(boundaryVersion) {
+		entities = enforceBoundaries(entities, text, nil)
+	}
+	if r.numericGuard {
+		entities = guardNumbers(entities, text, nil)
+	}
+	pii.MarkInternalEmails(entities, r.internalEmailDomains)
+	parseAddresses(entities, text)
+	r.sampleContexts(entities)
+	return entities, nil
+}
+
+// sampleContexts keeps at most the configured number of contexts on each entity
+func (r *RegexExtractor) sampleContexts(entities []pii.PiiEntity) {
+	if r.maxContexts <= 0 {
+		return
 	}
+	for i := range entities {
+		entities[i] = entities[i].SampleContexts(r.maxContexts)
+	}
+}
+
+// extractPhonesUS extracts US phone numbers using the configured code validation
+func (r *RegexExtractor) extractPhonesUS(text string) []pii.PiiEntity {
+	return extractPhonesUS(text, r.validateUSPhoneCodes, r.patternSetAtLeast(boundaryVersion))
+}
+
+// phoneUSRejection explains why a US phone candidate is rejected under the configured validation
+func (r *RegexExtractor) phoneUSRejection(text string, start, end int) string {
+	if r.patternSetAtLeast(boundaryVersion) {
+		return patterns.PhoneUSListRejection(text, start, end, r.validateUSPhoneCodes)
+	}
+	return patterns.PhoneUSRejection(text, start, end, r.validateUSPhoneCodes)
+}
+
+// extractPostalCodesArabic extracts Arabic countries postal codes, matching Arabic-script
+// digits unless the pattern set is pinned before they were supported
+func (r *RegexExtractor) extractPostalCodesArabic(text string) []pii.PiiEntity {
+	return extractPostalCodesArabic(text, r.patternSetAtLeast(arabicDigitsVersion))
+}
+
+// extractPhonesArabic extracts Arabic countries phone numbers, matching Arabic-script
+// digits unless the pattern set is pinned before they were supported
+func (r *RegexExtractor) extractPhonesArabic(text string) []pii.PiiEntity {
+	return extractPhonesArabic(text, r.patternSetAtLeast(arabicDigitsVersion))
+}
+
+// extractPhonesRussia extracts Russia phone numbers with the rules of the pattern set
+func (r *RegexExtractor) extractPhonesRussia(text string) []pii.PiiEntity {
+	return extractPhonesRussia(text, !r.patternSetAtLeast(russiaRulesVersion))
+}
+
+// extractStreetAddressesRussia extracts Russia street addresses with the rules of the
+// pattern set
+func (r *RegexExtractor) extractStreetAddressesRussia(text string) []pii.PiiEntity {
+	return extractStreetAddressesRussia(text, !r.patternSetAtLeast(russiaRulesVersion))
+}
+
+// extractPostalCodesIndia extracts India postal codes with the rules of the pattern set
+func (r *RegexExtractor) extractPostalCodesIndia(text string) []pii.PiiEntity {
+	return extractPostalCodesIndia(text, !r.patternSetAtLeast(indiaRulesVersion))
+}
+
+// extractPhonesIndia extracts India phone numbers with the rules of the pattern set
+func (r *RegexExtractor) extractPhonesIndia(text string) []pii.PiiEntity {
+	return extractPhonesIndia(text, !r.patternSetAtLeast(indiaRulesVersion))
+}
+
+// extractStreetAddressesIndia extracts India street addresses with the rules of the
+// pattern set
+func (r *RegexExtractor) extractStreetAddressesIndia(text string) []pii.PiiEntity {
+	return extractStreetAddressesIndia(text, !r.patternSetAtLeast(indiaRulesVersion))
+}
+
+// extractPassportsRussia extracts Russian passports, which pattern sets before 1.4.0 do
+// not match
+func (r *RegexExtractor) extractPassportsRussia(text string) []pii.PiiEntity {
+	if !r.patternSetAtLeast(russiaRulesVersion) {
+		return nil
+	}
+	return ExtractPassportsRussia(text)
+}
+
+// nationalIDArabicRejection explains why a Saudi national ID or Emirates ID match is rejected
+func nationalIDArabicRejection(_ *RegexExtractor, text string, start, end int) string {
+	return patterns.NationalIDArabicRejection(text, start, end)
+}
 
-	return []pii.PiiEntity{}, nil
+// patternRejection adapts a patterns rejection filter into a country pack filter
+func patternRejection(rejection func(text string, start, end int) string) func(r *RegexExtractor, text string, start, end int) string {
+	return func(_ *RegexExtractor, text string, start, end int) string {
+		return rejection(text, start, end)
+	}
 }
 
 // shouldExtractForCountry checks if extraction should be performed for a specific country
-func (r *RegexExtractor) shouldExtractForCountry(country string) bool {
+func (r *RegexExtractor) shouldExtractForCountry(country pii.Country) bool {
 	// If no countries specified, extract for all
 	if len(r.countries) == 0 {
 		return true
@@ -293,10 +506,11 @@ func (r *RegexExtractor) shouldExtractForCountry(country string) bool {
 
 // GetSupportedTypes returns the list of PII types this extractor can handle
 func (r *RegexExtractor) GetSupportedTypes() []pii.PiiType {
-	return []pii.PiiType{
+	types := []pii.PiiType{
 		pii.PiiTypePhone,
 		pii.PiiTypeEmail,
 		pii.PiiTypeSSN,
+		pii.PiiTypeNationalID,
 		pii.PiiTypeZipCode,
 		pii.PiiTypePoBox,
 		pii.PiiTypeStreetAddress,
@@ -304,7 +518,40 @@ func (r *RegexExtractor) GetSupportedTypes() []pii.PiiType {
 		pii.PiiTypeIPAddress,
 		pii.PiiTypeBtcAddress,
 		pii.PiiTypeIBAN,
+		pii.PiiTypeHostname,
+		pii.PiiTypeTrackData,
+	}
+	// Community packs may contribute types beyond the built-in ones
+	for _, pack := range registeredPacks() {
+		for _, cs := range pack.Scans {
+			if !slices.Contains(types, cs.Type) {
+				types = append(types, cs.Type)
+			}
+		}
 	}
+	if r.httpLogs {
+		types = append(types, pii.PiiTypeSessionToken)
+	}
+	for _, s := range r.ruleScans(r.rules.Load()) {
+		if !slices.Contains(types, s.piiType) {
+			types = append(types, s.piiType)
+		}
+	}
+	if r.pinnedTypes != nil {
+		types = slices.DeleteFunc(types, func(t pii.PiiType) bool { return !slices.Contains(r.pinnedTypes, t) })
+	}
+	return types
+}
+
+// Support returns the types detected by the extractor per country, with its configured
+// countries, pinned pattern set, HTTP log mode and rules. International types have no
+// country.
+func (r *RegexExtractor) Support() []extractors.Support {
+	cells := make([]extractors.Support, 0, 64)
+	for _, s := range r.scansFor(nil) {
+		cells = append(cells, extractors.Support{Type: s.piiType, Country: s.country, Methods: []extractors.ExtractionMethod{extractors.MethodRegex}})
+	}
+	return extractors.MergeSupport(cells)
 }
 
 // GetMethod returns the extraction method used by this extractor
@@ -318,25 +565,30 @@ func (r *RegexExtractor) GetName() string {
 }
 
 // GetCountries returns the list of countries this extractor is configured for
-func (r *RegexExtractor) GetCountries() []string {
+func (r *RegexExtractor) GetCountries() []pii.Country {
 	return r.countries
 }
 
+// GetPatternSetVersion returns the pattern set version used by this extractor
+func (r *RegexExtractor) GetPatternSetVersion() string {
+	return r.patternSetVersion
+}
+
 // GetTypes returns the list of PII types this extractor is configured for
 func (r *RegexExtractor) GetTypes() []pii.PiiType {
 	return r.types
 }
 
 // executeExtractorsParallel runs extraction functions in parallel using worker pool
-func (r *RegexExtractor) executeExtractorsParallel(text string, extractorFuncs []func(string) []pii.PiiEntity, initialEntities []pii.PiiEntity) []pii.PiiEntity {
+func (r *RegexExtractor) executeExtractorsParallel(ctx context.Context, text string, scans []scan, initialEntities []pii.PiiEntity) []pii.PiiEntity {
 	numWorkers := runtime.NumCPU()
-	if numWorkers > len(extractorFuncs) {
-		numWorkers = len(extractorFuncs)
+	if numWorkers > len(scans) {
+		numWorkers = len(scans)
 	}
 	
 	// Create channels for work distribution
-	jobs := make(chan func(string) []pii.PiiEntity, len(extractorFuncs))
-	results := make(chan []pii.PiiEntity, len(extractorFuncs))
+	jobs := make(chan scan, len(scans))
+	results := make(chan []pii.PiiEntity, len(scans))
 	
 	// Start worker goroutines
 	var wg sync.WaitGroup
@@ -344,8 +596,12 @@ func (r *RegexExtractor) executeExtractorsParallel(text string, extractorFuncs [
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			for extractorFunc := range jobs {
-				entities := extractorFunc(text)
+			for s := range jobs {
+				if ctx.Err() != nil {
+					results <- nil
+					continue
+				}
+				entities := runScan(ctx, s, text)
 				results <- entities
 			}
 		}()
@@ -353,8 +609,8 @@ func (r *RegexExtractor) executeExtractorsParallel(text string, extractorFuncs [
 	
 	// Send jobs to workers
 	go func() {
-		for _, extractorFunc := range extractorFuncs {
-			jobs <- extractorFunc
+		for _, s := range scans {
+			jobs <- s
 		}
 		close(jobs)
 	}()
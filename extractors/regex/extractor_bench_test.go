@@ -6,6 +6,7 @@ import (
 
 	"github.com/intMeric/pii-extractor/extractors"
 	"github.com/intMeric/pii-extractor/extractors/regex/patterns"
+	"github.com/intMeric/pii-extractor/pii"
 )
 
 // Benchmark data - realistic multi-country text with various PII types
@@ -89,7 +90,7 @@ func BenchmarkRegexExtractor_ExtractByType_Phone(b *testing.B) {
 
 func BenchmarkRegexExtractor_ExtractSpecificCountries(b *testing.B) {
 	config := &extractors.ExtractorConfig{
-		Countries: []string{"US", "UK", "France"},
+		Countries: []pii.Country{pii.CountryUS, pii.CountryGB, pii.CountryFR},
 	}
 	extractor := NewExtractor(config)
 	
@@ -160,4 +161,33 @@ func BenchmarkMemoryAllocations(b *testing.B) {
 		// Prevent compiler optimizations
 		_ = result.Total
 	}
-}
\ No newline at end of file
+}
+func BenchmarkRegexExtractor_ExtractBatch(b *testing.B) {
+	texts := batchTexts(1000)
+	extractor := NewDefaultExtractor()
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := extractor.ExtractBatch(texts); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRegexExtractor_ExtractEachOfBatch(b *testing.B) {
+	texts := batchTexts(1000)
+	extractor := NewDefaultExtractor()
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		for _, text := range texts {
+			if _, err := extractor.Extract(text); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,659 @@
+package regex
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/intMeric/pii-extractor/extractors"
+	patterns "github.com/intMeric/pii-extractor/extractors/regex/patterns"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+func TestScansFor_TypeAndCountryFilter(t *testing.T) {
+	extractor := NewExtractor(&extractors.ExtractorConfig{Countries: []pii.Country{pii.CountryUS, pii.CountryDE}})
+
+	scans := extractor.scansFor([]pii.PiiType{pii.PiiTypePhone})
+	if len(scans) != 2 {
+		t.Fatalf("Expected 2 phone scans (US, Germany), got %d", len(scans))
+	}
+	for _, s := range scans {
+		if s.piiType != pii.PiiTypePhone {
+			t.Errorf("Expected only phone scans, got %s", s.piiType)
+		}
+	}
+
+	if scans := extractor.scansFor([]pii.PiiType{pii.PiiTypeSSN}); len(scans) != 1 {
+		t.Errorf("Expected 1 SSN scan, got %d", len(scans))
+	}
+}
+
+func TestExtractByType_MatchesFullExtraction(t *testing.T) {
+	extractor := NewDefaultExtractor()
+	text := "Mail john@acme.com, SSN 123-45-6789, call (555) 123-4567, visit 123 Main Street"
+
+	result, err := extractor.Extract(text)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+
+	for _, piiType := range []pii.PiiType{pii.PiiTypeEmail, pii.PiiTypeSSN, pii.PiiTypePhone, pii.PiiTypeStreetAddress} {
+		entities, err := extractor.ExtractByType(text, piiType)
+		if err != nil {
+			t.Fatalf("ExtractByType(%s) error = %v", piiType, err)
+		}
+		// Several countries may match the same value; the full result merges them
+		values := make(map[string]bool)
+		for _, entity := range entities {
+			values[entity.GetValue()] = true
+		}
+		if len(values) != len(result.GetEntitiesByType(piiType)) {
+			t.Errorf("ExtractByType(%s) found %d distinct values, full extraction found %d",
+				piiType, len(values), len(result.GetEntitiesByType(piiType)))
+		}
+		for _, entity := range entities {
+			if entity.Type != piiType {
+				t.Errorf("ExtractByType(%s) returned a %s entity", piiType, entity.Type)
+			}
+		}
+	}
+}
+
+func TestAggregationOccurrences(t *testing.T) {
+	extractor := NewExtractor(&extractors.ExtractorConfig{
+		Types:           []pii.PiiType{pii.PiiTypeEmail},
+		AggregationMode: extractors.AggregationOccurrences,
+	})
+
+	text := "From alice@example.com to bob@example.com\ncc alice@example.com"
+	result, err := extractor.Extract(text)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if result.Total != 3 || len(result.Entities) != 3 {
+		t.Fatalf("Expected one entity per occurrence, got %d", result.Total)
+	}
+
+	last := result.Entities[2]
+	start, _ := last.Annotation(pii.AnnotationSpanStart)
+	line, _ := last.Annotation(pii.AnnotationSpanLine)
+	if last.GetValue() != "alice@example.com" || last.GetCount() != 1 || start != 45 || line != 2 {
+		t.Errorf("Unexpected last occurrence %s (count %d, start %v, line %v)", last.GetValue(), last.GetCount(), start, line)
+	}
+	if contexts := last.GetContexts(); len(contexts) != 1 || !strings.Contains(contexts[0], "cc") {
+		t.Errorf("Expected the context of the occurrence, got %v", contexts)
+	}
+}
+
+func TestAggregationOccurrences_Matches(t *testing.T) {
+	extractor := NewExtractor(&extractors.ExtractorConfig{
+		Types:           []pii.PiiType{pii.PiiTypeSSN, pii.PiiTypeSessionToken},
+		AggregationMode: extractors.AggregationOccurrences,
+		Options:         map[string]any{OptionHTTPLogs: true},
+	})
+
+	// The value inside the order ID is not a match, and the masked token is located
+	text := "SSN 123-45-6789\nOrder A123-45-6789B\nGET /app?sessionid=abcdef1234567890XYZ"
+	result, err := extractor.Extract(text)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if result.Stats[pii.PiiTypeSSN] != 1 || result.Stats[pii.PiiTypeSessionToken] != 1 {
+		t.Fatalf("Expected one entity per match, got %v", result.Stats)
+	}
+	token := result.GetSessionTokens()[0]
+	start, _ := token.Annotation(pii.AnnotationSpanStart)
+	line, _ := token.Annotation(pii.AnnotationSpanLine)
+	if start != strings.Index(text, "abcdef") || line != 3 {
+		t.Errorf("Expected the span of the raw token, got start %v, line %v", start, line)
+	}
+}
+
+func TestAggregationTopK(t *testing.T) {
+	extractor := NewExtractor(&extractors.ExtractorConfig{
+		Types:           []pii.PiiType{pii.PiiTypeEmail},
+		AggregationMode: extractors.AggregationTopK,
+		TopK:            2,
+	})
+
+	documents := []string{
+		"From alice@example.com to bob@example.com",
+		"From alice@example.com to carol@example.com, cc alice@example.com",
+		"From alice@example.com to bob@example.com",
+	}
+	for _, document := range documents {
+		result, err := extractor.Extract(document)
+		if err != nil {
+			t.Fatalf("Extract() error = %v", err)
+		}
+		if len(result.Entities) != 0 || result.Total == 0 {
+			t.Errorf("Expected counts without entities, got %d entities and total %d", len(result.Entities), result.Total)
+		}
+	}
+
+	aggregate := extractor.Aggregate()
+	emails := aggregate.Types[pii.PiiTypeEmail]
+	if aggregate.Documents != 3 || emails == nil {
+		t.Fatalf("Unexpected aggregate: %+v", aggregate)
+	}
+	if emails.Occurrences != 7 || emails.DistinctEstimate != 3 {
+		t.Errorf("Expected 7 occurrences and 3 distinct emails, got %d and %d", emails.Occurrences, emails.DistinctEstimate)
+	}
+	if len(emails.TopValues) != 2 || emails.TopValues[0].Value != "alice@example.com" || emails.TopValues[0].Count != 4 {
+		t.Errorf("Expected alice@example.com first with 4 occurrences, got %+v", emails.TopValues)
+	}
+
+	extractor.ResetAggregate()
+	if extractor.Aggregate().Documents != 0 {
+		t.Error("Expected ResetAggregate to clear the summary")
+	}
+}
+
+func TestMaxContextsConfig(t *testing.T) {
+	var lines []string
+	for i := range 20 {
+		lines = append(lines, fmt.Sprintf("Line %d. Mail john@acme.com about ticket %d.", i, i))
+	}
+	text := strings.Join(lines, "\n")
+
+	plain, err := NewDefaultExtractor().Extract(text)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if contexts := plain.GetEmails()[0].GetContexts(); len(contexts) != 20 {
+		t.Errorf("Expected every context by default, got %d", len(contexts))
+	}
+
+	capped, err := NewExtractor(&extractors.ExtractorConfig{MaxContexts: 3}).Extract(text)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	email := capped.GetEmails()[0]
+	if len(email.GetContexts()) != 3 || email.GetCount() != 20 {
+		t.Errorf("Expected 3 contexts of 20 occurrences, got %d contexts and count %d", len(email.GetContexts()), email.GetCount())
+	}
+}
+
+func TestEntityIDSaltConfig(t *testing.T) {
+	text := "Mail john@acme.com"
+	for _, salt := range []string{"", "tenant-a"} {
+		result, err := NewExtractor(&extractors.ExtractorConfig{EntityIDSalt: salt}).Extract(text)
+		if err != nil {
+			t.Fatalf("Extract() error = %v", err)
+		}
+		if id := result.Entities[0].ID; id != pii.EntityID(pii.PiiTypeEmail, "john@acme.com", salt) {
+			t.Errorf("Expected the ID salted with %q, got %q", salt, id)
+		}
+	}
+
+	config, err := json.Marshal(extractors.ExtractorConfig{EntityIDSalt: "tenant-a"})
+	if err != nil || strings.Contains(string(config), "tenant-a") {
+		t.Errorf("Expected the salt left out of serialized configs, got %s (%v)", config, err)
+	}
+}
+
+func TestDisplayModeConfig(t *testing.T) {
+	text := "SSN 123-45-6789"
+	for mode, want := range map[pii.DisplayMode]string{pii.DisplayMasked: "***-**-****", pii.DisplayFull: "123-45-6789"} {
+		result, err := NewExtractor(&extractors.ExtractorConfig{DisplayMode: mode}).Extract(text)
+		if err != nil {
+			t.Fatalf("Extract() error = %v", err)
+		}
+		if got := result.GetSSNs()[0].String(); got != want {
+			t.Errorf("DisplayMode %d: String() = %q, want %q", mode, got, want)
+		}
+	}
+}
+
+func TestInternalEmailDomains(t *testing.T) {
+	extractor := NewExtractor(&extractors.ExtractorConfig{
+		InternalEmailDomains: []string{"acme.com"},
+	})
+
+	result, err := extractor.Extract("Contact jane@ACME.com, bob@eu.acme.com or jane.doe@gmail.com")
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+
+	internal := map[string]bool{}
+	for _, entity := range result.GetEmails() {
+		email, _ := entity.AsEmail()
+		internal[email.Value] = email.Internal
+		if email.Internal && entity.GetSeverity() != pii.SeverityLow {
+			t.Errorf("Expected internal email %s to have low severity, got %s", email.Value, entity.GetSeverity())
+		}
+	}
+
+	want := map[string]bool{"jane@ACME.com": true, "bob@eu.acme.com": true, "jane.doe@gmail.com": false}
+	for value, isInternal := range want {
+		got, found := internal[value]
+		if !found {
+			t.Errorf("Expected email %s to be reported", value)
+		} else if got != isInternal {
+			t.Errorf("Email %s: Internal = %v, want %v", value, got, isInternal)
+		}
+	}
+
+	entities, _ := extractor.ExtractByType("mail jane@acme.com", pii.PiiTypeEmail)
+	if email, _ := entities[0].AsEmail(); !email.Internal {
+		t.Error("Expected ExtractByType to mark internal emails")
+	}
+}
+
+func TestHTTPLogsSessionTokens(t *testing.T) {
+	log := `10.0.0.5 - - "GET /account?session_id=9f8e7d6c5b4a3210 HTTP/1.1" 200 Authorization: Bearer abcdefghijklmnop`
+
+	if result, _ := NewDefaultExtractor().Extract(log); result.HasType(pii.PiiTypeSessionToken) {
+		t.Fatal("Expected session tokens to be ignored without the http_logs option")
+	}
+
+	extractor := NewExtractor(&extractors.ExtractorConfig{
+		Options: map[string]any{OptionHTTPLogs: true},
+	})
+	result, err := extractor.Extract(log)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+
+	tokens := result.GetSessionTokens()
+	if len(tokens) != 2 {
+		t.Fatalf("Expected 2 session tokens, got %d", len(tokens))
+	}
+	for _, entity := range tokens {
+		token, _ := entity.AsSessionToken()
+		for _, raw := range []string{"9f8e7d6c5b4a3210", "abcdefghijklmnop"} {
+			if strings.Contains(token.Value, raw) {
+				t.Errorf("Token value %q is not masked", token.Value)
+			}
+			for _, context := range token.Contexts {
+				if strings.Contains(context, raw) {
+					t.Errorf("Context %q leaks raw token %s", context, raw)
+				}
+			}
+		}
+		if token.Value != pii.MaskSessionToken("9f8e7d6c5b4a3210") && token.Value != pii.MaskSessionToken("abcdefghijklmnop") {
+			t.Errorf("Unexpected masked value %q", token.Value)
+		}
+		// Locations lead back to the raw token, which the masked value cannot
+		locations := entity.GetLocations()
+		if len(locations) != 1 || pii.MaskSessionToken(log[locations[0].Start:locations[0].End]) != token.Value {
+			t.Errorf("Locations %+v do not point to the token %q", locations, token.Value)
+		}
+		if entity.GetSeverity() != pii.SeverityHigh {
+			t.Errorf("Expected high severity, got %s", entity.GetSeverity())
+		}
+	}
+}
+
+func TestTrackDataCriticalSeverity(t *testing.T) {
+	result, err := NewDefaultExtractor().Extract("pos swipe ;4111111111111111=25121010000000000000? approved")
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+
+	tracks := result.GetTrackData()
+	if len(tracks) != 1 {
+		t.Fatalf("Expected 1 track data entity, got %d", len(tracks))
+	}
+	if track, _ := tracks[0].AsTrackData(); track.Track != 2 {
+		t.Errorf("Expected track 2, got %d", track.Track)
+	}
+	if tracks[0].GetSeverity() != pii.SeverityCritical {
+		t.Errorf("Expected critical severity, got %s", tracks[0].GetSeverity())
+	}
+}
+
+func TestRetentionPolicyConfig(t *testing.T) {
+	extractor := NewExtractor(&extractors.ExtractorConfig{
+		RetentionPolicy: pii.DefaultRetentionPolicy(),
+	})
+
+	result, err := extractor.Extract("Card 4111 1111 1111 1111, mail john@acme.com")
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	for _, entity := range result.Entities {
+		if len(entity.Retention) == 0 {
+			t.Errorf("Expected retention hints on %s", entity.Type)
+		}
+	}
+}
+
+func TestStreetAddressComponents(t *testing.T) {
+	extractor := NewExtractor(&extractors.ExtractorConfig{Countries: []pii.Country{pii.CountryUS}})
+
+	text := "Ship to 123 Main Street, Springfield, IL 62704 by Friday"
+	for _, extract := range []func() ([]pii.PiiEntity, error){
+		func() ([]pii.PiiEntity, error) {
+			result, err := extractor.Extract(text)
+			if err != nil {
+				return nil, err
+			}
+			return result.GetEntitiesByType(pii.PiiTypeStreetAddress), nil
+		},
+		func() ([]pii.PiiEntity, error) { return extractor.ExtractByType(text, pii.PiiTypeStreetAddress) },
+	} {
+		entities, err := extract()
+		if err != nil || len(entities) != 1 {
+			t.Fatalf("Expected 1 street address, got %d (%v)", len(entities), err)
+		}
+		address, _ := entities[0].AsStreetAddress()
+		if address.HouseNumber != "123" || address.StreetName != "Main" || address.StreetType != "Street" ||
+			address.City != "Springfield" || address.PostalCode != "62704" {
+			t.Errorf("Unexpected address components: %+v", address)
+		}
+	}
+}
+
+func TestNumericGuard(t *testing.T) {
+	text := "Invoice total $75001 for order 4411\nShip to New York, NY 10001\nFee 10001 USD, depth 555 123 4567 mm"
+	config := &extractors.ExtractorConfig{
+		Countries: []pii.Country{pii.CountryUS},
+		Types:     []pii.PiiType{pii.PiiTypeZipCode, pii.PiiTypePhone},
+	}
+
+	result, err := NewExtractor(config).Extract(text)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if result.Total != 3 {
+		t.Fatalf("Expected 3 entities without the guard, got %d", result.Total)
+	}
+
+	config.Options = map[string]any{OptionNumericGuard: true}
+	extractor := NewExtractor(config)
+	result, err = extractor.Extract(text)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if result.Total != 1 || result.Entities[0].GetValue() != "10001" {
+		t.Fatalf("Expected only the 10001 postal code with the guard, got %+v", result.Entities)
+	}
+	if zip := result.Entities[0]; zip.GetCount() != 1 || len(zip.GetContexts()) != 1 || !strings.Contains(zip.GetContexts()[0], "NY") {
+		t.Errorf("Expected the amount occurrence removed from the postal code, got %d %v", zip.GetCount(), zip.GetContexts())
+	}
+
+	var reasons []string
+	for _, explanation := range extractor.Explain(text) {
+		if explanation.Reason == ReasonFalsePositiveFilter {
+			reasons = append(reasons, explanation.Detail)
+		}
+	}
+	if !slices.Contains(reasons, patterns.NumericRejectCurrency) || !slices.Contains(reasons, patterns.NumericRejectUnit) {
+		t.Errorf("Expected Explain to report the numeric guard, got %v", reasons)
+	}
+}
+
+func TestCountries_LegacyNames(t *testing.T) {
+	var config extractors.ExtractorConfig
+	if err := json.Unmarshal([]byte(`{"countries": ["UK", "Arabic", "fr"]}`), &config); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	want := []pii.Country{pii.CountryGB, pii.RegionArabic, pii.CountryFR}
+	if !slices.Equal(config.Countries, want) {
+		t.Errorf("Countries = %v, want %v", config.Countries, want)
+	}
+
+	extractor := NewExtractor(&extractors.ExtractorConfig{Countries: []pii.Country{"UK"}})
+	result, err := extractor.Extract("Office at 10 Downing Street, London SW1A 2AA")
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if len(result.GetZipCodesByCountry(pii.CountryGB)) == 0 {
+		t.Errorf("Expected GB postal codes with a legacy UK filter, got %+v", result.Entities)
+	}
+	if len(result.GetZipCodesByCountry("UK")) == 0 {
+		t.Error("Expected result filters to accept legacy country names")
+	}
+}
+
+func TestExtractContext_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result, err := NewDefaultExtractor().ExtractContext(ctx, "Mail john@acme.com")
+	if !errors.Is(err, context.Canceled) || result != nil {
+		t.Errorf("Expected context.Canceled and no result, got %v and %+v", err, result)
+	}
+}
+
+func TestExtractContext_ChunkedDocument(t *testing.T) {
+	const chunkSize = 4096
+	line := "Contact john@acme.com or call (555) 123-4567 about the delivery.\n"
+	lines := 3 * chunkSize / len(line)
+	text := strings.Repeat(line, lines)
+
+	chunks := splitChunks(text, chunkSize)
+	if len(chunks) < 3 || strings.Join(chunks, "") != text {
+		t.Fatalf("Expected at least 3 chunks covering the text, got %d", len(chunks))
+	}
+	for _, chunk := range chunks {
+		if !strings.HasSuffix(chunk, "\n") {
+			t.Fatalf("Expected chunks to end on a newline, got %q", chunk)
+		}
+	}
+
+	extractor := NewExtractor(&extractors.ExtractorConfig{Types: []pii.PiiType{pii.PiiTypeEmail}})
+	extractor.chunkSize = chunkSize
+	result, err := extractor.ExtractContext(context.Background(), text)
+	if err != nil {
+		t.Fatalf("ExtractContext() error = %v", err)
+	}
+	emails := result.GetEmails()
+	if len(emails) != 1 {
+		t.Fatalf("Expected one merged email across chunks, got %d", len(emails))
+	}
+	if email, _ := emails[0].AsEmail(); email.Count != lines {
+		t.Errorf("Expected count %d, got %d", lines, email.Count)
+	}
+}
+
+func TestExtract_Locations(t *testing.T) {
+	const chunkSize = 256
+	line := "Écrire à john@acme.com, carte 4111 1111 1111 1111, serveur 10.0.0.1.\n"
+	text := strings.Repeat(line, 3*chunkSize/len(line))
+
+	extractor := NewDefaultExtractor()
+	extractor.chunkSize = chunkSize
+	result, err := extractor.Extract(text)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+
+	runes := []rune(text)
+	for _, entity := range result.Entities {
+		locations := entity.GetLocations()
+		if len(locations) != entity.GetCount() {
+			t.Errorf("%s %q: expected %d locations, got %d", entity.Type, entity.GetValue(), entity.GetCount(), len(locations))
+		}
+		for _, location := range locations {
+			if got := text[location.Start:location.End]; got != entity.GetValue() {
+				t.Errorf("%s: byte offsets %+v point to %q", entity.Type, location, got)
+			}
+			if got := string(runes[location.RuneStart:location.RuneEnd]); got != entity.GetValue() {
+				t.Errorf("%s: rune offsets %+v point to %q", entity.Type, location, got)
+			}
+		}
+	}
+	if len(result.GetEmails()) != 1 || len(result.GetCreditCards()) != 1 || len(result.GetIPAddresses()) != 1 {
+		t.Errorf("Unexpected entities: %+v", result.Stats)
+	}
+}
+
+func TestSplitChunks_NoNewline(t *testing.T) {
+	text := strings.Repeat("é", 10)
+	chunks := splitChunks(text, 5)
+	if strings.Join(chunks, "") != text {
+		t.Fatalf("Chunks do not cover the text: %q", chunks)
+	}
+	for _, chunk := range chunks {
+		if !utf8.ValidString(chunk) {
+			t.Errorf("Chunk %q splits a rune", chunk)
+		}
+	}
+}
+
+func TestArabicDigits(t *testing.T) {
+	text := "الهوية ١٠٨٧٦٥٤٣٢١ والجوال ٠٥٥-٩٨٧-٦٥٤٣، الهوية الإماراتية 784-1990-1234567-6، غير صالح 1087654320"
+	config := &extractors.ExtractorConfig{Countries: []pii.Country{pii.RegionArabic}}
+
+	result, err := NewExtractor(config).Extract(text)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	var ids []string
+	for _, entity := range result.GetNationalIDs() {
+		id, _ := entity.AsNationalID()
+		ids = append(ids, id.Scheme+" "+id.GetValue())
+	}
+	slices.Sort(ids)
+	if want := []string{"ae_emirates_id 784-1990-1234567-6", "sa_national_id ١٠٨٧٦٥٤٣٢١"}; !slices.Equal(ids, want) {
+		t.Errorf("National IDs = %v, want %v", ids, want)
+	}
+	if !slices.ContainsFunc(result.GetPhones(), func(e pii.PiiEntity) bool { return e.GetValue() == "٠٥٥-٩٨٧-٦٥٤٣" }) {
+		t.Errorf("Expected the Eastern Arabic phone as written, got %v", result.GetPhones())
+	}
+
+	// Pattern sets before 1.3.0 only match ASCII digits
+	config.PatternSetVersion = "1.2.0"
+	result, err = NewExtractor(config).Extract(text)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if result.HasType(pii.PiiTypeNationalID) {
+		t.Error("Expected national IDs to be disabled by the pinned pattern set")
+	}
+	for _, phone := range result.GetPhones() {
+		if strings.ContainsRune(phone.GetValue(), '٠') {
+			t.Errorf("Expected no Eastern Arabic phone with the pinned pattern set, got %q", phone.GetValue())
+		}
+	}
+}
+
+func TestRussiaRules(t *testing.T) {
+	text := "Passport 4508 123456, ul. Pushkina, d. 10, tel. 79031234567"
+	config := &extractors.ExtractorConfig{Countries: []pii.Country{pii.CountryRU}}
+
+	result, err := NewExtractor(config).Extract(text)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if ids := result.GetNationalIDs(); len(ids) != 1 || ids[0].GetValue() != "4508 123456" {
+		t.Errorf("Expected the passport, got %v", ids)
+	}
+	if addresses := result.GetStreetAddresses(); len(addresses) != 1 || addresses[0].GetValue() != "ul. Pushkina, d. 10" {
+		t.Errorf("Expected the transliterated address, got %v", addresses)
+	}
+	if phones := result.GetPhones(); len(phones) != 1 || phones[0].GetValue() != "79031234567" {
+		t.Errorf("Expected the 7-prefixed phone, got %v", phones)
+	}
+
+	// Pattern sets before 1.4.0 keep the previous Russia rules
+	config.PatternSetVersion = "1.3.0"
+	result, err = NewExtractor(config).Extract(text)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if result.HasType(pii.PiiTypeNationalID) || result.HasType(pii.PiiTypeStreetAddress) || result.HasType(pii.PiiTypePhone) {
+		t.Errorf("Expected no Russia matches with the pinned pattern set, got %v", result.Entities)
+	}
+}
+
+func TestIndiaRules(t *testing.T) {
+	text := "Ship to 42, 5th Cross, Bengaluru PIN 560 034, call +919845012345"
+	config := &extractors.ExtractorConfig{Countries: []pii.Country{pii.CountryIN}}
+
+	result, err := NewExtractor(config).Extract(text)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if codes := result.GetZipCodes(); len(codes) != 1 || codes[0].GetValue() != "560 034" {
+		t.Errorf("Expected the spaced PIN code, got %v", codes)
+	}
+	if addresses := result.GetStreetAddresses(); len(addresses) != 1 || addresses[0].GetValue() != "42, 5th Cross" {
+		t.Errorf("Expected the cross road address, got %v", addresses)
+	}
+	if phones := result.GetPhones(); len(phones) != 1 || phones[0].GetValue() != "+919845012345" {
+		t.Errorf("Expected the unseparated +91 phone, got %v", phones)
+	}
+
+	// Pattern sets before 1.5.0 keep the previous India rules
+	config.PatternSetVersion = "1.4.0"
+	result, err = NewExtractor(config).Extract(text)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if result.HasType(pii.PiiTypeZipCode) || result.HasType(pii.PiiTypeStreetAddress) {
+		t.Errorf("Expected no PIN code or address with the pinned pattern set, got %v", result.Entities)
+	}
+}
+
+func TestBeneluxRules(t *testing.T) {
+	text := "BSN 111222333, Damrak 1, 1012 LG Amsterdam, bel 06-12345678. " +
+		"Rijksregisternummer 85.07.30-033.28, Wetstraat 16, 1000 Brussel, GSM +32 470 12 34 56."
+	config := &extractors.ExtractorConfig{Countries: []pii.Country{pii.CountryNL, pii.CountryBE}}
+
+	result, err := NewExtractor(config).Extract(text)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	schemes := map[string]pii.Country{}
+	for _, entity := range result.GetNationalIDs() {
+		if id, ok := entity.AsNationalID(); ok {
+			schemes[id.Scheme] = id.Country
+		}
+	}
+	if schemes["nl_bsn"] != pii.CountryNL || schemes["be_national_number"] != pii.CountryBE || len(schemes) != 2 {
+		t.Errorf("Expected the BSN and the Belgian national number, got %v", schemes)
+	}
+	byCountry := result.GroupByCountry()
+	for _, country := range []pii.Country{pii.CountryNL, pii.CountryBE} {
+		types := map[pii.PiiType]bool{}
+		for _, entity := range byCountry[country] {
+			types[entity.Type] = true
+		}
+		if !types[pii.PiiTypePhone] || !types[pii.PiiTypeZipCode] {
+			t.Errorf("Expected %s phones and postal codes, got %v", country, byCountry[country])
+		}
+	}
+
+	// Pattern sets before 1.6.0 do not detect Benelux identifiers
+	config.PatternSetVersion = "1.5.0"
+	result, err = NewExtractor(config).Extract(text)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if len(result.GetEntitiesByCountry(pii.CountryNL)) > 0 || len(result.GetEntitiesByCountry(pii.CountryBE)) > 0 {
+		t.Errorf("Expected no Benelux entities with the pinned pattern set, got %v", result.Entities)
+	}
+}
+
+func TestSupport_FollowsConfiguration(t *testing.T) {
+	extractor := NewExtractor(&extractors.ExtractorConfig{Countries: []pii.Country{pii.CountryDE}})
+
+	has := func(support []extractors.Support, piiType pii.PiiType, country pii.Country) bool {
+		return slices.ContainsFunc(support, func(s extractors.Support) bool { return s.Type == piiType && s.Country == country })
+	}
+	support := extractor.Support()
+	if !has(support, pii.PiiTypePhone, pii.CountryDE) || !has(support, pii.PiiTypeEmail, "") {
+		t.Errorf("missing German phones or emails in %+v", support)
+	}
+	if has(support, pii.PiiTypeSSN, pii.CountryUS) {
+		t.Error("US SSNs reported for an extractor restricted to Germany")
+	}
+
+	rules, err := ParseRules([]byte(`{"version": 1, "rules": [{"name": "no-de-phones", "type": "phone", "country": "DE", "disable": true}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	extractor.SetRules(rules)
+	if has(extractor.Support(), pii.PiiTypePhone, pii.CountryDE) {
+		t.Error("German phones reported after a rule disabled them")
+	}
+}
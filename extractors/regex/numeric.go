@@ -0,0 +1,101 @@
+package regex
+
+import (
+	"slices"
+	"strings"
+
+	patterns "github.com/intMeric/pii-extractor/extractors/regex/patterns"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// OptionNumericGuard is the ExtractorConfig option rejecting phone numbers and postal codes
+// that are amounts or measurements, such as "$75001" or "555 123 4567 mm", in financial
+// documents and reports (see patterns.NumericRejection)
+const OptionNumericGuard = "numeric_guard"
+
+// guardNumbers drops the occurrences of phone numbers and postal codes rejected by
+// patterns.NumericRejection, removing their count and contexts. Entities left without
+// occurrences are dropped. Dropped occurrences are passed to record, unless it is nil.
+func guardNumbers(entities []pii.PiiEntity, text string, record func(pii.SuppressedCandidate)) []pii.PiiEntity {
+	kept := entities[:0]
+	for _, entity := range entities {
+		if entity.Type != pii.PiiTypePhone && entity.Type != pii.PiiTypeZipCode {
+			kept = append(kept, entity)
+			continue
+		}
+
+		value := entity.GetValue()
+		rejected := 0
+		var accepted, rejectedContexts []string
+		var rejectedStarts []int
+		for offset := 0; offset < len(text); {
+			index := strings.Index(text[offset:], value)
+			if index < 0 {
+				break
+			}
+			start, end := offset+index, offset+index+len(value)
+			offset = end
+			// Occurrences inside longer tokens were not matched by the patterns
+			if (start > 0 && isAlphanumeric(text[start-1])) || (end < len(text) && isAlphanumeric(text[end])) {
+				continue
+			}
+			context := patterns.ExtractContext(text, start, end)
+			if detail := patterns.NumericRejection(text, start, end); detail != "" {
+				if record != nil {
+					record(suppressedAt(entity, text, start, end, detail))
+				}
+				rejected++
+				rejectedContexts = append(rejectedContexts, context)
+				rejectedStarts = append(rejectedStarts, start)
+			} else {
+				accepted = append(accepted, context)
+			}
+		}
+		if rejected == 0 {
+			kept = append(kept, entity)
+			continue
+		}
+		if len(accepted) == 0 {
+			continue
+		}
+
+		trim := func(base pii.BasePii) pii.BasePii {
+			base.Count = max(base.Count-rejected, 1)
+			contexts := make([]string, 0, len(base.Contexts))
+			for _, context := range base.Contexts {
+				if !slices.Contains(rejectedContexts, context) || slices.Contains(accepted, context) {
+					contexts = append(contexts, context)
+				}
+			}
+			base.Contexts = contexts
+			base.Locations = slices.DeleteFunc(slices.Clone(base.Locations), func(location pii.Location) bool {
+				return slices.Contains(rejectedStarts, location.Start)
+			})
+			return base
+		}
+		switch v := entity.Value.(type) {
+		case pii.Phone:
+			v.BasePii = trim(v.BasePii)
+			entity.Value = v
+		case pii.ZipCode:
+			v.BasePii = trim(v.BasePii)
+			entity.Value = v
+		}
+		kept = append(kept, entity)
+	}
+	return kept
+}
+
+// numericRejection explains why a phone number or postal code match is rejected by the
+// numeric guard, when it is enabled
+func (r *RegexExtractor) numericRejection(piiType pii.PiiType, text string, start, end int) string {
+	if !r.numericGuard || (piiType != pii.PiiTypePhone && piiType != pii.PiiTypeZipCode) {
+		return ""
+	}
+	return patterns.NumericRejection(text, start, end)
+}
+
+// isAlphanumeric reports whether b is an ASCII letter or digit
+func isAlphanumeric(b byte) bool {
+	return b >= '0' && b <= '9' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z'
+}
@@ -0,0 +1,252 @@
+package regex
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+
+	patterns "github.com/intMeric/pii-extractor/extractors/regex/patterns"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// CountryScan is a single pattern scan contributed by a country pack
+type CountryScan struct {
+	Type    pii.PiiType
+	Regex   *regexp.Regexp                                              // candidate pattern, used by Explain (optional)
+	Extract func(r *RegexExtractor, text string) []pii.PiiEntity        // extracts the entities of this scan
+	Filter  func(r *RegexExtractor, text string, start, end int) string // explains why a Regex match is rejected (optional)
+}
+
+// CountryPack groups the pattern scans for one country or region
+type CountryPack struct {
+	Country pii.Country
+	Scans   []CountryScan
+}
+
+// Scan adapts a plain extraction function into a country pack scan
+func Scan(piiType pii.PiiType, regex *regexp.Regexp, extract func(text string) []pii.PiiEntity) CountryScan {
+	return CountryScan{
+		Type:    piiType,
+		Regex:   regex,
+		Extract: func(_ *RegexExtractor, text string) []pii.PiiEntity { return extract(text) },
+	}
+}
+
+var (
+	packsMu sync.RWMutex
+	packs   []CountryPack
+)
+
+// RegisterCountry registers a country pack, replacing any pack already registered
+// for the same country. Packs are scanned by every regex extractor whose configured
+// countries include the pack country, in registration order.
+func RegisterCountry(pack CountryPack) error {
+	country, ok := pii.ParseCountry(string(pack.Country))
+	if !ok {
+		return fmt.Errorf("invalid country %q", pack.Country)
+	}
+	if len(pack.Scans) == 0 {
+		return fmt.Errorf("country pack %s has no scans", country)
+	}
+	for i, s := range pack.Scans {
+		if s.Extract == nil {
+			return fmt.Errorf("country pack %s: scan %d (%s) has no extract function", country, i, s.Type)
+		}
+	}
+	pack.Country = country
+
+	packsMu.Lock()
+	defer packsMu.Unlock()
+
+	for i := range packs {
+		if packs[i].Country == country {
+			packs[i] = pack
+			return nil
+		}
+	}
+	packs = append(packs, pack)
+	return nil
+}
+
+// RegisteredCountries returns the countries with a registered pack, in registration order
+func RegisteredCountries() []pii.Country {
+	packsMu.RLock()
+	defer packsMu.RUnlock()
+
+	countries := make([]pii.Country, len(packs))
+	for i, pack := range packs {
+		countries[i] = pack.Country
+	}
+	return countries
+}
+
+// registeredPacks returns a snapshot of the registered country packs
+func registeredPacks() []CountryPack {
+	packsMu.RLock()
+	defer packsMu.RUnlock()
+	return append([]CountryPack(nil), packs...)
+}
+
+// mustRegisterCountry registers a built-in pack, panicking on invalid definitions
+func mustRegisterCountry(pack CountryPack) {
+	if err := RegisterCountry(pack); err != nil {
+		panic(err)
+	}
+}
+
+// Built-in country packs
+func init() {
+	mustRegisterCountry(CountryPack{Country: pii.CountryUS, Scans: []CountryScan{
+		{
+			Type:    pii.PiiTypePhone,
+			Regex:   patterns.PhoneUSRegex,
+			Extract: (*RegexExtractor).extractPhonesUS,
+			Filter:  (*RegexExtractor).phoneUSRejection,
+		},
+		Scan(pii.PiiTypeSSN, patterns.SSNUSRegex, ExtractSSNsUS),
+		Scan(pii.PiiTypeZipCode, patterns.ZipCodeUSRegex, ExtractZipCodesUS),
+		Scan(pii.PiiTypeStreetAddress, patterns.StreetAddressUSRegex, ExtractStreetAddressesUS),
+		Scan(pii.PiiTypePoBox, patterns.PoBoxUSRegex, ExtractPoBoxesUS),
+	}})
+
+	mustRegisterCountry(CountryPack{Country: pii.CountryGB, Scans: []CountryScan{
+		Scan(pii.PiiTypeZipCode, patterns.PostalCodeUKRegex, ExtractPostalCodesUK),
+		Scan(pii.PiiTypeStreetAddress, patterns.StreetAddressUKRegex, ExtractStreetAddressesUK),
+	}})
+
+	mustRegisterCountry(CountryPack{Country: pii.CountryFR, Scans: []CountryScan{
+		Scan(pii.PiiTypeZipCode, patterns.PostalCodeFranceRegex, ExtractPostalCodesFrance),
+		Scan(pii.PiiTypeStreetAddress, patterns.StreetAddressFranceRegex, ExtractStreetAddressesFrance),
+	}})
+
+	mustRegisterCountry(CountryPack{Country: pii.CountryES, Scans: []CountryScan{
+		Scan(pii.PiiTypeZipCode, patterns.PostalCodeSpainRegex, ExtractPostalCodesSpain),
+		Scan(pii.PiiTypeStreetAddress, patterns.StreetAddressSpainRegex, ExtractStreetAddressesSpain),
+	}})
+
+	mustRegisterCountry(CountryPack{Country: pii.CountryIT, Scans: []CountryScan{
+		Scan(pii.PiiTypeZipCode, patterns.PostalCodeItalyRegex, ExtractPostalCodesItaly),
+		Scan(pii.PiiTypeStreetAddress, patterns.StreetAddressItalyRegex, ExtractStreetAddressesItaly),
+	}})
+
+	mustRegisterCountry(CountryPack{Country: pii.CountryDE, Scans: []CountryScan{
+		Scan(pii.PiiTypeZipCode, patterns.PostalCodeGermanyRegex, ExtractPostalCodesGermany),
+		Scan(pii.PiiTypePhone, patterns.PhoneGermanyRegex, ExtractPhonesGermany),
+		Scan(pii.PiiTypeStreetAddress, patterns.StreetAddressGermanyRegex, ExtractStreetAddressesGermany),
+	}})
+
+	mustRegisterCountry(CountryPack{Country: pii.CountryCN, Scans: []CountryScan{
+		Scan(pii.PiiTypeZipCode, patterns.PostalCodeChinaRegex, ExtractPostalCodesChina),
+		Scan(pii.PiiTypePhone, patterns.PhoneChinaRegex, ExtractPhonesChina),
+		Scan(pii.PiiTypeStreetAddress, patterns.StreetAddressChinaRegex, ExtractStreetAddressesChina),
+	}})
+
+	mustRegisterCountry(CountryPack{Country: pii.CountryIN, Scans: []CountryScan{
+		{
+			Type:    pii.PiiTypeZipCode,
+			Regex:   patterns.PostalCodeIndiaRegex,
+			Extract: (*RegexExtractor).extractPostalCodesIndia,
+			Filter:  patternRejection(patterns.PostalCodeIndiaRejection),
+		},
+		{
+			Type:    pii.PiiTypePhone,
+			Regex:   patterns.PhoneIndiaRegex,
+			Extract: (*RegexExtractor).extractPhonesIndia,
+			Filter:  patternRejection(patterns.PhoneIndiaRejection),
+		},
+		{
+			Type:    pii.PiiTypeStreetAddress,
+			Regex:   patterns.StreetAddressIndiaRegex,
+			Extract: (*RegexExtractor).extractStreetAddressesIndia,
+		},
+	}})
+
+	mustRegisterCountry(CountryPack{Country: pii.RegionArabic, Scans: []CountryScan{
+		{
+			Type:    pii.PiiTypeZipCode,
+			Regex:   patterns.PostalCodeArabicRegex,
+			Extract: (*RegexExtractor).extractPostalCodesArabic,
+		},
+		{
+			Type:    pii.PiiTypePhone,
+			Regex:   patterns.PhoneArabicRegex,
+			Extract: (*RegexExtractor).extractPhonesArabic,
+		},
+		{
+			Type:    pii.PiiTypeNationalID,
+			Regex:   patterns.NationalIDSaudiRegex,
+			Extract: func(_ *RegexExtractor, text string) []pii.PiiEntity { return ExtractNationalIDsSaudi(text) },
+			Filter:  nationalIDArabicRejection,
+		},
+		{
+			Type:    pii.PiiTypeNationalID,
+			Regex:   patterns.EmiratesIDRegex,
+			Extract: func(_ *RegexExtractor, text string) []pii.PiiEntity { return ExtractEmiratesIDs(text) },
+			Filter:  nationalIDArabicRejection,
+		},
+		Scan(pii.PiiTypeStreetAddress, patterns.StreetAddressArabicRegex, ExtractStreetAddressesArabic),
+	}})
+
+	mustRegisterCountry(CountryPack{Country: pii.CountryRU, Scans: []CountryScan{
+		Scan(pii.PiiTypeZipCode, patterns.PostalCodeRussiaRegex, ExtractPostalCodesRussia),
+		{
+			Type:    pii.PiiTypePhone,
+			Regex:   patterns.PhoneRussiaRegex,
+			Extract: (*RegexExtractor).extractPhonesRussia,
+			Filter:  patternRejection(patterns.PhoneRussiaRejection),
+		},
+		{
+			Type:    pii.PiiTypeNationalID,
+			Regex:   patterns.PassportRussiaRegex,
+			Extract: (*RegexExtractor).extractPassportsRussia,
+			Filter:  patternRejection(patterns.PassportRussiaRejection),
+		},
+		{
+			Type:    pii.PiiTypeStreetAddress,
+			Regex:   patterns.StreetAddressRussiaRegex,
+			Extract: (*RegexExtractor).extractStreetAddressesRussia,
+			Filter:  patternRejection(patterns.StreetAddressRussiaRejection),
+		},
+	}})
+	mustRegisterCountry(CountryPack{Country: pii.CountryNL, Scans: []CountryScan{
+		{
+			Type:    pii.PiiTypeNationalID,
+			Regex:   patterns.BSNRegex,
+			Extract: since(beneluxVersion, ExtractBSNs),
+			Filter:  patternRejection(patterns.BSNRejection),
+		},
+		{
+			Type:    pii.PiiTypePhone,
+			Regex:   patterns.PhoneNetherlandsRegex,
+			Extract: since(beneluxVersion, ExtractPhonesNetherlands),
+			Filter:  patternRejection(patterns.PhoneNetherlandsRejection),
+		},
+		{
+			Type:    pii.PiiTypeZipCode,
+			Regex:   patterns.PostalCodeNetherlandsRegex,
+			Extract: since(beneluxVersion, ExtractPostalCodesNetherlands),
+			Filter:  patternRejection(patterns.PostalCodeNetherlandsRejection),
+		},
+	}})
+
+	mustRegisterCountry(CountryPack{Country: pii.CountryBE, Scans: []CountryScan{
+		{
+			Type:    pii.PiiTypeNationalID,
+			Regex:   patterns.NationalNumberBelgiumRegex,
+			Extract: since(beneluxVersion, ExtractNationalNumbersBelgium),
+			Filter:  patternRejection(patterns.NationalNumberBelgiumRejection),
+		},
+		{
+			Type:    pii.PiiTypePhone,
+			Regex:   patterns.PhoneBelgiumRegex,
+			Extract: since(beneluxVersion, ExtractPhonesBelgium),
+			Filter:  patternRejection(patterns.PhoneBelgiumRejection),
+		},
+		{
+			Type:    pii.PiiTypeZipCode,
+			Regex:   patterns.PostalCodeBelgiumRegex,
+			Extract: since(beneluxVersion, ExtractPostalCodesBelgium),
+			Filter:  patternRejection(patterns.PostalCodeBelgiumRejection),
+		},
+	}})
+}
@@ -0,0 +1,91 @@
+package regex
+
+import (
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/intMeric/pii-extractor/extractors"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// withPacks restores the registered country packs when the test ends
+func withPacks(t *testing.T) {
+	t.Helper()
+	saved := registeredPacks()
+	t.Cleanup(func() {
+		packsMu.Lock()
+		packs = saved
+		packsMu.Unlock()
+	})
+}
+
+func TestRegisterCountry_CommunityPack(t *testing.T) {
+	withPacks(t)
+
+	cepRegex := regexp.MustCompile(`\b\d{5}-\d{3}\b`)
+	err := RegisterCountry(CountryPack{Country: "br", Scans: []CountryScan{
+		Scan(pii.PiiTypeZipCode, cepRegex, func(text string) []pii.PiiEntity {
+			var entities []pii.PiiEntity
+			for _, value := range cepRegex.FindAllString(text, -1) {
+				entities = append(entities, pii.PiiEntity{Type: pii.PiiTypeZipCode, Value: pii.NewZipCode(value, "BR")})
+			}
+			return entities
+		}),
+	}})
+	if err != nil {
+		t.Fatalf("RegisterCountry() error = %v", err)
+	}
+	if !slices.Contains(RegisteredCountries(), pii.Country("BR")) {
+		t.Fatalf("Expected BR in %v", RegisteredCountries())
+	}
+
+	text := "Entrega: Av. Paulista, 01310-100 São Paulo"
+	result, err := NewExtractor(&extractors.ExtractorConfig{Countries: []pii.Country{"BR"}}).Extract(text)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if zips := result.GetZipCodesByCountry("BR"); len(zips) != 1 || zips[0].GetValue() != "01310-100" {
+		t.Errorf("Expected the BR postal code, got %+v", result.Entities)
+	}
+
+	result, _ = NewExtractor(&extractors.ExtractorConfig{Countries: []pii.Country{pii.CountryUS}}).Extract(text)
+	if len(result.GetZipCodesByCountry("BR")) != 0 {
+		t.Error("Expected the BR pack to be skipped when BR is not configured")
+	}
+}
+
+func TestRegisterCountry_ReplacesPack(t *testing.T) {
+	withPacks(t)
+
+	before := len(RegisteredCountries())
+	err := RegisterCountry(CountryPack{Country: "UK", Scans: []CountryScan{
+		Scan(pii.PiiTypeZipCode, nil, func(string) []pii.PiiEntity { return nil }),
+	}})
+	if err != nil {
+		t.Fatalf("RegisterCountry() error = %v", err)
+	}
+	if len(RegisteredCountries()) != before {
+		t.Errorf("Expected the GB pack to be replaced, got %v", RegisteredCountries())
+	}
+
+	result, _ := NewDefaultExtractor().Extract("10 Downing Street, London SW1A 2AA")
+	if len(result.GetZipCodesByCountry(pii.CountryGB)) != 0 {
+		t.Error("Expected the replacement GB pack to be used")
+	}
+}
+
+func TestRegisterCountry_Invalid(t *testing.T) {
+	withPacks(t)
+
+	noop := Scan(pii.PiiTypePhone, nil, func(string) []pii.PiiEntity { return nil })
+	for _, pack := range []CountryPack{
+		{Country: "Atlantis", Scans: []CountryScan{noop}},
+		{Country: "BR"},
+		{Country: "BR", Scans: []CountryScan{{Type: pii.PiiTypePhone}}},
+	} {
+		if err := RegisterCountry(pack); err == nil {
+			t.Errorf("Expected an error registering %+v", pack)
+		}
+	}
+}
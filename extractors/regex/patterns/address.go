@@ -0,0 +1,104 @@
+package patterns
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// AddressParts are the components of a street address
+type AddressParts struct {
+	HouseNumber string
+	StreetName  string
+	StreetType  string
+	City        string
+	PostalCode  string
+}
+
+// streetTypeSuffixes are the street types that follow the street name, as in "12 Main Street"
+// or "Münchner Straße 5". The US types come from StreetTypesUS.
+var streetTypeSuffixes = append([]string{
+	"crescent", "cres", "close", "cl", "way", "gardens", "gdns", "mews", "hill", "green",
+	"common", "grove", "rise", "view", "walk", "row", "gate",
+}, StreetTypesUS...)
+
+// compoundStreetTypes are the German street types written as the end of the street name, as
+// in "Hauptstraße"
+var compoundStreetTypes = []string{
+	"straße", "strasse", "str.", "platz", "weg", "allee", "gasse", "ring", "damm", "chaussee", "ufer",
+}
+
+// streetTypePrefixes are the street types that precede the street name, as in "12 rue de la
+// Paix", "calle Mayor" or "via Roma"
+var streetTypePrefixes = []string{
+	"rue", "avenue", "boulevard", "place", "impasse", "allée", "cours", "quai", "passage",
+	"square", "chemin", "route", "voie", "esplanade", "promenade", "calle", "avenida", "plaza",
+	"paseo", "ronda", "travesía", "carretera", "camino", "vía", "rambla", "via", "viale",
+	"piazza", "corso", "largo", "strada", "vicolo", "piazzale",
+}
+
+var (
+	houseNumberRegex = regexp.MustCompile(`^\d{1,5}[a-zA-Z]?$`)
+
+	// cityWords matches one to four capitalized words of a city name
+	cityWords = `\p{Lu}[\p{L}.'-]*(?:[ \t]+\p{Lu}[\p{L}.'-]*){0,3}`
+	// addressSeparator joins an address to its city line: a comma or a line break
+	addressSeparator = `^[ \t]*(?:,[ \t]*|\r?\n[ \t]*)`
+
+	// "Springfield, IL 62704" or "London SW1A 1AA"
+	cityPostalRegex = regexp.MustCompile(addressSeparator + `(` + cityWords + `)(?:[ \t]*,[ \t]*|[ \t]+)(?:[A-Z]{2}[ \t]+)?` +
+		`(\d{5}(?:-\d{4})?|[A-Z]{1,2}\d[A-Z\d]?[ \t]*\d[A-Z]{2})\b`)
+	// "75001 Paris" or "10115 Berlin"
+	postalCityRegex = regexp.MustCompile(addressSeparator + `(\d{4,6})[ \t]+(` + cityWords + `)`)
+	// "Springfield" alone
+	cityRegex = regexp.MustCompile(addressSeparator + `(` + cityWords + `)[ \t]*(?:[,\r\n]|$)`)
+)
+
+// ParseAddress decomposes a matched street address into its house number, street name and
+// street type. following is the text after the address: a city and postal code on the same
+// or the next line, such as ", Springfield, IL 62704" or "\n75001 Paris", fill City and
+// PostalCode. Components that cannot be identified are left empty.
+//
+// Street types written as the end of a German street name, such as "Hauptstraße", are
+// reported in StreetType while StreetName keeps the whole word.
+func ParseAddress(value, following string) AddressParts {
+	var parts AddressParts
+	words := strings.Fields(value)
+
+	// The house number leads ("12 Main Street") or trails ("Hauptstraße 5")
+	if len(words) > 1 && houseNumberRegex.MatchString(words[0]) {
+		parts.HouseNumber, words = words[0], words[1:]
+	} else if len(words) > 1 && houseNumberRegex.MatchString(words[len(words)-1]) {
+		parts.HouseNumber, words = words[len(words)-1], words[:len(words)-1]
+	}
+
+	if len(words) > 0 {
+		first, last := strings.ToLower(words[0]), strings.ToLower(words[len(words)-1])
+		switch {
+		case len(words) > 1 && slices.Contains(streetTypeSuffixes, strings.TrimSuffix(last, ".")),
+			len(words) > 1 && slices.Contains(compoundStreetTypes, last):
+			parts.StreetType, words = words[len(words)-1], words[:len(words)-1]
+		case len(words) > 1 && slices.Contains(streetTypePrefixes, first):
+			parts.StreetType, words = words[0], words[1:]
+		default:
+			for _, suffix := range compoundStreetTypes {
+				if strings.HasSuffix(last, suffix) && len(last) > len(suffix) {
+					// The suffix is lowercase ASCII or ß, so its length is the same in words
+					lastWord := words[len(words)-1]
+					parts.StreetType = lastWord[len(lastWord)-len(suffix):]
+					break
+				}
+			}
+		}
+		parts.StreetName = strings.Join(words, " ")
+	}
+
+	if m := cityPostalRegex.FindStringSubmatch(following); m != nil {
+		parts.City, parts.PostalCode = m[1], m[2]
+	} else if m := postalCityRegex.FindStringSubmatch(following); m != nil {
+		parts.PostalCode, parts.City = m[1], m[2]
+	} else if m := cityRegex.FindStringSubmatch(following); m != nil {
+		parts.City = m[1]
+	}
+	return parts
+}
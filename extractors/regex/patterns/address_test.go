@@ -0,0 +1,24 @@
+package patterns
+
+import "testing"
+
+func TestParseAddress(t *testing.T) {
+	tests := []struct {
+		value, following string
+		want             AddressParts
+	}{
+		{"123 Main Street", ", Springfield, IL 62704", AddressParts{"123", "Main", "Street", "Springfield", "62704"}},
+		{"42 North Oak Ave.", "\nNew York, NY 10001-1234 USA", AddressParts{"42", "North Oak", "Ave.", "New York", "10001-1234"}},
+		{"10 Downing Street", ", London SW1A 2AA", AddressParts{"10", "Downing", "Street", "London", "SW1A 2AA"}},
+		{"12 rue de la Paix", ", 75002 Paris", AddressParts{"12", "de la Paix", "rue", "Paris", "75002"}},
+		{"15 Münchner Straße", "\n80331 München", AddressParts{"15", "Münchner", "Straße", "München", "80331"}},
+		{"Hauptstraße 5", ", Berlin", AddressParts{"5", "Hauptstraße", "straße", "Berlin", ""}},
+		{"7 via Roma", " is closed", AddressParts{"7", "Roma", "via", "", ""}},
+		{"221B Baker Street", ", and more text", AddressParts{"221B", "Baker", "Street", "", ""}},
+	}
+	for _, tt := range tests {
+		if got := ParseAddress(tt.value, tt.following); got != tt.want {
+			t.Errorf("ParseAddress(%q, %q) = %+v, want %+v", tt.value, tt.following, got, tt.want)
+		}
+	}
+}
@@ -1,11 +1,16 @@
 package patterns
 
-import "regexp"
+import (
+	"regexp"
+	"strings"
+)
 
 // Arabic countries-specific patterns
 const (
 	PostalCodeArabicPattern    = `\b\d{5}\b`
 	PhoneArabicPattern         = `(?:\+(?:966|971|20|962|965|968|973|974|967)[\s\-]?)?(?:0)?[1-9]\d[\s\-]?\d{3}[\s\-]?\d{4}`
+	NationalIDSaudiPattern     = `\b[12]\d{9}\b`                            // Saudi national ID (1) or Iqama (2)
+	EmiratesIDPattern          = `\b784[\s\-]?\d{4}[\s\-]?\d{7}[\s\-]?\d\b` // UAE Emirates ID
 	StreetAddressArabicPattern = `(?i)(?:[^\x00-\x7F]+\s*)+(?:شارع|طريق|حي|منطقة|مدينة|قرية|ميدان|كورنيش|جسر|نفق|ساحة|حديقة|مجمع|برج|عمارة|بناية|فيلا|شقة|رقم|ص\.ب)\s*(?:\d+)?`
 )
 
@@ -13,10 +18,32 @@ const (
 var (
 	PostalCodeArabicRegex    = regexp.MustCompile(PostalCodeArabicPattern)
 	PhoneArabicRegex         = regexp.MustCompile(PhoneArabicPattern)
+	NationalIDSaudiRegex     = regexp.MustCompile(NationalIDSaudiPattern)
+	EmiratesIDRegex          = regexp.MustCompile(EmiratesIDPattern)
 	StreetAddressArabicRegex = regexp.MustCompile(StreetAddressArabicPattern)
 )
 
 // Arabic countries-specific convenience functions
 var PostalCodesArabic = func(text string) []string { return Match(text, PostalCodeArabicRegex) }
 var PhonesArabic = func(text string) []string { return Match(text, PhoneArabicRegex) }
-var StreetAddressesArabic = func(text string) []string { return MatchAddresses(text, StreetAddressArabicRegex) }
\ No newline at end of file
+var StreetAddressesArabic = func(text string) []string { return MatchAddresses(text, StreetAddressArabicRegex) }
+
+// NationalIDRejectChecksum is the reason given by NationalIDArabicRejection for IDs failing
+// their check digit
+const NationalIDRejectChecksum = "national ID check digit mismatch"
+
+// NationalIDArabicRejection explains why a Saudi national ID or Emirates ID match is not
+// reported: both end with a Luhn check digit. Eastern Arabic digits must be normalized
+// first (see ScanNormalized).
+func NationalIDArabicRejection(text string, start, end int) string {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, text[start:end])
+	if !LuhnValid(digits) {
+		return NationalIDRejectChecksum
+	}
+	return ""
+}
\ No newline at end of file
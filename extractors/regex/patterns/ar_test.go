@@ -149,4 +149,42 @@ func TestArabicStreetAddresses(t *testing.T) {
 			}
 		})
 	}
+}
+
+func TestScanNormalized_EasternArabicDigits(t *testing.T) {
+	text := "اتصل على ٠٥٥-٩٨٧-٦٥٤٣ أو 055-987-6543، الرمز ۱۱۵۶۴"
+
+	phones := ScanNormalized(text, PhoneArabicRegex, nil)
+	if len(phones) != 2 || phones[0].Value != "٠٥٥-٩٨٧-٦٥٤٣" || phones[1].Value != "055-987-6543" {
+		t.Fatalf("Expected both phones as written, got %+v", phones)
+	}
+	for _, span := range append(phones, ScanNormalized(text, PostalCodeArabicRegex, nil)...) {
+		if text[span.Start:span.End] != span.Value {
+			t.Errorf("Expected the span offsets to point to %q in the original text", span.Value)
+		}
+	}
+	if codes := ScanNormalized(text, PostalCodeArabicRegex, nil); len(codes) != 1 || codes[0].Value != "۱۱۵۶۴" {
+		t.Errorf("Expected the Persian-digit postal code, got %+v", codes)
+	}
+	if normalized, offsets := NormalizeDigits("055-987-6543"); normalized != "055-987-6543" || offsets != nil {
+		t.Error("Expected ASCII text to be returned unchanged")
+	}
+}
+
+func TestNationalIDArabicRejection(t *testing.T) {
+	testCases := []struct {
+		text  string
+		valid bool
+	}{
+		{"1087654321", true},
+		{"1087654320", false},
+		{"784-1990-1234567-6", true},
+		{"784-1990-1234567-5", false},
+	}
+
+	for _, tc := range testCases {
+		if got := NationalIDArabicRejection(tc.text, 0, len(tc.text)) == ""; got != tc.valid {
+			t.Errorf("NationalIDArabicRejection(%q) valid = %v, want %v", tc.text, got, tc.valid)
+		}
+	}
 }
\ No newline at end of file
@@ -0,0 +1,115 @@
+package patterns
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Belgium-specific patterns
+const (
+	NationalNumberBelgiumPattern = `\b\d{2}\.?\d{2}\.?\d{2}[\-\s]?\d{3}\.?\d{2}\b`
+	PhoneBelgiumPattern          = `(?:(?:\+|00)32[\s\-]?(?:\(0\)[\s\-]?)?|\b0)(?:4\d{2}(?:[\s\-./]?\d){6}|[1-9](?:[\s\-./]?\d){7})`
+	PostalCodeBelgiumPattern     = `\b(?:B-)?[1-9]\d{3}\b`
+)
+
+// Belgium-specific compiled patterns
+var (
+	NationalNumberBelgiumRegex = regexp.MustCompile(NationalNumberBelgiumPattern)
+	PhoneBelgiumRegex          = regexp.MustCompile(PhoneBelgiumPattern)
+	PostalCodeBelgiumRegex     = regexp.MustCompile(PostalCodeBelgiumPattern)
+)
+
+// Belgium-specific convenience functions
+var NationalNumbersBelgium = func(text string) []string {
+	return matchRejecting(text, NationalNumberBelgiumRegex, NationalNumberBelgiumRejection)
+}
+var PhonesBelgium = func(text string) []string { return matchRejecting(text, PhoneBelgiumRegex, PhoneBelgiumRejection) }
+var PostalCodesBelgium = func(text string) []string {
+	return matchRejecting(text, PostalCodeBelgiumRegex, PostalCodeBelgiumRejection)
+}
+
+// Reasons returned by the Belgium rejection filters
+const (
+	NationalIDRejectDate     = "national ID birth date does not exist"
+	PostalCodeRejectNoLocale = "no B- prefix or place name after the code"
+)
+
+// NationalNumberBelgiumRejection checks a NationalNumberBelgiumRegex match. The
+// national register number is a YY.MM.DD birth date, a 3-digit serial and a check number
+// equal to 97 minus the first nine digits modulo 97, prefixed with 2 for births from 2000.
+// BIS numbers add 20 or 40 to the month, and a zero month or day stands for an unknown
+// date.
+func NationalNumberBelgiumRejection(text string, start, end int) string {
+	if touchesDigits(text, start, end) {
+		return PhoneRejectEmbedded
+	}
+	digits := digitsOnly(text[start:end])
+	body, _ := strconv.Atoi(digits[:9])
+	check, _ := strconv.Atoi(digits[9:])
+
+	var century int
+	switch {
+	case 97-body%97 == check:
+		century = 1900
+	case 97-(2_000_000_000+body)%97 == check:
+		century = 2000
+	default:
+		return NationalIDRejectChecksum
+	}
+
+	year, _ := strconv.Atoi(digits[0:2])
+	month, _ := strconv.Atoi(digits[2:4])
+	day, _ := strconv.Atoi(digits[4:6])
+	if month >= 40 {
+		month -= 40
+	} else if month >= 20 {
+		month -= 20
+	}
+	if month > 12 || day > 31 {
+		return NationalIDRejectDate
+	}
+	if month == 0 || day == 0 {
+		return ""
+	}
+	date := time.Date(century+year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if date.Month() != time.Month(month) || date.Day() != day {
+		return NationalIDRejectDate
+	}
+	return ""
+}
+
+// PhoneBelgiumRejection checks a PhoneBelgiumRegex match and rejects numbers embedded in
+// a longer number
+func PhoneBelgiumRejection(text string, start, end int) string {
+	if touchesDigits(text, start, end) {
+		return PhoneRejectEmbedded
+	}
+	return ""
+}
+
+// PostalCodeBelgiumRejection checks a PostalCodeBelgiumRegex match. Any 4-digit number,
+// such as a year, fits the pattern, so the code must carry the B- prefix or be followed
+// by a capitalized place name of at least three letters, as in "1000 Brussel".
+func PostalCodeBelgiumRejection(text string, start, end int) string {
+	if strings.HasPrefix(text[start:end], "B-") {
+		return ""
+	}
+	rest := text[end:]
+	trimmed := strings.TrimLeft(rest, " ")
+	if len(trimmed) == len(rest) || len(rest)-len(trimmed) > 2 {
+		return PostalCodeRejectNoLocale
+	}
+	if first, _ := utf8.DecodeRuneInString(trimmed); !unicode.IsUpper(first) {
+		return PostalCodeRejectNoLocale
+	}
+	// Two capitals are the letters of a Dutch postcode, such as "1234 AB"
+	letters := strings.IndexFunc(trimmed, func(r rune) bool { return !unicode.IsLetter(r) })
+	if letters >= 0 && utf8.RuneCountInString(trimmed[:letters]) < 3 {
+		return PostalCodeRejectNoLocale
+	}
+	return ""
+}
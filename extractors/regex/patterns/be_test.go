@@ -0,0 +1,102 @@
+package patterns
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestBelgiumNationalNumbers(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{
+			name:     "Born before and after 2000",
+			text:     "Rijksregisternummer 85.07.30-033.28, numéro national 10120512350.",
+			expected: []string{"85.07.30-033.28", "10120512350"},
+		},
+		{
+			name:     "Check number mismatch",
+			text:     "NN 85.07.30-033.29",
+			expected: []string{},
+		},
+		{
+			name:     "Birth date does not exist",
+			text:     "NN 85.02.30-033.90",
+			expected: []string{},
+		},
+		{
+			name:     "Embedded in a longer number",
+			text:     "Ref 185073003328",
+			expected: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if result := NationalNumbersBelgium(tc.text); !slices.Equal(result, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestBelgiumPhones(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{
+			name:     "Landlines and mobiles",
+			text:     "Bel 02 123 45 67, +32 470 12 34 56, 0032 9 123 45 67 of 0470/12.34.56.",
+			expected: []string{"02 123 45 67", "+32 470 12 34 56", "0032 9 123 45 67", "0470/12.34.56"},
+		},
+		{
+			name:     "Embedded in a longer number",
+			text:     "Ordernummer 02123456789",
+			expected: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if result := PhonesBelgium(tc.text); !slices.Equal(result, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestBelgiumPostalCodes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{
+			name:     "Before a place name or with the B- prefix",
+			text:     "Wetstraat 16, 1000 Brussel; Place Saint-Lambert, 4000 LIÈGE; B-9000 Gent.",
+			expected: []string{"1000", "4000", "B-9000"},
+		},
+		{
+			name:     "Years and quantities",
+			text:     "In 2024 we shipped 1500 units, see chapter 1234 for details.",
+			expected: []string{},
+		},
+		{
+			name:     "Dutch postcode letters",
+			text:     "1012 LG Amsterdam",
+			expected: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if result := PostalCodesBelgium(tc.text); !slices.Equal(result, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package patterns
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Reasons returned by BoundaryRejection
+const (
+	BoundaryRejectWord  = "inside a longer word or number"
+	BoundaryRejectToken = "inside a larger token such as an order ID or encoded blob"
+)
+
+// TokenJoiners are the characters joining the parts of a larger token, such as the "-" of
+// "ORD-2024-4111111111111111", the "_" of identifiers or the "/" and "+" of base64
+const TokenJoiners = "-_/+\\"
+
+// ListSeparators are the token joiners that also separate the items of a list, such as
+// the "/" of "555-123-4567/555-987-6543", when a match is on both sides
+const ListSeparators = "/"
+
+// phoneExtension matches an extension written right after a phone number, such as the
+// "x12" of "555-123-4567x12"
+var phoneExtension = regexp.MustCompile(`^(?i)(?:x|ext\.?|extension)\s*\d{1,6}`)
+
+// BoundaryRejection checks that a match at text[start:end] is a whole token, and returns
+// why it is part of a larger one, or an empty string if it is accepted. The extractor
+// applies it to identifiers such as phone, card and account numbers, so that they are
+// never found inside order IDs, file names or base64 blobs. A match is inside a larger token when a
+// letter, digit or underscore touches it, when a joiner (TokenJoiners) followed by a letter
+// or digit does, when a period followed by a digit does, or when base64 padding ("=" or
+// "==") ends it. Letters of scripts written without spaces, such as Chinese, do not extend
+// tokens, and a one or two letter country prefix such as the "D-" of "D-10115" does not
+// join one.
+func BoundaryRejection(text string, start, end int) string {
+	return BoundaryRejectionAmong(text, start, end, nil)
+}
+
+// BoundaryRejectionAmong is BoundaryRejection for a match among the other matches of
+// the text, given as start and end offsets like those of MatchWithIndices: a list
+// separator (ListSeparators) with another match right on its other side separates the
+// two matches instead of joining them into a larger token.
+func BoundaryRejectionAmong(text string, start, end int, matches [][]int) string {
+	before, beforeSize := utf8.DecodeLastRuneInString(text[:start])
+	after, afterSize := utf8.DecodeRuneInString(text[end:])
+	if isTokenRune(before) || isTokenRune(after) {
+		return BoundaryRejectWord
+	}
+
+	if previous, _ := utf8.DecodeLastRuneInString(text[:start-beforeSize]); joins(before, previous) &&
+		!(before == '-' && isCountryPrefix(text[:start-beforeSize])) &&
+		!separates(before, matches, func(match []int) bool { return match[1] == start-beforeSize }) {
+		return BoundaryRejectToken
+	}
+	rest := text[end+afterSize:]
+	if next, _ := utf8.DecodeRuneInString(rest); joins(after, next) &&
+		!separates(after, matches, func(match []int) bool { return match[0] == end+afterSize }) {
+		return BoundaryRejectToken
+	}
+	if after == '=' {
+		padding := strings.TrimPrefix(rest, "=")
+		if next, _ := utf8.DecodeRuneInString(padding); !isTokenRune(next) && next != '=' {
+			return BoundaryRejectToken
+		}
+	}
+	return ""
+}
+
+// PhoneExtensionEnd returns the end of the extension written right after a phone number
+// ending at end, such as the "x12" of "555-123-4567x12" or the "ext.12" of
+// "555-123-4567ext.12", or end itself when there is none. The extension is part of the
+// phone token, so boundaries are checked after it.
+func PhoneExtensionEnd(text string, end int) int {
+	return end + len(phoneExtension.FindString(text[end:]))
+}
+
+// separates reports whether a joiner is a list separator with a match on its other side
+func separates(joiner rune, matches [][]int, adjacent func(match []int) bool) bool {
+	return strings.ContainsRune(ListSeparators, joiner) && slices.ContainsFunc(matches, adjacent)
+}
+
+// joins reports whether a joiner character followed, on the side away from a match, by r
+// links the match to the rest of a token
+func joins(joiner, r rune) bool {
+	if joiner == '.' {
+		return unicode.IsDigit(r)
+	}
+	return strings.ContainsRune(TokenJoiners, joiner) && isTokenRune(r)
+}
+
+// isCountryPrefix reports whether text ends with a one or two letter uppercase country
+// code starting its token, such as the "D" of "D-10115" or the "NL" of "NL-1012"
+func isCountryPrefix(text string) bool {
+	letters := 0
+	for letters < 3 && letters < len(text) {
+		c := text[len(text)-1-letters]
+		if c < 'A' || c > 'Z' {
+			break
+		}
+		letters++
+	}
+	if letters == 0 || letters > 2 {
+		return false
+	}
+	previous, _ := utf8.DecodeLastRuneInString(text[:len(text)-letters])
+	return !isTokenRune(previous)
+}
+
+// isTokenRune reports whether r continues a token: a letter, digit or underscore, except
+// the letters of scripts written without spaces between words
+func isTokenRune(r rune) bool {
+	switch {
+	case r == '_' || unicode.IsDigit(r):
+		return true
+	case unicode.IsLetter(r):
+		return !unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Thai, unicode.Lao, unicode.Khmer, unicode.Myanmar)
+	}
+	return false
+}
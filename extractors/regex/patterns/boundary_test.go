@@ -0,0 +1,79 @@
+package patterns
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBoundaryRejection(t *testing.T) {
+	tests := []struct {
+		text, value, want string
+	}{
+		{"Card 4111111111111111 on file", "4111111111111111", ""},
+		{"card=4111111111111111&exp=12", "4111111111111111", ""},
+		{"(555) 123-4567, ext. 12", "(555) 123-4567", ""},
+		{"Call 555-123-4567.", "555-123-4567", ""},
+		{"Adresse D-10115 Berlin", "10115", ""},
+		{"电话13812345678联系", "13812345678", ""},
+		{"Order ORD4111111111111111 shipped", "4111111111111111", BoundaryRejectWord},
+		{"Ref 94111111111111111", "4111111111111111", BoundaryRejectWord},
+		{"key_4111111111111111", "4111111111111111", BoundaryRejectWord},
+		{"Order ORD-2024-4111111111111111 shipped", "4111111111111111", BoundaryRejectToken},
+		{"Order 4111111111111111-A2 shipped", "4111111111111111", BoundaryRejectToken},
+		{"See /files/123-45-6789/scan.pdf", "123-45-6789", BoundaryRejectToken},
+		{"blob aGVsbG8+123456789/d29ybGQ=", "123456789", BoundaryRejectToken},
+		{"blob aGVsbG8gd29ybGQ+5551234567==", "5551234567", BoundaryRejectToken},
+		{"Version 1.555-123-4567", "555-123-4567", BoundaryRejectToken},
+	}
+	for _, tt := range tests {
+		start := strings.Index(tt.text, tt.value)
+		if got := BoundaryRejection(tt.text, start, start+len(tt.value)); got != tt.want {
+			t.Errorf("BoundaryRejection(%q, %q) = %q, want %q", tt.text, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestBoundaryRejectionAmong(t *testing.T) {
+	text := "Tel: 555-123-4567/555-987-6543"
+	first := []int{5, 17}
+	second := []int{18, 30}
+	matches := [][]int{first, second}
+
+	if got := BoundaryRejectionAmong(text, first[0], first[1], matches); got != "" {
+		t.Errorf("Expected the first number of the list accepted, got %q", got)
+	}
+	if got := BoundaryRejectionAmong(text, second[0], second[1], matches); got != "" {
+		t.Errorf("Expected the second number of the list accepted, got %q", got)
+	}
+	if got := BoundaryRejectionAmong(text, first[0], first[1], [][]int{first}); got != BoundaryRejectToken {
+		t.Errorf("Expected a joiner without a match on its other side to join, got %q", got)
+	}
+
+	// Other joiners do not separate list items
+	text = "Tel: 555-123-4567-555-987-6543"
+	if got := BoundaryRejectionAmong(text, first[0], first[1], matches); got != BoundaryRejectToken {
+		t.Errorf("Expected a hyphen to join the matches, got %q", got)
+	}
+}
+
+func TestPhoneExtensionEnd(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"555-123-4567x12 now", "x12"},
+		{"555-123-4567 X 12 now", ""},
+		{"555-123-4567ext.12 now", "ext.12"},
+		{"555-123-4567ext 1234 now", "ext 1234"},
+		{"555-123-4567xyz now", ""},
+		{"555-123-4567", ""},
+	}
+	for _, tt := range tests {
+		if got := PhoneExtensionEnd(tt.text, 12); tt.text[12:got] != tt.want {
+			t.Errorf("PhoneExtensionEnd(%q) = %q, want %q", tt.text, tt.text[12:got], tt.want)
+		}
+		if tt.want != "" && BoundaryRejection(tt.text, 0, PhoneExtensionEnd(tt.text, 12)) != "" {
+			t.Errorf("Expected %q accepted with its extension", tt.text)
+		}
+	}
+}
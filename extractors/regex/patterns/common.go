@@ -10,7 +10,7 @@ import (
 const (
 	EmailPattern          = `(?i)\b([A-Za-z0-9!#$%&'*+\/=?^_{|.}~-]+@(?:[a-z0-9](?:[a-z0-9-]*[a-z0-9])?\.)+[a-z0-9](?:[a-z0-9-]*[a-z0-9])?)\b`
 	IPv4Pattern           = `(?:(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\.){3}(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)`
-	IPv6Pattern           = `(?:(?:(?:[0-9A-Fa-f]{1,4}:){7}(?:[0-9A-Fa-f]{1,4}|:))|(?:(?:[0-9A-Fa-f]{1,4}:){6}(?::[0-9A-Fa-f]{1,4}|(?:(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)(?:\.(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)){3})|:))|(?:(?:[0-9A-Fa-f]{1,4}:){5}(?:(?:(?::[0-9A-Fa-f]{1,4}){1,2})|:(?:(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)(?:\.(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)){3})|:))|(?:(?:[0-9A-Fa-f]{1,4}:){4}(?:(?:(?::[0-9A-Fa-f]{1,4}){1,3})|(?:(?::[0-9A-Fa-f]{1,4})?:(?:(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)(?:\.(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)){3}))|:))|(?:(?:[0-9A-Fa-f]{1,4}:){3}(?:(?:(?::[0-9A-Fa-f]{1,4}){1,4})|(?:(?::[0-9A-Fa-f]{1,4}){0,2}:(?:(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)(?:\.(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)){3}))|:))|(?:(?:[0-9A-Fa-f]{1,4}:){2}(?:(?:(?::[0-9A-Fa-f]{1,4}){1,5})|(?:(?::[0-9A-Fa-f]{1,4}){0,3}:(?:(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)(?:\.(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)){3}))|:))|(?:(?:[0-9A-Fa-f]{1,4}:){1}(?:(?:(?::[0-9A-Fa-f]{1,4}){1,6})|(?:(?::[0-9A-Fa-f]{1,4}){0,4}:(?:(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)(?:\.(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)){3}))|:))|(?::(?:(?:(?::[0-9A-Fa-f]{1,4}){1,7})|(?:(?::[0-9A-Fa-f]{1,4}){0,5}:(?:(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)(?:\.(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)){3}))|:)))(?:%.+)?\s*`
+	IPv6Pattern           = `(?:(?:(?:[0-9A-Fa-f]{1,4}:){7}(?:[0-9A-Fa-f]{1,4}|:))|(?:(?:[0-9A-Fa-f]{1,4}:){6}(?::[0-9A-Fa-f]{1,4}|(?:(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)(?:\.(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)){3})|:))|(?:(?:[0-9A-Fa-f]{1,4}:){5}(?:(?:(?::[0-9A-Fa-f]{1,4}){1,2})|:(?:(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)(?:\.(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)){3})|:))|(?:(?:[0-9A-Fa-f]{1,4}:){4}(?:(?:(?::[0-9A-Fa-f]{1,4}){1,3})|(?:(?::[0-9A-Fa-f]{1,4})?:(?:(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)(?:\.(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)){3}))|:))|(?:(?:[0-9A-Fa-f]{1,4}:){3}(?:(?:(?::[0-9A-Fa-f]{1,4}){1,4})|(?:(?::[0-9A-Fa-f]{1,4}){0,2}:(?:(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)(?:\.(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)){3}))|:))|(?:(?:[0-9A-Fa-f]{1,4}:){2}(?:(?:(?::[0-9A-Fa-f]{1,4}){1,5})|(?:(?::[0-9A-Fa-f]{1,4}){0,3}:(?:(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)(?:\.(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)){3}))|:))|(?:(?:[0-9A-Fa-f]{1,4}:){1}(?:(?:(?::[0-9A-Fa-f]{1,4}){1,6})|(?:(?::[0-9A-Fa-f]{1,4}){0,4}:(?:(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)(?:\.(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)){3}))|:))|(?::(?:(?:(?::[0-9A-Fa-f]{1,4}){1,7})|(?:(?::[0-9A-Fa-f]{1,4}){0,5}:(?:(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)(?:\.(?:25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)){3}))|:)))(?:%[0-9A-Za-z_.-]+)?`
 	IPPattern             = IPv4Pattern + `|` + IPv6Pattern
 	CreditCardPattern     = `\b(?:(?:\d{4}[\s-]?){3}\d{4}|\d{15,16})\b`
 	VISACreditCardPattern = `4\d{3}[\s-]?\d{4}[\s-]?\d{4}[\s-]?\d{4}`
@@ -136,77 +136,14 @@ func NewContextCache(text string) *ContextCache {
 
 // ExtractContext extracts the context around a match using exactly 10 words before and after
 func ExtractContext(text string, start, end int) string {
-	return extractWordContext(text, start, end)
+	return NewContextCache(text).ExtractContext(start, end)
 }
 
-// ExtractContextWithCache extracts context using pre-computed word cache for better performance
+// ExtractContext extracts context using the pre-computed words, for repeated extraction on the same text
 func (cache *ContextCache) ExtractContext(start, end int) string {
 	return cache.extractWordContextCached(start, end)
 }
 
-// extractWordContext extracts 10 words before and after the match
-func extractWordContext(text string, start, end int) string {
-	words := strings.Fields(text)
-	if len(words) == 0 {
-		return ""
-	}
-
-	// Find the word indices that contain our match
-	wordStart := -1
-	wordEnd := -1
-	currentPos := 0
-
-	for i, word := range words {
-		// Find the actual position of this word in the text
-		wordStartPos := strings.Index(text[currentPos:], word)
-		if wordStartPos == -1 {
-			continue
-		}
-		wordStartPos += currentPos
-		wordEndPos := wordStartPos + len(word)
-
-		// Check if this word contains the start of our match
-		if wordStartPos <= start && start < wordEndPos {
-			wordStart = i
-		}
-		// Check if this word contains the end of our match
-		if wordStartPos < end && end <= wordEndPos {
-			wordEnd = i
-		}
-
-		// Move to the position after this word for the next search
-		currentPos = wordEndPos
-
-		// Skip whitespace
-		for currentPos < len(text) && unicode.IsSpace(rune(text[currentPos])) {
-			currentPos++
-		}
-
-		if wordStart != -1 && wordEnd != -1 {
-			break
-		}
-	}
-
-	if wordStart == -1 || wordEnd == -1 {
-		return ""
-	}
-
-	// Extract 10 words before and after
-	contextStart := max(0, wordStart-10)
-	contextEnd := min(len(words), wordEnd+10+1)
-
-	context := strings.Join(words[contextStart:contextEnd], " ")
-	// Clean up the context by trimming whitespace and removing newlines
-	context = strings.ReplaceAll(context, "\n", " ")
-	context = strings.ReplaceAll(context, "\r", " ")
-	context = strings.ReplaceAll(context, "\t", " ")
-	// Replace multiple spaces with single space
-	for strings.Contains(context, "  ") {
-		context = strings.ReplaceAll(context, "  ", " ")
-	}
-	return strings.TrimSpace(context)
-}
-
 // extractWordContextCached extracts 10 words before and after using pre-computed words
 func (cache *ContextCache) extractWordContextCached(start, end int) string {
 	if len(cache.words) == 0 {
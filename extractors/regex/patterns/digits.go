@@ -0,0 +1,83 @@
+package patterns
+
+import (
+	"regexp"
+	"strings"
+)
+
+// NormalizeDigit returns the ASCII digit of an Eastern Arabic (٠-٩) or Extended
+// Arabic-Indic (۰-۹, used in Persian and Urdu) digit, and r unchanged otherwise
+func NormalizeDigit(r rune) rune {
+	switch {
+	case r >= '٠' && r <= '٩':
+		return '0' + r - '٠'
+	case r >= '۰' && r <= '۹':
+		return '0' + r - '۰'
+	}
+	return r
+}
+
+// NormalizeDigits returns text with its Eastern Arabic and Extended Arabic-Indic digits
+// replaced by ASCII digits, along with the offset in text of every byte of the result
+// plus one for its end, so that matches in the result can be reported on text. The
+// offsets are nil when text has no such digit and is returned unchanged.
+func NormalizeDigits(text string) (string, []int) {
+	if !strings.ContainsFunc(text, func(r rune) bool { return NormalizeDigit(r) != r }) {
+		return text, nil
+	}
+
+	var b strings.Builder
+	b.Grow(len(text))
+	offsets := make([]int, 0, len(text)+1)
+	for i, r := range text {
+		if digit := NormalizeDigit(r); digit != r {
+			b.WriteRune(digit)
+			offsets = append(offsets, i)
+			continue
+		}
+		size := len(string(r))
+		b.WriteString(text[i : i+size])
+		for j := range size {
+			offsets = append(offsets, i+j)
+		}
+	}
+	offsets = append(offsets, len(text))
+	return b.String(), offsets
+}
+
+// ScanNormalized is Scan matching regex against text with its Arabic-script digits
+// normalized by NormalizeDigits, so that ASCII patterns also match "٠٥٠ ١٢٣ ٤٥٦٧". Spans
+// report the original text and offsets. accept receives the normalized text and offsets.
+func ScanNormalized(text string, regex *regexp.Regexp, accept func(text string, start, end int) bool) []Span {
+	normalized, offsets := NormalizeDigits(text)
+	if offsets == nil {
+		return Scan(text, regex, accept)
+	}
+
+	var indices [][]int
+	for _, idx := range MatchWithIndices(normalized, regex) {
+		if accept == nil || accept(normalized, idx[0], idx[1]) {
+			indices = append(indices, idx)
+		}
+	}
+	if len(indices) == 0 {
+		return nil
+	}
+
+	var cache *ContextCache
+	if len(indices) >= ContextCacheThreshold {
+		cache = NewContextCache(text)
+	}
+	spans := make([]Span, len(indices))
+	for i, idx := range indices {
+		start, end := offsets[idx[0]], offsets[idx[1]]
+		var context string
+		if cache != nil {
+			context = cache.ExtractContext(start, end)
+		} else {
+			context = ExtractContext(text, start, end)
+		}
+		spans[i] = Span{Value: text[start:end], Start: start, End: end, Context: context}
+	}
+	return spans
+}
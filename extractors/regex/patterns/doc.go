@@ -0,0 +1,21 @@
+// Package patterns holds the compiled regular expressions used by the regex extractor
+// and the low-level helpers to build custom extraction flows on top of them.
+//
+// # Stability
+//
+// The following are part of the module's public API and follow semantic versioning:
+//
+//   - the *Pattern constants and their compiled *Regex variables (pattern contents may be
+//     tightened in minor releases to fix false positives, but names are not removed)
+//   - Scan, ScanNormalized, Span, Match, MatchAddresses and MatchWithIndices
+//   - NormalizeDigit and NormalizeDigits
+//   - ExtractContext, ContextCache and NewContextCache
+//   - the *Rejection false-positive filters
+//
+// A typical custom flow runs Scan with one of the compiled patterns and an optional
+// rejection filter:
+//
+//	for _, span := range patterns.Scan(text, patterns.PhoneUSRegex, nil) {
+//		fmt.Println(span.Value, span.Start, span.End, span.Context)
+//	}
+package patterns
@@ -0,0 +1,92 @@
+package patterns
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+// HostnamePattern matches dotted hostnames and fully qualified domain names. The last label
+// must start with a letter so dotted numbers and IP addresses are not matched.
+const HostnamePattern = `(?i)\b(?:[a-z0-9](?:[a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z](?:[a-z0-9-]{0,61}[a-z0-9])?\b`
+
+// HostnameRegex is the compiled HostnamePattern
+var HostnameRegex = regexp.MustCompile(HostnamePattern)
+
+// InternalHostnameSuffixes lists private-use suffixes accepted in addition to the public
+// suffix list, so internal hostnames such as db01.corp.internal are detected
+var InternalHostnameSuffixes = []string{
+	"internal",
+	"intranet",
+	"local",
+	"localdomain",
+	"lan",
+	"corp",
+	"home.arpa",
+}
+
+// fileExtensionTLDs are country-code or generic TLDs that are far more often file
+// extensions, rejected for two-label names such as setup.py or notes.md
+var fileExtensionTLDs = map[string]bool{
+	"py": true, "sh": true, "md": true, "rs": true, "so": true, "pl": true,
+	"cc": true, "cs": true, "ps": true, "ml": true, "zip": true, "mov": true,
+}
+
+// Reasons returned by HostnameRejection
+const (
+	HostnameRejectEmail         = "domain part of an email address"
+	HostnameRejectUnknownSuffix = "suffix is not on the public suffix list or an internal suffix"
+	HostnameRejectFileName      = "looks like a file name"
+)
+
+// HostnameSuffix returns the public or internal suffix of a hostname. The hostname is
+// valid when it has at least one label in addition to a known suffix.
+func HostnameSuffix(hostname string) (suffix string, internal bool, valid bool) {
+	hostname = strings.ToLower(strings.TrimSuffix(hostname, "."))
+
+	for _, internalSuffix := range InternalHostnameSuffixes {
+		if strings.HasSuffix(hostname, "."+internalSuffix) {
+			return internalSuffix, true, true
+		}
+	}
+
+	suffix, icann := publicsuffix.PublicSuffix(hostname)
+	// Unlisted TLDs are returned as a single-label, non-ICANN suffix
+	if !icann && !strings.Contains(suffix, ".") {
+		return suffix, false, false
+	}
+	if len(hostname) <= len(suffix) {
+		return suffix, false, false
+	}
+	return suffix, false, true
+}
+
+// HostnameRejection checks a HostnameRegex match at text[start:end] and returns why it is
+// not a hostname, or an empty string if it is accepted
+func HostnameRejection(text string, start, end int) string {
+	if (start > 0 && text[start-1] == '@') || (end < len(text) && text[end] == '@') {
+		return HostnameRejectEmail
+	}
+
+	hostname := text[start:end]
+	suffix, internal, valid := HostnameSuffix(hostname)
+	if !valid {
+		return HostnameRejectUnknownSuffix
+	}
+	if !internal && strings.Count(hostname, ".") == 1 && fileExtensionTLDs[suffix] {
+		return HostnameRejectFileName
+	}
+	return ""
+}
+
+// Hostnames returns the hostnames found in text
+var Hostnames = func(text string) []string {
+	hostnames := []string{}
+	for _, idx := range MatchWithIndices(text, HostnameRegex) {
+		if HostnameRejection(text, idx[0], idx[1]) == "" {
+			hostnames = append(hostnames, text[idx[0]:idx[1]])
+		}
+	}
+	return hostnames
+}
@@ -0,0 +1,78 @@
+package patterns
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHostnameExtraction(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name:     "public FQDNs",
+			input:    "Fetched https://api.example.com/v1 and www.bbc.co.uk today",
+			expected: []string{"api.example.com", "www.bbc.co.uk"},
+		},
+		{
+			name:     "internal hostnames",
+			input:    "Connection to db01.corp.internal and printer.local refused",
+			expected: []string{"db01.corp.internal", "printer.local"},
+		},
+		{
+			name:     "email domains are not hostnames",
+			input:    "Mail john.doe@mail.example.com about it",
+			expected: []string{},
+		},
+		{
+			name:     "unknown suffixes, versions and IPs",
+			input:    "Open config.yaml, upgrade to v1.2.3 on 10.0.0.1, i.e. later",
+			expected: []string{},
+		},
+		{
+			name:     "file names with TLD-like extensions",
+			input:    "Run setup.py then read notes.md",
+			expected: []string{},
+		},
+		{
+			name:     "bare public suffix",
+			input:    "Registered under co.uk rules",
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Hostnames(tt.input)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Hostnames() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHostnameSuffix(t *testing.T) {
+	tests := []struct {
+		hostname string
+		suffix   string
+		internal bool
+		valid    bool
+	}{
+		{"www.bbc.co.uk", "co.uk", false, true},
+		{"Build.Example.COM", "com", false, true},
+		{"app.github.io", "github.io", false, true},
+		{"db01.corp.internal", "internal", true, true},
+		{"router.home.arpa", "home.arpa", true, true},
+		{"host.notatld", "notatld", false, false},
+	}
+
+	for _, tt := range tests {
+		suffix, internal, valid := HostnameSuffix(tt.hostname)
+		if suffix != tt.suffix || internal != tt.internal || valid != tt.valid {
+			t.Errorf("HostnameSuffix(%q) = (%q, %v, %v), want (%q, %v, %v)",
+				tt.hostname, suffix, internal, valid, tt.suffix, tt.internal, tt.valid)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package patterns
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Sources of session tokens found in HTTP logs
+const (
+	SessionSourceSetCookie = "set_cookie"
+	SessionSourceCookie    = "cookie"
+	SessionSourceBearer    = "bearer"
+	SessionSourceQuery     = "query"
+)
+
+// MinSessionTokenLength is the shortest value reported as a session token, so short
+// identifiers such as sid=1 are ignored
+const MinSessionTokenLength = 8
+
+// HTTP log patterns. Token values are captured in the last group.
+const (
+	SetCookiePattern         = `(?im)\bset-cookie:[ \t]*([^=;\s]+)=([^;\s]+)`
+	CookieHeaderPattern      = `(?im)\bcookie:[ \t]*([^\r\n]+)`
+	BearerTokenPattern       = `(?i)\bauthorization:[ \t]*bearer[ \t]+([A-Za-z0-9\-._~+/]+=*)`
+	SessionQueryParamPattern = `(?i)[?&;](` + sessionNamePattern + `)=([^&?\s#"'<>;]+)`
+)
+
+// sessionNamePattern matches cookie and query parameter names that carry session IDs
+const sessionNamePattern = `jsessionid|phpsessid|asp\.net_sessionid|connect\.sid|session_?id|session|sessid|sid|access_token|auth_token|token`
+
+// HTTP log compiled patterns
+var (
+	SetCookieRegex         = regexp.MustCompile(SetCookiePattern)
+	CookieHeaderRegex      = regexp.MustCompile(CookieHeaderPattern)
+	BearerTokenRegex       = regexp.MustCompile(BearerTokenPattern)
+	SessionQueryParamRegex = regexp.MustCompile(SessionQueryParamPattern)
+	sessionNameRegex       = regexp.MustCompile(`(?i)^(?:` + sessionNamePattern + `|[a-z0-9_.-]*(?:sess|sid|token|auth)[a-z0-9_.-]*)$`)
+	cookiePairRegex        = regexp.MustCompile(`([^=;\s]+)=([^;\s]+)`)
+)
+
+// SessionTokenMatch is a session token value found at text[Start:End]
+type SessionTokenMatch struct {
+	Start  int
+	End    int
+	Source string // One of the SessionSource constants
+	Name   string // Cookie or query parameter name, empty for bearer tokens
+}
+
+// FindSessionTokens returns the session token values in HTTP log text: Set-Cookie values,
+// session-like cookies of Cookie headers, Authorization bearer tokens and session ID query
+// parameters. Matches are sorted by position.
+func FindSessionTokens(text string) []SessionTokenMatch {
+	var matches []SessionTokenMatch
+	add := func(start, end int, source, name string) {
+		if end-start >= MinSessionTokenLength {
+			matches = append(matches, SessionTokenMatch{Start: start, End: end, Source: source, Name: name})
+		}
+	}
+
+	setCookieHeaders := SetCookieRegex.FindAllStringSubmatchIndex(text, -1)
+	for _, idx := range setCookieHeaders {
+		add(idx[4], idx[5], SessionSourceSetCookie, text[idx[2]:idx[3]])
+	}
+
+	for _, idx := range CookieHeaderRegex.FindAllStringSubmatchIndex(text, -1) {
+		// Set-Cookie headers also match the Cookie pattern
+		if idx[0] >= 4 && strings.EqualFold(text[idx[0]-4:idx[0]], "set-") {
+			continue
+		}
+		offset := idx[2]
+		for _, pair := range cookiePairRegex.FindAllStringSubmatchIndex(text[idx[2]:idx[3]], -1) {
+			name := text[offset+pair[2] : offset+pair[3]]
+			if sessionNameRegex.MatchString(name) {
+				add(offset+pair[4], offset+pair[5], SessionSourceCookie, name)
+			}
+		}
+	}
+
+	for _, idx := range BearerTokenRegex.FindAllStringSubmatchIndex(text, -1) {
+		add(idx[2], idx[3], SessionSourceBearer, "")
+	}
+
+	for _, idx := range SessionQueryParamRegex.FindAllStringSubmatchIndex(text, -1) {
+		add(idx[4], idx[5], SessionSourceQuery, text[idx[2]:idx[3]])
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Start < matches[j].Start
+	})
+	return matches
+}
+
+// SessionTokens returns the raw session token values found in HTTP log text
+var SessionTokens = func(text string) []string {
+	tokens := []string{}
+	for _, match := range FindSessionTokens(text) {
+		tokens = append(tokens, text[match.Start:match.End])
+	}
+	return tokens
+}
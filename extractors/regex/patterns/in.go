@@ -1,14 +1,24 @@
 package patterns
 
-import "regexp"
+import (
+	"regexp"
+	"strings"
+)
 
 // India-specific patterns
 const (
-	PostalCodeIndiaPattern    = `\b[1-9]\d{5}\b`
-	PhoneIndiaPattern         = `(?:\+91\s?|0)?(?:[6-9]\d{9}|11[\s\-]?\d{4}[\s\-]?\d{4})`
-	StreetAddressIndiaPattern = `(?i)\b\d{1,4}[a-z]?\s+(?:[a-z\s\-']+\s+)*(?:road|rd|street|st|lane|ln|nagar|colony|sector|block|phase|plot|house|building|apartment|flat|cross|main|layout|extension|park|garden|circle|square|compound|society|residency|enclave|vihar|kunj|puram|gram|marg|path)\b`
+	PostalCodeIndiaPattern    = `\b[1-9]\d{2}\s?\d{3}\b`
+	PhoneIndiaPattern         = `(?:` + indiaPhonePrefix + `|0)?(?:[6-9]\d{4}[\s\-]?\d{5}|11[\s\-]?\d{4}[\s\-]?\d{4})|(?:` + indiaPhonePrefix + `|0)(?:2[02]|33|4[04]|79|80)[\s\-]?\d{4}[\s\-]?\d{4}`
+	StreetAddressIndiaPattern = `(?i)\b\d{1,4}[a-z]?,?\s+(?:(?:\d{1,2}(?:st|nd|rd|th)|[a-z][a-z\-'.]*)\s+)*(?:(?:phase|sector|block|stage)(?:\s+\d{1,3}[a-z]?\b)?|` + indiaStreetKeywords + `\b)`
 )
 
+// indiaPhonePrefix is the +91 or 0091 country code, with or without a separator
+const indiaPhonePrefix = `(?:\+|00)91[\s\-]?`
+
+// indiaStreetKeywords end the street part of an Indian address, as in "MG Road",
+// "Sarojini Nagar", "Tilak Marg", "HSR Layout" or "5th Cross"
+const indiaStreetKeywords = `(?:road|rd|street|st|lane|ln|nagar|colony|plot|house|building|apartment|flat|cross|main|layout|extension|park|garden|circle|square|compound|society|residency|enclave|vihar|kunj|puram|gram|marg|path|chowk|bagh)`
+
 // India-specific compiled patterns
 var (
 	PostalCodeIndiaRegex    = regexp.MustCompile(PostalCodeIndiaPattern)
@@ -17,6 +27,44 @@ var (
 )
 
 // India-specific convenience functions
-var PostalCodesIndia = func(text string) []string { return Match(text, PostalCodeIndiaRegex) }
-var PhonesIndia = func(text string) []string { return Match(text, PhoneIndiaRegex) }
-var StreetAddressesIndia = func(text string) []string { return MatchAddresses(text, StreetAddressIndiaRegex) }
\ No newline at end of file
+var PostalCodesIndia = func(text string) []string {
+	return matchRejecting(text, PostalCodeIndiaRegex, PostalCodeIndiaRejection)
+}
+var PhonesIndia = func(text string) []string { return matchRejecting(text, PhoneIndiaRegex, PhoneIndiaRejection) }
+var StreetAddressesIndia = func(text string) []string { return MatchAddresses(text, StreetAddressIndiaRegex) }
+
+// PostalCodeRejectNoKeyword is returned by PostalCodeIndiaRejection for a spaced PIN code
+// without a PIN keyword before it
+const PostalCodeRejectNoKeyword = "no PIN keyword before the spaced code"
+
+// pinKeywordWindow is the number of bytes before a spaced PIN code searched for a PIN
+// keyword
+const pinKeywordWindow = 16
+
+// pinKeywords introduce Indian PIN codes
+var pinKeywords = []string{"pin", "postal code", "post code"}
+
+// PostalCodeIndiaRejection checks a PostalCodeIndiaRegex match. Codes written as
+// "560 001" must follow a keyword such as "PIN" or "Pincode", since pairs of 3-digit
+// numbers are common outside addresses.
+func PostalCodeIndiaRejection(text string, start, end int) string {
+	if !strings.ContainsAny(text[start:end], " \t\n\f\r") {
+		return ""
+	}
+	window := strings.ToLower(text[max(start-pinKeywordWindow, 0):start])
+	for _, keyword := range pinKeywords {
+		if strings.Contains(window, keyword) {
+			return ""
+		}
+	}
+	return PostalCodeRejectNoKeyword
+}
+
+// PhoneIndiaRejection checks a PhoneIndiaRegex match and rejects numbers embedded in a
+// longer number, such as account or order numbers
+func PhoneIndiaRejection(text string, start, end int) string {
+	if touchesDigits(text, start, end) {
+		return PhoneRejectEmbedded
+	}
+	return ""
+}
@@ -25,6 +25,11 @@ func TestIndiaPostalCodes(t *testing.T) {
 			text:     "Send the package to 110001 New Delhi or 400001 Mumbai.",
 			expected: []string{"110001", "400001"},
 		},
+		{
+			name:     "PIN prefix and spaced codes",
+			text:     "Koramangala, Bengaluru PIN-560034. Pincode: 400 050, Mumbai. Pune 411 001.",
+			expected: []string{"560034", "400 050"},
+		},
 		{
 			name:     "No postal codes",
 			text:     "This text has no PIN codes.",
@@ -79,6 +84,21 @@ func TestIndiaPhones(t *testing.T) {
 			text:     "Mobile: 98765-43210, Landline: 11 2345 6789.",
 			expected: []string{"98765-43210", "11 2345 6789"},
 		},
+		{
+			name:     "Country code with and without separators",
+			text:     "WhatsApp +919876543210, office +91-80-2345-6789, alt 0091 98450 12345.",
+			expected: []string{"+919876543210", "+91-80-2345-6789", "0091 98450 12345"},
+		},
+		{
+			name:     "Eleven digits starting with 0",
+			text:     "Customer care 09876543210 or Chennai 044-2345-6789.",
+			expected: []string{"09876543210", "044-2345-6789"},
+		},
+		{
+			name:     "Embedded in a longer number",
+			text:     "Order 1239876543210 and account 98765432101234.",
+			expected: []string{},
+		},
 		{
 			name:     "No phone numbers",
 			text:     "This text has no phone numbers.",
@@ -128,6 +148,16 @@ func TestIndiaStreetAddresses(t *testing.T) {
 			text:     "Plot 56 JP Nagar Phase 2, House 78 Koramangala Layout.",
 			expected: []string{"56 JP Nagar Phase 2", "78 Koramangala Layout"},
 		},
+		{
+			name:     "Cross and main roads",
+			text:     "Deliver to No. 42, 5th Cross, Malleshwaram or 18 2nd Main Road, Indiranagar.",
+			expected: []string{"42, 5th Cross", "18 2nd Main Road"},
+		},
+		{
+			name:     "Marg and numbered sectors",
+			text:     "Office at 221 Tilak Marg, home at 17 HSR Layout Sector 2 near 9 Chandni Chowk.",
+			expected: []string{"221 Tilak Marg", "17 HSR Layout Sector 2", "9 Chandni Chowk"},
+		},
 		{
 			name:     "No street addresses",
 			text:     "This text contains no street addresses.",
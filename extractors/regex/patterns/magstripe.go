@@ -0,0 +1,78 @@
+package patterns
+
+import "regexp"
+
+// Magnetic stripe track data patterns (ISO/IEC 7813). Start and end sentinels are optional
+// because logs often strip them; the PAN is captured in the first group.
+const (
+	// Track1Pattern matches %B<PAN>^<NAME>^<YYMM><service code><discretionary data>?
+	Track1Pattern = `%?\bB(\d{13,19})\^[A-Za-z0-9 /.'-]{2,26}\^\d{7}[0-9A-Za-z ]*\??`
+	// Track2Pattern matches ;<PAN>=<YYMM><service code><discretionary data>?
+	Track2Pattern = `;?\b(\d{13,19})=\d{7}\d*\??`
+)
+
+// Magnetic stripe track data compiled patterns
+var (
+	Track1Regex = regexp.MustCompile(Track1Pattern)
+	Track2Regex = regexp.MustCompile(Track2Pattern)
+)
+
+// TrackDataRejectLuhn is the reason returned by TrackDataRejection for an invalid PAN
+const TrackDataRejectLuhn = "primary account number fails the Luhn check"
+
+// TrackDataRejection checks a Track1Regex or Track2Regex match at text[start:end] and returns
+// why it is not track data, or an empty string if it is accepted. Requiring a valid PAN keeps
+// digit runs such as query string values from being reported.
+func TrackDataRejection(regex *regexp.Regexp, text string, start, end int) string {
+	match := regex.FindStringSubmatch(text[start:end])
+	if match == nil || !LuhnValid(match[1]) {
+		return TrackDataRejectLuhn
+	}
+	return ""
+}
+
+// LuhnValid reports whether a string of digits passes the Luhn checksum
+func LuhnValid(digits string) bool {
+	if len(digits) < 2 {
+		return false
+	}
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := digits[i]
+		if d < '0' || d > '9' {
+			return false
+		}
+		n := int(d - '0')
+		if double {
+			n *= 2
+			if n > 9 {
+				n -= 9
+			}
+		}
+		sum += n
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// Track1Data returns the Track 1 data found in text
+var Track1Data = func(text string) []string {
+	return matchTrackData(text, Track1Regex)
+}
+
+// Track2Data returns the Track 2 data found in text
+var Track2Data = func(text string) []string {
+	return matchTrackData(text, Track2Regex)
+}
+
+// matchTrackData returns the track data matches with a valid PAN
+func matchTrackData(text string, regex *regexp.Regexp) []string {
+	results := []string{}
+	for _, idx := range MatchWithIndices(text, regex) {
+		if TrackDataRejection(regex, text, idx[0], idx[1]) == "" {
+			results = append(results, text[idx[0]:idx[1]])
+		}
+	}
+	return results
+}
@@ -0,0 +1,67 @@
+package patterns
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTrackDataExtraction(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		track1 []string
+		track2 []string
+	}{
+		{
+			name:   "track 1 with sentinels",
+			input:  "swipe=%B4111111111111111^DOE/JOHN^2512101000000000000000? ok",
+			track1: []string{"%B4111111111111111^DOE/JOHN^2512101000000000000000?"},
+			track2: []string{},
+		},
+		{
+			name:   "track 2 with and without sentinels",
+			input:  "t2=;4111111111111111=25121010000000000000? raw 5555555555554444=2512101",
+			track1: []string{},
+			track2: []string{";4111111111111111=25121010000000000000?", "5555555555554444=2512101"},
+		},
+		{
+			name:   "PAN failing Luhn",
+			input:  "id=;4111111111111112=25121010000? and B4111111111111112^DOE/JOHN^2512101",
+			track1: []string{},
+			track2: []string{},
+		},
+		{
+			name:   "PAN only",
+			input:  "Card 4111 1111 1111 1111 expires 12/25",
+			track1: []string{},
+			track2: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Track1Data(tt.input); !reflect.DeepEqual(got, tt.track1) {
+				t.Errorf("Track1Data() = %v, want %v", got, tt.track1)
+			}
+			if got := Track2Data(tt.input); !reflect.DeepEqual(got, tt.track2) {
+				t.Errorf("Track2Data() = %v, want %v", got, tt.track2)
+			}
+		})
+	}
+}
+
+func TestLuhnValid(t *testing.T) {
+	tests := map[string]bool{
+		"4111111111111111": true,
+		"5555555555554444": true,
+		"4111111111111112": false,
+		"79927398713":      true,
+		"4111-1111":        false,
+		"":                 false,
+	}
+	for digits, want := range tests {
+		if got := LuhnValid(digits); got != want {
+			t.Errorf("LuhnValid(%q) = %v, want %v", digits, got, want)
+		}
+	}
+}
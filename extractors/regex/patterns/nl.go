@@ -0,0 +1,86 @@
+package patterns
+
+import "regexp"
+
+// Netherlands-specific patterns
+const (
+	BSNPattern                   = `\b\d{4}\.?\d{2}\.?\d{3}\b|\b\d{3}[ ]\d{3}[ ]\d{3}\b`
+	PhoneNetherlandsPattern      = `(?:(?:\+|00)31[\s\-]?(?:\(0\)[\s\-]?)?|\b0)[1-9](?:[\s\-]?\d){8}`
+	PostalCodeNetherlandsPattern = `\b[1-9]\d{3}[ ]?[A-Z]{2}\b`
+)
+
+// Netherlands-specific compiled patterns
+var (
+	BSNRegex                   = regexp.MustCompile(BSNPattern)
+	PhoneNetherlandsRegex      = regexp.MustCompile(PhoneNetherlandsPattern)
+	PostalCodeNetherlandsRegex = regexp.MustCompile(PostalCodeNetherlandsPattern)
+)
+
+// Netherlands-specific convenience functions
+var BSNs = func(text string) []string { return matchRejecting(text, BSNRegex, BSNRejection) }
+var PhonesNetherlands = func(text string) []string {
+	return matchRejecting(text, PhoneNetherlandsRegex, PhoneNetherlandsRejection)
+}
+var PostalCodesNetherlands = func(text string) []string {
+	return matchRejecting(text, PostalCodeNetherlandsRegex, PostalCodeNetherlandsRejection)
+}
+
+// PostalCodeRejectLetters is returned by PostalCodeNetherlandsRejection for the letter
+// pairs SA, SD and SS, which Dutch postcodes never use
+const PostalCodeRejectLetters = "letters not used in Dutch postcodes"
+
+// BSNRejection checks a BSNRegex match: a BSN (burgerservicenummer) passes the 11-test
+// and is not part of a longer number
+func BSNRejection(text string, start, end int) string {
+	if touchesDigits(text, start, end) {
+		return PhoneRejectEmbedded
+	}
+	if !ElevenTestValid(digitsOnly(text[start:end])) {
+		return NationalIDRejectChecksum
+	}
+	return ""
+}
+
+// ElevenTestValid reports whether a 9-digit BSN passes the 11-test: the digits weighted
+// 9 down to 2, and the last one by -1, sum to a non-zero multiple of 11
+func ElevenTestValid(digits string) bool {
+	if len(digits) != 9 {
+		return false
+	}
+	sum := 0
+	for i := 0; i < 8; i++ {
+		sum += int(digits[i]-'0') * (9 - i)
+	}
+	sum -= int(digits[8] - '0')
+	return sum != 0 && sum%11 == 0
+}
+
+// PhoneNetherlandsRejection checks a PhoneNetherlandsRegex match and rejects numbers
+// embedded in a longer number
+func PhoneNetherlandsRejection(text string, start, end int) string {
+	if touchesDigits(text, start, end) {
+		return PhoneRejectEmbedded
+	}
+	return ""
+}
+
+// PostalCodeNetherlandsRejection checks a PostalCodeNetherlandsRegex match and rejects
+// the letter pairs SA, SD and SS
+func PostalCodeNetherlandsRejection(text string, start, end int) string {
+	switch text[end-2 : end] {
+	case "SA", "SD", "SS":
+		return PostalCodeRejectLetters
+	}
+	return ""
+}
+
+// digitsOnly returns the ASCII digits of s
+func digitsOnly(s string) string {
+	digits := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] >= '0' && s[i] <= '9' {
+			digits = append(digits, s[i])
+		}
+	}
+	return string(digits)
+}
@@ -0,0 +1,107 @@
+package patterns
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestBSNs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{
+			name:     "Valid BSNs in common formats",
+			text:     "BSN 111222333, burgerservicenummer 1234.56.782 en 123 456 782.",
+			expected: []string{"111222333", "1234.56.782", "123 456 782"},
+		},
+		{
+			name:     "11-test failures",
+			text:     "Ordernummer 123456789 en factuur 111222334.",
+			expected: []string{},
+		},
+		{
+			name:     "Embedded in a longer number",
+			text:     "Rekening 9111222333 en 111222333-4.",
+			expected: []string{},
+		},
+		{
+			name:     "All zeros",
+			text:     "BSN 000000000",
+			expected: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if result := BSNs(tc.text); !slices.Equal(result, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestNetherlandsPhones(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{
+			name:     "International and national formats",
+			text:     "Bel +31 20 123 4567, 0031 6 12345678, +31 (0)20 123 4567 of 06-12345678.",
+			expected: []string{"+31 20 123 4567", "0031 6 12345678", "+31 (0)20 123 4567", "06-12345678"},
+		},
+		{
+			name:     "Landline with area code",
+			text:     "Kantoor: 010-1234567",
+			expected: []string{"010-1234567"},
+		},
+		{
+			name:     "Embedded in a longer number",
+			text:     "Referentie 0612345678901",
+			expected: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if result := PhonesNetherlands(tc.text); !slices.Equal(result, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestNetherlandsPostalCodes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{
+			name:     "Postcodes with and without space",
+			text:     "Damrak 1, 1012 LG Amsterdam; Coolsingel 40, 3011AD Rotterdam.",
+			expected: []string{"1012 LG", "3011AD"},
+		},
+		{
+			name:     "Letter pairs never used",
+			text:     "1234 SA, 1234 SD and 1234 SS",
+			expected: []string{},
+		},
+		{
+			name:     "Leading zero and lowercase letters",
+			text:     "0123 AB or 1234 ab",
+			expected: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if result := PostalCodesNetherlands(tc.text); !slices.Equal(result, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package patterns
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Reasons returned by NumericRejection
+const (
+	NumericRejectCurrency = "amount next to a currency symbol or code"
+	NumericRejectGrouped  = "part of a number with thousand separators or decimals"
+	NumericRejectUnit     = "measurement followed by a unit"
+	NumericRejectSeries   = "part of a series of numbers"
+)
+
+// CurrencyCodes are the ISO 4217 codes and currency names that mark a number as an amount
+var CurrencyCodes = []string{
+	"USD", "EUR", "GBP", "JPY", "CNY", "RMB", "INR", "RUB", "CHF", "CAD", "AUD", "AED", "SAR",
+	"dollar", "dollars", "euro", "euros", "pound", "pounds", "yen", "yuan", "rupee", "rupees",
+	"ruble", "rubles", "rouble", "roubles",
+}
+
+// thousandSeparators are the characters grouping the digits of a number, including the
+// no-break spaces of French and Swiss formatting
+const thousandSeparators = ",.'\u00a0\u202f"
+
+// MeasurementUnits are the units that mark a number as a measurement
+var MeasurementUnits = []string{
+	"mm", "cm", "m", "km", "ft", "yd", "mi", "mg", "g", "kg", "lb", "lbs", "oz",
+	"ml", "l", "w", "kw", "kwh", "mw", "v", "hz", "khz", "mhz", "ghz", "kb", "mb", "gb", "tb",
+	"%", "‰", "°",
+}
+
+// NumericRejection checks a phone number or postal code match at text[start:end] and returns
+// why it is an amount or a measurement rather than PII, or an empty string if it is accepted.
+// Matches are rejected when a currency symbol or code is next to them, when they are part of
+// a number written with thousand separators or decimals such as "1,275,001.50", or when a
+// unit follows them. Values whose digit groups are separated by spaces, such as
+// "555 123 4567", are also rejected when the token before or after them on the same line
+// is another number.
+func NumericRejection(text string, start, end int) string {
+	before := strings.TrimRight(text[:start], " \t\u00a0\u202f")
+	after := strings.TrimLeft(text[end:], " \t\u00a0\u202f")
+
+	if r, _ := utf8.DecodeLastRuneInString(before); unicode.Is(unicode.Sc, r) {
+		return NumericRejectCurrency
+	}
+	if r, _ := utf8.DecodeRuneInString(after); unicode.Is(unicode.Sc, r) {
+		return NumericRejectCurrency
+	}
+	previous, next := lastToken(before), firstToken(after)
+	if slices.Contains(CurrencyCodes, previous) || slices.Contains(CurrencyCodes, next) ||
+		slices.Contains(CurrencyCodes, strings.ToLower(next)) {
+		return NumericRejectCurrency
+	}
+
+	if separator, size := utf8.DecodeLastRuneInString(text[:start]); strings.ContainsRune(thousandSeparators, separator) &&
+		start > size && isDigit(text[start-size-1]) {
+		return NumericRejectGrouped
+	}
+	if end+1 < len(text) && strings.ContainsRune(",.", rune(text[end])) && isDigit(text[end+1]) {
+		return NumericRejectGrouped
+	}
+
+	if slices.Contains(MeasurementUnits, strings.ToLower(next)) {
+		return NumericRejectUnit
+	}
+
+	// The tokens are empty when the value starts or ends its line
+	if strings.ContainsAny(text[start:end], " \t") && (isNumber(previous) || isNumber(next)) {
+		return NumericRejectSeries
+	}
+	return ""
+}
+
+// lastToken returns the word, number or symbol run at the end of s, on its last line
+func lastToken(s string) string {
+	i := strings.LastIndexAny(s, " \t\r\n\u00a0\u202f(:;=")
+	return s[i+1:]
+}
+
+// firstToken returns the word, number or symbol run at the start of s, without trailing
+// punctuation
+func firstToken(s string) string {
+	if i := strings.IndexAny(s, " \t\r\n\u00a0\u202f);:,"); i >= 0 {
+		s = s[:i]
+	}
+	return strings.TrimRight(s, ".!?")
+}
+
+// isNumber reports whether a token is an integer or decimal number
+func isNumber(token string) bool {
+	if token == "" {
+		return false
+	}
+	for i := 0; i < len(token); i++ {
+		if !isDigit(token[i]) && token[i] != '.' && token[i] != ',' {
+			return false
+		}
+	}
+	return isDigit(token[0]) && isDigit(token[len(token)-1])
+}
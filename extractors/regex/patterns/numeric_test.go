@@ -0,0 +1,32 @@
+package patterns
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNumericRejection(t *testing.T) {
+	tests := []struct {
+		text, value, want string
+	}{
+		{"Total: $75001 due", "75001", NumericRejectCurrency},
+		{"Total: 75001 € due", "75001", NumericRejectCurrency},
+		{"EUR 75001 paid", "75001", NumericRejectCurrency},
+		{"75001 dollars", "75001", NumericRejectCurrency},
+		{"Revenue 1\u00a075001 net", "75001", NumericRejectGrouped},
+		{"Revenue 12.75001", "75001", NumericRejectGrouped},
+		{"Revenue 75001,50", "75001", NumericRejectGrouped},
+		{"Depth 75001 mm", "75001", NumericRejectUnit},
+		{"Readings 12 555 123 4567 89", "555 123 4567", NumericRejectSeries},
+		{"Office at 75001 Paris", "75001", ""},
+		{"Call 555 123 4567 today", "555 123 4567", ""},
+		{"12 items\n555 123 4567\n89 left", "555 123 4567", ""},
+		{"Zip 10001 in New York", "10001", ""},
+	}
+	for _, tt := range tests {
+		start := strings.Index(tt.text, tt.value)
+		if got := NumericRejection(tt.text, start, start+len(tt.value)); got != tt.want {
+			t.Errorf("NumericRejection(%q, %q) = %q, want %q", tt.text, tt.value, got, tt.want)
+		}
+	}
+}
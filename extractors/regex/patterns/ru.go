@@ -1,22 +1,94 @@
 package patterns
 
-import "regexp"
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
 
 // Russia-specific patterns
 const (
 	PostalCodeRussiaPattern    = `\b[1-6]\d{5}\b`
-	PhoneRussiaPattern         = `(?:\+7|8)[\s\-]?\(?(?:3[0-9][0-9]|4[0-9][0-9]|8[0-9][0-9]|9[0-9][0-9])\)?[\s\-]?\d{3}[\s\-]?\d{2}[\s\-]?\d{2}`
-	StreetAddressRussiaPattern = `(?i)(?:[^\x00-\x7F]+\s*)+(?:улица|ул\.|проспект|пр\.|переулок|пер\.|площадь|пл\.|набережная|наб\.|бульвар|б-р|шоссе|ш\.|тракт|дорога|линия|аллея|тупик|проезд|спуск|подъем|мост|км|дом|д\.|корпус|корп\.|строение|стр\.|квартира|кв\.)\s*(?:\d+[а-я]?)?`
+	PhoneRussiaPattern         = `(?:\+7|8|7)[\s\-]?\(?[3489]\d{2}\)?[\s\-]?\d{3}[\s\-]?\d{2}[\s\-]?\d{2}`
+	PassportRussiaPattern      = `\b\d{2}\s?\d{2}\s(?:№\s?)?\d{6}\b`
+	StreetAddressRussiaPattern = `(?:` + russianStreetKeywords + `\s*(?:\p{Lu}|\d)[\p{L}\d\-']*(?:\s+\p{Lu}[\p{L}\-']*)?|\p{Lu}[\p{L}\-]*\s+` + russianStreetTypes + `)` + russianBuildingParts
+)
+
+// Russian street keywords in Cyrillic, inflected as in "на улице", and in common Latin
+// transliterations, followed by the building, block and apartment numbers
+const (
+	russianStreetKeywords = `(?i:(?:улиц(?:а|е|у|ы|ей)|проспект(?:а|е|у)?|переул(?:ок|ке|ка)|площад(?:ь|и)|набережн(?:ая|ой|ую)|бульвар(?:а|е|у)?|шоссе|проезд(?:а|е|у)?|алле(?:я|е|ю)|тупик(?:а|е|у)?|лини(?:я|и|ю)|ulitsa|ulica|prospekt|pereulok|ploshchad'?|ploshad'?|naberezhnaya|bul'?var|shosse|proezd|alleya|tupik|liniya)\s|(?:ул|просп|пр|пер|пл|наб|бул|ш|ul|prosp|pr|per|pl|nab|bul|sh)\.|(?:пр-т|б-р|пр-д|pr-t|b-r)\s)`
+	russianStreetTypes    = `(?i:улица|проспект|переулок|площадь|набережная|бульвар|шоссе|проезд|аллея|ulitsa|ulica|prospekt|pereulok|ploshchad'?|naberezhnaya|bul'?var|shosse|proezd|alleya)`
+	russianBuildingParts  = `(?:,?\s*(?i:дом|д\.|корпус|корп\.|к\.|строение|стр\.|квартира|кв\.|офис|оф\.|dom|d\.|korpus|korp\.|k\.|stroenie|str\.|kvartira|kv\.|ofis)\s*\d+(?:[/\-]\d+)?\p{L}?)*`
 )
 
 // Russia-specific compiled patterns
 var (
 	PostalCodeRussiaRegex    = regexp.MustCompile(PostalCodeRussiaPattern)
 	PhoneRussiaRegex         = regexp.MustCompile(PhoneRussiaPattern)
+	PassportRussiaRegex      = regexp.MustCompile(PassportRussiaPattern)
 	StreetAddressRussiaRegex = regexp.MustCompile(StreetAddressRussiaPattern)
 )
 
 // Russia-specific convenience functions
 var PostalCodesRussia = func(text string) []string { return Match(text, PostalCodeRussiaRegex) }
-var PhonesRussia = func(text string) []string { return Match(text, PhoneRussiaRegex) }
-var StreetAddressesRussia = func(text string) []string { return MatchAddresses(text, StreetAddressRussiaRegex) }
\ No newline at end of file
+var PhonesRussia = func(text string) []string { return matchRejecting(text, PhoneRussiaRegex, PhoneRussiaRejection) }
+var PassportsRussia = func(text string) []string { return matchRejecting(text, PassportRussiaRegex, PassportRussiaRejection) }
+var StreetAddressesRussia = func(text string) []string {
+	return matchRejecting(text, StreetAddressRussiaRegex, StreetAddressRussiaRejection)
+}
+
+// matchRejecting returns the matches of regex accepted by a rejection filter
+func matchRejecting(text string, regex *regexp.Regexp, rejection func(text string, start, end int) string) []string {
+	results := []string{}
+	for _, idx := range MatchWithIndices(text, regex) {
+		if rejection(text, idx[0], idx[1]) == "" {
+			results = append(results, text[idx[0]:idx[1]])
+		}
+	}
+	return results
+}
+
+// Reasons returned by the Russia rejection filters
+const (
+	AddressRejectEmbedded   = "street keyword inside a longer word"
+	PassportRejectNoKeyword = "no passport keyword before the number"
+)
+
+// passportKeywordWindow is the number of bytes before a passport number searched for
+// a passport keyword
+const passportKeywordWindow = 48
+
+// passportKeywords introduce Russian passport numbers
+var passportKeywords = []string{"паспорт", "серия", "passport", "pasport", "seriya"}
+
+// PhoneRussiaRejection checks a PhoneRussiaRegex match and rejects numbers embedded in a
+// longer number, such as the "7" of "127 495 123 45 67"
+func PhoneRussiaRejection(text string, start, end int) string {
+	if touchesDigits(text, start, end) {
+		return PhoneRejectEmbedded
+	}
+	return ""
+}
+
+// PassportRussiaRejection checks a PassportRussiaRegex match: the series and number must
+// follow a keyword such as "паспорт" or "серия", since any 10-digit number fits the pattern
+func PassportRussiaRejection(text string, start, end int) string {
+	window := strings.ToLower(text[max(start-passportKeywordWindow, 0):start])
+	for _, keyword := range passportKeywords {
+		if strings.Contains(window, keyword) {
+			return ""
+		}
+	}
+	return PassportRejectNoKeyword
+}
+
+// StreetAddressRussiaRejection checks a StreetAddressRussiaRegex match and rejects street
+// keywords found at the end of a longer word, such as "пр." in "апр."
+func StreetAddressRussiaRejection(text string, start, end int) string {
+	if previous, _ := utf8.DecodeLastRuneInString(text[:start]); unicode.IsLetter(previous) || unicode.IsDigit(previous) {
+		return AddressRejectEmbedded
+	}
+	return ""
+}
\ No newline at end of file
@@ -79,6 +79,16 @@ func TestRussiaPhones(t *testing.T) {
 			text:     "Телефоны: 7-495-123-45-67, 8(812)987-65-43, +7 903 1234567.",
 			expected: []string{"7-495-123-45-67", "8(812)987-65-43", "+7 903 1234567"},
 		},
+		{
+			name:     "Toll-free and bare 7 prefix",
+			text:     "Horyachaya liniya 8-800-555-35-35, mob. 79031234567.",
+			expected: []string{"8-800-555-35-35", "79031234567"},
+		},
+		{
+			name:     "Embedded in a longer number",
+			text:     "Счёт 1284951234567 и ИНН 77495123456789.",
+			expected: []string{},
+		},
 		{
 			name:     "No phone numbers",
 			text:     "Этот текст не содержит номеров телефонов.",
@@ -128,6 +138,21 @@ func TestRussiaStreetAddresses(t *testing.T) {
 			text:     "Московская улица, дом 15, корпус 2, строение 1, квартира 45.",
 			expected: []string{"Московская улица, дом 15, корпус 2, строение 1, квартира 45"},
 		},
+		{
+			name:     "Transliterated addresses",
+			text:     "Address: ul. Pushkina, d. 10, kv. 5; Nevsky prospekt, dom 28.",
+			expected: []string{"ul. Pushkina, d. 10, kv. 5", "Nevsky prospekt, dom 28"},
+		},
+		{
+			name:     "Inflected keyword in mixed script",
+			text:     "Живу на улице Ленина, d. 7, а офис на пр-т Мира, стр. 2.",
+			expected: []string{"улице Ленина, d. 7", "пр-т Мира, стр. 2"},
+		},
+		{
+			name:     "Keyword inside a word",
+			text:     "Встреча 5 апр. Сергей, в мае.",
+			expected: []string{},
+		},
 		{
 			name:     "No street addresses",
 			text:     "Этот текст не содержит адресов улиц.",
@@ -149,4 +174,43 @@ func TestRussiaStreetAddresses(t *testing.T) {
 			}
 		})
 	}
+}
+
+func TestRussiaPassports(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{
+			name:     "Cyrillic keyword",
+			text:     "Паспорт: серия 45 08 № 123456, выдан ОВД.",
+			expected: []string{"45 08 № 123456"},
+		},
+		{
+			name:     "Transliterated keyword",
+			text:     "Passport RF 4508 123456 issued in Moscow.",
+			expected: []string{"4508 123456"},
+		},
+		{
+			name:     "No keyword",
+			text:     "Заказ 4508 123456 оплачен.",
+			expected: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := PassportsRussia(tc.text)
+			if len(result) != len(tc.expected) {
+				t.Errorf("Expected %d passports, got %d: %v", len(tc.expected), len(result), result)
+				return
+			}
+			for i, expected := range tc.expected {
+				if result[i] != expected {
+					t.Errorf("Expected passport %s, got %s", expected, result[i])
+				}
+			}
+		})
+	}
 }
\ No newline at end of file
@@ -0,0 +1,53 @@
+package patterns
+
+import "regexp"
+
+// ContextCacheThreshold is the number of matches from which Scan builds a ContextCache
+// instead of re-splitting the text for every context
+const ContextCacheThreshold = 10
+
+// Span is a pattern match with its byte offsets in the scanned text and its context
+type Span struct {
+	Value   string `json:"value"`
+	Start   int    `json:"start"`
+	End     int    `json:"end"`
+	Context string `json:"context"`
+}
+
+// Scan returns every match of regex in text along with its context (10 words before
+// and after). When accept is non-nil, matches it rejects are dropped before their
+// context is computed; the *Rejection filters can be adapted for it.
+func Scan(text string, regex *regexp.Regexp, accept func(text string, start, end int) bool) []Span {
+	indices := MatchWithIndices(text, regex)
+	if accept != nil {
+		accepted := indices[:0]
+		for _, idx := range indices {
+			if accept(text, idx[0], idx[1]) {
+				accepted = append(accepted, idx)
+			}
+		}
+		indices = accepted
+	}
+	if len(indices) == 0 {
+		return nil
+	}
+
+	// The cache only pays off when it is reused for many matches
+	var cache *ContextCache
+	if len(indices) >= ContextCacheThreshold {
+		cache = NewContextCache(text)
+	}
+
+	spans := make([]Span, len(indices))
+	for i, idx := range indices {
+		start, end := idx[0], idx[1]
+		var context string
+		if cache != nil {
+			context = cache.ExtractContext(start, end)
+		} else {
+			context = ExtractContext(text, start, end)
+		}
+		spans[i] = Span{Value: text[start:end], Start: start, End: end, Context: context}
+	}
+	return spans
+}
@@ -0,0 +1,44 @@
+package patterns
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestScan(t *testing.T) {
+	text := "Call (555) 123-4567 or 1(555) 987-6543 today"
+
+	spans := Scan(text, PhoneUSRegex, nil)
+	if len(spans) == 0 {
+		t.Fatal("Expected phone spans")
+	}
+	for _, span := range spans {
+		if text[span.Start:span.End] != span.Value {
+			t.Errorf("Span offsets [%d:%d] do not match value %q", span.Start, span.End, span.Value)
+		}
+		if !strings.Contains(span.Context, "Call") {
+			t.Errorf("Expected the context to include surrounding words, got %q", span.Context)
+		}
+	}
+
+	accepted := Scan(text, PhoneUSRegex, func(text string, start, end int) bool {
+		return PhoneUSRejection(text, start, end, false) == ""
+	})
+	if len(accepted) > len(spans) {
+		t.Errorf("Expected the filter to keep at most %d spans, got %d", len(spans), len(accepted))
+	}
+
+	if spans := Scan("nothing here", EmailRegex, nil); spans != nil {
+		t.Errorf("Expected no spans, got %+v", spans)
+	}
+}
+
+func TestScan_CachedContextMatchesUncached(t *testing.T) {
+	text := strings.Repeat("write to someone@example.com about the invoice ", ContextCacheThreshold+2)
+
+	for _, span := range Scan(text, EmailRegex, nil) {
+		if want := ExtractContext(text, span.Start, span.End); span.Context != want {
+			t.Fatalf("Cached context %q differs from %q", span.Context, want)
+		}
+	}
+}
@@ -1,17 +1,37 @@
 package patterns
 
-import "regexp"
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
 
 // US-specific patterns
 const (
-	PhoneUSPattern          = `(?:(?:\+?\d{1,3}[-.\s*]?)?(?:\(?\d{3}\)?[-.\s*]?)?\d{3}[-.\s*]?\d{4,6})|(?:(?:(?:\(\+?\d{2}\))|(?:\+?\d{2}))\s*\d{2}\s*\d{3}\s*\d{4})`
+	PhoneUSPattern          = `(?:\+?1[-.\s]?)?(?:\(\d{3}\)\s?|\d{3}[-.\s]?)\d{3}[-.\s]?\d{4}|\d{3}[-.]\d{4}`
 	PhonesWithExtsUSPattern = `(?i)(?:(?:\+?1\s*(?:[.-]\s*)?)?(?:\(\s*(?:[2-9]1[02-9]|[2-9][02-8]1|[2-9][02-8][02-9])\s*\)|(?:[2-9]1[02-9]|[2-9][02-8]1|[2-9][02-8][02-9]))\s*(?:[.-]\s*)?)?(?:[2-9]1[02-9]|[2-9][02-9]1|[2-9][02-9]{2})\s*(?:[.-]\s*)?(?:[0-9]{4})(?:\s*(?:#|x\.?|ext\.?|extension)\s*(?:\d+)?)`
-	StreetAddressUSPattern  = `(?i)\d{1,4}\s+[a-z\s]+?\s+(?:street|st|avenue|ave|road|rd|highway|hwy|square|sq|trail|trl|drive|dr|court|ct|park|parkway|pkwy|circle|cir|boulevard|blvd)\b`
 	ZipCodeUSPattern        = `\b\d{5}(?:[-\s]\d{4})?\b`
 	PoBoxUSPattern          = `(?i)P\.? ?O\.? Box \d+`
 	SSNUSPattern            = `(?:\d{3}-\d{2}-\d{4})`
 )
 
+// StreetTypesUS is the dictionary of street type suffixes recognized in US addresses
+var StreetTypesUS = []string{
+	"street", "st", "avenue", "ave", "road", "rd", "highway", "hwy", "square", "sq",
+	"trail", "trl", "drive", "dr", "court", "ct", "park", "parkway", "pkwy",
+	"circle", "cir", "boulevard", "blvd", "lane", "ln", "place", "pl", "terrace", "ter",
+}
+
+// MaxStreetNameWordsUS is the maximum number of words between the house number and the street type
+const MaxStreetNameWordsUS = 4
+
+// StreetAddressUSPattern matches a house number, one to MaxStreetNameWordsUS name words on the
+// same line, and a street type from StreetTypesUS. Every token has an explicit length bound so
+// a match never spans more than a few words, whatever the surrounding text looks like.
+var StreetAddressUSPattern = `(?i)\b\d{1,5}[a-z]?[ \t]+` +
+	`(?:(?:\d{1,3}(?:st|nd|rd|th)|[a-z][a-z'.-]{0,29})[ \t]+){1,` + strconv.Itoa(MaxStreetNameWordsUS) + `}?` +
+	`(?:` + strings.Join(StreetTypesUS, "|") + `)\b`
+
 // US-specific compiled patterns
 var (
 	PhoneUSRegex          = regexp.MustCompile(PhoneUSPattern)
@@ -23,9 +43,167 @@ var (
 )
 
 // US-specific convenience functions
-var PhonesUS = func(text string) []string { return Match(text, PhoneUSRegex) }
+var PhonesUS = func(text string) []string {
+	results := []string{}
+	for _, idx := range MatchWithIndices(text, PhoneUSRegex) {
+		if PhoneUSRejection(text, idx[0], idx[1], false) == "" {
+			results = append(results, text[idx[0]:idx[1]])
+		}
+	}
+	return results
+}
 var PhonesWithExtsUS = func(text string) []string { return Match(text, PhonesWithExtsUSRegex) }
 var StreetAddressesUS = func(text string) []string { return Match(text, StreetAddressUSRegex) }
 var ZipCodesUS = func(text string) []string { return Match(text, ZipCodeUSRegex) }
 var PoBoxesUS = func(text string) []string { return Match(text, PoBoxUSRegex) }
 var SSNsUS = func(text string) []string { return Match(text, SSNUSRegex) }
+
+// Reasons returned by PhoneUSRejection
+const (
+	PhoneRejectEmbedded = "embedded in a longer number"
+	PhoneRejectLength   = "not a 7 or 10 digit NANP number"
+	PhoneRejectAreaCode = "invalid NANP area code"
+	PhoneRejectExchange = "invalid NANP exchange code"
+)
+
+// PhoneUSRejection checks a PhoneUSRegex match at text[start:end] and returns why it is not
+// a phone number, or an empty string if it is accepted. Matches touching other digits are
+// rejected, so fragments of card numbers, IBANs and long identifiers are never reported.
+// When validateCodes is true, area and exchange codes must follow the NANP numbering plan.
+func PhoneUSRejection(text string, start, end int, validateCodes bool) string {
+	return phoneUSRejection(text, start, end, validateCodes, false)
+}
+
+// PhoneUSListRejection is PhoneUSRejection for lists of numbers: a number of phone length
+// after a "/" is another item of the list, as in "555-123-4567/555-987-6543", and does
+// not embed the match
+func PhoneUSListRejection(text string, start, end int, validateCodes bool) string {
+	return phoneUSRejection(text, start, end, validateCodes, true)
+}
+
+func phoneUSRejection(text string, start, end int, validateCodes, lists bool) string {
+	if touchesDigitsIn(text, start, end, lists) {
+		return PhoneRejectEmbedded
+	}
+
+	digits := make([]byte, 0, 11)
+	for i := start; i < end; i++ {
+		if text[i] >= '0' && text[i] <= '9' {
+			digits = append(digits, text[i])
+		}
+	}
+	if len(digits) == 11 && digits[0] == '1' {
+		digits = digits[1:]
+	}
+
+	switch len(digits) {
+	case 10:
+		if validateCodes {
+			if !IsValidAreaCodeUS(string(digits[:3])) {
+				return PhoneRejectAreaCode
+			}
+			if !isValidExchangeCodeUS(digits[3:6]) {
+				return PhoneRejectExchange
+			}
+		}
+	case 7:
+		if validateCodes && !isValidExchangeCodeUS(digits[:3]) {
+			return PhoneRejectExchange
+		}
+	default:
+		return PhoneRejectLength
+	}
+
+	return ""
+}
+
+// touchesDigits reports whether the span is directly adjacent to a digit, or to a
+// separator followed by a digit, on either side
+func touchesDigits(text string, start, end int) bool {
+	return touchesDigitsIn(text, start, end, false)
+}
+
+// touchesDigitsIn is touchesDigits, where a "/" followed by a number of phone length
+// separates the items of a list such as "555-123-4567/555-987-6543" when lists is true
+func touchesDigitsIn(text string, start, end int, lists bool) bool {
+	if start > 0 {
+		before := text[start-1]
+		if isDigit(before) {
+			return true
+		}
+		if strings.IndexByte("-./", before) >= 0 && start > 1 && isDigit(text[start-2]) &&
+			!(lists && before == '/' && listedNumber(text[strings.LastIndexAny(text[:start-1], listItemEnds)+1:start-1])) {
+			return true
+		}
+	}
+	if end < len(text) {
+		after := text[end]
+		if isDigit(after) {
+			return true
+		}
+		if strings.IndexByte("-./", after) >= 0 && end+1 < len(text) && isDigit(text[end+1]) &&
+			!(lists && after == '/' && listedNumber(listItem(text[end+1:]))) {
+			return true
+		}
+	}
+	return false
+}
+
+// listItemEnds are the characters ending the items of a list of numbers
+const listItemEnds = " \t\r\n/,;"
+
+// listItem returns the list item text starts with
+func listItem(text string) string {
+	if i := strings.IndexAny(text, listItemEnds); i >= 0 {
+		return text[:i]
+	}
+	return text
+}
+
+// listedNumber reports whether a list item is a number of phone length, made of at least
+// 7 digits and phone punctuation only
+func listedNumber(item string) bool {
+	digits := 0
+	for i := 0; i < len(item); i++ {
+		switch {
+		case isDigit(item[i]):
+			digits++
+		case strings.IndexByte("-.()+", item[i]) < 0:
+			return false
+		}
+	}
+	return digits >= 7
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+// reservedAreaCodesUS lists NANP area code blocks that are never assigned to geographic
+// or non-geographic service: N11 service codes, the 37X and 96X reserved blocks, and the
+// N9X expansion codes
+var reservedAreaCodesUS = map[string]bool{
+	"211": true, "311": true, "411": true, "511": true, "611": true, "711": true, "811": true, "911": true,
+	"370": true, "371": true, "372": true, "373": true, "374": true, "375": true, "376": true, "377": true, "378": true, "379": true,
+	"960": true, "961": true, "962": true, "963": true, "964": true, "965": true, "966": true, "967": true, "968": true, "969": true,
+}
+
+// IsValidAreaCodeUS checks a 3-digit NANP area code: NXX where N is 2-9, excluding N9X
+// expansion codes and reserved blocks
+func IsValidAreaCodeUS(code string) bool {
+	if len(code) != 3 || code[0] < '2' || code[0] > '9' || !isDigit(code[1]) || !isDigit(code[2]) {
+		return false
+	}
+	if code[1] == '9' {
+		return false
+	}
+	return !reservedAreaCodesUS[code]
+}
+
+// isValidExchangeCodeUS checks a 3-digit NANP central office code: NXX where N is 2-9, excluding N11
+func isValidExchangeCodeUS(code []byte) bool {
+	if code[0] < '2' || code[0] > '9' {
+		return false
+	}
+	return !(code[1] == '1' && code[2] == '1')
+}
@@ -2,6 +2,7 @@ package patterns
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -196,3 +197,144 @@ func TestUSStreetAddressExtraction(t *testing.T) {
 		})
 	}
 }
+
+func TestUSPhoneBoundaries(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name:     "credit card fragments are not phones",
+			input:    "Card: 4111-1111-1111-1111 and 5555 5555 5555 4444",
+			expected: []string{},
+		},
+		{
+			name:     "digits inside a longer identifier",
+			input:    "Order 98765551234567 shipped",
+			expected: []string{},
+		},
+		{
+			name:     "phone followed by an extension",
+			input:    "Call 555-123-4567x89",
+			expected: []string{"555-123-4567"},
+		},
+		{
+			name:     "local seven digit number",
+			input:    "Dial 867-5309 now",
+			expected: []string{"867-5309"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := PhonesUS(tt.input)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("PhonesUS() = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPhoneUSRejectionCodes(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"(212) 555-0142", ""},
+		{"(555) 123-4567", PhoneRejectExchange},
+		{"(911) 555-0142", PhoneRejectAreaCode},
+		{"(195) 555-0142", PhoneRejectAreaCode},
+		{"(296) 555-0142", PhoneRejectAreaCode},
+		{"+1 212 411 0142", PhoneRejectExchange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := PhoneUSRejection(tt.input, 0, len(tt.input), true); got != tt.expected {
+				t.Errorf("PhoneUSRejection(%q) = %q, expected %q", tt.input, got, tt.expected)
+			}
+			if got := PhoneUSRejection(tt.input, 0, len(tt.input), false); got != "" {
+				t.Errorf("PhoneUSRejection(%q) without code validation = %q, expected no rejection", tt.input, got)
+			}
+		})
+	}
+}
+
+func TestUSStreetAddressBoundedMatches(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name:     "number glued to a token",
+			input:    "Part A300 Third Ct replacement",
+			expected: []string{},
+		},
+		{
+			name:     "ordinal street names",
+			input:    "Office: 350 5th Avenue, New York",
+			expected: []string{"350 5th Avenue"},
+		},
+		{
+			name:     "address does not span lines",
+			input:    "Room 12\nMain Street is closed",
+			expected: []string{},
+		},
+		{
+			name:     "too many words before the street type",
+			input:    "We sold 12 boxes of red apples on the street",
+			expected: []string{},
+		},
+		{
+			name:     "long lowercase run",
+			input:    "12 " + strings.Repeat("lorem ipsum ", 5000) + "street",
+			expected: []string{},
+		},
+		{
+			name:     "very long word",
+			input:    "12 " + strings.Repeat("a", 100000) + " street",
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := StreetAddressesUS(tt.input)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("StreetAddressesUS() = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func FuzzStreetAddressesUS(f *testing.F) {
+	f.Add("Address: 123 Main Street, Anytown, ST 12345")
+	f.Add("Location: 456 Oak Ave and 789 Pine Rd")
+	f.Add("12 " + strings.Repeat("a ", 1000) + "st")
+	f.Add("1 st st st st st st st st st")
+
+	isStreetType := make(map[string]bool, len(StreetTypesUS))
+	for _, streetType := range StreetTypesUS {
+		isStreetType[streetType] = true
+	}
+
+	f.Fuzz(func(t *testing.T, text string) {
+		for _, match := range StreetAddressesUS(text) {
+			words := strings.Fields(match)
+			if len(words) < 3 || len(words) > MaxStreetNameWordsUS+2 {
+				t.Fatalf("match %q has %d words", match, len(words))
+			}
+			if match[0] < '0' || match[0] > '9' {
+				t.Fatalf("match %q does not start with a house number", match)
+			}
+			if strings.ContainsAny(match, "\r\n") {
+				t.Fatalf("match %q spans lines", match)
+			}
+			if !isStreetType[strings.ToLower(words[len(words)-1])] {
+				t.Fatalf("match %q does not end with a street type", match)
+			}
+		}
+	})
+}
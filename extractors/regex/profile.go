@@ -0,0 +1,228 @@
+package regex
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// OptionAutoConfigure is the ExtractorConfig option selecting the country packs and types
+// scanned in each document from a cheap pre-pass (see DetectProfile), instead of running
+// every pattern on every document of a heterogeneous corpus
+const OptionAutoConfigure = "auto_configure"
+
+// ProfileMinLanguageWords is the number of stop words of a language needed to detect it
+const ProfileMinLanguageWords = 2
+
+// Profile is what a pre-pass over a document found about the PII it may contain
+type Profile struct {
+	Languages []string      // ISO 639-1 codes of the languages detected from stop words
+	Scripts   []string      // non-Latin scripts used, such as "Arabic" or "Han"
+	Countries []pii.Country // countries pointed to by the languages, scripts, phone prefixes and keywords; nil when none
+	Excluded  []pii.PiiType // types that cannot occur, such as digit-only types in a text without digits
+}
+
+// AllowsCountry reports whether the packs of a country should be scanned. Documents
+// without any country signal, and packs of countries the pre-pass does not know, are
+// always scanned.
+func (p Profile) AllowsCountry(country pii.Country) bool {
+	if len(p.Countries) == 0 || country == "" {
+		return true
+	}
+	return slices.Contains(p.Countries, country) || !slices.Contains(pii.SupportedCountries(), country)
+}
+
+// AllowsType reports whether a type should be scanned
+func (p Profile) AllowsType(piiType pii.PiiType) bool {
+	return !slices.Contains(p.Excluded, piiType)
+}
+
+// languageStopWords are frequent words specific to each language
+var languageStopWords = map[string][]string{
+	"en": {"the", "and", "of", "is", "with", "you", "your", "please", "this", "that"},
+	"fr": {"les", "et", "des", "est", "une", "pour", "avec", "vous", "dans", "nous"},
+	"es": {"los", "las", "y", "del", "para", "por", "usted", "está", "como", "pero"},
+	"it": {"il", "gli", "della", "di", "che", "è", "sono", "per", "questo", "anche"},
+	"de": {"der", "die", "und", "das", "ist", "nicht", "mit", "ein", "für", "sie"},
+	"nl": {"het", "een", "en", "van", "niet", "voor", "zijn", "ik", "wij", "bij"},
+}
+
+// languageCountries are the countries whose packs are scanned for each language
+var languageCountries = map[string][]pii.Country{
+	"en": {pii.CountryUS, pii.CountryGB, pii.CountryIN},
+	"fr": {pii.CountryFR, pii.CountryBE},
+	"es": {pii.CountryES},
+	"it": {pii.CountryIT},
+	"de": {pii.CountryDE, pii.CountryBE},
+	"nl": {pii.CountryNL, pii.CountryBE},
+}
+
+// scriptCountries are the countries whose packs are scanned for each non-Latin script
+var scriptCountries = []struct {
+	name      string
+	table     *unicode.RangeTable
+	countries []pii.Country
+}{
+	{"Arabic", unicode.Arabic, []pii.Country{pii.RegionArabic}},
+	{"Cyrillic", unicode.Cyrillic, []pii.Country{pii.CountryRU}},
+	{"Han", unicode.Han, []pii.Country{pii.CountryCN}},
+	{"Devanagari", unicode.Devanagari, []pii.Country{pii.CountryIN}},
+}
+
+// phonePrefixes are the international dialing codes of each country
+var phonePrefixes = map[string]pii.Country{
+	"1": pii.CountryUS, "44": pii.CountryGB, "33": pii.CountryFR, "34": pii.CountryES,
+	"39": pii.CountryIT, "49": pii.CountryDE, "86": pii.CountryCN, "91": pii.CountryIN,
+	"7": pii.CountryRU, "31": pii.CountryNL, "32": pii.CountryBE,
+	"966": pii.RegionArabic, "971": pii.RegionArabic, "20": pii.RegionArabic,
+}
+
+// countryKeywords are lowercase words pointing to a country, such as its name or the
+// name of its identifiers
+var countryKeywords = map[string]pii.Country{
+	"usa": pii.CountryUS, "ssn": pii.CountryUS,
+	"uk": pii.CountryGB, "britain": pii.CountryGB, "england": pii.CountryGB, "scotland": pii.CountryGB, "nhs": pii.CountryGB,
+	"france": pii.CountryFR, "insee": pii.CountryFR, "siret": pii.CountryFR,
+	"spain": pii.CountryES, "españa": pii.CountryES, "dni": pii.CountryES, "nie": pii.CountryES,
+	"italy": pii.CountryIT, "italia": pii.CountryIT,
+	"germany": pii.CountryDE, "deutschland": pii.CountryDE, "straße": pii.CountryDE, "strasse": pii.CountryDE,
+	"china": pii.CountryCN,
+	"india": pii.CountryIN, "aadhaar": pii.CountryIN,
+	"russia":      pii.CountryRU,
+	"netherlands": pii.CountryNL, "nederland": pii.CountryNL, "bsn": pii.CountryNL,
+	"belgium": pii.CountryBE, "belgique": pii.CountryBE, "belgië": pii.CountryBE,
+	"uae": pii.RegionArabic, "saudi": pii.RegionArabic, "egypt": pii.RegionArabic,
+}
+
+// digitTypes are the types whose values always contain digits
+var digitTypes = []pii.PiiType{
+	pii.PiiTypePhone, pii.PiiTypeSSN, pii.PiiTypeZipCode, pii.PiiTypePoBox, pii.PiiTypeCreditCard,
+	pii.PiiTypeIPAddress, pii.PiiTypeIBAN, pii.PiiTypeTrackData, pii.PiiTypeNationalID,
+}
+
+// DetectProfile runs a single pass over text detecting its languages, scripts, dialing
+// codes and country keywords, and which types cannot occur in it
+func DetectProfile(text string) Profile {
+	var profile Profile
+	countries := make(map[pii.Country]bool)
+	languageWords := make(map[string]int)
+	scripts := make([]int, len(scriptCountries))
+	hasDigit, hasAt := false, false
+
+	word := make([]rune, 0, 32)
+	endWord := func() {
+		if len(word) == 0 {
+			return
+		}
+		token := string(word)
+		for language, stopWords := range languageStopWords {
+			if slices.Contains(stopWords, token) {
+				languageWords[language]++
+			}
+		}
+		if country, ok := countryKeywords[token]; ok {
+			countries[country] = true
+		}
+		word = word[:0]
+	}
+
+	for i, r := range text {
+		switch {
+		case unicode.IsLetter(r):
+			word = append(word, unicode.ToLower(r))
+			if r > unicode.MaxLatin1 {
+				for j, script := range scriptCountries {
+					if unicode.Is(script.table, r) {
+						scripts[j]++
+						break
+					}
+				}
+			}
+			continue
+		case unicode.IsDigit(r):
+			// Arabic-Indic and full-width digits count, as the patterns normalize them
+			hasDigit = true
+		case r == '@':
+			hasAt = true
+		case r == '+':
+			if country, ok := dialingCode(text[i+1:]); ok {
+				countries[country] = true
+			}
+		}
+		endWord()
+	}
+	endWord()
+
+	top := 0
+	for _, count := range languageWords {
+		top = max(top, count)
+	}
+	for language, count := range languageWords {
+		// Stray words of other languages, such as "die" in English, are ignored
+		if count >= ProfileMinLanguageWords && 4*count >= top {
+			profile.Languages = append(profile.Languages, language)
+			for _, country := range languageCountries[language] {
+				countries[country] = true
+			}
+		}
+	}
+	slices.Sort(profile.Languages)
+	for j, count := range scripts {
+		if count > 0 {
+			profile.Scripts = append(profile.Scripts, scriptCountries[j].name)
+			for _, country := range scriptCountries[j].countries {
+				countries[country] = true
+			}
+		}
+	}
+
+	for _, country := range pii.SupportedCountries() {
+		if countries[country] {
+			profile.Countries = append(profile.Countries, country)
+		}
+	}
+	if !hasDigit {
+		profile.Excluded = append(profile.Excluded, digitTypes...)
+	}
+	if !hasAt {
+		profile.Excluded = append(profile.Excluded, pii.PiiTypeEmail)
+	}
+	return profile
+}
+
+// dialingCode returns the country of the international dialing code starting text, such
+// as "44" in "+44 20 7946 0958", trying the longest codes first
+func dialingCode(text string) (pii.Country, bool) {
+	digits := 0
+	for digits < len(text) && digits < 3 && text[digits] >= '0' && text[digits] <= '9' {
+		digits++
+	}
+	for n := digits; n > 0; n-- {
+		if country, ok := phonePrefixes[text[:n]]; ok {
+			return country, true
+		}
+	}
+	return "", false
+}
+
+// profileScans returns the scans allowed by the profile of text
+func profileScans(scans []scan, text string) ([]scan, Profile) {
+	profile := DetectProfile(text)
+	return slices.DeleteFunc(slices.Clone(scans), func(s scan) bool {
+		return !profile.AllowsCountry(s.country) || !profile.AllowsType(s.piiType)
+	}), profile
+}
+
+// profileAttribute describes the countries of a profile for trace spans
+func profileAttribute(profile Profile) string {
+	if len(profile.Countries) == 0 {
+		return "all"
+	}
+	countries := make([]string, len(profile.Countries))
+	for i, country := range profile.Countries {
+		countries[i] = country.String()
+	}
+	return strings.Join(countries, ",")
+}
@@ -0,0 +1,138 @@
+package regex
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/intMeric/pii-extractor/extractors"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+func TestDetectProfile(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		languages []string
+		countries []pii.Country
+	}{
+		{
+			name:      "french letter",
+			text:      "Bonjour, vous pouvez nous écrire au 12 rue de la Paix, 75002 Paris, pour les questions et les demandes.",
+			languages: []string{"fr"},
+			countries: []pii.Country{pii.CountryFR, pii.CountryBE},
+		},
+		{
+			name:      "cyrillic script",
+			text:      "Адрес: ул. Тверская, д. 13, 101000 Москва",
+			countries: []pii.Country{pii.CountryRU},
+		},
+		{
+			name:      "dialing code and keyword",
+			text:      "Call +44 20 7946 0958 or the NHS helpline",
+			countries: []pii.Country{pii.CountryGB},
+		},
+		{
+			name: "no signal",
+			text: "2024-05-01T10:00:00Z 192.168.1.1 GET /index.html 200",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := DetectProfile(tt.text)
+			if !slices.Equal(profile.Languages, tt.languages) {
+				t.Errorf("Languages = %v, want %v", profile.Languages, tt.languages)
+			}
+			if !slices.Equal(profile.Countries, tt.countries) {
+				t.Errorf("Countries = %v, want %v", profile.Countries, tt.countries)
+			}
+		})
+	}
+}
+
+func TestDetectProfile_ExcludedTypes(t *testing.T) {
+	profile := DetectProfile("Thanks for your help with the migration")
+	for _, piiType := range []pii.PiiType{pii.PiiTypePhone, pii.PiiTypeCreditCard, pii.PiiTypeEmail} {
+		if profile.AllowsType(piiType) {
+			t.Errorf("Expected %s excluded from a text without digits or @", piiType)
+		}
+	}
+	if !profile.AllowsType(pii.PiiTypeHostname) {
+		t.Error("Expected hostnames to stay allowed")
+	}
+	if !profile.AllowsCountry("PT") {
+		t.Error("Expected packs of countries unknown to the pre-pass to stay allowed")
+	}
+}
+
+func TestAutoConfigure_SameFindings(t *testing.T) {
+	auto := NewExtractor(&extractors.ExtractorConfig{Options: map[string]any{OptionAutoConfigure: true}})
+	full := NewDefaultExtractor()
+
+	for _, text := range []string{benchmarkText, "Contact: marie.dupont@example.fr, 75001 Paris, tél. +33 1 42 96 87 56 pour les rendez-vous et les questions."} {
+		want, _ := full.Extract(text)
+		got, err := auto.Extract(text)
+		if err != nil {
+			t.Fatalf("Extract() error = %v", err)
+		}
+		// Matches of skipped packs, such as Arabic phone numbers in IBAN digits, are dropped
+		if missing := missingValues(want, got, DetectProfile(text)); len(missing) > 0 {
+			t.Errorf("Auto-configuration missed %v", missing)
+		}
+	}
+}
+
+func TestAutoConfigure_SkipsOtherCountries(t *testing.T) {
+	auto := NewExtractor(&extractors.ExtractorConfig{Options: map[string]any{OptionAutoConfigure: true}})
+	// A French document: the US ZIP code pattern is not run on the postal code
+	result, err := auto.Extract("Vous pouvez nous écrire au 12 rue de la Paix, 75002 Paris, pour les questions et les demandes.")
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	for _, entity := range result.GetZipCodes() {
+		if country := pii.EntityCountry(entity); country != pii.CountryFR {
+			t.Errorf("Expected only French postal codes, got %s (%s)", entity.GetValue(), country)
+		}
+	}
+}
+
+// missingValues returns the values of want for countries allowed by the profile not found
+// in got
+func missingValues(want, got *pii.PiiExtractionResult, profile Profile) []string {
+	found := make(map[string]bool)
+	for _, entity := range got.Entities {
+		found[entity.Type.String()+":"+entity.GetValue()] = true
+	}
+	var missing []string
+	for _, entity := range want.Entities {
+		if key := entity.Type.String() + ":" + entity.GetValue(); !found[key] && profile.AllowsCountry(pii.EntityCountry(entity)) {
+			missing = append(missing, key)
+		}
+	}
+	return missing
+}
+
+func BenchmarkRegexExtractor_AutoConfigure(b *testing.B) {
+	corpus := []string{
+		strings.Repeat("Thanks for the update, please find the notes of the meeting with the team. ", 40),
+		strings.Repeat("Merci pour votre message, nous vous écrirons avec les détails dans la journée. ", 40),
+		strings.Repeat("2024-05-01T10:00:00Z 10.0.0.12 GET /api/orders 200 ", 40),
+	}
+	for _, auto := range []bool{false, true} {
+		extractor := NewExtractor(&extractors.ExtractorConfig{Options: map[string]any{OptionAutoConfigure: auto}})
+		name := "all"
+		if auto {
+			name = "auto"
+		}
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				for _, text := range corpus {
+					if _, err := extractor.Extract(text); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,272 @@
+package regex
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// RulesVersion is the version of the rules file schema
+const RulesVersion = 1
+
+// RulesFile is the schema of an external rules file, written in JSON:
+//
+//	{
+//	  "version": 1,
+//	  "rules": [
+//	    {"name": "employee-id", "type": "national_id", "country": "US", "pattern": "\\bEMP-\\d{6}\\b", "scheme": "acme_employee"},
+//	    {"name": "fr-phones", "type": "phone", "country": "FR", "pattern": "\\b0[1-9](?: \\d{2}){4}\\b", "replace": true},
+//	    {"name": "no-hostnames", "type": "hostname", "disable": true}
+//	  ]
+//	}
+type RulesFile struct {
+	Version int        `json:"version"`
+	Rules   []RuleSpec `json:"rules"`
+}
+
+// RuleSpec is one rule of a rules file
+type RuleSpec struct {
+	Name    string      `json:"name"`              // unique name of the rule
+	Type    string      `json:"type"`              // PII type name, such as "phone" or "national_id"
+	Country pii.Country `json:"country,omitempty"` // country of the matches, empty for international rules
+	Pattern string      `json:"pattern,omitempty"` // Go regular expression, required unless Disable is set
+	Scheme  string      `json:"scheme,omitempty"`  // identifier scheme of national_id matches
+
+	// Replace turns off the built-in scans of the rule type and country, so that the
+	// rule overrides them instead of adding to them
+	Replace bool `json:"replace,omitempty"`
+	// Disable turns off the built-in scans of the rule type and country without adding a
+	// pattern
+	Disable bool `json:"disable,omitempty"`
+}
+
+// RuleSet is a validated and compiled rules file, applied to an extractor with SetRules.
+// It is immutable and can be shared by several extractors.
+type RuleSet struct {
+	rules    []compiledRule
+	replaced map[ruleTarget]bool
+	checksum [sha256.Size]byte
+}
+
+// compiledRule is a rule with its compiled pattern
+type compiledRule struct {
+	spec    RuleSpec
+	piiType pii.PiiType
+	regex   *regexp.Regexp // nil for Disable rules
+}
+
+// ruleTarget is the type and country of built-in scans replaced by a rule
+type ruleTarget struct {
+	piiType pii.PiiType
+	country pii.Country
+}
+
+// LoadRules reads and validates the rules file at path
+func LoadRules(path string) (*RuleSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := ParseRules(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return rules, nil
+}
+
+// ParseRules validates and compiles a rules file. Unknown fields, unknown types and
+// countries, invalid patterns and duplicate names are rejected; every problem found is
+// reported in the returned error.
+func ParseRules(data []byte) (*RuleSet, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var file RulesFile
+	if err := decoder.Decode(&file); err != nil {
+		return nil, fmt.Errorf("invalid rules file: %w", err)
+	}
+	if file.Version != RulesVersion {
+		return nil, fmt.Errorf("unsupported rules file version %d, expected %d", file.Version, RulesVersion)
+	}
+
+	set := &RuleSet{replaced: make(map[ruleTarget]bool), checksum: sha256.Sum256(data)}
+	names := make(map[string]bool)
+	var errs []error
+	for i, spec := range file.Rules {
+		rule, err := compileRule(spec)
+		if err == nil && names[spec.Name] {
+			err = errors.New("duplicate name")
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("rule %d (%s): %w", i, spec.Name, err))
+			continue
+		}
+		names[spec.Name] = true
+		if spec.Replace || spec.Disable {
+			set.replaced[ruleTarget{rule.piiType, rule.spec.Country}] = true
+		}
+		set.rules = append(set.rules, rule)
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+// compileRule validates a rule and compiles its pattern
+func compileRule(spec RuleSpec) (compiledRule, error) {
+	if strings.TrimSpace(spec.Name) == "" {
+		return compiledRule{}, errors.New("missing name")
+	}
+	piiType, ok := pii.ParsePiiType(spec.Type)
+	if !ok {
+		return compiledRule{}, fmt.Errorf("unknown type %q", spec.Type)
+	}
+	if spec.Country != "" {
+		country, ok := pii.ParseCountry(string(spec.Country))
+		if !ok {
+			return compiledRule{}, fmt.Errorf("unknown country %q", spec.Country)
+		}
+		spec.Country = country
+	}
+	rule := compiledRule{spec: spec, piiType: piiType}
+
+	switch {
+	case spec.Disable && spec.Pattern != "":
+		return compiledRule{}, errors.New("a disable rule has no pattern")
+	case spec.Disable:
+		return rule, nil
+	case spec.Pattern == "":
+		return compiledRule{}, errors.New("missing pattern")
+	}
+	regex, err := regexp.Compile(spec.Pattern)
+	if err != nil {
+		return compiledRule{}, fmt.Errorf("invalid pattern: %w", err)
+	}
+	if regex.MatchString("") {
+		return compiledRule{}, errors.New("pattern matches the empty string")
+	}
+	rule.regex = regex
+	return rule, nil
+}
+
+// Specs returns the rules of the set, in file order
+func (s *RuleSet) Specs() []RuleSpec {
+	specs := make([]RuleSpec, len(s.rules))
+	for i, rule := range s.rules {
+		specs[i] = rule.spec
+	}
+	return specs
+}
+
+// Checksum returns the hex SHA-256 of the rules file the set was parsed from
+func (s *RuleSet) Checksum() string {
+	return fmt.Sprintf("%x", s.checksum)
+}
+
+// replaces reports whether the rules turn off the built-in scans of a type and country
+func (s *RuleSet) replaces(piiType pii.PiiType, country pii.Country) bool {
+	return s != nil && s.replaced[ruleTarget{piiType, country}]
+}
+
+// SetRules applies a rule set to the extractor, replacing the rules applied before. It
+// is safe to call while extractions run: each extraction uses the rules applied when it
+// started. A nil set removes the rules.
+func (r *RegexExtractor) SetRules(rules *RuleSet) {
+	r.rules.Store(rules)
+}
+
+// Rules returns the rule set applied to the extractor, or nil
+func (r *RegexExtractor) Rules() *RuleSet {
+	return r.rules.Load()
+}
+
+// ruleScans returns the scans of the rules matching the configured countries
+func (r *RegexExtractor) ruleScans(rules *RuleSet) []scan {
+	if rules == nil {
+		return nil
+	}
+	var scans []scan
+	for _, rule := range rules.rules {
+		if rule.regex == nil || (rule.spec.Country != "" && !r.shouldExtractForCountry(rule.spec.Country)) {
+			continue
+		}
+		scans = append(scans, scan{rule.piiType, rule.spec.Country, rule.extract})
+	}
+	return scans
+}
+
+// extract returns the entities of the rule matches in text
+func (rule compiledRule) extract(text string) []pii.PiiEntity {
+	values := extractWithContext(text, rule.regex,
+		func(value, context string) pii.BasePii {
+			return pii.BasePii{Value: value, Contexts: []string{context}, Count: 1}
+		},
+		func(base *pii.BasePii, context string) {
+			base.IncrementCount()
+			base.AddContext(context)
+		})
+
+	entities := make([]pii.PiiEntity, 0, len(values))
+	for _, base := range values {
+		entities = append(entities, pii.PiiEntity{Type: rule.piiType, Value: rule.value(base)})
+	}
+	return entities
+}
+
+// value wraps a match into the value object of the rule type
+func (rule compiledRule) value(base pii.BasePii) pii.Pii {
+	country := rule.spec.Country
+	switch rule.piiType {
+	case pii.PiiTypePhone:
+		return pii.Phone{BasePii: base, Country: country}
+	case pii.PiiTypeEmail:
+		return pii.Email{BasePii: base}
+	case pii.PiiTypeSSN:
+		return pii.SSN{BasePii: base, Country: country}
+	case pii.PiiTypeZipCode:
+		return pii.ZipCode{BasePii: base, Country: country}
+	case pii.PiiTypePoBox:
+		return pii.PoBox{BasePii: base, Country: country}
+	case pii.PiiTypeStreetAddress:
+		return pii.StreetAddress{BasePii: base, Country: country}
+	case pii.PiiTypeCreditCard:
+		return pii.CreditCard{BasePii: base}
+	case pii.PiiTypeIPAddress:
+		return pii.IPAddress{BasePii: base}
+	case pii.PiiTypeBtcAddress:
+		return pii.BtcAddress{BasePii: base}
+	case pii.PiiTypeIBAN:
+		return pii.IBAN{BasePii: base, Country: country}
+	case pii.PiiTypeHostname:
+		return pii.Hostname{BasePii: base}
+	case pii.PiiTypeSessionToken:
+		// Tokens and secrets are masked as by the built-in scans
+		length := len(base.Value)
+		base.Value = pii.MaskSessionToken(base.Value)
+		return pii.SessionToken{BasePii: base, Length: length}
+	case pii.PiiTypeTrackData:
+		return pii.TrackData{BasePii: base}
+	case pii.PiiTypeSecret:
+		length := len(base.Value)
+		base.Value = pii.MaskSessionToken(base.Value)
+		return pii.Secret{BasePii: base, Length: length}
+	case pii.PiiTypeHash:
+		return pii.Hash{BasePii: base}
+	case pii.PiiTypePersonName:
+		return pii.PersonName{BasePii: base, Confidence: 1}
+	default:
+		return pii.NationalID{BasePii: base, Country: country, Scheme: rule.spec.Scheme}
+	}
+}
+
+// explainScan returns the rule as a pattern scan reported by Explain
+func (rule compiledRule) explainScan() patternScan {
+	return patternScan{piiType: rule.piiType, country: rule.spec.Country, regex: rule.regex}
+}
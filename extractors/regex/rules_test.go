@@ -0,0 +1,116 @@
+package regex
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+const testRules = `{
+  "version": 1,
+  "rules": [
+    {"name": "employee-id", "type": "national_id", "country": "US", "pattern": "\\bEMP-\\d{6}\\b", "scheme": "acme_employee"},
+    {"name": "internal-hosts", "type": "hostname", "disable": true}
+  ]
+}`
+
+func TestParseRules_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   string
+		wantErr string
+	}{
+		{"not json", `rules`, "invalid rules file"},
+		{"unknown field", `{"version": 1, "rules": [], "extra": true}`, "unknown field"},
+		{"version", `{"version": 2, "rules": []}`, "unsupported rules file version 2"},
+		{"type", `{"version": 1, "rules": [{"name": "a", "type": "dna", "pattern": "x"}]}`, `rule 0 (a): unknown type "dna"`},
+		{"country", `{"version": 1, "rules": [{"name": "a", "type": "phone", "country": "Atlantis", "pattern": "x"}]}`, "unknown country"},
+		{"pattern", `{"version": 1, "rules": [{"name": "a", "type": "phone", "pattern": "("}]}`, "invalid pattern"},
+		{"empty match", `{"version": 1, "rules": [{"name": "a", "type": "phone", "pattern": "x*"}]}`, "matches the empty string"},
+		{"missing pattern", `{"version": 1, "rules": [{"name": "a", "type": "phone"}]}`, "missing pattern"},
+		{"disable with pattern", `{"version": 1, "rules": [{"name": "a", "type": "phone", "pattern": "x", "disable": true}]}`, "no pattern"},
+		{"duplicate", `{"version": 1, "rules": [{"name": "a", "type": "phone", "pattern": "x"}, {"name": "a", "type": "ssn", "pattern": "y"}]}`, "rule 1 (a): duplicate name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseRules([]byte(tt.rules)); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseRules() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	// Every invalid rule is reported
+	_, err := ParseRules([]byte(`{"version": 1, "rules": [{"name": "", "type": "phone", "pattern": "x"}, {"name": "b", "type": "dna", "pattern": "x"}]}`))
+	if err == nil || !strings.Contains(err.Error(), "missing name") || !strings.Contains(err.Error(), "unknown type") {
+		t.Errorf("Expected both errors reported, got %v", err)
+	}
+}
+
+func TestRegexExtractor_SetRules(t *testing.T) {
+	rules, err := ParseRules([]byte(testRules))
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := "Badge EMP-123456 issued, see wiki.acme.com or mail john@acme.com"
+
+	extractor := NewDefaultExtractor()
+	before, _ := extractor.Extract(text)
+	if !before.HasType(pii.PiiTypeHostname) || before.HasType(pii.PiiTypeNationalID) {
+		t.Fatalf("Unexpected result before the rules: %v", before.Stats)
+	}
+
+	extractor.SetRules(rules)
+	after, err := extractor.Extract(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after.HasType(pii.PiiTypeHostname) {
+		t.Error("Expected the disabled hostname scan not to run")
+	}
+	ids := after.GetEntitiesByType(pii.PiiTypeNationalID)
+	if len(ids) != 1 || ids[0].GetValue() != "EMP-123456" {
+		t.Fatalf("Expected the employee ID, got %+v", ids)
+	}
+	if id, _ := pii.GetTypedValue[pii.NationalID](ids[0]); id.Scheme != "acme_employee" || id.Country != pii.CountryUS {
+		t.Errorf("Unexpected national ID %+v", id)
+	}
+	if !after.HasType(pii.PiiTypeEmail) {
+		t.Error("Expected the built-in scans to keep running")
+	}
+
+	// Rules of countries outside the configuration are not run
+	gb := NewExtractor(nil)
+	gb.countries = []pii.Country{pii.CountryGB}
+	gb.SetRules(rules)
+	if result, _ := gb.Extract(text); result.HasType(pii.PiiTypeNationalID) {
+		t.Error("Expected the US rule to be skipped for GB")
+	}
+
+	extractor.SetRules(nil)
+	if result, _ := extractor.Extract(text); !result.HasType(pii.PiiTypeHostname) {
+		t.Error("Expected the built-in scans back once the rules are removed")
+	}
+}
+
+func TestRegexExtractor_ReplaceRule(t *testing.T) {
+	rules, err := ParseRules([]byte(`{"version": 1, "rules": [
+		{"name": "ssn-no-dashes", "type": "ssn", "country": "US", "pattern": "\\bSSN:\\d{9}\\b", "replace": true}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	extractor := NewDefaultExtractor()
+	extractor.SetRules(rules)
+
+	result, _ := extractor.Extract("SSN 123-45-6789 and SSN:987654321")
+	ssns := result.GetEntitiesByType(pii.PiiTypeSSN)
+	if len(ssns) != 1 || ssns[0].GetValue() != "SSN:987654321" {
+		t.Errorf("Expected only the rule match, got %+v", ssns)
+	}
+	for _, s := range extractor.explainScans() {
+		if s.piiType == pii.PiiTypeSSN && s.country == pii.CountryUS && s.regex != rules.rules[0].regex {
+			t.Error("Expected the replaced scan out of Explain")
+		}
+	}
+}
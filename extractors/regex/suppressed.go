@@ -0,0 +1,65 @@
+package regex
+
+import (
+	"slices"
+
+	patterns "github.com/intMeric/pii-extractor/extractors/regex/patterns"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// OptionRecordSuppressed is the ExtractorConfig option recording the candidates dropped by
+// false-positive filters, including the numeric guard, in the Suppressed section of results
+const OptionRecordSuppressed = "record_suppressed"
+
+// filterScans returns the pattern scans with a false-positive filter among the scans run.
+// Candidates excluded by the configured types and countries, and near misses, were never
+// findings and are not recorded.
+func (r *RegexExtractor) filterScans(scans []scan) []patternScan {
+	var filtered []patternScan
+	for _, ps := range r.explainScans() {
+		if ps.filter == nil {
+			continue
+		}
+		if slices.ContainsFunc(scans, func(s scan) bool { return s.piiType == ps.piiType && s.country == ps.country }) {
+			filtered = append(filtered, ps)
+		}
+	}
+	return filtered
+}
+
+// rejectedMatches returns the matches of the filtered scans in text that their filter
+// rejects, with spans at offset in the whole text
+func (r *RegexExtractor) rejectedMatches(text string, offset int, scans []patternScan) []pii.SuppressedCandidate {
+	var candidates []pii.SuppressedCandidate
+	for _, ps := range scans {
+		for _, idx := range patterns.MatchWithIndices(text, ps.regex) {
+			detail := ps.filter(r, text, idx[0], idx[1])
+			if detail == "" {
+				continue
+			}
+			candidates = append(candidates, pii.SuppressedCandidate{
+				Type:    ps.piiType,
+				Country: ps.country,
+				Value:   text[idx[0]:idx[1]],
+				Start:   offset + idx[0],
+				End:     offset + idx[1],
+				Reason:  string(ReasonFalsePositiveFilter),
+				Detail:  detail,
+			})
+		}
+	}
+	return candidates
+}
+
+// suppressedAt returns the candidate of an entity occurrence dropped by a false-positive
+// filter, with the detail of the filter
+func suppressedAt(entity pii.PiiEntity, text string, start, end int, detail string) pii.SuppressedCandidate {
+	return pii.SuppressedCandidate{
+		Type:   entity.Type,
+		Value:  text[start:end],
+		Start:  start,
+		End:    end,
+		Reason: string(ReasonFalsePositiveFilter),
+		Detail: detail,
+	}
+}
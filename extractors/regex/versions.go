@@ -0,0 +1,186 @@
+package regex
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// PatternSetVersion identifies the built-in detection rules of this release. It is
+// reported on every regex extraction result and bumped whenever a pattern or
+// false-positive filter changes.
+const PatternSetVersion = "1.7.0"
+
+// arabicDigitsVersion is the pattern set from which Arabic phones and postal codes match
+// Eastern Arabic digits
+const arabicDigitsVersion = "1.3.0"
+
+// russiaRulesVersion is the pattern set from which Russian phones and street addresses
+// use the current rules
+const russiaRulesVersion = "1.4.0"
+
+// indiaRulesVersion is the pattern set from which Indian phones, PIN codes and street
+// addresses use the current rules
+const indiaRulesVersion = "1.5.0"
+
+// beneluxVersion is the pattern set from which Dutch and Belgian national IDs, phones and
+// postal codes are detected
+const beneluxVersion = "1.6.0"
+
+// boundaryVersion is the pattern set from which identifiers found inside larger tokens,
+// such as order IDs or base64 blobs, are rejected
+const boundaryVersion = "1.7.0"
+
+// Russian rules of the pattern sets before russiaRulesVersion, kept so that pinned
+// extractors reproduce their results
+var (
+	phoneRussiaLegacyRegex         = regexp.MustCompile(`(?:\+7|8)[\s\-]?\(?(?:3[0-9][0-9]|4[0-9][0-9]|8[0-9][0-9]|9[0-9][0-9])\)?[\s\-]?\d{3}[\s\-]?\d{2}[\s\-]?\d{2}`)
+	streetAddressRussiaLegacyRegex = regexp.MustCompile(`(?i)(?:[^\x00-\x7F]+\s*)+(?:улица|ул\.|проспект|пр\.|переулок|пер\.|площадь|пл\.|набережная|наб\.|бульвар|б-р|шоссе|ш\.|тракт|дорога|линия|аллея|тупик|проезд|спуск|подъем|мост|км|дом|д\.|корпус|корп\.|строение|стр\.|квартира|кв\.)\s*(?:\d+[а-я]?)?`)
+)
+
+// Indian rules of the pattern sets before indiaRulesVersion
+var (
+	postalCodeIndiaLegacyRegex    = regexp.MustCompile(`\b[1-9]\d{5}\b`)
+	phoneIndiaLegacyRegex         = regexp.MustCompile(`(?:\+91\s?|0)?(?:[6-9]\d{9}|11[\s\-]?\d{4}[\s\-]?\d{4})`)
+	streetAddressIndiaLegacyRegex = regexp.MustCompile(`(?i)\b\d{1,4}[a-z]?\s+(?:[a-z\s\-']+\s+)*(?:road|rd|street|st|lane|ln|nagar|colony|sector|block|phase|plot|house|building|apartment|flat|cross|main|layout|extension|park|garden|circle|square|compound|society|residency|enclave|vihar|kunj|puram|gram|marg|path)\b`)
+)
+
+// PatternSetRelease describes the detection rule changes of one pattern set version
+type PatternSetRelease struct {
+	Version string        `json:"version"`
+	Added   []pii.PiiType `json:"added,omitempty"`   // types first detected in this release
+	Changed []pii.PiiType `json:"changed,omitempty"` // types whose existing rules changed
+	Notes   []string      `json:"notes"`
+}
+
+// PatternSetChangelog lists every pattern set release, oldest first
+var PatternSetChangelog = []PatternSetRelease{
+	{
+		Version: "1.0.0",
+		Added: []pii.PiiType{
+			pii.PiiTypeEmail, pii.PiiTypePhone, pii.PiiTypeSSN, pii.PiiTypeZipCode, pii.PiiTypeStreetAddress,
+			pii.PiiTypePoBox, pii.PiiTypeCreditCard, pii.PiiTypeIPAddress, pii.PiiTypeBtcAddress, pii.PiiTypeIBAN,
+		},
+		Notes: []string{"Initial pattern set: emails, cards, IPs, BTC, IBANs and phones, SSNs, postal codes, addresses and P.O. boxes for 10 countries"},
+	},
+	{
+		Version: "1.1.0",
+		Changed: []pii.PiiType{pii.PiiTypePhone, pii.PiiTypeIPAddress, pii.PiiTypeStreetAddress},
+		Notes: []string{
+			"US phones embedded in longer digit sequences are rejected structurally",
+			"IP matches are trimmed and validated with net/netip",
+			"US street address pattern rewritten with bounded tokens",
+		},
+	},
+	{
+		Version: "1.2.0",
+		Added:   []pii.PiiType{pii.PiiTypeHostname, pii.PiiTypeSessionToken, pii.PiiTypeTrackData},
+		Notes: []string{
+			"Hostnames and FQDNs validated against the public suffix list",
+			"Session tokens in HTTP logs (http_logs option)",
+			"Magnetic stripe Track 1/Track 2 data with Luhn check",
+		},
+	},
+	{
+		Version: "1.3.0",
+		Added:   []pii.PiiType{pii.PiiTypeNationalID},
+		Notes: []string{
+			"Saudi national IDs and UAE Emirates IDs with check digit validation",
+			"Arabic phones and postal codes written with Eastern Arabic digits (not matched when pinned to an earlier version)",
+		},
+	},
+	{
+		Version: "1.4.0",
+		Notes: []string{
+			"Russian passport series and numbers after a passport keyword",
+			"Russian street addresses led by inflected Cyrillic or transliterated Latin street keywords, with building parts (previous rules when pinned to an earlier version)",
+			"Russian phones with a bare 7 prefix, rejected inside longer numbers (previous rules when pinned to an earlier version)",
+		},
+	},
+	{
+		Version: "1.5.0",
+		Notes: []string{
+			"Indian phones with +91 or 0091 and any separators, 0-prefixed mobiles and metro landlines, rejected inside longer numbers (previous rules when pinned to an earlier version)",
+			"Indian PIN codes written as \"560 001\" after a PIN keyword (previous rules when pinned to an earlier version)",
+			"Indian street addresses with ordinal cross and main roads, numbered phases and sectors, and Chowk and Bagh (previous rules when pinned to an earlier version)",
+		},
+	},
+	{
+		Version: "1.6.0",
+		Notes: []string{
+			"Dutch BSNs passing the 11-test, phones and postcodes (1012 AB)",
+			"Belgian national register numbers with birth date and check number validation, phones and postal codes with the B- prefix or a place name",
+		},
+	},
+	{
+		Version: "1.7.0",
+		Notes: []string{
+			"Phones, SSNs, postal codes, P.O. boxes, cards, track data, BTC addresses, IBANs and national IDs inside larger tokens such as order IDs, file names or base64 blobs are rejected (previous rules when pinned to an earlier version)",
+			"US phone numbers separated by \"/\" in a list, such as 555-123-4567/555-987-6543, and phone numbers followed by an extension such as x12 are found",
+		},
+	},
+}
+
+// PatternSetTypes returns the types detected by a pattern set version
+func PatternSetTypes(version string) ([]pii.PiiType, error) {
+	index := releaseIndex(version)
+	if index < 0 {
+		return nil, fmt.Errorf("unknown pattern set version %q", version)
+	}
+
+	var types []pii.PiiType
+	for _, release := range PatternSetChangelog[:index+1] {
+		types = append(types, release.Added...)
+	}
+	return types, nil
+}
+
+// CheckPatternSetVersion reports whether this release can reproduce the results of a
+// pattern set version. Rules added since then can be disabled, but rules changed since
+// then cannot be reverted, so pinning to such a version is an error.
+func CheckPatternSetVersion(version string) error {
+	index := releaseIndex(version)
+	if index < 0 {
+		return fmt.Errorf("unknown pattern set version %q", version)
+	}
+
+	var changed []string
+	for _, release := range PatternSetChangelog[index+1:] {
+		for _, piiType := range release.Changed {
+			changed = append(changed, release.Version+" "+piiType.String())
+		}
+	}
+	if len(changed) > 0 {
+		return fmt.Errorf("pattern set %s cannot be reproduced by %s: rules changed in %s",
+			version, PatternSetVersion, strings.Join(changed, ", "))
+	}
+	return nil
+}
+
+// since adapts the extraction function of rules added in a pattern set version, so that
+// extractors pinned to an earlier version do not run them
+func since(version string, extract func(text string) []pii.PiiEntity) func(r *RegexExtractor, text string) []pii.PiiEntity {
+	return func(r *RegexExtractor, text string) []pii.PiiEntity {
+		if !r.patternSetAtLeast(version) {
+			return nil
+		}
+		return extract(text)
+	}
+}
+
+// patternSetAtLeast reports whether the extractor's pattern set is version or later
+func (r *RegexExtractor) patternSetAtLeast(version string) bool {
+	return releaseIndex(r.patternSetVersion) >= releaseIndex(version)
+}
+
+// releaseIndex returns the changelog index of a version, or -1 if unknown
+func releaseIndex(version string) int {
+	for i, release := range PatternSetChangelog {
+		if release.Version == version {
+			return i
+		}
+	}
+	return -1
+}
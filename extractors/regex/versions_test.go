@@ -0,0 +1,61 @@
+package regex
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/intMeric/pii-extractor/extractors"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+func TestPatternSetVersion_Reported(t *testing.T) {
+	if last := PatternSetChangelog[len(PatternSetChangelog)-1]; last.Version != PatternSetVersion {
+		t.Fatalf("Changelog ends at %s, expected the current version %s", last.Version, PatternSetVersion)
+	}
+
+	result, err := NewDefaultExtractor().Extract("Mail john@acme.com")
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if result.PatternSetVersion != PatternSetVersion {
+		t.Errorf("Expected pattern set %s on the result, got %q", PatternSetVersion, result.PatternSetVersion)
+	}
+}
+
+func TestPatternSetVersion_Pinned(t *testing.T) {
+	extractor := NewExtractor(&extractors.ExtractorConfig{PatternSetVersion: "1.1.0"})
+
+	result, err := extractor.Extract("Mail john@acme.com, host api.example.com, swipe ;4111111111111111=25121010000000000000?")
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if result.PatternSetVersion != "1.1.0" {
+		t.Errorf("Expected the pinned version on the result, got %q", result.PatternSetVersion)
+	}
+	if result.HasType(pii.PiiTypeHostname) || result.HasType(pii.PiiTypeTrackData) {
+		t.Errorf("Expected types added in 1.2.0 to be disabled, got %v", result.Stats)
+	}
+	if !result.HasType(pii.PiiTypeEmail) {
+		t.Error("Expected emails to still be detected")
+	}
+	for _, piiType := range extractor.GetSupportedTypes() {
+		if piiType == pii.PiiTypeHostname {
+			t.Error("Expected hostnames to be unsupported by the pinned extractor")
+		}
+	}
+}
+
+func TestPatternSetVersion_NotReproducible(t *testing.T) {
+	for version, want := range map[string]string{
+		"1.0.0": "rules changed in 1.1.0 phone",
+		"0.9.0": "unknown pattern set version",
+	} {
+		extractor := NewExtractor(&extractors.ExtractorConfig{PatternSetVersion: version})
+		if _, err := extractor.Extract("Mail john@acme.com"); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Pinning %s: expected an error containing %q, got %v", version, want, err)
+		}
+		if _, err := extractor.ExtractByType("Mail john@acme.com", pii.PiiTypeEmail); err == nil {
+			t.Errorf("Pinning %s: expected ExtractByType to fail", version)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package regex
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+)
+
+// DefaultRulesPollInterval is how often a RulesWatcher checks its file for changes by default
+const DefaultRulesPollInterval = 5 * time.Second
+
+// WatchOptions configures a RulesWatcher
+type WatchOptions struct {
+	// PollInterval is how often the file is checked for changes (DefaultRulesPollInterval
+	// when zero); a negative interval only reloads on signals
+	PollInterval time.Duration
+
+	// Signals trigger a reload (SIGHUP on Unix systems when nil, none when empty)
+	Signals []os.Signal
+
+	// OnReload is called with the new rules after every successful reload
+	OnReload func(rules *RuleSet)
+
+	// OnError is called when the file cannot be read or is invalid; the extractors keep
+	// the rules they had
+	OnError func(err error)
+}
+
+// RulesWatcher keeps the rules of extractors in sync with a rules file, reloading it when
+// it changes or on SIGHUP, so that detection rules are updated without a redeploy. An
+// invalid file is reported and ignored: the last valid rules stay in effect.
+type RulesWatcher struct {
+	path       string
+	extractors []*RegexExtractor
+	opts       WatchOptions
+
+	mu      sync.Mutex
+	rules   *RuleSet
+	modTime time.Time
+	size    int64
+	missing bool // the file could not be found on the last load
+}
+
+// NewRulesWatcher loads the rules file and applies it to the extractors, returning an
+// error when the file is missing or invalid. Call Run to watch for changes.
+func NewRulesWatcher(path string, opts WatchOptions, extractors ...*RegexExtractor) (*RulesWatcher, error) {
+	if opts.PollInterval == 0 {
+		opts.PollInterval = DefaultRulesPollInterval
+	}
+	if opts.Signals == nil {
+		opts.Signals = reloadSignals
+	}
+	w := &RulesWatcher{path: path, extractors: extractors, opts: opts}
+	if err := w.Reload(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Rules returns the rules currently applied
+func (w *RulesWatcher) Rules() *RuleSet {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.rules
+}
+
+// Reload reads the rules file and applies it to the extractors. When the file is invalid,
+// the error is returned and the current rules are kept.
+func (w *RulesWatcher) Reload() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	info, err := os.Stat(w.path)
+	w.missing = err != nil
+	if err != nil {
+		return err
+	}
+	// An invalid version of the file is reported once, not on every poll
+	w.modTime, w.size = info.ModTime(), info.Size()
+	rules, err := LoadRules(w.path)
+	if err != nil {
+		return err
+	}
+	if w.rules != nil && w.rules.checksum == rules.checksum {
+		return nil
+	}
+	w.rules = rules
+	for _, extractor := range w.extractors {
+		extractor.SetRules(rules)
+	}
+	if w.opts.OnReload != nil {
+		w.opts.OnReload(rules)
+	}
+	return nil
+}
+
+// Run reloads the rules whenever the file changes or a reload signal is received, until
+// ctx is done. Reload errors are passed to OnError.
+func (w *RulesWatcher) Run(ctx context.Context) error {
+	signals := make(chan os.Signal, 1)
+	if len(w.opts.Signals) > 0 {
+		signal.Notify(signals, w.opts.Signals...)
+		defer signal.Stop(signals)
+	}
+	var tick <-chan time.Time
+	if w.opts.PollInterval > 0 {
+		ticker := time.NewTicker(w.opts.PollInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-signals:
+			w.report(w.Reload())
+		case <-tick:
+			if w.changed() {
+				w.report(w.Reload())
+			}
+		}
+	}
+}
+
+// changed reports whether the file was modified since the last load. A file that
+// disappears counts as changed once, so that the error is reported.
+func (w *RulesWatcher) changed() bool {
+	info, err := os.Stat(w.path)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err != nil {
+		return !w.missing
+	}
+	return w.missing || !info.ModTime().Equal(w.modTime) || info.Size() != w.size
+}
+
+// report passes a reload error to OnError
+func (w *RulesWatcher) report(err error) {
+	if err != nil && w.opts.OnError != nil {
+		w.opts.OnError(err)
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !unix
+
+package regex
+
+import "os"
+
+// reloadSignals are the signals reloading a RulesWatcher by default; there is no reload
+// signal outside Unix systems
+var reloadSignals = []os.Signal{}
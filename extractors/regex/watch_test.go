@@ -0,0 +1,98 @@
+//go:build unix
+
+package regex
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+func TestRulesWatcher(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.json")
+	if err := os.WriteFile(path, []byte(testRules), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	var reloads int
+	var errs []error
+	extractor := NewDefaultExtractor()
+	watcher, err := NewRulesWatcher(path, WatchOptions{
+		PollInterval: 10 * time.Millisecond,
+		Signals:      []os.Signal{syscall.SIGHUP},
+		OnReload: func(*RuleSet) {
+			mu.Lock()
+			defer mu.Unlock()
+			reloads++
+		},
+		OnError: func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			errs = append(errs, err)
+		},
+	}, extractor)
+	if err != nil {
+		t.Fatalf("NewRulesWatcher() error = %v", err)
+	}
+	if extractor.Rules() != watcher.Rules() {
+		t.Fatal("Expected the rules applied on creation")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go watcher.Run(ctx)
+
+	waitFor := func(what string, done func() bool) {
+		t.Helper()
+		for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(5 * time.Millisecond) {
+			mu.Lock()
+			ok := done()
+			mu.Unlock()
+			if ok {
+				return
+			}
+		}
+		t.Fatalf("Timed out waiting for %s", what)
+	}
+
+	// An invalid file is reported and the last valid rules stay in effect
+	os.WriteFile(path, []byte(`{"version": 1, "rules": [{"name": "broken", "type": "phone", "pattern": "("}]}`), 0o600)
+	waitFor("the invalid file error", func() bool { return len(errs) == 1 })
+	if !strings.Contains(errs[0].Error(), "invalid pattern") {
+		t.Errorf("Unexpected error %v", errs[0])
+	}
+	if result, _ := extractor.Extract("EMP-123456"); !result.HasType(pii.PiiTypeNationalID) {
+		t.Error("Expected the previous rules kept")
+	}
+
+	// A valid change is applied
+	os.WriteFile(path, []byte(`{"version": 1, "rules": [{"name": "ticket", "type": "national_id", "pattern": "\\bTCK-\\d{4}\\b"}]}`), 0o600)
+	waitFor("the reload", func() bool { return reloads == 2 })
+	result, _ := extractor.Extract("EMP-123456 TCK-0042")
+	if ids := result.GetEntitiesByType(pii.PiiTypeNationalID); len(ids) != 1 || ids[0].GetValue() != "TCK-0042" {
+		t.Errorf("Expected the new rules applied, got %+v", ids)
+	}
+
+	// SIGHUP reloads, but unchanged rules are not applied again
+	syscall.Kill(os.Getpid(), syscall.SIGHUP)
+	time.Sleep(50 * time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	if reloads != 2 {
+		t.Errorf("Expected no reload of unchanged rules, got %d reloads", reloads)
+	}
+}
+
+func TestNewRulesWatcher_Invalid(t *testing.T) {
+	if _, err := NewRulesWatcher(filepath.Join(t.TempDir(), "missing.json"), WatchOptions{}); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
@@ -0,0 +1,11 @@
+//go:build unix
+
+package regex
+
+import (
+	"os"
+	"syscall"
+)
+
+// reloadSignals are the signals reloading a RulesWatcher by default
+var reloadSignals = []os.Signal{syscall.SIGHUP}
@@ -1,16 +1,50 @@
 package extractors
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"sort"
 	"sync"
+	"time"
 )
 
-// Registry manages available PII extractors
+// Registry manages available PII extractors and their lifecycle
 type Registry struct {
 	extractors map[string]PiiExtractor
+	failed     bool // the last Start failed
+	closed     bool
 	mu         sync.RWMutex
 }
 
+// Starter is implemented by extractors that need initialization before serving requests
+type Starter interface {
+	Start(ctx context.Context) error
+}
+
+// HealthChecker is implemented by extractors that depend on external services such as LLM providers
+type HealthChecker interface {
+	HealthCheck(ctx context.Context) error
+}
+
+// HealthStatus is the health of a single registered extractor
+type HealthStatus struct {
+	Name    string           `json:"name"`
+	Method  ExtractionMethod `json:"method"`
+	Healthy bool             `json:"healthy"`
+	Error   string           `json:"error,omitempty"`
+	Latency time.Duration    `json:"latency"`
+}
+
+// HealthReport aggregates the health of all registered extractors
+type HealthReport struct {
+	Healthy    bool           `json:"healthy"`
+	Ready      bool           `json:"ready"`
+	Extractors []HealthStatus `json:"extractors"`
+	CheckedAt  time.Time      `json:"checked_at"`
+}
+
 // NewRegistry creates a new extractor registry
 func NewRegistry() *Registry {
 	return &Registry{
@@ -26,6 +60,9 @@ func (r *Registry) Register(name string, extractor PiiExtractor) error {
 	if extractor == nil {
 		return fmt.Errorf("cannot register nil extractor")
 	}
+	if r.closed {
+		return fmt.Errorf("cannot register extractor '%s': registry is closed", name)
+	}
 	
 	r.extractors[name] = extractor
 	return nil
@@ -72,6 +109,127 @@ func (r *Registry) GetByMethod(method ExtractionMethod) []PiiExtractor {
 	return extractors
 }
 
+// Start initializes every registered extractor implementing Starter, in name order.
+// The registry is not ready until a Start succeeds once one failed.
+func (r *Registry) Start(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return fmt.Errorf("registry is closed")
+	}
+
+	for _, name := range r.sortedNames() {
+		if starter, ok := r.extractors[name].(Starter); ok {
+			if err := starter.Start(ctx); err != nil {
+				r.failed = true
+				return fmt.Errorf("failed to start extractor '%s': %w", name, err)
+			}
+		}
+	}
+
+	r.failed = false
+	return nil
+}
+
+// Ready returns true unless the registry is closed or its last Start failed. A registry
+// that was never started, such as the default registry, is ready.
+func (r *Registry) Ready() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return !r.failed && !r.closed
+}
+
+// HealthCheck checks every registered extractor implementing HealthChecker concurrently.
+// Extractors without external dependencies are reported healthy. The returned error
+// joins the failures of all unhealthy extractors.
+func (r *Registry) HealthCheck(ctx context.Context) (*HealthReport, error) {
+	r.mu.RLock()
+	names := r.sortedNames()
+	registered := make([]PiiExtractor, len(names))
+	for i, name := range names {
+		registered[i] = r.extractors[name]
+	}
+	ready := !r.failed && !r.closed
+	r.mu.RUnlock()
+
+	report := &HealthReport{
+		Healthy:    true,
+		Ready:      ready,
+		Extractors: make([]HealthStatus, len(names)),
+		CheckedAt:  time.Now(),
+	}
+
+	var wg sync.WaitGroup
+	for i, extractor := range registered {
+		report.Extractors[i] = HealthStatus{Name: names[i], Method: extractor.GetMethod(), Healthy: true}
+
+		checker, ok := extractor.(HealthChecker)
+		if !ok {
+			continue
+		}
+
+		wg.Add(1)
+		go func(status *HealthStatus, checker HealthChecker) {
+			defer wg.Done()
+			start := time.Now()
+			err := checker.HealthCheck(ctx)
+			status.Latency = time.Since(start)
+			if err != nil {
+				status.Healthy = false
+				status.Error = err.Error()
+			}
+		}(&report.Extractors[i], checker)
+	}
+	wg.Wait()
+
+	var errs []error
+	for _, status := range report.Extractors {
+		if !status.Healthy {
+			report.Healthy = false
+			errs = append(errs, fmt.Errorf("extractor '%s': %s", status.Name, status.Error))
+		}
+	}
+	if !ready {
+		errs = append(errs, fmt.Errorf("registry is not ready"))
+	}
+
+	return report, errors.Join(errs...)
+}
+
+// Close releases every registered extractor implementing io.Closer.
+// The registry rejects new registrations once closed.
+func (r *Registry) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+
+	var errs []error
+	for _, name := range r.sortedNames() {
+		if closer, ok := r.extractors[name].(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				errs = append(errs, fmt.Errorf("failed to close extractor '%s': %w", name, err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// sortedNames returns registered names in a stable order. Callers must hold the lock.
+func (r *Registry) sortedNames() []string {
+	names := make([]string, 0, len(r.extractors))
+	for name := range r.extractors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // Default global registry
 var defaultRegistry = NewRegistry()
 
@@ -93,4 +251,19 @@ func List() []string {
 // GetByMethod returns extractors by method from the default registry
 func GetByMethod(method ExtractionMethod) []PiiExtractor {
 	return defaultRegistry.GetByMethod(method)
-}
\ No newline at end of file
+}
+
+// Start initializes the extractors of the default registry
+func Start(ctx context.Context) error {
+	return defaultRegistry.Start(ctx)
+}
+
+// HealthCheck checks the extractors of the default registry
+func HealthCheck(ctx context.Context) (*HealthReport, error) {
+	return defaultRegistry.HealthCheck(ctx)
+}
+
+// Close releases the extractors of the default registry
+func Close() error {
+	return defaultRegistry.Close()
+}
@@ -0,0 +1,135 @@
+package extractors
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// lifecycleExtractor is a minimal extractor recording lifecycle calls
+type lifecycleExtractor struct {
+	healthErr error
+	startErr  error
+	started   bool
+	closed    bool
+}
+
+func (f *lifecycleExtractor) Extract(text string) (*pii.PiiExtractionResult, error) {
+	return pii.NewPiiExtractionResult(nil), nil
+}
+
+func (f *lifecycleExtractor) ExtractByType(text string, piiType pii.PiiType) ([]pii.PiiEntity, error) {
+	return nil, nil
+}
+
+func (f *lifecycleExtractor) GetSupportedTypes() []pii.PiiType { return nil }
+func (f *lifecycleExtractor) GetMethod() ExtractionMethod      { return MethodLLM }
+func (f *lifecycleExtractor) GetName() string                  { return "lifecycle" }
+
+func (f *lifecycleExtractor) Start(ctx context.Context) error {
+	if f.startErr != nil {
+		return f.startErr
+	}
+	f.started = true
+	return nil
+}
+
+func (f *lifecycleExtractor) HealthCheck(ctx context.Context) error {
+	return f.healthErr
+}
+
+func (f *lifecycleExtractor) Close() error {
+	f.closed = true
+	return nil
+}
+
+func TestRegistryLifecycle(t *testing.T) {
+	registry := NewRegistry()
+	extractor := &lifecycleExtractor{}
+	if err := registry.Register("llm", extractor); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	// A registry that was never started, such as the default registry, is ready
+	if report, err := registry.HealthCheck(context.Background()); err != nil || !report.Ready {
+		t.Errorf("Expected a registry that was never started to be ready, got %+v, %v", report, err)
+	}
+
+	if err := registry.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if !extractor.started || !registry.Ready() {
+		t.Error("Expected the extractor to be started and the registry ready")
+	}
+
+	report, err := registry.HealthCheck(context.Background())
+	if err != nil {
+		t.Fatalf("HealthCheck() error = %v", err)
+	}
+	if !report.Healthy || !report.Ready || len(report.Extractors) != 1 {
+		t.Errorf("Unexpected health report: %+v", report)
+	}
+
+	if err := registry.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if !extractor.closed || registry.Ready() {
+		t.Error("Expected the extractor to be closed and the registry not ready")
+	}
+	if err := registry.Register("other", &lifecycleExtractor{}); err == nil {
+		t.Error("Expected Register to fail on a closed registry")
+	}
+}
+
+func TestRegistryHealthCheckAggregatesFailures(t *testing.T) {
+	registry := NewRegistry()
+	providerErr := errors.New("provider unavailable")
+	registry.Register("healthy", &lifecycleExtractor{})
+	registry.Register("failing", &lifecycleExtractor{healthErr: providerErr})
+	registry.Start(context.Background())
+
+	report, err := registry.HealthCheck(context.Background())
+	if err == nil {
+		t.Fatal("Expected HealthCheck to return an error")
+	}
+	if report.Healthy {
+		t.Error("Expected report to be unhealthy")
+	}
+
+	// Extractors are reported in name order
+	if report.Extractors[0].Name != "failing" || report.Extractors[0].Healthy {
+		t.Errorf("Expected 'failing' to be unhealthy, got %+v", report.Extractors[0])
+	}
+	if report.Extractors[0].Error != providerErr.Error() {
+		t.Errorf("Expected error %q, got %q", providerErr, report.Extractors[0].Error)
+	}
+	if !report.Extractors[1].Healthy {
+		t.Errorf("Expected 'healthy' to be healthy, got %+v", report.Extractors[1])
+	}
+}
+
+func TestRegistryFailedStartNotReady(t *testing.T) {
+	registry := NewRegistry()
+	extractor := &lifecycleExtractor{startErr: errors.New("model not loaded")}
+	registry.Register("llm", extractor)
+
+	if err := registry.Start(context.Background()); err == nil {
+		t.Fatal("Expected Start to fail")
+	}
+	if registry.Ready() {
+		t.Error("Expected the registry not to be ready after a failed Start")
+	}
+	if _, err := registry.HealthCheck(context.Background()); err == nil {
+		t.Error("Expected HealthCheck to fail after a failed Start")
+	}
+
+	extractor.startErr = nil
+	if err := registry.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if !registry.Ready() {
+		t.Error("Expected the registry to be ready once started")
+	}
+}
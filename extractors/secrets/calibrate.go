@@ -0,0 +1,98 @@
+package secrets
+
+// maxExamples caps the false positive values kept in a CalibrationReport
+const maxExamples = 20
+
+// Sample is a labelled document of a calibration corpus. Secrets lists the values that are
+// real secrets; every other finding in Text counts as a false positive.
+type Sample struct {
+	Text    string
+	Secrets []string
+}
+
+// CalibrationReport summarizes detector accuracy on a labelled corpus
+type CalibrationReport struct {
+	EntropyThreshold     float64  `json:"entropy_threshold"`
+	Documents            int      `json:"documents"`
+	Candidates           int      `json:"candidates"` // Values of acceptable length evaluated
+	Findings             int      `json:"findings"`
+	TruePositives        int      `json:"true_positives"`
+	FalsePositives       int      `json:"false_positives"`
+	FalseNegatives       int      `json:"false_negatives"`
+	FalsePositiveRate    float64  `json:"false_positive_rate"` // FalsePositives / candidates that are not secrets
+	Precision            float64  `json:"precision"`
+	Recall               float64  `json:"recall"`
+	FalsePositiveSamples []string `json:"false_positive_samples,omitempty"`
+}
+
+// Calibrate runs a detector built from config over the samples and reports its false
+// positive rate, precision and recall. Use a corpus representative of the codebase or logs
+// being scanned, since thresholds do not transfer well between sources.
+func Calibrate(config *Config, samples []Sample) *CalibrationReport {
+	detector := NewDetector(config)
+	report := &CalibrationReport{
+		EntropyThreshold: detector.config.EntropyThreshold,
+		Documents:        len(samples),
+	}
+
+	labelledSecrets := 0
+	negatives := 0
+	for _, sample := range samples {
+		secrets := make(map[string]bool, len(sample.Secrets))
+		for _, secret := range sample.Secrets {
+			secrets[secret] = true
+		}
+		labelledSecrets += len(secrets)
+
+		for _, candidate := range detector.candidates(sample.Text) {
+			report.Candidates++
+			if !secrets[sample.Text[candidate[0]:candidate[1]]] {
+				negatives++
+			}
+		}
+
+		found := make(map[string]bool)
+		for _, finding := range detector.Find(sample.Text) {
+			value := sample.Text[finding.Start:finding.End]
+			report.Findings++
+			if secrets[value] {
+				if !found[value] {
+					report.TruePositives++
+					found[value] = true
+				}
+				continue
+			}
+			report.FalsePositives++
+			if len(report.FalsePositiveSamples) < maxExamples {
+				report.FalsePositiveSamples = append(report.FalsePositiveSamples, value)
+			}
+		}
+	}
+
+	report.FalseNegatives = labelledSecrets - report.TruePositives
+	if negatives > 0 {
+		report.FalsePositiveRate = float64(report.FalsePositives) / float64(negatives)
+	}
+	if report.TruePositives+report.FalsePositives > 0 {
+		report.Precision = float64(report.TruePositives) / float64(report.TruePositives+report.FalsePositives)
+	}
+	if labelledSecrets > 0 {
+		report.Recall = float64(report.TruePositives) / float64(labelledSecrets)
+	}
+	return report
+}
+
+// SweepEntropy calibrates config at each entropy threshold, to pick the threshold with an
+// acceptable false positive rate. Other parameters are taken from config.
+func SweepEntropy(config *Config, samples []Sample, thresholds []float64) []*CalibrationReport {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	reports := make([]*CalibrationReport, 0, len(thresholds))
+	for _, threshold := range thresholds {
+		trial := *config
+		trial.EntropyThreshold = threshold
+		reports = append(reports, Calibrate(&trial, samples))
+	}
+	return reports
+}
@@ -0,0 +1,211 @@
+// Package secrets detects high-entropy credentials such as API keys, generated passwords and
+// passphrases. Thresholds are tunable per corpus and can be calibrated on labelled samples.
+package secrets
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// CharsetClass is a set of character classes counted by MinCharsetClasses
+type CharsetClass int
+
+const (
+	CharsetLower CharsetClass = 1 << iota
+	CharsetUpper
+	CharsetDigit
+	CharsetSymbol
+)
+
+// CharsetAll counts every character class
+const CharsetAll = CharsetLower | CharsetUpper | CharsetDigit | CharsetSymbol
+
+// DefaultContextKeywords are the keywords that make a nearby value more likely to be a secret
+var DefaultContextKeywords = []string{
+	"password", "passwd", "passphrase", "pwd", "secret", "token", "api_key", "apikey",
+	"api-key", "access_key", "private_key", "client_secret", "credential", "auth",
+}
+
+// Config holds the tunable parameters of the detector
+type Config struct {
+	// EntropyThreshold is the minimum Shannon entropy, in bits per character, of a secret
+	EntropyThreshold float64
+	// KeywordEntropyThreshold replaces EntropyThreshold when a context keyword precedes the value
+	KeywordEntropyThreshold float64
+	// MinLength and MaxLength bound the length of a candidate value
+	MinLength int
+	MaxLength int
+	// Charsets selects the character classes counted towards MinCharsetClasses
+	Charsets CharsetClass
+	// MinCharsetClasses is the number of distinct counted classes a secret must mix
+	MinCharsetClasses int
+	// ContextKeywords are matched case-insensitively in the KeywordWindow bytes before a value
+	ContextKeywords []string
+	KeywordWindow   int
+	// RequireKeyword only reports values preceded by a context keyword
+	RequireKeyword bool
+}
+
+// DefaultConfig returns thresholds suited to source code and application logs
+func DefaultConfig() *Config {
+	return &Config{
+		EntropyThreshold:        4.0,
+		KeywordEntropyThreshold: 3.0,
+		MinLength:               20,
+		MaxLength:               256,
+		Charsets:                CharsetAll,
+		MinCharsetClasses:       2,
+		ContextKeywords:         DefaultContextKeywords,
+		KeywordWindow:           32,
+	}
+}
+
+// Finding is a secret found at text[Start:End]
+type Finding struct {
+	Start   int
+	End     int
+	Entropy float64
+	Keyword string // Context keyword found before the value, if any
+}
+
+// Detector finds secrets using a Config
+type Detector struct {
+	config *Config
+}
+
+// NewDetector creates a detector. A nil config uses DefaultConfig.
+func NewDetector(config *Config) *Detector {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	return &Detector{config: config}
+}
+
+// Config returns the detector configuration
+func (d *Detector) Config() *Config {
+	return d.config
+}
+
+// Find returns the secrets found in text, in order of appearance
+func (d *Detector) Find(text string) []Finding {
+	var findings []Finding
+	for _, candidate := range d.candidates(text) {
+		if finding, ok := d.evaluate(text, candidate[0], candidate[1]); ok {
+			findings = append(findings, finding)
+		}
+	}
+	return findings
+}
+
+// candidates splits text into values of acceptable length. Values are delimited by whitespace,
+// quotes, brackets and the separators of key=value and key: value assignments.
+func (d *Detector) candidates(text string) [][2]int {
+	var spans [][2]int
+	start := -1
+	flush := func(end int) {
+		if start < 0 {
+			return
+		}
+		// Trailing sentence punctuation is not part of the value
+		for end > start && strings.ContainsRune(".,;!?", rune(text[end-1])) {
+			end--
+		}
+		if length := end - start; length >= d.config.MinLength && (d.config.MaxLength <= 0 || length <= d.config.MaxLength) {
+			spans = append(spans, [2]int{start, end})
+		}
+		start = -1
+	}
+
+	for i, r := range text {
+		if unicode.IsSpace(r) || strings.ContainsRune("\"'`,;()[]{}<>=:", r) {
+			flush(i)
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	flush(len(text))
+	return spans
+}
+
+// evaluate applies the entropy, charset and keyword rules to text[start:end]
+func (d *Detector) evaluate(text string, start, end int) (Finding, bool) {
+	value := text[start:end]
+	keyword := d.keywordBefore(text, start)
+	if d.config.RequireKeyword && keyword == "" {
+		return Finding{}, false
+	}
+	if CharsetClasses(value, d.config.Charsets) < d.config.MinCharsetClasses {
+		return Finding{}, false
+	}
+
+	threshold := d.config.EntropyThreshold
+	if keyword != "" && d.config.KeywordEntropyThreshold > 0 {
+		threshold = d.config.KeywordEntropyThreshold
+	}
+	entropy := Entropy(value)
+	if entropy < threshold {
+		return Finding{}, false
+	}
+	return Finding{Start: start, End: end, Entropy: entropy, Keyword: keyword}, true
+}
+
+// keywordBefore returns the first context keyword in the window preceding start
+func (d *Detector) keywordBefore(text string, start int) string {
+	windowStart := start - d.config.KeywordWindow
+	if windowStart < 0 {
+		windowStart = 0
+	}
+	window := strings.ToLower(text[windowStart:start])
+	for _, keyword := range d.config.ContextKeywords {
+		if strings.Contains(window, strings.ToLower(keyword)) {
+			return keyword
+		}
+	}
+	return ""
+}
+
+// Entropy returns the Shannon entropy of s in bits per character
+func Entropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+	counts := make(map[rune]int)
+	total := 0
+	for _, r := range s {
+		counts[r]++
+		total++
+	}
+	var entropy float64
+	for _, count := range counts {
+		p := float64(count) / float64(total)
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// CharsetClasses returns how many of the selected character classes occur in s
+func CharsetClasses(s string, charsets CharsetClass) int {
+	var found CharsetClass
+	for _, r := range s {
+		switch {
+		case unicode.IsLower(r):
+			found |= CharsetLower
+		case unicode.IsUpper(r):
+			found |= CharsetUpper
+		case unicode.IsDigit(r):
+			found |= CharsetDigit
+		default:
+			found |= CharsetSymbol
+		}
+	}
+	classes := 0
+	for class := CharsetLower; class <= CharsetSymbol; class <<= 1 {
+		if found&charsets&class != 0 {
+			classes++
+		}
+	}
+	return classes
+}
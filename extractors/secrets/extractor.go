@@ -0,0 +1,94 @@
+package secrets
+
+import (
+	"github.com/intMeric/pii-extractor/extractors"
+	patterns "github.com/intMeric/pii-extractor/extractors/regex/patterns"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// Extractor reports detected secrets as PiiTypeSecret entities. Values are masked, and every
+// secret found is also masked in the contexts.
+type Extractor struct {
+	name     string
+	detector *Detector
+}
+
+// NewExtractor creates a secrets extractor. A nil config uses DefaultConfig.
+func NewExtractor(config *Config) *Extractor {
+	return &Extractor{
+		name:     "secrets-extractor",
+		detector: NewDetector(config),
+	}
+}
+
+// Extract performs secret detection on the given text
+func (e *Extractor) Extract(text string) (*pii.PiiExtractionResult, error) {
+	return pii.NewPiiExtractionResult(e.extract(text)), nil
+}
+
+// ExtractByType extracts secrets when piiType is PiiTypeSecret
+func (e *Extractor) ExtractByType(text string, piiType pii.PiiType) ([]pii.PiiEntity, error) {
+	if piiType != pii.PiiTypeSecret {
+		return []pii.PiiEntity{}, nil
+	}
+	return e.extract(text), nil
+}
+
+// extract converts detector findings into masked secret entities
+func (e *Extractor) extract(text string) []pii.PiiEntity {
+	findings := e.detector.Find(text)
+	rawSecrets := make([]string, 0, len(findings))
+	for _, finding := range findings {
+		rawSecrets = append(rawSecrets, text[finding.Start:finding.End])
+	}
+
+	secretMap := make(map[string]*pii.Secret, len(findings))
+	var order []string
+	locator := pii.NewLocator(text)
+	for i, finding := range findings {
+		raw := rawSecrets[i]
+		context := pii.MaskSessionTokens(patterns.ExtractContext(text, finding.Start, finding.End), rawSecrets)
+		location := locator.Locate(finding.Start, finding.End)
+
+		if secret, exists := secretMap[raw]; exists {
+			secret.BasePii.IncrementCount()
+			secret.BasePii.AddContext(context)
+			secret.BasePii.AddLocation(location)
+			continue
+		}
+		secret := pii.NewSecret(raw, finding.Entropy, finding.Keyword)
+		secret.Contexts = []string{context}
+		secret.Locations = []pii.Location{location}
+		secretMap[raw] = &secret
+		order = append(order, raw)
+	}
+
+	entities := make([]pii.PiiEntity, 0, len(order))
+	for _, raw := range order {
+		entities = append(entities, pii.PiiEntity{
+			Type:  pii.PiiTypeSecret,
+			Value: *secretMap[raw],
+		})
+	}
+	return entities
+}
+
+// GetSupportedTypes returns the list of PII types this extractor can handle
+func (e *Extractor) GetSupportedTypes() []pii.PiiType {
+	return []pii.PiiType{pii.PiiTypeSecret}
+}
+
+// GetMethod returns the extraction method used by this extractor
+func (e *Extractor) GetMethod() extractors.ExtractionMethod {
+	return extractors.MethodRegex
+}
+
+// GetName returns the name of this extractor
+func (e *Extractor) GetName() string {
+	return e.name
+}
+
+// Detector returns the detector used by this extractor
+func (e *Extractor) Detector() *Detector {
+	return e.detector
+}
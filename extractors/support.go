@@ -0,0 +1,85 @@
+package extractors
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// Support is one cell of a support matrix: a PII type detected for a country by some
+// extraction methods
+type Support struct {
+	Type    pii.PiiType        `json:"type"`
+	Country pii.Country        `json:"country,omitempty"` // empty when detected regardless of country
+	Methods []ExtractionMethod `json:"methods"`
+}
+
+// SupportReporter is implemented by extractors that detect some types only for some
+// countries, such as the regex extractor with its country packs. Extractors that do not
+// implement it support their GetSupportedTypes regardless of country.
+type SupportReporter interface {
+	// Support returns the types and countries detected with the current configuration
+	Support() []Support
+}
+
+// SupportOf returns the support of one extractor
+func SupportOf(extractor PiiExtractor) []Support {
+	if reporter, ok := extractor.(SupportReporter); ok {
+		return reporter.Support()
+	}
+	method := extractor.GetMethod()
+	var support []Support
+	for _, piiType := range extractor.GetSupportedTypes() {
+		support = append(support, Support{Type: piiType, Methods: []ExtractionMethod{method}})
+	}
+	return support
+}
+
+// SupportMatrix merges the support of the extractors into one cell per type and country,
+// sorted by type then country, with the methods of each cell sorted and deduplicated
+func SupportMatrix(extractors ...PiiExtractor) []Support {
+	var matrix []Support
+	for _, extractor := range extractors {
+		matrix = append(matrix, SupportOf(extractor)...)
+	}
+	return MergeSupport(matrix)
+}
+
+// MergeSupport merges cells of the same type and country, as SupportMatrix does
+func MergeSupport(cells []Support) []Support {
+	type key struct {
+		piiType pii.PiiType
+		country pii.Country
+	}
+	index := make(map[key]int)
+	var merged []Support
+	for _, cell := range cells {
+		k := key{cell.Type, cell.Country}
+		i, ok := index[k]
+		if !ok {
+			i = len(merged)
+			index[k] = i
+			merged = append(merged, Support{Type: cell.Type, Country: cell.Country})
+		}
+		merged[i].Methods = append(merged[i].Methods, cell.Methods...)
+	}
+	for i := range merged {
+		slices.Sort(merged[i].Methods)
+		merged[i].Methods = slices.Compact(merged[i].Methods)
+	}
+	slices.SortFunc(merged, func(a, b Support) int {
+		return cmp.Or(cmp.Compare(a.Type, b.Type), cmp.Compare(a.Country, b.Country))
+	})
+	return merged
+}
+
+// WithMethod returns a copy of the cells attributed to a single method, for extractors
+// that run another extractor's detection under their own method
+func WithMethod(cells []Support, method ExtractionMethod) []Support {
+	relabeled := make([]Support, len(cells))
+	for i, cell := range cells {
+		relabeled[i] = Support{Type: cell.Type, Country: cell.Country, Methods: []ExtractionMethod{method}}
+	}
+	return relabeled
+}
@@ -0,0 +1,54 @@
+package extractors
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// typesExtractor supports a fixed list of types regardless of country
+type typesExtractor struct {
+	lifecycleExtractor
+	method ExtractionMethod
+	types  []pii.PiiType
+}
+
+func (f *typesExtractor) GetSupportedTypes() []pii.PiiType { return f.types }
+func (f *typesExtractor) GetMethod() ExtractionMethod      { return f.method }
+
+// countryExtractor reports its support per country
+type countryExtractor struct {
+	typesExtractor
+	support []Support
+}
+
+func (f *countryExtractor) Support() []Support { return f.support }
+
+func TestSupportMatrix(t *testing.T) {
+	llm := &typesExtractor{method: MethodLLM, types: []pii.PiiType{pii.PiiTypeEmail, pii.PiiTypePhone}}
+	regex := &countryExtractor{support: []Support{
+		{Type: pii.PiiTypePhone, Country: pii.CountryUS, Methods: []ExtractionMethod{MethodRegex}},
+		{Type: pii.PiiTypeEmail, Methods: []ExtractionMethod{MethodRegex}},
+	}}
+
+	matrix := SupportMatrix(regex, llm, WithMiddleware(regex))
+	want := []Support{
+		{Type: pii.PiiTypePhone, Methods: []ExtractionMethod{MethodLLM}},
+		{Type: pii.PiiTypePhone, Country: pii.CountryUS, Methods: []ExtractionMethod{MethodRegex}},
+		{Type: pii.PiiTypeEmail, Methods: []ExtractionMethod{MethodLLM, MethodRegex}},
+	}
+	if !slices.EqualFunc(matrix, want, func(a, b Support) bool {
+		return a.Type == b.Type && a.Country == b.Country && slices.Equal(a.Methods, b.Methods)
+	}) {
+		t.Errorf("SupportMatrix = %+v, want %+v", matrix, want)
+	}
+
+	hybrid := WithMethod(SupportOf(regex), MethodHybrid)
+	if len(hybrid) != 2 || !slices.Equal(hybrid[0].Methods, []ExtractionMethod{MethodHybrid}) {
+		t.Errorf("WithMethod = %+v", hybrid)
+	}
+	if regex.support[0].Methods[0] != MethodRegex {
+		t.Error("WithMethod modified the cells")
+	}
+}
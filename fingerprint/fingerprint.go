@@ -0,0 +1,203 @@
+package fingerprint
+
+import (
+	"hash/fnv"
+	"math/bits"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// DefaultThreshold is the maximum Hamming distance between two fingerprints
+// for the documents to be considered near-duplicates
+const DefaultThreshold = 3
+
+// shingleSize is the number of consecutive words hashed together
+const shingleSize = 3
+
+// SimHash computes a 64-bit SimHash fingerprint of the text using word shingles.
+// Near-duplicate texts produce fingerprints with a small Hamming distance.
+func SimHash(text string) uint64 {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r) && r != '@' && r != '.'
+	})
+	trimmed := words[:0]
+	for _, word := range words {
+		if word = strings.Trim(word, "."); word != "" {
+			trimmed = append(trimmed, word)
+		}
+	}
+	words = trimmed
+	if len(words) == 0 {
+		return 0
+	}
+
+	var weights [64]int
+	addFeature := func(feature string) {
+		h := fnv.New64a()
+		h.Write([]byte(feature))
+		sum := h.Sum64()
+		for i := 0; i < 64; i++ {
+			if sum&(1<<uint(i)) != 0 {
+				weights[i]++
+			} else {
+				weights[i]--
+			}
+		}
+	}
+
+	if len(words) < shingleSize {
+		addFeature(strings.Join(words, " "))
+	} else {
+		for i := 0; i+shingleSize <= len(words); i++ {
+			addFeature(strings.Join(words[i:i+shingleSize], " "))
+		}
+	}
+
+	var fingerprint uint64
+	for i := 0; i < 64; i++ {
+		if weights[i] > 0 {
+			fingerprint |= 1 << uint(i)
+		}
+	}
+	return fingerprint
+}
+
+// Distance returns the Hamming distance between two fingerprints
+func Distance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// document holds a fingerprinted document and the cluster it belongs to
+type document struct {
+	id          string
+	fingerprint uint64
+	cluster     *cluster
+}
+
+// cluster groups near-duplicate documents and their merged findings
+type cluster struct {
+	entities map[string]pii.PiiEntity
+	order    []string
+}
+
+// Aggregator collects extraction results across documents, merging the findings of
+// near-duplicate documents (retries, quoted email threads) so they are counted once
+type Aggregator struct {
+	threshold int
+	documents []document
+	clusters  []*cluster
+	mu        sync.Mutex
+}
+
+// NewAggregator creates a new aggregator using the given Hamming distance threshold.
+// A negative threshold uses DefaultThreshold.
+func NewAggregator(threshold int) *Aggregator {
+	if threshold < 0 {
+		threshold = DefaultThreshold
+	}
+	return &Aggregator{
+		threshold: threshold,
+	}
+}
+
+// Add records the extraction result of a document. It returns the ID of the document
+// it was merged into when the text is a near-duplicate of a previously added document.
+func (a *Aggregator) Add(id, text string, result *pii.PiiExtractionResult) (duplicateOf string, isDuplicate bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	fingerprint := SimHash(text)
+
+	var target *cluster
+	for _, doc := range a.documents {
+		if Distance(doc.fingerprint, fingerprint) <= a.threshold {
+			target = doc.cluster
+			duplicateOf = doc.id
+			isDuplicate = true
+			break
+		}
+	}
+
+	if target == nil {
+		target = &cluster{
+			entities: make(map[string]pii.PiiEntity),
+		}
+		a.clusters = append(a.clusters, target)
+	}
+
+	a.documents = append(a.documents, document{
+		id:          id,
+		fingerprint: fingerprint,
+		cluster:     target,
+	})
+
+	if result == nil {
+		return duplicateOf, isDuplicate
+	}
+
+	for _, entity := range result.Entities {
+		key := entityKey(entity)
+		if _, exists := target.entities[key]; exists {
+			// The finding is already accounted for by the original document
+			continue
+		}
+		target.entities[key] = entity
+		target.order = append(target.order, key)
+	}
+
+	return duplicateOf, isDuplicate
+}
+
+// Result returns the aggregated extraction result across all distinct documents
+func (a *Aggregator) Result() *pii.PiiExtractionResult {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var entities []pii.PiiEntity
+	for _, c := range a.clusters {
+		for _, key := range c.order {
+			entities = append(entities, c.entities[key])
+		}
+	}
+	return pii.NewPiiExtractionResult(entities)
+}
+
+// Clusters returns the document IDs grouped by near-duplicate cluster.
+// The first ID in each group is the document the others were merged into.
+func (a *Aggregator) Clusters() [][]string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	index := make(map[*cluster]int, len(a.clusters))
+	groups := make([][]string, len(a.clusters))
+	for i, c := range a.clusters {
+		index[c] = i
+	}
+	for _, doc := range a.documents {
+		i := index[doc.cluster]
+		groups[i] = append(groups[i], doc.id)
+	}
+	return groups
+}
+
+// DocumentCount returns the number of documents added to the aggregator
+func (a *Aggregator) DocumentCount() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.documents)
+}
+
+// DistinctCount returns the number of distinct (non-duplicate) documents
+func (a *Aggregator) DistinctCount() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.clusters)
+}
+
+// entityKey creates a unique key for an entity based on type and value
+func entityKey(entity pii.PiiEntity) string {
+	return entity.Type.String() + ":" + entity.GetValue()
+}
@@ -0,0 +1,59 @@
+package fingerprint
+
+import (
+	"testing"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+const original = `Hello team, please contact John at john.doe@example.com regarding the invoice.
+He asked that we call him back before Friday to confirm the delivery address
+and the payment schedule for the next quarter. Thanks, Mary`
+
+const quoted = `> Hello team, please contact John at john.doe@example.com regarding the invoice.
+> He asked that we call him back before Friday to confirm the delivery address
+> and the payment schedule for the next quarter. Thanks, Mary.`
+
+const unrelated = `The quarterly report shows revenue growth in every region, with the
+strongest results coming from the enterprise segment and new partnerships in Asia.`
+
+func TestSimHash_NearDuplicates(t *testing.T) {
+	if d := Distance(SimHash(original), SimHash(quoted)); d > DefaultThreshold {
+		t.Errorf("Expected near-duplicate distance <= %d, got %d", DefaultThreshold, d)
+	}
+	if d := Distance(SimHash(original), SimHash(unrelated)); d <= DefaultThreshold {
+		t.Errorf("Expected unrelated texts to be distant, got %d", d)
+	}
+}
+
+func TestAggregator_MergesDuplicates(t *testing.T) {
+	email := pii.PiiEntity{Type: pii.PiiTypeEmail, Value: pii.NewEmail("john.doe@example.com")}
+	phone := pii.PiiEntity{Type: pii.PiiTypePhone, Value: pii.NewPhoneUS("555-123-4567")}
+
+	aggregator := NewAggregator(-1)
+
+	if _, dup := aggregator.Add("doc-1", original, pii.NewPiiExtractionResult([]pii.PiiEntity{email})); dup {
+		t.Fatal("First document should not be a duplicate")
+	}
+
+	duplicateOf, dup := aggregator.Add("doc-2", quoted, pii.NewPiiExtractionResult([]pii.PiiEntity{email, phone}))
+	if !dup || duplicateOf != "doc-1" {
+		t.Fatalf("Expected doc-2 to duplicate doc-1, got %q, %v", duplicateOf, dup)
+	}
+
+	aggregator.Add("doc-3", unrelated, pii.NewPiiExtractionResult([]pii.PiiEntity{email}))
+
+	result := aggregator.Result()
+	if result.Total != 2 {
+		t.Fatalf("Expected 2 entities, got %d", result.Total)
+	}
+	for _, entity := range result.GetEmails() {
+		if entity.GetCount() != 2 {
+			t.Errorf("Expected email counted once per distinct document (2), got %d", entity.GetCount())
+		}
+	}
+
+	if aggregator.DocumentCount() != 3 || aggregator.DistinctCount() != 2 {
+		t.Errorf("Expected 3 documents in 2 clusters, got %d in %d", aggregator.DocumentCount(), aggregator.DistinctCount())
+	}
+}
@@ -0,0 +1,234 @@
+// Package goldentest runs an extractor over a corpus of documents and compares its
+// findings with recorded golden snapshots. Pointed at their own corpora, users of this
+// module get contract tests for detection behavior that fail when an upgrade changes
+// what is found:
+//
+//	func TestDetectionContract(t *testing.T) {
+//		goldentest.Run(t, "testdata/corpus", regex.NewDefaultExtractor())
+//	}
+//
+// Every file of the corpus directory, recursively, is a document, except the snapshots
+// themselves: the snapshot of "tickets/42.txt" is "tickets/42.txt.golden.json". Run the
+// tests with GOLDENTEST_UPDATE=1 to record or refresh the snapshots, then review them
+// like code.
+package goldentest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/intMeric/pii-extractor/extractors"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// SnapshotSuffix is appended to the name of a document to name its snapshot
+const SnapshotSuffix = ".golden.json"
+
+// UpdateEnv is the environment variable that records snapshots instead of comparing
+// them when set to a true value such as 1
+const UpdateEnv = "GOLDENTEST_UPDATE"
+
+// Snapshot is the recorded findings of one document. It holds only what identifies a
+// detection, so that it is stable across runs: no entity IDs, contexts or versions.
+type Snapshot struct {
+	Findings []Finding `json:"findings"`
+}
+
+// Finding is one distinct value found in a document
+type Finding struct {
+	Type    pii.PiiType `json:"type"`
+	Value   string      `json:"value"`
+	Country pii.Country `json:"country,omitempty"`
+	Count   int         `json:"count"`
+}
+
+// key identifies a finding regardless of its count
+func (f Finding) key() string {
+	return f.Type.String() + "\x00" + f.Value + "\x00" + string(f.Country)
+}
+
+// String returns a short description of the finding
+func (f Finding) String() string {
+	if f.Country == "" {
+		return fmt.Sprintf("%s %q", f.Type, f.Value)
+	}
+	return fmt.Sprintf("%s %q (%s)", f.Type, f.Value, f.Country)
+}
+
+// NewSnapshot returns the snapshot of a result, with findings sorted by type, value and
+// country
+func NewSnapshot(result *pii.PiiExtractionResult) Snapshot {
+	snapshot := Snapshot{Findings: []Finding{}}
+	if result == nil {
+		return snapshot
+	}
+	for _, entity := range result.Entities {
+		snapshot.Findings = append(snapshot.Findings, Finding{
+			Type:    entity.Type,
+			Value:   entity.GetValue(),
+			Country: pii.EntityCountry(entity),
+			Count:   entity.GetCount(),
+		})
+	}
+	slices.SortFunc(snapshot.Findings, func(a, b Finding) int {
+		return strings.Compare(a.key(), b.key())
+	})
+	return snapshot
+}
+
+// Diff describes the differences between a recorded snapshot and the current one, one
+// line per finding, or returns an empty string when they match
+func Diff(want, got Snapshot) string {
+	wanted := make(map[string]Finding)
+	for _, finding := range want.Findings {
+		wanted[finding.key()] = finding
+	}
+	var lines []string
+	found := make(map[string]bool)
+	for _, finding := range got.Findings {
+		key := finding.key()
+		found[key] = true
+		previous, ok := wanted[key]
+		switch {
+		case !ok:
+			lines = append(lines, "+ "+finding.String())
+		case previous.Count != finding.Count:
+			lines = append(lines, fmt.Sprintf("~ %s: count %d, was %d", finding, finding.Count, previous.Count))
+		}
+	}
+	for _, finding := range want.Findings {
+		if !found[finding.key()] {
+			lines = append(lines, "- "+finding.String())
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Option configures Run
+type Option func(*config)
+
+type config struct {
+	update bool
+}
+
+// WithUpdate records the snapshots instead of comparing them, overriding UpdateEnv
+func WithUpdate(update bool) Option {
+	return func(c *config) {
+		c.update = update
+	}
+}
+
+// Run extracts every document of the corpus directory in its own subtest, named after
+// the document path, and fails the subtests whose findings differ from their snapshot.
+// Documents without a snapshot fail until it is recorded.
+func Run(t *testing.T, dir string, extractor extractors.PiiExtractor, opts ...Option) {
+	t.Helper()
+	cfg := config{update: updateFromEnv()}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	documents, err := Documents(dir)
+	if err != nil {
+		t.Fatalf("goldentest: %v", err)
+	}
+	if len(documents) == 0 {
+		t.Fatalf("goldentest: no documents in %s", dir)
+	}
+	for _, document := range documents {
+		t.Run(filepath.ToSlash(document), func(t *testing.T) {
+			if err := Check(filepath.Join(dir, document), extractor, cfg.update); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// Documents returns the paths of the documents of a corpus directory, relative to it and
+// sorted. Snapshots and hidden files and directories are skipped.
+func Documents(dir string) ([]string, error) {
+	var documents []string
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != dir && strings.HasPrefix(entry.Name(), ".") {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() || strings.HasSuffix(entry.Name(), SnapshotSuffix) {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		documents = append(documents, rel)
+		return nil
+	})
+	return documents, err
+}
+
+// ErrNoSnapshot is returned by Check when a document has no snapshot yet
+var ErrNoSnapshot = errors.New("no snapshot recorded")
+
+// Check extracts one document and compares its findings with its snapshot, or records
+// the snapshot when update is true
+func Check(document string, extractor extractors.PiiExtractor, update bool) error {
+	text, err := os.ReadFile(document)
+	if err != nil {
+		return err
+	}
+	result, err := extractor.Extract(string(text))
+	if err != nil {
+		return fmt.Errorf("extraction failed: %w", err)
+	}
+	got := NewSnapshot(result)
+
+	path := document + SnapshotSuffix
+	if update {
+		data, err := json.MarshalIndent(got, "", "  ")
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(path, append(data, '\n'), 0o644)
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%w for %s (run with %s=1 to record it)", ErrNoSnapshot, document, UpdateEnv)
+	}
+	if err != nil {
+		return err
+	}
+	var want Snapshot
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&want); err != nil {
+		return fmt.Errorf("invalid snapshot %s: %w", path, err)
+	}
+	if diff := Diff(want, got); diff != "" {
+		return fmt.Errorf("findings of %s changed (- missing, + new, ~ count):\n%s", document, diff)
+	}
+	return nil
+}
+
+// updateFromEnv reports whether UpdateEnv asks for snapshots to be recorded
+func updateFromEnv() bool {
+	switch strings.ToLower(os.Getenv(UpdateEnv)) {
+	case "1", "true", "yes":
+		return true
+	default:
+		return false
+	}
+}
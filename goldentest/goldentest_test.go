@@ -0,0 +1,75 @@
+package goldentest
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/intMeric/pii-extractor/extractors/regex"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+func TestRun(t *testing.T) {
+	Run(t, "testdata/corpus", regex.NewDefaultExtractor())
+}
+
+func TestDocuments(t *testing.T) {
+	documents, err := Documents("testdata/corpus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"contact.txt", filepath.Join("tickets", "42.txt")}
+	if strings.Join(documents, ",") != strings.Join(want, ",") {
+		t.Errorf("Documents() = %v, want %v", documents, want)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	dir := t.TempDir()
+	document := filepath.Join(dir, "doc.txt")
+	os.WriteFile(document, []byte("Mail john@acme.com, SSN 123-45-6789"), 0o644)
+	extractor := regex.NewDefaultExtractor()
+
+	if err := Check(document, extractor, false); !errors.Is(err, ErrNoSnapshot) {
+		t.Fatalf("Expected ErrNoSnapshot, got %v", err)
+	}
+	if err := Check(document, extractor, true); err != nil {
+		t.Fatalf("Check() recording error = %v", err)
+	}
+	if err := Check(document, extractor, false); err != nil {
+		t.Fatalf("Check() after recording error = %v", err)
+	}
+
+	// A behavior change is reported finding by finding
+	os.WriteFile(document, []byte("Mail john@acme.com and john@acme.com, card 4111 1111 1111 1111"), 0o644)
+	err := Check(document, extractor, false)
+	if err == nil {
+		t.Fatal("Expected the changed findings reported")
+	}
+	for _, line := range []string{`- ssn "123-45-6789" (US)`, `+ credit_card "4111 1111 1111 1111"`, `~ email "john@acme.com": count 2, was 1`} {
+		if !strings.Contains(err.Error(), line) {
+			t.Errorf("Expected %q in:\n%v", line, err)
+		}
+	}
+}
+
+func TestNewSnapshot(t *testing.T) {
+	result := pii.NewPiiExtractionResult([]pii.PiiEntity{
+		{Type: pii.PiiTypeSSN, Value: pii.NewSSN("123-45-6789")},
+		{Type: pii.PiiTypeEmail, Value: pii.NewEmail("b@acme.com")},
+		{Type: pii.PiiTypeEmail, Value: pii.NewEmail("a@acme.com")},
+	})
+	snapshot := NewSnapshot(result)
+	var values []string
+	for _, finding := range snapshot.Findings {
+		values = append(values, finding.Value)
+	}
+	if strings.Join(values, ",") != "a@acme.com,b@acme.com,123-45-6789" {
+		t.Errorf("Expected findings sorted by type and value, got %v", values)
+	}
+	if Diff(snapshot, NewSnapshot(result)) != "" {
+		t.Error("Expected identical snapshots to match")
+	}
+}
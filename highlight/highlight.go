@@ -0,0 +1,153 @@
+// Package highlight renders a document as standalone HTML with detected entities
+// highlighted, for human review of individual extraction results.
+package highlight
+
+import (
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// DefaultColor is the highlight color of types missing from Colors
+const DefaultColor = "#d0d0d0"
+
+// Colors maps PII types to their highlight background color
+var Colors = map[pii.PiiType]string{
+	pii.PiiTypePhone:         "#ffd8a8",
+	pii.PiiTypeEmail:         "#a5d8ff",
+	pii.PiiTypeSSN:           "#ffa8a8",
+	pii.PiiTypeZipCode:       "#d8f5a2",
+	pii.PiiTypePoBox:         "#c0eb75",
+	pii.PiiTypeStreetAddress: "#b2f2bb",
+	pii.PiiTypeCreditCard:    "#ff8787",
+	pii.PiiTypeIPAddress:     "#99e9f2",
+	pii.PiiTypeBtcAddress:    "#ffe066",
+	pii.PiiTypeIBAN:          "#fcc2d7",
+	pii.PiiTypeHostname:      "#bac8ff",
+	pii.PiiTypeSessionToken:  "#e599f7",
+	pii.PiiTypeTrackData:     "#ff6b6b",
+	pii.PiiTypeSecret:        "#f783ac",
+	pii.PiiTypeHash:          "#ced4da",
+	pii.PiiTypeNationalID:    "#ffc9c9",
+	pii.PiiTypePersonName:    "#d0bfff",
+}
+
+// Color returns the highlight color of a PII type
+func Color(piiType pii.PiiType) string {
+	if color, ok := Colors[piiType]; ok {
+		return color
+	}
+	return DefaultColor
+}
+
+// Options configures the rendered page
+type Options struct {
+	// Title is the page title; empty uses "PII review"
+	Title string
+}
+
+// Segment is a run of document text, highlighted when it is an entity occurrence
+type Segment struct {
+	Text   string
+	Entity *pii.PiiEntity
+}
+
+// Tooltip describes the entity of a highlighted segment: type, severity and, when the
+// entity was validated, the validation verdict and confidence
+func (s Segment) Tooltip() string {
+	if s.Entity == nil {
+		return ""
+	}
+	parts := []string{s.Entity.Type.String(), "severity " + s.Entity.GetSeverity().String()}
+	if validation := s.Entity.Validation; validation != nil {
+		verdict := "invalid"
+		if validation.Valid {
+			verdict = "valid"
+		}
+		parts = append(parts, fmt.Sprintf("%s, confidence %.2f", verdict, validation.Confidence))
+		if validation.Reasoning != "" {
+			parts = append(parts, validation.Reasoning)
+		}
+	}
+	return strings.Join(parts, " · ")
+}
+
+// Segments splits text into plain and highlighted segments, following the non-overlapping
+// occurrences of the result entities
+func Segments(text string, result *pii.PiiExtractionResult) []Segment {
+	var segments []Segment
+	offset := 0
+	for _, occurrence := range result.Occurrences(text) {
+		if occurrence.Start > offset {
+			segments = append(segments, Segment{Text: text[offset:occurrence.Start]})
+		}
+		entity := occurrence.Entity
+		segments = append(segments, Segment{Text: text[occurrence.Start:occurrence.End], Entity: &entity})
+		offset = occurrence.End
+	}
+	if offset < len(text) {
+		segments = append(segments, Segment{Text: text[offset:]})
+	}
+	return segments
+}
+
+// legendEntry is one PII type of the page legend
+type legendEntry struct {
+	Type        pii.PiiType
+	Occurrences int
+}
+
+// page is the data of the HTML template
+type page struct {
+	Title    string
+	Segments []Segment
+	Legend   []legendEntry
+	Colors   map[string]string
+}
+
+//go:embed page.html.tmpl
+var pageTemplate string
+
+var tmpl = template.Must(template.New("page").Funcs(template.FuncMap{
+	"class": class,
+}).Parse(pageTemplate))
+
+// Render writes a standalone HTML page with the text and its entities highlighted by
+// type, with hover tooltips showing type, severity and validation. The page embeds its
+// styles and loads no external resources, so it can be archived or attached as is.
+func Render(w io.Writer, text string, result *pii.PiiExtractionResult, options Options) error {
+	data := page{
+		Title:    options.Title,
+		Segments: Segments(text, result),
+		Colors:   make(map[string]string),
+	}
+	if data.Title == "" {
+		data.Title = "PII review"
+	}
+
+	counts := make(map[pii.PiiType]int)
+	for _, segment := range data.Segments {
+		if segment.Entity != nil {
+			counts[segment.Entity.Type]++
+		}
+	}
+	for piiType, count := range counts {
+		data.Legend = append(data.Legend, legendEntry{Type: piiType, Occurrences: count})
+		data.Colors[class(piiType)] = Color(piiType)
+	}
+	sort.Slice(data.Legend, func(i, j int) bool {
+		return data.Legend[i].Type < data.Legend[j].Type
+	})
+
+	return tmpl.Execute(w, data)
+}
+
+// class returns the CSS class of a PII type
+func class(piiType pii.PiiType) string {
+	return "pii-" + strings.ReplaceAll(piiType.String(), "_", "-")
+}
@@ -0,0 +1,69 @@
+package highlight
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+func TestSegments(t *testing.T) {
+	result := pii.NewPiiExtractionResult([]pii.PiiEntity{
+		{Type: pii.PiiTypeEmail, Value: pii.NewEmail("john@acme.com")},
+		{Type: pii.PiiTypeSSN, Value: pii.NewSSN("123-45-6789")},
+	})
+
+	segments := Segments("Mail john@acme.com, SSN 123-45-6789", result)
+	if len(segments) != 4 {
+		t.Fatalf("Expected 4 segments, got %+v", segments)
+	}
+	if segments[0].Text != "Mail " || segments[0].Entity != nil {
+		t.Errorf("Expected a plain leading segment, got %+v", segments[0])
+	}
+	if segments[1].Entity == nil || segments[1].Entity.Type != pii.PiiTypeEmail {
+		t.Errorf("Expected the email segment, got %+v", segments[1])
+	}
+	if segments[3].Text != "123-45-6789" || segments[3].Tooltip() != "ssn · severity critical" {
+		t.Errorf("Expected the SSN segment with its tooltip, got %q", segments[3].Tooltip())
+	}
+}
+
+func TestRender(t *testing.T) {
+	result := pii.NewPiiExtractionResult([]pii.PiiEntity{{
+		Type:       pii.PiiTypeEmail,
+		Value:      pii.NewEmail("john@acme.com"),
+		Validation: &pii.ValidationResult{Valid: false, Confidence: 0.3, Reasoning: "placeholder"},
+	}})
+
+	var out strings.Builder
+	if err := Render(&out, "<p>Contact john@acme.com</p>", result, Options{Title: "Ticket 42"}); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	page := out.String()
+
+	for _, want := range []string{
+		"<title>Ticket 42</title>",
+		"&lt;p&gt;Contact ",
+		`<mark class="pii-email invalid" title="email · severity medium · invalid, confidence 0.30 · placeholder"`,
+		">john@acme.com</mark>",
+		".pii-email { background: #a5d8ff; }",
+		`<span class="pii-email">email (1)</span>`,
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("Expected the page to contain %q, got:\n%s", want, page)
+		}
+	}
+	if strings.Contains(page, "<p>") {
+		t.Error("Expected the document text to be escaped")
+	}
+}
+
+func TestRender_NoEntities(t *testing.T) {
+	var out strings.Builder
+	if err := Render(&out, "nothing here", pii.NewPiiExtractionResult(nil), Options{}); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "No PII detected.") || !strings.Contains(out.String(), "nothing here") {
+		t.Errorf("Expected the empty legend and the text, got:\n%s", out.String())
+	}
+}
@@ -1,11 +1,14 @@
 package piiextractor
 
 import (
+	"context"
+
 	"github.com/intMeric/pii-extractor/extractors"
 	hybridExtractor "github.com/intMeric/pii-extractor/extractors/hybrid"
 	llmExtractor "github.com/intMeric/pii-extractor/extractors/llm"
 	regexExtractor "github.com/intMeric/pii-extractor/extractors/regex"
 	"github.com/intMeric/pii-extractor/pii"
+	"github.com/intMeric/pii-extractor/redact"
 )
 
 // Re-export types from pii package for convenience
@@ -13,7 +16,24 @@ type PiiType = pii.PiiType
 type PiiEntity = pii.PiiEntity
 type PiiExtractionResult = pii.PiiExtractionResult
 type ValidationStats = pii.ValidationStats
+type TypeValidationStats = pii.TypeValidationStats
 type ValidationResult = pii.ValidationResult
+type SkippedValidation = pii.SkippedValidation
+type SuppressedCandidate = pii.SuppressedCandidate
+type Severity = pii.Severity
+type Country = pii.Country
+type Aggregate = pii.Aggregate
+type TypeAggregate = pii.TypeAggregate
+type Aggregator = pii.Aggregator
+type DistinctCounts = pii.DistinctCounts
+type ResultBuilder = pii.ResultBuilder
+type NoiseConfig = pii.NoiseConfig
+type MergePolicy = pii.MergePolicy
+type Occurrence = pii.Occurrence
+type RetentionHint = pii.RetentionHint
+type RetentionPolicy = pii.RetentionPolicy
+type ScrubLevel = pii.ScrubLevel
+type DisplayMode = pii.DisplayMode
 
 // Re-export PII value types
 type Pii = pii.Pii
@@ -28,6 +48,14 @@ type CreditCard = pii.CreditCard
 type IPAddress = pii.IPAddress
 type BtcAddress = pii.BtcAddress
 type IBAN = pii.IBAN
+type Hostname = pii.Hostname
+type SessionToken = pii.SessionToken
+type TrackData = pii.TrackData
+type Secret = pii.Secret
+type Hash = pii.Hash
+type NationalID = pii.NationalID
+type PersonName = pii.PersonName
+type Scrubbed = pii.Scrubbed
 
 // Re-export constants
 const (
@@ -41,19 +69,103 @@ const (
 	PiiTypeIPAddress     = pii.PiiTypeIPAddress
 	PiiTypeBtcAddress    = pii.PiiTypeBtcAddress
 	PiiTypeIBAN          = pii.PiiTypeIBAN
+	PiiTypeHostname      = pii.PiiTypeHostname
+	PiiTypeSessionToken  = pii.PiiTypeSessionToken
+	PiiTypeTrackData     = pii.PiiTypeTrackData
+	PiiTypeSecret        = pii.PiiTypeSecret
+	PiiTypeHash          = pii.PiiTypeHash
+	PiiTypeNationalID    = pii.PiiTypeNationalID
+	PiiTypePersonName    = pii.PiiTypePersonName
+)
+
+// Re-export countries (ISO 3166-1 alpha-2 codes)
+const (
+	CountryUS    = pii.CountryUS
+	CountryGB    = pii.CountryGB
+	CountryFR    = pii.CountryFR
+	CountryES    = pii.CountryES
+	CountryIT    = pii.CountryIT
+	CountryDE    = pii.CountryDE
+	CountryCN    = pii.CountryCN
+	CountryIN    = pii.CountryIN
+	CountryRU    = pii.CountryRU
+	CountryNL    = pii.CountryNL
+	CountryBE    = pii.CountryBE
+	RegionArabic = pii.RegionArabic
+)
+
+// Re-export result merge policies
+const (
+	MergeCounts     = pii.MergeCounts
+	KeepPerDocument = pii.KeepPerDocument
+)
+
+// Re-export severity levels
+const (
+	SeverityLow      = pii.SeverityLow
+	SeverityMedium   = pii.SeverityMedium
+	SeverityHigh     = pii.SeverityHigh
+	SeverityCritical = pii.SeverityCritical
+)
+
+// Re-export scrub levels
+const (
+	ScrubContexts = pii.ScrubContexts
+	ScrubValues   = pii.ScrubValues
+	ScrubAll      = pii.ScrubAll
+)
+
+// Re-export display modes of String methods
+const (
+	DisplayMasked = pii.DisplayMasked
+	DisplayFull   = pii.DisplayFull
 )
 
 // Re-export extractors types for convenience
 type ExtractionMethod = extractors.ExtractionMethod
 type ExtractorConfig = extractors.ExtractorConfig
+type AggregationMode = extractors.AggregationMode
 type PiiExtractor = extractors.PiiExtractor
+type ContextExtractor = extractors.ContextExtractor
+type BatchExtractor = extractors.BatchExtractor
+type BatchResult = extractors.BatchResult
+type Registry = extractors.Registry
+type HealthReport = extractors.HealthReport
+type HealthStatus = extractors.HealthStatus
+type Middleware = extractors.Middleware
+type MiddlewareOption = extractors.MiddlewareOption
+type PreProcessor = extractors.PreProcessor
+type PostProcessor = extractors.PostProcessor
+type Support = extractors.Support
+type SupportReporter = extractors.SupportReporter
+
+// Re-export LLM client types for convenience
+type LLMClient = llmExtractor.Client
+type LLMClientConfig = llmExtractor.ClientConfig
+type LLMClientFactory = llmExtractor.ClientFactory
+type LLMResponseCache = llmExtractor.ResponseCache
+type LLMExample = llmExtractor.Example
+type LLMExampleLibrary = llmExtractor.ExampleLibrary
 
 // Re-export hybrid types for convenience
 type ValidationConfig = hybridExtractor.ValidationConfig
+type ValidationBudget = hybridExtractor.ValidationBudget
 type LLMProvider = hybridExtractor.LLMProvider
 type ValidatedExtractor = hybridExtractor.ValidatedExtractor
 type EnsembleExtractor = hybridExtractor.EnsembleExtractor
 
+// Re-export regex explain types for convenience
+type Explanation = regexExtractor.Explanation
+type ExclusionReason = regexExtractor.ExclusionReason
+
+// Re-export regex country pack types for convenience
+type CountryPack = regexExtractor.CountryPack
+type CountryScan = regexExtractor.CountryScan
+type PatternSetRelease = regexExtractor.PatternSetRelease
+
+// PatternSetVersion identifies the built-in regex detection rules of this release
+const PatternSetVersion = regexExtractor.PatternSetVersion
+
 // Re-export extraction methods
 const (
 	MethodRegex  = extractors.MethodRegex
@@ -62,6 +174,13 @@ const (
 	MethodHybrid = extractors.MethodHybrid
 )
 
+// Re-export aggregation modes
+const (
+	AggregationNone        = extractors.AggregationNone
+	AggregationTopK        = extractors.AggregationTopK
+	AggregationOccurrences = extractors.AggregationOccurrences
+)
+
 // Re-export LLM providers
 const (
 	ProviderOpenAI    = hybridExtractor.ProviderOpenAI
@@ -88,6 +207,12 @@ func NewLLMExtractor(provider llmExtractor.Provider, model string, config *Extra
 	return llmExtractor.NewExtractor(provider, model, config)
 }
 
+// SetLLMClientFactory installs the factory creating the clients of LLM extractors and
+// validators, such as gollm.NewClient from the extractors/llm/gollm module
+func SetLLMClientFactory(factory LLMClientFactory) {
+	llmExtractor.SetClientFactory(factory)
+}
+
 // NewEnsembleExtractor creates a new ensemble extractor that combines multiple extractors
 func NewEnsembleExtractor(extractors ...PiiExtractor) *hybridExtractor.EnsembleExtractor {
 	return hybridExtractor.NewEnsembleExtractor(extractors...)
@@ -103,6 +228,42 @@ func DefaultValidationConfig() *ValidationConfig {
 	return hybridExtractor.DefaultValidationConfig()
 }
 
+// ExtractWithContext runs an extraction under ctx, falling back to Extract for extractors without context support
+func ExtractWithContext(ctx context.Context, extractor PiiExtractor, text string) (*PiiExtractionResult, error) {
+	return extractors.ExtractWithContext(ctx, extractor, text)
+}
+
+// ExtractBatch extracts each of many small texts under ctx, using the batch path of
+// extractors that have one, and returns their results and summary
+func ExtractBatch(ctx context.Context, extractor PiiExtractor, texts []string) (*BatchResult, error) {
+	return extractors.ExtractBatch(ctx, extractor, texts)
+}
+
+// ExtractAndRedact extracts the PII of text with the default regex extractor and returns
+// the result along with the text redacted by the policy, in a single pass
+func ExtractAndRedact(text string, policy redact.Preset) (*PiiExtractionResult, string, error) {
+	return redact.New(policy).ExtractAndRedact(context.Background(), regexExtractor.NewDefaultExtractor(), text)
+}
+
+// RegisterCountry registers a regex country pack, replacing any pack for the same country
+func RegisterCountry(pack CountryPack) error {
+	return regexExtractor.RegisterCountry(pack)
+}
+
+// WithMiddleware wraps an extractor with pre- and post-processing steps
+func WithMiddleware(extractor PiiExtractor, options ...MiddlewareOption) *Middleware {
+	return extractors.WithMiddleware(extractor, options...)
+}
+
+// WithPreProcessor adds a step transforming the text before extraction
+var WithPreProcessor = extractors.WithPreProcessor
+
+// WithPostProcessor adds a step transforming the result after extraction
+var WithPostProcessor = extractors.WithPostProcessor
+
+// FilterEntities returns a post-processor keeping only the entities accepted by keep
+var FilterEntities = extractors.FilterEntities
+
 // Registry functions
 
 // Register adds an extractor to the global registry
@@ -125,14 +286,88 @@ func List() []string {
 	return extractors.List()
 }
 
+// NewRegistry creates a standalone extractor registry
+func NewRegistry() *Registry {
+	return extractors.NewRegistry()
+}
+
+// Start initializes the extractors of the global registry
+func Start(ctx context.Context) error {
+	return extractors.Start(ctx)
+}
+
+// HealthCheck checks the extractors of the global registry, including LLM provider availability
+func HealthCheck(ctx context.Context) (*HealthReport, error) {
+	return extractors.HealthCheck(ctx)
+}
+
+// Close releases the extractors of the global registry
+func Close() error {
+	return extractors.Close()
+}
+
+// Support matrix
+
+// SupportMatrix returns the PII types detected per country by each extraction method
+// of this build: the regex extractor with the registered country packs, including
+// community packs, the LLM extractor, and the hybrid extractor validating regex results.
+// Marshaled to JSON, it documents coverage for compliance reviews.
+func SupportMatrix() []Support {
+	regexSupport := regexExtractor.NewDefaultExtractor().Support()
+	matrix := append([]Support(nil), regexSupport...)
+	for _, piiType := range llmExtractor.SupportedTypes() {
+		matrix = append(matrix, Support{Type: piiType, Methods: []ExtractionMethod{MethodLLM}})
+	}
+	matrix = append(matrix, extractors.WithMethod(regexSupport, MethodHybrid)...)
+	return extractors.MergeSupport(matrix)
+}
+
+// SupportMatrixOf returns the support matrix of configured extractors, such as a regex
+// extractor restricted to some countries
+func SupportMatrixOf(extractorList ...PiiExtractor) []Support {
+	return extractors.SupportMatrix(extractorList...)
+}
+
 // Utility functions
 
 // NewPiiExtractionResult creates a new extraction result
 var NewPiiExtractionResult = pii.NewPiiExtractionResult
 
+// NewResultBuilder creates a thread-safe builder merging entities into a result
+var NewResultBuilder = pii.NewResultBuilder
+
+// NewAggregator creates a memory-bounded aggregator keeping the top-K values per type
+var NewAggregator = pii.NewAggregator
+
+// NewDistinctCounts creates mergeable per-type distinct value estimates
+var NewDistinctCounts = pii.NewDistinctCounts
+
+// EntityID returns the deterministic ID of a value
+var EntityID = pii.EntityID
+
+// DefaultRetentionPolicy returns the baseline PCI DSS, credential and GDPR retention policy
+var DefaultRetentionPolicy = pii.DefaultRetentionPolicy
+
+// ParseCountry parses an ISO 3166-1 alpha-2 code or a legacy country name such as "UK"
+var ParseCountry = pii.ParseCountry
+
+// SupportedCountries returns the countries with built-in patterns
+var SupportedCountries = pii.SupportedCountries
+
+// ParsePiiType parses a type name such as "credit_card", ignoring case
+var ParsePiiType = pii.ParsePiiType
+
+// PiiTypes returns all PII types
+var PiiTypes = pii.PiiTypes
+
+// MergeResults combines the results of several documents, merging identical values
+var MergeResults = pii.MergeResults
+
+// MergeResultsWith combines the results of several documents with the given merge policy
+var MergeResultsWith = pii.MergeResultsWith
+
 // PII constructors
 var NewEmail = pii.NewEmail
-var NewPhoneUS = pii.NewPhoneUS
 var NewPhone = pii.NewPhone
 var NewSSN = pii.NewSSN
 var NewZipCode = pii.NewZipCode
@@ -142,6 +377,28 @@ var NewCreditCard = pii.NewCreditCard
 var NewIPAddress = pii.NewIPAddress
 var NewBtcAddress = pii.NewBtcAddress
 var NewIBAN = pii.NewIBAN
+var NewHostname = pii.NewHostname
+var NewSessionToken = pii.NewSessionToken
+var NewTrackData = pii.NewTrackData
+var NewSecret = pii.NewSecret
+var NewHash = pii.NewHash
+var NewNationalID = pii.NewNationalID
+var NewPersonName = pii.NewPersonName
+
+// NewPhoneUS creates a US phone value
+//
+// Deprecated: use NewPhone(value, CountryUS).
+var NewPhoneUS = pii.NewPhoneUS
+
+// NewEntity creates an entity, failing with ErrTypeMismatch when the value object is of
+// another type
+var NewEntity = pii.NewEntity
+
+// EntityOf creates the entity of a value object, with the type of the value
+var EntityOf = pii.EntityOf
+
+// ErrTypeMismatch is returned when the value object of an entity does not match its type
+var ErrTypeMismatch = pii.ErrTypeMismatch
 
 // GetTypedValue performs a safe type assertion for PII values
 func GetTypedValue[T Pii](entity PiiEntity) (T, bool) {
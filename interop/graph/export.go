@@ -0,0 +1,157 @@
+package graph
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// GraphMLNamespace is the XML namespace of GraphML documents
+const GraphMLNamespace = "http://graphml.graphdrawing.org/xmlns"
+
+// graphML is the root element of a GraphML document
+type graphML struct {
+	XMLName xml.Name     `xml:"graphml"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+// graphMLKey declares a node or edge attribute
+type graphMLKey struct {
+	ID   string `xml:"id,attr"`
+	For  string `xml:"for,attr"`
+	Name string `xml:"attr.name,attr"`
+	Type string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	ID     string        `xml:"id,attr"`
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// WriteGraphML writes the graph as an undirected GraphML document, with the type, value,
+// country, count and documents of each node and the scope and weight of each edge
+func (g *Graph) WriteGraphML(w io.Writer) error {
+	document := graphML{
+		Xmlns: GraphMLNamespace,
+		Keys: []graphMLKey{
+			{ID: "type", For: "node", Name: "type", Type: "string"},
+			{ID: "value", For: "node", Name: "value", Type: "string"},
+			{ID: "country", For: "node", Name: "country", Type: "string"},
+			{ID: "count", For: "node", Name: "count", Type: "int"},
+			{ID: "documents", For: "node", Name: "documents", Type: "int"},
+			{ID: "scope", For: "edge", Name: "scope", Type: "string"},
+			{ID: "weight", For: "edge", Name: "weight", Type: "int"},
+		},
+		Graph: graphMLGraph{ID: "pii", EdgeDefault: "undirected"},
+	}
+	for _, node := range g.Nodes() {
+		data := []graphMLData{
+			{Key: "type", Value: node.Type.String()},
+			{Key: "value", Value: node.Value},
+		}
+		if node.Country != "" {
+			data = append(data, graphMLData{Key: "country", Value: node.Country.String()})
+		}
+		data = append(data,
+			graphMLData{Key: "count", Value: strconv.Itoa(node.Count)},
+			graphMLData{Key: "documents", Value: strconv.Itoa(node.Documents)})
+		document.Graph.Nodes = append(document.Graph.Nodes, graphMLNode{ID: node.ID, Data: data})
+	}
+	for i, edge := range g.Edges() {
+		document.Graph.Edges = append(document.Graph.Edges, graphMLEdge{
+			ID:     "e" + strconv.Itoa(i),
+			Source: edge.Source,
+			Target: edge.Target,
+			Data: []graphMLData{
+				{Key: "scope", Value: edge.Scope.String()},
+				{Key: "weight", Value: strconv.Itoa(edge.Weight)},
+			},
+		})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// Neo4jNodeLabel is the label of every node in Neo4j, next to the label of its type
+const Neo4jNodeLabel = "Pii"
+
+// WriteNeo4jCSV writes the graph as the node and relationship files of neo4j-admin
+// database import. Nodes are labelled Pii and their type, such as "Pii;StreetAddress".
+// Relationships are typed by scope, such as CO_OCCURS_IN_SENTENCE.
+func (g *Graph) WriteNeo4jCSV(nodes, relationships io.Writer) error {
+	writer := csv.NewWriter(nodes)
+	writer.Write([]string{"id:ID", "type", "value", "country", "count:int", "documents:int", ":LABEL"})
+	for _, node := range g.Nodes() {
+		writer.Write([]string{
+			node.ID,
+			node.Type.String(),
+			node.Value,
+			node.Country.String(),
+			strconv.Itoa(node.Count),
+			strconv.Itoa(node.Documents),
+			Neo4jNodeLabel + ";" + Neo4jLabel(node.Type.String()),
+		})
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return err
+	}
+
+	writer = csv.NewWriter(relationships)
+	writer.Write([]string{":START_ID", ":END_ID", ":TYPE", "weight:int"})
+	for _, edge := range g.Edges() {
+		writer.Write([]string{edge.Source, edge.Target, Neo4jRelationship(edge.Scope), strconv.Itoa(edge.Weight)})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// Neo4jLabel returns the Neo4j label of a type name, such as "StreetAddress" for
+// "street_address"
+func Neo4jLabel(typeName string) string {
+	var label strings.Builder
+	for _, word := range strings.Split(typeName, "_") {
+		if word != "" {
+			label.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	return label.String()
+}
+
+// Neo4jRelationship returns the Neo4j relationship type of a scope, such as
+// CO_OCCURS_IN_DOCUMENT
+func Neo4jRelationship(scope Scope) string {
+	return "CO_OCCURS_IN_" + strings.ToUpper(scope.String())
+}
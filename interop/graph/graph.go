@@ -0,0 +1,252 @@
+// Package graph exports the entities of a corpus and their co-occurrences as a property
+// graph, so that investigation tooling can explore the connections between the emails,
+// phones and addresses found together in documents, sentences or records.
+//
+// Each distinct entity is a node, identified by its entity ID. Two entities found in the
+// same document, sentence or record are linked by an edge of that scope, weighted by the
+// number of documents, sentences or records they share. The graph is written as GraphML,
+// for Gephi, yEd or NetworkX, or as the node and relationship CSV files of neo4j-admin
+// database import.
+package graph
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// DefaultMaxGroupSize is the default largest number of entities of a document, sentence
+// or record linked pairwise
+const DefaultMaxGroupSize = 200
+
+// Scope is the unit of text in which two entities co-occur
+type Scope int
+
+const (
+	ScopeDocument Scope = iota // the same document
+	ScopeSentence              // the same sentence of a document
+	ScopeRecord                // the same record, such as a CSV row or a database row
+)
+
+// String returns the name of the scope
+func (s Scope) String() string {
+	switch s {
+	case ScopeDocument:
+		return "document"
+	case ScopeSentence:
+		return "sentence"
+	case ScopeRecord:
+		return "record"
+	}
+	return "unknown"
+}
+
+// Node is a distinct entity of the corpus
+type Node struct {
+	ID        string
+	Type      pii.PiiType
+	Value     string
+	Country   pii.Country
+	Count     int // occurrences across the corpus
+	Documents int // documents and records the entity was found in
+}
+
+// Edge links two entities found together. Source is the lower node ID.
+type Edge struct {
+	Source string
+	Target string
+	Scope  Scope
+	Weight int // documents, sentences or records shared
+}
+
+// Option configures a Graph
+type Option func(*Graph)
+
+// WithValue sets the value exported for each node, such as a redact.Masker, instead of
+// the value as found
+func WithValue(value func(pii.PiiEntity) string) Option {
+	return func(g *Graph) {
+		g.value = value
+	}
+}
+
+// WithMaxGroupSize sets the largest number of entities of a document, sentence or record
+// linked pairwise (DefaultMaxGroupSize by default). The edges of a group grow with the
+// square of its size, so larger groups add their nodes but no edges at their scope.
+func WithMaxGroupSize(size int) Option {
+	return func(g *Graph) {
+		g.maxGroupSize = size
+	}
+}
+
+// Graph accumulates the entities and co-occurrences of a corpus. It is safe for
+// concurrent use.
+type Graph struct {
+	value        func(pii.PiiEntity) string
+	maxGroupSize int
+
+	mu    sync.Mutex
+	nodes map[string]*Node
+	edges map[edgeKey]*Edge
+}
+
+// edgeKey identifies an edge
+type edgeKey struct {
+	source, target string
+	scope          Scope
+}
+
+// New creates an empty graph
+func New(opts ...Option) *Graph {
+	g := &Graph{
+		value:        pii.PiiEntity.GetValue,
+		maxGroupSize: DefaultMaxGroupSize,
+		nodes:        make(map[string]*Node),
+		edges:        make(map[edgeKey]*Edge),
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// AddDocument adds the entities of a document's result, linked at the document scope.
+// When the text of the document is given, entities occurring in the same sentence are
+// also linked at the sentence scope.
+func (g *Graph) AddDocument(text string, result *pii.PiiExtractionResult) {
+	var sentences [][]string
+	if text != "" {
+		sentences = g.sentences(text, result)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	ids := g.addNodes(result.Entities, pii.PiiEntity.GetCount)
+	g.link(ids, ScopeDocument)
+	for _, sentence := range sentences {
+		g.link(sentence, ScopeSentence)
+	}
+}
+
+// AddRecord adds the entities of one record, linked at the record scope, such as the
+// records of consistency.Checker.Records. Each entity counts as one occurrence, and each
+// record as one document of its entities.
+func (g *Graph) AddRecord(record []pii.PiiEntity) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.link(g.addNodes(record, func(pii.PiiEntity) int { return 1 }), ScopeRecord)
+}
+
+// addNodes adds or updates the nodes of the entities, adding count occurrences of each,
+// and returns their distinct IDs
+func (g *Graph) addNodes(entities []pii.PiiEntity, count func(pii.PiiEntity) int) []string {
+	var ids []string
+	for _, entity := range entities {
+		id := nodeID(entity)
+		node, ok := g.nodes[id]
+		if !ok {
+			node = &Node{ID: id, Type: entity.Type, Value: g.value(entity), Country: pii.EntityCountry(entity)}
+			g.nodes[id] = node
+		}
+		node.Count += count(entity)
+		if !slices.Contains(ids, id) {
+			node.Documents++
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// link adds an edge of the scope between every pair of nodes
+func (g *Graph) link(ids []string, scope Scope) {
+	if len(ids) > g.maxGroupSize {
+		return
+	}
+	for i, a := range ids {
+		for _, b := range ids[i+1:] {
+			key := edgeKey{min(a, b), max(a, b), scope}
+			edge, ok := g.edges[key]
+			if !ok {
+				edge = &Edge{Source: key.source, Target: key.target, Scope: scope}
+				g.edges[key] = edge
+			}
+			edge.Weight++
+		}
+	}
+}
+
+// sentences returns the distinct node IDs of the entities occurring in each sentence of
+// text. A sentence ends at a line break or at a period, question or exclamation mark
+// followed by a space, so that the dots of emails and hostnames do not end it.
+func (g *Graph) sentences(text string, result *pii.PiiExtractionResult) [][]string {
+	var sentences [][]string
+	var sentence []string
+	offset := 0
+	for _, occurrence := range result.Occurrences(text) {
+		if sentenceEnds(text[offset:occurrence.Start]) && len(sentence) > 0 {
+			sentences = append(sentences, sentence)
+			sentence = nil
+		}
+		offset = occurrence.End
+		if id := nodeID(occurrence.Entity); !slices.Contains(sentence, id) {
+			sentence = append(sentence, id)
+		}
+	}
+	if len(sentence) > 0 {
+		sentences = append(sentences, sentence)
+	}
+	return sentences
+}
+
+// sentenceEnds reports whether the text between two occurrences ends a sentence
+func sentenceEnds(between string) bool {
+	if strings.ContainsAny(between, "\n\r") {
+		return true
+	}
+	for i := 0; i+1 < len(between); i++ {
+		if strings.IndexByte(".?!", between[i]) >= 0 && (between[i+1] == ' ' || between[i+1] == '\t') {
+			return true
+		}
+	}
+	return false
+}
+
+// nodeID returns the entity ID, unsalted for entities without one as in
+// pii.NewPiiExtractionResult
+func nodeID(entity pii.PiiEntity) string {
+	if entity.ID != "" {
+		return entity.ID
+	}
+	return pii.EntityID(entity.Type, entity.GetValue(), "")
+}
+
+// Nodes returns the nodes, sorted by type then ID
+func (g *Graph) Nodes() []Node {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	nodes := make([]Node, 0, len(g.nodes))
+	for _, node := range g.nodes {
+		nodes = append(nodes, *node)
+	}
+	slices.SortFunc(nodes, func(a, b Node) int {
+		return cmp.Or(cmp.Compare(a.Type, b.Type), strings.Compare(a.ID, b.ID))
+	})
+	return nodes
+}
+
+// Edges returns the edges, sorted by scope, source then target
+func (g *Graph) Edges() []Edge {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	edges := make([]Edge, 0, len(g.edges))
+	for _, edge := range g.edges {
+		edges = append(edges, *edge)
+	}
+	slices.SortFunc(edges, func(a, b Edge) int {
+		return cmp.Or(cmp.Compare(a.Scope, b.Scope), strings.Compare(a.Source, b.Source), strings.Compare(a.Target, b.Target))
+	})
+	return edges
+}
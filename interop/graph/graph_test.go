@@ -0,0 +1,132 @@
+package graph
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+func TestGraph_DocumentAndSentenceScopes(t *testing.T) {
+	email := pii.PiiEntity{Type: pii.PiiTypeEmail, Value: pii.NewEmail("john@acme.com")}
+	phone := pii.PiiEntity{Type: pii.PiiTypePhone, Value: pii.NewPhone("555-123-4567", "US")}
+	address := pii.PiiEntity{Type: pii.PiiTypeStreetAddress, Value: pii.NewStreetAddress("12 Main Street", "US")}
+
+	text := "Write to john@acme.com or call 555-123-4567. Visit 12 Main Street."
+	g := New()
+	g.AddDocument(text, pii.NewPiiExtractionResult([]pii.PiiEntity{email, phone, address}))
+	g.AddDocument("", pii.NewPiiExtractionResult([]pii.PiiEntity{email, phone}))
+
+	if nodes := g.Nodes(); len(nodes) != 3 {
+		t.Fatalf("Expected 3 nodes, got %+v", nodes)
+	}
+	weights := make(map[Scope]map[[2]string]int)
+	for _, edge := range g.Edges() {
+		if weights[edge.Scope] == nil {
+			weights[edge.Scope] = make(map[[2]string]int)
+		}
+		weights[edge.Scope][[2]string{edge.Source, edge.Target}] = edge.Weight
+	}
+
+	pair := func(a, b pii.PiiEntity) [2]string {
+		x, y := nodeID(a), nodeID(b)
+		return [2]string{min(x, y), max(x, y)}
+	}
+	if got := weights[ScopeDocument][pair(email, phone)]; got != 2 {
+		t.Errorf("Expected email and phone to share 2 documents, got %d", got)
+	}
+	if got := weights[ScopeDocument][pair(email, address)]; got != 1 {
+		t.Errorf("Expected email and address to share 1 document, got %d", got)
+	}
+	if got := weights[ScopeSentence][pair(email, phone)]; got != 1 {
+		t.Errorf("Expected email and phone to share 1 sentence, got %d", got)
+	}
+	if _, ok := weights[ScopeSentence][pair(email, address)]; ok {
+		t.Error("Expected email and address not to share a sentence")
+	}
+}
+
+func TestGraph_RecordsAndGroupLimit(t *testing.T) {
+	email := pii.PiiEntity{Type: pii.PiiTypeEmail, Value: pii.NewEmail("john@acme.com")}
+	phone := pii.PiiEntity{Type: pii.PiiTypePhone, Value: pii.NewPhone("555-123-4567", "US")}
+	ssn := pii.PiiEntity{Type: pii.PiiTypeSSN, Value: pii.NewSSN("123-45-6789")}
+
+	g := New(WithMaxGroupSize(2))
+	g.AddRecord([]pii.PiiEntity{email, phone})
+	g.AddRecord([]pii.PiiEntity{email, phone, ssn})
+
+	edges := g.Edges()
+	if len(edges) != 1 || edges[0].Scope != ScopeRecord || edges[0].Weight != 1 {
+		t.Fatalf("Expected one record edge of weight 1, got %+v", edges)
+	}
+	for _, node := range g.Nodes() {
+		if node.Type == pii.PiiTypeEmail && (node.Count != 2 || node.Documents != 2) {
+			t.Errorf("Expected the email in 2 records, got %+v", node)
+		}
+	}
+}
+
+func TestWriteGraphML(t *testing.T) {
+	g := New(WithValue(func(entity pii.PiiEntity) string { return pii.MaskValue(entity.GetValue()) }))
+	g.AddRecord([]pii.PiiEntity{
+		{Type: pii.PiiTypeEmail, Value: pii.NewEmail("john@acme.com")},
+		{Type: pii.PiiTypeStreetAddress, Value: pii.NewStreetAddress("12 Main Street", "US")},
+	})
+
+	var buf bytes.Buffer
+	if err := g.WriteGraphML(&buf); err != nil {
+		t.Fatalf("WriteGraphML() error = %v", err)
+	}
+	output := buf.String()
+	if strings.Contains(output, "john@acme.com") {
+		t.Errorf("Expected masked values, got:\n%s", output)
+	}
+	for _, want := range []string{`edgedefault="undirected"`, `<data key="country">US</data>`, `<data key="scope">record</data>`} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %s in:\n%s", want, output)
+		}
+	}
+
+	var document graphML
+	if err := xml.Unmarshal(buf.Bytes(), &document); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if len(document.Graph.Nodes) != 2 || len(document.Graph.Edges) != 1 {
+		t.Errorf("Expected 2 nodes and 1 edge, got %+v", document.Graph)
+	}
+}
+
+func TestWriteNeo4jCSV(t *testing.T) {
+	g := New()
+	g.AddDocument("", pii.NewPiiExtractionResult([]pii.PiiEntity{
+		{Type: pii.PiiTypeEmail, Value: pii.NewEmail("john@acme.com")},
+		{Type: pii.PiiTypeStreetAddress, Value: pii.NewStreetAddress("12 Main Street", "US")},
+	}))
+
+	var nodes, relationships bytes.Buffer
+	if err := g.WriteNeo4jCSV(&nodes, &relationships); err != nil {
+		t.Fatalf("WriteNeo4jCSV() error = %v", err)
+	}
+
+	nodeRows, err := csv.NewReader(&nodes).ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if len(nodeRows) != 3 || nodeRows[0][0] != "id:ID" {
+		t.Fatalf("Unexpected node rows: %v", nodeRows)
+	}
+	if got := nodeRows[2][6]; got != "Pii;StreetAddress" {
+		t.Errorf("Expected the address labelled Pii;StreetAddress, got %q", got)
+	}
+
+	relationshipRows, err := csv.NewReader(&relationships).ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if len(relationshipRows) != 2 || relationshipRows[1][2] != "CO_OCCURS_IN_DOCUMENT" || relationshipRows[1][3] != "1" {
+		t.Errorf("Unexpected relationship rows: %v", relationshipRows)
+	}
+}
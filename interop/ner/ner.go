@@ -0,0 +1,186 @@
+// Package ner exports extraction results as named entity recognition training annotations,
+// either spaCy-style JSONL or CoNLL-style BIO tagged tokens.
+package ner
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// OutsideTag is the BIO tag of tokens outside any entity
+const OutsideTag = "O"
+
+// Labels maps PII types to annotation labels. Types missing from the map use their
+// upper-cased type name.
+var Labels = map[pii.PiiType]string{}
+
+// Label returns the annotation label of a PII type
+func Label(piiType pii.PiiType) string {
+	if label, ok := Labels[piiType]; ok {
+		return label
+	}
+	return strings.ToUpper(piiType.String())
+}
+
+// Span is a labelled entity occurrence at byte offsets in the text
+type Span struct {
+	Start int
+	End   int
+	Label string
+}
+
+// Spans locates every occurrence of the result entities in text. NER annotations cannot
+// overlap, so overlapping occurrences keep the earliest, then longest, span.
+func Spans(text string, result *pii.PiiExtractionResult) []Span {
+	var spans []Span
+	for _, occurrence := range result.Occurrences(text) {
+		spans = append(spans, Span{Start: occurrence.Start, End: occurrence.End, Label: Label(occurrence.Entity.Type)})
+	}
+	return spans
+}
+
+// SpacyEntity is an entity annotation in spaCy training data, encoded as [start, end, label]
+// with offsets counted in characters
+type SpacyEntity struct {
+	Start int
+	End   int
+	Label string
+}
+
+// MarshalJSON encodes the entity as a [start, end, label] array
+func (e SpacyEntity) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{e.Start, e.End, e.Label})
+}
+
+// UnmarshalJSON decodes a [start, end, label] array
+func (e *SpacyEntity) UnmarshalJSON(data []byte) error {
+	var fields []json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	if len(fields) != 3 {
+		return fmt.Errorf("spacy entity must have 3 fields, got %d", len(fields))
+	}
+	if err := json.Unmarshal(fields[0], &e.Start); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(fields[1], &e.End); err != nil {
+		return err
+	}
+	return json.Unmarshal(fields[2], &e.Label)
+}
+
+// SpacyExample is one line of spaCy-format JSONL training data
+type SpacyExample struct {
+	Text     string        `json:"text"`
+	Entities []SpacyEntity `json:"entities"`
+}
+
+// ToSpacy converts an extraction result over text into a spaCy training example. Byte
+// offsets are converted to the character offsets spaCy expects.
+func ToSpacy(text string, result *pii.PiiExtractionResult) SpacyExample {
+	example := SpacyExample{Text: text, Entities: []SpacyEntity{}}
+	for _, span := range Spans(text, result) {
+		start := utf8.RuneCountInString(text[:span.Start])
+		example.Entities = append(example.Entities, SpacyEntity{
+			Start: start,
+			End:   start + utf8.RuneCountInString(text[span.Start:span.End]),
+			Label: span.Label,
+		})
+	}
+	return example
+}
+
+// WriteSpacyJSONL writes the spaCy training example of a document as a single JSONL line
+func WriteSpacyJSONL(w io.Writer, text string, result *pii.PiiExtractionResult) error {
+	data, err := json.Marshal(ToSpacy(text, result))
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	_, err = w.Write(data)
+	return err
+}
+
+// Token is a BIO tagged token at byte offsets in the text
+type Token struct {
+	Text  string
+	Start int
+	End   int
+	Tag   string
+}
+
+// BIOTokens splits text on whitespace and entity boundaries and tags each token with
+// B-<label>, I-<label> or O. Splitting at entity boundaries keeps every annotation aligned
+// to whole tokens, e.g. the trailing comma of "john@acme.com," becomes its own token.
+func BIOTokens(text string, result *pii.PiiExtractionResult) []Token {
+	spans := Spans(text, result)
+	var tokens []Token
+
+	spanIndex := 0
+	emit := func(start, end int) {
+		for spanIndex < len(spans) && spans[spanIndex].End <= start {
+			spanIndex++
+		}
+		tag := OutsideTag
+		if spanIndex < len(spans) && spans[spanIndex].Start <= start {
+			if spans[spanIndex].Start == start {
+				tag = "B-" + spans[spanIndex].Label
+			} else {
+				tag = "I-" + spans[spanIndex].Label
+			}
+		}
+		tokens = append(tokens, Token{Text: text[start:end], Start: start, End: end, Tag: tag})
+	}
+
+	boundaries := make(map[int]bool, 2*len(spans))
+	for _, span := range spans {
+		boundaries[span.Start] = true
+		boundaries[span.End] = true
+	}
+
+	start := -1
+	for i, r := range text {
+		if unicode.IsSpace(r) {
+			if start >= 0 {
+				emit(start, i)
+				start = -1
+			}
+			continue
+		}
+		if start >= 0 && boundaries[i] {
+			emit(start, i)
+			start = -1
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		emit(start, len(text))
+	}
+
+	return tokens
+}
+
+// WriteCoNLL writes the BIO tagged tokens of a document as "token<TAB>tag" lines followed by
+// a blank line separating it from the next document
+func WriteCoNLL(w io.Writer, text string, result *pii.PiiExtractionResult) error {
+	writer := bufio.NewWriter(w)
+	for _, token := range BIOTokens(text, result) {
+		if _, err := fmt.Fprintf(writer, "%s\t%s\n", token.Text, token.Tag); err != nil {
+			return err
+		}
+	}
+	if err := writer.WriteByte('\n'); err != nil {
+		return err
+	}
+	return writer.Flush()
+}
@@ -0,0 +1,86 @@
+package ner
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+func testResult() *pii.PiiExtractionResult {
+	return pii.NewPiiExtractionResult([]pii.PiiEntity{
+		{Type: pii.PiiTypeEmail, Value: pii.NewEmail("john@acme.com")},
+		{Type: pii.PiiTypeStreetAddress, Value: pii.NewStreetAddress("12 Main Street", "US")},
+	})
+}
+
+func TestToSpacy_CharacterOffsets(t *testing.T) {
+	text := "Écrire à john@acme.com, 12 Main Street"
+
+	example := ToSpacy(text, testResult())
+	if len(example.Entities) != 2 {
+		t.Fatalf("Expected 2 entities, got %+v", example.Entities)
+	}
+
+	runes := []rune(text)
+	for _, entity := range example.Entities {
+		got := string(runes[entity.Start:entity.End])
+		if got != "john@acme.com" && got != "12 Main Street" {
+			t.Errorf("Entity %+v does not align to characters, got %q", entity, got)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := WriteSpacyJSONL(&buf, text, testResult()); err != nil {
+		t.Fatalf("WriteSpacyJSONL() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), `"entities":[[9,22,"EMAIL"],[24,38,"STREET_ADDRESS"]]`) {
+		t.Errorf("Unexpected JSONL line: %s", buf.String())
+	}
+
+	var decoded SpacyExample
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if decoded.Entities[0] != example.Entities[0] {
+		t.Errorf("Round trip changed entity: %+v", decoded.Entities[0])
+	}
+}
+
+func TestBIOTokens(t *testing.T) {
+	text := "Mail john@acme.com, 12 Main Street."
+
+	var tags []string
+	for _, token := range BIOTokens(text, testResult()) {
+		tags = append(tags, token.Text+"/"+token.Tag)
+	}
+
+	want := "Mail/O john@acme.com/B-EMAIL ,/O 12/B-STREET_ADDRESS Main/I-STREET_ADDRESS Street/I-STREET_ADDRESS ./O"
+	if got := strings.Join(tags, " "); got != want {
+		t.Errorf("BIOTokens() = %s, want %s", got, want)
+	}
+}
+
+func TestSpans_DropsOverlaps(t *testing.T) {
+	result := pii.NewPiiExtractionResult([]pii.PiiEntity{
+		{Type: pii.PiiTypeStreetAddress, Value: pii.NewStreetAddress("10 Main Street", "US")},
+		{Type: pii.PiiTypeZipCode, Value: pii.NewZipCode("10", "US")},
+	})
+
+	spans := Spans("at 10 Main Street", result)
+	if len(spans) != 1 || spans[0].Label != "STREET_ADDRESS" {
+		t.Errorf("Expected the longest span only, got %+v", spans)
+	}
+}
+
+func TestWriteCoNLL(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCoNLL(&buf, "to john@acme.com", testResult()); err != nil {
+		t.Fatalf("WriteCoNLL() error = %v", err)
+	}
+	if got := buf.String(); got != "to\tO\njohn@acme.com\tB-EMAIL\n\n" {
+		t.Errorf("WriteCoNLL() = %q", got)
+	}
+}
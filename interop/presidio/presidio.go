@@ -0,0 +1,172 @@
+// Package presidio converts between extraction results and Microsoft Presidio analyzer
+// results, so Presidio recognizers and evaluation datasets can be used alongside this package.
+package presidio
+
+import (
+	"fmt"
+	"sort"
+
+	patterns "github.com/intMeric/pii-extractor/extractors/regex/patterns"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// DefaultScore is the score given to entities without a validation confidence
+const DefaultScore = 0.85
+
+// RecognizerName identifies this package in the recognition metadata of exported results
+const RecognizerName = "PiiExtractorRecognizer"
+
+// AnalyzerResult mirrors a Presidio RecognizerResult as returned by the analyzer API
+type AnalyzerResult struct {
+	EntityType          string         `json:"entity_type"`
+	Start               int            `json:"start"`
+	End                 int            `json:"end"`
+	Score               float64        `json:"score"`
+	RecognitionMetadata map[string]any `json:"recognition_metadata,omitempty"`
+}
+
+// EntityTypes maps PII types to Presidio entity types. Presidio has no built-in postal code
+// or P.O. box entities, so custom recognizer names are used for them.
+var EntityTypes = map[pii.PiiType]string{
+	pii.PiiTypeEmail:         "EMAIL_ADDRESS",
+	pii.PiiTypePhone:         "PHONE_NUMBER",
+	pii.PiiTypeSSN:           "US_SSN",
+	pii.PiiTypeCreditCard:    "CREDIT_CARD",
+	pii.PiiTypeIPAddress:     "IP_ADDRESS",
+	pii.PiiTypeIBAN:          "IBAN_CODE",
+	pii.PiiTypeBtcAddress:    "CRYPTO",
+	pii.PiiTypeStreetAddress: "LOCATION",
+	pii.PiiTypeZipCode:       "ZIP_CODE",
+	pii.PiiTypePoBox:         "PO_BOX",
+}
+
+// PiiTypes maps Presidio entity types to PII types. Several Presidio types may map to the
+// same PII type; Presidio types without an equivalent are ignored on import.
+var PiiTypes = map[string]pii.PiiType{
+	"EMAIL_ADDRESS":  pii.PiiTypeEmail,
+	"PHONE_NUMBER":   pii.PiiTypePhone,
+	"US_SSN":         pii.PiiTypeSSN,
+	"CREDIT_CARD":    pii.PiiTypeCreditCard,
+	"IP_ADDRESS":     pii.PiiTypeIPAddress,
+	"IBAN_CODE":      pii.PiiTypeIBAN,
+	"CRYPTO":         pii.PiiTypeBtcAddress,
+	"LOCATION":       pii.PiiTypeStreetAddress,
+	"STREET_ADDRESS": pii.PiiTypeStreetAddress,
+	"ZIP_CODE":       pii.PiiTypeZipCode,
+	"PO_BOX":         pii.PiiTypePoBox,
+}
+
+// ToAnalyzerResults converts an extraction result over text into Presidio analyzer results,
+// one per occurrence of each entity (see pii.PiiEntity.OccurrencesIn), sorted by start
+// offset. Offsets are counted in characters, as Presidio does, not in bytes. Entities whose
+// type has no Presidio mapping are skipped.
+func ToAnalyzerResults(text string, result *pii.PiiExtractionResult) []AnalyzerResult {
+	var results []AnalyzerResult
+	if result == nil {
+		return results
+	}
+
+	for _, entity := range result.Entities {
+		entityType, ok := EntityTypes[entity.Type]
+		if !ok {
+			continue
+		}
+
+		score := DefaultScore
+		if entity.Validation != nil {
+			score = entity.Validation.Confidence
+		}
+
+		locator := pii.NewLocator(text)
+		for _, occurrence := range entity.OccurrencesIn(text) {
+			location := locator.Locate(occurrence.Start, occurrence.End)
+			results = append(results, AnalyzerResult{
+				EntityType: entityType,
+				Start:      location.RuneStart,
+				End:        location.RuneEnd,
+				Score:      score,
+				RecognitionMetadata: map[string]any{
+					"recognizer_name": RecognizerName,
+				},
+			})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Start != results[j].Start {
+			return results[i].Start < results[j].Start
+		}
+		return results[i].End > results[j].End
+	})
+	return results
+}
+
+// FromAnalyzerResults converts Presidio analyzer results over text into an extraction result.
+// Their offsets are counted in characters, as Presidio does, and recorded as the entity
+// locations. The score is kept as the entity validation confidence and contexts are extracted
+// from text. Results with a Presidio entity type missing from PiiTypes are ignored.
+func FromAnalyzerResults(text string, results []AnalyzerResult) (*pii.PiiExtractionResult, error) {
+	// offsets[i] is the byte offset of the character i of text
+	offsets := make([]int, 0, len(text)+1)
+	for offset := range text {
+		offsets = append(offsets, offset)
+	}
+	offsets = append(offsets, len(text))
+
+	entities := make([]pii.PiiEntity, 0, len(results))
+	for _, result := range results {
+		piiType, ok := PiiTypes[result.EntityType]
+		if !ok {
+			continue
+		}
+		if result.Start < 0 || result.End >= len(offsets) || result.Start >= result.End {
+			return nil, fmt.Errorf("invalid span [%d:%d] for %s in text of %d characters",
+				result.Start, result.End, result.EntityType, len(offsets)-1)
+		}
+
+		start, end := offsets[result.Start], offsets[result.End]
+		value := newValue(piiType, text[start:end], patterns.ExtractContext(text, start, end),
+			pii.Location{Start: start, End: end, RuneStart: result.Start, RuneEnd: result.End})
+		recognizer, _ := result.RecognitionMetadata["recognizer_name"].(string)
+		entities = append(entities, pii.PiiEntity{
+			Type:  piiType,
+			Value: value,
+			Validation: &pii.ValidationResult{
+				Valid:      true,
+				Confidence: result.Score,
+				Provider:   "presidio",
+				Model:      recognizer,
+			},
+		})
+	}
+
+	return pii.NewPiiExtractionResult(entities), nil
+}
+
+// newValue creates the PII value object for a type. Country and subtype fields are left
+// empty because Presidio results do not carry them.
+func newValue(piiType pii.PiiType, value, context string, location pii.Location) pii.Pii {
+	base := pii.BasePii{Value: value, Contexts: []string{context}, Count: 1, Locations: []pii.Location{location}}
+	switch piiType {
+	case pii.PiiTypeEmail:
+		return pii.Email{BasePii: base}
+	case pii.PiiTypePhone:
+		return pii.Phone{BasePii: base}
+	case pii.PiiTypeSSN:
+		return pii.SSN{BasePii: base}
+	case pii.PiiTypeZipCode:
+		return pii.ZipCode{BasePii: base}
+	case pii.PiiTypePoBox:
+		return pii.PoBox{BasePii: base}
+	case pii.PiiTypeStreetAddress:
+		return pii.StreetAddress{BasePii: base}
+	case pii.PiiTypeCreditCard:
+		return pii.CreditCard{BasePii: base}
+	case pii.PiiTypeIPAddress:
+		return pii.IPAddress{BasePii: base}
+	case pii.PiiTypeBtcAddress:
+		return pii.BtcAddress{BasePii: base}
+	default:
+		return pii.IBAN{BasePii: base}
+	}
+}
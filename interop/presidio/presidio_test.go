@@ -0,0 +1,139 @@
+package presidio
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/intMeric/pii-extractor/extractors/regex"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+func TestToAnalyzerResults(t *testing.T) {
+	text := "Mail john@acme.com or john@acme.com, SSN 123-45-6789"
+	result, err := regex.NewDefaultExtractor().Extract(text)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+
+	results := ToAnalyzerResults(text, result)
+
+	var emails, ssns int
+	for i, r := range results {
+		if i > 0 && results[i-1].Start > r.Start {
+			t.Errorf("Results are not sorted by start offset: %+v", results)
+		}
+		switch r.EntityType {
+		case "EMAIL_ADDRESS":
+			emails++
+		case "US_SSN":
+			ssns++
+		}
+		if r.Score != DefaultScore {
+			t.Errorf("Expected default score, got %v", r.Score)
+		}
+		if text[r.Start:r.End] == "" {
+			t.Errorf("Empty span %+v", r)
+		}
+	}
+	if emails != 2 {
+		t.Errorf("Expected one result per email occurrence, got %d", emails)
+	}
+	if ssns != 1 {
+		t.Errorf("Expected 1 SSN result, got %d", ssns)
+	}
+}
+
+func TestFromAnalyzerResults(t *testing.T) {
+	text := "Call 555-123-4567 from 10.0.0.1 in Paris"
+	data := `[
+		{"entity_type": "PHONE_NUMBER", "start": 5, "end": 17, "score": 0.75,
+		 "recognition_metadata": {"recognizer_name": "PhoneRecognizer"}},
+		{"entity_type": "IP_ADDRESS", "start": 23, "end": 31, "score": 0.95},
+		{"entity_type": "PERSON", "start": 35, "end": 40, "score": 0.85}
+	]`
+
+	var results []AnalyzerResult
+	if err := json.Unmarshal([]byte(data), &results); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	result, err := FromAnalyzerResults(text, results)
+	if err != nil {
+		t.Fatalf("FromAnalyzerResults() error = %v", err)
+	}
+	if result.Total != 2 {
+		t.Fatalf("Expected 2 entities (PERSON is unmapped), got %d", result.Total)
+	}
+
+	phones := result.GetPhones()
+	if len(phones) != 1 || phones[0].GetValue() != "555-123-4567" {
+		t.Fatalf("Expected phone 555-123-4567, got %+v", phones)
+	}
+	if v := phones[0].Validation; v == nil || v.Confidence != 0.75 || v.Model != "PhoneRecognizer" {
+		t.Errorf("Expected score kept as validation confidence, got %+v", v)
+	}
+	if len(phones[0].GetContexts()) == 0 {
+		t.Error("Expected context to be extracted from text")
+	}
+}
+
+func TestFromAnalyzerResults_InvalidSpan(t *testing.T) {
+	_, err := FromAnalyzerResults("short", []AnalyzerResult{{EntityType: "US_SSN", Start: 2, End: 20}})
+	if err == nil {
+		t.Error("Expected an error for a span outside the text")
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	text := "IBAN FR7630006000011234567890189 and card 4111 1111 1111 1111"
+	original, err := regex.NewDefaultExtractor().Extract(text)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+
+	imported, err := FromAnalyzerResults(text, ToAnalyzerResults(text, original))
+	if err != nil {
+		t.Fatalf("FromAnalyzerResults() error = %v", err)
+	}
+
+	for _, piiType := range []pii.PiiType{pii.PiiTypeIBAN, pii.PiiTypeCreditCard} {
+		before, after := len(original.GetEntitiesByType(piiType)), len(imported.GetEntitiesByType(piiType))
+		if before == 0 || before != after {
+			t.Errorf("%s entities changed: %d -> %d", piiType, before, after)
+		}
+	}
+}
+
+func TestAnalyzerResults_CharacterOffsets(t *testing.T) {
+	text := "Café résumé: john@acme.com"
+	result, err := regex.NewDefaultExtractor().Extract(text)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+
+	// Presidio counts characters: the email starts at 13, not at byte 16
+	results := ToAnalyzerResults(text, result)
+	if len(results) != 1 || results[0].Start != 13 || results[0].End != 26 {
+		t.Fatalf("Expected the email at characters 13-26, got %+v", results)
+	}
+	if got := string([]rune(text)[results[0].Start:results[0].End]); got != "john@acme.com" {
+		t.Errorf("Character offsets point to %q", got)
+	}
+
+	imported, err := FromAnalyzerResults(text, results)
+	if err != nil {
+		t.Fatalf("FromAnalyzerResults() error = %v", err)
+	}
+	emails := imported.GetEmails()
+	if len(emails) != 1 || emails[0].GetValue() != "john@acme.com" {
+		t.Fatalf("Expected the email back, got %+v", imported.Entities)
+	}
+	if locations := emails[0].GetLocations(); len(locations) != 1 || locations[0].Start != 16 || locations[0].RuneStart != 13 {
+		t.Errorf("Expected byte and character offsets, got %+v", locations)
+	}
+
+	// Spans past the characters of the text are rejected, even if the bytes would fit
+	if _, err := FromAnalyzerResults(text, []AnalyzerResult{{EntityType: "EMAIL_ADDRESS", Start: 13, End: 28}}); err == nil {
+		t.Error("Expected an error for a span past the last character")
+	}
+}
@@ -0,0 +1,108 @@
+// Package ipgeo enriches IP address entities with their country and autonomous system,
+// looked up by a user-provided reader such as an adapter over a MaxMind GeoIP2 or
+// GeoLite2 database, so that policies can depend on where an address is located: public
+// IP addresses of EU users are personal data under the GDPR.
+package ipgeo
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	"github.com/intMeric/pii-extractor/extractors"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// Record is the location of an IP address. Zero fields are unknown.
+type Record struct {
+	Country      pii.Country
+	ASN          uint32
+	Organization string
+}
+
+// Reader looks up the location of public IP addresses. A MaxMind adapter queries the
+// Country and ASN databases:
+//
+//	ipgeo.ReaderFunc(func(addr netip.Addr) (ipgeo.Record, error) {
+//		country, err := countryDB.Country(net.IP(addr.AsSlice()))
+//		if err != nil {
+//			return ipgeo.Record{}, err
+//		}
+//		asn, err := asnDB.ASN(net.IP(addr.AsSlice()))
+//		if err != nil {
+//			return ipgeo.Record{}, err
+//		}
+//		return ipgeo.Record{
+//			Country:      pii.Country(country.Country.IsoCode),
+//			ASN:          uint32(asn.AutonomousSystemNumber),
+//			Organization: asn.AutonomousSystemOrganization,
+//		}, nil
+//	})
+type Reader interface {
+	Lookup(addr netip.Addr) (Record, error)
+}
+
+// ReaderFunc adapts a function to Reader
+type ReaderFunc func(addr netip.Addr) (Record, error)
+
+// Lookup implements Reader
+func (f ReaderFunc) Lookup(addr netip.Addr) (Record, error) {
+	return f(addr)
+}
+
+// IsPublic reports whether an address is globally routable: not private, loopback,
+// link-local, multicast, unspecified or in the carrier-grade NAT range
+func IsPublic(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() && !addr.IsPrivate() && !cgnat.Contains(addr)
+}
+
+// cgnat is the shared address space of carrier-grade NAT (RFC 6598)
+var cgnat = netip.MustParsePrefix("100.64.0.0/10")
+
+// Enrich returns a copy of the result whose IP address entities have their Public flag
+// set and, for public addresses, their country and autonomous system looked up. IPv6
+// addresses embedding an IPv4 address are looked up by the embedded address. Lookup
+// errors are returned with the result enriched so far.
+func Enrich(result *pii.PiiExtractionResult, reader Reader) (*pii.PiiExtractionResult, error) {
+	enriched := *result
+	enriched.Entities = make([]pii.PiiEntity, len(result.Entities))
+	copy(enriched.Entities, result.Entities)
+
+	for i, entity := range enriched.Entities {
+		ip, ok := entity.AsIPAddress()
+		if !ok {
+			continue
+		}
+		addr, err := address(ip)
+		if err != nil {
+			continue
+		}
+		ip.Public = IsPublic(addr)
+		if ip.Public {
+			record, err := reader.Lookup(addr)
+			if err != nil {
+				return &enriched, fmt.Errorf("looking up %s: %w", addr, err)
+			}
+			ip.Country, ip.ASN, ip.Organization = record.Country.Canonical(), record.ASN, record.Organization
+		}
+		entity.Value = ip
+		enriched.Entities[i] = entity
+	}
+	return &enriched, nil
+}
+
+// PostProcessor returns a middleware post-processor enriching the results
+func PostProcessor(reader Reader) extractors.PostProcessor {
+	return func(_ context.Context, _ string, result *pii.PiiExtractionResult) (*pii.PiiExtractionResult, error) {
+		return Enrich(result, reader)
+	}
+}
+
+// address parses the address of an entity, preferring its embedded IPv4 address
+func address(ip pii.IPAddress) (netip.Addr, error) {
+	if ip.EmbeddedIPv4 != "" {
+		return netip.ParseAddr(ip.EmbeddedIPv4)
+	}
+	return netip.ParseAddr(ip.GetValue())
+}
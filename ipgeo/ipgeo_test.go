@@ -0,0 +1,77 @@
+package ipgeo
+
+import (
+	"errors"
+	"net/netip"
+	"testing"
+
+	"github.com/intMeric/pii-extractor/extractors"
+	"github.com/intMeric/pii-extractor/extractors/regex"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+var testReader = ReaderFunc(func(addr netip.Addr) (Record, error) {
+	switch addr.String() {
+	case "81.2.69.160":
+		return Record{Country: "gb", ASN: 20712, Organization: "Andrews & Arnold Ltd"}, nil
+	case "2.125.160.216":
+		return Record{Country: pii.CountryFR, ASN: 3215, Organization: "Orange"}, nil
+	}
+	return Record{}, nil
+})
+
+func TestEnrich(t *testing.T) {
+	extractor := extractors.WithMiddleware(regex.NewDefaultExtractor(), extractors.WithPostProcessor(PostProcessor(testReader)))
+	result, err := extractor.Extract("Login from 2.125.160.216, proxy 10.0.0.8, office 81.2.69.160")
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+
+	ips := map[string]pii.IPAddress{}
+	for _, entity := range result.GetIPAddresses() {
+		ip, _ := entity.AsIPAddress()
+		ips[ip.GetValue()] = ip
+	}
+	if len(ips) != 3 {
+		t.Fatalf("Expected 3 IP addresses, got %v", ips)
+	}
+
+	fr := ips["2.125.160.216"]
+	if !fr.Public || fr.Country != pii.CountryFR || fr.ASN != 3215 || fr.Organization != "Orange" || !fr.Country.InEU() {
+		t.Errorf("Unexpected enrichment %+v", fr)
+	}
+	if gb := ips["81.2.69.160"]; gb.Country != pii.CountryGB {
+		t.Errorf("Expected the country code canonicalized, got %q", gb.Country)
+	}
+	if private := ips["10.0.0.8"]; private.Public || private.Country != "" {
+		t.Errorf("Expected private addresses not to be looked up, got %+v", private)
+	}
+
+	eu := result.GetEntitiesByCountry(pii.CountryFR)
+	if len(eu) != 1 || eu[0].GetValue() != "2.125.160.216" {
+		t.Errorf("Expected the located address in the country's entities, got %v", eu)
+	}
+}
+
+func TestEnrich_LookupError(t *testing.T) {
+	failing := ReaderFunc(func(netip.Addr) (Record, error) { return Record{}, errors.New("database closed") })
+	result := pii.NewPiiExtractionResult([]pii.PiiEntity{{Type: pii.PiiTypeIPAddress, Value: pii.NewIPAddress("8.8.8.8", "ipv4")}})
+	if _, err := Enrich(result, failing); err == nil {
+		t.Error("Expected the lookup error")
+	}
+	if ip, _ := result.Entities[0].AsIPAddress(); ip.Public {
+		t.Error("Expected the source result to be left untouched")
+	}
+}
+
+func TestIsPublic(t *testing.T) {
+	for value, want := range map[string]bool{
+		"8.8.8.8": true, "2001:4860:4860::8888": true, "::ffff:8.8.8.8": true,
+		"10.1.2.3": false, "192.168.0.1": false, "127.0.0.1": false, "100.64.0.1": false,
+		"fe80::1": false, "fd00::1": false, "224.0.0.1": false,
+	} {
+		if got := IsPublic(netip.MustParseAddr(value)); got != want {
+			t.Errorf("IsPublic(%s) = %v, want %v", value, got, want)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package logs
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// JournalField is a field of a journal entry
+type JournalField struct {
+	Name  string
+	Value string
+}
+
+// JournalEntry is an entry of the journald export format, with its fields in order
+type JournalEntry []JournalField
+
+// Get returns the value of a field
+func (e JournalEntry) Get(name string) (string, bool) {
+	for _, field := range e {
+		if field.Name == name {
+			return field.Value, true
+		}
+	}
+	return "", false
+}
+
+// JournalReader reads entries in the journald export format, as written by
+// "journalctl -o export"
+type JournalReader struct {
+	reader *bufio.Reader
+}
+
+// NewJournalReader creates a reader of journald export data
+func NewJournalReader(r io.Reader) *JournalReader {
+	return &JournalReader{reader: bufio.NewReader(r)}
+}
+
+// Next returns the next entry, or io.EOF after the last one. Fields are at most
+// MaxLineLength bytes long; a longer field fails with an error.
+func (j *JournalReader) Next() (JournalEntry, error) {
+	var entry JournalEntry
+	for {
+		data, err := readDelimited(j.reader, '\n', MaxLineLength+len("\n"), "journal: field")
+		line := string(data)
+		if err == io.EOF && line == "" {
+			if entry == nil {
+				return nil, io.EOF
+			}
+			return entry, nil
+		}
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			if entry == nil {
+				continue
+			}
+			return entry, nil
+		}
+
+		if name, value, ok := strings.Cut(line, "="); ok {
+			entry = append(entry, JournalField{Name: name, Value: value})
+			continue
+		}
+
+		// Binary-safe field: name, 64-bit little endian length, data, newline
+		var size uint64
+		if err := binary.Read(j.reader, binary.LittleEndian, &size); err != nil {
+			return nil, errors.New("journal: truncated binary field " + line)
+		}
+		if size > MaxLineLength {
+			return nil, errors.New("journal: binary field too large " + line)
+		}
+		value := make([]byte, size+1)
+		if _, err := io.ReadFull(j.reader, value); err != nil {
+			return nil, errors.New("journal: truncated binary field " + line)
+		}
+		entry = append(entry, JournalField{Name: line, Value: string(value[:size])})
+	}
+}
+
+// WriteJournalEntry writes an entry in the journald export format, using the binary-safe
+// encoding for values containing newlines
+func WriteJournalEntry(w io.Writer, entry JournalEntry) error {
+	var b bytes.Buffer
+	for _, field := range entry {
+		if strings.ContainsRune(field.Value, '\n') {
+			b.WriteString(field.Name + "\n")
+			binary.Write(&b, binary.LittleEndian, uint64(len(field.Value)))
+			b.WriteString(field.Value + "\n")
+			continue
+		}
+		b.WriteString(field.Name + "=" + field.Value + "\n")
+	}
+	b.WriteByte('\n')
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// SanitizeJournalEntry sanitizes the fields of a journal entry by the field rules.
+// MESSAGE is sanitized as a log line of any format. Fields starting with '_' are trusted
+// metadata added by journald and are kept.
+func (s *Sanitizer) SanitizeJournalEntry(ctx context.Context, entry JournalEntry) (JournalEntry, map[string]*pii.PiiExtractionResult, error) {
+	fields := make(map[string]*pii.PiiExtractionResult)
+	sanitized := make(JournalEntry, 0, len(entry))
+	for _, field := range entry {
+		if strings.HasPrefix(field.Name, "_") {
+			sanitized = append(sanitized, field)
+			continue
+		}
+		switch s.rules.action(field.Name) {
+		case ActionDrop:
+			continue
+		case ActionMask:
+			field.Value = Redacted
+		case ActionScan:
+			if field.Name == "MESSAGE" {
+				line, err := s.SanitizeLine(ctx, field.Value)
+				if err != nil {
+					return nil, nil, err
+				}
+				field.Value = line.Text
+				for path, result := range line.Fields {
+					fields[strings.TrimSuffix("MESSAGE."+path, ".")] = result
+				}
+				break
+			}
+			var err error
+			if field.Value, err = s.scan(ctx, field.Name, field.Value, fields); err != nil {
+				return nil, nil, err
+			}
+		}
+		sanitized = append(sanitized, field)
+	}
+	return sanitized, fields, nil
+}
+
+// SanitizeJournal sanitizes every entry of journald export data from r and writes the
+// sanitized entries to w in the same format, e.g. for systemd-journal-remote
+func (s *Sanitizer) SanitizeJournal(ctx context.Context, r io.Reader, w io.Writer) (*Stats, error) {
+	stats := &Stats{Types: make(map[pii.PiiType]int)}
+	reader := NewJournalReader(r)
+	out := bufio.NewWriter(w)
+	for {
+		entry, err := reader.Next()
+		if err == io.EOF {
+			return stats, out.Flush()
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		sanitized, fields, err := s.SanitizeJournalEntry(ctx, entry)
+		if err != nil {
+			return nil, err
+		}
+		stats.record(!slices.Equal(sanitized, entry), fields)
+		if err := WriteJournalEntry(out, sanitized); err != nil {
+			return nil, err
+		}
+	}
+}
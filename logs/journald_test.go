@@ -0,0 +1,73 @@
+package logs
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+func TestSanitizeJournal(t *testing.T) {
+	var input bytes.Buffer
+	input.WriteString("__CURSOR=s=1\n_HOSTNAME=john@acme.com\nPRIORITY=6\nMESSAGE=login by john@acme.com\nPASSWORD=hunter2\n\n")
+	// Binary-safe multi-line message
+	message := "line one\nssn 123-45-6789"
+	input.WriteString("__CURSOR=s=2\nMESSAGE\n")
+	binary.Write(&input, binary.LittleEndian, uint64(len(message)))
+	input.WriteString(message + "\n\n")
+
+	var out bytes.Buffer
+	stats, err := NewSanitizer(DefaultRules()).SanitizeJournal(context.Background(), &input, &out)
+	if err != nil {
+		t.Fatalf("SanitizeJournal() error = %v", err)
+	}
+	if stats.Lines != 2 || stats.Changed != 2 || stats.Types[pii.PiiTypeEmail] != 1 || stats.Types[pii.PiiTypeSSN] != 1 {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+
+	reader := NewJournalReader(&out)
+	first, err := reader.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value, _ := first.Get("MESSAGE"); value != "login by [EMAIL]" {
+		t.Errorf("Expected a sanitized message, got %q", value)
+	}
+	if value, _ := first.Get("_HOSTNAME"); value != "john@acme.com" {
+		t.Errorf("Expected trusted fields to be kept, got %q", value)
+	}
+	if _, ok := first.Get("PASSWORD"); ok {
+		t.Error("Expected the password field to be dropped")
+	}
+
+	second, err := reader.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value, _ := second.Get("MESSAGE"); value != "line one\nssn [SSN]" {
+		t.Errorf("Expected a sanitized binary-safe message, got %q", value)
+	}
+	if _, err := reader.Next(); err != io.EOF {
+		t.Errorf("Expected io.EOF, got %v", err)
+	}
+}
+
+func TestJournalReader_FieldTooLong(t *testing.T) {
+	input := "MESSAGE=" + strings.Repeat("a", MaxLineLength) + "\n\n"
+	if _, err := NewJournalReader(strings.NewReader(input)).Next(); err == nil || !strings.Contains(err.Error(), "field longer than") {
+		t.Errorf("Expected a too long field error, got %v", err)
+	}
+
+	input = "MESSAGE=" + strings.Repeat("a", MaxLineLength-len("MESSAGE=")) + "\n\n"
+	entry, err := NewJournalReader(strings.NewReader(input)).Next()
+	if err != nil {
+		t.Fatalf("Next() error = %v", err)
+	}
+	if value, _ := entry.Get("MESSAGE"); len(value) != MaxLineLength-len("MESSAGE=") {
+		t.Errorf("Expected the longest field to be read, got %d bytes", len(value))
+	}
+}
@@ -0,0 +1,186 @@
+package logs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// errSyntax reports a line that does not parse in its expected format
+var errSyntax = errors.New("syntax error")
+
+// member is a field of a JSON object, kept in its original order
+type member struct {
+	key   string
+	value any // string, json.Number, bool, nil, []any or []member
+}
+
+// sanitizeJSON sanitizes a JSON object line, preserving its field order
+func (s *Sanitizer) sanitizeJSON(ctx context.Context, line string, fields map[string]*pii.PiiExtractionResult) (string, error) {
+	decoder := json.NewDecoder(strings.NewReader(line))
+	decoder.UseNumber()
+	value, err := decodeValue(decoder)
+	if err != nil {
+		return "", errSyntax
+	}
+	object, ok := value.([]member)
+	if !ok {
+		return "", errSyntax
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return "", errSyntax
+	}
+
+	object, err = s.sanitizeObject(ctx, "", object, fields)
+	if err != nil {
+		return "", err
+	}
+	var out bytes.Buffer
+	if err := encodeValue(&out, object); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// sanitizeObject applies the field rules to the members of an object
+func (s *Sanitizer) sanitizeObject(ctx context.Context, prefix string, object []member, fields map[string]*pii.PiiExtractionResult) ([]member, error) {
+	sanitized := make([]member, 0, len(object))
+	for _, m := range object {
+		path := m.key
+		if prefix != "" {
+			path = prefix + "." + m.key
+		}
+		action := s.rules.action(path)
+		switch action {
+		case ActionDrop:
+			continue
+		case ActionMask:
+			m.value = Redacted
+		case ActionScan:
+			var err error
+			if m.value, err = s.sanitizeValue(ctx, path, m.value, fields); err != nil {
+				return nil, err
+			}
+		}
+		sanitized = append(sanitized, m)
+	}
+	return sanitized, nil
+}
+
+// sanitizeValue scans a JSON value. Numbers are scanned as text and become masked strings
+// when they hold PII, such as card numbers logged as numbers.
+func (s *Sanitizer) sanitizeValue(ctx context.Context, path string, value any, fields map[string]*pii.PiiExtractionResult) (any, error) {
+	switch value := value.(type) {
+	case string:
+		return s.scan(ctx, path, value, fields)
+	case json.Number:
+		masked, err := s.scan(ctx, path, value.String(), fields)
+		if err != nil || masked == value.String() {
+			return value, err
+		}
+		return masked, nil
+	case []member:
+		return s.sanitizeObject(ctx, path, value, fields)
+	case []any:
+		for i, item := range value {
+			var err error
+			if value[i], err = s.sanitizeValue(ctx, path, item, fields); err != nil {
+				return nil, err
+			}
+		}
+		return value, nil
+	default:
+		return value, nil
+	}
+}
+
+// decodeValue decodes the next JSON value, keeping object members in order
+func decodeValue(decoder *json.Decoder) (any, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch token {
+	case json.Delim('{'):
+		var object []member
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			object = append(object, member{key: key.(string), value: value})
+		}
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+		if object == nil {
+			object = []member{}
+		}
+		return object, nil
+	case json.Delim('['):
+		array := []any{}
+		for decoder.More() {
+			value, err := decodeValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, value)
+		}
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+		return array, nil
+	default:
+		return token, nil
+	}
+}
+
+// encodeValue writes a decoded JSON value
+func encodeValue(out *bytes.Buffer, value any) error {
+	switch value := value.(type) {
+	case []member:
+		out.WriteByte('{')
+		for i, m := range value {
+			if i > 0 {
+				out.WriteByte(',')
+			}
+			if err := encodeValue(out, m.key); err != nil {
+				return err
+			}
+			out.WriteByte(':')
+			if err := encodeValue(out, m.value); err != nil {
+				return err
+			}
+		}
+		out.WriteByte('}')
+	case []any:
+		out.WriteByte('[')
+		for i, item := range value {
+			if i > 0 {
+				out.WriteByte(',')
+			}
+			if err := encodeValue(out, item); err != nil {
+				return err
+			}
+		}
+		out.WriteByte(']')
+	default:
+		encoder := json.NewEncoder(out)
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(value); err != nil {
+			return err
+		}
+		// Encode terminates every value with a newline
+		out.Truncate(out.Len() - 1)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package logs
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// pair is a key=value pair of a logfmt record
+type pair struct {
+	key      string
+	value    string
+	hasValue bool // false for bare keys
+	quoted   bool
+}
+
+// sanitizeLogfmt sanitizes a logfmt line
+func (s *Sanitizer) sanitizeLogfmt(ctx context.Context, line string, fields map[string]*pii.PiiExtractionResult) (string, error) {
+	pairs, err := parseLogfmt(line)
+	if err != nil {
+		return "", err
+	}
+
+	var parts []string
+	for _, p := range pairs {
+		switch s.rules.action(p.key) {
+		case ActionDrop:
+			continue
+		case ActionMask:
+			if p.hasValue {
+				p.value, p.quoted = Redacted, false
+			}
+		case ActionScan:
+			if p.hasValue {
+				masked, err := s.scan(ctx, p.key, p.value, fields)
+				if err != nil {
+					return "", err
+				}
+				if masked != p.value {
+					p.value, p.quoted = masked, false
+				}
+			}
+		}
+		parts = append(parts, formatPair(p))
+	}
+	return strings.Join(parts, " "), nil
+}
+
+// parseLogfmt parses space-separated key=value pairs, where values may be double-quoted
+// with Go string escapes
+func parseLogfmt(line string) ([]pair, error) {
+	var pairs []pair
+	i := 0
+	for {
+		for i < len(line) && (line[i] == ' ' || line[i] == '\t') {
+			i++
+		}
+		if i == len(line) {
+			return pairs, nil
+		}
+
+		start := i
+		for i < len(line) && line[i] != '=' && line[i] != ' ' && line[i] != '\t' && line[i] != '"' {
+			i++
+		}
+		if i == start {
+			return nil, errSyntax
+		}
+		p := pair{key: line[start:i]}
+		if i < len(line) && line[i] == '=' {
+			p.hasValue = true
+			i++
+			if i < len(line) && line[i] == '"' {
+				end := i + 1
+				for end < len(line) && line[end] != '"' {
+					if line[end] == '\\' {
+						end++
+					}
+					end++
+				}
+				if end >= len(line) {
+					return nil, errSyntax
+				}
+				value, err := strconv.Unquote(line[i : end+1])
+				if err != nil {
+					return nil, errSyntax
+				}
+				p.value, p.quoted = value, true
+				i = end + 1
+			} else {
+				start = i
+				for i < len(line) && line[i] != ' ' && line[i] != '\t' {
+					i++
+				}
+				p.value = line[start:i]
+				if strings.ContainsRune(p.value, '"') {
+					return nil, errSyntax
+				}
+			}
+		} else if i < len(line) && line[i] == '"' {
+			return nil, errSyntax
+		}
+		pairs = append(pairs, p)
+	}
+}
+
+// formatPair writes a pair back, quoting values that need it
+func formatPair(p pair) string {
+	if !p.hasValue {
+		return p.key
+	}
+	if p.quoted || p.value == "" || strings.ContainsAny(p.value, " \t=\"\\") || strings.ContainsFunc(p.value, func(r rune) bool { return r < ' ' }) {
+		return p.key + "=" + strconv.Quote(p.value)
+	}
+	return p.key + "=" + p.value
+}
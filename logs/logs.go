@@ -0,0 +1,248 @@
+// Package logs sanitizes structured log lines. JSON lines and logfmt records are parsed
+// into fields, each field is dropped, masked, kept or scanned according to field rules,
+// and the record is written back in its original format with the detected PII masked.
+package logs
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"strings"
+
+	"github.com/intMeric/pii-extractor/extractors"
+	"github.com/intMeric/pii-extractor/extractors/regex"
+	"github.com/intMeric/pii-extractor/pii"
+	"github.com/intMeric/pii-extractor/redact"
+)
+
+// Redacted replaces the value of fields with ActionMask
+const Redacted = "[REDACTED]"
+
+// MaxLineLength is the longest line Sanitize accepts
+const MaxLineLength = 1 << 20
+
+// Format is the format of a log line
+type Format string
+
+const (
+	FormatAuto   Format = ""       // Detect the format of every line
+	FormatJSON   Format = "json"   // JSON object per line
+	FormatLogfmt Format = "logfmt" // key=value pairs
+	FormatText   Format = "text"   // Unstructured text, scanned as a whole
+)
+
+// Action is the treatment of a field
+type Action string
+
+const (
+	ActionScan Action = "scan" // Extract PII from the value and mask it
+	ActionDrop Action = "drop" // Remove the field from the record
+	ActionMask Action = "mask" // Replace the whole value with Redacted
+	ActionKeep Action = "keep" // Leave the value untouched
+)
+
+// Rules maps field names to actions. A rule matches the dot-separated path of a nested
+// JSON field ("user.email") or its last key ("email"), case-insensitively; the full
+// path takes precedence.
+type Rules struct {
+	Fields map[string]Action
+
+	// Default is the action of fields without a rule (empty = ActionScan)
+	Default Action
+}
+
+// DefaultRules drops common credential fields, masks session fields and scans everything
+// else
+func DefaultRules() Rules {
+	return Rules{Fields: map[string]Action{
+		"password":      ActionDrop,
+		"passwd":        ActionDrop,
+		"secret":        ActionDrop,
+		"authorization": ActionMask,
+		"auth":          ActionMask,
+		"cookie":        ActionMask,
+		"set-cookie":    ActionMask,
+		"api_key":       ActionMask,
+		"token":         ActionMask,
+		"access_token":  ActionMask,
+		"refresh_token": ActionMask,
+		"session_id":    ActionMask,
+		"sessionid":     ActionMask,
+		"jsessionid":    ActionMask,
+	}}
+}
+
+// action returns the action of a field
+func (r Rules) action(path string) Action {
+	path = strings.ToLower(path)
+	for name, action := range r.Fields {
+		if strings.ToLower(name) == path {
+			return action
+		}
+	}
+	if i := strings.LastIndexByte(path, '.'); i >= 0 {
+		key := path[i+1:]
+		for name, action := range r.Fields {
+			if strings.ToLower(name) == key {
+				return action
+			}
+		}
+	}
+	if r.Default != "" {
+		return r.Default
+	}
+	return ActionScan
+}
+
+// Line is a sanitized log line
+type Line struct {
+	Text   string                              // Sanitized line
+	Format Format                              // Detected format
+	Fields map[string]*pii.PiiExtractionResult // Findings of the scanned fields with PII; "" for text lines
+}
+
+// Sanitizer sanitizes log lines
+type Sanitizer struct {
+	extractor extractors.PiiExtractor
+	redactor  *redact.Redactor
+	rules     Rules
+	format    Format
+}
+
+// Option configures a Sanitizer
+type Option func(*Sanitizer)
+
+// WithExtractor sets the extractor run on scanned fields (default regex extractor with
+// HTTP log awareness)
+func WithExtractor(extractor extractors.PiiExtractor) Option {
+	return func(s *Sanitizer) { s.extractor = extractor }
+}
+
+// WithRedactor sets the redactor masking scanned fields (default redact.PresetStrict)
+func WithRedactor(redactor *redact.Redactor) Option {
+	return func(s *Sanitizer) { s.redactor = redactor }
+}
+
+// WithFormat forces the format of every line instead of detecting it
+func WithFormat(format Format) Option {
+	return func(s *Sanitizer) { s.format = format }
+}
+
+// NewSanitizer creates a sanitizer applying the given field rules
+func NewSanitizer(rules Rules, options ...Option) *Sanitizer {
+	s := &Sanitizer{rules: rules}
+	for _, option := range options {
+		option(s)
+	}
+	if s.extractor == nil {
+		s.extractor = regex.NewExtractor(&extractors.ExtractorConfig{
+			Options: map[string]any{regex.OptionHTTPLogs: true},
+		})
+	}
+	if s.redactor == nil {
+		s.redactor = redact.New(redact.PresetStrict)
+	}
+	return s
+}
+
+// SanitizeLine sanitizes one log line. Lines that do not parse in the expected format
+// are scanned as text.
+func (s *Sanitizer) SanitizeLine(ctx context.Context, line string) (*Line, error) {
+	format := s.format
+	if format == FormatAuto {
+		format = Detect(line)
+	}
+
+	sanitized := &Line{Format: format, Fields: make(map[string]*pii.PiiExtractionResult)}
+	var err error
+	switch format {
+	case FormatJSON:
+		sanitized.Text, err = s.sanitizeJSON(ctx, line, sanitized.Fields)
+	case FormatLogfmt:
+		sanitized.Text, err = s.sanitizeLogfmt(ctx, line, sanitized.Fields)
+	}
+	if format == FormatText || err == errSyntax {
+		sanitized.Format = FormatText
+		sanitized.Text, err = s.scan(ctx, "", line, sanitized.Fields)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return sanitized, nil
+}
+
+// Stats summarizes a sanitized stream
+type Stats struct {
+	Lines   int                 `json:"lines"`   // Lines, or journal entries
+	Changed int                 `json:"changed"` // Lines with dropped, masked or redacted fields
+	Types   map[pii.PiiType]int `json:"types"`   // Lines containing each type
+}
+
+// record counts a sanitized line or entry
+func (s *Stats) record(changed bool, fields map[string]*pii.PiiExtractionResult) {
+	s.Lines++
+	if changed {
+		s.Changed++
+	}
+	seen := make(map[pii.PiiType]bool)
+	for _, result := range fields {
+		for piiType := range result.Stats {
+			if !seen[piiType] {
+				seen[piiType] = true
+				s.Types[piiType]++
+			}
+		}
+	}
+}
+
+// Sanitize sanitizes every line of r and writes the result to w
+func (s *Sanitizer) Sanitize(ctx context.Context, r io.Reader, w io.Writer) (*Stats, error) {
+	stats := &Stats{Types: make(map[pii.PiiType]int)}
+	lines := bufio.NewScanner(r)
+	lines.Buffer(make([]byte, 0, 64*1024), MaxLineLength)
+	out := bufio.NewWriter(w)
+
+	for lines.Scan() {
+		line := lines.Text()
+		sanitized, err := s.SanitizeLine(ctx, line)
+		if err != nil {
+			return nil, err
+		}
+		stats.record(sanitized.Text != line, sanitized.Fields)
+		out.WriteString(sanitized.Text)
+		if err := out.WriteByte('\n'); err != nil {
+			return nil, err
+		}
+	}
+	if err := lines.Err(); err != nil {
+		return nil, err
+	}
+	return stats, out.Flush()
+}
+
+// Detect returns the format of a line: JSON for an object, logfmt when it holds key=value
+// pairs and text otherwise
+func Detect(line string) Format {
+	trimmed := strings.TrimSpace(line)
+	if strings.HasPrefix(trimmed, "{") && strings.HasSuffix(trimmed, "}") {
+		return FormatJSON
+	}
+	if pairs, err := parseLogfmt(line); err == nil && len(pairs) > 0 && pairs[0].hasValue {
+		return FormatLogfmt
+	}
+	return FormatText
+}
+
+// scan extracts the PII of a field value and returns the value with the PII masked,
+// recording the findings in fields
+func (s *Sanitizer) scan(ctx context.Context, path, value string, fields map[string]*pii.PiiExtractionResult) (string, error) {
+	result, err := extractors.ExtractWithContext(ctx, s.extractor, value)
+	if err != nil {
+		return "", err
+	}
+	if result.IsEmpty() {
+		return value, nil
+	}
+	fields[path] = result
+	return s.redactor.Redact(value, result), nil
+}
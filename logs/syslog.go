@@ -0,0 +1,368 @@
+package logs
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// nilValue is the RFC 5424 placeholder of empty header fields
+const nilValue = "-"
+
+// SDParam is a parameter of a structured data element
+type SDParam struct {
+	Name  string
+	Value string
+}
+
+// SDElement is a structured data element, such as [origin ip="192.0.2.1"]
+type SDElement struct {
+	ID     string
+	Params []SDParam
+}
+
+// SyslogMessage is an RFC 5424 syslog message. Empty header fields are the nil value.
+type SyslogMessage struct {
+	Priority       int // facility*8 + severity
+	Version        int
+	Timestamp      string
+	Hostname       string
+	AppName        string
+	ProcID         string
+	MsgID          string
+	StructuredData []SDElement
+	Message        string
+}
+
+// Facility returns the facility of the message
+func (m *SyslogMessage) Facility() int {
+	return m.Priority / 8
+}
+
+// Severity returns the syslog severity of the message (0 emergency to 7 debug)
+func (m *SyslogMessage) Severity() int {
+	return m.Priority % 8
+}
+
+// String formats the message as an RFC 5424 line
+func (m *SyslogMessage) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "<%d>%d", m.Priority, m.Version)
+	for _, field := range []string{m.Timestamp, m.Hostname, m.AppName, m.ProcID, m.MsgID} {
+		if field == "" {
+			field = nilValue
+		}
+		b.WriteString(" " + field)
+	}
+	b.WriteByte(' ')
+	if len(m.StructuredData) == 0 {
+		b.WriteString(nilValue)
+	}
+	for _, element := range m.StructuredData {
+		b.WriteString("[" + element.ID)
+		for _, param := range element.Params {
+			b.WriteString(" " + param.Name + `="` + sdEscaper.Replace(param.Value) + `"`)
+		}
+		b.WriteByte(']')
+	}
+	if m.Message != "" {
+		b.WriteString(" " + m.Message)
+	}
+	return b.String()
+}
+
+// sdEscaper escapes the characters RFC 5424 requires to be escaped in parameter values
+var sdEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`)
+
+// ParseSyslog parses an RFC 5424 syslog message. A UTF-8 byte order mark before the
+// message text is dropped.
+func ParseSyslog(line string) (*SyslogMessage, error) {
+	line = strings.TrimRight(line, "\r\n")
+	if !strings.HasPrefix(line, "<") {
+		return nil, errors.New("syslog: missing priority")
+	}
+	end := strings.IndexByte(line, '>')
+	if end < 2 || end > 4 {
+		return nil, errors.New("syslog: invalid priority")
+	}
+	priority, err := strconv.Atoi(line[1:end])
+	if err != nil || priority > 191 {
+		return nil, errors.New("syslog: invalid priority")
+	}
+
+	m := &SyslogMessage{Priority: priority}
+	rest := line[end+1:]
+	header := make([]string, 6)
+	for i := range header {
+		field, remaining, ok := strings.Cut(rest, " ")
+		if !ok && i < len(header)-1 {
+			return nil, errors.New("syslog: truncated header")
+		}
+		header[i], rest = field, remaining
+	}
+	if m.Version, err = strconv.Atoi(header[0]); err != nil {
+		return nil, errors.New("syslog: invalid version")
+	}
+	m.Timestamp, m.Hostname, m.AppName, m.ProcID, m.MsgID = nilToEmpty(header[1]), nilToEmpty(header[2]),
+		nilToEmpty(header[3]), nilToEmpty(header[4]), nilToEmpty(header[5])
+
+	if strings.HasPrefix(rest, nilValue) {
+		rest = rest[1:]
+	} else {
+		if m.StructuredData, rest, err = parseStructuredData(rest); err != nil {
+			return nil, err
+		}
+	}
+	if rest != "" {
+		if rest[0] != ' ' {
+			return nil, errors.New("syslog: invalid structured data")
+		}
+		m.Message = strings.TrimPrefix(rest[1:], "\ufeff")
+	}
+	return m, nil
+}
+
+// parseStructuredData parses the structured data elements at the start of s and returns
+// the remaining text
+func parseStructuredData(s string) ([]SDElement, string, error) {
+	var elements []SDElement
+	for strings.HasPrefix(s, "[") {
+		end := strings.IndexAny(s, " ]")
+		if end < 0 {
+			return nil, "", errors.New("syslog: unterminated structured data")
+		}
+		element := SDElement{ID: s[1:end]}
+		s = s[end:]
+		for strings.HasPrefix(s, " ") {
+			eq := strings.Index(s, `="`)
+			if eq < 0 {
+				return nil, "", errors.New("syslog: invalid structured data parameter")
+			}
+			param := SDParam{Name: s[1:eq]}
+			var value strings.Builder
+			i := eq + 2
+			for ; i < len(s) && s[i] != '"'; i++ {
+				if s[i] == '\\' && i+1 < len(s) && strings.IndexByte(`\"]`, s[i+1]) >= 0 {
+					i++
+				}
+				value.WriteByte(s[i])
+			}
+			if i == len(s) {
+				return nil, "", errors.New("syslog: unterminated structured data parameter")
+			}
+			param.Value = value.String()
+			element.Params = append(element.Params, param)
+			s = s[i+1:]
+		}
+		if !strings.HasPrefix(s, "]") {
+			return nil, "", errors.New("syslog: unterminated structured data element")
+		}
+		elements = append(elements, element)
+		s = s[1:]
+	}
+	if elements == nil {
+		return nil, "", errors.New("syslog: invalid structured data")
+	}
+	return elements, s, nil
+}
+
+func nilToEmpty(field string) string {
+	if field == nilValue {
+		return ""
+	}
+	return field
+}
+
+// SanitizeSyslog sanitizes a syslog message: the message text as a log line of any
+// format, and structured data parameters by the field rules, matched by "id.name" or name.
+// It returns the findings per field, the message text under "msg".
+func (s *Sanitizer) SanitizeSyslog(ctx context.Context, m *SyslogMessage) (map[string]*pii.PiiExtractionResult, error) {
+	fields := make(map[string]*pii.PiiExtractionResult)
+	for i := range m.StructuredData {
+		element := &m.StructuredData[i]
+		params := element.Params[:0]
+		for _, param := range element.Params {
+			path := element.ID + "." + param.Name
+			switch s.rules.action(path) {
+			case ActionDrop:
+				continue
+			case ActionMask:
+				param.Value = Redacted
+			case ActionScan:
+				var err error
+				if param.Value, err = s.scan(ctx, path, param.Value, fields); err != nil {
+					return nil, err
+				}
+			}
+			params = append(params, param)
+		}
+		element.Params = params
+	}
+
+	if m.Message != "" {
+		line, err := s.SanitizeLine(ctx, m.Message)
+		if err != nil {
+			return nil, err
+		}
+		m.Message = line.Text
+		for path, result := range line.Fields {
+			if path == "" {
+				path = "msg"
+			} else {
+				path = "msg." + path
+			}
+			fields[path] = result
+		}
+	}
+	return fields, nil
+}
+
+// SyslogHandler receives every sanitized syslog message, e.g. to forward it off-box
+type SyslogHandler func(ctx context.Context, m *SyslogMessage) error
+
+// SyslogServer receives RFC 5424 messages over UDP (RFC 5426) or TCP (RFC 6587 octet
+// counting or newline framing), sanitizes them and passes them to a handler. Serve calls
+// the handler concurrently from one goroutine per connection.
+type SyslogServer struct {
+	Sanitizer *Sanitizer
+	Handler   SyslogHandler
+
+	// ErrorLog receives malformed messages and handler errors; nil drops them
+	ErrorLog func(err error)
+}
+
+// ServePacket reads one message per datagram from conn until ctx is done or conn fails
+func (srv *SyslogServer) ServePacket(ctx context.Context, conn net.PacketConn) error {
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	buffer := make([]byte, 64*1024)
+	for {
+		n, _, err := conn.ReadFrom(buffer)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		srv.handle(ctx, string(buffer[:n]))
+	}
+}
+
+// Serve accepts stream connections on ln until ctx is done or ln fails
+func (srv *SyslogServer) Serve(ctx context.Context, ln net.Listener) error {
+	stop := context.AfterFunc(ctx, func() { ln.Close() })
+	defer stop()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer conn.Close()
+			closeConn := context.AfterFunc(ctx, func() { conn.Close() })
+			defer closeConn()
+			if err := srv.ServeStream(ctx, conn); err != nil && ctx.Err() == nil {
+				srv.logError(err)
+			}
+		}()
+	}
+}
+
+// ServeStream reads framed messages from r until EOF. Each frame is either octet
+// counted ("<length> <message>") or terminated by a newline, and is at most
+// MaxLineLength bytes long; a longer frame ends the stream with an error.
+func (srv *SyslogServer) ServeStream(ctx context.Context, r io.Reader) error {
+	reader := bufio.NewReader(r)
+	for {
+		first, err := reader.Peek(1)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var frame string
+		if first[0] >= '1' && first[0] <= '9' {
+			length, err := readDelimited(reader, ' ', len(strconv.Itoa(MaxLineLength))+1, "syslog: frame")
+			if err != nil {
+				return err
+			}
+			n, err := strconv.Atoi(strings.TrimSpace(string(length)))
+			if err != nil || n > MaxLineLength {
+				return fmt.Errorf("syslog: invalid frame length %q", length)
+			}
+			data := make([]byte, n)
+			if _, err := io.ReadFull(reader, data); err != nil {
+				return err
+			}
+			frame = string(data)
+		} else {
+			line, err := readDelimited(reader, '\n', MaxLineLength+len("\r\n"), "syslog: frame")
+			if err != nil && (err != io.EOF || len(line) == 0) {
+				return err
+			}
+			frame = string(bytes.TrimRight(line, "\r\n"))
+		}
+		if frame != "" {
+			srv.handle(ctx, frame)
+		}
+	}
+}
+
+// readDelimited reads until and including delim, failing once more than limit bytes are
+// read without finding it, so that a client cannot make the server buffer an endless
+// frame. what names the data in the error.
+func readDelimited(reader *bufio.Reader, delim byte, limit int, what string) ([]byte, error) {
+	var data []byte
+	for {
+		chunk, err := reader.ReadSlice(delim)
+		if len(data)+len(chunk) > limit {
+			return nil, fmt.Errorf("%s longer than %d bytes", what, limit)
+		}
+		data = append(data, chunk...)
+		if err != bufio.ErrBufferFull {
+			return data, err
+		}
+	}
+}
+
+// handle parses, sanitizes and hands over one message
+func (srv *SyslogServer) handle(ctx context.Context, frame string) {
+	m, err := ParseSyslog(frame)
+	if err != nil {
+		srv.logError(err)
+		return
+	}
+	if _, err := srv.Sanitizer.SanitizeSyslog(ctx, m); err != nil {
+		srv.logError(err)
+		return
+	}
+	if err := srv.Handler(ctx, m); err != nil {
+		srv.logError(err)
+	}
+}
+
+func (srv *SyslogServer) logError(err error) {
+	if srv.ErrorLog != nil {
+		srv.ErrorLog(err)
+	}
+}
@@ -0,0 +1,153 @@
+package logs
+
+import (
+	"context"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestParseSyslog(t *testing.T) {
+	line := `<165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog - ID47 [exampleSDID@32473 iut="3" eventSource="App\"lication" eventID="1011"][examplePriority@32473 class="high"] ` + "\ufeff" + `An application event log entry`
+	m, err := ParseSyslog(line)
+	if err != nil {
+		t.Fatalf("ParseSyslog() error = %v", err)
+	}
+	if m.Facility() != 20 || m.Severity() != 5 || m.Hostname != "mymachine.example.com" || m.ProcID != "" || m.MsgID != "ID47" {
+		t.Errorf("Unexpected header %+v", m)
+	}
+	if len(m.StructuredData) != 2 || m.StructuredData[0].Params[1].Value != `App"lication` {
+		t.Errorf("Unexpected structured data %+v", m.StructuredData)
+	}
+	if m.Message != "An application event log entry" {
+		t.Errorf("Unexpected message %q", m.Message)
+	}
+	if !strings.HasPrefix(m.String(), `<165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog - ID47 [exampleSDID@32473 iut="3" eventSource="App\"lication"`) {
+		t.Errorf("Unexpected formatting %q", m.String())
+	}
+
+	for _, invalid := range []string{"no priority", "<999>1 - - - - -", "<34>1 truncated", `<34>1 - - - - - [id x="1"`} {
+		if _, err := ParseSyslog(invalid); err == nil {
+			t.Errorf("Expected an error for %q", invalid)
+		}
+	}
+}
+
+func TestSanitizeSyslog(t *testing.T) {
+	m, err := ParseSyslog(`<34>1 - host app 42 - [auth@1 user="john@acme.com" password="x"] login by {"ssn":"123-45-6789"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields, err := NewSanitizer(DefaultRules()).SanitizeSyslog(context.Background(), m)
+	if err != nil {
+		t.Fatalf("SanitizeSyslog() error = %v", err)
+	}
+	// ']' is escaped in parameter values
+	want := `<34>1 - host app 42 - [auth@1 user="[EMAIL\]"] login by {"ssn":"[SSN]"}`
+	if m.String() != want {
+		t.Errorf("Sanitized message =\n%s\nwant\n%s", m.String(), want)
+	}
+	if fields["auth@1.user"] == nil || fields["msg"] == nil {
+		t.Errorf("Expected findings for the parameter and the message, got %v", fields)
+	}
+}
+
+func TestSanitizeSyslog_SessionTokens(t *testing.T) {
+	m, err := ParseSyslog(`<34>1 - host nginx 42 - - GET /app?sessionid=abcdef1234567890XYZ Cookie: JSESSIONID=ABCDEF1234567890ABCD`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewSanitizer(DefaultRules()).SanitizeSyslog(context.Background(), m); err != nil {
+		t.Fatalf("SanitizeSyslog() error = %v", err)
+	}
+	want := `<34>1 - host nginx 42 - - GET /app?sessionid=[SESSION_TOKEN] Cookie: JSESSIONID=[SESSION_TOKEN]`
+	if m.String() != want {
+		t.Errorf("Sanitized message =\n%s\nwant\n%s", m.String(), want)
+	}
+}
+
+func TestSyslogServer(t *testing.T) {
+	var mu sync.Mutex
+	var received []string
+	server := &SyslogServer{
+		Sanitizer: NewSanitizer(DefaultRules()),
+		Handler: func(_ context.Context, m *SyslogMessage) error {
+			mu.Lock()
+			defer mu.Unlock()
+			received = append(received, m.Message)
+			return nil
+		},
+	}
+
+	// Octet counting then newline framing on a stream
+	first := "<13>1 - - - - - - mail john@acme.com"
+	stream := strconv.Itoa(len(first)) + " " + first + "<13>1 - - - - - - ssn 123-45-6789\n\n"
+	if err := server.ServeStream(context.Background(), strings.NewReader(stream)); err != nil {
+		t.Fatalf("ServeStream() error = %v", err)
+	}
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("UDP unavailable: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- server.ServePacket(ctx, conn) }()
+
+	client, err := net.Dial("udp", conn.LocalAddr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	client.Write([]byte("<13>1 - - - - - - card 4111-1111-1111-1111"))
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		mu.Lock()
+		n := len(received)
+		mu.Unlock()
+		if n == 3 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	<-done
+
+	mu.Lock()
+	defer mu.Unlock()
+	if strings.Join(received, "|") != "mail [EMAIL]|ssn [SSN]|card [CREDIT_CARD]" {
+		t.Errorf("Unexpected sanitized messages %q", received)
+	}
+}
+
+// endlessReader returns the same byte forever
+type endlessReader byte
+
+func (r endlessReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = byte(r)
+	}
+	return len(p), nil
+}
+
+func TestSyslogServer_ServeStreamFrameLimit(t *testing.T) {
+	server := &SyslogServer{
+		Sanitizer: NewSanitizer(DefaultRules()),
+		Handler:   func(context.Context, *SyslogMessage) error { return nil },
+	}
+
+	// A newline-framed message that never ends, and an octet count that never ends
+	for name, stream := range map[string]io.Reader{
+		"line":   io.MultiReader(strings.NewReader("<13>1 - - - - - - "), endlessReader('a')),
+		"length": endlessReader('9'),
+	} {
+		if err := server.ServeStream(context.Background(), stream); err == nil || !strings.Contains(err.Error(), "frame longer than") {
+			t.Errorf("%s: expected an oversized frame error, got %v", name, err)
+		}
+	}
+}
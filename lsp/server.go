@@ -0,0 +1,242 @@
+// Package lsp implements a Language Server Protocol server publishing diagnostics for the
+// PII detected in open documents, so editors such as VS Code warn writers inline before
+// a document containing personal data is saved or shared.
+package lsp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/intMeric/pii-extractor/extractors"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// Source is the source name of the published diagnostics
+const Source = "pii-extractor"
+
+// ServerInfo is the name reported to clients on initialization
+const ServerInfo = "pii-lsp"
+
+// ErrExit is returned by Serve when the client sends exit without a prior shutdown
+var ErrExit = errors.New("exit without shutdown")
+
+// Server publishes PII diagnostics for the documents opened by an LSP client. Messages
+// are handled sequentially, so a server serves a single connection.
+type Server struct {
+	extractor extractors.PiiExtractor
+
+	// MinSeverity is the lowest severity reported; findings below it are not published
+	MinSeverity pii.Severity
+
+	documents   map[string]string
+	initialized bool
+	shutdown    bool
+	out         io.Writer
+}
+
+// NewServer creates a server running the given extractor on every document change
+func NewServer(extractor extractors.PiiExtractor) *Server {
+	return &Server{
+		extractor: extractor,
+		documents: make(map[string]string),
+	}
+}
+
+// Serve reads LSP messages from r and writes responses and notifications to w until the
+// client exits, r is exhausted or ctx is done. It returns nil after a clean
+// shutdown/exit sequence or at the end of r.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	s.out = w
+	reader := bufio.NewReader(r)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		msg, err := readMessage(reader)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			if msg == nil {
+				return err
+			}
+			// The frame was read but its body is not JSON; report it and keep serving
+			if err := s.reply(nil, nil, &responseError{Code: codeParseError, Message: err.Error()}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if msg.Method == "exit" {
+			if !s.shutdown {
+				return ErrExit
+			}
+			return nil
+		}
+		if err := s.handle(ctx, msg); err != nil {
+			return err
+		}
+	}
+}
+
+// handle dispatches one request or notification. Only write errors are returned.
+func (s *Server) handle(ctx context.Context, msg *message) error {
+	isRequest := msg.ID != nil
+	if !s.initialized && msg.Method != "initialize" {
+		if isRequest {
+			return s.reply(msg.ID, nil, &responseError{Code: codeServerNotInitialized, Message: "server not initialized"})
+		}
+		return nil
+	}
+	if s.shutdown && msg.Method != "exit" {
+		if isRequest {
+			return s.reply(msg.ID, nil, &responseError{Code: codeInvalidRequest, Message: "server is shutting down"})
+		}
+		return nil
+	}
+
+	switch msg.Method {
+	case "initialize":
+		s.initialized = true
+		return s.reply(msg.ID, map[string]any{
+			"capabilities": map[string]any{
+				"textDocumentSync": map[string]any{
+					"openClose": true,
+					"change":    2, // incremental
+				},
+			},
+			"serverInfo": map[string]any{"name": ServerInfo},
+		}, nil)
+
+	case "shutdown":
+		s.shutdown = true
+		return s.reply(msg.ID, nil, nil)
+
+	case "textDocument/didOpen":
+		var params didOpenParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil
+		}
+		s.documents[params.TextDocument.URI] = params.TextDocument.Text
+		return s.publish(ctx, params.TextDocument.URI, params.TextDocument.Version)
+
+	case "textDocument/didChange":
+		var params didChangeParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil
+		}
+		uri := params.TextDocument.URI
+		text := s.documents[uri]
+		for _, change := range params.ContentChanges {
+			if change.Range == nil {
+				text = change.Text
+				continue
+			}
+			start, end := offset(text, change.Range.Start), offset(text, change.Range.End)
+			text = text[:start] + change.Text + text[max(start, end):]
+		}
+		s.documents[uri] = text
+		return s.publish(ctx, uri, params.TextDocument.Version)
+
+	case "textDocument/didClose":
+		var params didCloseParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil
+		}
+		delete(s.documents, params.TextDocument.URI)
+		// Clear the diagnostics of the closed document
+		return s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{
+			URI:         params.TextDocument.URI,
+			Diagnostics: []Diagnostic{},
+		})
+	}
+
+	if isRequest {
+		return s.reply(msg.ID, nil, &responseError{Code: codeMethodNotFound, Message: "method not supported: " + msg.Method})
+	}
+	// Unknown notifications (initialized, didSave, $/...) are ignored
+	return nil
+}
+
+// publish extracts the PII of a document and publishes its diagnostics. Extraction
+// errors are reported to the client as a log message instead of failing the server.
+func (s *Server) publish(ctx context.Context, uri string, version int) error {
+	text := s.documents[uri]
+	result, err := extractors.ExtractWithContext(ctx, s.extractor, text)
+	if err != nil {
+		return s.notify("window/logMessage", map[string]any{
+			"type":    1, // error
+			"message": fmt.Sprintf("PII extraction failed for %s: %v", uri, err),
+		})
+	}
+	return s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{
+		URI:         uri,
+		Version:     version,
+		Diagnostics: s.Diagnostics(text, result),
+	})
+}
+
+// Diagnostics converts the entities of a result into diagnostics on every occurrence in
+// text, skipping findings below MinSeverity
+func (s *Server) Diagnostics(text string, result *pii.PiiExtractionResult) []Diagnostic {
+	diagnostics := []Diagnostic{}
+	for _, occurrence := range result.Occurrences(text) {
+		entity := occurrence.Entity
+		severity := entity.GetSeverity()
+		if severity < s.MinSeverity {
+			continue
+		}
+		message := fmt.Sprintf("Possible %s (%s severity)", entity.Type, severity)
+		if entity.Validation != nil {
+			message += fmt.Sprintf(", validation confidence %.2f", entity.Validation.Confidence)
+		}
+		diagnostics = append(diagnostics, Diagnostic{
+			Range:    Range{Start: position(text, occurrence.Start), End: position(text, occurrence.End)},
+			Severity: DiagnosticSeverity(severity),
+			Code:     entity.Type.String(),
+			Source:   Source,
+			Message:  message,
+		})
+	}
+	return diagnostics
+}
+
+// DiagnosticSeverity maps a PII severity to an LSP diagnostic severity: critical findings
+// are errors, high ones warnings, medium ones information and low ones hints
+func DiagnosticSeverity(severity pii.Severity) int {
+	switch severity {
+	case pii.SeverityCritical:
+		return SeverityError
+	case pii.SeverityHigh:
+		return SeverityWarning
+	case pii.SeverityMedium:
+		return SeverityInformation
+	default:
+		return SeverityHint
+	}
+}
+
+// reply sends the response to a request
+func (s *Server) reply(id *json.RawMessage, result any, respErr *responseError) error {
+	if id == nil {
+		null := json.RawMessage("null")
+		id = &null
+	}
+	if respErr != nil {
+		return writeMessage(s.out, &message{ID: id, Error: respErr})
+	}
+	return writeFrame(s.out, &response{JSONRPC: "2.0", ID: id, Result: result})
+}
+
+// notify sends a notification to the client
+func (s *Server) notify(method string, params any) error {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	return writeMessage(s.out, &message{Method: method, Params: body})
+}
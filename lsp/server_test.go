@@ -0,0 +1,187 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/intMeric/pii-extractor/extractors/regex"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// session frames client messages and decodes the server output
+type session struct {
+	in bytes.Buffer
+}
+
+func (s *session) send(t *testing.T, id int, method string, params any) {
+	t.Helper()
+	msg := &message{Method: method}
+	if id > 0 {
+		raw := json.RawMessage(strings.TrimSpace(string(mustJSON(t, id))))
+		msg.ID = &raw
+	}
+	if params != nil {
+		msg.Params = mustJSON(t, params)
+	}
+	if err := writeMessage(&s.in, msg); err != nil {
+		t.Fatalf("writeMessage() error = %v", err)
+	}
+}
+
+func mustJSON(t *testing.T, v any) []byte {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	return data
+}
+
+func readAll(t *testing.T, out *bytes.Buffer) []*message {
+	t.Helper()
+	var messages []*message
+	reader := bufio.NewReader(out)
+	for out.Len() > 0 || reader.Buffered() > 0 {
+		msg, err := readMessage(reader)
+		if err != nil {
+			t.Fatalf("readMessage() error = %v", err)
+		}
+		messages = append(messages, msg)
+	}
+	return messages
+}
+
+func diagnosticsOf(t *testing.T, msg *message) publishDiagnosticsParams {
+	t.Helper()
+	if msg.Method != "textDocument/publishDiagnostics" {
+		t.Fatalf("Expected publishDiagnostics, got %q", msg.Method)
+	}
+	var params publishDiagnosticsParams
+	if err := json.Unmarshal(msg.Params, &params); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	return params
+}
+
+func TestServer_Session(t *testing.T) {
+	var s session
+	s.send(t, 1, "initialize", map[string]any{})
+	s.send(t, 0, "initialized", map[string]any{})
+	s.send(t, 0, "textDocument/didOpen", map[string]any{"textDocument": map[string]any{
+		"uri": "file:///notes.txt", "version": 1, "text": "Hello\nmail 😀 john.doe@acme.com",
+	}})
+	s.send(t, 0, "textDocument/didChange", map[string]any{
+		"textDocument": map[string]any{"uri": "file:///notes.txt", "version": 2},
+		"contentChanges": []map[string]any{{
+			"range": Range{Start: Position{Line: 0, Character: 5}, End: Position{Line: 0, Character: 5}},
+			"text":  " SSN 123-45-6789",
+		}},
+	})
+	s.send(t, 0, "textDocument/didClose", map[string]any{"textDocument": map[string]any{"uri": "file:///notes.txt"}})
+	s.send(t, 2, "textDocument/hover", map[string]any{})
+	s.send(t, 3, "shutdown", nil)
+	s.send(t, 0, "exit", nil)
+
+	var out bytes.Buffer
+	if err := NewServer(regex.NewDefaultExtractor()).Serve(context.Background(), &s.in, &out); err != nil {
+		t.Fatalf("Serve() error = %v", err)
+	}
+	// JSON-RPC requires the result member of a successful response, even when null
+	if !bytes.Contains(out.Bytes(), []byte(`{"jsonrpc":"2.0","id":3,"result":null}`)) {
+		t.Errorf("Expected a null shutdown result, got %s", out.Bytes())
+	}
+	messages := readAll(t, &out)
+	if len(messages) != 6 {
+		t.Fatalf("Expected 6 messages, got %d", len(messages))
+	}
+	if messages[0].Error != nil || messages[0].Result == nil {
+		t.Errorf("Expected an initialize result, got %+v", messages[0])
+	}
+
+	opened := diagnosticsOf(t, messages[1])
+	if len(opened.Diagnostics) != 1 {
+		t.Fatalf("Expected one diagnostic on open, got %+v", opened.Diagnostics)
+	}
+	email := opened.Diagnostics[0]
+	// The emoji counts as two UTF-16 code units
+	want := Range{Start: Position{Line: 1, Character: 8}, End: Position{Line: 1, Character: 25}}
+	if email.Range != want || email.Code != "email" || email.Severity != SeverityInformation || email.Source != Source {
+		t.Errorf("Unexpected email diagnostic %+v", email)
+	}
+
+	changed := diagnosticsOf(t, messages[2])
+	if changed.Version != 2 || len(changed.Diagnostics) != 2 {
+		t.Fatalf("Expected two diagnostics after the change, got %+v", changed)
+	}
+	ssn := changed.Diagnostics[0]
+	if ssn.Code != "ssn" || ssn.Severity != SeverityError || ssn.Range.Start != (Position{Line: 0, Character: 10}) {
+		t.Errorf("Unexpected SSN diagnostic %+v", ssn)
+	}
+
+	if closed := diagnosticsOf(t, messages[3]); closed.Diagnostics == nil || len(closed.Diagnostics) != 0 {
+		t.Errorf("Expected diagnostics to be cleared on close, got %+v", closed)
+	}
+	if messages[4].Error == nil || messages[4].Error.Code != codeMethodNotFound {
+		t.Errorf("Expected method not found for hover, got %+v", messages[4])
+	}
+	if messages[5].Error != nil {
+		t.Errorf("Expected a shutdown result, got %+v", messages[5].Error)
+	}
+}
+
+func TestServer_ExitWithoutShutdown(t *testing.T) {
+	var s session
+	s.send(t, 1, "textDocument/hover", map[string]any{})
+	s.send(t, 0, "exit", nil)
+
+	var out bytes.Buffer
+	if err := NewServer(regex.NewDefaultExtractor()).Serve(context.Background(), &s.in, &out); !errors.Is(err, ErrExit) {
+		t.Errorf("Expected ErrExit, got %v", err)
+	}
+	messages := readAll(t, &out)
+	if len(messages) != 1 || messages[0].Error == nil || messages[0].Error.Code != codeServerNotInitialized {
+		t.Errorf("Expected a not initialized error, got %+v", messages)
+	}
+}
+
+func TestServer_MinSeverity(t *testing.T) {
+	server := NewServer(regex.NewDefaultExtractor())
+	server.MinSeverity = pii.SeverityHigh
+
+	text := "john@acme.com 123-45-6789"
+	result, _ := regex.NewDefaultExtractor().Extract(text)
+	diagnostics := server.Diagnostics(text, result)
+	if len(diagnostics) != 1 || diagnostics[0].Code != "ssn" {
+		t.Errorf("Expected only the SSN diagnostic, got %+v", diagnostics)
+	}
+}
+
+func TestOffset(t *testing.T) {
+	text := "ab\n😀cd\nef"
+	tests := []struct {
+		pos     Position
+		want    int
+		clamped bool
+	}{
+		{Position{0, 1}, 1, false},
+		{Position{0, 9}, 2, true},
+		{Position{1, 2}, 7, false},
+		{Position{2, 0}, 10, false},
+		{Position{5, 0}, len(text), true},
+	}
+	for _, tt := range tests {
+		if got := offset(text, tt.pos); got != tt.want {
+			t.Errorf("offset(%+v) = %d, want %d", tt.pos, got, tt.want)
+		}
+		if !tt.clamped {
+			if got := position(text, tt.want); got != tt.pos {
+				t.Errorf("position(%d) = %+v, want %+v", tt.want, got, tt.pos)
+			}
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package names
+
+import (
+	"github.com/intMeric/pii-extractor/extractors"
+	patterns "github.com/intMeric/pii-extractor/extractors/regex/patterns"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// Extractor reports the names found by a Gazetteer as PiiTypePersonName entities, with
+// the highest confidence of their occurrences
+type Extractor struct {
+	name      string
+	gazetteer *Gazetteer
+}
+
+// NewExtractor creates a person name extractor over a gazetteer, or over the embedded
+// lists of every locale when gazetteer is nil
+func NewExtractor(gazetteer *Gazetteer) *Extractor {
+	if gazetteer == nil {
+		gazetteer = NewGazetteer()
+	}
+	return &Extractor{name: "name-gazetteer", gazetteer: gazetteer}
+}
+
+// Extract performs person name detection on the given text
+func (e *Extractor) Extract(text string) (*pii.PiiExtractionResult, error) {
+	return pii.NewPiiExtractionResult(e.extract(text)), nil
+}
+
+// ExtractByType extracts person names when piiType is PiiTypePersonName
+func (e *Extractor) ExtractByType(text string, piiType pii.PiiType) ([]pii.PiiEntity, error) {
+	if piiType != pii.PiiTypePersonName {
+		return []pii.PiiEntity{}, nil
+	}
+	return e.extract(text), nil
+}
+
+// extract converts matches into person name entities, grouping repeated names
+func (e *Extractor) extract(text string) []pii.PiiEntity {
+	nameMap := make(map[string]*pii.PersonName)
+	var order []string
+	locator := pii.NewLocator(text)
+	for _, match := range e.gazetteer.Find(text) {
+		context := patterns.ExtractContext(text, match.Start, match.End)
+		location := locator.Locate(match.Start, match.End)
+
+		if name, exists := nameMap[match.Text]; exists {
+			name.BasePii.IncrementCount()
+			name.BasePii.AddContext(context)
+			name.BasePii.AddLocation(location)
+			name.Confidence = max(name.Confidence, match.Confidence)
+			continue
+		}
+		name := pii.NewPersonName(match.Text, match.Locale, match.Confidence)
+		name.First = match.First
+		name.Last = match.Last
+		name.Contexts = []string{context}
+		name.AddLocation(location)
+		nameMap[match.Text] = &name
+		order = append(order, match.Text)
+	}
+
+	entities := make([]pii.PiiEntity, 0, len(order))
+	for _, value := range order {
+		entities = append(entities, pii.PiiEntity{
+			Type:  pii.PiiTypePersonName,
+			Value: *nameMap[value],
+		})
+	}
+	return entities
+}
+
+// GetSupportedTypes returns the list of PII types this extractor can handle
+func (e *Extractor) GetSupportedTypes() []pii.PiiType {
+	return []pii.PiiType{pii.PiiTypePersonName}
+}
+
+// GetMethod returns the extraction method used by this extractor. Gazetteer matching is
+// rule-based, like the regex extractor.
+func (e *Extractor) GetMethod() extractors.ExtractionMethod {
+	return extractors.MethodRegex
+}
+
+// GetName returns the name of this extractor
+func (e *Extractor) GetName() string {
+	return e.name
+}
@@ -0,0 +1,384 @@
+package names
+
+import (
+	"bufio"
+	"cmp"
+	"embed"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Base confidences of the shapes of name matched by a Gazetteer, scaled by how common
+// the names are
+const (
+	FullNameWeight  = 0.9 // a known first name followed by a known last name
+	SurnameWeight   = 0.6 // a known first name followed by an unknown capitalized word
+	FirstNameWeight = 0.4 // a known first name alone
+	LastNameWeight  = 0.2 // a known last name alone
+)
+
+// LabelWeight is the signal weight of a name following a label such as "Name:" or "Client :"
+const LabelWeight = 0.7
+
+// DefaultMinConfidence is the lowest confidence of the matches reported by default
+const DefaultMinConfidence = 0.35
+
+// minFrequencyScore is the frequency score of the least common name of a list, the most
+// common one scoring 1
+const minFrequencyScore = 0.7
+
+// sentenceStartPenalty scales the confidence of a single name starting a sentence, where
+// any word is capitalized
+const sentenceStartPenalty = 0.5
+
+//go:embed lists/*.txt
+var lists embed.FS
+
+// labels are the words that, followed by a colon, introduce a name
+var labels = map[string]bool{
+	"name": true, "contact": true, "customer": true, "client": true, "patient": true,
+	"employee": true, "author": true, "signed": true, "from": true, "to": true, "attn": true,
+	"nom": true, "prénom": true, "nombre": true, "nome": true, "naam": true,
+}
+
+// gazetteerWordRegex matches a word that may be a name, such as "O'Brien" or "Jean-Luc"
+var gazetteerWordRegex = regexp.MustCompile(`\p{L}[\p{L}'’-]*`)
+
+// Match is a person name found in text[Start:End] by a Gazetteer
+type Match struct {
+	Text       string  `json:"text"`
+	Start      int     `json:"start"`
+	End        int     `json:"end"`
+	First      string  `json:"first,omitempty"` // empty for a last name alone
+	Last       string  `json:"last,omitempty"`  // empty for a first name alone
+	Locale     string  `json:"locale"`          // ISO 639-1 code of the lists that matched
+	Confidence float64 `json:"confidence"`
+}
+
+// rank is the position of a name in the list of a locale
+type rank struct {
+	locale    string
+	score     float64 // from minFrequencyScore for the least common name to 1
+	ambiguous bool    // the name is also a common word, such as "Will" or "Brown"
+}
+
+// nameList is the first and last names of a locale, most frequent first
+type nameList struct {
+	first, last []string
+}
+
+// builtinLists parses the embedded lists once
+var builtinLists = sync.OnceValue(func() map[string]nameList {
+	parsed := make(map[string]nameList)
+	entries, _ := lists.ReadDir("lists")
+	for _, entry := range entries {
+		kind, locale, _ := strings.Cut(strings.TrimSuffix(entry.Name(), ".txt"), "_")
+		data, _ := lists.ReadFile("lists/" + entry.Name())
+		list := parsed[locale]
+		if kind == "first" {
+			list.first = parseList(string(data))
+		} else {
+			list.last = parseList(string(data))
+		}
+		parsed[locale] = list
+	}
+	return parsed
+})
+
+// parseList returns the names of a list file, one per line, skipping blank lines and
+// comments starting with #
+func parseList(data string) []string {
+	var names []string
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			names = append(names, line)
+		}
+	}
+	return names
+}
+
+// Locales returns the locales of the embedded name lists, sorted
+func Locales() []string {
+	var locales []string
+	for locale := range builtinLists() {
+		locales = append(locales, locale)
+	}
+	slices.Sort(locales)
+	return locales
+}
+
+// Option configures a Gazetteer
+type Option func(*Gazetteer)
+
+// WithLocales restricts matching to the embedded lists of some locales, such as "en" and
+// "fr" (all of Locales by default)
+func WithLocales(locales ...string) Option {
+	return func(g *Gazetteer) {
+		g.locales = locales
+	}
+}
+
+// WithNames adds lists of first and last names for a locale, most frequent first, such
+// as names exported from a CRM or a national statistics office. A trailing "*" marks a
+// name that is also a common word, matched only next to another name or after a
+// salutation or label.
+func WithNames(locale string, first, last []string) Option {
+	return func(g *Gazetteer) {
+		g.custom[locale] = nameList{first: first, last: last}
+	}
+}
+
+// WithMinConfidence sets the lowest confidence of the matches reported
+// (DefaultMinConfidence by default)
+func WithMinConfidence(confidence float64) Option {
+	return func(g *Gazetteer) {
+		g.minConfidence = confidence
+	}
+}
+
+// Gazetteer matches capitalized words against frequency-ranked lists of first and last
+// names per locale, as a lighter alternative to named entity recognition. The
+// confidence of a match grows with how common its names are, when a first name is
+// followed by a last name, and after a salutation ("Mme Dupont") or a label ("Name:").
+// It is safe for concurrent use.
+type Gazetteer struct {
+	locales       []string
+	custom        map[string]nameList
+	minConfidence float64
+
+	first map[string][]rank // lowercased first names
+	last  map[string][]rank // lowercased last names
+}
+
+// NewGazetteer creates a gazetteer over the embedded lists of every locale
+func NewGazetteer(opts ...Option) *Gazetteer {
+	g := &Gazetteer{
+		locales:       Locales(),
+		custom:        make(map[string]nameList),
+		minConfidence: DefaultMinConfidence,
+		first:         make(map[string][]rank),
+		last:          make(map[string][]rank),
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	builtin := builtinLists()
+	for _, locale := range g.locales {
+		g.add(locale, builtin[locale])
+	}
+	for locale, list := range g.custom {
+		g.add(locale, list)
+	}
+	return g
+}
+
+// add indexes the names of a locale
+func (g *Gazetteer) add(locale string, list nameList) {
+	index := func(ranks map[string][]rank, names []string) {
+		for i, name := range names {
+			name, ambiguous := strings.CutSuffix(name, "*")
+			key := strings.ToLower(name)
+			if slices.ContainsFunc(ranks[key], func(r rank) bool { return r.locale == locale }) {
+				continue
+			}
+			score := 1 - (1-minFrequencyScore)*float64(i)/float64(len(names))
+			ranks[key] = append(ranks[key], rank{locale: locale, score: score, ambiguous: ambiguous})
+		}
+	}
+	index(g.first, list.first)
+	index(g.last, list.last)
+}
+
+// lookup returns the ranks of a name word. Compound names missing from the lists, such as
+// "Marie-Claire", match when each of their parts does, with the score of the rarest part.
+func lookup(ranks map[string][]rank, word string) []rank {
+	key := strings.ToLower(word)
+	if found, ok := ranks[key]; ok {
+		return found
+	}
+	parts := strings.Split(key, "-")
+	if len(parts) < 2 {
+		return nil
+	}
+	var compound []rank
+	for _, r := range ranks[parts[0]] {
+		for _, part := range parts[1:] {
+			i := slices.IndexFunc(ranks[part], func(p rank) bool { return p.locale == r.locale })
+			if i < 0 {
+				r.score = 0
+				break
+			}
+			r.score = min(r.score, ranks[part][i].score)
+			r.ambiguous = false
+		}
+		if r.score > 0 {
+			compound = append(compound, r)
+		}
+	}
+	return compound
+}
+
+// best returns the highest ranked entry, or false when there is none
+func best(ranks []rank) (rank, bool) {
+	if len(ranks) == 0 {
+		return rank{}, false
+	}
+	return slices.MaxFunc(ranks, func(a, b rank) int { return cmp.Compare(a.score, b.score) }), true
+}
+
+// word is a word of the text with its offsets
+type word struct {
+	text       string
+	start, end int
+}
+
+// Find returns the names of text in order of appearance, with at least the minimum
+// confidence
+func (g *Gazetteer) Find(text string) []Match {
+	words := g.words(text)
+	if len(words) == 0 {
+		return nil
+	}
+	salutations := FindSalutations(text)
+
+	var matches []Match
+	for i := 0; i < len(words); i++ {
+		w := words[i]
+		firsts := lookup(g.first, w.text)
+		lasts := lookup(g.last, w.text)
+		if len(firsts) == 0 && len(lasts) == 0 {
+			continue
+		}
+
+		var next *word
+		if i+1 < len(words) && text[w.end:words[i+1].start] == " " {
+			next = &words[i+1]
+		}
+
+		var match Match
+		var base float64
+		switch {
+		case len(firsts) > 0 && next != nil && len(lookup(g.last, next.text)) > 0:
+			first, last, locale := pairRanks(firsts, lookup(g.last, next.text))
+			match = Match{First: w.text, Last: next.text, Locale: locale}
+			base = FullNameWeight * (first.score + last.score) / 2
+		case len(firsts) > 0 && next != nil && len(lookup(g.first, next.text)) == 0:
+			first, _ := best(firsts)
+			match = Match{First: w.text, Last: next.text, Locale: first.locale}
+			base = SurnameWeight * first.score
+		case len(firsts) > 0:
+			first, _ := best(firsts)
+			match = Match{First: w.text, Locale: first.locale}
+			if !first.ambiguous {
+				base = FirstNameWeight * first.score
+			}
+		default:
+			last, _ := best(lasts)
+			match = Match{Last: w.text, Locale: last.locale}
+			if !last.ambiguous {
+				base = LastNameWeight * last.score
+			}
+		}
+
+		match.Start = w.start
+		match.End = w.end
+		if match.First != "" && match.Last != "" {
+			match.End = next.end
+			i++
+		}
+		match.Text = text[match.Start:match.End]
+
+		context := contextWeight(text, match.Start, salutations)
+		if context == 0 && (match.First == "" || match.Last == "") && sentenceStart(text, w.start) {
+			base *= sentenceStartPenalty
+		}
+		confidence := 1 - (1-base)*(1-context)
+		match.Confidence = math.Round(confidence*1000) / 1000
+		if match.Confidence > 0 && match.Confidence >= g.minConfidence {
+			matches = append(matches, match)
+		}
+	}
+	return matches
+}
+
+// words returns the capitalized words of text, without a possessive "'s"
+func (g *Gazetteer) words(text string) []word {
+	var words []word
+	for _, loc := range gazetteerWordRegex.FindAllStringIndex(text, -1) {
+		w := text[loc[0]:loc[1]]
+		trimmed := strings.TrimRight(strings.TrimSuffix(strings.TrimSuffix(w, "'s"), "’s"), "'’-")
+		if capitalized(trimmed) {
+			words = append(words, word{text: trimmed, start: loc[0], end: loc[0] + len(trimmed)})
+		}
+	}
+	return words
+}
+
+// capitalized reports whether a word starts with an uppercase letter and has lowercase
+// ones, ruling out lowercase words and all-caps acronyms
+func capitalized(w string) bool {
+	first, size := utf8.DecodeRuneInString(w)
+	return unicode.IsUpper(first) && strings.IndexFunc(w[size:], unicode.IsLower) >= 0
+}
+
+// pairRanks returns the ranks of a first and last name and their locale, preferring a
+// locale having both, then the locale of the last name, which tells more than first names
+// shared across languages
+func pairRanks(firsts, lasts []rank) (rank, rank, string) {
+	var first, last rank
+	found := false
+	for _, f := range firsts {
+		for _, l := range lasts {
+			if f.locale == l.locale && (!found || f.score+l.score > first.score+last.score) {
+				first, last, found = f, l, true
+			}
+		}
+	}
+	if found {
+		return first, last, first.locale
+	}
+	first, _ = best(firsts)
+	last, _ = best(lasts)
+	return first, last, last.locale
+}
+
+// sentenceStart reports whether the word at offset starts a sentence or a line
+func sentenceStart(text string, offset int) bool {
+	before := strings.TrimRight(text[:offset], " \t\"'“(")
+	if before == "" {
+		return true
+	}
+	last, _ := utf8.DecodeLastRuneInString(before)
+	return strings.ContainsRune(".!?\n\r", last)
+}
+
+// contextWeight returns the weight of the strongest name signal before offset: a
+// salutation whose name candidate contains it, or a label
+func contextWeight(text string, offset int, salutations []Salutation) float64 {
+	weight := 0.0
+	for _, salutation := range salutations {
+		if salutation.Name != "" && salutation.NameStart <= offset && offset < salutation.NameEnd {
+			weight = max(weight, salutation.Weight)
+		}
+	}
+
+	line := text[:offset]
+	if i := strings.LastIndexAny(line, "\n\r"); i >= 0 {
+		line = line[i+1:]
+	}
+	line = strings.TrimRight(line, " \t")
+	if label, ok := strings.CutSuffix(line, ":"); ok {
+		fields := strings.Fields(strings.ToLower(label))
+		if len(fields) > 0 && labels[fields[len(fields)-1]] {
+			weight = max(weight, LabelWeight)
+		}
+	}
+	return weight
+}
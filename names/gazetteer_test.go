@@ -0,0 +1,127 @@
+package names
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+func TestGazetteer_Find(t *testing.T) {
+	tests := []struct {
+		text   string
+		name   string
+		first  string
+		last   string
+		locale string
+	}{
+		{"Please call John Smith tomorrow", "John Smith", "John", "Smith", "en"},
+		{"Bonjour Mme Dupont, votre dossier", "Dupont", "", "Dupont", "fr"},
+		{"Name: Sarah Connor", "Sarah Connor", "Sarah", "Connor", "en"},
+		{"Le dossier de Marie-Claire Martin", "Marie-Claire Martin", "Marie-Claire", "Martin", "fr"},
+		{"Regards, Hans Müller's office", "Hans Müller", "Hans", "Müller", "de"},
+		{"Payment from Maria García received", "Maria García", "Maria", "García", "es"},
+	}
+	g := NewGazetteer()
+	for _, tt := range tests {
+		matches := g.Find(tt.text)
+		if len(matches) != 1 {
+			t.Errorf("Find(%q) = %+v, want %q", tt.text, matches, tt.name)
+			continue
+		}
+		m := matches[0]
+		if m.Text != tt.name || m.First != tt.first || m.Last != tt.last || m.Locale != tt.locale {
+			t.Errorf("Find(%q) = %+v", tt.text, m)
+		}
+		if tt.text[m.Start:m.End] != m.Text {
+			t.Errorf("Offsets of %+v do not match %q", m, tt.text)
+		}
+	}
+}
+
+func TestGazetteer_Confidence(t *testing.T) {
+	g := NewGazetteer(WithMinConfidence(0))
+	confidence := func(text string) float64 {
+		matches := g.Find(text)
+		if len(matches) == 0 {
+			return 0
+		}
+		return matches[0].Confidence
+	}
+
+	full := confidence("I met John Smith yesterday")
+	surname := confidence("I met John Zyxwort yesterday")
+	first := confidence("I met John yesterday")
+	last := confidence("I met Jones yesterday")
+	if !(full > surname && surname > first && first > last && last > 0) {
+		t.Errorf("Expected full > surname > first > last > 0, got %v %v %v %v", full, surname, first, last)
+	}
+	if common, rare := confidence("I met James yesterday"), confidence("I met Raymond yesterday"); common <= rare {
+		t.Errorf("Expected common names to score higher, got %v and %v", common, rare)
+	}
+	if titled := confidence("I met Mr. Jones yesterday"); titled <= last {
+		t.Errorf("Expected a title to raise the confidence, got %v and %v", titled, last)
+	}
+	if start := confidence("John called. He asked for a refund"); start >= first {
+		t.Errorf("Expected a name starting a sentence to score lower, got %v and %v", start, first)
+	}
+}
+
+func TestGazetteer_Rejected(t *testing.T) {
+	g := NewGazetteer()
+	for _, text := range []string{
+		"JOHN SMITH ORDER 42",            // all caps
+		"ask john smith",                 // lowercase
+		"The Brown fox ate Green apples", // last names that are common words
+		"Will you send it? May I help?",  // first names that are common words
+		"Martin said yes",                // a single name starting a sentence
+	} {
+		if matches := g.Find(text); len(matches) != 0 {
+			t.Errorf("Expected no name in %q, got %+v", text, matches)
+		}
+	}
+	if matches := g.Find("Please ask Mr. Brown"); len(matches) != 1 || matches[0].Text != "Brown" {
+		t.Errorf("Expected an ambiguous name after a title, got %+v", matches)
+	}
+}
+
+func TestGazetteer_Options(t *testing.T) {
+	if matches := NewGazetteer(WithLocales("fr")).Find("I met John Smith"); len(matches) != 0 {
+		t.Errorf("Expected no English names with French lists only, got %+v", matches)
+	}
+	g := NewGazetteer(WithLocales(), WithNames("xx", []string{"Zorro"}, []string{"Vega*"}))
+	matches := g.Find("I met Zorro Vega")
+	if len(matches) != 1 || matches[0].Text != "Zorro Vega" || matches[0].Locale != "xx" {
+		t.Errorf("Expected a match from the custom lists, got %+v", matches)
+	}
+	if !slices.Contains(Locales(), "en") || !slices.Contains(Locales(), "fr") {
+		t.Errorf("Expected embedded English and French lists, got %v", Locales())
+	}
+}
+
+func TestExtractor(t *testing.T) {
+	text := "Dear Ms. Jones, John Smith called. John Smith will call back."
+	result, err := NewExtractor(nil).Extract(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := result.GetPersonNames()
+	if len(names) != 2 {
+		t.Fatalf("Expected 2 names, got %+v", result.Entities)
+	}
+	name, ok := names[1].AsPersonName()
+	if !ok || name.Value != "John Smith" || name.Count != 2 || name.First != "John" || name.Last != "Smith" {
+		t.Errorf("Unexpected name %+v", names[1].Value)
+	}
+	if locations := names[1].GetLocations(); len(locations) != 2 || text[locations[1].Start:locations[1].End] != "John Smith" {
+		t.Errorf("Unexpected locations %+v", locations)
+	}
+	if name.Confidence <= 0 || name.Confidence > 1 {
+		t.Errorf("Unexpected confidence %v", name.Confidence)
+	}
+
+	entities, _ := NewExtractor(nil).ExtractByType(text, pii.PiiTypeEmail)
+	if len(entities) != 0 {
+		t.Errorf("Expected no entities of other types, got %+v", entities)
+	}
+}
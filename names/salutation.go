@@ -0,0 +1,178 @@
+// Package names detects weak signals of person names. Salutations and titles ("Mr.",
+// "Mme", "Dr.", "Herr") and greetings ("Hi", "Bonjour") are usually followed by a name in
+// support transcripts and letters; the capitalized words after them are reported as name
+// candidates, with the gender a title implies.
+//
+// A Gazetteer matches capitalized words against embedded frequency-ranked lists of first
+// and last names per locale, and the Extractor reports its matches as person names.
+package names
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Gender is the grammatical gender implied by a title
+type Gender string
+
+const (
+	GenderUnknown Gender = ""
+	GenderMale    Gender = "male"
+	GenderFemale  Gender = "female"
+)
+
+// Kind distinguishes titles, a strong signal, from greetings, a weaker one
+type Kind string
+
+const (
+	KindTitle    Kind = "title"
+	KindGreeting Kind = "greeting"
+)
+
+// Signal weights of a name candidate following a title or a greeting
+const (
+	TitleWeight    = 0.8
+	GreetingWeight = 0.5
+)
+
+// Salutation is a title or greeting found in text, with the name candidate following it
+type Salutation struct {
+	Text     string `json:"text"` // The title or greeting as written
+	Kind     Kind   `json:"kind"` // KindTitle or KindGreeting
+	Gender   Gender `json:"gender,omitempty"`
+	Language string `json:"language"` // ISO 639-1 code
+	Start    int    `json:"start"`
+	End      int    `json:"end"`
+
+	// Name is the capitalized words following the salutation, empty when there are none.
+	// NameStart and NameEnd are its byte offsets.
+	Name      string  `json:"name,omitempty"`
+	NameStart int     `json:"name_start,omitempty"`
+	NameEnd   int     `json:"name_end,omitempty"`
+	Weight    float64 `json:"weight"` // Strength of the name signal (TitleWeight or GreetingWeight)
+}
+
+// term describes a salutation
+type term struct {
+	kind     Kind
+	gender   Gender
+	language string
+}
+
+// terms maps salutations, lowercased and without their final period, to their description
+var terms = map[string]term{
+	// English
+	"mr": {KindTitle, GenderMale, "en"}, "mister": {KindTitle, GenderMale, "en"}, "sir": {KindTitle, GenderMale, "en"},
+	"mrs": {KindTitle, GenderFemale, "en"}, "ms": {KindTitle, GenderFemale, "en"}, "miss": {KindTitle, GenderFemale, "en"},
+	"madam": {KindTitle, GenderFemale, "en"}, "mx": {KindTitle, GenderUnknown, "en"},
+	"dr": {KindTitle, GenderUnknown, "en"}, "prof": {KindTitle, GenderUnknown, "en"},
+	"dear": {KindGreeting, GenderUnknown, "en"}, "hi": {KindGreeting, GenderUnknown, "en"},
+	"hello": {KindGreeting, GenderUnknown, "en"}, "hey": {KindGreeting, GenderUnknown, "en"},
+	"thanks": {KindGreeting, GenderUnknown, "en"},
+	// French
+	"m": {KindTitle, GenderMale, "fr"}, "monsieur": {KindTitle, GenderMale, "fr"},
+	"mme": {KindTitle, GenderFemale, "fr"}, "madame": {KindTitle, GenderFemale, "fr"},
+	"mlle": {KindTitle, GenderFemale, "fr"}, "mademoiselle": {KindTitle, GenderFemale, "fr"},
+	"bonjour": {KindGreeting, GenderUnknown, "fr"}, "merci": {KindGreeting, GenderUnknown, "fr"},
+	// German
+	"herr": {KindTitle, GenderMale, "de"}, "herrn": {KindTitle, GenderMale, "de"}, "frau": {KindTitle, GenderFemale, "de"},
+	"hallo": {KindGreeting, GenderUnknown, "de"}, "liebe": {KindGreeting, GenderFemale, "de"}, "lieber": {KindGreeting, GenderMale, "de"},
+	// Spanish and Portuguese
+	"sr": {KindTitle, GenderMale, "es"}, "señor": {KindTitle, GenderMale, "es"}, "don": {KindTitle, GenderMale, "es"},
+	"sra": {KindTitle, GenderFemale, "es"}, "señora": {KindTitle, GenderFemale, "es"}, "srta": {KindTitle, GenderFemale, "es"},
+	"doña": {KindTitle, GenderFemale, "es"}, "hola": {KindGreeting, GenderUnknown, "es"},
+	"senhor": {KindTitle, GenderMale, "pt"}, "senhora": {KindTitle, GenderFemale, "pt"}, "olá": {KindGreeting, GenderUnknown, "pt"},
+	// Italian
+	"sig": {KindTitle, GenderMale, "it"}, "signor": {KindTitle, GenderMale, "it"}, "signore": {KindTitle, GenderMale, "it"},
+	"sig.ra": {KindTitle, GenderFemale, "it"}, "signora": {KindTitle, GenderFemale, "it"}, "dott": {KindTitle, GenderUnknown, "it"},
+	"ciao": {KindGreeting, GenderUnknown, "it"}, "gentile": {KindGreeting, GenderUnknown, "it"},
+	// Dutch
+	"dhr": {KindTitle, GenderMale, "nl"}, "mevr": {KindTitle, GenderFemale, "nl"}, "mevrouw": {KindTitle, GenderFemale, "nl"},
+	"meneer": {KindTitle, GenderMale, "nl"}, "hoi": {KindGreeting, GenderUnknown, "nl"},
+}
+
+// salutationRegex matches a word that may be a salutation, with its optional period.
+// Go regexps have no lookbehind, so the preceding character is checked separately.
+var salutationRegex = regexp.MustCompile(`(?i)\b(sig\.ra|[\p{L}]+)(\.?)`)
+
+// nameWordRegex matches a capitalized name word, such as "O'Brien" or "Jean-Luc"
+var nameWordRegex = regexp.MustCompile(`^\p{Lu}[\p{Ll}\p{Lu}'’-]*\.?`)
+
+// maxNameWords is the maximum number of name words following a salutation
+const maxNameWords = 3
+
+// FindSalutations returns the salutations of text in order. Greetings are only reported
+// when a name candidate follows them, since "Hi," alone says nothing about a name.
+func FindSalutations(text string) []Salutation {
+	var salutations []Salutation
+	for _, m := range salutationRegex.FindAllStringSubmatchIndex(text, -1) {
+		word := text[m[2]:m[3]]
+		t, ok := terms[strings.ToLower(word)]
+		if !ok {
+			continue
+		}
+		// Titles are capitalized; lowercase "dr" or "m" are more likely other words
+		if first, _ := utf8.DecodeRuneInString(word); t.kind == KindTitle && !unicode.IsUpper(first) {
+			continue
+		}
+		// Single-letter "M" is only a title with its period ("M. Dupont")
+		if len(word) == 1 && m[5] == m[4] {
+			continue
+		}
+
+		salutation := Salutation{
+			Text:     text[m[0]:m[1]],
+			Kind:     t.kind,
+			Gender:   t.gender,
+			Language: t.language,
+			Start:    m[0],
+			End:      m[1],
+			Weight:   TitleWeight,
+		}
+		if t.kind == KindGreeting {
+			salutation.Weight = GreetingWeight
+		}
+		salutation.Name, salutation.NameStart, salutation.NameEnd = nameAfter(text, m[1])
+		if salutation.Kind == KindGreeting && salutation.Name == "" {
+			continue
+		}
+		salutations = append(salutations, salutation)
+	}
+	return salutations
+}
+
+// nameAfter returns the capitalized words following offset on the same line, skipping a
+// single space or comma-free separator, and their offsets
+func nameAfter(text string, offset int) (string, int, int) {
+	rest := text[offset:]
+	trimmed := strings.TrimLeft(rest, " \t")
+	if len(trimmed) == len(rest) || len(rest)-len(trimmed) > 2 {
+		return "", 0, 0
+	}
+	start := offset + len(rest) - len(trimmed)
+	end := start
+
+	for words := 0; words < maxNameWords; words++ {
+		word := nameWordRegex.FindString(text[end:])
+		if word == "" {
+			break
+		}
+		// Another salutation ends the name ("Mr. and Mrs. Smith" aside)
+		if _, ok := terms[strings.ToLower(strings.TrimSuffix(word, "."))]; ok {
+			break
+		}
+		end += len(word)
+		next := strings.TrimPrefix(text[end:], " ")
+		if len(next) == len(text[end:]) || nameWordRegex.FindString(next) == "" {
+			break
+		}
+		end += 1
+	}
+	name := strings.TrimRight(text[start:end], " .")
+	if name == "" {
+		return "", 0, 0
+	}
+	return name, start, start + len(name)
+}
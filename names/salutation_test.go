@@ -0,0 +1,66 @@
+package names
+
+import "testing"
+
+func TestFindSalutations(t *testing.T) {
+	tests := []struct {
+		text     string
+		title    string
+		kind     Kind
+		gender   Gender
+		language string
+		name     string
+	}{
+		{"Thank you Mr. John Smith for calling", "Mr.", KindTitle, GenderMale, "en", "John Smith"},
+		{"Bonjour Mme Dupont, votre dossier", "Mme", KindTitle, GenderFemale, "fr", "Dupont"},
+		{"Rappel pour M. Jean-Luc Picard", "M.", KindTitle, GenderMale, "fr", "Jean-Luc Picard"},
+		{"Sehr geehrter Herr Müller,", "Herr", KindTitle, GenderMale, "de", "Müller"},
+		{"Appointment with Dr. O'Brien tomorrow", "Dr.", KindTitle, GenderUnknown, "en", "O'Brien"},
+		{"Gentile Sig.ra Rossi", "Sig.ra", KindTitle, GenderFemale, "it", "Rossi"},
+		{"Agent: Hi Sarah, how can I help?", "Hi", KindGreeting, GenderUnknown, "en", "Sarah"},
+	}
+	for _, tt := range tests {
+		salutations := FindSalutations(tt.text)
+		var found *Salutation
+		for i := range salutations {
+			if salutations[i].Text == tt.title {
+				found = &salutations[i]
+			}
+		}
+		if found == nil {
+			t.Errorf("FindSalutations(%q) = %+v, want %q", tt.text, salutations, tt.title)
+			continue
+		}
+		if found.Kind != tt.kind || found.Gender != tt.gender || found.Language != tt.language || found.Name != tt.name {
+			t.Errorf("FindSalutations(%q) = %+v", tt.text, *found)
+		}
+		if found.Name != "" && tt.text[found.NameStart:found.NameEnd] != found.Name {
+			t.Errorf("Name offsets of %q do not match %q", tt.text, found.Name)
+		}
+	}
+}
+
+func TestFindSalutations_Weights(t *testing.T) {
+	salutations := FindSalutations("Hello Anna, Mrs. Jones called")
+	if len(salutations) != 2 || salutations[0].Weight != GreetingWeight || salutations[1].Weight != TitleWeight {
+		t.Errorf("Expected a greeting and a title with their weights, got %+v", salutations)
+	}
+}
+
+func TestFindSalutations_Rejected(t *testing.T) {
+	for _, text := range []string{
+		"Hi, thanks for waiting",      // greeting without a name
+		"hello team",                  // lowercase words are not names
+		"the dr said m dupont is out", // lowercase titles
+		"M Dupont",                    // single-letter title without its period
+	} {
+		for _, salutation := range FindSalutations(text) {
+			if salutation.Name != "" || salutation.Kind == KindGreeting {
+				t.Errorf("Expected no name signal in %q, got %+v", text, salutation)
+			}
+		}
+	}
+	if salutations := FindSalutations("Mr. and Mrs. Smith"); len(salutations) != 2 || salutations[0].Name != "" || salutations[1].Name != "Smith" {
+		t.Errorf("Expected the name after the second title only, got %+v", salutations)
+	}
+}
@@ -0,0 +1,137 @@
+package pii
+
+import (
+	"sync"
+
+	"github.com/intMeric/pii-extractor/sketch"
+)
+
+// DefaultTopK is the default number of most frequent values kept per type when aggregating
+const DefaultTopK = 10
+
+// TypeAggregate summarizes all values of one PII type seen by an Aggregator
+type TypeAggregate struct {
+	Type             PiiType          `json:"type"`
+	Occurrences      int              `json:"occurrences"`       // Total occurrences across documents
+	Documents        int              `json:"documents"`         // Documents containing at least one value
+	DistinctEstimate uint64           `json:"distinct_estimate"` // HyperLogLog estimate of distinct values
+	TopValues        []sketch.Counter `json:"top_values"`        // Most frequent values, most frequent first
+}
+
+// Aggregate is a memory-bounded summary of many extraction results
+type Aggregate struct {
+	Documents int                        `json:"documents"`
+	Types     map[PiiType]*TypeAggregate `json:"types"`
+}
+
+// Aggregator accumulates extraction results into per-type top-K values, counts and distinct
+// estimates. Its memory depends on the number of types and K, not on the number of entities.
+type Aggregator struct {
+	mu        sync.Mutex
+	topK      int
+	documents int
+	types     map[PiiType]*typeAggregator
+}
+
+// typeAggregator holds the sketches of a single PII type
+type typeAggregator struct {
+	occurrences int
+	documents   int
+	distinct    *sketch.HyperLogLog
+	top         *sketch.TopK
+}
+
+// NewAggregator creates an aggregator keeping the topK most frequent values per type.
+// A value of zero or less uses DefaultTopK.
+func NewAggregator(topK int) *Aggregator {
+	if topK <= 0 {
+		topK = DefaultTopK
+	}
+	return &Aggregator{
+		topK:  topK,
+		types: make(map[PiiType]*typeAggregator),
+	}
+}
+
+// Add records the entities of one document
+func (a *Aggregator) Add(entities []PiiEntity) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.documents++
+	seen := make(map[PiiType]bool)
+	for _, entity := range entities {
+		stats, exists := a.types[entity.Type]
+		if !exists {
+			stats = &typeAggregator{
+				distinct: sketch.NewHyperLogLog(sketch.DefaultPrecision),
+				top:      sketch.NewTopK(a.topK),
+			}
+			a.types[entity.Type] = stats
+		}
+
+		count := entity.GetCount()
+		if count < 1 {
+			count = 1
+		}
+		stats.occurrences += count
+		stats.distinct.Add(entity.GetValue())
+		stats.top.Add(entity.GetValue(), count)
+
+		if !seen[entity.Type] {
+			seen[entity.Type] = true
+			stats.documents++
+		}
+	}
+}
+
+// AddResult records the entities of an extraction result
+func (a *Aggregator) AddResult(result *PiiExtractionResult) {
+	if result == nil {
+		return
+	}
+	a.Add(result.Entities)
+}
+
+// Snapshot returns the current summary
+func (a *Aggregator) Snapshot() *Aggregate {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	aggregate := &Aggregate{
+		Documents: a.documents,
+		Types:     make(map[PiiType]*TypeAggregate, len(a.types)),
+	}
+	for piiType, stats := range a.types {
+		aggregate.Types[piiType] = &TypeAggregate{
+			Type:             piiType,
+			Occurrences:      stats.occurrences,
+			Documents:        stats.documents,
+			DistinctEstimate: stats.distinct.Estimate(),
+			TopValues:        stats.top.Top(),
+		}
+	}
+	return aggregate
+}
+
+// Distinct returns a copy of the distinct value sketches per type, to be merged with the
+// sketches of other shards (see DistinctCounts.Merge)
+func (a *Aggregator) Distinct() *DistinctCounts {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	distinct := NewDistinctCounts(sketch.DefaultPrecision)
+	for piiType, stats := range a.types {
+		distinct.sketches[piiType] = stats.distinct.Clone()
+	}
+	return distinct
+}
+
+// Reset clears all accumulated data
+func (a *Aggregator) Reset() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.documents = 0
+	a.types = make(map[PiiType]*typeAggregator)
+}
@@ -0,0 +1,49 @@
+package pii
+
+import "maps"
+
+// Annotate attaches enrichment data (geo lookup, BIN lookup, directory match, ...) to the
+// entity under key, replacing any previous value. Values should be JSON-serializable so
+// results can be exported; namespacing keys by integration (e.g. "geo.country") avoids
+// collisions between enrichment passes.
+func (p *PiiEntity) Annotate(key string, value any) {
+	if p.Annotations == nil {
+		p.Annotations = make(map[string]any)
+	}
+	p.Annotations[key] = value
+}
+
+// Annotation returns the enrichment data attached under key
+func (p PiiEntity) Annotation(key string) (any, bool) {
+	value, ok := p.Annotations[key]
+	return value, ok
+}
+
+// GetAnnotated returns all entities carrying an annotation under key
+func (r *PiiExtractionResult) GetAnnotated(key string) []PiiEntity {
+	var result []PiiEntity
+	for _, entity := range r.Entities {
+		if _, ok := entity.Annotations[key]; ok {
+			result = append(result, entity)
+		}
+	}
+	return result
+}
+
+// mergeAnnotations adds the source annotations missing from target. The target map is
+// copied first since it may be shared with the entity it was deduplicated from.
+func mergeAnnotations(target, source *PiiEntity) {
+	if len(source.Annotations) == 0 {
+		return
+	}
+	merged := maps.Clone(target.Annotations)
+	if merged == nil {
+		merged = make(map[string]any, len(source.Annotations))
+	}
+	for key, value := range source.Annotations {
+		if _, exists := merged[key]; !exists {
+			merged[key] = value
+		}
+	}
+	target.Annotations = merged
+}
@@ -0,0 +1,60 @@
+package pii
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestAnnotate(t *testing.T) {
+	entity := PiiEntity{Type: PiiTypeIPAddress, Value: NewIPAddress("8.8.8.8", "ipv4")}
+	entity.Annotate("geo.country", "US")
+	entity.Annotate("geo.asn", 15169)
+
+	if country, ok := entity.Annotation("geo.country"); !ok || country != "US" {
+		t.Errorf("Expected geo.country annotation, got %v", country)
+	}
+
+	data, err := json.Marshal(entity)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var decoded map[string]any
+	json.Unmarshal(data, &decoded)
+	annotations, _ := decoded["annotations"].(map[string]any)
+	if annotations["geo.country"] != "US" || annotations["geo.asn"] != float64(15169) {
+		t.Errorf("Expected annotations in JSON, got %s", data)
+	}
+
+	plain, _ := json.Marshal(PiiEntity{Type: PiiTypeEmail, Value: NewEmail("john@example.com")})
+	var plainDecoded map[string]any
+	json.Unmarshal(plain, &plainDecoded)
+	if _, ok := plainDecoded["annotations"]; ok {
+		t.Errorf("Expected annotations to be omitted when empty, got %s", plain)
+	}
+}
+
+func TestAnnotationsMergedOnDeduplication(t *testing.T) {
+	first := PiiEntity{Type: PiiTypeEmail, Value: NewEmail("john@example.com")}
+	first.Annotate("hr.employee_id", "E123")
+	second := PiiEntity{Type: PiiTypeEmail, Value: NewEmail("john@example.com")}
+	second.Annotate("hr.employee_id", "E999")
+	second.Annotate("hr.department", "Sales")
+
+	result := NewPiiExtractionResult([]PiiEntity{first, second})
+	if result.Total != 1 {
+		t.Fatalf("Expected one deduplicated entity, got %d", result.Total)
+	}
+	merged := result.Entities[0]
+	if id, _ := merged.Annotation("hr.employee_id"); id != "E123" {
+		t.Errorf("Expected the first annotation to win, got %v", id)
+	}
+	if department, _ := merged.Annotation("hr.department"); department != "Sales" {
+		t.Errorf("Expected missing annotations to be merged, got %v", department)
+	}
+	if len(first.Annotations) != 1 {
+		t.Errorf("Expected the original entity to be left untouched, got %v", first.Annotations)
+	}
+	if len(result.GetAnnotated("hr.department")) != 1 {
+		t.Error("Expected GetAnnotated to find the merged entity")
+	}
+}
@@ -0,0 +1,88 @@
+package pii
+
+import "sync"
+
+// ResultBuilder assembles an extraction result from entities produced by custom pipelines,
+// such as per-page workers. Duplicate values are merged as they are added, summing their
+// counts and merging their contexts, annotations and validation, exactly as the
+// extractors do. It is safe for concurrent use.
+type ResultBuilder struct {
+	mu         sync.Mutex
+	entities   map[string]*PiiEntity
+	order      []string // keys in the order their value was first added
+	suppressed []SuppressedCandidate
+}
+
+// NewResultBuilder creates an empty result builder
+func NewResultBuilder() *ResultBuilder {
+	return &ResultBuilder{entities: make(map[string]*PiiEntity)}
+}
+
+// Add adds entities, merging the values already added
+func (b *ResultBuilder) Add(entities ...PiiEntity) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, entity := range entities {
+		key := generateEntityKey(entity)
+		if existing, exists := b.entities[key]; exists {
+			mergeEntity(existing, &entity)
+			continue
+		}
+		b.entities[key] = &entity
+		b.order = append(b.order, key)
+	}
+}
+
+// AddResult adds the entities and suppressed candidates of a partial result. Nil results
+// are ignored.
+func (b *ResultBuilder) AddResult(result *PiiExtractionResult) {
+	if result == nil {
+		return
+	}
+	b.Add(result.Entities...)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.suppressed = append(b.suppressed, result.Suppressed...)
+}
+
+// AddSuppressed records candidates dropped by false-positive filters
+func (b *ResultBuilder) AddSuppressed(candidates ...SuppressedCandidate) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.suppressed = append(b.suppressed, candidates...)
+}
+
+// Len returns the number of distinct entities added so far
+func (b *ResultBuilder) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.order)
+}
+
+// Build returns the result of the entities added so far, with stats and entity IDs, in
+// the order their value was first added. The builder can keep being used afterwards.
+func (b *ResultBuilder) Build() *PiiExtractionResult {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	entities := make([]PiiEntity, 0, len(b.order))
+	stats := make(map[PiiType]int)
+	for _, key := range b.order {
+		entity := *b.entities[key]
+		entities = append(entities, entity)
+		stats[entity.Type]++
+	}
+
+	result := &PiiExtractionResult{
+		Entities: entities,
+		Stats:    stats,
+		Total:    len(entities),
+	}
+	if len(b.suppressed) > 0 {
+		result.Suppressed = append([]SuppressedCandidate(nil), b.suppressed...)
+	}
+	result.assignMissingIDs()
+	return result
+}
@@ -0,0 +1,67 @@
+package pii
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestResultBuilder_Concurrent(t *testing.T) {
+	builder := NewResultBuilder()
+
+	var wg sync.WaitGroup
+	for page := 0; page < 8; page++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			email := NewEmail("john@acme.com")
+			email.AddContext(fmt.Sprintf("page %d", page))
+			builder.Add(PiiEntity{Type: PiiTypeEmail, Value: email})
+
+			phone := NewPhone(fmt.Sprintf("555-010%d", page), CountryUS)
+			builder.Add(PiiEntity{Type: PiiTypePhone, Value: phone})
+		}()
+	}
+	wg.Wait()
+
+	result := builder.Build()
+	if result.Total != 9 || result.Stats[PiiTypeEmail] != 1 || result.Stats[PiiTypePhone] != 8 {
+		t.Fatalf("Unexpected stats: total %d, %v", result.Total, result.Stats)
+	}
+	email := result.GetEntitiesByType(PiiTypeEmail)[0]
+	if email.GetCount() != 8 || len(email.GetContexts()) != 8 {
+		t.Errorf("Expected the 8 pages merged, got count %d and contexts %v", email.GetCount(), email.GetContexts())
+	}
+	for _, entity := range result.Entities {
+		if entity.ID == "" {
+			t.Errorf("Expected an ID on %s", entity.GetValue())
+		}
+	}
+}
+
+func TestResultBuilder_AddResult(t *testing.T) {
+	builder := NewResultBuilder()
+	builder.AddResult(nil)
+	builder.AddResult(&PiiExtractionResult{
+		Entities:   []PiiEntity{{Type: PiiTypeSSN, Value: NewSSN("123-45-6789")}},
+		Suppressed: []SuppressedCandidate{{Type: PiiTypeCreditCard, Value: "4111 1111 1111 1112", Reason: "luhn"}},
+	})
+	builder.Add(PiiEntity{Type: PiiTypeEmail, Value: NewEmail("jane@acme.com")})
+
+	if builder.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", builder.Len())
+	}
+	first := builder.Build()
+	if first.Entities[0].Type != PiiTypeSSN || first.Entities[1].Type != PiiTypeEmail {
+		t.Errorf("Expected entities in the order they were added, got %+v", first.Entities)
+	}
+	if len(first.Suppressed) != 1 {
+		t.Errorf("Expected the suppressed candidate kept, got %+v", first.Suppressed)
+	}
+
+	// Building again includes the entities added in between
+	builder.Add(PiiEntity{Type: PiiTypeEmail, Value: NewEmail("bob@acme.com")})
+	if second := builder.Build(); second.Total != 3 || first.Total != 2 {
+		t.Errorf("Expected 2 then 3 entities, got %d and %d", first.Total, second.Total)
+	}
+}
@@ -0,0 +1,152 @@
+package pii
+
+import (
+	"hash/fnv"
+)
+
+// AddContextLimit adds a context if it doesn't already exist, keeping at most limit contexts.
+// Once the limit is reached, contexts are reservoir sampled so every distinct context has the
+// same chance of being kept. Sampling is seeded from the context itself, so the same input
+// always yields the same contexts.
+func (p *BasePii) AddContextLimit(context string, limit int) {
+	if p.hasContext(context) {
+		return
+	}
+
+	// Contexts set through struct literals count as already seen
+	if p.contextsSeen < len(p.Contexts) {
+		p.contextsSeen = len(p.Contexts)
+	}
+	p.contextsSeen++
+
+	if limit <= 0 || len(p.Contexts) < limit {
+		p.Contexts = append(p.Contexts, context)
+		p.index.add(p.Contexts)
+		return
+	}
+
+	if slot := reservoirSlot(context, p.contextsSeen); slot < limit {
+		p.index.replace(p.Contexts[slot], context, slot)
+		p.Contexts[slot] = context
+	}
+}
+
+// contextIndexThreshold is the number of contexts above which duplicate detection uses a hash set.
+// Smaller lists are scanned linearly, which is faster and avoids allocating a map per entity.
+const contextIndexThreshold = 16
+
+// contextIndex is a hash set over the contexts of a BasePii. BasePii values are copied freely,
+// so the index records the slice it was built for and is rebuilt when used with another one.
+type contextIndex struct {
+	slots map[string]int
+	first *string
+	size  int
+}
+
+// hasContext reports whether the context is already stored
+func (p *BasePii) hasContext(context string) bool {
+	if len(p.Contexts) < contextIndexThreshold {
+		p.index = nil
+		for _, existingContext := range p.Contexts {
+			if existingContext == context {
+				return true
+			}
+		}
+		return false
+	}
+
+	if !p.index.indexes(p.Contexts) {
+		p.index = newContextIndex(p.Contexts)
+	}
+	_, exists := p.index.slots[context]
+	return exists
+}
+
+// newContextIndex builds an index over contexts
+func newContextIndex(contexts []string) *contextIndex {
+	index := &contextIndex{slots: make(map[string]int, len(contexts))}
+	for slot, context := range contexts {
+		index.slots[context] = slot
+	}
+	index.track(contexts)
+	return index
+}
+
+// indexes reports whether the index was built for exactly this slice
+func (i *contextIndex) indexes(contexts []string) bool {
+	return i != nil && i.size == len(contexts) && (len(contexts) == 0 || i.first == &contexts[0])
+}
+
+// track records the slice the index is in sync with
+func (i *contextIndex) track(contexts []string) {
+	i.size = len(contexts)
+	if len(contexts) > 0 {
+		i.first = &contexts[0]
+	}
+}
+
+// add records the last context appended to contexts
+func (i *contextIndex) add(contexts []string) {
+	if i == nil {
+		return
+	}
+	i.slots[contexts[len(contexts)-1]] = len(contexts) - 1
+	i.track(contexts)
+}
+
+// replace records that the context at slot was replaced
+func (i *contextIndex) replace(old, new string, slot int) {
+	if i == nil {
+		return
+	}
+	delete(i.slots, old)
+	i.slots[new] = slot
+}
+
+// reservoirSlot returns a deterministic pseudo-random index in [0, seen) for a context
+func reservoirSlot(context string, seen int) int {
+	h := fnv.New64a()
+	h.Write([]byte(context))
+
+	// splitmix64 finalizer mixes the context hash with its position in the stream
+	x := h.Sum64() + uint64(seen)*0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	x ^= x >> 31
+
+	return int(x % uint64(seen))
+}
+
+// TrimContexts returns a copy of the entity keeping at most its first limit contexts. A
+// limit of zero or less removes them all.
+func (p PiiEntity) TrimContexts(limit int) PiiEntity {
+	if len(p.GetContexts()) <= max(limit, 0) {
+		return p
+	}
+	if value, ok := withBase(p.Value, func(base BasePii) BasePii {
+		return BasePii{Value: base.Value, Contexts: base.Contexts[:max(limit, 0):max(limit, 0)], Count: base.Count, Locations: base.Locations, display: base.display}
+	}); ok {
+		p.Value = value
+	}
+	return p
+}
+
+// SampleContexts returns a copy of the entity keeping a deterministic sample of at most
+// limit of its contexts, the same sample AddContextLimit keeps when the contexts are added
+// in order. A limit of zero or less keeps them all.
+func (p PiiEntity) SampleContexts(limit int) PiiEntity {
+	contexts := p.GetContexts()
+	if limit <= 0 || len(contexts) <= limit {
+		return p
+	}
+	if value, ok := withBase(p.Value, func(base BasePii) BasePii {
+		sampled := BasePii{Value: base.Value, Count: base.Count, Locations: base.Locations, display: base.display}
+		for _, context := range base.Contexts {
+			sampled.AddContextLimit(context, limit)
+		}
+		return sampled
+	}); ok {
+		p.Value = value
+	}
+	return p
+}
@@ -0,0 +1,140 @@
+package pii
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestAddContextLimit_CapsContexts(t *testing.T) {
+	base := BasePii{Value: "john@acme.com", Contexts: []string{"context 0"}, Count: 1}
+	for i := 1; i < 10000; i++ {
+		base.AddContextLimit(fmt.Sprintf("context %d", i), 5)
+	}
+
+	if len(base.Contexts) != 5 {
+		t.Fatalf("Expected 5 contexts, got %d", len(base.Contexts))
+	}
+
+	// Later contexts must have a chance to replace the first ones
+	if reflect.DeepEqual(base.Contexts, []string{"context 0", "context 1", "context 2", "context 3", "context 4"}) {
+		t.Error("Expected reservoir sampling to replace early contexts")
+	}
+}
+
+func TestAddContextLimit_Deterministic(t *testing.T) {
+	var first, second BasePii
+	for i := 0; i < 1000; i++ {
+		context := fmt.Sprintf("context %d", i)
+		first.AddContextLimit(context, 3)
+		second.AddContextLimit(context, 3)
+	}
+
+	if !reflect.DeepEqual(first.Contexts, second.Contexts) {
+		t.Errorf("Expected identical samples, got %v and %v", first.Contexts, second.Contexts)
+	}
+}
+
+func TestAddContextLimit_Unlimited(t *testing.T) {
+	var base BasePii
+	for i := 0; i < 20; i++ {
+		base.AddContextLimit(fmt.Sprintf("context %d", i), 0)
+		base.AddContextLimit(fmt.Sprintf("context %d", i), 0)
+	}
+
+	if len(base.Contexts) != 20 {
+		t.Errorf("Expected 20 distinct contexts, got %d", len(base.Contexts))
+	}
+}
+
+func TestAddContext_Unlimited(t *testing.T) {
+	var base BasePii
+	for i := 0; i < 20; i++ {
+		base.AddContext(fmt.Sprintf("context %d", i))
+	}
+
+	if len(base.Contexts) != 20 {
+		t.Errorf("Expected every context by default, got %d", len(base.Contexts))
+	}
+}
+
+func TestPiiEntity_SampleContexts(t *testing.T) {
+	email := NewEmail("john@acme.com")
+	var streamed BasePii
+	for i := 0; i < 100; i++ {
+		context := fmt.Sprintf("context %d", i)
+		email.AddContext(context)
+		streamed.AddContextLimit(context, 3)
+	}
+	entity := PiiEntity{Type: PiiTypeEmail, Value: email}
+
+	sampled := entity.SampleContexts(3)
+	if !reflect.DeepEqual(sampled.GetContexts(), streamed.Contexts) {
+		t.Errorf("Expected the sample kept by AddContextLimit, got %v and %v", sampled.GetContexts(), streamed.Contexts)
+	}
+	if len(entity.GetContexts()) != 100 || len(entity.SampleContexts(0).GetContexts()) != 100 {
+		t.Error("Expected the source entity left untouched and no limit to keep every context")
+	}
+}
+
+func TestAddContextLimit_IndexedDuplicates(t *testing.T) {
+	var base BasePii
+	for round := 0; round < 3; round++ {
+		for i := 0; i < 100; i++ {
+			base.AddContextLimit(fmt.Sprintf("context %d", i), 0)
+		}
+	}
+
+	if len(base.Contexts) != 100 {
+		t.Errorf("Expected 100 distinct contexts, got %d", len(base.Contexts))
+	}
+}
+
+func TestAddContextLimit_CopiesDoNotShareIndex(t *testing.T) {
+	var original BasePii
+	for i := 0; i < 50; i++ {
+		original.AddContextLimit(fmt.Sprintf("context %d", i), 0)
+	}
+
+	// Copies made by value (as in entity merging) must keep independent duplicate detection
+	clone := original
+	clone.Contexts = append([]string(nil), original.Contexts...)
+	original.AddContextLimit("only in original", 0)
+	clone.AddContextLimit("only in original", 0)
+
+	if len(clone.Contexts) != 51 || clone.Contexts[50] != "only in original" {
+		t.Errorf("Expected the clone to accept the context, got %d contexts", len(clone.Contexts))
+	}
+}
+
+func BenchmarkAddContext_Unlimited(b *testing.B) {
+	contexts := make([]string, 5000)
+	for i := range contexts {
+		contexts[i] = fmt.Sprintf("context %d", i)
+	}
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		var base BasePii
+		for _, context := range contexts {
+			base.AddContextLimit(context, 0)
+		}
+	}
+}
+
+func TestPiiEntity_TrimContexts(t *testing.T) {
+	email := NewEmail("john@acme.com")
+	email.Contexts = []string{"one", "two", "three"}
+	entity := PiiEntity{Type: PiiTypeEmail, Value: email}
+
+	trimmed := entity.TrimContexts(2)
+	if got := trimmed.GetContexts(); len(got) != 2 || got[0] != "one" {
+		t.Errorf("TrimContexts(2) contexts = %v", got)
+	}
+	if len(entity.GetContexts()) != 3 {
+		t.Error("Expected the source entity left untouched")
+	}
+	if got := entity.TrimContexts(0); len(got.GetContexts()) != 0 || got.GetValue() != "john@acme.com" {
+		t.Errorf("TrimContexts(0) = %+v", got)
+	}
+}
@@ -0,0 +1,123 @@
+package pii
+
+import "strings"
+
+// Country identifies the country targeted by a pattern, as an ISO 3166-1 alpha-2 code
+type Country string
+
+const (
+	CountryUS Country = "US"
+	CountryGB Country = "GB"
+	CountryFR Country = "FR"
+	CountryES Country = "ES"
+	CountryIT Country = "IT"
+	CountryDE Country = "DE"
+	CountryCN Country = "CN"
+	CountryIN Country = "IN"
+	CountryRU Country = "RU"
+	CountryNL Country = "NL"
+	CountryBE Country = "BE"
+
+	// RegionArabic covers the Arabic-speaking countries (Saudi Arabia, UAE, Egypt, ...) sharing
+	// one pattern set. XA is in the ISO 3166 user-assigned range, so it never names a real country.
+	RegionArabic Country = "XA"
+)
+
+// countryNames maps supported countries to their English names
+var countryNames = map[Country]string{
+	CountryUS:    "United States",
+	CountryGB:    "United Kingdom",
+	CountryFR:    "France",
+	CountryES:    "Spain",
+	CountryIT:    "Italy",
+	CountryDE:    "Germany",
+	CountryCN:    "China",
+	CountryIN:    "India",
+	CountryRU:    "Russia",
+	CountryNL:    "Netherlands",
+	CountryBE:    "Belgium",
+	RegionArabic: "Arabic-speaking countries",
+}
+
+// euCountries lists the member states of the European Union
+var euCountries = map[Country]bool{
+	"AT": true, "BE": true, "BG": true, "HR": true, "CY": true, "CZ": true, "DK": true,
+	"EE": true, "FI": true, "FR": true, "DE": true, "GR": true, "HU": true, "IE": true,
+	"IT": true, "LV": true, "LT": true, "LU": true, "MT": true, "NL": true, "PL": true,
+	"PT": true, "RO": true, "SK": true, "SI": true, "ES": true, "SE": true,
+}
+
+// legacyCountries maps the free-form names used before country codes to their codes
+var legacyCountries = map[string]Country{
+	"uk":      CountryGB,
+	"france":  CountryFR,
+	"spain":   CountryES,
+	"italy":   CountryIT,
+	"germany": CountryDE,
+	"china":   CountryCN,
+	"india":   CountryIN,
+	"russia":  CountryRU,
+	"arabic":  RegionArabic,
+}
+
+// SupportedCountries returns the countries with built-in patterns
+func SupportedCountries() []Country {
+	return []Country{CountryUS, CountryGB, CountryFR, CountryES, CountryIT, CountryDE, CountryCN, CountryIN, RegionArabic, CountryRU, CountryNL, CountryBE}
+}
+
+// ParseCountry parses an ISO 3166-1 alpha-2 code or one of the legacy country names
+// ("UK", "France", "Arabic", ...), ignoring case. It returns false for anything else.
+func ParseCountry(s string) (Country, bool) {
+	s = strings.TrimSpace(s)
+	if country, ok := legacyCountries[strings.ToLower(s)]; ok {
+		return country, true
+	}
+	if len(s) == 2 && isASCIILetter(s[0]) && isASCIILetter(s[1]) {
+		return Country(strings.ToUpper(s)), true
+	}
+	return "", false
+}
+
+// Canonical returns the ISO code of a country given as a code or legacy name, or the
+// country unchanged when it cannot be parsed
+func (c Country) Canonical() Country {
+	if country, ok := ParseCountry(string(c)); ok {
+		return country
+	}
+	return c
+}
+
+// Name returns the English name of a supported country, or its code otherwise
+func (c Country) Name() string {
+	if name, ok := countryNames[c.Canonical()]; ok {
+		return name
+	}
+	return string(c)
+}
+
+// InEU reports whether the country is a member state of the European Union
+func (c Country) InEU() bool {
+	return euCountries[c.Canonical()]
+}
+
+// String returns the country code
+func (c Country) String() string {
+	return string(c)
+}
+
+// MarshalText encodes the canonical country code
+func (c Country) MarshalText() ([]byte, error) {
+	return []byte(c.Canonical()), nil
+}
+
+// UnmarshalText decodes a country code or, for backward compatibility, a legacy country
+// name such as "UK" or "Arabic"
+func (c *Country) UnmarshalText(data []byte) error {
+	*c = Country(data).Canonical()
+	return nil
+}
+
+// isASCIILetter reports whether b is an ASCII letter
+func isASCIILetter(b byte) bool {
+	return (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
@@ -0,0 +1,111 @@
+package pii
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseCountry(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Country
+		ok    bool
+	}{
+		{"US", CountryUS, true},
+		{"gb", CountryGB, true},
+		{"UK", CountryGB, true},
+		{"France", CountryFR, true},
+		{" arabic ", RegionArabic, true},
+		{"BR", Country("BR"), true},
+		{"Atlantis", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseCountry(tt.input)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ParseCountry(%q) = (%q, %v), want (%q, %v)", tt.input, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestCountryJSON_BackwardCompatible(t *testing.T) {
+	var phone Phone
+	if err := json.Unmarshal([]byte(`{"value": "+7 495 123-45-67", "country": "Russia"}`), &phone); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if phone.Country != CountryRU {
+		t.Errorf("Expected legacy country name to decode as RU, got %q", phone.Country)
+	}
+
+	data, err := json.Marshal(NewZipCode("SW1A 1AA", "UK"))
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var decoded map[string]any
+	json.Unmarshal(data, &decoded)
+	if decoded["country"] != "GB" {
+		t.Errorf("Expected the canonical code in JSON, got %v", decoded["country"])
+	}
+
+	if CountryGB.Name() != "United Kingdom" || RegionArabic.Name() != "Arabic-speaking countries" {
+		t.Errorf("Unexpected names %q, %q", CountryGB.Name(), RegionArabic.Name())
+	}
+}
+
+func TestGetEntitiesByCountry(t *testing.T) {
+	result := NewPiiExtractionResult([]PiiEntity{
+		{Type: PiiTypeZipCode, Value: NewZipCode("SW1A 1AA", "UK")},
+		{Type: PiiTypePhone, Value: NewPhone("+49 30 12345678", CountryDE)},
+		{Type: PiiTypeEmail, Value: NewEmail("john@example.com")},
+	})
+
+	if got := result.GetEntitiesByCountry(CountryGB); len(got) != 1 || got[0].Type != PiiTypeZipCode {
+		t.Errorf("Expected the GB postal code, got %+v", got)
+	}
+	if got := result.GetEntitiesByCountry("germany"); len(got) != 1 {
+		t.Errorf("Expected legacy names to match, got %d entities", len(got))
+	}
+	if EntityCountry(result.GetEmails()[0]) != "" {
+		t.Error("Expected no country for international types")
+	}
+}
+
+func TestGroupByCountryAndType(t *testing.T) {
+	result := NewPiiExtractionResult([]PiiEntity{
+		{Type: PiiTypeZipCode, Value: NewZipCode("SW1A 1AA", "UK")},
+		{Type: PiiTypePhone, Value: NewPhone("+49 30 12345678", CountryDE)},
+		{Type: PiiTypeZipCode, Value: NewZipCode("10115", CountryDE)},
+		{Type: PiiTypeEmail, Value: NewEmail("john@example.com")},
+		{Type: PiiTypePhone, Value: NewPhone("+31 20 123 4567", "NL")},
+	})
+
+	byCountry := result.GroupByCountry()
+	if len(byCountry) != 4 {
+		t.Errorf("Expected GB, DE, NL and international groups, got %d", len(byCountry))
+	}
+	if got := byCountry[CountryDE]; len(got) != 2 || got[0].Type == got[1].Type {
+		t.Errorf("Expected the German phone and postal code, got %+v", got)
+	}
+	if got := byCountry["NL"]; len(got) != 1 {
+		t.Errorf("Expected countries without a built-in pack to be grouped, got %+v", got)
+	}
+	if got := byCountry[""]; len(got) != 1 || got[0].Type != PiiTypeEmail {
+		t.Errorf("Expected the email under the empty country, got %+v", got)
+	}
+
+	byType := result.GroupByType()
+	if len(byType[PiiTypeZipCode]) != 2 || len(byType[PiiTypePhone]) != 2 || len(byType[PiiTypeEmail]) != 1 {
+		t.Errorf("Unexpected groups by type: %+v", byType)
+	}
+	if _, ok := byType[PiiTypeSSN]; ok {
+		t.Error("Expected no group for types without entities")
+	}
+}
+
+func TestCountry_InEU(t *testing.T) {
+	for country, want := range map[Country]bool{CountryFR: true, "ie": true, "Germany": true, CountryGB: false, CountryUS: false, RegionArabic: false} {
+		if got := country.InEU(); got != want {
+			t.Errorf("%s.InEU() = %v, want %v", country, got, want)
+		}
+	}
+}
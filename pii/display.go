@@ -0,0 +1,90 @@
+package pii
+
+import (
+	"unicode"
+)
+
+// DisplayMode sets how the String methods of values and entities print the value, so
+// that printing a result while debugging does not leak full SSNs or card numbers into logs
+type DisplayMode int32
+
+const (
+	// DisplayMasked masks every letter and digit, keeping separators, except the last
+	// DisplayKeepLast ones of values long enough to stay unidentifiable (default)
+	DisplayMasked DisplayMode = iota
+	// DisplayFull prints values as found, for local debugging only
+	DisplayFull
+)
+
+// DisplayKeepLast is the number of trailing letters or digits kept by DisplayMasked in
+// values with at least DisplayMinLength letters and digits, such as the last four digits
+// of a card number
+const (
+	DisplayKeepLast  = 4
+	DisplayMinLength = 12
+)
+
+// DisplayMaskRune is the character replacing masked characters in printed values
+const DisplayMaskRune = '*'
+
+// DisplayValue returns value as printed by String methods under the given display mode
+func DisplayValue(value string, mode DisplayMode) string {
+	if mode == DisplayFull {
+		return value
+	}
+	return MaskValue(value)
+}
+
+// SetDisplayMode sets how the String methods of the entities of the result print their
+// values. GetValue always returns the value as found. Extractors apply
+// ExtractorConfig.DisplayMode this way.
+func (r *PiiExtractionResult) SetDisplayMode(mode DisplayMode) {
+	for i := range r.Entities {
+		r.Entities[i] = r.Entities[i].WithDisplayMode(mode)
+	}
+}
+
+// WithDisplayMode returns a copy of the entity whose String method prints the value
+// under the given display mode
+func (p PiiEntity) WithDisplayMode(mode DisplayMode) PiiEntity {
+	if value, ok := withBase(p.Value, func(base BasePii) BasePii {
+		base.display = mode
+		return base
+	}); ok {
+		p.Value = value
+	}
+	return p
+}
+
+// MaskValue masks value as DisplayMasked does, such as "**** **** **** 1111" for a card
+// number or "***-**-****" for an SSN
+func MaskValue(value string) string {
+	runes := []rune(value)
+	maskable := 0
+	for _, r := range runes {
+		if isDisplayMaskable(r) {
+			maskable++
+		}
+	}
+	keep := 0
+	if maskable >= DisplayMinLength {
+		keep = DisplayKeepLast
+	}
+
+	seen := 0
+	for i, r := range runes {
+		if !isDisplayMaskable(r) {
+			continue
+		}
+		if seen < maskable-keep {
+			runes[i] = DisplayMaskRune
+		}
+		seen++
+	}
+	return string(runes)
+}
+
+// isDisplayMaskable reports whether a character of a printed value is masked
+func isDisplayMaskable(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
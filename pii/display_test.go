@@ -0,0 +1,53 @@
+package pii
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestMaskValue(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"4111 1111 1111 1111", "**** **** **** 1111"},
+		{"123-45-6789", "***-**-****"},
+		{"john.doe@example.com", "****.***@******e.com"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := MaskValue(tt.value); got != tt.want {
+			t.Errorf("MaskValue(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestString_MaskedByDefault(t *testing.T) {
+	result := NewPiiExtractionResult([]PiiEntity{
+		{Type: PiiTypeSSN, Value: NewSSN("123-45-6789")},
+		{Type: PiiTypeCreditCard, Value: NewCreditCard("4111 1111 1111 1111", "visa")},
+	})
+
+	printed := fmt.Sprint(result)
+	if strings.Contains(printed, "123-45-6789") || strings.Contains(printed, "4111 1111") {
+		t.Errorf("Printed result leaks values: %s", printed)
+	}
+	if !strings.Contains(printed, "***-**-****") || !strings.Contains(printed, "**** **** **** 1111") {
+		t.Errorf("Expected masked values in %s", printed)
+	}
+	if result.GetSSNs()[0].GetValue() != "123-45-6789" {
+		t.Error("Expected GetValue to return the value as found")
+	}
+
+	result.SetDisplayMode(DisplayFull)
+	if got := result.GetSSNs()[0].String(); got != "123-45-6789" {
+		t.Errorf("Expected the full value under DisplayFull, got %q", got)
+	}
+	if got := NewPiiExtractionResult(result.Entities).Entities[0].TrimContexts(0).String(); got != "123-45-6789" {
+		t.Errorf("Expected copies to keep the display mode, got %q", got)
+	}
+	if got := (PiiEntity{Type: PiiTypeSSN, Value: NewSSN("123-45-6789")}).String(); got != "***-**-****" {
+		t.Errorf("Expected other entities to stay masked, got %q", got)
+	}
+}
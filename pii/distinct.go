@@ -0,0 +1,154 @@
+package pii
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/intMeric/pii-extractor/sketch"
+)
+
+// DistinctCounts estimates the number of distinct values per PII type with one HyperLogLog
+// sketch per type, without retaining the values. Counts built on separate shards merge into
+// the counts of the whole corpus, and serialize to JSON to be shipped between processes.
+// It is safe for concurrent use.
+type DistinctCounts struct {
+	mu        sync.Mutex
+	precision uint8
+	sketches  map[PiiType]*sketch.HyperLogLog
+}
+
+// NewDistinctCounts creates distinct counts with sketches of the given precision (see
+// sketch.NewHyperLogLog); zero uses sketch.DefaultPrecision. Only counts of the same
+// precision can be merged.
+func NewDistinctCounts(precision uint8) *DistinctCounts {
+	if precision == 0 {
+		precision = sketch.DefaultPrecision
+	}
+	return &DistinctCounts{
+		precision: sketch.NewHyperLogLog(precision).Precision(),
+		sketches:  make(map[PiiType]*sketch.HyperLogLog),
+	}
+}
+
+// Add records the values of entities
+func (d *DistinctCounts) Add(entities []PiiEntity) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, entity := range entities {
+		d.sketch(entity.Type).Add(entity.GetValue())
+	}
+}
+
+// AddResult records the values of an extraction result
+func (d *DistinctCounts) AddResult(result *PiiExtractionResult) {
+	if result == nil {
+		return
+	}
+	d.Add(result.Entities)
+}
+
+// sketch returns the sketch of a type, creating it when missing
+func (d *DistinctCounts) sketch(piiType PiiType) *sketch.HyperLogLog {
+	s, ok := d.sketches[piiType]
+	if !ok {
+		s = sketch.NewHyperLogLog(d.precision)
+		d.sketches[piiType] = s
+	}
+	return s
+}
+
+// Merge adds the values counted by other, failing with sketch.ErrPrecisionMismatch when
+// the precisions differ
+func (d *DistinctCounts) Merge(other *DistinctCounts) error {
+	sketches := other.Sketches()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if other.precision != d.precision {
+		return fmt.Errorf("%w: %d and %d", sketch.ErrPrecisionMismatch, d.precision, other.precision)
+	}
+	for piiType, s := range sketches {
+		if err := d.sketch(piiType).Merge(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Estimate returns the approximate number of distinct values of a type
+func (d *DistinctCounts) Estimate(piiType PiiType) uint64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if s, ok := d.sketches[piiType]; ok {
+		return s.Estimate()
+	}
+	return 0
+}
+
+// Estimates returns the approximate number of distinct values of every type seen
+func (d *DistinctCounts) Estimates() map[PiiType]uint64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	estimates := make(map[PiiType]uint64, len(d.sketches))
+	for piiType, s := range d.sketches {
+		estimates[piiType] = s.Estimate()
+	}
+	return estimates
+}
+
+// Sketches returns a copy of the sketch of every type seen
+func (d *DistinctCounts) Sketches() map[PiiType]*sketch.HyperLogLog {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	sketches := make(map[PiiType]*sketch.HyperLogLog, len(d.sketches))
+	for piiType, s := range d.sketches {
+		sketches[piiType] = s.Clone()
+	}
+	return sketches
+}
+
+// distinctCountsJSON is the JSON form of DistinctCounts, with sketches keyed by type name
+type distinctCountsJSON struct {
+	Precision uint8                          `json:"precision"`
+	Sketches  map[string]*sketch.HyperLogLog `json:"sketches"`
+}
+
+// MarshalJSON encodes the counts with their sketches in base64, keyed by type name
+func (d *DistinctCounts) MarshalJSON() ([]byte, error) {
+	encoded := distinctCountsJSON{Precision: d.precision, Sketches: make(map[string]*sketch.HyperLogLog)}
+	for piiType, s := range d.Sketches() {
+		encoded.Sketches[piiType.String()] = s
+	}
+	return json.Marshal(encoded)
+}
+
+// UnmarshalJSON decodes counts encoded by MarshalJSON
+func (d *DistinctCounts) UnmarshalJSON(data []byte) error {
+	var encoded distinctCountsJSON
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+
+	sketches := make(map[PiiType]*sketch.HyperLogLog, len(encoded.Sketches))
+	for name, s := range encoded.Sketches {
+		piiType, ok := ParsePiiType(name)
+		if !ok {
+			return fmt.Errorf("unknown PII type %q", name)
+		}
+		if s == nil || s.Precision() != encoded.Precision {
+			return fmt.Errorf("%w: sketch of %s", sketch.ErrPrecisionMismatch, name)
+		}
+		sketches[piiType] = s
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.precision = encoded.Precision
+	d.sketches = sketches
+	return nil
+}
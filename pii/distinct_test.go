@@ -0,0 +1,57 @@
+package pii
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestDistinctCounts_MergeAcrossShards(t *testing.T) {
+	shards := []*DistinctCounts{NewDistinctCounts(0), NewDistinctCounts(0)}
+	for i := 0; i < 1000; i++ {
+		entity := PiiEntity{Type: PiiTypeEmail, Value: NewEmail(fmt.Sprintf("user%d@example.com", i))}
+		shards[i%2].Add([]PiiEntity{entity})
+		shards[(i+1)%2].Add([]PiiEntity{entity}) // every value is on both shards
+	}
+	shards[0].Add([]PiiEntity{{Type: PiiTypeSSN, Value: NewSSN("123-45-6789")}})
+
+	// Ship the second shard as JSON, as between processes
+	data, err := json.Marshal(shards[1])
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var received DistinctCounts
+	if err := json.Unmarshal(data, &received); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if err := shards[0].Merge(&received); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	if estimate := shards[0].Estimate(PiiTypeEmail); estimate < 970 || estimate > 1030 {
+		t.Errorf("Expected about 1000 distinct emails, got %d", estimate)
+	}
+	if estimates := shards[0].Estimates(); estimates[PiiTypeSSN] != 1 || len(estimates) != 2 {
+		t.Errorf("Unexpected estimates %v", estimates)
+	}
+	if err := shards[0].Merge(NewDistinctCounts(10)); err == nil {
+		t.Error("Expected an error merging counts of another precision")
+	}
+}
+
+func TestAggregator_Distinct(t *testing.T) {
+	aggregator := NewAggregator(5)
+	aggregator.Add([]PiiEntity{
+		{Type: PiiTypeEmail, Value: NewEmail("a@example.com")},
+		{Type: PiiTypeEmail, Value: NewEmail("b@example.com")},
+	})
+
+	distinct := aggregator.Distinct()
+	distinct.Add([]PiiEntity{{Type: PiiTypeEmail, Value: NewEmail("c@example.com")}})
+	if distinct.Estimate(PiiTypeEmail) != 3 {
+		t.Errorf("Expected 3 distinct emails, got %d", distinct.Estimate(PiiTypeEmail))
+	}
+	if snapshot := aggregator.Snapshot(); snapshot.Types[PiiTypeEmail].DistinctEstimate != 2 {
+		t.Errorf("Expected the aggregator sketches untouched, got %d", snapshot.Types[PiiTypeEmail].DistinctEstimate)
+	}
+}
@@ -0,0 +1,46 @@
+package pii
+
+import "strings"
+
+// Domain returns the lower-cased domain part of the email address
+func (e Email) Domain() string {
+	at := strings.LastIndexByte(e.Value, '@')
+	if at < 0 {
+		return ""
+	}
+	return strings.ToLower(e.Value[at+1:])
+}
+
+// IsInternalDomain reports whether domain equals one of the internal domains or is one of
+// their subdomains, ignoring case
+func IsInternalDomain(domain string, internalDomains []string) bool {
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	for _, internal := range internalDomains {
+		internal = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(internal), "@"))
+		if internal == "" {
+			continue
+		}
+		if domain == internal || strings.HasSuffix(domain, "."+internal) {
+			return true
+		}
+	}
+	return false
+}
+
+// MarkInternalEmails sets Internal on every email entity whose domain is one of the internal
+// domains. Internal emails are still reported but with a lowered severity.
+func MarkInternalEmails(entities []PiiEntity, internalDomains []string) {
+	if len(internalDomains) == 0 {
+		return
+	}
+	for i, entity := range entities {
+		email, ok := entity.AsEmail()
+		if !ok || email.Internal {
+			continue
+		}
+		if IsInternalDomain(email.Domain(), internalDomains) {
+			email.Internal = true
+			entities[i].Value = email
+		}
+	}
+}
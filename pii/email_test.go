@@ -0,0 +1,21 @@
+package pii
+
+import "testing"
+
+func TestIsInternalDomain(t *testing.T) {
+	internal := []string{"acme.com", "@Corp.Example"}
+	tests := map[string]bool{
+		"acme.com":         true,
+		"mail.acme.com":    true,
+		"ACME.COM":         true,
+		"corp.example":     true,
+		"notacme.com":      false,
+		"acme.com.evil.io": false,
+		"":                 false,
+	}
+	for domain, want := range tests {
+		if got := IsInternalDomain(domain, internal); got != want {
+			t.Errorf("IsInternalDomain(%q) = %v, want %v", domain, got, want)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package pii
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrTypeMismatch is returned when the value object of an entity does not match its type
+var ErrTypeMismatch = errors.New("PII value does not match the entity type")
+
+// TypeOf returns the PII type of a value object. It returns false for nil, Scrubbed values,
+// which can stand for any type, and value objects defined outside this package.
+func TypeOf(value Pii) (PiiType, bool) {
+	switch value.(type) {
+	case Phone:
+		return PiiTypePhone, true
+	case Email:
+		return PiiTypeEmail, true
+	case SSN:
+		return PiiTypeSSN, true
+	case ZipCode:
+		return PiiTypeZipCode, true
+	case PoBox:
+		return PiiTypePoBox, true
+	case StreetAddress:
+		return PiiTypeStreetAddress, true
+	case CreditCard:
+		return PiiTypeCreditCard, true
+	case IPAddress:
+		return PiiTypeIPAddress, true
+	case BtcAddress:
+		return PiiTypeBtcAddress, true
+	case IBAN:
+		return PiiTypeIBAN, true
+	case Hostname:
+		return PiiTypeHostname, true
+	case SessionToken:
+		return PiiTypeSessionToken, true
+	case TrackData:
+		return PiiTypeTrackData, true
+	case Secret:
+		return PiiTypeSecret, true
+	case Hash:
+		return PiiTypeHash, true
+	case NationalID:
+		return PiiTypeNationalID, true
+	case PersonName:
+		return PiiTypePersonName, true
+	default:
+		return 0, false
+	}
+}
+
+// NewEntity creates an entity of the given type, returning ErrTypeMismatch when the value
+// object is of another type, such as a Phone for PiiTypeEmail
+func NewEntity(piiType PiiType, value Pii) (PiiEntity, error) {
+	entity := PiiEntity{Type: piiType, Value: value}
+	if err := entity.Validate(); err != nil {
+		return PiiEntity{}, err
+	}
+	return entity, nil
+}
+
+// EntityOf creates the entity of a value object, with the type of the value:
+//
+//	entity, err := pii.EntityOf(pii.NewEmail("john@example.com"))
+func EntityOf(value Pii) (PiiEntity, error) {
+	piiType, ok := TypeOf(value)
+	if !ok {
+		return PiiEntity{}, fmt.Errorf("%w: cannot infer the type of a %T value", ErrTypeMismatch, value)
+	}
+	return PiiEntity{Type: piiType, Value: value}, nil
+}
+
+// Validate checks that the entity has a known type and a value object of that type.
+// Scrubbed values are valid for every type.
+func (p PiiEntity) Validate() error {
+	if p.Type.String() == "unknown" {
+		return fmt.Errorf("unknown PII type %d", int(p.Type))
+	}
+	if p.Value == nil {
+		return fmt.Errorf("%w: %s entity without a value", ErrTypeMismatch, p.Type)
+	}
+	if _, ok := p.Value.(Scrubbed); ok {
+		return nil
+	}
+	if piiType, ok := TypeOf(p.Value); !ok || piiType != p.Type {
+		return fmt.Errorf("%w: %s entity with a %T value", ErrTypeMismatch, p.Type, p.Value)
+	}
+	return nil
+}
+
+// UnmarshalJSON decodes an entity encoded as JSON, decoding its value into the value object
+// of its type, or into Scrubbed for scrubbed entities
+func (p *PiiEntity) UnmarshalJSON(data []byte) error {
+	type plain PiiEntity
+	var raw struct {
+		plain
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	entity := PiiEntity(raw.plain)
+	if len(raw.Value) > 0 && string(raw.Value) != "null" {
+		value, err := decodeValue(entity.Type, raw.Value)
+		if err != nil {
+			return err
+		}
+		entity.Value = value
+	}
+	*p = entity
+	return nil
+}
+
+// decodeValue decodes the JSON value object of a type
+func decodeValue(piiType PiiType, data json.RawMessage) (Pii, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	if _, ok := fields["severity"]; ok {
+		return decodeAs[Scrubbed](data)
+	}
+
+	switch piiType {
+	case PiiTypePhone:
+		return decodeAs[Phone](data)
+	case PiiTypeEmail:
+		return decodeAs[Email](data)
+	case PiiTypeSSN:
+		return decodeAs[SSN](data)
+	case PiiTypeZipCode:
+		return decodeAs[ZipCode](data)
+	case PiiTypePoBox:
+		return decodeAs[PoBox](data)
+	case PiiTypeStreetAddress:
+		return decodeAs[StreetAddress](data)
+	case PiiTypeCreditCard:
+		return decodeAs[CreditCard](data)
+	case PiiTypeIPAddress:
+		return decodeAs[IPAddress](data)
+	case PiiTypeBtcAddress:
+		return decodeAs[BtcAddress](data)
+	case PiiTypeIBAN:
+		return decodeAs[IBAN](data)
+	case PiiTypeHostname:
+		return decodeAs[Hostname](data)
+	case PiiTypeSessionToken:
+		return decodeAs[SessionToken](data)
+	case PiiTypeTrackData:
+		return decodeAs[TrackData](data)
+	case PiiTypeSecret:
+		return decodeAs[Secret](data)
+	case PiiTypeHash:
+		return decodeAs[Hash](data)
+	case PiiTypeNationalID:
+		return decodeAs[NationalID](data)
+	case PiiTypePersonName:
+		return decodeAs[PersonName](data)
+	default:
+		return nil, fmt.Errorf("cannot decode the value of unknown PII type %d", int(piiType))
+	}
+}
+
+// decodeAs decodes a value object of type T
+func decodeAs[T Pii](data json.RawMessage) (Pii, error) {
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
@@ -0,0 +1,92 @@
+package pii
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestNewEntity(t *testing.T) {
+	entity, err := NewEntity(PiiTypeEmail, NewEmail("john@example.com"))
+	if err != nil || entity.Type != PiiTypeEmail || entity.GetValue() != "john@example.com" {
+		t.Fatalf("NewEntity() = %+v, %v", entity, err)
+	}
+
+	if _, err := NewEntity(PiiTypeEmail, NewPhone("555-0100", CountryUS)); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("Expected ErrTypeMismatch for a phone email, got %v", err)
+	}
+	if _, err := NewEntity(PiiTypePhone, nil); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("Expected ErrTypeMismatch without a value, got %v", err)
+	}
+	if _, err := NewEntity(PiiType(99), NewEmail("john@example.com")); err == nil {
+		t.Error("Expected an error for an unknown type")
+	}
+	if _, err := NewEntity(PiiTypeSSN, Scrubbed{BasePii: BasePii{Value: "pii_0123"}}); err != nil {
+		t.Errorf("Expected scrubbed values to be valid for every type, got %v", err)
+	}
+}
+
+func TestEntityOf(t *testing.T) {
+	values := []Pii{
+		NewPhone("555-0100", CountryUS), NewEmail("john@example.com"), NewSSN("123-45-6789"),
+		NewZipCode("10001", CountryUS), NewPoBox("P.O. Box 1", CountryUS), NewStreetAddress("1 Main St", CountryUS),
+		NewCreditCard("4111111111111111", "visa"), NewIPAddress("10.0.0.1", "ipv4"),
+		NewBtcAddress("1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa"), NewIBAN("GB82WEST12345698765432", CountryGB),
+		NewHostname("db01.corp.internal", "internal", true), NewSessionToken("abcdef0123456789", "cookie", "sid"),
+		NewTrackData("%B4111111111111111^DOE/JOHN^2512101?", 1), NewSecret("sk_live_0123456789", 4.2, "key"),
+		NewHash("5d41402abc4b2a76b9719d911017c592", "md5"), NewNationalID("784-1990-1234567-6", RegionArabic, "ae_emirates_id"),
+		NewPersonName("John Smith", "en", 0.9),
+	}
+	if len(values) != len(PiiTypes()) {
+		t.Fatalf("Expected a value for each of the %d types", len(PiiTypes()))
+	}
+	for i, value := range values {
+		entity, err := EntityOf(value)
+		if err != nil || entity.Type != PiiTypes()[i] {
+			t.Errorf("EntityOf(%T) = %s, %v", value, entity.Type, err)
+		}
+	}
+
+	if _, err := EntityOf(Scrubbed{}); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("Expected scrubbed values to have no inferable type, got %v", err)
+	}
+}
+
+func TestPiiEntity_UnmarshalJSON(t *testing.T) {
+	result := NewPiiExtractionResult([]PiiEntity{
+		{Type: PiiTypePhone, Value: NewPhone("555-123-4567", CountryUS)},
+		{Type: PiiTypeEmail, Value: NewEmail("john@acme.com")},
+		{Type: PiiTypeIBAN, Value: NewIBAN("DE89370400440532013000", CountryDE)},
+	})
+	result.Entities[0].Annotate("source", "crm")
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded PiiExtractionResult
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if !reflect.DeepEqual(decoded.Entities, result.Entities) {
+		t.Errorf("Round trip changed the entities:\n%+v\n%+v", decoded.Entities, result.Entities)
+	}
+
+	scrubbedResult, err := result.Scrub(ScrubValues, "tenant-secret")
+	if err != nil {
+		t.Fatalf("Scrub() error = %v", err)
+	}
+	scrubbed, _ := json.Marshal(scrubbedResult)
+	if err := json.Unmarshal(scrubbed, &decoded); err != nil {
+		t.Fatalf("Unmarshal() of a scrubbed result error = %v", err)
+	}
+	if !decoded.Entities[0].IsScrubbed() {
+		t.Errorf("Expected a scrubbed value, got %T", decoded.Entities[0].Value)
+	}
+
+	var entity PiiEntity
+	if err := json.Unmarshal([]byte(`{"type":"phone","value":"555"}`), &entity); err == nil {
+		t.Error("Expected an error for a value that is not an object")
+	}
+}
@@ -0,0 +1,96 @@
+package pii
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/netip"
+	"strings"
+	"unicode"
+)
+
+// EntityIDPrefix starts every entity ID
+const EntityIDPrefix = "pii_"
+
+// EntityID returns the deterministic ID of a value: an HMAC-SHA256 of its type and
+// normalized value keyed by salt, such as "pii_3f2a...". The same finding gets the same
+// ID across scans, so that ticketing and case-management systems can track it.
+func EntityID(piiType PiiType, value, salt string) string {
+	mac := hmac.New(sha256.New, []byte(salt))
+	mac.Write([]byte(piiType.String()))
+	mac.Write([]byte{0})
+	mac.Write([]byte(NormalizeValue(piiType, value)))
+	return EntityIDPrefix + hex.EncodeToString(mac.Sum(nil)[:16])
+}
+
+// NormalizeValue returns the canonical form of a value, so that formatting differences
+// ("555-123-4567" and "(555) 123 4567", "John@Acme.com" and "john@acme.com") do not
+// change entity IDs
+func NormalizeValue(piiType PiiType, value string) string {
+	value = strings.TrimSpace(value)
+	switch piiType {
+	case PiiTypePhone, PiiTypeSSN, PiiTypeNationalID, PiiTypeCreditCard:
+		return strings.Map(keepDigits, value)
+	case PiiTypeEmail:
+		return strings.ToLower(value)
+	case PiiTypeHostname:
+		return strings.TrimSuffix(strings.ToLower(value), ".")
+	case PiiTypeIBAN, PiiTypeZipCode:
+		return strings.ToUpper(strings.Map(dropSeparators, value))
+	case PiiTypeIPAddress:
+		if addr, err := netip.ParseAddr(value); err == nil {
+			return addr.String()
+		}
+	case PiiTypeStreetAddress, PiiTypePoBox, PiiTypePersonName:
+		return strings.Join(strings.Fields(strings.ToLower(value)), " ")
+	}
+	return value
+}
+
+// AssignIDs sets the ID of every entity with the given salt. A per-tenant secret salt
+// keeps IDs from matching across tenants and from being reversed by hashing guessed
+// values, such as every SSN; extractors apply ExtractorConfig.EntityIDSalt this way.
+func (r *PiiExtractionResult) AssignIDs(salt string) {
+	for i := range r.Entities {
+		r.Entities[i].ID = EntityID(r.Entities[i].Type, r.Entities[i].GetValue(), salt)
+	}
+}
+
+// assignMissingIDs sets the unsalted ID of the entities without one, keeping the IDs
+// already assigned, such as salted ones
+func (r *PiiExtractionResult) assignMissingIDs() {
+	for i := range r.Entities {
+		if r.Entities[i].ID == "" {
+			r.Entities[i].ID = EntityID(r.Entities[i].Type, r.Entities[i].GetValue(), "")
+		}
+	}
+}
+
+// GetEntityByID returns the entity with the given ID
+func (r *PiiExtractionResult) GetEntityByID(id string) (PiiEntity, bool) {
+	for _, entity := range r.Entities {
+		if entity.ID == id {
+			return entity, true
+		}
+	}
+	return PiiEntity{}, false
+}
+
+func keepDigits(r rune) rune {
+	switch {
+	case r >= '0' && r <= '9':
+		return r
+	case r >= '٠' && r <= '٩': // Eastern Arabic digits
+		return '0' + r - '٠'
+	case r >= '۰' && r <= '۹': // Extended Arabic-Indic digits
+		return '0' + r - '۰'
+	}
+	return -1
+}
+
+func dropSeparators(r rune) rune {
+	if unicode.IsSpace(r) || r == '-' {
+		return -1
+	}
+	return r
+}
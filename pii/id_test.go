@@ -0,0 +1,56 @@
+package pii
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEntityID(t *testing.T) {
+	tests := []struct {
+		piiType PiiType
+		a, b    string
+	}{
+		{PiiTypePhone, "555-123-4567", "(555) 123 4567"},
+		{PiiTypeEmail, "John@Acme.com", "john@acme.com"},
+		{PiiTypeIBAN, "de89 3704 0044 0532 0130 00", "DE89370400440532013000"},
+		{PiiTypeIPAddress, "2001:DB8:0:0::1", "2001:db8::1"},
+		{PiiTypeStreetAddress, "12  Main St\nSpringfield", "12 main st springfield"},
+	}
+	for _, tt := range tests {
+		if EntityID(tt.piiType, tt.a, "") != EntityID(tt.piiType, tt.b, "") {
+			t.Errorf("Expected %q and %q to share an ID as %s", tt.a, tt.b, tt.piiType)
+		}
+	}
+
+	id := EntityID(PiiTypeEmail, "john@acme.com", "")
+	if !strings.HasPrefix(id, EntityIDPrefix) || len(id) != len(EntityIDPrefix)+32 {
+		t.Errorf("Unexpected ID format %q", id)
+	}
+	if id == EntityID(PiiTypeHostname, "john@acme.com", "") {
+		t.Error("Expected the type to be part of the ID")
+	}
+	if id == EntityID(PiiTypeEmail, "john@acme.com", "tenant-a") {
+		t.Error("Expected the salt to change the ID")
+	}
+}
+
+func TestPiiExtractionResult_IDs(t *testing.T) {
+	entities := []PiiEntity{{Type: PiiTypeEmail, Value: NewEmail("john@acme.com")}}
+	result := NewPiiExtractionResult(entities)
+	id := result.Entities[0].ID
+	if id != EntityID(PiiTypeEmail, "john@acme.com", "") {
+		t.Errorf("Expected NewPiiExtractionResult to assign IDs, got %q", id)
+	}
+	if _, ok := result.GetEntityByID(id); !ok {
+		t.Error("Expected GetEntityByID to find the entity")
+	}
+
+	salted := NewPiiExtractionResult(entities)
+	salted.AssignIDs("tenant-b")
+	if salted.Entities[0].ID != EntityID(PiiTypeEmail, "john@acme.com", "tenant-b") {
+		t.Error("Expected AssignIDs to replace the IDs")
+	}
+	if rebuilt := NewPiiExtractionResult(salted.Entities); rebuilt.Entities[0].ID != salted.Entities[0].ID {
+		t.Errorf("Expected NewPiiExtractionResult to keep salted IDs, got %q", rebuilt.Entities[0].ID)
+	}
+}
@@ -0,0 +1,125 @@
+package pii
+
+import (
+	"slices"
+	"unicode/utf8"
+)
+
+// Location is the position of one occurrence of a value in the extracted text, so that
+// redaction and highlighting tools can map entities back to the source document
+type Location struct {
+	Start     int `json:"start"`      // byte offset of the occurrence
+	End       int `json:"end"`        // byte offset just after the occurrence
+	RuneStart int `json:"rune_start"` // character offset of the occurrence
+	RuneEnd   int `json:"rune_end"`   // character offset just after the occurrence
+}
+
+// AddLocation records the location of an occurrence
+func (p *BasePii) AddLocation(location Location) {
+	p.Locations = append(p.Locations, location)
+}
+
+// GetLocations returns the locations of the occurrences, sorted by offset
+func (p BasePii) GetLocations() []Location {
+	return p.Locations
+}
+
+// GetLocations returns the locations of the entity occurrences, or nil when the value
+// does not record them
+func (p PiiEntity) GetLocations() []Location {
+	if located, ok := p.Value.(interface{ GetLocations() []Location }); ok {
+		return located.GetLocations()
+	}
+	return nil
+}
+
+// WithLocations returns a copy of the entity with its locations replaced
+func (p PiiEntity) WithLocations(locations []Location) PiiEntity {
+	if value, ok := withBase(p.Value, func(base BasePii) BasePii {
+		base.Locations = locations
+		return base
+	}); ok {
+		p.Value = value
+	}
+	return p
+}
+
+// WithoutOccurrences returns a copy of the entity without the occurrences at some of its
+// locations, lowering its count and removing the given contexts, which are those only
+// the removed occurrences had
+func (p PiiEntity) WithoutOccurrences(locations []Location, contexts []string) PiiEntity {
+	if value, ok := withBase(p.Value, func(base BasePii) BasePii {
+		base.Count = max(base.Count-len(locations), 1)
+		base.Contexts = slices.DeleteFunc(slices.Clone(base.Contexts), func(context string) bool {
+			return slices.Contains(contexts, context)
+		})
+		base.Locations = slices.DeleteFunc(slices.Clone(base.Locations), func(location Location) bool {
+			return slices.Contains(locations, location)
+		})
+		return base
+	}); ok {
+		p.Value = value
+	}
+	return p
+}
+
+// ShiftLocations returns a copy of the entity with its locations moved by a number of
+// bytes and runes, such as the offsets of the chunk of a document the entity was found in
+func (p PiiEntity) ShiftLocations(bytes, runes int) PiiEntity {
+	locations := p.GetLocations()
+	if len(locations) == 0 || (bytes == 0 && runes == 0) {
+		return p
+	}
+	shifted := make([]Location, len(locations))
+	for i, location := range locations {
+		shifted[i] = Location{
+			Start:     location.Start + bytes,
+			End:       location.End + bytes,
+			RuneStart: location.RuneStart + runes,
+			RuneEnd:   location.RuneEnd + runes,
+		}
+	}
+	return p.WithLocations(shifted)
+}
+
+// mergeLocations appends the locations of a duplicate entity to an existing one, keeping
+// them sorted by offset
+func mergeLocations(existing, entity *PiiEntity) {
+	added := entity.GetLocations()
+	if len(added) == 0 {
+		return
+	}
+	locations := append(slices.Clip(existing.GetLocations()), added...)
+	slices.SortFunc(locations, func(a, b Location) int { return a.Start - b.Start })
+	*existing = existing.WithLocations(slices.CompactFunc(locations, func(a, b Location) bool { return a == b }))
+}
+
+// Locator computes the locations of byte offsets in a text, counting runes from the
+// previous offset, so that locating the matches of a scan in order is linear
+type Locator struct {
+	text  string
+	bytes int // byte offset of the last located position
+	runes int // rune offset of the last located position
+}
+
+// NewLocator creates a locator for text
+func NewLocator(text string) *Locator {
+	return &Locator{text: text}
+}
+
+// Locate returns the location of the bytes from start to end
+func (l *Locator) Locate(start, end int) Location {
+	runeStart := l.runeOffset(start)
+	return Location{Start: start, End: end, RuneStart: runeStart, RuneEnd: l.runeOffset(end)}
+}
+
+// runeOffset returns the rune offset of a byte offset
+func (l *Locator) runeOffset(offset int) int {
+	if offset < l.bytes {
+		// Offsets going backwards are counted again from the start
+		l.bytes, l.runes = 0, 0
+	}
+	l.runes += utf8.RuneCountInString(l.text[l.bytes:offset])
+	l.bytes = offset
+	return l.runes
+}
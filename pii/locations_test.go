@@ -0,0 +1,68 @@
+package pii
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestLocator_ByteAndRuneOffsets(t *testing.T) {
+	text := "Écrire à john@acme.com ou à jane@acme.com"
+	locator := NewLocator(text)
+
+	start := strings.Index(text, "john")
+	first := locator.Locate(start, start+13)
+	if first != (Location{Start: 11, End: 24, RuneStart: 9, RuneEnd: 22}) {
+		t.Errorf("Unexpected first location %+v", first)
+	}
+	start = strings.Index(text, "jane")
+	second := locator.Locate(start, start+13)
+	if second.RuneStart != 28 || second.RuneEnd != 41 {
+		t.Errorf("Unexpected second location %+v", second)
+	}
+	// Going backwards counts again from the start
+	if again := locator.Locate(11, 24); again != first {
+		t.Errorf("Expected %+v again, got %+v", first, again)
+	}
+}
+
+func TestLocations_MergedAndShifted(t *testing.T) {
+	a := PiiEntity{Type: PiiTypeEmail, Value: Email{BasePii: BasePii{Value: "john@acme.com", Count: 1, Locations: []Location{{Start: 40, End: 53, RuneStart: 40, RuneEnd: 53}}}}}
+	b := PiiEntity{Type: PiiTypeEmail, Value: Email{BasePii: BasePii{Value: "john@acme.com", Count: 1, Locations: []Location{{Start: 0, End: 13, RuneStart: 0, RuneEnd: 13}}}}}
+
+	result := NewPiiExtractionResult([]PiiEntity{a, b})
+	locations := result.Entities[0].GetLocations()
+	if len(locations) != 2 || locations[0].Start != 0 || locations[1].Start != 40 {
+		t.Fatalf("Expected both locations sorted by offset, got %+v", locations)
+	}
+
+	shifted := result.Entities[0].ShiftLocations(100, 90)
+	if got := shifted.GetLocations()[1]; got != (Location{Start: 140, End: 153, RuneStart: 130, RuneEnd: 143}) {
+		t.Errorf("Unexpected shifted location %+v", got)
+	}
+	if !slices.Equal(result.Entities[0].GetLocations(), locations) {
+		t.Error("Expected ShiftLocations to leave the entity unchanged")
+	}
+
+	if merged := MergeResults(result, result); merged.Entities[0].GetLocations() != nil {
+		t.Errorf("Expected no locations across documents, got %+v", merged.Entities[0].GetLocations())
+	}
+	if trimmed := result.Entities[0].TrimContexts(0); len(trimmed.GetLocations()) != 2 {
+		t.Error("Expected TrimContexts to keep locations")
+	}
+}
+
+func TestLocations_WithoutOccurrences(t *testing.T) {
+	first, second := Location{Start: 0, End: 13, RuneStart: 0, RuneEnd: 13}, Location{Start: 40, End: 53, RuneStart: 40, RuneEnd: 53}
+	entity := PiiEntity{Type: PiiTypeEmail, Value: Email{BasePii: BasePii{
+		Value: "john@acme.com", Count: 2, Contexts: []string{"to john@acme.com", "ID xjohn@acme.com"}, Locations: []Location{first, second},
+	}}}
+
+	kept := entity.WithoutOccurrences([]Location{second}, []string{"ID xjohn@acme.com"})
+	if kept.GetCount() != 1 || !slices.Equal(kept.GetContexts(), []string{"to john@acme.com"}) || !slices.Equal(kept.GetLocations(), []Location{first}) {
+		t.Errorf("Expected the first occurrence only, got %+v", kept.Value)
+	}
+	if entity.GetCount() != 2 || len(entity.GetLocations()) != 2 || len(entity.GetContexts()) != 2 {
+		t.Error("Expected WithoutOccurrences to leave the entity unchanged")
+	}
+}
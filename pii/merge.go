@@ -0,0 +1,112 @@
+package pii
+
+import "maps"
+
+// MergePolicy controls how MergeResults handles the same value found in several documents
+type MergePolicy string
+
+const (
+	// MergeCounts combines identical values across documents into one entity with summed
+	// counts and merged contexts, without locations
+	MergeCounts MergePolicy = "merge_counts"
+	// KeepPerDocument keeps one entity per document, annotated with its document index,
+	// with its locations in that document
+	KeepPerDocument MergePolicy = "keep_per_document"
+)
+
+// AnnotationDocument is the annotation holding the index of the source result of an
+// entity merged with KeepPerDocument
+const AnnotationDocument = "document"
+
+// MergeResults combines the results of several documents into a corpus-level result,
+// merging identical values (MergeCounts)
+func MergeResults(results ...*PiiExtractionResult) *PiiExtractionResult {
+	return MergeResultsWith(MergeCounts, results...)
+}
+
+// MergeResultsWith combines the results of several documents with the given policy,
+// recomputing stats and validation stats and concatenating suppressed candidates. Nil
+// results are skipped but keep their index.
+func MergeResultsWith(policy MergePolicy, results ...*PiiExtractionResult) *PiiExtractionResult {
+	var entities []PiiEntity
+	for i, result := range results {
+		if result == nil {
+			continue
+		}
+		for _, entity := range result.Entities {
+			if policy == KeepPerDocument {
+				// Copy the annotations so that the source result is left untouched
+				entity.Annotations = maps.Clone(entity.Annotations)
+				entity.Annotate(AnnotationDocument, i)
+			} else {
+				// Offsets in different documents cannot be combined
+				entity = entity.WithLocations(nil)
+			}
+			entities = append(entities, entity)
+		}
+	}
+
+	var merged *PiiExtractionResult
+	if policy == KeepPerDocument {
+		merged = &PiiExtractionResult{Entities: entities, Stats: make(map[PiiType]int), Total: len(entities)}
+		for _, entity := range entities {
+			merged.Stats[entity.Type]++
+		}
+	} else {
+		merged = NewPiiExtractionResult(entities)
+	}
+
+	merged.ValidationStats = mergeValidationStats(merged.Entities, results)
+	merged.PatternSetVersion = commonPatternSetVersion(results)
+	for _, result := range results {
+		if result != nil {
+			merged.Suppressed = append(merged.Suppressed, result.Suppressed...)
+		}
+	}
+	return merged
+}
+
+// mergeValidationStats recomputes the validation stats of merged entities, or returns nil
+// when none of the results carried validation stats
+func mergeValidationStats(entities []PiiEntity, results []*PiiExtractionResult) *ValidationStats {
+	var stats *ValidationStats
+	for _, result := range results {
+		if result == nil || result.ValidationStats == nil {
+			continue
+		}
+		if stats == nil {
+			stats = &ValidationStats{Provider: result.ValidationStats.Provider, Model: result.ValidationStats.Model}
+		}
+		// Mixed providers or models cannot be attributed to a single one
+		if stats.Provider != result.ValidationStats.Provider {
+			stats.Provider = ""
+		}
+		if stats.Model != result.ValidationStats.Model {
+			stats.Model = ""
+		}
+		stats.SkippedCount += result.ValidationStats.SkippedCount
+		stats.Skipped = append(stats.Skipped, result.ValidationStats.Skipped...)
+	}
+	if stats == nil {
+		return nil
+	}
+
+	stats.Count(entities)
+	return stats
+}
+
+// commonPatternSetVersion returns the pattern set version shared by all results, or empty
+// when they differ
+func commonPatternSetVersion(results []*PiiExtractionResult) string {
+	version := ""
+	for _, result := range results {
+		if result == nil || result.PatternSetVersion == "" {
+			continue
+		}
+		if version != "" && version != result.PatternSetVersion {
+			return ""
+		}
+		version = result.PatternSetVersion
+	}
+	return version
+}
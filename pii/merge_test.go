@@ -0,0 +1,78 @@
+package pii
+
+import "testing"
+
+func mergeFixtures() (*PiiExtractionResult, *PiiExtractionResult) {
+	first := NewPiiExtractionResult([]PiiEntity{
+		{Type: PiiTypeEmail, Value: NewEmail("john@example.com"), Validation: &ValidationResult{Valid: true, Confidence: 0.9}},
+		{Type: PiiTypeSSN, Value: NewSSN("123-45-6789")},
+	})
+	first.ValidationStats = &ValidationStats{Provider: "openai", Model: "gpt-4o", SkippedCount: 1,
+		Skipped: []SkippedValidation{{Type: PiiTypeSSN, Value: "123-45-6789", Reason: "max_calls"}}}
+	first.PatternSetVersion = "1.2.0"
+
+	second := NewPiiExtractionResult([]PiiEntity{
+		{Type: PiiTypeEmail, Value: NewEmail("john@example.com")},
+		{Type: PiiTypePhone, Value: NewPhone("(555) 123-4567", CountryUS), Validation: &ValidationResult{Valid: false, Confidence: 0.5}},
+	})
+	second.ValidationStats = &ValidationStats{Provider: "openai", Model: "gpt-4o-mini"}
+	second.PatternSetVersion = "1.2.0"
+	return first, second
+}
+
+func TestMergeResults_MergeCounts(t *testing.T) {
+	first, second := mergeFixtures()
+	merged := MergeResults(first, nil, second)
+
+	if merged.Total != 3 || merged.Stats[PiiTypeEmail] != 1 {
+		t.Fatalf("Expected 3 distinct entities with one email, got %d (%v)", merged.Total, merged.Stats)
+	}
+	email := merged.GetEmails()[0]
+	if email.GetCount() != 2 || !email.IsValidated() {
+		t.Errorf("Expected the email count summed and its validation kept, got count %d, validation %v", email.GetCount(), email.Validation)
+	}
+
+	stats := merged.ValidationStats
+	if stats == nil || stats.TotalValidated != 2 || stats.ValidCount != 1 || stats.InvalidCount != 1 {
+		t.Fatalf("Unexpected validation stats: %+v", stats)
+	}
+	if stats.AverageConfidence != 0.7 || stats.SkippedCount != 1 || len(stats.Skipped) != 1 {
+		t.Errorf("Unexpected validation stats: %+v", stats)
+	}
+	if stats.ByType[PiiTypePhone] == nil || stats.ByType[PiiTypePhone].InvalidCount != 1 {
+		t.Errorf("Expected the rejected phone in the per-type stats, got %v", stats.ByType)
+	}
+	if stats.Provider != "openai" || stats.Model != "" {
+		t.Errorf("Expected the shared provider and no model, got %q/%q", stats.Provider, stats.Model)
+	}
+	if merged.PatternSetVersion != "1.2.0" {
+		t.Errorf("Expected the shared pattern set version, got %q", merged.PatternSetVersion)
+	}
+}
+
+func TestMergeResults_KeepPerDocument(t *testing.T) {
+	first, second := mergeFixtures()
+	second.PatternSetVersion = "1.1.0"
+	merged := MergeResultsWith(KeepPerDocument, first, second)
+
+	if merged.Total != 4 || merged.Stats[PiiTypeEmail] != 2 {
+		t.Fatalf("Expected 4 entities with two emails, got %d (%v)", merged.Total, merged.Stats)
+	}
+	for _, entity := range merged.GetEmails() {
+		if _, ok := entity.Annotation(AnnotationDocument); !ok {
+			t.Errorf("Expected a document annotation on %+v", entity)
+		}
+	}
+	if document, _ := merged.Entities[len(merged.Entities)-1].Annotation(AnnotationDocument); document != 1 {
+		t.Errorf("Expected the last entity to come from document 1, got %v", document)
+	}
+	if first.Entities[0].Annotations != nil {
+		t.Error("Expected the source results to be left untouched")
+	}
+	if merged.PatternSetVersion != "" {
+		t.Errorf("Expected no pattern set version for mixed versions, got %q", merged.PatternSetVersion)
+	}
+	if MergeResults().ValidationStats != nil {
+		t.Error("Expected no validation stats when no result carried any")
+	}
+}
@@ -0,0 +1,222 @@
+package pii
+
+import (
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Occurrence is one occurrence of an entity value at byte offsets in the text
+type Occurrence struct {
+	Start  int       `json:"start"`
+	End    int       `json:"end"`
+	Entity PiiEntity `json:"entity"`
+}
+
+// Occurrences locates every occurrence of the result entities in text (see
+// PiiEntity.OccurrencesIn), sorted by offset. Overlapping occurrences keep the earliest,
+// then longest, one.
+func (r *PiiExtractionResult) Occurrences(text string) []Occurrence {
+	var occurrences []Occurrence
+	if r == nil {
+		return occurrences
+	}
+
+	for _, entity := range r.Entities {
+		occurrences = append(occurrences, entity.OccurrencesIn(text)...)
+	}
+
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		if occurrences[i].Start != occurrences[j].Start {
+			return occurrences[i].Start < occurrences[j].Start
+		}
+		return occurrences[i].End > occurrences[j].End
+	})
+
+	kept := occurrences[:0]
+	for _, occurrence := range occurrences {
+		if len(kept) > 0 && occurrence.Start < kept[len(kept)-1].End {
+			continue
+		}
+		kept = append(kept, occurrence)
+	}
+	return kept
+}
+
+// OccurrencesIn returns the occurrences of the entity in text, sorted by offset: the
+// locations recorded by the extractor when they all point to the entity in text, or else
+// every occurrence of the value found by searching text. Session tokens and secrets, whose
+// masked values never appear in text, are only found at their locations.
+func (p PiiEntity) OccurrencesIn(text string) []Occurrence {
+	var occurrences []Occurrence
+	if locations := p.GetLocations(); len(locations) > 0 && !slices.ContainsFunc(locations, func(location Location) bool {
+		return !p.locatedAt(text, location)
+	}) {
+		for _, location := range locations {
+			occurrences = append(occurrences, Occurrence{Start: location.Start, End: location.End, Entity: p})
+		}
+		return occurrences
+	}
+
+	value := p.GetValue()
+	if value == "" || p.masked() {
+		return occurrences
+	}
+	for offset := 0; offset < len(text); {
+		index := strings.Index(text[offset:], value)
+		if index < 0 {
+			break
+		}
+		start := offset + index
+		occurrences = append(occurrences, Occurrence{Start: start, End: start + len(value), Entity: p})
+		offset = start + len(value)
+	}
+	return occurrences
+}
+
+// locatedAt reports whether the text at a location is the entity value, or the raw value
+// of a masked entity
+func (p PiiEntity) locatedAt(text string, location Location) bool {
+	if location.Start < 0 || location.Start > location.End || location.End > len(text) {
+		return false
+	}
+	raw := text[location.Start:location.End]
+	if p.masked() {
+		return MaskSessionToken(raw) == p.GetValue()
+	}
+	return raw == p.GetValue()
+}
+
+// masked reports whether the entity value is masked (see MaskSessionToken)
+func (p PiiEntity) masked() bool {
+	return p.Type == PiiTypeSessionToken || p.Type == PiiTypeSecret
+}
+
+// Annotations set on the entities of per-occurrence results (see PerOccurrence)
+const (
+	AnnotationSpanStart = "span.start" // byte offset of the occurrence in the text
+	AnnotationSpanEnd   = "span.end"   // byte offset just after the occurrence
+	AnnotationSpanLine  = "span.line"  // 1-based line number of the occurrence
+)
+
+// PerOccurrence returns a copy of the result with one entity per occurrence of its entities
+// in text (see PiiEntity.OccurrencesIn), sorted by offset, instead of one entity per distinct
+// value: entities located by the extractor give one entity per match, others one per
+// occurrence of their value. Each entity has a count of 1, its location, the span
+// annotations and, when context is not nil, the context it returns for its span. Stats and
+// Total count occurrences. Entities without occurrence in text, such as masked secrets
+// without locations, are kept as they are, after the occurrences.
+func (r *PiiExtractionResult) PerOccurrence(text string, context func(start, end int) string) *PiiExtractionResult {
+	var occurrences []Occurrence
+	var unlocated []PiiEntity
+	for _, entity := range r.Entities {
+		found := entity.OccurrencesIn(text)
+		if len(found) == 0 {
+			unlocated = append(unlocated, entity)
+		}
+		occurrences = append(occurrences, found...)
+	}
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		if occurrences[i].Start != occurrences[j].Start {
+			return occurrences[i].Start < occurrences[j].Start
+		}
+		return occurrences[i].End > occurrences[j].End
+	})
+
+	result := *r
+	result.Entities = make([]PiiEntity, 0, len(occurrences)+len(unlocated))
+	result.Stats = make(map[PiiType]int)
+	locator := NewLocator(text)
+	line, lineOffset := 1, 0
+	for _, occurrence := range occurrences {
+		line += strings.Count(text[lineOffset:occurrence.Start], "\n")
+		lineOffset = occurrence.Start
+
+		entity := occurrence.Entity
+		if value, ok := withBase(entity.Value, func(base BasePii) BasePii {
+			single := BasePii{Value: base.Value, Count: 1, Locations: []Location{locator.Locate(occurrence.Start, occurrence.End)}, display: base.display}
+			if context != nil {
+				single.Contexts = []string{context(occurrence.Start, occurrence.End)}
+			}
+			return single
+		}); ok {
+			entity.Value = value
+		}
+		entity.Annotations = maps.Clone(entity.Annotations)
+		entity.Annotate(AnnotationSpanStart, occurrence.Start)
+		entity.Annotate(AnnotationSpanEnd, occurrence.End)
+		entity.Annotate(AnnotationSpanLine, line)
+
+		result.Entities = append(result.Entities, entity)
+		result.Stats[entity.Type]++
+	}
+	for _, entity := range unlocated {
+		result.Entities = append(result.Entities, entity)
+		result.Stats[entity.Type]++
+	}
+	result.Total = len(result.Entities)
+	return &result
+}
+
+// withBase returns a copy of a value with its BasePii replaced by base(BasePii), or false
+// for values of unknown types
+func withBase(value Pii, base func(BasePii) BasePii) (Pii, bool) {
+	switch v := value.(type) {
+	case Phone:
+		v.BasePii = base(v.BasePii)
+		return v, true
+	case Email:
+		v.BasePii = base(v.BasePii)
+		return v, true
+	case SSN:
+		v.BasePii = base(v.BasePii)
+		return v, true
+	case ZipCode:
+		v.BasePii = base(v.BasePii)
+		return v, true
+	case PoBox:
+		v.BasePii = base(v.BasePii)
+		return v, true
+	case StreetAddress:
+		v.BasePii = base(v.BasePii)
+		return v, true
+	case CreditCard:
+		v.BasePii = base(v.BasePii)
+		return v, true
+	case IPAddress:
+		v.BasePii = base(v.BasePii)
+		return v, true
+	case BtcAddress:
+		v.BasePii = base(v.BasePii)
+		return v, true
+	case IBAN:
+		v.BasePii = base(v.BasePii)
+		return v, true
+	case Hostname:
+		v.BasePii = base(v.BasePii)
+		return v, true
+	case SessionToken:
+		v.BasePii = base(v.BasePii)
+		return v, true
+	case TrackData:
+		v.BasePii = base(v.BasePii)
+		return v, true
+	case Secret:
+		v.BasePii = base(v.BasePii)
+		return v, true
+	case Hash:
+		v.BasePii = base(v.BasePii)
+		return v, true
+	case NationalID:
+		v.BasePii = base(v.BasePii)
+		return v, true
+	case PersonName:
+		v.BasePii = base(v.BasePii)
+		return v, true
+	case Scrubbed:
+		v.BasePii = base(v.BasePii)
+		return v, true
+	}
+	return nil, false
+}
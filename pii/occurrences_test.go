@@ -0,0 +1,78 @@
+package pii
+
+import "testing"
+
+func TestOccurrences(t *testing.T) {
+	result := NewPiiExtractionResult([]PiiEntity{
+		{Type: PiiTypeZipCode, Value: NewZipCode("10", CountryUS)},
+		{Type: PiiTypeStreetAddress, Value: NewStreetAddress("10 Main Street", CountryUS)},
+		{Type: PiiTypeEmail, Value: NewEmail("a@b.com")},
+	})
+
+	occurrences := result.Occurrences("a@b.com lives at 10 Main Street, write a@b.com")
+	if len(occurrences) != 3 {
+		t.Fatalf("Expected 3 occurrences, got %+v", occurrences)
+	}
+	if occurrences[1].Entity.Type != PiiTypeStreetAddress || occurrences[1].Start != 17 || occurrences[1].End != 31 {
+		t.Errorf("Expected the longest overlapping occurrence to win, got %+v", occurrences[1])
+	}
+	if occurrences[2].Start != 39 || occurrences[2].Entity.Type != PiiTypeEmail {
+		t.Errorf("Expected every occurrence of a repeated value, got %+v", occurrences[2])
+	}
+
+	var empty *PiiExtractionResult
+	if len(empty.Occurrences("text")) != 0 {
+		t.Error("Expected no occurrences for a nil result")
+	}
+}
+
+func TestPerOccurrence(t *testing.T) {
+	email := NewEmail("a@b.com")
+	email.Count = 2
+	result := NewPiiExtractionResult([]PiiEntity{
+		{Type: PiiTypeEmail, Value: email},
+		{Type: PiiTypeSecret, Value: NewSecret("sk_live_0123456789", 4.2, "key")},
+	})
+
+	text := "write a@b.com\nor\na@b.com"
+	perOccurrence := result.PerOccurrence(text, func(start, end int) string { return text[start:end] + "!" })
+	if perOccurrence.Total != 3 || perOccurrence.Stats[PiiTypeEmail] != 2 {
+		t.Fatalf("Expected 2 email occurrences and the secret, got %d (%v)", perOccurrence.Total, perOccurrence.Stats)
+	}
+
+	second := perOccurrence.Entities[1]
+	if second.GetCount() != 1 || len(second.GetContexts()) != 1 || second.GetContexts()[0] != "a@b.com!" {
+		t.Errorf("Expected a single occurrence with its own context, got %+v", second.Value)
+	}
+	if second.Annotations[AnnotationSpanStart] != 17 || second.Annotations[AnnotationSpanEnd] != 24 || second.Annotations[AnnotationSpanLine] != 3 {
+		t.Errorf("Unexpected span annotations %v", second.Annotations)
+	}
+	if perOccurrence.Entities[0].Annotations[AnnotationSpanLine] != 1 {
+		t.Errorf("Expected the first occurrence on line 1, got %v", perOccurrence.Entities[0].Annotations)
+	}
+
+	if secret := perOccurrence.Entities[2]; secret.Type != PiiTypeSecret || secret.Annotations != nil {
+		t.Errorf("Expected the masked secret kept without a span, got %+v", secret)
+	}
+	if result.Total != 2 || result.Entities[0].Annotations != nil {
+		t.Error("Expected the original result to be left unchanged")
+	}
+}
+
+func TestPerOccurrence_Locations(t *testing.T) {
+	text := "SSN 123-45-6789, order A123-45-6789B, key sk_live_0123456789"
+	ssn := NewSSN("123-45-6789")
+	ssn.Locations = []Location{NewLocator(text).Locate(4, 15)}
+	secret := NewSecret("sk_live_0123456789", 4.2, "key")
+	secret.Locations = []Location{NewLocator(text).Locate(42, 60)}
+	result := NewPiiExtractionResult([]PiiEntity{{Type: PiiTypeSSN, Value: ssn}, {Type: PiiTypeSecret, Value: secret}})
+
+	// Located entities give one entity per location, without searching the value
+	perOccurrence := result.PerOccurrence(text, nil)
+	if perOccurrence.Total != 2 || perOccurrence.Stats[PiiTypeSSN] != 1 {
+		t.Fatalf("Expected one entity per location, got %d (%v)", perOccurrence.Total, perOccurrence.Stats)
+	}
+	if secret := perOccurrence.Entities[1]; secret.Annotations[AnnotationSpanStart] != 42 || secret.Annotations[AnnotationSpanEnd] != 60 {
+		t.Errorf("Expected the masked secret at its location, got %+v", secret)
+	}
+}
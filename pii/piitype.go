@@ -0,0 +1,74 @@
+package pii
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PiiTypes returns all PII types, in declaration order
+func PiiTypes() []PiiType {
+	var types []PiiType
+	for piiType := PiiTypePhone; piiType.String() != "unknown"; piiType++ {
+		types = append(types, piiType)
+	}
+	return types
+}
+
+// ParsePiiType parses a type name such as "email" or "credit_card", ignoring case, or the
+// integer encoding of stored results that predate named types. It returns false for
+// anything else.
+func ParsePiiType(s string) (PiiType, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for _, piiType := range PiiTypes() {
+		if piiType.String() == s {
+			return piiType, true
+		}
+	}
+	if n, err := strconv.Atoi(s); err == nil && n >= 0 && PiiType(n).String() != "unknown" {
+		return PiiType(n), true
+	}
+	return 0, false
+}
+
+// MarshalText implements encoding.TextMarshaler, encoding the type as its name. JSON
+// object keys, such as the keys of Stats, use it as well.
+func (p PiiType) MarshalText() ([]byte, error) {
+	if p.String() == "unknown" {
+		return nil, fmt.Errorf("unknown PII type %d", int(p))
+	}
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting names and integers
+func (p *PiiType) UnmarshalText(text []byte) error {
+	piiType, ok := ParsePiiType(string(text))
+	if !ok {
+		return fmt.Errorf("unknown PII type %q", text)
+	}
+	*p = piiType
+	return nil
+}
+
+// MarshalJSON encodes the type as a JSON string of its name
+func (p PiiType) MarshalJSON() ([]byte, error) {
+	text, err := p.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON decodes a type name, or the integer written by earlier versions
+func (p *PiiType) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		return p.UnmarshalText([]byte(name))
+	}
+	var n int
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("PII type: expected a name or an integer, got %s", data)
+	}
+	return p.UnmarshalText([]byte(strconv.Itoa(n)))
+}
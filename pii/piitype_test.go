@@ -0,0 +1,67 @@
+package pii
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPiiType_JSONRoundTrip(t *testing.T) {
+	for _, piiType := range PiiTypes() {
+		data, err := json.Marshal(piiType)
+		if err != nil {
+			t.Fatalf("Marshal(%s) error = %v", piiType, err)
+		}
+		if string(data) != `"`+piiType.String()+`"` {
+			t.Errorf("Expected %s encoded by name, got %s", piiType, data)
+		}
+		var decoded PiiType
+		if err := json.Unmarshal(data, &decoded); err != nil || decoded != piiType {
+			t.Errorf("Unmarshal(%s) = %v, %v", data, decoded, err)
+		}
+	}
+}
+
+func TestPiiType_UnmarshalLegacyIntegers(t *testing.T) {
+	var entity struct {
+		Type  PiiType         `json:"type"`
+		Stats map[PiiType]int `json:"stats"`
+	}
+	if err := json.Unmarshal([]byte(`{"type": 6, "stats": {"1": 2, "credit_card": 1}}`), &entity); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if entity.Type != PiiTypeCreditCard {
+		t.Errorf("Expected the integer 6 decoded as credit_card, got %s", entity.Type)
+	}
+	if entity.Stats[PiiTypeEmail] != 2 || entity.Stats[PiiTypeCreditCard] != 1 {
+		t.Errorf("Expected integer and named keys decoded, got %v", entity.Stats)
+	}
+
+	for _, invalid := range []string{`"passport"`, `99`, `-1`, `true`} {
+		var piiType PiiType
+		if err := json.Unmarshal([]byte(invalid), &piiType); err == nil {
+			t.Errorf("Expected an error decoding %s", invalid)
+		}
+	}
+}
+
+func TestPiiExtractionResult_JSONTypeNames(t *testing.T) {
+	result := NewPiiExtractionResult([]PiiEntity{{Type: PiiTypeEmail, Value: NewEmail("john@example.com")}})
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var decoded map[string]any
+	json.Unmarshal(data, &decoded)
+	if stats, _ := decoded["stats"].(map[string]any); stats["email"] != 1.0 {
+		t.Errorf("Expected stats keyed by type name, got %v", decoded["stats"])
+	}
+}
+
+func TestParsePiiType(t *testing.T) {
+	if piiType, ok := ParsePiiType(" Street_Address "); !ok || piiType != PiiTypeStreetAddress {
+		t.Errorf("ParsePiiType() = %v, %v", piiType, ok)
+	}
+	if _, ok := ParsePiiType("unknown"); ok {
+		t.Error("Expected unknown to be rejected")
+	}
+}
@@ -0,0 +1,84 @@
+package pii
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+)
+
+// NoiseConfig configures the Laplace mechanism applied by Aggregate.WithNoise
+type NoiseConfig struct {
+	// Epsilon is the total privacy budget of the release, split evenly across every
+	// released count. Smaller values add more noise.
+	Epsilon float64 `json:"epsilon"`
+
+	// MaxContribution bounds how many occurrences (and distinct values) of one type a
+	// single document contributes. Documents exceeding it weaken the guarantee. Zero means 1.
+	MaxContribution int `json:"max_contribution,omitempty"`
+
+	// Types lists the types always released, with noisy counts even when absent, so
+	// that the presence of a rare type is not revealed. Empty releases observed types only.
+	Types []PiiType `json:"types,omitempty"`
+
+	// Rand is the randomness source; nil uses a randomly seeded generator
+	Rand *rand.Rand `json:"-"`
+}
+
+// WithNoise returns a copy of the aggregate with Laplace noise added to every count, for
+// sharing prevalence statistics without exposing exact counts of rare identifiers. Noisy
+// counts are rounded and clamped at zero. Top values are dropped, since releasing the
+// values themselves is not differentially private.
+func (a *Aggregate) WithNoise(config NoiseConfig) (*Aggregate, error) {
+	if config.Epsilon <= 0 || math.IsInf(config.Epsilon, 0) || math.IsNaN(config.Epsilon) {
+		return nil, errors.New("noise epsilon must be a positive finite number")
+	}
+	contribution := float64(max(config.MaxContribution, 1))
+	random := config.Rand
+	if random == nil {
+		random = rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	}
+
+	types := make(map[PiiType]*TypeAggregate, len(a.Types))
+	for piiType, stats := range a.Types {
+		types[piiType] = stats
+	}
+	for _, piiType := range config.Types {
+		if _, exists := types[piiType]; !exists {
+			types[piiType] = &TypeAggregate{Type: piiType}
+		}
+	}
+
+	// Sequential composition: the document count plus three counts per type
+	releases := float64(1 + 3*len(types))
+	noise := func(count float64, sensitivity float64) float64 {
+		return max(0, math.Round(count+laplace(random, sensitivity*releases/config.Epsilon)))
+	}
+
+	noisy := &Aggregate{
+		Documents: int(noise(float64(a.Documents), 1)),
+		Types:     make(map[PiiType]*TypeAggregate, len(types)),
+	}
+	for piiType, stats := range types {
+		noisy.Types[piiType] = &TypeAggregate{
+			Type:             piiType,
+			Occurrences:      int(noise(float64(stats.Occurrences), contribution)),
+			Documents:        int(noise(float64(stats.Documents), 1)),
+			DistinctEstimate: uint64(noise(float64(stats.DistinctEstimate), contribution)),
+			TopValues:        nil,
+		}
+	}
+	return noisy, nil
+}
+
+// laplace samples the Laplace distribution centered on zero with the given scale
+func laplace(random *rand.Rand, scale float64) float64 {
+	u := random.Float64() - 0.5
+	if u == -0.5 {
+		return 0
+	}
+	sign := 1.0
+	if u < 0 {
+		sign = -1
+	}
+	return -scale * sign * math.Log(1-2*math.Abs(u))
+}
@@ -0,0 +1,60 @@
+package pii
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestAggregateWithNoise(t *testing.T) {
+	aggregator := NewAggregator(5)
+	for i := 0; i < 1000; i++ {
+		aggregator.Add([]PiiEntity{{Type: PiiTypeEmail, Value: NewEmail("john@example.com")}})
+	}
+	aggregate := aggregator.Snapshot()
+
+	noisy, err := aggregate.WithNoise(NoiseConfig{
+		Epsilon: 1,
+		Types:   []PiiType{PiiTypeEmail, PiiTypeSSN},
+		Rand:    rand.New(rand.NewPCG(1, 2)),
+	})
+	if err != nil {
+		t.Fatalf("WithNoise() error = %v", err)
+	}
+
+	emails := noisy.Types[PiiTypeEmail]
+	if emails == nil || emails.TopValues != nil {
+		t.Fatalf("Expected email counts without top values, got %+v", emails)
+	}
+	// 7 released counts at epsilon 1: Laplace scale 7, so the error is well below 100
+	if math.Abs(float64(emails.Occurrences-1000)) > 100 || math.Abs(float64(noisy.Documents-1000)) > 100 {
+		t.Errorf("Noisy counts too far from the truth: %+v, documents %d", emails, noisy.Documents)
+	}
+	if _, ok := noisy.Types[PiiTypeSSN]; !ok {
+		t.Error("Expected configured types to be released even when absent")
+	}
+	if ssn := noisy.Types[PiiTypeSSN]; ssn.Occurrences < 0 || ssn.Documents < 0 {
+		t.Errorf("Expected counts clamped at zero, got %+v", ssn)
+	}
+	if aggregate.Types[PiiTypeEmail].Occurrences != 1000 || len(aggregate.Types[PiiTypeEmail].TopValues) == 0 {
+		t.Error("Expected the original aggregate to be left untouched")
+	}
+
+	if _, err := aggregate.WithNoise(NoiseConfig{}); err == nil {
+		t.Error("Expected an error without epsilon")
+	}
+}
+
+func TestLaplaceScale(t *testing.T) {
+	random := rand.New(rand.NewPCG(3, 4))
+	const samples, scale = 20000, 2.0
+
+	var sumAbs float64
+	for i := 0; i < samples; i++ {
+		sumAbs += math.Abs(laplace(random, scale))
+	}
+	// The mean absolute deviation of Laplace(b) is b
+	if mean := sumAbs / samples; math.Abs(mean-scale) > 0.1 {
+		t.Errorf("Expected mean absolute noise %.1f, got %.3f", scale, mean)
+	}
+}
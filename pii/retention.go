@@ -0,0 +1,107 @@
+package pii
+
+// RetentionAction is the handling a retention hint asks downstream systems to apply
+type RetentionAction string
+
+const (
+	RetentionDoNotStore     RetentionAction = "do_not_store"     // Must not be persisted at all
+	RetentionRestrict       RetentionAction = "restrict"         // Store only masked, truncated or encrypted
+	RetentionEraseOnRequest RetentionAction = "erase_on_request" // Must be erasable on data subject request
+	RetentionExpire         RetentionAction = "expire"           // Delete once older than MaxAgeDays
+)
+
+// RetentionHint is retention guidance attached to an entity by a RetentionPolicy
+type RetentionHint struct {
+	Regulation string          `json:"regulation"` // e.g. "PCI DSS", "GDPR"
+	Action     RetentionAction `json:"action"`
+	MaxAgeDays int             `json:"max_age_days,omitempty"` // Maximum retention age, 0 when unbounded
+	Note       string          `json:"note,omitempty"`
+}
+
+// RetentionPolicy maps PII types to the retention hints attached to their entities
+type RetentionPolicy map[PiiType][]RetentionHint
+
+// gdprErase is the hint attached to personal data under the default policy
+var gdprErase = RetentionHint{
+	Regulation: "GDPR",
+	Action:     RetentionEraseOnRequest,
+	Note:       "Personal data: erase on request (Art. 17)",
+}
+
+// DefaultRetentionPolicy returns a baseline policy covering PCI DSS cardholder data,
+// credentials and GDPR personal data. It is guidance, not legal advice; adapt it to the
+// regulations that apply to your data.
+func DefaultRetentionPolicy() RetentionPolicy {
+	return RetentionPolicy{
+		PiiTypeTrackData: {{
+			Regulation: "PCI DSS",
+			Action:     RetentionDoNotStore,
+			Note:       "Full track data must not be stored after authorization (Req. 3.3.1)",
+		}},
+		PiiTypeCreditCard: {{
+			Regulation: "PCI DSS",
+			Action:     RetentionRestrict,
+			Note:       "Render the PAN unreadable wherever it is stored (Req. 3.5)",
+		}},
+		PiiTypeSessionToken: {{
+			Regulation: "Security",
+			Action:     RetentionDoNotStore,
+			Note:       "Session tokens must not be kept in logs; invalidate exposed sessions",
+		}},
+		PiiTypeSecret: {{
+			Regulation: "Security",
+			Action:     RetentionDoNotStore,
+			Note:       "Credentials must not be kept in logs; rotate exposed secrets",
+		}},
+		PiiTypeHash: {{
+			Regulation: "GDPR",
+			Action:     RetentionRestrict,
+			Note:       "Unsalted hashes of personal data are pseudonymous, not anonymous: use a keyed hash",
+		}},
+		PiiTypeSSN: {
+			{Regulation: "GLBA", Action: RetentionRestrict, Note: "Store only masked or encrypted"},
+			gdprErase,
+		},
+		PiiTypeNationalID:    {{Regulation: "GDPR", Action: RetentionRestrict, Note: "National identification number (Art. 87): store only masked or encrypted"}, gdprErase},
+		PiiTypeIBAN:          {{Regulation: "GDPR", Action: RetentionRestrict, Note: "Financial data: restrict access"}, gdprErase},
+		PiiTypeEmail:         {gdprErase},
+		PiiTypePhone:         {gdprErase},
+		PiiTypeStreetAddress: {gdprErase},
+		PiiTypePoBox:         {gdprErase},
+		PiiTypeZipCode:       {gdprErase},
+		PiiTypeIPAddress:     {gdprErase},
+		PiiTypeBtcAddress:    {gdprErase},
+		PiiTypePersonName:    {gdprErase},
+	}
+}
+
+// ApplyRetentionPolicy attaches the policy hints to every entity of the result, replacing
+// any hints attached previously. Entities of types missing from the policy get no hints.
+func (r *PiiExtractionResult) ApplyRetentionPolicy(policy RetentionPolicy) {
+	for i := range r.Entities {
+		hints := policy[r.Entities[i].Type]
+		if len(hints) == 0 {
+			r.Entities[i].Retention = nil
+			continue
+		}
+		r.Entities[i].Retention = append([]RetentionHint(nil), hints...)
+	}
+}
+
+// MostRestrictive returns the strictest action among the hints, ordered do_not_store,
+// restrict, expire, erase_on_request, or an empty action when there are no hints
+func MostRestrictive(hints []RetentionHint) RetentionAction {
+	rank := map[RetentionAction]int{
+		RetentionDoNotStore:     4,
+		RetentionRestrict:       3,
+		RetentionExpire:         2,
+		RetentionEraseOnRequest: 1,
+	}
+	var strictest RetentionAction
+	for _, hint := range hints {
+		if rank[hint.Action] > rank[strictest] {
+			strictest = hint.Action
+		}
+	}
+	return strictest
+}
@@ -0,0 +1,56 @@
+package pii
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestApplyRetentionPolicy(t *testing.T) {
+	result := NewPiiExtractionResult([]PiiEntity{
+		{Type: PiiTypeTrackData, Value: NewTrackData(";4111111111111111=2512101?", 2)},
+		{Type: PiiTypeEmail, Value: NewEmail("john@acme.com")},
+		{Type: PiiTypeHostname, Value: NewHostname("db01.corp.internal", "internal", true)},
+	})
+
+	result.ApplyRetentionPolicy(DefaultRetentionPolicy())
+
+	for _, entity := range result.Entities {
+		switch entity.Type {
+		case PiiTypeTrackData:
+			if MostRestrictive(entity.Retention) != RetentionDoNotStore {
+				t.Errorf("Expected track data to be do_not_store, got %+v", entity.Retention)
+			}
+		case PiiTypeEmail:
+			if len(entity.Retention) != 1 || entity.Retention[0].Action != RetentionEraseOnRequest {
+				t.Errorf("Expected GDPR erase hint on email, got %+v", entity.Retention)
+			}
+		case PiiTypeHostname:
+			if entity.Retention != nil {
+				t.Errorf("Expected no hint for a type missing from the policy, got %+v", entity.Retention)
+			}
+		}
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if !strings.Contains(string(data), `"action":"do_not_store"`) {
+		t.Errorf("Expected retention hints in JSON, got %s", data)
+	}
+}
+
+func TestApplyRetentionPolicy_Custom(t *testing.T) {
+	result := NewPiiExtractionResult([]PiiEntity{{Type: PiiTypePhone, Value: NewPhoneUS("555-123-4567")}})
+	policy := RetentionPolicy{
+		PiiTypePhone: {{Regulation: "Internal", Action: RetentionExpire, MaxAgeDays: 30}},
+	}
+
+	result.ApplyRetentionPolicy(policy)
+	policy[PiiTypePhone][0].MaxAgeDays = 1
+
+	if hints := result.Entities[0].Retention; len(hints) != 1 || hints[0].MaxAgeDays != 30 {
+		t.Errorf("Expected hints copied from the policy, got %+v", hints)
+	}
+}
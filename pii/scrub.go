@@ -0,0 +1,126 @@
+package pii
+
+import (
+	"errors"
+	"maps"
+)
+
+// ScrubLevel sets how much of a result Scrub removes
+type ScrubLevel int
+
+const (
+	// ScrubContexts removes the contexts and validation reasoning, which quote the
+	// surrounding text, and keeps the values
+	ScrubContexts ScrubLevel = iota
+	// ScrubValues also replaces every value with its entity ID, keeping its type, count,
+	// severity and country, and hashes the values of skipped validations and suppressed
+	// candidates
+	ScrubValues
+	// ScrubAll also removes the annotations, which enrichment passes may fill with
+	// personal data such as directory matches
+	ScrubAll
+)
+
+// Scrubbed is the value of an entity scrubbed with ScrubValues or ScrubAll. Value holds
+// the entity ID instead of the raw value.
+type Scrubbed struct {
+	BasePii
+	Severity Severity `json:"severity"`
+	Country  Country  `json:"country,omitempty"`
+}
+
+// ErrNoSalt is returned by Scrub when values are to be replaced without a salt
+var ErrNoSalt = errors.New("pii: scrubbing values requires an entity ID salt")
+
+// Scrub returns a copy of the result without the PII itself, so that findings can be
+// stored long-term in ticketing systems. The result is left untouched. Entity IDs are
+// computed with salt, when given. From ScrubValues on, values are replaced by these IDs
+// and a secret salt is required (ErrNoSalt): unsalted hashes of SSNs or phone numbers
+// are reversed by hashing every candidate.
+func (r *PiiExtractionResult) Scrub(level ScrubLevel, salt string) (*PiiExtractionResult, error) {
+	if level >= ScrubValues && salt == "" {
+		return nil, ErrNoSalt
+	}
+
+	scrubbed := &PiiExtractionResult{
+		Entities:          make([]PiiEntity, len(r.Entities)),
+		Stats:             maps.Clone(r.Stats),
+		Total:             r.Total,
+		PatternSetVersion: r.PatternSetVersion,
+	}
+	for i, entity := range r.Entities {
+		scrubbed.Entities[i] = scrubEntity(entity, level, salt)
+	}
+
+	if r.ValidationStats != nil {
+		stats := *r.ValidationStats
+		if level >= ScrubValues {
+			stats.Skipped = make([]SkippedValidation, len(r.ValidationStats.Skipped))
+			for i, skipped := range r.ValidationStats.Skipped {
+				skipped.Value = EntityID(skipped.Type, skipped.Value, salt)
+				stats.Skipped[i] = skipped
+			}
+		}
+		scrubbed.ValidationStats = &stats
+	}
+
+	if len(r.Suppressed) > 0 {
+		scrubbed.Suppressed = make([]SuppressedCandidate, len(r.Suppressed))
+		for i, candidate := range r.Suppressed {
+			if level >= ScrubValues {
+				candidate.Value = EntityID(candidate.Type, candidate.Value, salt)
+			}
+			scrubbed.Suppressed[i] = candidate
+		}
+	}
+	return scrubbed, nil
+}
+
+// IsScrubbed returns true if the entity value was replaced by Scrub
+func (p PiiEntity) IsScrubbed() bool {
+	_, ok := p.Value.(Scrubbed)
+	return ok
+}
+
+// scrubEntity returns a scrubbed copy of an entity, with its ID computed with salt when
+// given
+func scrubEntity(entity PiiEntity, level ScrubLevel, salt string) PiiEntity {
+	if salt != "" || entity.ID == "" {
+		entity.ID = EntityID(entity.Type, entity.GetValue(), salt)
+	}
+	if entity.Validation != nil {
+		validation := *entity.Validation
+		validation.Reasoning = ""
+		entity.Validation = &validation
+	}
+	if level >= ScrubAll {
+		entity.Annotations = nil
+	} else {
+		entity.Annotations = maps.Clone(entity.Annotations)
+	}
+
+	if level < ScrubValues && entity.Value != nil {
+		// Values of unknown types cannot be copied without their contexts, and are hashed
+		if value, ok := withoutContexts(entity.Value); ok {
+			entity.Value = value
+			return entity
+		}
+	}
+	entity.Value = Scrubbed{
+		BasePii:  BasePii{Value: entity.ID, Count: entity.GetCount(), Locations: entity.GetLocations()},
+		Severity: entity.GetSeverity(),
+		Country:  EntityCountry(entity),
+	}
+	return entity
+}
+
+// withoutContexts returns a copy of a value without its contexts, or false for values of
+// unknown types
+func withoutContexts(value Pii) (Pii, bool) {
+	return withBase(value, BasePii.withoutContexts)
+}
+
+// withoutContexts returns the value, count and locations of p
+func (p BasePii) withoutContexts() BasePii {
+	return BasePii{Value: p.Value, Count: p.Count, Locations: p.Locations}
+}
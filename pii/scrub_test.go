@@ -0,0 +1,95 @@
+package pii
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func scrubFixture() *PiiExtractionResult {
+	email := NewEmail("john@acme.com")
+	email.Internal = true
+	email.AddContext("write to john@acme.com today")
+	iban := NewIBAN("DE89370400440532013000", CountryDE)
+	iban.AddContext("pay DE89370400440532013000")
+
+	result := NewPiiExtractionResult([]PiiEntity{
+		{Type: PiiTypeEmail, Value: email, Validation: &ValidationResult{Valid: true, Confidence: 0.9, Reasoning: "john@acme.com looks real"}},
+		{Type: PiiTypeIBAN, Value: iban, Annotations: map[string]any{"directory.owner": "John Smith"}},
+	})
+	result.ValidationStats = &ValidationStats{Skipped: []SkippedValidation{{Type: PiiTypePhone, Value: "555-0100", Reason: "budget"}}}
+	result.Suppressed = []SuppressedCandidate{{Type: PiiTypeIPAddress, Value: "999.1.1.1", Reason: "false_positive_filter"}}
+	return result
+}
+
+func TestPiiExtractionResult_Scrub(t *testing.T) {
+	result := scrubFixture()
+
+	contexts, err := result.Scrub(ScrubContexts, "")
+	if err != nil {
+		t.Fatalf("Scrub(ScrubContexts) error = %v", err)
+	}
+	for _, entity := range contexts.Entities {
+		if len(entity.GetContexts()) != 0 || entity.IsScrubbed() {
+			t.Errorf("Expected %s to keep its value without contexts", entity.GetValue())
+		}
+		if entity.Validation != nil && entity.Validation.Reasoning != "" {
+			t.Error("Expected the validation reasoning to be removed")
+		}
+	}
+
+	values, err := result.Scrub(ScrubValues, "tenant-secret")
+	if err != nil {
+		t.Fatalf("Scrub(ScrubValues) error = %v", err)
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	for _, raw := range []string{"john@acme.com", "DE89370400440532013000", "555-0100", "999.1.1.1"} {
+		if strings.Contains(string(data), raw) {
+			t.Errorf("Expected %q to be scrubbed from %s", raw, data)
+		}
+	}
+	if !strings.Contains(string(data), "John Smith") {
+		t.Error("Expected ScrubValues to keep annotations")
+	}
+	for i, entity := range values.Entities {
+		original := result.Entities[i]
+		id := EntityID(original.Type, original.GetValue(), "tenant-secret")
+		if !entity.IsScrubbed() || entity.GetValue() != id || entity.ID != id {
+			t.Errorf("Expected the value to be replaced by the salted ID, got %+v", entity)
+		}
+		if entity.GetSeverity() != original.GetSeverity() || entity.GetCount() != original.GetCount() || EntityCountry(entity) != EntityCountry(original) {
+			t.Errorf("Expected severity, count and country to be kept for %s", original.Type)
+		}
+	}
+	if values.Total != 2 || values.Stats[PiiTypeIBAN] != 1 {
+		t.Errorf("Expected stats to be kept, got %+v", values.Stats)
+	}
+
+	all, err := result.Scrub(ScrubAll, "tenant-secret")
+	if err != nil {
+		t.Fatalf("Scrub(ScrubAll) error = %v", err)
+	}
+	for _, entity := range all.Entities {
+		if entity.Annotations != nil {
+			t.Error("Expected ScrubAll to remove annotations")
+		}
+	}
+
+	// The source result is left untouched
+	if len(result.Entities[0].GetContexts()) == 0 || result.ValidationStats.Skipped[0].Value != "555-0100" || result.Suppressed[0].Value != "999.1.1.1" {
+		t.Error("Expected Scrub not to modify the result")
+	}
+}
+
+func TestPiiExtractionResult_ScrubRequiresSalt(t *testing.T) {
+	result := scrubFixture()
+	for _, level := range []ScrubLevel{ScrubValues, ScrubAll} {
+		if scrubbed, err := result.Scrub(level, ""); !errors.Is(err, ErrNoSalt) || scrubbed != nil {
+			t.Errorf("Scrub(%d) without a salt = %v, %v; want ErrNoSalt", level, scrubbed, err)
+		}
+	}
+}
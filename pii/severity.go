@@ -0,0 +1,59 @@
+package pii
+
+// Severity represents how sensitive a PII finding is
+type Severity int
+
+const (
+	SeverityLow Severity = iota
+	SeverityMedium
+	SeverityHigh
+	SeverityCritical
+)
+
+// String returns the string representation of the severity
+func (s Severity) String() string {
+	switch s {
+	case SeverityLow:
+		return "low"
+	case SeverityMedium:
+		return "medium"
+	case SeverityHigh:
+		return "high"
+	case SeverityCritical:
+		return "critical"
+	default:
+		return "unknown"
+	}
+}
+
+// DefaultSeverity returns the default severity for a PII type
+func DefaultSeverity(piiType PiiType) Severity {
+	switch piiType {
+	case PiiTypeSSN, PiiTypeNationalID, PiiTypeCreditCard, PiiTypeTrackData, PiiTypeSecret:
+		return SeverityCritical
+	case PiiTypeIBAN, PiiTypeBtcAddress, PiiTypeStreetAddress, PiiTypeSessionToken:
+		return SeverityHigh
+	case PiiTypePhone, PiiTypeEmail, PiiTypePoBox, PiiTypeIPAddress, PiiTypeHostname, PiiTypePersonName:
+		return SeverityMedium
+	case PiiTypeZipCode, PiiTypeHash:
+		return SeverityLow
+	default:
+		return SeverityMedium
+	}
+}
+
+// GetSeverity returns the severity of the PII entity. Internal email addresses are
+// downgraded to SeverityLow, hashes of known values are at least SeverityHigh and
+// scrubbed entities keep their original severity.
+func (p PiiEntity) GetSeverity() Severity {
+	if scrubbed, ok := p.Value.(Scrubbed); ok {
+		return scrubbed.Severity
+	}
+	if email, ok := p.AsEmail(); ok && email.Internal {
+		return SeverityLow
+	}
+	if hash, ok := p.AsHash(); ok && hash.Known != nil {
+		return max(DefaultSeverity(*hash.Known), SeverityHigh)
+	}
+	return DefaultSeverity(p.Type)
+}
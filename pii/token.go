@@ -0,0 +1,33 @@
+package pii
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// SessionTokenPrefixLength is the number of leading characters of a session token kept
+// readable by MaskSessionToken
+const SessionTokenPrefixLength = 4
+
+// MaskSessionToken masks all but the first SessionTokenPrefixLength characters of a token
+// and appends a short SHA-256 fingerprint, e.g. "eyJh***#9f86d081". The fingerprint keeps
+// distinct tokens sharing a prefix apart without revealing them.
+func MaskSessionToken(token string) string {
+	prefix := token
+	if len(prefix) > SessionTokenPrefixLength {
+		prefix = prefix[:SessionTokenPrefixLength]
+	}
+	sum := sha256.Sum256([]byte(token))
+	return prefix + "***#" + hex.EncodeToString(sum[:4])
+}
+
+// MaskSessionTokens replaces every raw token occurring in text with its masked form
+func MaskSessionTokens(text string, tokens []string) string {
+	for _, token := range tokens {
+		if token != "" {
+			text = strings.ReplaceAll(text, token, MaskSessionToken(token))
+		}
+	}
+	return text
+}
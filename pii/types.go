@@ -14,6 +14,13 @@ const (
 	PiiTypeIPAddress
 	PiiTypeBtcAddress
 	PiiTypeIBAN
+	PiiTypeHostname
+	PiiTypeSessionToken
+	PiiTypeTrackData
+	PiiTypeSecret
+	PiiTypeHash
+	PiiTypeNationalID
+	PiiTypePersonName
 )
 
 // String returns the string representation of the PII type
@@ -39,6 +46,20 @@ func (p PiiType) String() string {
 		return "btc_address"
 	case PiiTypeIBAN:
 		return "iban"
+	case PiiTypeHostname:
+		return "hostname"
+	case PiiTypeSessionToken:
+		return "session_token"
+	case PiiTypeTrackData:
+		return "track_data"
+	case PiiTypeSecret:
+		return "secret"
+	case PiiTypeHash:
+		return "hash"
+	case PiiTypeNationalID:
+		return "national_id"
+	case PiiTypePersonName:
+		return "person_name"
 	default:
 		return "unknown"
 	}
@@ -61,6 +82,38 @@ type ValidationStats struct {
 	AverageConfidence float64 `json:"average_confidence"`
 	Provider          string  `json:"provider,omitempty"`
 	Model             string  `json:"model,omitempty"`
+
+	SkippedCount int                 `json:"skipped_count,omitempty"` // Entities not validated because the budget ran out
+	Skipped      []SkippedValidation `json:"skipped,omitempty"`
+
+	ByType map[PiiType]*TypeValidationStats `json:"by_type,omitempty"` // Breakdown of the counts per PII type
+}
+
+// TypeValidationStats contains the validation statistics of a single PII type
+type TypeValidationStats struct {
+	TotalValidated    int     `json:"total_validated"`
+	ValidCount        int     `json:"valid_count"`
+	InvalidCount      int     `json:"invalid_count"`
+	AverageConfidence float64 `json:"average_confidence"`
+}
+
+// SkippedValidation describes an entity that was not validated because of a budget limit
+type SkippedValidation struct {
+	Type   PiiType `json:"type"`
+	Value  string  `json:"value"`
+	Reason string  `json:"reason"`
+}
+
+// SuppressedCandidate describes a candidate that matched a pattern but was dropped by a
+// false-positive filter, so that audits can check the filters are not hiding real findings
+type SuppressedCandidate struct {
+	Type    PiiType `json:"type"`
+	Country Country `json:"country,omitempty"`
+	Value   string  `json:"value"`
+	Start   int     `json:"start"`
+	End     int     `json:"end"`
+	Reason  string  `json:"reason"`
+	Detail  string  `json:"detail,omitempty"`
 }
 
 // Pii interface that all PII value objects must implement
@@ -73,14 +126,20 @@ type Pii interface {
 
 // BasePii provides common functionality for all PII types
 type BasePii struct {
-	Value    string   `json:"value"`
-	Contexts []string `json:"contexts"`
-	Count    int      `json:"count"`
+	Value     string     `json:"value"`
+	Contexts  []string   `json:"contexts"`
+	Count     int        `json:"count"`
+	Locations []Location `json:"locations,omitempty"` // every occurrence, when recorded by the extractor
+
+	contextsSeen int           // distinct contexts offered to AddContext, used for reservoir sampling
+	index        *contextIndex // hash set for duplicate detection on large context lists
+	display      DisplayMode   // how String prints the value
 }
 
-// String returns the string representation of the PII
+// String returns the value as printed under its display mode, masked by default (see
+// PiiEntity.WithDisplayMode). Use GetValue for the value as found.
 func (p BasePii) String() string {
-	return p.Value
+	return DisplayValue(p.Value, p.display)
 }
 
 // GetValue returns the string value
@@ -105,12 +164,7 @@ func (p *BasePii) IncrementCount() {
 
 // AddContext adds a new context if it doesn't already exist
 func (p *BasePii) AddContext(context string) {
-	for _, existingContext := range p.Contexts {
-		if existingContext == context {
-			return
-		}
-	}
-	p.Contexts = append(p.Contexts, context)
+	p.AddContextLimit(context, 0)
 }
 
 // PII value objects
@@ -118,36 +172,45 @@ func (p *BasePii) AddContext(context string) {
 // Phone represents a phone number
 type Phone struct {
 	BasePii
-	Country string `json:"country,omitempty"`
+	Country Country `json:"country,omitempty"`
 }
 
 // Email represents an email address
 type Email struct {
 	BasePii
+	Internal bool `json:"internal,omitempty"` // Address on a configured corporate/internal domain
 }
 
 // SSN represents a Social Security Number
 type SSN struct {
 	BasePii
-	Country string `json:"country,omitempty"`
+	Country Country `json:"country,omitempty"`
 }
 
 // ZipCode represents a ZIP/postal code
 type ZipCode struct {
 	BasePii
-	Country string `json:"country,omitempty"`
+	Country Country `json:"country,omitempty"`
 }
 
 // StreetAddress represents a street address
 type StreetAddress struct {
 	BasePii
-	Country string `json:"country,omitempty"`
+	Country Country `json:"country,omitempty"`
+
+	// Components parsed from the matched address, empty when not identified. City and
+	// PostalCode are read from the text following the address.
+	HouseNumber string `json:"house_number,omitempty"`
+	StreetName  string `json:"street_name,omitempty"`
+	StreetType  string `json:"street_type,omitempty"`
+	City        string `json:"city,omitempty"`
+	PostalCode  string `json:"postal_code,omitempty"`
 }
 
 // PoBox represents a P.O. Box
 type PoBox struct {
 	BasePii
-	Country string `json:"country,omitempty"`
+	Country Country `json:"country,omitempty"`
 }
 
 // CreditCard represents a credit card number
@@ -159,7 +222,15 @@ type CreditCard struct {
 // IPAddress represents an IP address
 type IPAddress struct {
 	BasePii
-	Version string `json:"version,omitempty"` // ipv4, ipv6
+	Version      string `json:"version,omitempty"`       // ipv4, ipv6
+	Zone         string `json:"zone,omitempty"`          // IPv6 zone ID (e.g. eth0 in fe80::1%eth0)
+	EmbeddedIPv4 string `json:"embedded_ipv4,omitempty"` // IPv4 address embedded in an IPv6 address
+
+	// Set by IP enrichment (see the ipgeo package)
+	Public       bool    `json:"public,omitempty"`       // Globally routable, not private, loopback or link-local
+	Country      Country `json:"country,omitempty"`      // Country the address is located in
+	ASN          uint32  `json:"asn,omitempty"`          // Autonomous system number
+	Organization string  `json:"organization,omitempty"` // Organization of the autonomous system
 }
 
 // BtcAddress represents a Bitcoin address
@@ -170,7 +241,65 @@ type BtcAddress struct {
 // IBAN represents an International Bank Account Number
 type IBAN struct {
 	BasePii
-	Country string `json:"country,omitempty"`
+	Country Country `json:"country,omitempty"`
+}
+
+// Hostname represents a hostname or fully qualified domain name
+type Hostname struct {
+	BasePii
+	Suffix   string `json:"suffix,omitempty"`   // Public suffix (e.g. co.uk) or internal suffix (e.g. internal)
+	Internal bool   `json:"internal,omitempty"` // Hostname under a private-use suffix such as .internal or .local
+}
+
+// SessionToken represents a session cookie, bearer token or session ID. The value is masked
+// (see MaskSessionToken) so the raw token is never stored.
+type SessionToken struct {
+	BasePii
+	Source string `json:"source,omitempty"` // set_cookie, cookie, bearer or query
+	Name   string `json:"name,omitempty"`   // Cookie or query parameter name
+	Length int    `json:"length"`           // Length of the raw token
+}
+
+// TrackData represents magnetic stripe track data, which PCI DSS prohibits storing
+type TrackData struct {
+	BasePii
+	Track int `json:"track"` // 1 or 2
+}
+
+// Secret represents a high-entropy credential such as an API key or generated password.
+// The value is masked like a session token so the raw secret is never stored.
+type Secret struct {
+	BasePii
+	Entropy float64 `json:"entropy"`           // Shannon entropy in bits per character
+	Length  int     `json:"length"`            // Length of the raw secret
+	Keyword string  `json:"keyword,omitempty"` // Context keyword found before the secret
+}
+
+// Hash represents a hex digest such as MD5, SHA-1 or SHA-256, or a bcrypt hash, found where
+// a value may have been pseudonymized. Known is set when the digest matched a known PII
+// value, making the hash reversible.
+type Hash struct {
+	BasePii
+	Algorithm string   `json:"algorithm"`       // md5, sha1, sha256 or bcrypt
+	Known     *PiiType `json:"known,omitempty"` // Type of the known value the digest matched
+}
+
+// NationalID represents a national identity number other than a US SSN, such as a Saudi
+// national ID or a UAE Emirates ID
+type NationalID struct {
+	BasePii
+	Country Country `json:"country,omitempty"`
+	Scheme  string  `json:"scheme"` // Identifier scheme, such as "sa_national_id" or "ae_emirates_id"
+}
+
+// PersonName represents a person's name matched against lists of first and last names.
+// Confidence reflects how common the names are and the context they were found in.
+type PersonName struct {
+	BasePii
+	First      string  `json:"first,omitempty"`  // First name as found, empty for a last name alone
+	Last       string  `json:"last,omitempty"`   // Last name as found, empty for a first name alone
+	Locale     string  `json:"locale,omitempty"` // ISO 639-1 code of the name lists that matched, such as "fr"
+	Confidence float64 `json:"confidence"`       // Between 0 and 1
 }
 
 // Constructor functions for PII types
@@ -187,6 +316,8 @@ func NewEmail(value string) Email {
 }
 
 // NewPhoneUS creates a new US Phone PII value
+//
+// Deprecated: use NewPhone(value, CountryUS).
 func NewPhoneUS(value string) Phone {
 	return Phone{
 		BasePii: BasePii{
@@ -194,12 +325,12 @@ func NewPhoneUS(value string) Phone {
 			Contexts: []string{},
 			Count:    1,
 		},
-		Country: "US",
+		Country: CountryUS,
 	}
 }
 
 // NewPhone creates a new Phone PII value with specified country
-func NewPhone(value, country string) Phone {
+func NewPhone(value string, country Country) Phone {
 	return Phone{
 		BasePii: BasePii{
 			Value:    value,
@@ -218,12 +349,12 @@ func NewSSN(value string) SSN {
 			Contexts: []string{},
 			Count:    1,
 		},
-		Country: "US",
+		Country: CountryUS,
 	}
 }
 
 // NewZipCode creates a new ZipCode PII value
-func NewZipCode(value, country string) ZipCode {
+func NewZipCode(value string, country Country) ZipCode {
 	return ZipCode{
 		BasePii: BasePii{
 			Value:    value,
@@ -235,7 +366,7 @@ func NewZipCode(value, country string) ZipCode {
 }
 
 // NewStreetAddress creates a new StreetAddress PII value
-func NewStreetAddress(value, country string) StreetAddress {
+func NewStreetAddress(value string, country Country) StreetAddress {
 	return StreetAddress{
 		BasePii: BasePii{
 			Value:    value,
@@ -247,7 +378,7 @@ func NewStreetAddress(value, country string) StreetAddress {
 }
 
 // NewPoBox creates a new PoBox PII value
-func NewPoBox(value, country string) PoBox {
+func NewPoBox(value string, country Country) PoBox {
 	return PoBox{
 		BasePii: BasePii{
 			Value:    value,
@@ -294,7 +425,7 @@ func NewBtcAddress(value string) BtcAddress {
 }
 
 // NewIBAN creates a new IBAN PII value
-func NewIBAN(value, country string) IBAN {
+func NewIBAN(value string, country Country) IBAN {
 	return IBAN{
 		BasePii: BasePii{
 			Value:    value,
@@ -305,11 +436,106 @@ func NewIBAN(value, country string) IBAN {
 	}
 }
 
+// NewHostname creates a new Hostname PII value
+func NewHostname(value, suffix string, internal bool) Hostname {
+	return Hostname{
+		BasePii: BasePii{
+			Value:    value,
+			Contexts: []string{},
+			Count:    1,
+		},
+		Suffix:   suffix,
+		Internal: internal,
+	}
+}
+
+// NewSessionToken creates a new SessionToken PII value, masking the raw token
+func NewSessionToken(token, source, name string) SessionToken {
+	return SessionToken{
+		BasePii: BasePii{
+			Value:    MaskSessionToken(token),
+			Contexts: []string{},
+			Count:    1,
+		},
+		Source: source,
+		Name:   name,
+		Length: len(token),
+	}
+}
+
+// NewTrackData creates a new TrackData PII value
+func NewTrackData(value string, track int) TrackData {
+	return TrackData{
+		BasePii: BasePii{
+			Value:    value,
+			Contexts: []string{},
+			Count:    1,
+		},
+		Track: track,
+	}
+}
+
+// NewSecret creates a new Secret PII value, masking the raw secret
+func NewSecret(secret string, entropy float64, keyword string) Secret {
+	return Secret{
+		BasePii: BasePii{
+			Value:    MaskSessionToken(secret),
+			Contexts: []string{},
+			Count:    1,
+		},
+		Entropy: entropy,
+		Length:  len(secret),
+		Keyword: keyword,
+	}
+}
+
+// NewHash creates a new Hash PII value
+func NewHash(value, algorithm string) Hash {
+	return Hash{
+		BasePii: BasePii{
+			Value:    value,
+			Contexts: []string{},
+			Count:    1,
+		},
+		Algorithm: algorithm,
+	}
+}
+
+// NewNationalID creates a new NationalID PII value
+func NewNationalID(value string, country Country, scheme string) NationalID {
+	return NationalID{
+		BasePii: BasePii{
+			Value:    value,
+			Contexts: []string{},
+			Count:    1,
+		},
+		Country: country,
+		Scheme:  scheme,
+	}
+}
+
+// NewPersonName creates a new PersonName PII value
+func NewPersonName(value, locale string, confidence float64) PersonName {
+	return PersonName{
+		BasePii: BasePii{
+			Value:    value,
+			Contexts: []string{},
+			Count:    1,
+		},
+		Locale:     locale,
+		Confidence: confidence,
+	}
+}
+
 // PiiEntity represents a single PII item found in text
 type PiiEntity struct {
+	ID         string            `json:"id,omitempty"`         // Deterministic ID of the finding (see EntityID)
 	Type       PiiType           `json:"type"`                 // The type of PII (phone, email, ssn, etc.)
 	Value      Pii               `json:"value"`                // The actual PII value object
 	Validation *ValidationResult `json:"validation,omitempty"` // Optional LLM validation result
+	Retention  []RetentionHint   `json:"retention,omitempty"`  // Optional retention guidance (see RetentionPolicy)
+
+	Annotations map[string]any `json:"annotations,omitempty"` // Data attached by enrichment passes (see Annotate)
 }
 
 // GetTypedValue performs a safe type assertion for the PII value
@@ -321,7 +547,8 @@ func GetTypedValue[T Pii](entity PiiEntity) (T, bool) {
 	return zero, false
 }
 
-// String returns a string representation of the PII entity
+// String returns a string representation of the PII entity, masked by default (see
+// WithDisplayMode)
 func (p PiiEntity) String() string {
 	if p.Value != nil {
 		return p.Value.String()
@@ -395,6 +622,41 @@ func (p PiiEntity) AsIBAN() (IBAN, bool) {
 	return GetTypedValue[IBAN](p)
 }
 
+// AsSessionToken attempts to cast the value to a SessionToken
+func (p PiiEntity) AsSessionToken() (SessionToken, bool) {
+	return GetTypedValue[SessionToken](p)
+}
+
+// AsTrackData attempts to cast the value to a TrackData
+func (p PiiEntity) AsTrackData() (TrackData, bool) {
+	return GetTypedValue[TrackData](p)
+}
+
+// AsSecret attempts to cast the value to a Secret
+func (p PiiEntity) AsSecret() (Secret, bool) {
+	return GetTypedValue[Secret](p)
+}
+
+// AsHash attempts to cast the value to a Hash
+func (p PiiEntity) AsHash() (Hash, bool) {
+	return GetTypedValue[Hash](p)
+}
+
+// AsNationalID attempts to cast the value to a NationalID
+func (p PiiEntity) AsNationalID() (NationalID, bool) {
+	return GetTypedValue[NationalID](p)
+}
+
+// AsPersonName attempts to cast the value to a PersonName
+func (p PiiEntity) AsPersonName() (PersonName, bool) {
+	return GetTypedValue[PersonName](p)
+}
+
+// AsHostname attempts to cast the value to a Hostname
+func (p PiiEntity) AsHostname() (Hostname, bool) {
+	return GetTypedValue[Hostname](p)
+}
+
 // AsCreditCard attempts to cast the value to a CreditCard
 func (p PiiEntity) AsCreditCard() (CreditCard, bool) {
 	return GetTypedValue[CreditCard](p)
@@ -457,6 +719,41 @@ func (p PiiEntity) IsIBAN() bool {
 	return p.Type == PiiTypeIBAN
 }
 
+// IsSessionToken returns true if the entity is a session token
+func (p PiiEntity) IsSessionToken() bool {
+	return p.Type == PiiTypeSessionToken
+}
+
+// IsTrackData returns true if the entity is magnetic stripe track data
+func (p PiiEntity) IsTrackData() bool {
+	return p.Type == PiiTypeTrackData
+}
+
+// IsSecret returns true if the entity is a secret
+func (p PiiEntity) IsSecret() bool {
+	return p.Type == PiiTypeSecret
+}
+
+// IsHash returns true if the entity is a hash
+func (p PiiEntity) IsHash() bool {
+	return p.Type == PiiTypeHash
+}
+
+// IsNationalID returns true if the entity is a national identity number
+func (p PiiEntity) IsNationalID() bool {
+	return p.Type == PiiTypeNationalID
+}
+
+// IsPersonName returns true if the entity is a person name
+func (p PiiEntity) IsPersonName() bool {
+	return p.Type == PiiTypePersonName
+}
+
+// IsHostname returns true if the entity is a hostname
+func (p PiiEntity) IsHostname() bool {
+	return p.Type == PiiTypeHostname
+}
+
 // IsValidated returns true if the entity has been validated by an LLM
 func (p PiiEntity) IsValidated() bool {
 	return p.Validation != nil
@@ -481,6 +778,10 @@ type PiiExtractionResult struct {
 	Stats           map[PiiType]int  `json:"stats"`
 	Total           int              `json:"total"`
 	ValidationStats *ValidationStats `json:"validation_stats,omitempty"` // Optional validation statistics
+
+	PatternSetVersion string `json:"pattern_set_version,omitempty"` // Detection rules that produced the result, if pattern-based
+
+	Suppressed []SuppressedCandidate `json:"suppressed,omitempty"` // Candidates dropped by false-positive filters, when recorded
 }
 
 // NewPiiExtractionResult creates a new PiiExtractionResult from entities with deduplication
@@ -493,11 +794,13 @@ func NewPiiExtractionResult(entities []PiiEntity) *PiiExtractionResult {
 		stats[entity.Type]++
 	}
 
-	return &PiiExtractionResult{
+	result := &PiiExtractionResult{
 		Entities: dedupedEntities,
 		Stats:    stats,
 		Total:    len(dedupedEntities),
 	}
+	result.assignMissingIDs()
+	return result
 }
 
 // GetEntitiesByType returns all entities of a specific type
@@ -511,6 +814,27 @@ func (r *PiiExtractionResult) GetEntitiesByType(piiType PiiType) []PiiEntity {
 	return result
 }
 
+// GroupByType returns the entities of each type, in result order, in a single pass
+func (r *PiiExtractionResult) GroupByType() map[PiiType][]PiiEntity {
+	groups := make(map[PiiType][]PiiEntity, len(r.Stats))
+	for _, entity := range r.Entities {
+		groups[entity.Type] = append(groups[entity.Type], entity)
+	}
+	return groups
+}
+
+// GroupByCountry returns the entities of each canonical country (see EntityCountry), in
+// result order, in a single pass. Entities without a country, such as emails, are grouped
+// under the empty country. Every country is covered, including those of community packs.
+func (r *PiiExtractionResult) GroupByCountry() map[Country][]PiiEntity {
+	groups := make(map[Country][]PiiEntity)
+	for _, entity := range r.Entities {
+		country := EntityCountry(entity)
+		groups[country] = append(groups[country], entity)
+	}
+	return groups
+}
+
 // IsEmpty returns true if no PII entities were found
 func (r *PiiExtractionResult) IsEmpty() bool {
 	return r.Total == 0
@@ -604,13 +928,48 @@ func (r *PiiExtractionResult) GetIBANs() []PiiEntity {
 	return r.GetEntitiesByType(PiiTypeIBAN)
 }
 
+// GetSessionTokens returns all session token entities
+func (r *PiiExtractionResult) GetSessionTokens() []PiiEntity {
+	return r.GetEntitiesByType(PiiTypeSessionToken)
+}
+
+// GetTrackData returns all magnetic stripe track data entities
+func (r *PiiExtractionResult) GetTrackData() []PiiEntity {
+	return r.GetEntitiesByType(PiiTypeTrackData)
+}
+
+// GetSecrets returns all secret entities
+func (r *PiiExtractionResult) GetSecrets() []PiiEntity {
+	return r.GetEntitiesByType(PiiTypeSecret)
+}
+
+// GetHashes returns all hash entities
+func (r *PiiExtractionResult) GetHashes() []PiiEntity {
+	return r.GetEntitiesByType(PiiTypeHash)
+}
+
+// GetNationalIDs returns all national identity number entities
+func (r *PiiExtractionResult) GetNationalIDs() []PiiEntity {
+	return r.GetEntitiesByType(PiiTypeNationalID)
+}
+
+// GetPersonNames returns all person name entities
+func (r *PiiExtractionResult) GetPersonNames() []PiiEntity {
+	return r.GetEntitiesByType(PiiTypePersonName)
+}
+
+// GetHostnames returns all hostname entities
+func (r *PiiExtractionResult) GetHostnames() []PiiEntity {
+	return r.GetEntitiesByType(PiiTypeHostname)
+}
+
 // International extraction convenience methods
 
 // GetZipCodesByCountry returns all ZIP/postal code entities for a specific country
-func (r *PiiExtractionResult) GetZipCodesByCountry(country string) []PiiEntity {
+func (r *PiiExtractionResult) GetZipCodesByCountry(country Country) []PiiEntity {
 	var result []PiiEntity
 	for _, entity := range r.GetZipCodes() {
-		if zipCode, ok := entity.AsZipCode(); ok && zipCode.Country == country {
+		if zipCode, ok := entity.AsZipCode(); ok && zipCode.Country.Canonical() == country.Canonical() {
 			result = append(result, entity)
 		}
 	}
@@ -618,10 +977,10 @@ func (r *PiiExtractionResult) GetZipCodesByCountry(country string) []PiiEntity {
 }
 
 // GetStreetAddressesByCountry returns all street address entities for a specific country
-func (r *PiiExtractionResult) GetStreetAddressesByCountry(country string) []PiiEntity {
+func (r *PiiExtractionResult) GetStreetAddressesByCountry(country Country) []PiiEntity {
 	var result []PiiEntity
 	for _, entity := range r.GetStreetAddresses() {
-		if address, ok := entity.AsStreetAddress(); ok && address.Country == country {
+		if address, ok := entity.AsStreetAddress(); ok && address.Country.Canonical() == country.Canonical() {
 			result = append(result, entity)
 		}
 	}
@@ -629,10 +988,10 @@ func (r *PiiExtractionResult) GetStreetAddressesByCountry(country string) []PiiE
 }
 
 // GetPhonesByCountry returns all phone entities for a specific country
-func (r *PiiExtractionResult) GetPhonesByCountry(country string) []PiiEntity {
+func (r *PiiExtractionResult) GetPhonesByCountry(country Country) []PiiEntity {
 	var result []PiiEntity
 	for _, entity := range r.GetPhones() {
-		if phone, ok := entity.AsPhone(); ok && phone.Country == country {
+		if phone, ok := entity.AsPhone(); ok && phone.Country.Canonical() == country.Canonical() {
 			result = append(result, entity)
 		}
 	}
@@ -642,48 +1001,99 @@ func (r *PiiExtractionResult) GetPhonesByCountry(country string) []PiiEntity {
 // Convenience methods for specific countries
 
 // GetUKEntities returns all UK-specific PII entities (postal codes and addresses)
+//
+// Deprecated: use GroupByCountry()[CountryGB], which covers every type carrying the
+// country.
 func (r *PiiExtractionResult) GetUKEntities() []PiiEntity {
 	var result []PiiEntity
-	result = append(result, r.GetZipCodesByCountry("UK")...)
-	result = append(result, r.GetStreetAddressesByCountry("UK")...)
+	result = append(result, r.GetZipCodesByCountry(CountryGB)...)
+	result = append(result, r.GetStreetAddressesByCountry(CountryGB)...)
 	return result
 }
 
 // GetFranceEntities returns all France-specific PII entities (postal codes and addresses)
+//
+// Deprecated: use GroupByCountry()[CountryFR], which covers every type carrying the
+// country.
 func (r *PiiExtractionResult) GetFranceEntities() []PiiEntity {
 	var result []PiiEntity
-	result = append(result, r.GetZipCodesByCountry("France")...)
-	result = append(result, r.GetStreetAddressesByCountry("France")...)
+	result = append(result, r.GetZipCodesByCountry(CountryFR)...)
+	result = append(result, r.GetStreetAddressesByCountry(CountryFR)...)
 	return result
 }
 
 // GetSpainEntities returns all Spain-specific PII entities (postal codes and addresses)
+//
+// Deprecated: use GroupByCountry()[CountryES], which covers every type carrying the
+// country.
 func (r *PiiExtractionResult) GetSpainEntities() []PiiEntity {
 	var result []PiiEntity
-	result = append(result, r.GetZipCodesByCountry("Spain")...)
-	result = append(result, r.GetStreetAddressesByCountry("Spain")...)
+	result = append(result, r.GetZipCodesByCountry(CountryES)...)
+	result = append(result, r.GetStreetAddressesByCountry(CountryES)...)
 	return result
 }
 
 // GetItalyEntities returns all Italy-specific PII entities (postal codes and addresses)
+//
+// Deprecated: use GroupByCountry()[CountryIT], which covers every type carrying the
+// country.
 func (r *PiiExtractionResult) GetItalyEntities() []PiiEntity {
 	var result []PiiEntity
-	result = append(result, r.GetZipCodesByCountry("Italy")...)
-	result = append(result, r.GetStreetAddressesByCountry("Italy")...)
+	result = append(result, r.GetZipCodesByCountry(CountryIT)...)
+	result = append(result, r.GetStreetAddressesByCountry(CountryIT)...)
 	return result
 }
 
 // GetUSEntities returns all US-specific PII entities (phones, SSNs, ZIP codes, addresses, P.O. boxes)
+//
+// Deprecated: use GroupByCountry()[CountryUS], which covers every type carrying the
+// country.
 func (r *PiiExtractionResult) GetUSEntities() []PiiEntity {
 	var result []PiiEntity
-	result = append(result, r.GetPhonesByCountry("US")...)
-	result = append(result, r.GetZipCodesByCountry("US")...)
-	result = append(result, r.GetStreetAddressesByCountry("US")...)
+	result = append(result, r.GetPhonesByCountry(CountryUS)...)
+	result = append(result, r.GetZipCodesByCountry(CountryUS)...)
+	result = append(result, r.GetStreetAddressesByCountry(CountryUS)...)
 	result = append(result, r.GetSSNs()...)    // SSNs are US-specific
 	result = append(result, r.GetPoBoxes()...) // P.O. boxes are currently US-specific
 	return result
 }
 
+// GetEntitiesByCountry returns all entities whose value carries the given country
+func (r *PiiExtractionResult) GetEntitiesByCountry(country Country) []PiiEntity {
+	var result []PiiEntity
+	for _, entity := range r.Entities {
+		if entityCountry := EntityCountry(entity); entityCountry != "" && entityCountry == country.Canonical() {
+			result = append(result, entity)
+		}
+	}
+	return result
+}
+
+// EntityCountry returns the canonical country of an entity, or empty for international types
+func EntityCountry(entity PiiEntity) Country {
+	switch v := entity.Value.(type) {
+	case Phone:
+		return v.Country.Canonical()
+	case SSN:
+		return v.Country.Canonical()
+	case NationalID:
+		return v.Country.Canonical()
+	case ZipCode:
+		return v.Country.Canonical()
+	case StreetAddress:
+		return v.Country.Canonical()
+	case PoBox:
+		return v.Country.Canonical()
+	case IBAN:
+		return v.Country.Canonical()
+	case IPAddress:
+		return v.Country.Canonical()
+	case Scrubbed:
+		return v.Country
+	}
+	return ""
+}
+
 // deduplicateEntities removes duplicate entities and merges their contexts
 func deduplicateEntities(entities []PiiEntity) []PiiEntity {
 	entityMap := make(map[string]*PiiEntity)
@@ -692,8 +1102,7 @@ func deduplicateEntities(entities []PiiEntity) []PiiEntity {
 		key := generateEntityKey(entity)
 		
 		if existing, exists := entityMap[key]; exists {
-			// Merge contexts and update count
-			mergeEntityContexts(existing, &entity)
+			mergeEntity(existing, &entity)
 		} else {
 			// Create a copy to avoid modifying the original
 			entityCopy := entity
@@ -710,6 +1119,17 @@ func deduplicateEntities(entities []PiiEntity) []PiiEntity {
 	return result
 }
 
+// mergeEntity merges a duplicate entity into an existing one, merging contexts and
+// annotations and updating the count
+func mergeEntity(existing, entity *PiiEntity) {
+	mergeEntityContexts(existing, entity)
+	mergeLocations(existing, entity)
+	mergeAnnotations(existing, entity)
+	if existing.Validation == nil {
+		existing.Validation = entity.Validation
+	}
+}
+
 // generateEntityKey creates a unique key for an entity based on type and value
 func generateEntityKey(entity PiiEntity) string {
 	return entity.Type.String() + ":" + entity.GetValue()
@@ -835,5 +1255,68 @@ func mergeEntityContexts(target, source *PiiEntity) {
 			tv.BasePii.Count += sv.BasePii.Count
 			target.Value = tv
 		}
+	case Hostname:
+		if sv, ok := sourceValue.(Hostname); ok {
+			for _, context := range sourceContexts {
+				tv.BasePii.AddContext(context)
+			}
+			tv.BasePii.Count += sv.BasePii.Count
+			target.Value = tv
+		}
+	case SessionToken:
+		if sv, ok := sourceValue.(SessionToken); ok {
+			for _, context := range sourceContexts {
+				tv.BasePii.AddContext(context)
+			}
+			tv.BasePii.Count += sv.BasePii.Count
+			target.Value = tv
+		}
+	case TrackData:
+		if sv, ok := sourceValue.(TrackData); ok {
+			for _, context := range sourceContexts {
+				tv.BasePii.AddContext(context)
+			}
+			tv.BasePii.Count += sv.BasePii.Count
+			target.Value = tv
+		}
+	case Secret:
+		if sv, ok := sourceValue.(Secret); ok {
+			for _, context := range sourceContexts {
+				tv.BasePii.AddContext(context)
+			}
+			tv.BasePii.Count += sv.BasePii.Count
+			target.Value = tv
+		}
+	case Hash:
+		if sv, ok := sourceValue.(Hash); ok {
+			for _, context := range sourceContexts {
+				tv.BasePii.AddContext(context)
+			}
+			tv.BasePii.Count += sv.BasePii.Count
+			if tv.Known == nil {
+				tv.Known = sv.Known
+			}
+			target.Value = tv
+		}
+	case NationalID:
+		if sv, ok := sourceValue.(NationalID); ok {
+			for _, context := range sourceContexts {
+				tv.BasePii.AddContext(context)
+			}
+			tv.BasePii.Count += sv.BasePii.Count
+			target.Value = tv
+		}
+	case PersonName:
+		if sv, ok := sourceValue.(PersonName); ok {
+			for _, context := range sourceContexts {
+				tv.BasePii.AddContext(context)
+			}
+			tv.BasePii.Count += sv.BasePii.Count
+			tv.Confidence = max(tv.Confidence, sv.Confidence)
+			if tv.Locale == "" {
+				tv.Locale = sv.Locale
+			}
+			target.Value = tv
+		}
 	}
 }
\ No newline at end of file
@@ -0,0 +1,53 @@
+package pii
+
+// Count sets the validation counts and average confidences of the stats, in total and per
+// type, from the validated entities. Provider, model and skipped entities are left as is.
+func (s *ValidationStats) Count(entities []PiiEntity) {
+	s.TotalValidated, s.ValidCount, s.InvalidCount, s.AverageConfidence = 0, 0, 0, 0
+	s.ByType = nil
+
+	var totalConfidence float64
+	typeConfidence := make(map[PiiType]float64)
+	for _, entity := range entities {
+		if !entity.IsValidated() {
+			continue
+		}
+		if s.ByType == nil {
+			s.ByType = make(map[PiiType]*TypeValidationStats)
+		}
+		typeStats, ok := s.ByType[entity.Type]
+		if !ok {
+			typeStats = &TypeValidationStats{}
+			s.ByType[entity.Type] = typeStats
+		}
+
+		confidence := entity.GetValidationConfidence()
+		s.TotalValidated++
+		typeStats.TotalValidated++
+		totalConfidence += confidence
+		typeConfidence[entity.Type] += confidence
+		if entity.IsValid() {
+			s.ValidCount++
+			typeStats.ValidCount++
+		} else {
+			s.InvalidCount++
+			typeStats.InvalidCount++
+		}
+	}
+
+	if s.TotalValidated > 0 {
+		s.AverageConfidence = totalConfidence / float64(s.TotalValidated)
+	}
+	for piiType, typeStats := range s.ByType {
+		typeStats.AverageConfidence = typeConfidence[piiType] / float64(typeStats.TotalValidated)
+	}
+}
+
+// RejectionRate returns the fraction of validated entities of the type judged invalid, or 0
+// when none was validated
+func (s *TypeValidationStats) RejectionRate() float64 {
+	if s.TotalValidated == 0 {
+		return 0
+	}
+	return float64(s.InvalidCount) / float64(s.TotalValidated)
+}
@@ -0,0 +1,41 @@
+package pii
+
+import "testing"
+
+func TestValidationStats_CountByType(t *testing.T) {
+	entities := []PiiEntity{
+		{Type: PiiTypeEmail, Value: NewEmail("john@example.com"), Validation: &ValidationResult{Valid: true, Confidence: 0.9}},
+		{Type: PiiTypeEmail, Value: NewEmail("jane@example.com"), Validation: &ValidationResult{Valid: true, Confidence: 0.7}},
+		{Type: PiiTypePhone, Value: NewPhone("555-0100", CountryUS), Validation: &ValidationResult{Valid: false, Confidence: 0.6}},
+		{Type: PiiTypePhone, Value: NewPhone("555-0101", CountryUS), Validation: &ValidationResult{Valid: true, Confidence: 0.8}},
+		{Type: PiiTypeSSN, Value: NewSSN("123-45-6789")},
+	}
+
+	stats := &ValidationStats{Provider: "openai"}
+	stats.Count(entities)
+
+	if stats.TotalValidated != 4 || stats.ValidCount != 3 || stats.InvalidCount != 1 {
+		t.Fatalf("Unexpected totals: %+v", stats)
+	}
+	if len(stats.ByType) != 2 || stats.ByType[PiiTypeSSN] != nil {
+		t.Fatalf("Expected a breakdown of the validated types only, got %v", stats.ByType)
+	}
+
+	email := stats.ByType[PiiTypeEmail]
+	if email.TotalValidated != 2 || email.ValidCount != 2 || email.RejectionRate() != 0 {
+		t.Errorf("Unexpected email stats: %+v", email)
+	}
+	if diff := email.AverageConfidence - 0.8; diff > 1e-9 || diff < -1e-9 {
+		t.Errorf("Expected an average email confidence of 0.8, got %v", email.AverageConfidence)
+	}
+
+	phone := stats.ByType[PiiTypePhone]
+	if phone.InvalidCount != 1 || phone.RejectionRate() != 0.5 {
+		t.Errorf("Expected half of the phones rejected, got %+v", phone)
+	}
+
+	stats.Count(nil)
+	if stats.TotalValidated != 0 || stats.ByType != nil || stats.Provider != "openai" {
+		t.Errorf("Expected recounting to reset the counts only, got %+v", stats)
+	}
+}
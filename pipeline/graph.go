@@ -0,0 +1,85 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/intMeric/pii-extractor/pii"
+	"github.com/intMeric/pii-extractor/telemetry"
+)
+
+// node is a stage of the graph, run as soon as the stages it depends on are done
+type node struct {
+	name     string
+	after    []int         // indices of the nodes whose results it takes, all earlier in the graph
+	timeout  time.Duration // zero = none beyond the deadline of the run
+	required bool          // a failure fails the run instead of passing a nil result on
+	run      func(ctx context.Context, inputs []*pii.PiiExtractionResult) (*pii.PiiExtractionResult, error)
+}
+
+// runGraph runs every node in its own goroutine and returns their results, waiting for
+// all of them before returning. The first failure of a required node cancels the others
+// and is returned.
+func runGraph(ctx context.Context, nodes []node) ([]*pii.PiiExtractionResult, error) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	results := make([]*pii.PiiExtractionResult, len(nodes))
+	done := make([]chan struct{}, len(nodes))
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+
+	var wg sync.WaitGroup
+	for i, n := range nodes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(done[i])
+
+			inputs := make([]*pii.PiiExtractionResult, len(n.after))
+			for j, dependency := range n.after {
+				select {
+				case <-done[dependency]:
+					inputs[j] = results[dependency]
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			result, err := runNode(ctx, n, inputs)
+			if err != nil && n.required {
+				cancel(fmt.Errorf("stage %s: %w", n.name, err))
+				return
+			}
+			results[i] = result
+		}()
+	}
+	wg.Wait()
+
+	if err := context.Cause(ctx); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// runNode runs a node under its own deadline inside its trace span
+func runNode(ctx context.Context, n node, inputs []*pii.PiiExtractionResult) (*pii.PiiExtractionResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, telemetry.SpanPipelineStage, telemetry.String("pipeline.stage", n.name))
+	defer span.End()
+
+	if n.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, n.timeout)
+		defer cancel()
+	}
+	result, err := n.run(ctx, inputs)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	span.SetAttributes(telemetry.Int("pii.entities", result.Total))
+	return result, nil
+}
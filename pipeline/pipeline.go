@@ -0,0 +1,298 @@
+// Package pipeline composes regex extraction, LLM extraction, ensemble combination, rule
+// validation and LLM validation into a graph of stages run with structured concurrency:
+// extraction stages run in parallel, each stage runs under its own deadline within the
+// deadline of the run, a failing stage cancels the others, and no goroutine outlives the
+// run. Pipelines are declared with a Config, which can be loaded from JSON, instead of
+// wrapping a ValidatedExtractor around an EnsembleExtractor by hand.
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/intMeric/pii-extractor/extractors"
+	"github.com/intMeric/pii-extractor/extractors/hybrid"
+	"github.com/intMeric/pii-extractor/extractors/llm"
+	"github.com/intMeric/pii-extractor/extractors/regex"
+	"github.com/intMeric/pii-extractor/pii"
+	"github.com/intMeric/pii-extractor/telemetry"
+)
+
+// Names of the stages following the extraction stages, reported in errors and trace spans
+const (
+	StageCombine  = "combine"
+	StageRules    = "rules"
+	StageValidate = "validate"
+)
+
+// ErrNoExtraction is returned when every extraction stage of a run failed
+var ErrNoExtraction = errors.New("every extraction stage failed")
+
+// ExtractorStage declares an extraction stage
+type ExtractorStage struct {
+	Name     string                      `json:"name"`               // unique among the stages
+	Method   extractors.ExtractionMethod `json:"method,omitempty"`   // MethodRegex or MethodLLM, unless the extractor is given with WithExtractor
+	Provider llm.Provider                `json:"provider,omitempty"` // LLM provider of MethodLLM
+	Model    string                      `json:"model,omitempty"`    // LLM model of MethodLLM
+	Config   *extractors.ExtractorConfig `json:"config,omitempty"`
+	Timeout  time.Duration               `json:"timeout,omitempty"`  // zero = none beyond the run timeout
+	Required bool                        `json:"required,omitempty"` // a failure fails the run instead of being left out of the combination
+}
+
+// Config declares the stages of a pipeline. Extraction stages run in parallel, then their
+// results are combined, checked by the rules in order and validated by an LLM.
+type Config struct {
+	Extractors []ExtractorStage           `json:"extractors"`
+	Strategy   hybrid.CombinationStrategy `json:"strategy,omitempty"`   // StrategyUnion by default
+	Rules      []string                   `json:"rules,omitempty"`      // built-in rules or rules added with WithRule
+	Validation *hybrid.ValidationConfig   `json:"validation,omitempty"` // nil or disabled = no LLM validation; its Timeout bounds the stage
+	Timeout    time.Duration              `json:"timeout,omitempty"`    // deadline of a whole run, zero = none
+}
+
+// Option configures a Pipeline
+type Option func(*options)
+
+type options struct {
+	extractors map[string]extractors.PiiExtractor
+	rules      map[string]extractors.PostProcessor
+}
+
+// WithExtractor provides the extractor of the stage with the given name, such as a plugin
+// or an extractor configured in code, instead of building it from the stage method
+func WithExtractor(name string, extractor extractors.PiiExtractor) Option {
+	return func(o *options) {
+		o.extractors[name] = extractor
+	}
+}
+
+// WithRule adds a rule that Config.Rules can name, or replaces a built-in one
+func WithRule(name string, rule extractors.PostProcessor) Option {
+	return func(o *options) {
+		o.rules[name] = rule
+	}
+}
+
+// namedRule is a rule of the rules stage
+type namedRule struct {
+	name string
+	run  extractors.PostProcessor
+}
+
+// Pipeline runs the stages declared by a Config. It implements PiiExtractor and is safe
+// for concurrent use.
+type Pipeline struct {
+	name       string
+	config     Config
+	extractors []extractors.PiiExtractor // one per extraction stage
+	ensemble   *hybrid.EnsembleExtractor
+	rules      []namedRule
+	validator  *hybrid.ValidatedExtractor // nil without LLM validation
+}
+
+// New creates a pipeline from its declaration, building the extractors of the stages and
+// checking the strategy and rule names
+func New(config Config, opts ...Option) (*Pipeline, error) {
+	o := options{
+		extractors: make(map[string]extractors.PiiExtractor),
+		rules:      builtinRules(),
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if len(config.Extractors) == 0 {
+		return nil, errors.New("pipeline: no extraction stage")
+	}
+	p := &Pipeline{name: "pipeline", config: config}
+	var names []string
+	for _, stage := range config.Extractors {
+		if stage.Name == "" || slices.Contains(names, stage.Name) || slices.Contains([]string{StageCombine, StageRules, StageValidate}, stage.Name) {
+			return nil, fmt.Errorf("pipeline: extraction stages need unique names, got %q", stage.Name)
+		}
+		names = append(names, stage.Name)
+
+		extractor, err := stageExtractor(stage, o.extractors[stage.Name])
+		if err != nil {
+			return nil, fmt.Errorf("pipeline: stage %s: %w", stage.Name, err)
+		}
+		p.extractors = append(p.extractors, extractor)
+	}
+
+	strategy := config.Strategy
+	switch strategy {
+	case "":
+		strategy = hybrid.StrategyUnion
+	case hybrid.StrategyUnion, hybrid.StrategyIntersection, hybrid.StrategyMajority, hybrid.StrategyWeighted:
+	default:
+		return nil, fmt.Errorf("pipeline: unknown strategy %q", strategy)
+	}
+	p.ensemble = hybrid.NewEnsembleExtractor(p.extractors...).WithStrategy(strategy)
+
+	for _, name := range config.Rules {
+		rule, ok := o.rules[name]
+		if !ok {
+			return nil, fmt.Errorf("pipeline: unknown rule %q", name)
+		}
+		p.rules = append(p.rules, namedRule{name: name, run: rule})
+	}
+
+	if config.Validation != nil && config.Validation.Enabled {
+		validator, err := hybrid.NewValidatedExtractor(nil, config.Validation)
+		if err != nil {
+			return nil, fmt.Errorf("pipeline: %w", err)
+		}
+		p.validator = validator
+	}
+	return p, nil
+}
+
+// stageExtractor returns the extractor of a stage, building it from its method unless
+// one is given
+func stageExtractor(stage ExtractorStage, given extractors.PiiExtractor) (extractors.PiiExtractor, error) {
+	if given != nil {
+		return given, nil
+	}
+	switch stage.Method {
+	case extractors.MethodRegex:
+		return regex.NewExtractor(stage.Config), nil
+	case extractors.MethodLLM:
+		return llm.NewExtractor(stage.Provider, stage.Model, stage.Config)
+	default:
+		return nil, fmt.Errorf("unknown method %q", stage.Method)
+	}
+}
+
+// Extract runs the pipeline on the given text
+func (p *Pipeline) Extract(text string) (*pii.PiiExtractionResult, error) {
+	return p.ExtractContext(context.Background(), text)
+}
+
+// ExtractContext runs the pipeline on the given text under ctx and the configured
+// timeout. Failed extraction stages that are not required are left out of the
+// combination; any other failure, or the cancellation of ctx, returns an error and no
+// result. Extractors that do not take a context finish before the run returns.
+func (p *Pipeline) ExtractContext(ctx context.Context, text string) (*pii.PiiExtractionResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, telemetry.SpanPipelineRun, telemetry.Int("pipeline.extractors", len(p.extractors)))
+	defer span.End()
+
+	if p.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.config.Timeout)
+		defer cancel()
+	}
+
+	results, err := runGraph(ctx, p.graph(text))
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	result := results[len(results)-1]
+	span.SetAttributes(telemetry.Int("pii.entities", result.Total))
+	return result, nil
+}
+
+// graph returns the stages of a run on text: the extraction stages, then the combination,
+// the rules and the validation, each taking the result of the previous one
+func (p *Pipeline) graph(text string) []node {
+	var nodes []node
+	var extracted []int
+	for i, stage := range p.config.Extractors {
+		extractor := p.extractors[i]
+		extracted = append(extracted, len(nodes))
+		nodes = append(nodes, node{
+			name:     stage.Name,
+			timeout:  stage.Timeout,
+			required: stage.Required,
+			run: func(ctx context.Context, _ []*pii.PiiExtractionResult) (*pii.PiiExtractionResult, error) {
+				return extractors.ExtractWithContext(ctx, extractor, text)
+			},
+		})
+	}
+
+	nodes = append(nodes, node{
+		name:     StageCombine,
+		after:    extracted,
+		required: true,
+		run: func(ctx context.Context, inputs []*pii.PiiExtractionResult) (*pii.PiiExtractionResult, error) {
+			// Failed optional stages are left out, so they neither empty an intersection
+			// nor vote against the entities of a majority
+			extracted := slices.DeleteFunc(inputs, func(result *pii.PiiExtractionResult) bool { return result == nil })
+			if len(extracted) == 0 {
+				return nil, ErrNoExtraction
+			}
+			return p.ensemble.Combine(ctx, extracted), nil
+		},
+	})
+
+	if len(p.rules) > 0 {
+		nodes = append(nodes, node{
+			name:     StageRules,
+			after:    []int{len(nodes) - 1},
+			required: true,
+			run: func(ctx context.Context, inputs []*pii.PiiExtractionResult) (*pii.PiiExtractionResult, error) {
+				result := inputs[0]
+				for _, rule := range p.rules {
+					var err error
+					if result, err = rule.run(ctx, text, result); err != nil {
+						return nil, fmt.Errorf("rule %s: %w", rule.name, err)
+					}
+				}
+				return result, nil
+			},
+		})
+	}
+
+	if p.validator != nil {
+		nodes = append(nodes, node{
+			name:     StageValidate,
+			after:    []int{len(nodes) - 1},
+			required: true,
+			run: func(ctx context.Context, inputs []*pii.PiiExtractionResult) (*pii.PiiExtractionResult, error) {
+				return p.validator.ValidateResult(ctx, text, inputs[0]), nil
+			},
+		})
+	}
+	return nodes
+}
+
+// ExtractByType runs the pipeline and returns the entities of one type
+func (p *Pipeline) ExtractByType(text string, piiType pii.PiiType) ([]pii.PiiEntity, error) {
+	result, err := p.Extract(text)
+	if err != nil {
+		return nil, err
+	}
+	return result.GetEntitiesByType(piiType), nil
+}
+
+// GetSupportedTypes returns the types supported by any extraction stage
+func (p *Pipeline) GetSupportedTypes() []pii.PiiType {
+	return p.ensemble.GetSupportedTypes()
+}
+
+// Support returns the merged support of the extraction stages
+func (p *Pipeline) Support() []extractors.Support {
+	return extractors.SupportMatrix(p.extractors...)
+}
+
+// GetMethod returns the extraction method
+func (p *Pipeline) GetMethod() extractors.ExtractionMethod {
+	return extractors.MethodHybrid
+}
+
+// GetName returns the pipeline name
+func (p *Pipeline) GetName() string {
+	return p.name
+}
+
+// HealthCheck verifies the extraction stages that depend on an external service and the
+// LLM validator
+func (p *Pipeline) HealthCheck(ctx context.Context) error {
+	err := p.ensemble.HealthCheck(ctx)
+	if p.validator != nil {
+		err = errors.Join(err, p.validator.HealthCheck(ctx))
+	}
+	return err
+}
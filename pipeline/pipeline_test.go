@@ -0,0 +1,307 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/intMeric/pii-extractor/extractors"
+	"github.com/intMeric/pii-extractor/extractors/hybrid"
+	"github.com/intMeric/pii-extractor/extractors/regex"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// fakeExtractor returns the given entities after delay, or fails with err, stopping
+// early when its context is cancelled
+type fakeExtractor struct {
+	extractors.PiiExtractor
+	entities  []pii.PiiEntity
+	delay     time.Duration
+	err       error
+	cancelled *atomic.Bool
+}
+
+func (f fakeExtractor) ExtractContext(ctx context.Context, text string) (*pii.PiiExtractionResult, error) {
+	select {
+	case <-time.After(f.delay):
+	case <-ctx.Done():
+		if f.cancelled != nil {
+			f.cancelled.Store(true)
+		}
+		return nil, ctx.Err()
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+	return pii.NewPiiExtractionResult(f.entities), nil
+}
+
+func (f fakeExtractor) Extract(text string) (*pii.PiiExtractionResult, error) {
+	return f.ExtractContext(context.Background(), text)
+}
+
+func newFake(entities ...pii.PiiEntity) fakeExtractor {
+	return fakeExtractor{PiiExtractor: regex.NewDefaultExtractor(), entities: entities}
+}
+
+func email(value string) pii.PiiEntity {
+	return pii.PiiEntity{Type: pii.PiiTypeEmail, Value: pii.Email{BasePii: pii.BasePii{Value: value, Count: 1}}}
+}
+
+func card(value string) pii.PiiEntity {
+	return pii.PiiEntity{Type: pii.PiiTypeCreditCard, Value: pii.CreditCard{BasePii: pii.BasePii{Value: value, Count: 1}}}
+}
+
+// fakeValidator marks every entity as valid and counts its calls
+type fakeValidator struct {
+	calls atomic.Int32
+}
+
+func (f *fakeValidator) ValidateEntity(ctx context.Context, entity pii.PiiEntity, context string) (*pii.ValidationResult, error) {
+	f.calls.Add(1)
+	return &pii.ValidationResult{Valid: true, Confidence: 0.9, Provider: "fake", Model: "fake"}, nil
+}
+
+func (f *fakeValidator) ValidateBatch(ctx context.Context, entities []pii.PiiEntity, contexts []string) ([]*pii.ValidationResult, error) {
+	results := make([]*pii.ValidationResult, len(entities))
+	for i, entity := range entities {
+		results[i], _ = f.ValidateEntity(ctx, entity, contexts[i])
+	}
+	return results, nil
+}
+
+func (f *fakeValidator) HealthCheck(ctx context.Context) error {
+	return nil
+}
+
+func (f *fakeValidator) GetProviderInfo() (string, string) {
+	return "fake", "fake"
+}
+
+func TestPipeline_CombinesParallelStages(t *testing.T) {
+	first := newFake(email("john@acme.com"))
+	first.delay = 100 * time.Millisecond
+	second := newFake(email("jane@acme.com"))
+	second.delay = 100 * time.Millisecond
+
+	p, err := New(Config{Extractors: []ExtractorStage{{Name: "first"}, {Name: "second"}}},
+		WithExtractor("first", first), WithExtractor("second", second))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	start := time.Now()
+	result, err := p.Extract("text")
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if result.Total != 2 {
+		t.Errorf("Expected the union of both stages, got %d entities", result.Total)
+	}
+	if elapsed := time.Since(start); elapsed > 180*time.Millisecond {
+		t.Errorf("Expected the stages to run in parallel, took %v", elapsed)
+	}
+}
+
+func TestPipeline_OptionalStageFailure(t *testing.T) {
+	failing := newFake()
+	failing.err = errors.New("provider down")
+
+	p, err := New(Config{Extractors: []ExtractorStage{{Name: "regex"}, {Name: "llm"}}},
+		WithExtractor("regex", newFake(email("john@acme.com"))), WithExtractor("llm", failing))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	result, err := p.Extract("text")
+	if err != nil {
+		t.Fatalf("Expected the failed optional stage to be left out, got %v", err)
+	}
+	if result.Total != 1 {
+		t.Errorf("Expected the entities of the remaining stage, got %d", result.Total)
+	}
+
+	p, _ = New(Config{Extractors: []ExtractorStage{{Name: "llm"}}}, WithExtractor("llm", failing))
+	if _, err := p.Extract("text"); !errors.Is(err, ErrNoExtraction) {
+		t.Errorf("Expected ErrNoExtraction when every stage fails, got %v", err)
+	}
+}
+
+func TestPipeline_OptionalStageFailureStrategies(t *testing.T) {
+	failing := newFake()
+	failing.err = errors.New("provider down")
+
+	tests := []struct {
+		strategy hybrid.CombinationStrategy
+		want     int
+	}{
+		{hybrid.StrategyIntersection, 1}, // john@ found by both remaining stages
+		{hybrid.StrategyMajority, 2},     // one of the two remaining stages is a majority
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			p, err := New(Config{Extractors: []ExtractorStage{{Name: "first"}, {Name: "second"}, {Name: "llm"}}, Strategy: tt.strategy},
+				WithExtractor("first", newFake(email("john@acme.com"), email("jane@acme.com"))),
+				WithExtractor("second", newFake(email("john@acme.com"))),
+				WithExtractor("llm", failing))
+			if err != nil {
+				t.Fatalf("New failed: %v", err)
+			}
+			result, err := p.Extract("text")
+			if err != nil {
+				t.Fatalf("Extract failed: %v", err)
+			}
+			if result.Total != tt.want {
+				t.Errorf("Expected the failed stage to be left out of the combination, got %d entities", result.Total)
+			}
+		})
+	}
+}
+
+func TestPipeline_RequiredStageFailureCancelsOthers(t *testing.T) {
+	failing := newFake()
+	failing.err = errors.New("provider down")
+	slow := newFake(email("john@acme.com"))
+	slow.delay = 5 * time.Second
+	slow.cancelled = &atomic.Bool{}
+
+	p, err := New(Config{Extractors: []ExtractorStage{{Name: "regex", Required: true}, {Name: "llm"}}},
+		WithExtractor("regex", failing), WithExtractor("llm", slow))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	start := time.Now()
+	_, err = p.Extract("text")
+	if err == nil || !strings.Contains(err.Error(), "stage regex: provider down") {
+		t.Errorf("Expected the failure of the required stage, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the slow stage to be cancelled, took %v", elapsed)
+	}
+	if !slow.cancelled.Load() {
+		t.Error("Expected the slow stage to see the cancellation before the run returned")
+	}
+}
+
+func TestPipeline_Timeouts(t *testing.T) {
+	slow := newFake(email("jane@acme.com"))
+	slow.delay = 5 * time.Second
+
+	p, err := New(Config{Extractors: []ExtractorStage{{Name: "regex"}, {Name: "llm", Timeout: 50 * time.Millisecond}}},
+		WithExtractor("regex", newFake(email("john@acme.com"))), WithExtractor("llm", slow))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	result, err := p.Extract("text")
+	if err != nil {
+		t.Fatalf("Expected the timed out optional stage to be left out, got %v", err)
+	}
+	if result.Total != 1 {
+		t.Errorf("Expected the entities of the stage in time, got %d", result.Total)
+	}
+
+	p, _ = New(Config{Extractors: []ExtractorStage{{Name: "llm"}}, Timeout: 50 * time.Millisecond}, WithExtractor("llm", slow))
+	if _, err := p.Extract("text"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the run to time out, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := p.ExtractContext(ctx, "text"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the caller's cancellation, got %v", err)
+	}
+}
+
+func TestPipeline_RulesAndValidation(t *testing.T) {
+	validator := &fakeValidator{}
+	p, err := New(Config{
+		Extractors: []ExtractorStage{{Name: "regex"}},
+		Rules:      []string{RuleChecksums, RuleConsistency},
+		Validation: &hybrid.ValidationConfig{Enabled: true, MinConfidence: 0.5, Validator: validator},
+	}, WithExtractor("regex", newFake(card("4111111111111111"), card("4111111111111112"), email("john@acme.com"))))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	result, err := p.Extract("Card 4111111111111111, 4111111111111112 and john@acme.com")
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if cards := result.GetCreditCards(); len(cards) != 1 || cards[0].GetValue() != "4111111111111111" {
+		t.Errorf("Expected the card failing Luhn to be dropped, got %v", cards)
+	}
+	if got := validator.calls.Load(); got != 2 {
+		t.Errorf("Expected the remaining entities to be validated, got %d calls", got)
+	}
+	if result.ValidationStats == nil || result.ValidationStats.TotalValidated != 2 {
+		t.Errorf("Expected validation stats for 2 entities, got %+v", result.ValidationStats)
+	}
+}
+
+func TestPipeline_CustomRule(t *testing.T) {
+	failing := func(context.Context, string, *pii.PiiExtractionResult) (*pii.PiiExtractionResult, error) {
+		return nil, errors.New("quota exceeded")
+	}
+	p, err := New(Config{Extractors: []ExtractorStage{{Name: "regex"}}, Rules: []string{"audit"}},
+		WithExtractor("regex", newFake(email("john@acme.com"))), WithRule("audit", failing))
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if _, err := p.Extract("text"); err == nil || !strings.Contains(err.Error(), "stage rules: rule audit: quota exceeded") {
+		t.Errorf("Expected the failure of the rule, got %v", err)
+	}
+}
+
+func TestPipeline_DeclarativeConfig(t *testing.T) {
+	var config Config
+	err := json.Unmarshal([]byte(`{
+		"extractors": [{"name": "regex", "method": "regex", "required": true}],
+		"strategy": "union",
+		"rules": ["checksums"],
+		"timeout": 5000000000
+	}`), &config)
+	if err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	p, err := New(config)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	result, err := p.Extract("Contact john@acme.com, card 4111111111111112")
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if len(result.GetEmails()) != 1 || len(result.GetCreditCards()) != 0 {
+		t.Errorf("Expected the email and no invalid card, got %v", result.Entities)
+	}
+	if p.GetMethod() != extractors.MethodHybrid || p.GetName() != "pipeline" {
+		t.Errorf("Unexpected method %s or name %s", p.GetMethod(), p.GetName())
+	}
+}
+
+func TestNew_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		want   string
+	}{
+		{"no stage", Config{}, "no extraction stage"},
+		{"duplicate name", Config{Extractors: []ExtractorStage{{Name: "a", Method: extractors.MethodRegex}, {Name: "a", Method: extractors.MethodRegex}}}, "unique names"},
+		{"reserved name", Config{Extractors: []ExtractorStage{{Name: StageCombine, Method: extractors.MethodRegex}}}, "unique names"},
+		{"unknown method", Config{Extractors: []ExtractorStage{{Name: "a", Method: "ocr"}}}, `unknown method "ocr"`},
+		{"unknown strategy", Config{Extractors: []ExtractorStage{{Name: "a", Method: extractors.MethodRegex}}, Strategy: "best"}, `unknown strategy "best"`},
+		{"unknown rule", Config{Extractors: []ExtractorStage{{Name: "a", Method: extractors.MethodRegex}}, Rules: []string{"spelling"}}, `unknown rule "spelling"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(tt.config); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected an error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
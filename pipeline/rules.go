@@ -0,0 +1,28 @@
+package pipeline
+
+import (
+	"github.com/intMeric/pii-extractor/calibration"
+	"github.com/intMeric/pii-extractor/consistency"
+	"github.com/intMeric/pii-extractor/extractors"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// Built-in rules of the rules stage
+const (
+	RuleChecksums   = "checksums"   // drops card numbers failing Luhn and IBANs failing mod 97
+	RuleConsistency = "consistency" // flags inconsistent entities (see consistency.Checker)
+)
+
+// builtinRules returns the rules that Config.Rules can name without WithRule
+func builtinRules() map[string]extractors.PostProcessor {
+	return map[string]extractors.PostProcessor{
+		RuleChecksums:   extractors.FilterEntities(passesChecksum),
+		RuleConsistency: consistency.NewChecker().PostProcessor(),
+	}
+}
+
+// passesChecksum reports whether an entity has no checksum or passes it
+func passesChecksum(entity pii.PiiEntity) bool {
+	signal, score := calibration.RawScore(entity)
+	return signal != calibration.SignalChecksum || score > 0
+}
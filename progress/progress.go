@@ -0,0 +1,114 @@
+// Package progress reports the advance of long-running operations (directory scans,
+// streamed documents, validation of many entities) so that UIs and CLIs can show
+// progress bars and ETAs.
+package progress
+
+import (
+	"sync"
+	"time"
+)
+
+// Unit is what an operation counts
+type Unit string
+
+const (
+	UnitBytes    Unit = "bytes"
+	UnitEntities Unit = "entities"
+	UnitFiles    Unit = "files"
+)
+
+// Update is the state of an operation after some work was done
+type Update struct {
+	Operation string        `json:"operation"` // Such as "scan", "stream" or "validate"
+	Unit      Unit          `json:"unit"`
+	Done      int64         `json:"done"`
+	Total     int64         `json:"total"` // Zero when unknown
+	Elapsed   time.Duration `json:"elapsed"`
+}
+
+// Fraction returns the part of the work done, between 0 and 1, or 0 when the total is
+// unknown
+func (u Update) Fraction() float64 {
+	if u.Total <= 0 {
+		return 0
+	}
+	return min(float64(u.Done)/float64(u.Total), 1)
+}
+
+// ETA estimates the time left from the rate so far. It returns false when the total is
+// unknown or nothing was done yet.
+func (u Update) ETA() (time.Duration, bool) {
+	if u.Total <= 0 || u.Done <= 0 {
+		return 0, false
+	}
+	remaining := max(u.Total-u.Done, 0)
+	return time.Duration(float64(u.Elapsed) / float64(u.Done) * float64(remaining)), true
+}
+
+// Func receives progress updates. Operations call it from one goroutine at a time, but
+// possibly not from the goroutine that started them.
+type Func func(Update)
+
+// Channel returns a Func sending updates to ch without blocking the operation: updates
+// are dropped while ch is full, so a slow consumer only sees fewer updates. The final
+// update may be dropped too; use the operation's result to detect completion.
+func Channel(ch chan<- Update) Func {
+	return func(update Update) {
+		select {
+		case ch <- update:
+		default:
+		}
+	}
+}
+
+// Tracker counts the work of an operation and reports it to a Func. It is safe for
+// concurrent use and serializes the calls to the Func. A Tracker with a nil Func only
+// counts.
+type Tracker struct {
+	update Update
+	report Func
+	start  time.Time
+	mu     sync.Mutex
+}
+
+// NewTracker creates a tracker for an operation of total units (zero when unknown)
+func NewTracker(operation string, unit Unit, total int64, report Func) *Tracker {
+	return &Tracker{
+		update: Update{Operation: operation, Unit: unit, Total: total},
+		report: report,
+		start:  time.Now(),
+	}
+}
+
+// Add records n more units done and reports the new state
+func (t *Tracker) Add(n int64) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.update.Done += n
+	t.update.Elapsed = time.Since(t.start)
+	if t.report != nil {
+		t.report(t.update)
+	}
+}
+
+// SetTotal updates the total once it becomes known
+func (t *Tracker) SetTotal(total int64) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.update.Total = total
+}
+
+// Update returns the current state
+func (t *Tracker) Update() Update {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	update := t.update
+	update.Elapsed = time.Since(t.start)
+	return update
+}
@@ -0,0 +1,65 @@
+package progress
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestUpdate_ETA(t *testing.T) {
+	update := Update{Done: 25, Total: 100, Elapsed: 10 * time.Second}
+	if eta, ok := update.ETA(); !ok || eta != 30*time.Second {
+		t.Errorf("ETA() = %v, %v, want 30s", eta, ok)
+	}
+	if update.Fraction() != 0.25 {
+		t.Errorf("Fraction() = %v, want 0.25", update.Fraction())
+	}
+	if _, ok := (Update{Done: 25, Elapsed: time.Second}).ETA(); ok {
+		t.Error("Expected no ETA without a total")
+	}
+}
+
+func TestTracker_Concurrent(t *testing.T) {
+	var updates []Update
+	tracker := NewTracker("validate", UnitEntities, 100, func(update Update) {
+		// Calls are serialized, so no lock is needed here
+		updates = append(updates, update)
+	})
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 10 {
+				tracker.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(updates) != 100 || updates[99].Done != 100 || updates[99].Operation != "validate" {
+		t.Errorf("Expected 100 ordered updates, got %d ending with %+v", len(updates), updates[len(updates)-1])
+	}
+	for i, update := range updates {
+		if update.Done != int64(i+1) {
+			t.Fatalf("Expected update %d to count %d, got %d", i, i+1, update.Done)
+		}
+	}
+}
+
+func TestChannel(t *testing.T) {
+	ch := make(chan Update, 1)
+	report := Channel(ch)
+	report(Update{Done: 1})
+	report(Update{Done: 2}) // dropped, the channel is full
+
+	if update := <-ch; update.Done != 1 {
+		t.Errorf("Expected the first update, got %+v", update)
+	}
+	select {
+	case update := <-ch:
+		t.Errorf("Expected the second update to be dropped, got %+v", update)
+	default:
+	}
+}
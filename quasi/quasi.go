@@ -0,0 +1,286 @@
+// Package quasi flags quasi-identifier pairs: precise timestamps appearing next to a direct
+// identifier such as an email address or IP address. Neither is sensitive on its own in
+// telemetry, but an exact event time tied to a user singles out what that user did and
+// when, so privacy reviews of telemetry treat the pair as personal data.
+//
+// Timestamps are detected in ISO 8601 / RFC 3339, Common Log Format, BSD syslog and,
+// after a time keyword, Unix epoch forms, along with their time zone when given.
+package quasi
+
+import (
+	"context"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/intMeric/pii-extractor/extractors"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// ProfilePrivacyTelemetry is the profile flagged on identifiers paired with a precise timestamp
+const ProfilePrivacyTelemetry = "privacy_telemetry"
+
+// Annotations set on flagged identifiers
+const (
+	AnnotationProfile   = "quasi.profile"   // ProfilePrivacyTelemetry
+	AnnotationTimestamp = "quasi.timestamp" // first timestamp paired with the identifier
+	AnnotationTimezone  = "quasi.timezone"  // time zone of that timestamp, when given
+	AnnotationPairs     = "quasi.pairs"     // occurrences of the identifier paired with a timestamp
+)
+
+// Precision is the finest time unit of a timestamp
+type Precision int
+
+const (
+	PrecisionMinute Precision = iota + 1
+	PrecisionSecond
+	PrecisionSubsecond
+)
+
+// String returns the name of the precision
+func (p Precision) String() string {
+	switch p {
+	case PrecisionMinute:
+		return "minute"
+	case PrecisionSecond:
+		return "second"
+	case PrecisionSubsecond:
+		return "subsecond"
+	default:
+		return "unknown"
+	}
+}
+
+// Timestamp is a timestamp found in text
+type Timestamp struct {
+	Value     string    `json:"value"`
+	Start     int       `json:"start"`
+	End       int       `json:"end"`
+	Precision Precision `json:"precision"`
+	Zone      string    `json:"zone,omitempty"` // UTC offset, "Z" or IANA zone name
+}
+
+var (
+	// 2024-03-05T14:22:31.120Z, 2024-03-05 14:22:31+01:00 Europe/Paris
+	isoRegex = regexp.MustCompile(`\b\d{4}-(?:0[1-9]|1[0-2])-(?:0[1-9]|[12]\d|3[01])[T ](?:[01]\d|2[0-3]):[0-5]\d(:[0-5]\d([.,]\d{1,9})?)?(Z|[+-](?:[01]\d|2[0-3]):?[0-5]\d)?(?:\s([A-Z][A-Za-z_]+/[A-Za-z_]+(?:/[A-Za-z_]+)?|UTC|GMT))?`)
+
+	// [05/Mar/2024:14:22:31 +0000]
+	clfRegex = regexp.MustCompile(`\b(?:0[1-9]|[12]\d|3[01])/(?:Jan|Feb|Mar|Apr|May|Jun|Jul|Aug|Sep|Oct|Nov|Dec)/\d{4}:(?:[01]\d|2[0-3]):[0-5]\d:[0-5]\d( [+-]\d{4})?`)
+
+	// Mar  5 14:22:31
+	syslogRegex = regexp.MustCompile(`\b(?:Jan|Feb|Mar|Apr|May|Jun|Jul|Aug|Sep|Oct|Nov|Dec) [ 1-3]\d (?:[01]\d|2[0-3]):[0-5]\d:[0-5]\d(\.\d{1,9})?\b`)
+
+	// ts=1709648551, "timestamp": 1709648551120
+	epochRegex = regexp.MustCompile(`(?i)\b(?:ts|time|timestamp|epoch|event_time|created_at)["']?\s*[:=]\s*["']?(1\d{9}(\d{3}|\d{6})?)\b`)
+)
+
+// FindTimestamps returns the timestamps of text with at least minute precision, sorted by offset
+func FindTimestamps(text string) []Timestamp {
+	var timestamps []Timestamp
+	for _, m := range isoRegex.FindAllStringSubmatchIndex(text, -1) {
+		ts := Timestamp{Value: text[m[0]:m[1]], Start: m[0], End: m[1], Precision: PrecisionMinute}
+		if m[2] >= 0 {
+			ts.Precision = PrecisionSecond
+		}
+		if m[4] >= 0 {
+			ts.Precision = PrecisionSubsecond
+		}
+		if m[8] >= 0 {
+			ts.Zone = text[m[8]:m[9]]
+		} else if m[6] >= 0 {
+			ts.Zone = text[m[6]:m[7]]
+		}
+		timestamps = append(timestamps, ts)
+	}
+	for _, m := range clfRegex.FindAllStringSubmatchIndex(text, -1) {
+		ts := Timestamp{Value: text[m[0]:m[1]], Start: m[0], End: m[1], Precision: PrecisionSecond}
+		if m[2] >= 0 {
+			ts.Zone = strings.TrimSpace(text[m[2]:m[3]])
+		}
+		timestamps = append(timestamps, ts)
+	}
+	for _, m := range syslogRegex.FindAllStringSubmatchIndex(text, -1) {
+		ts := Timestamp{Value: text[m[0]:m[1]], Start: m[0], End: m[1], Precision: PrecisionSecond}
+		if m[2] >= 0 {
+			ts.Precision = PrecisionSubsecond
+		}
+		timestamps = append(timestamps, ts)
+	}
+	for _, m := range epochRegex.FindAllStringSubmatchIndex(text, -1) {
+		ts := Timestamp{Value: text[m[2]:m[3]], Start: m[2], End: m[3], Precision: PrecisionSecond}
+		if m[4] >= 0 {
+			ts.Precision = PrecisionSubsecond
+		}
+		timestamps = append(timestamps, ts)
+	}
+
+	slices.SortFunc(timestamps, func(a, b Timestamp) int { return a.Start - b.Start })
+	return timestamps
+}
+
+// DefaultIdentifiers are the types paired with timestamps when no types are configured
+var DefaultIdentifiers = []pii.PiiType{
+	pii.PiiTypeEmail,
+	pii.PiiTypePhone,
+	pii.PiiTypeIPAddress,
+	pii.PiiTypeSessionToken,
+	pii.PiiTypeSSN,
+	pii.PiiTypeCreditCard,
+	pii.PiiTypeIBAN,
+	pii.PiiTypeBtcAddress,
+}
+
+// DefaultMaxDistance is the default maximum number of bytes between a timestamp and an identifier
+const DefaultMaxDistance = 200
+
+// Detector flags identifiers paired with precise timestamps
+type Detector struct {
+	identifiers  []pii.PiiType
+	minPrecision Precision
+	maxDistance  int
+}
+
+// Option configures a Detector
+type Option func(*Detector)
+
+// WithIdentifiers sets the types paired with timestamps (DefaultIdentifiers by default)
+func WithIdentifiers(types ...pii.PiiType) Option {
+	return func(d *Detector) {
+		d.identifiers = types
+	}
+}
+
+// WithMinPrecision sets the coarsest precision of the paired timestamps (PrecisionSecond
+// by default)
+func WithMinPrecision(precision Precision) Option {
+	return func(d *Detector) {
+		d.minPrecision = precision
+	}
+}
+
+// WithMaxDistance sets the maximum number of bytes between a timestamp and an identifier
+// on the same line (DefaultMaxDistance by default)
+func WithMaxDistance(distance int) Option {
+	return func(d *Detector) {
+		d.maxDistance = distance
+	}
+}
+
+// NewDetector creates a detector
+func NewDetector(opts ...Option) *Detector {
+	d := &Detector{
+		identifiers:  DefaultIdentifiers,
+		minPrecision: PrecisionSecond,
+		maxDistance:  DefaultMaxDistance,
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Flag returns a copy of the result whose identifiers appearing on the same line as a
+// precise timestamp, within the maximum distance, are annotated with the privacy
+// telemetry profile and their paired timestamp
+func (d *Detector) Flag(text string, result *pii.PiiExtractionResult) *pii.PiiExtractionResult {
+	var timestamps []Timestamp
+	for _, ts := range FindTimestamps(text) {
+		if ts.Precision >= d.minPrecision {
+			timestamps = append(timestamps, ts)
+		}
+	}
+
+	flagged := *result
+	flagged.Entities = slices.Clone(result.Entities)
+	if len(timestamps) == 0 {
+		return &flagged
+	}
+
+	type pairing struct {
+		first Timestamp
+		pairs int
+	}
+	pairings := make(map[string]*pairing)
+	for _, occurrence := range result.Occurrences(text) {
+		if !slices.Contains(d.identifiers, occurrence.Entity.Type) {
+			continue
+		}
+		ts, ok := d.nearest(text, timestamps, occurrence.Start, occurrence.End)
+		if !ok {
+			continue
+		}
+		key := entityKey(occurrence.Entity)
+		if p, exists := pairings[key]; exists {
+			p.pairs++
+		} else {
+			pairings[key] = &pairing{first: ts, pairs: 1}
+		}
+	}
+
+	for i, entity := range flagged.Entities {
+		p, ok := pairings[entityKey(entity)]
+		if !ok {
+			continue
+		}
+		// Copy the annotations so that the source result is left untouched
+		entity.Annotations = maps.Clone(entity.Annotations)
+		entity.Annotate(AnnotationProfile, ProfilePrivacyTelemetry)
+		entity.Annotate(AnnotationTimestamp, p.first.Value)
+		if p.first.Zone != "" {
+			entity.Annotate(AnnotationTimezone, p.first.Zone)
+		}
+		entity.Annotate(AnnotationPairs, p.pairs)
+		flagged.Entities[i] = entity
+	}
+	return &flagged
+}
+
+// PostProcessor returns a middleware post-processor flagging the results
+func (d *Detector) PostProcessor() extractors.PostProcessor {
+	return func(_ context.Context, text string, result *pii.PiiExtractionResult) (*pii.PiiExtractionResult, error) {
+		return d.Flag(text, result), nil
+	}
+}
+
+// Flagged returns the entities of a result flagged with the privacy telemetry profile
+func Flagged(result *pii.PiiExtractionResult) []pii.PiiEntity {
+	var flagged []pii.PiiEntity
+	for _, entity := range result.GetAnnotated(AnnotationProfile) {
+		if entity.Annotations[AnnotationProfile] == ProfilePrivacyTelemetry {
+			flagged = append(flagged, entity)
+		}
+	}
+	return flagged
+}
+
+// nearest returns the closest timestamp on the same line as the span, within the maximum
+// distance
+func (d *Detector) nearest(text string, timestamps []Timestamp, start, end int) (Timestamp, bool) {
+	var best Timestamp
+	bestDistance := -1
+	for _, ts := range timestamps {
+		var distance int
+		var between string
+		switch {
+		case ts.End <= start:
+			distance, between = start-ts.End, text[ts.End:start]
+		case ts.Start >= end:
+			distance, between = ts.Start-end, text[end:ts.Start]
+		default:
+			continue // overlapping spans, such as digits of an epoch read as a phone number
+		}
+		if distance > d.maxDistance || strings.ContainsRune(between, '\n') {
+			continue
+		}
+		if bestDistance < 0 || distance < bestDistance {
+			best, bestDistance = ts, distance
+		}
+	}
+	return best, bestDistance >= 0
+}
+
+// entityKey identifies an entity by type and value
+func entityKey(entity pii.PiiEntity) string {
+	return entity.Type.String() + ":" + entity.GetValue()
+}
@@ -0,0 +1,94 @@
+package quasi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/intMeric/pii-extractor/extractors"
+	"github.com/intMeric/pii-extractor/extractors/regex"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+func TestFindTimestamps(t *testing.T) {
+	tests := []struct {
+		text      string
+		value     string
+		precision Precision
+		zone      string
+	}{
+		{"at 2024-03-05T14:22:31.120Z login", "2024-03-05T14:22:31.120Z", PrecisionSubsecond, "Z"},
+		{"at 2024-03-05 14:22:31+01:00 Europe/Paris", "2024-03-05 14:22:31+01:00 Europe/Paris", PrecisionSecond, "Europe/Paris"},
+		{"at 2024-03-05 14:22 ok", "2024-03-05 14:22", PrecisionMinute, ""},
+		{`[05/Mar/2024:14:22:31 +0000] "GET /"`, "05/Mar/2024:14:22:31 +0000", PrecisionSecond, "+0000"},
+		{"Mar  5 14:22:31 host sshd", "Mar  5 14:22:31", PrecisionSecond, ""},
+		{`{"ts": 1709648551120}`, "1709648551120", PrecisionSubsecond, ""},
+	}
+	for _, tt := range tests {
+		timestamps := FindTimestamps(tt.text)
+		if len(timestamps) != 1 {
+			t.Errorf("FindTimestamps(%q) = %+v, want one timestamp", tt.text, timestamps)
+			continue
+		}
+		ts := timestamps[0]
+		if ts.Value != tt.value || ts.Precision != tt.precision || ts.Zone != tt.zone || tt.text[ts.Start:ts.End] != ts.Value {
+			t.Errorf("FindTimestamps(%q) = %+v", tt.text, ts)
+		}
+	}
+
+	for _, text := range []string{"on 2024-03-05", "order 1709648551", "version 2024-13-05 14:22"} {
+		if timestamps := FindTimestamps(text); len(timestamps) != 0 {
+			t.Errorf("Expected no timestamp in %q, got %+v", text, timestamps)
+		}
+	}
+}
+
+func TestDetector_Flag(t *testing.T) {
+	text := "2024-03-05T14:22:31Z login john@example.com from 10.1.2.3\n" +
+		"welcome mail sent to jane@example.com\n" +
+		"2024-03-05T14:22:40Z logout john@example.com"
+	extractor := regex.NewExtractor(&extractors.ExtractorConfig{
+		Types: []pii.PiiType{pii.PiiTypeEmail, pii.PiiTypeIPAddress},
+	})
+	middleware := extractors.WithMiddleware(extractor, extractors.WithPostProcessor(NewDetector().PostProcessor()))
+
+	result, err := middleware.ExtractContext(context.Background(), text)
+	if err != nil {
+		t.Fatalf("ExtractContext() error = %v", err)
+	}
+
+	flagged := map[string]pii.PiiEntity{}
+	for _, entity := range Flagged(result) {
+		flagged[entity.GetValue()] = entity
+	}
+	if len(flagged) != 2 {
+		t.Fatalf("Expected john and the IP flagged, got %v", flagged)
+	}
+	john := flagged["john@example.com"]
+	if john.Annotations[AnnotationTimestamp] != "2024-03-05T14:22:31Z" || john.Annotations[AnnotationPairs] != 2 || john.Annotations[AnnotationTimezone] != "Z" {
+		t.Errorf("Unexpected annotations %v", john.Annotations)
+	}
+	if _, ok := flagged["jane@example.com"]; ok {
+		t.Error("Expected an identifier without a timestamp on its line not to be flagged")
+	}
+}
+
+func TestDetector_Options(t *testing.T) {
+	text := "2024-03-05 14:22 john@example.com"
+	result := pii.NewPiiExtractionResult([]pii.PiiEntity{{Type: pii.PiiTypeEmail, Value: pii.NewEmail("john@example.com")}})
+
+	if len(Flagged(NewDetector().Flag(text, result))) != 0 {
+		t.Error("Expected minute timestamps to be ignored by default")
+	}
+	if len(Flagged(NewDetector(WithMinPrecision(PrecisionMinute)).Flag(text, result))) != 1 {
+		t.Error("Expected minute timestamps to be paired with WithMinPrecision(PrecisionMinute)")
+	}
+	if len(Flagged(NewDetector(WithMinPrecision(PrecisionMinute), WithMaxDistance(0)).Flag(text, result))) != 0 {
+		t.Error("Expected timestamps beyond the maximum distance to be ignored")
+	}
+	if len(Flagged(NewDetector(WithMinPrecision(PrecisionMinute), WithIdentifiers(pii.PiiTypeIPAddress)).Flag(text, result))) != 0 {
+		t.Error("Expected only the configured identifier types to be flagged")
+	}
+	if result.Entities[0].Annotations != nil {
+		t.Error("Expected the source result to be left untouched")
+	}
+}
@@ -0,0 +1,59 @@
+package redact
+
+import (
+	"context"
+	"strings"
+
+	"github.com/intMeric/pii-extractor/extractors"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// ExtractAndRedact extracts the PII of text and returns the result along with the text
+// redacted by the preset, in one call. The redaction replaces the spans of the result at
+// the offsets recorded by the extractor (see Spans) instead of searching every value in the
+// text again.
+func (r *Redactor) ExtractAndRedact(ctx context.Context, extractor extractors.PiiExtractor, text string) (*pii.PiiExtractionResult, string, error) {
+	result, err := extractors.ExtractWithContext(ctx, extractor, text)
+	if err != nil {
+		return nil, "", err
+	}
+	return result, r.RedactSpans(text, Spans(text, result)), nil
+}
+
+// RedactSpans replaces the given occurrences of entities in text with their masked form.
+// Occurrences must be sorted by offset and must not overlap, as returned by Spans.
+func (r *Redactor) RedactSpans(text string, occurrences []pii.Occurrence) string {
+	if len(occurrences) == 0 {
+		return text
+	}
+
+	var b strings.Builder
+	b.Grow(len(text))
+	masks := make(map[string]string)
+	last := 0
+	for _, occurrence := range occurrences {
+		value := occurrence.Entity.GetValue()
+		mask, ok := masks[value]
+		if !ok {
+			mask = r.Mask(occurrence.Entity)
+			masks[value] = mask
+		}
+		b.WriteString(text[last:occurrence.Start])
+		b.WriteString(mask)
+		last = occurrence.End
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+// Spans returns the occurrences of the result entities in text, sorted by offset without
+// overlaps. Occurrences are the match offsets recorded by the extractor in the entity
+// locations, such as the single span of each entity of a per-occurrence result (see
+// pii.PiiExtractionResult.PerOccurrence); only entities without locations are searched
+// by value (see pii.PiiExtractionResult.Occurrences).
+func Spans(text string, result *pii.PiiExtractionResult) []pii.Occurrence {
+	if result == nil {
+		return nil
+	}
+	return result.Occurrences(text)
+}
@@ -0,0 +1,94 @@
+package redact
+
+import (
+	"context"
+	"testing"
+
+	"github.com/intMeric/pii-extractor/extractors"
+	"github.com/intMeric/pii-extractor/extractors/regex"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// fixedExtractor returns the same result for any text
+type fixedExtractor struct {
+	result *pii.PiiExtractionResult
+}
+
+func (e fixedExtractor) Extract(string) (*pii.PiiExtractionResult, error) {
+	return e.result, nil
+}
+
+func (e fixedExtractor) ExtractByType(string, pii.PiiType) ([]pii.PiiEntity, error) {
+	return e.result.Entities, nil
+}
+
+func (e fixedExtractor) GetSupportedTypes() []pii.PiiType {
+	return []pii.PiiType{pii.PiiTypeEmail, pii.PiiTypePhone}
+}
+
+func (e fixedExtractor) GetMethod() extractors.ExtractionMethod {
+	return extractors.MethodRegex
+}
+
+func (e fixedExtractor) GetName() string {
+	return "fixed"
+}
+
+func TestExtractAndRedact(t *testing.T) {
+	text := "mail john@example.com or call 555-0100, john@example.com again"
+	result := pii.NewPiiExtractionResult([]pii.PiiEntity{
+		entity(pii.PiiTypeEmail, pii.NewEmail("john@example.com")),
+		entity(pii.PiiTypePhone, pii.NewPhone("555-0100", pii.CountryUS)),
+	})
+
+	got, redacted, err := New(PresetStrict).ExtractAndRedact(context.Background(), fixedExtractor{result}, text)
+	if err != nil {
+		t.Fatalf("ExtractAndRedact() error: %v", err)
+	}
+	if got != result {
+		t.Error("Expected the extraction result to be returned")
+	}
+	want := "mail [EMAIL] or call [PHONE], [EMAIL] again"
+	if redacted != want {
+		t.Errorf("ExtractAndRedact() = %q, want %q", redacted, want)
+	}
+	if redacted != New(PresetStrict).Redact(text, result) {
+		t.Error("Expected the same text as Redact")
+	}
+}
+
+func TestSpans_PerOccurrence(t *testing.T) {
+	text := "john@example.com, then john@example.com"
+	result := pii.NewPiiExtractionResult([]pii.PiiEntity{
+		entity(pii.PiiTypeEmail, pii.NewEmail("john@example.com")),
+	}).PerOccurrence(text, nil)
+
+	// Drop the first occurrence: only the span kept in the result is redacted
+	result.Entities = result.Entities[1:]
+	spans := Spans(text, result)
+	if len(spans) != 1 || spans[0].Start != 23 {
+		t.Fatalf("Expected the annotated span only, got %+v", spans)
+	}
+	if got := New(PresetStrict).RedactSpans(text, spans); got != "john@example.com, then [EMAIL]" {
+		t.Errorf("RedactSpans() = %q", got)
+	}
+}
+
+func TestExtractAndRedact_SessionTokens(t *testing.T) {
+	text := "GET /app?sessionid=abcdef1234567890XYZ HTTP/1.1 from john@example.com"
+	extractor := regex.NewExtractor(&extractors.ExtractorConfig{
+		Options: map[string]any{regex.OptionHTTPLogs: true},
+	})
+
+	result, redacted, err := New(PresetStrict).ExtractAndRedact(context.Background(), extractor, text)
+	if err != nil {
+		t.Fatalf("ExtractAndRedact() error: %v", err)
+	}
+	if len(result.GetSessionTokens()) != 1 {
+		t.Fatalf("Expected a session token, got %v", result.Stats)
+	}
+	want := "GET /app?sessionid=[SESSION_TOKEN] HTTP/1.1 from [EMAIL]"
+	if redacted != want {
+		t.Errorf("ExtractAndRedact() = %q, want %q", redacted, want)
+	}
+}
@@ -0,0 +1,90 @@
+package redact
+
+import "github.com/intMeric/pii-extractor/pii"
+
+// Preset is a named set of per-type maskers
+type Preset struct {
+	Name    string
+	Maskers map[pii.PiiType]Masker
+	Default Masker // used for types without a masker; nil uses BySeverity
+
+	// KeepBelow leaves entities whose severity is below this level unmasked, such as
+	// internal email addresses. SeverityLow (the zero value) masks everything.
+	KeepBelow pii.Severity
+}
+
+// With returns a copy of the preset using masker for piiType
+func (p Preset) With(piiType pii.PiiType, masker Masker) Preset {
+	maskers := make(map[pii.PiiType]Masker, len(p.Maskers)+1)
+	for t, m := range p.Maskers {
+		maskers[t] = m
+	}
+	maskers[piiType] = masker
+	p.Maskers = maskers
+	return p
+}
+
+// ibanMasker keeps the country code and the last 4 characters of an IBAN
+func ibanMasker(entity pii.PiiEntity) string {
+	return keepEnds(entity.GetValue(), 2, 4)
+}
+
+var (
+	// PresetStandard applies the common display norms: cards show the first 6 and last
+	// 4 digits, SSNs the last 4, emails mask the local part, IBANs show the country and
+	// last 4 characters and phones the last 2 digits. Other types are masked by severity.
+	PresetStandard = Preset{
+		Name: "standard",
+		Maskers: map[pii.PiiType]Masker{
+			pii.PiiTypeCreditCard: KeepEnds(6, 4),
+			pii.PiiTypeSSN:        KeepLast(4),
+			pii.PiiTypeEmail:      MaskEmailLocal(),
+			pii.PiiTypeIBAN:       ibanMasker,
+			pii.PiiTypePhone:      KeepLast(2),
+		},
+	}
+
+	// PresetPCI follows PCI DSS display rules: card numbers show at most the first 6 and
+	// last 4 digits, and magnetic stripe data is never shown. Other types are masked by severity.
+	PresetPCI = Preset{
+		Name: "pci",
+		Maskers: map[pii.PiiType]Masker{
+			pii.PiiTypeCreditCard: KeepEnds(6, 4),
+			pii.PiiTypeTrackData:  TypeLabel(),
+		},
+	}
+
+	// PresetSupportTicket shows support agents just enough to identify a customer: the
+	// last 4 digits of cards and SSNs, the email domain, the IBAN country and last 4
+	// characters and the last 2 phone digits. Low severity entities such as internal
+	// email addresses stay readable.
+	PresetSupportTicket = Preset{
+		Name: "support_ticket",
+		Maskers: map[pii.PiiType]Masker{
+			pii.PiiTypeCreditCard: KeepLast(4),
+			pii.PiiTypeSSN:        KeepLast(4),
+			pii.PiiTypeEmail:      MaskEmailLocal(),
+			pii.PiiTypeIBAN:       ibanMasker,
+			pii.PiiTypePhone:      KeepLast(2),
+			pii.PiiTypeTrackData:  TypeLabel(),
+			pii.PiiTypeSecret:     TypeLabel(),
+		},
+		KeepBelow: pii.SeverityMedium,
+	}
+
+	// PresetStrict replaces every value with its type label, such as "[EMAIL]"
+	PresetStrict = Preset{
+		Name:    "strict",
+		Default: TypeLabel(),
+	}
+)
+
+// Presets returns the built-in presets by name
+func Presets() map[string]Preset {
+	return map[string]Preset{
+		PresetStandard.Name:      PresetStandard,
+		PresetPCI.Name:           PresetPCI,
+		PresetSupportTicket.Name: PresetSupportTicket,
+		PresetStrict.Name:        PresetStrict,
+	}
+}
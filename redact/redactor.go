@@ -0,0 +1,136 @@
+// Package redact masks detected PII in values and documents using per-type masking presets.
+package redact
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// Masker returns the masked form of an entity value
+type Masker func(entity pii.PiiEntity) string
+
+// Redactor masks entities and documents according to a Preset
+type Redactor struct {
+	preset Preset
+}
+
+// New creates a redactor using the given preset
+func New(preset Preset) *Redactor {
+	return &Redactor{preset: preset}
+}
+
+// Preset returns the preset used by the redactor
+func (r *Redactor) Preset() Preset {
+	return r.preset
+}
+
+// Mask returns the masked form of an entity value. Entities below the preset's
+// KeepBelow severity are returned unmasked.
+func (r *Redactor) Mask(entity pii.PiiEntity) string {
+	if r.preset.KeepBelow > pii.SeverityLow && entity.GetSeverity() < r.preset.KeepBelow {
+		return entity.GetValue()
+	}
+	if masker, ok := r.preset.Maskers[entity.Type]; ok {
+		return masker(entity)
+	}
+	if r.preset.Default != nil {
+		return r.preset.Default(entity)
+	}
+	return BySeverity()(entity)
+}
+
+// Redact replaces the occurrences of the result entities in text with their masked form.
+// Occurrences are taken at the offsets recorded by the extractor, which is how masked
+// session tokens and secrets are found, and searched by value otherwise (see
+// pii.PiiExtractionResult.Occurrences). Of overlapping occurrences, the earliest then
+// longest is replaced, so that a value contained in another (a ZIP code inside a street
+// address) does not break the longer match.
+func (r *Redactor) Redact(text string, result *pii.PiiExtractionResult) string {
+	if result == nil || len(result.Entities) == 0 {
+		return text
+	}
+	return r.RedactSpans(text, result.Occurrences(text))
+}
+
+// MaskRune is the character replacing masked characters
+const MaskRune = '*'
+
+// MaskAll masks every letter and digit, keeping separators
+func MaskAll() Masker {
+	return KeepEnds(0, 0)
+}
+
+// KeepEnds keeps the first and last letters or digits of a value and masks the others,
+// keeping separators such as spaces, dashes and parentheses in place
+func KeepEnds(first, last int) Masker {
+	return func(entity pii.PiiEntity) string {
+		return keepEnds(entity.GetValue(), first, last)
+	}
+}
+
+// KeepLast keeps the last letters or digits of a value
+func KeepLast(last int) Masker {
+	return KeepEnds(0, last)
+}
+
+// MaskEmailLocal masks the local part of an email address except its first character,
+// keeping the domain
+func MaskEmailLocal() Masker {
+	return func(entity pii.PiiEntity) string {
+		value := entity.GetValue()
+		at := strings.LastIndexByte(value, '@')
+		if at <= 0 {
+			return keepEnds(value, 0, 0)
+		}
+		local := []rune(value[:at])
+		return string(local[0]) + strings.Repeat(string(MaskRune), len(local)-1) + value[at:]
+	}
+}
+
+// BySeverity replaces high and critical severity values with their type label, hiding
+// even their shape, and masks every letter and digit of lower severity values
+func BySeverity() Masker {
+	return func(entity pii.PiiEntity) string {
+		if entity.GetSeverity() >= pii.SeverityHigh {
+			return TypeLabel()(entity)
+		}
+		return MaskAll()(entity)
+	}
+}
+
+// TypeLabel replaces the value with its type in brackets, such as "[CREDIT_CARD]"
+func TypeLabel() Masker {
+	return func(entity pii.PiiEntity) string {
+		return "[" + strings.ToUpper(entity.Type.String()) + "]"
+	}
+}
+
+// keepEnds masks the letters and digits of value except the first and last ones
+func keepEnds(value string, first, last int) string {
+	runes := []rune(value)
+	total := 0
+	for _, r := range runes {
+		if isMaskable(r) {
+			total++
+		}
+	}
+
+	index := 0
+	for i, r := range runes {
+		if !isMaskable(r) {
+			continue
+		}
+		if index >= first && index < total-last {
+			runes[i] = MaskRune
+		}
+		index++
+	}
+	return string(runes)
+}
+
+// isMaskable reports whether a character carries information and must be masked
+func isMaskable(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,214 @@
+package redact
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// AttestationVersion is the version of the attestation format
+const AttestationVersion = 1
+
+// Attestation is the machine-readable outcome of Verify: whether a redacted document
+// differs from its original only at the detected entity spans. It holds hashes, offsets
+// and replacements but none of the original values, so it can be archived with the
+// redacted document.
+type Attestation struct {
+	Version        int    `json:"version"`
+	OriginalSHA256 string `json:"original_sha256"`
+	RedactedSHA256 string `json:"redacted_sha256"`
+
+	// Verified is true when every change is a replacement of a detected span, no span was
+	// left with its original value and every entity was located
+	Verified bool `json:"verified"`
+
+	// Spans is the number of detected entity spans in the original
+	Spans int `json:"spans"`
+	// Replaced are the spans replaced in the redacted document, in original order
+	Replaced []SpanChange `json:"replaced,omitempty"`
+	// Unredacted are the spans left with their original value
+	Unredacted []SpanChange `json:"unredacted,omitempty"`
+	// Unlocated are the types of the entities with no span in the original, such as masked
+	// session tokens without recorded locations, whose redaction cannot be checked
+	Unlocated []pii.PiiType `json:"unlocated,omitempty"`
+	// Tampering describes the first change outside the detected spans, if any
+	Tampering *Tampering `json:"tampering,omitempty"`
+}
+
+// SpanChange is a detected entity span of the original and what replaced it
+type SpanChange struct {
+	Start       int         `json:"start"` // byte offsets in the original
+	End         int         `json:"end"`
+	Type        pii.PiiType `json:"type"`
+	Replacement string      `json:"replacement,omitempty"` // empty for unredacted spans
+}
+
+// Tampering locates the first content of the original that the redacted document does not
+// keep as is, outside the detected spans
+type Tampering struct {
+	Start   int    `json:"start"` // byte offsets in the original of the content that changed
+	End     int    `json:"end"`
+	Message string `json:"message"`
+}
+
+// Verify checks that redacted differs from original only at the spans of the result
+// entities in original, at the offsets recorded by the extractor (see Spans): the text
+// between spans must be kept verbatim and in order, while each span may be replaced by
+// anything but its original value. Adjacent spans are checked as one span. An entity with no
+// span in original fails the verification, since its value may be left anywhere.
+func Verify(original, redacted string, result *pii.PiiExtractionResult) *Attestation {
+	attestation := &Attestation{
+		Version:        AttestationVersion,
+		OriginalSHA256: sha256Hex(original),
+		RedactedSHA256: sha256Hex(redacted),
+	}
+	if result != nil {
+		for _, entity := range result.Entities {
+			if len(entity.OccurrencesIn(original)) == 0 {
+				attestation.Unlocated = append(attestation.Unlocated, entity.Type)
+			}
+		}
+	}
+
+	spans := mergeAdjacent(Spans(original, result))
+	attestation.Spans = len(spans)
+
+	// gaps[i] is the original text before spans[i]; the last gap follows the last span
+	gaps := make([]string, len(spans)+1)
+	offset := 0
+	for i, span := range spans {
+		gaps[i] = original[offset:span.Start]
+		offset = span.End
+	}
+	gaps[len(spans)] = original[offset:]
+
+	ends, ok := align(redacted, gaps)
+	if !ok {
+		attestation.Tampering = firstTampering(original, redacted, spans, gaps)
+		return attestation
+	}
+
+	position := len(gaps[0])
+	for i, span := range spans {
+		replacement := redacted[position:ends[i]]
+		change := SpanChange{Start: span.Start, End: span.End, Type: span.Entity.Type}
+		if replacement == original[span.Start:span.End] {
+			attestation.Unredacted = append(attestation.Unredacted, change)
+		} else {
+			change.Replacement = replacement
+			attestation.Replaced = append(attestation.Replaced, change)
+		}
+		position = ends[i] + len(gaps[i+1])
+	}
+	attestation.Verified = len(attestation.Unredacted) == 0 && len(attestation.Unlocated) == 0
+	return attestation
+}
+
+// mergeAdjacent merges spans with no text between them, whose replacements cannot be told
+// apart
+func mergeAdjacent(spans []pii.Occurrence) []pii.Occurrence {
+	var merged []pii.Occurrence
+	for _, span := range spans {
+		if n := len(merged); n > 0 && merged[n-1].End == span.Start {
+			merged[n-1].End = span.End
+			continue
+		}
+		merged = append(merged, span)
+	}
+	return merged
+}
+
+// align finds where each span replacement ends in redacted, such that redacted is gaps[0],
+// replacement 0, gaps[1], replacement 1, ..., gaps[n]. Replacements are tried shortest
+// first, backtracking when a later gap cannot be matched, since a mask may contain the text
+// of the next gap.
+func align(redacted string, gaps []string) ([]int, bool) {
+	if !strings.HasPrefix(redacted, gaps[0]) {
+		return nil, false
+	}
+	spans := len(gaps) - 1
+	ends := make([]int, spans)
+	failed := make(map[[2]int]bool)
+
+	var match func(i, position int) bool
+	match = func(i, position int) bool {
+		if i == spans {
+			return position == len(redacted)
+		}
+		if failed[[2]int{i, position}] {
+			return false
+		}
+		gap := gaps[i+1]
+		last := i+1 == spans
+		for from := position; from <= len(redacted); {
+			index := strings.Index(redacted[from:], gap)
+			if index < 0 {
+				break
+			}
+			end := from + index
+			if last {
+				// The trailing gap must end the document
+				end = len(redacted) - len(gap)
+				if end < position || redacted[end:] != gap {
+					break
+				}
+			}
+			ends[i] = end
+			if match(i+1, end+len(gap)) {
+				return true
+			}
+			if last {
+				break
+			}
+			from = end + 1
+		}
+		failed[[2]int{i, position}] = true
+		return false
+	}
+	return ends, match(0, len(gaps[0]))
+}
+
+// firstTampering locates the first gap of the original that the redacted document does not
+// keep, matching gaps greedily
+func firstTampering(original, redacted string, spans []pii.Occurrence, gaps []string) *Tampering {
+	start := 0
+	position := 0
+	for i, gap := range gaps {
+		if i > 0 {
+			start = spans[i-1].End
+		}
+		var index int
+		switch {
+		case i == 0:
+			index = -1
+			if strings.HasPrefix(redacted, gap) {
+				index = 0
+			}
+		case i == len(gaps)-1:
+			index = -1
+			if strings.HasSuffix(redacted[position:], gap) {
+				index = len(redacted) - len(gap) - position
+			}
+		default:
+			index = strings.Index(redacted[position:], gap)
+		}
+		if index < 0 {
+			return &Tampering{
+				Start:   start,
+				End:     start + len(gap),
+				Message: fmt.Sprintf("original bytes %d-%d outside detected spans are not kept as is", start, start+len(gap)),
+			}
+		}
+		position += index + len(gap)
+	}
+	return &Tampering{Start: len(original), End: len(original), Message: "content was added or reordered"}
+}
+
+// sha256Hex returns the hex SHA-256 of text
+func sha256Hex(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
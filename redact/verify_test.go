@@ -0,0 +1,103 @@
+package redact
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/intMeric/pii-extractor/extractors"
+	"github.com/intMeric/pii-extractor/extractors/regex"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+func TestVerify(t *testing.T) {
+	original := "Mail john@acme.com, SSN 123-45-6789 - thanks"
+	result := pii.NewPiiExtractionResult([]pii.PiiEntity{
+		{Type: pii.PiiTypeEmail, Value: pii.NewEmail("john@acme.com")},
+		{Type: pii.PiiTypeSSN, Value: pii.NewSSN("123-45-6789")},
+	})
+
+	// The email mask contains the text of the next gap, which must not confuse the alignment
+	attestation := Verify(original, "Mail [EMAIL, REDACTED], SSN ***-**-6789 - thanks", result)
+	if !attestation.Verified || attestation.Tampering != nil || len(attestation.Replaced) != 2 {
+		t.Fatalf("Expected a verified attestation, got %+v", attestation)
+	}
+	if replaced := attestation.Replaced[0]; replaced.Replacement != "[EMAIL, REDACTED]" || replaced.Start != 5 || replaced.Type != pii.PiiTypeEmail {
+		t.Errorf("Unexpected email replacement %+v", replaced)
+	}
+	if attestation.OriginalSHA256 == attestation.RedactedSHA256 || len(attestation.OriginalSHA256) != 64 {
+		t.Errorf("Unexpected hashes %+v", attestation)
+	}
+}
+
+func TestVerify_Tampering(t *testing.T) {
+	original := "Mail john@acme.com, pay 100 EUR"
+	result := pii.NewPiiExtractionResult([]pii.PiiEntity{{Type: pii.PiiTypeEmail, Value: pii.NewEmail("john@acme.com")}})
+
+	attestation := Verify(original, "Mail [EMAIL], pay 900 EUR", result)
+	if attestation.Verified || attestation.Tampering == nil {
+		t.Fatalf("Expected tampering, got %+v", attestation)
+	}
+	if attestation.Tampering.Start != 18 || attestation.Tampering.End != len(original) {
+		t.Errorf("Expected the tampering located after the email, got %+v", attestation.Tampering)
+	}
+}
+
+func TestVerify_Unredacted(t *testing.T) {
+	original := "SSN 123-45-6789 and 987-65-4321"
+	result := pii.NewPiiExtractionResult([]pii.PiiEntity{
+		{Type: pii.PiiTypeSSN, Value: pii.NewSSN("123-45-6789")},
+		{Type: pii.PiiTypeSSN, Value: pii.NewSSN("987-65-4321")},
+	})
+
+	attestation := Verify(original, "SSN [SSN] and 987-65-4321", result)
+	if attestation.Verified || attestation.Tampering != nil {
+		t.Fatalf("Expected an unverified attestation without tampering, got %+v", attestation)
+	}
+	if len(attestation.Unredacted) != 1 || attestation.Unredacted[0].Start != 20 || attestation.Unredacted[0].Replacement != "" {
+		t.Errorf("Expected the second SSN reported unredacted, got %+v", attestation.Unredacted)
+	}
+}
+
+func TestVerify_RedactorOutput(t *testing.T) {
+	original := "Card 4111111111111111 for jane@example.com"
+	result := pii.NewPiiExtractionResult([]pii.PiiEntity{
+		{Type: pii.PiiTypeCreditCard, Value: pii.NewCreditCard("4111111111111111", "visa")},
+		{Type: pii.PiiTypeEmail, Value: pii.NewEmail("jane@example.com")},
+	})
+	redacted := New(PresetStrict).Redact(original, result)
+
+	if attestation := Verify(original, redacted, result); !attestation.Verified {
+		t.Errorf("Expected the redactor output to verify, got %+v", attestation)
+	}
+}
+
+func TestVerify_SessionTokens(t *testing.T) {
+	original := "GET /app?sessionid=abcdef1234567890XYZ HTTP/1.1 from john@example.com"
+	result, err := regex.NewExtractor(&extractors.ExtractorConfig{
+		Options: map[string]any{regex.OptionHTTPLogs: true},
+	}).Extract(original)
+	if err != nil {
+		t.Fatalf("Extract() error: %v", err)
+	}
+
+	// Redacting only the email leaves the token, which Verify must see at its location
+	leaked := "GET /app?sessionid=abcdef1234567890XYZ HTTP/1.1 from [EMAIL]"
+	attestation := Verify(original, leaked, result)
+	if attestation.Verified || len(attestation.Unredacted) != 1 || attestation.Unredacted[0].Type != pii.PiiTypeSessionToken {
+		t.Errorf("Expected the session token reported unredacted, got %+v", attestation)
+	}
+
+	redacted := New(PresetStrict).Redact(original, result)
+	if attestation := Verify(original, redacted, result); !attestation.Verified || attestation.Spans != 2 {
+		t.Errorf("Expected a verified attestation with 2 spans, got %+v", attestation)
+	}
+
+	// Without locations, the masked token cannot be found in the original
+	for i, entity := range result.Entities {
+		result.Entities[i] = entity.WithLocations(nil)
+	}
+	attestation = Verify(original, redacted, result)
+	if attestation.Verified || !slices.Equal(attestation.Unlocated, []pii.PiiType{pii.PiiTypeSessionToken}) {
+		t.Errorf("Expected the unlocated session token to fail the verification, got %+v", attestation)
+	}
+}
@@ -0,0 +1,271 @@
+// Package redis shares the state of a horizontally scaled fleet of scanners through
+// Redis: the LLM validation cache (llm.ResponseCache), so that a value validated by one
+// instance is not sent to the provider again by another, and the cross-document dedup
+// store (dedup.Store).
+//
+// The client speaks the Redis protocol (RESP2) over TCP or TLS with a small connection
+// pool, without third-party dependencies. It works with Redis, Valkey, KeyDB and managed
+// services such as ElastiCache or Memorystore, but not with Redis Cluster.
+package redis
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultTimeout bounds each command when the caller gives no deadline, such as the
+// llm.ResponseCache methods
+const DefaultTimeout = time.Second
+
+// DefaultPoolSize is the default number of idle connections kept open
+const DefaultPoolSize = 8
+
+// DefaultPrefix namespaces the keys written by the client
+const DefaultPrefix = "pii-extractor:"
+
+// ErrClosed is returned by commands after Close
+var ErrClosed = errors.New("redis: client closed")
+
+// Error is an error reply of the server, such as "WRONGPASS invalid username-password pair"
+type Error string
+
+func (e Error) Error() string {
+	return "redis: " + string(e)
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithAuth authenticates connections with AUTH. An empty username uses the password
+// alone, for servers without ACLs.
+func WithAuth(username, password string) Option {
+	return func(c *Client) {
+		c.username, c.password = username, password
+	}
+}
+
+// WithDB selects a logical database other than 0
+func WithDB(db int) Option {
+	return func(c *Client) {
+		c.db = db
+	}
+}
+
+// WithTLS connects over TLS, as managed services with in-transit encryption require
+func WithTLS(config *tls.Config) Option {
+	return func(c *Client) {
+		c.tls = config
+	}
+}
+
+// WithPrefix sets the prefix of every key (DefaultPrefix by default), so that several
+// fleets or environments can share one server
+func WithPrefix(prefix string) Option {
+	return func(c *Client) {
+		c.prefix = prefix
+	}
+}
+
+// WithTimeout sets the timeout of commands run without a context deadline (DefaultTimeout
+// by default)
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// WithPoolSize sets the number of idle connections kept open (DefaultPoolSize by default)
+func WithPoolSize(size int) Option {
+	return func(c *Client) {
+		c.poolSize = max(size, 1)
+	}
+}
+
+// Client runs commands on a Redis server. It is safe for concurrent use. Connections are
+// opened on demand, so creating a client never fails on an unreachable server; call Ping
+// to check it.
+type Client struct {
+	addr     string
+	username string
+	password string
+	db       int
+	tls      *tls.Config
+	prefix   string
+	timeout  time.Duration
+	poolSize int
+
+	mu     sync.Mutex
+	idle   []*conn
+	closed bool
+}
+
+// New creates a client of the server at addr, such as "localhost:6379"
+func New(addr string, opts ...Option) *Client {
+	c := &Client{addr: addr, prefix: DefaultPrefix, timeout: DefaultTimeout, poolSize: DefaultPoolSize}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// NewFromURL creates a client from a redis:// or rediss:// (TLS) URL, such as
+// "redis://:password@localhost:6379/2". Options apply after the URL.
+func NewFromURL(rawURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("redis: invalid URL: %w", err)
+	}
+	var urlOpts []Option
+	switch u.Scheme {
+	case "redis":
+	case "rediss":
+		urlOpts = append(urlOpts, WithTLS(&tls.Config{ServerName: u.Hostname()}))
+	default:
+		return nil, fmt.Errorf("redis: unsupported URL scheme %q", u.Scheme)
+	}
+	if u.User != nil {
+		password, _ := u.User.Password()
+		urlOpts = append(urlOpts, WithAuth(u.User.Username(), password))
+	}
+	if path := strings.Trim(u.Path, "/"); path != "" {
+		db, err := strconv.Atoi(path)
+		if err != nil {
+			return nil, fmt.Errorf("redis: invalid database %q", path)
+		}
+		urlOpts = append(urlOpts, WithDB(db))
+	}
+	addr := u.Host
+	if u.Port() == "" {
+		addr = net.JoinHostPort(u.Hostname(), "6379")
+	}
+	return New(addr, append(urlOpts, opts...)...), nil
+}
+
+// Ping checks that the server answers
+func (c *Client) Ping(ctx context.Context) error {
+	_, err := c.Do(ctx, "PING")
+	return err
+}
+
+// Do runs a command and returns its reply: a string for simple and bulk strings, an
+// int64 for integers, a []any for arrays and nil for null replies. Error replies are
+// returned as Error. Keys are passed as is, without the client prefix.
+func (c *Client) Do(ctx context.Context, args ...string) (any, error) {
+	if _, ok := ctx.Deadline(); !ok && c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	cn, err := c.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reply, err := cn.do(ctx, args)
+	var replyErr Error
+	if err != nil && !errors.As(err, &replyErr) {
+		// The connection state is unknown after an I/O error
+		cn.Close()
+		return nil, err
+	}
+	c.put(cn)
+	return reply, err
+}
+
+// Close closes the idle connections. Commands in flight complete, then their connection
+// is closed.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	idle := c.idle
+	c.idle, c.closed = nil, true
+	c.mu.Unlock()
+
+	for _, cn := range idle {
+		cn.Close()
+	}
+	return nil
+}
+
+// key returns the prefixed key of a namespace
+func (c *Client) key(namespace, key string) string {
+	return c.prefix + namespace + ":" + key
+}
+
+// get returns an idle connection or dials a new one
+func (c *Client) get(ctx context.Context) (*conn, error) {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil, ErrClosed
+	}
+	if n := len(c.idle); n > 0 {
+		cn := c.idle[n-1]
+		c.idle = c.idle[:n-1]
+		c.mu.Unlock()
+		return cn, nil
+	}
+	c.mu.Unlock()
+	return c.dial(ctx)
+}
+
+// put returns a connection to the pool, closing it when the pool is full
+func (c *Client) put(cn *conn) {
+	c.mu.Lock()
+	if !c.closed && len(c.idle) < c.poolSize {
+		c.idle = append(c.idle, cn)
+		cn = nil
+	}
+	c.mu.Unlock()
+	if cn != nil {
+		cn.Close()
+	}
+}
+
+// dial opens an authenticated connection on the selected database
+func (c *Client) dial(ctx context.Context) (*conn, error) {
+	var dialer net.Dialer
+	netConn, err := dialer.DialContext(ctx, "tcp", c.addr)
+	if err != nil {
+		return nil, err
+	}
+	if c.tls != nil {
+		config := c.tls.Clone()
+		if config.ServerName == "" {
+			config.ServerName, _, _ = net.SplitHostPort(c.addr)
+		}
+		tlsConn := tls.Client(netConn, config)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			netConn.Close()
+			return nil, err
+		}
+		netConn = tlsConn
+	}
+
+	cn := &conn{Conn: netConn, reader: bufio.NewReader(netConn)}
+	var setup [][]string
+	switch {
+	case c.username != "":
+		setup = append(setup, []string{"AUTH", c.username, c.password})
+	case c.password != "":
+		setup = append(setup, []string{"AUTH", c.password})
+	}
+	if c.db != 0 {
+		setup = append(setup, []string{"SELECT", strconv.Itoa(c.db)})
+	}
+	for _, args := range setup {
+		if _, err := cn.do(ctx, args); err != nil {
+			cn.Close()
+			return nil, err
+		}
+	}
+	return cn, nil
+}
@@ -0,0 +1,150 @@
+package redis
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"time"
+)
+
+// conn is a connection to the server
+type conn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+// do writes a command and reads its reply, within the deadline of the context
+func (c *conn) do(ctx context.Context, args []string) (any, error) {
+	deadline, _ := ctx.Deadline()
+	if err := c.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+	// Unblock the I/O when the context is cancelled before its deadline
+	stop := context.AfterFunc(ctx, func() {
+		c.SetDeadline(time.Unix(1, 0))
+	})
+	defer stop()
+
+	if _, err := c.Write(encodeCommand(args)); err != nil {
+		return nil, contextError(ctx, err)
+	}
+	reply, err := readReply(c.reader)
+	if _, ok := err.(Error); !ok && err != nil {
+		return nil, contextError(ctx, err)
+	}
+	return reply, err
+}
+
+// contextError returns the context error when the context ended the I/O
+func contextError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+// encodeCommand encodes a command as an array of bulk strings
+func encodeCommand(args []string) []byte {
+	buf := make([]byte, 0, 64)
+	buf = append(buf, '*')
+	buf = strconv.AppendInt(buf, int64(len(args)), 10)
+	buf = append(buf, '\r', '\n')
+	for _, arg := range args {
+		buf = append(buf, '$')
+		buf = strconv.AppendInt(buf, int64(len(arg)), 10)
+		buf = append(buf, '\r', '\n')
+		buf = append(buf, arg...)
+		buf = append(buf, '\r', '\n')
+	}
+	return buf
+}
+
+// Largest replies read, so that a faulty or hostile server cannot make the client
+// allocate without bound. Cached LLM responses and dedup replies are far smaller.
+const (
+	MaxBulkLength  = 64 << 20 // bytes of a bulk string
+	MaxArrayLength = 1 << 20  // items of an array
+)
+
+// readReply reads one reply. An error reply is returned as Error, after the whole reply
+// was read so that the connection can be reused.
+func readReply(r *bufio.Reader) (any, error) {
+	line, err := readLine(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(line) == 0 {
+		return nil, fmt.Errorf("redis: empty reply line")
+	}
+	payload := string(line[1:])
+	switch line[0] {
+	case '+':
+		return payload, nil
+	case '-':
+		return nil, Error(payload)
+	case ':':
+		n, err := strconv.ParseInt(payload, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("redis: invalid integer reply %q", payload)
+		}
+		return n, nil
+	case '$':
+		n, err := strconv.Atoi(payload)
+		if err != nil || n < -1 {
+			return nil, fmt.Errorf("redis: invalid bulk length %q", payload)
+		}
+		if n > MaxBulkLength {
+			return nil, fmt.Errorf("redis: bulk reply of %d bytes exceeds %d", n, MaxBulkLength)
+		}
+		if n == -1 {
+			return nil, nil
+		}
+		data := make([]byte, n+2)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, err
+		}
+		return string(data[:n]), nil
+	case '*':
+		n, err := strconv.Atoi(payload)
+		if err != nil || n < -1 {
+			return nil, fmt.Errorf("redis: invalid array length %q", payload)
+		}
+		if n > MaxArrayLength {
+			return nil, fmt.Errorf("redis: array reply of %d items exceeds %d", n, MaxArrayLength)
+		}
+		if n == -1 {
+			return nil, nil
+		}
+		items := make([]any, n)
+		var replyErr error
+		for i := range items {
+			item, err := readReply(r)
+			if _, ok := err.(Error); ok {
+				replyErr = err
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			items[i] = item
+		}
+		return items, replyErr
+	default:
+		return nil, fmt.Errorf("redis: unexpected reply type %q", line[0])
+	}
+}
+
+// readLine reads a line without its CRLF
+func readLine(r *bufio.Reader) ([]byte, error) {
+	line, err := r.ReadSlice('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 2 || line[len(line)-2] != '\r' {
+		return nil, fmt.Errorf("redis: malformed reply line %q", line)
+	}
+	return line[:len(line)-2], nil
+}
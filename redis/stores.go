@@ -0,0 +1,46 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+	"time"
+)
+
+// Key namespaces of the stores, after the client prefix
+const (
+	CacheNamespace = "cache"
+	DedupNamespace = "seen"
+)
+
+// Get implements llm.ResponseCache. Errors, such as an unreachable server, are treated as
+// misses so that validation goes on without the cache.
+func (c *Client) Get(key string) (string, bool) {
+	reply, err := c.Do(context.Background(), "GET", c.key(CacheNamespace, key))
+	response, ok := reply.(string)
+	return response, err == nil && ok
+}
+
+// Set implements llm.ResponseCache. Write failures are ignored: the response is simply
+// generated again next time.
+func (c *Client) Set(key, response string, ttl time.Duration) {
+	c.Do(context.Background(), withTTL([]string{"SET", c.key(CacheNamespace, key), response}, ttl)...)
+}
+
+// Add implements dedup.Store with an atomic SET NX, so that exactly one instance of the
+// fleet reports each key
+func (c *Client) Add(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	reply, err := c.Do(ctx, withTTL([]string{"SET", c.key(DedupNamespace, key), "1", "NX"}, ttl)...)
+	if err != nil {
+		return false, err
+	}
+	return reply != nil, nil
+}
+
+// withTTL appends the expiry of a SET command, rounded up to the millisecond
+func withTTL(args []string, ttl time.Duration) []string {
+	if ttl <= 0 {
+		return args
+	}
+	ms := (ttl + time.Millisecond - 1) / time.Millisecond
+	return append(args, "PX", strconv.FormatInt(int64(ms), 10))
+}
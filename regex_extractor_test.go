@@ -1,7 +1,10 @@
 package piiextractor
 
 import (
+	"slices"
 	"testing"
+
+	"github.com/intMeric/pii-extractor/pii"
 )
 
 func TestRegexExtractor_Extract(t *testing.T) {
@@ -97,3 +100,27 @@ func TestRegexExtractor_TypeAssertions(t *testing.T) {
 		}
 	}
 }
+
+func TestSupportMatrix(t *testing.T) {
+	matrix := SupportMatrix()
+	methods := func(piiType PiiType, country Country) []ExtractionMethod {
+		for _, cell := range matrix {
+			if cell.Type == piiType && cell.Country == country {
+				return cell.Methods
+			}
+		}
+		return nil
+	}
+
+	if got := methods(PiiTypeSSN, CountryUS); !slices.Equal(got, []ExtractionMethod{MethodHybrid, MethodRegex}) {
+		t.Errorf("US SSN methods = %v", got)
+	}
+	if got := methods(PiiTypeEmail, ""); !slices.Equal(got, []ExtractionMethod{MethodHybrid, MethodLLM, MethodRegex}) {
+		t.Errorf("email methods = %v", got)
+	}
+	for _, country := range pii.SupportedCountries() {
+		if methods(PiiTypeZipCode, country) == nil {
+			t.Errorf("no postal code support for %s", country)
+		}
+	}
+}
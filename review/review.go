@@ -0,0 +1,286 @@
+// Package review selects the extraction findings most worth a human review: uncertain
+// validations, disagreement between ensemble members and values with unusual shapes.
+// Reviewed labels can then feed pattern fixes or model training.
+package review
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"sort"
+	"sync"
+	"unicode"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// Reason explains why a finding was selected for review
+type Reason string
+
+const (
+	ReasonLowConfidence Reason = "low_confidence" // Validation confidence close to the decision boundary
+	ReasonDisagreement  Reason = "disagreement"   // Only some ensemble members found the value
+	ReasonNovelPattern  Reason = "novel_pattern"  // Value shape rarely seen for its type
+)
+
+// Config controls how findings are scored
+type Config struct {
+	// ConfidenceThreshold is the validation confidence below which a finding is uncertain
+	ConfidenceThreshold float64
+	// NovelShapeMaxCount is the number of times a value shape may be seen and still be novel
+	NovelShapeMaxCount int
+	// NoveltyWeight scales the novelty score so rare shapes rank below uncertain findings
+	NoveltyWeight float64
+}
+
+// DefaultConfig returns the default selector configuration
+func DefaultConfig() *Config {
+	return &Config{
+		ConfidenceThreshold: 0.8,
+		NovelShapeMaxCount:  1,
+		NoveltyWeight:       0.5,
+	}
+}
+
+// Candidate is a finding queued for human review
+type Candidate struct {
+	Document string             `json:"document"`
+	Type     pii.PiiType        `json:"type"`
+	Value    string             `json:"value"`
+	Context  string             `json:"context,omitempty"`
+	Shape    string             `json:"shape"`
+	Score    float64            `json:"score"`
+	Reasons  []Reason           `json:"reasons"`
+	Found    []string           `json:"found_by,omitempty"`  // Ensemble members that found the value
+	Missed   []string           `json:"missed_by,omitempty"` // Ensemble members that did not
+	Details  map[Reason]float64 `json:"reason_scores"`       // Score contributed by each reason
+}
+
+// observation is a finding recorded by the selector
+type observation struct {
+	document   string
+	entity     pii.PiiEntity
+	shape      string
+	found      []string
+	missed     []string
+	confidence float64 // Lowest validation confidence, or -1 when unvalidated
+}
+
+// Selector accumulates findings across documents and ranks them for review
+type Selector struct {
+	config       *Config
+	observations map[string]*observation
+	order        []string
+	shapes       map[pii.PiiType]map[string]int
+	mu           sync.Mutex
+}
+
+// NewSelector creates a selector. A nil config uses DefaultConfig.
+func NewSelector(config *Config) *Selector {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	return &Selector{
+		config:       config,
+		observations: make(map[string]*observation),
+		shapes:       make(map[pii.PiiType]map[string]int),
+	}
+}
+
+// AddResult records the findings of a document
+func (s *Selector) AddResult(document string, result *pii.PiiExtractionResult) {
+	if result == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, entity := range result.Entities {
+		s.observe(document, entity)
+	}
+}
+
+// AddMembers records the findings of each ensemble member on a document, keyed by member
+// name. A value found by some members but not others is a disagreement.
+func (s *Selector) AddMembers(document string, members map[string]*pii.PiiExtractionResult) {
+	names := make([]string, 0, len(members))
+	for name := range members {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	foundBy := make(map[string]map[string]bool)
+	for _, name := range names {
+		result := members[name]
+		if result == nil {
+			continue
+		}
+		for _, entity := range result.Entities {
+			obs := s.observe(document, entity)
+			key := entityKey(entity)
+			if foundBy[key] == nil {
+				foundBy[key] = make(map[string]bool)
+			}
+			if !foundBy[key][name] {
+				foundBy[key][name] = true
+				obs.found = append(obs.found, name)
+			}
+		}
+	}
+
+	for key, found := range foundBy {
+		obs := s.observations[document+"\x00"+key]
+		for _, name := range names {
+			if !found[name] {
+				obs.missed = append(obs.missed, name)
+			}
+		}
+	}
+}
+
+// observe records an entity once per document and returns its observation
+func (s *Selector) observe(document string, entity pii.PiiEntity) *observation {
+	key := document + "\x00" + entityKey(entity)
+	if obs, exists := s.observations[key]; exists {
+		if confidence := validConfidence(entity); confidence >= 0 && (obs.confidence < 0 || confidence < obs.confidence) {
+			obs.confidence = confidence
+		}
+		return obs
+	}
+
+	obs := &observation{
+		document:   document,
+		entity:     entity,
+		shape:      Shape(entity.GetValue()),
+		confidence: validConfidence(entity),
+	}
+	s.observations[key] = obs
+	s.order = append(s.order, key)
+
+	if s.shapes[entity.Type] == nil {
+		s.shapes[entity.Type] = make(map[string]int)
+	}
+	s.shapes[entity.Type][obs.shape]++
+	return obs
+}
+
+// Select returns up to limit candidates ranked by decreasing score. A limit of zero or
+// less returns every candidate. Findings with no review reason are not returned.
+func (s *Selector) Select(limit int) []Candidate {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var candidates []Candidate
+	for _, key := range s.order {
+		if candidate, ok := s.score(s.observations[key]); ok {
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+	if limit > 0 && len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+	return candidates
+}
+
+// score computes the review score of an observation
+func (s *Selector) score(obs *observation) (Candidate, bool) {
+	candidate := Candidate{
+		Document: obs.document,
+		Type:     obs.entity.Type,
+		Value:    obs.entity.GetValue(),
+		Shape:    obs.shape,
+		Found:    obs.found,
+		Missed:   obs.missed,
+		Details:  make(map[Reason]float64),
+	}
+	if contexts := obs.entity.GetContexts(); len(contexts) > 0 {
+		candidate.Context = contexts[0]
+	}
+
+	add := func(reason Reason, score float64) {
+		candidate.Reasons = append(candidate.Reasons, reason)
+		candidate.Details[reason] = score
+		candidate.Score += score
+	}
+
+	// Uncertainty sampling: the less confident the validator, the more informative the label
+	if obs.confidence >= 0 && obs.confidence < s.config.ConfidenceThreshold {
+		add(ReasonLowConfidence, 1-obs.confidence)
+	}
+
+	// Vote disagreement: highest when members are evenly split
+	if len(obs.missed) > 0 {
+		agreement := float64(len(obs.found)) / float64(len(obs.found)+len(obs.missed))
+		add(ReasonDisagreement, 1-math.Abs(2*agreement-1))
+	}
+
+	if count := s.shapes[obs.entity.Type][obs.shape]; count <= s.config.NovelShapeMaxCount {
+		add(ReasonNovelPattern, s.config.NoveltyWeight/float64(count))
+	}
+
+	return candidate, len(candidate.Reasons) > 0
+}
+
+// Reset discards all recorded findings
+func (s *Selector) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.observations = make(map[string]*observation)
+	s.order = nil
+	s.shapes = make(map[pii.PiiType]map[string]int)
+}
+
+// WriteQueue writes candidates as JSONL, one review item per line
+func WriteQueue(w io.Writer, candidates []Candidate) error {
+	encoder := json.NewEncoder(w)
+	for _, candidate := range candidates {
+		if err := encoder.Encode(candidate); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Shape abstracts a value into its character classes, collapsing runs so values of the same
+// format share a shape: "john@acme.com" becomes "a@a.a" and "555-123-4567" becomes "9-9-9".
+func Shape(value string) string {
+	shape := make([]rune, 0, len(value))
+	for _, r := range value {
+		switch {
+		case unicode.IsDigit(r):
+			r = '9'
+		case unicode.IsUpper(r):
+			r = 'A'
+		case unicode.IsLetter(r):
+			r = 'a'
+		}
+		if len(shape) > 0 && shape[len(shape)-1] == r && (r == '9' || r == 'A' || r == 'a') {
+			continue
+		}
+		shape = append(shape, r)
+	}
+	return string(shape)
+}
+
+// validConfidence returns the validation confidence of an entity, or -1 when the entity was
+// not validated
+func validConfidence(entity pii.PiiEntity) float64 {
+	if entity.Validation == nil {
+		return -1
+	}
+	return entity.Validation.Confidence
+}
+
+// entityKey creates a unique key for an entity based on type and value
+func entityKey(entity pii.PiiEntity) string {
+	return entity.Type.String() + ":" + entity.GetValue()
+}
@@ -0,0 +1,99 @@
+package review
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+func email(value string, validation *pii.ValidationResult) pii.PiiEntity {
+	return pii.PiiEntity{Type: pii.PiiTypeEmail, Value: pii.NewEmail(value), Validation: validation}
+}
+
+func TestShape(t *testing.T) {
+	tests := map[string]string{
+		"john@acme.com":  "a@a.a",
+		"555-123-4567":   "9-9-9",
+		"SW1A 1AA":       "A9A 9A",
+		"(555) 123-4567": "(9) 9-9",
+	}
+	for value, want := range tests {
+		if got := Shape(value); got != want {
+			t.Errorf("Shape(%q) = %q, want %q", value, got, want)
+		}
+	}
+}
+
+func TestSelector_RanksUncertainFindings(t *testing.T) {
+	selector := NewSelector(nil)
+	selector.AddResult("doc-1", pii.NewPiiExtractionResult([]pii.PiiEntity{
+		email("sure@acme.com", &pii.ValidationResult{Valid: true, Confidence: 0.95}),
+		email("maybe@acme.com", &pii.ValidationResult{Valid: true, Confidence: 0.55}),
+		email("doubt@acme.com", &pii.ValidationResult{Valid: false, Confidence: 0.7}),
+	}))
+
+	candidates := selector.Select(0)
+	if len(candidates) != 2 {
+		t.Fatalf("Expected 2 uncertain candidates, got %+v", candidates)
+	}
+	if candidates[0].Value != "maybe@acme.com" || candidates[1].Value != "doubt@acme.com" {
+		t.Errorf("Expected least confident first, got %s then %s", candidates[0].Value, candidates[1].Value)
+	}
+	if candidates[0].Reasons[0] != ReasonLowConfidence {
+		t.Errorf("Expected low_confidence reason, got %v", candidates[0].Reasons)
+	}
+}
+
+func TestSelector_Disagreement(t *testing.T) {
+	shared := email("a@acme.com", nil)
+	selector := NewSelector(nil)
+	selector.AddMembers("doc-1", map[string]*pii.PiiExtractionResult{
+		"regex": pii.NewPiiExtractionResult([]pii.PiiEntity{shared, email("b@acme.com", nil)}),
+		"llm":   pii.NewPiiExtractionResult([]pii.PiiEntity{shared}),
+	})
+
+	candidates := selector.Select(0)
+	if len(candidates) != 1 {
+		t.Fatalf("Expected only the disputed value, got %+v", candidates)
+	}
+	candidate := candidates[0]
+	if candidate.Value != "b@acme.com" || candidate.Details[ReasonDisagreement] != 1 {
+		t.Errorf("Expected an evenly split disagreement on b@acme.com, got %+v", candidate)
+	}
+	if len(candidate.Found) != 1 || candidate.Found[0] != "regex" || len(candidate.Missed) != 1 || candidate.Missed[0] != "llm" {
+		t.Errorf("Unexpected members found=%v missed=%v", candidate.Found, candidate.Missed)
+	}
+}
+
+func TestSelector_NovelPattern(t *testing.T) {
+	selector := NewSelector(nil)
+	var entities []pii.PiiEntity
+	for _, value := range []string{"a@acme.com", "b@acme.com", "c@acme.com", "d.e@acme.co.uk"} {
+		entities = append(entities, email(value, nil))
+	}
+	selector.AddResult("doc-1", pii.NewPiiExtractionResult(entities))
+
+	candidates := selector.Select(1)
+	if len(candidates) != 1 || candidates[0].Value != "d.e@acme.co.uk" || candidates[0].Reasons[0] != ReasonNovelPattern {
+		t.Errorf("Expected the rare shape to be selected, got %+v", candidates)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteQueue(&buf, candidates); err != nil {
+		t.Fatalf("WriteQueue() error = %v", err)
+	}
+	var decoded Candidate
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if decoded.Value != candidates[0].Value {
+		t.Errorf("Queue round trip changed value: %s", decoded.Value)
+	}
+
+	selector.Reset()
+	if len(selector.Select(0)) != 0 {
+		t.Error("Expected no candidates after Reset")
+	}
+}
@@ -0,0 +1,387 @@
+// Package elastic scans the documents of an Elasticsearch or OpenSearch index for PII,
+// paging through the index with search_after, and can write a redacted copy of every
+// document to another index. Progress is checkpointed to a file so an interrupted scan
+// resumes where it stopped.
+package elastic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/intMeric/pii-extractor/extractors"
+	"github.com/intMeric/pii-extractor/extractors/regex"
+	"github.com/intMeric/pii-extractor/pii"
+	"github.com/intMeric/pii-extractor/redact"
+)
+
+// DefaultBatchSize is the number of documents fetched per page when Options.BatchSize is zero
+const DefaultBatchSize = 500
+
+// Unscanned replaces, in the redacted copies, the value of the fields whose extraction
+// failed
+const Unscanned = "[UNSCANNED]"
+
+// Client sends requests to an Elasticsearch or OpenSearch cluster
+type Client struct {
+	// URL of the cluster, e.g. "https://localhost:9200"
+	URL string
+
+	// Basic authentication, or an API key sent as "Authorization: ApiKey <key>"
+	Username string
+	Password string
+	APIKey   string
+
+	// HTTP sends the requests; nil uses http.DefaultClient
+	HTTP *http.Client
+}
+
+// Options configures an index scan
+type Options struct {
+	// Fields lists the document fields to extract, as dot-separated paths such as
+	// "message" or "user.comment". String values and arrays of strings are scanned.
+	Fields []string
+
+	// Query restricts the scanned documents (nil = match_all)
+	Query map[string]any
+
+	// Sort is the field documents are paged by with search_after. It must hold a unique,
+	// sortable value per document (default "_id"; Elasticsearch 8 requires enabling
+	// indices.id_field_data or using a keyword copy of the id).
+	Sort string
+
+	// BatchSize is the number of documents per page (0 = DefaultBatchSize)
+	BatchSize int
+
+	// Extractor runs on every field value; nil uses the default regex extractor
+	Extractor extractors.PiiExtractor
+
+	// RedactTo is the index receiving a copy of every scanned document with the PII of
+	// Fields masked by Redactor, under the same id (empty = no copy)
+	RedactTo string
+	// Redactor masks the copied documents; nil uses redact.PresetStrict
+	Redactor *redact.Redactor
+
+	// CheckpointFile stores the scan position after every page. An existing checkpoint
+	// is resumed; the file is removed once the scan completes (empty = no checkpoint).
+	CheckpointFile string
+
+	// OnDocument is called with the findings of every document holding PII
+	OnDocument func(id string, results map[string]*pii.PiiExtractionResult)
+}
+
+// FieldStats is the PII distribution of one field
+type FieldStats struct {
+	Documents int                 `json:"documents"` // Documents where the field holds text
+	WithPII   int                 `json:"with_pii"`  // Documents where PII was found in the field
+	Failed    int                 `json:"failed"`    // Documents where the extraction of the field failed
+	Types     map[pii.PiiType]int `json:"types"`     // Documents containing each type in the field
+}
+
+// Report is the outcome of an index scan, including the documents of resumed runs
+type Report struct {
+	Index            string                 `json:"index"`
+	Documents        int                    `json:"documents"`
+	DocumentsWithPII int                    `json:"documents_with_pii"`
+	Redacted         int                    `json:"redacted"` // Documents written to RedactTo
+	Fields           map[string]*FieldStats `json:"fields"`
+	Duration         time.Duration          `json:"duration"`
+}
+
+// Checkpoint is the saved position of an interrupted scan
+type Checkpoint struct {
+	Index       string  `json:"index"`
+	SearchAfter []any   `json:"search_after"`
+	Report      *Report `json:"report"`
+}
+
+// hit is a document returned by a search
+type hit struct {
+	ID     string         `json:"_id"`
+	Source map[string]any `json:"_source"`
+	Sort   []any          `json:"sort"`
+}
+
+// Scan pages through the documents of an index and extracts the PII of the selected fields
+func (c *Client) Scan(ctx context.Context, index string, opts Options) (*Report, error) {
+	start := time.Now()
+	if len(opts.Fields) == 0 {
+		return nil, errors.New("no fields to scan")
+	}
+	if opts.Sort == "" {
+		opts.Sort = "_id"
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultBatchSize
+	}
+	if opts.Extractor == nil {
+		opts.Extractor = regex.NewDefaultExtractor()
+	}
+	if opts.Redactor == nil {
+		opts.Redactor = redact.New(redact.PresetStrict)
+	}
+
+	checkpoint, err := loadCheckpoint(opts.CheckpointFile, index)
+	if err != nil {
+		return nil, err
+	}
+	report := checkpoint.Report
+	previous := report.Duration
+
+	for {
+		hits, err := c.search(ctx, index, opts, checkpoint.SearchAfter)
+		if err != nil {
+			return nil, err
+		}
+		if len(hits) == 0 {
+			break
+		}
+
+		var bulk bytes.Buffer
+		for _, hit := range hits {
+			results, failed := scanDocument(ctx, hit, opts, report)
+			if results == nil {
+				return nil, ctx.Err()
+			}
+			if opts.RedactTo != "" {
+				redactDocument(hit.Source, results, failed, opts.Redactor)
+				if err := appendBulk(&bulk, opts.RedactTo, hit); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if bulk.Len() > 0 {
+			if err := c.bulk(ctx, &bulk); err != nil {
+				return nil, err
+			}
+			report.Redacted += len(hits)
+		}
+
+		// Checkpoint only once the redacted copies of the page are written
+		checkpoint.SearchAfter = hits[len(hits)-1].Sort
+		report.Duration = previous + time.Since(start)
+		if err := saveCheckpoint(opts.CheckpointFile, checkpoint); err != nil {
+			return nil, err
+		}
+		if len(hits) < opts.BatchSize {
+			break
+		}
+	}
+
+	report.Duration = previous + time.Since(start)
+	if opts.CheckpointFile != "" {
+		if err := os.Remove(opts.CheckpointFile); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+// scanDocument extracts the fields of a document and updates the report. It returns the
+// results per field and the fields whose extraction failed, or nil results when ctx is
+// done.
+func scanDocument(ctx context.Context, hit hit, opts Options, report *Report) (map[string]*pii.PiiExtractionResult, []string) {
+	results := make(map[string]*pii.PiiExtractionResult)
+	var failed []string
+	report.Documents++
+	withPII := false
+	for _, field := range opts.Fields {
+		text, ok := fieldText(hit.Source, field)
+		if !ok {
+			continue
+		}
+		stats := report.Fields[field]
+		if stats == nil {
+			stats = &FieldStats{Types: make(map[pii.PiiType]int)}
+			report.Fields[field] = stats
+		}
+		stats.Documents++
+
+		result, err := extractors.ExtractWithContext(ctx, opts.Extractor, text)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, nil
+			}
+			// A failing field does not abort the whole index, but is masked whole in the
+			// redacted copy
+			stats.Failed++
+			failed = append(failed, field)
+			continue
+		}
+		results[field] = result
+		if result.IsEmpty() {
+			continue
+		}
+		withPII = true
+		stats.WithPII++
+		for piiType := range result.Stats {
+			stats.Types[piiType]++
+		}
+	}
+	if withPII {
+		report.DocumentsWithPII++
+		if opts.OnDocument != nil {
+			opts.OnDocument(hit.ID, results)
+		}
+	}
+	return results, failed
+}
+
+// search fetches the page of documents following searchAfter
+func (c *Client) search(ctx context.Context, index string, opts Options, searchAfter []any) ([]hit, error) {
+	request := map[string]any{
+		"size": opts.BatchSize,
+		"sort": []any{map[string]string{opts.Sort: "asc"}},
+	}
+	if opts.Query != nil {
+		request["query"] = opts.Query
+	}
+	if opts.RedactTo == "" {
+		// Only the scanned fields are needed when no copy is written
+		request["_source"] = opts.Fields
+	}
+	if searchAfter != nil {
+		request["search_after"] = searchAfter
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Hits struct {
+			Hits []hit `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := c.do(ctx, "/"+url.PathEscape(index)+"/_search", "application/json", bytes.NewReader(body), &response); err != nil {
+		return nil, err
+	}
+	return response.Hits.Hits, nil
+}
+
+// appendBulk appends an index action for a document to a bulk request body
+func appendBulk(bulk *bytes.Buffer, index string, hit hit) error {
+	action, err := json.Marshal(map[string]any{"index": map[string]string{"_index": index, "_id": hit.ID}})
+	if err != nil {
+		return err
+	}
+	source, err := json.Marshal(hit.Source)
+	if err != nil {
+		return err
+	}
+	bulk.Write(action)
+	bulk.WriteByte('\n')
+	bulk.Write(source)
+	bulk.WriteByte('\n')
+	return nil
+}
+
+// bulk sends a bulk request and fails if any of its actions failed
+func (c *Client) bulk(ctx context.Context, body io.Reader) error {
+	var response struct {
+		Errors bool `json:"errors"`
+		Items  []map[string]struct {
+			ID    string          `json:"_id"`
+			Error json.RawMessage `json:"error"`
+		} `json:"items"`
+	}
+	if err := c.do(ctx, "/_bulk", "application/x-ndjson", body, &response); err != nil {
+		return err
+	}
+	if response.Errors {
+		for _, item := range response.Items {
+			for _, result := range item {
+				if len(result.Error) > 0 {
+					return fmt.Errorf("bulk write of document %s: %s", result.ID, result.Error)
+				}
+			}
+		}
+		return errors.New("bulk write failed")
+	}
+	return nil
+}
+
+// do sends a POST request and decodes the JSON response into out
+func (c *Client) do(ctx context.Context, path, contentType string, body io.Reader, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(c.URL, "/")+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	switch {
+	case c.APIKey != "":
+		req.Header.Set("Authorization", "ApiKey "+c.APIKey)
+	case c.Username != "":
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+
+	client := c.HTTP
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s: unexpected status %d: %s", path, resp.StatusCode, message)
+	}
+	decoder := json.NewDecoder(resp.Body)
+	decoder.UseNumber() // keep sort values and copied numbers exact
+	return decoder.Decode(out)
+}
+
+// loadCheckpoint reads the checkpoint of an index, or returns a fresh one
+func loadCheckpoint(file, index string) (*Checkpoint, error) {
+	fresh := &Checkpoint{Index: index, Report: &Report{Index: index, Fields: make(map[string]*FieldStats)}}
+	if file == "" {
+		return fresh, nil
+	}
+	data, err := os.ReadFile(file)
+	if os.IsNotExist(err) {
+		return fresh, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var checkpoint Checkpoint
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber() // keep search_after values exact
+	if err := decoder.Decode(&checkpoint); err != nil {
+		return nil, fmt.Errorf("read checkpoint %s: %w", file, err)
+	}
+	if checkpoint.Index != index || checkpoint.Report == nil {
+		return nil, fmt.Errorf("checkpoint %s belongs to index %q", file, checkpoint.Index)
+	}
+	if checkpoint.Report.Fields == nil {
+		checkpoint.Report.Fields = make(map[string]*FieldStats)
+	}
+	return &checkpoint, nil
+}
+
+// saveCheckpoint atomically writes the checkpoint
+func saveCheckpoint(file string, checkpoint *Checkpoint) error {
+	if file == "" {
+		return nil
+	}
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	tmp := file + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, file)
+}
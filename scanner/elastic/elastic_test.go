@@ -0,0 +1,199 @@
+package elastic
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/intMeric/pii-extractor/extractors"
+	"github.com/intMeric/pii-extractor/extractors/regex"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// fakeCluster serves an index of documents sorted by id and records bulk writes
+type fakeCluster struct {
+	t        *testing.T
+	docs     []map[string]any
+	written  map[string]map[string]any
+	failPage int // page number answered with an error, once
+	page     int
+}
+
+func (f *fakeCluster) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "ApiKey secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	switch r.URL.Path {
+	case "/tickets/_search":
+		var request struct {
+			Size        int   `json:"size"`
+			SearchAfter []any `json:"search_after"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			f.t.Fatal(err)
+		}
+		f.page++
+		if f.page == f.failPage {
+			f.failPage = 0
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		var hits []map[string]any
+		for _, doc := range f.docs {
+			id := doc["id"].(string)
+			if request.SearchAfter != nil && id <= request.SearchAfter[0].(string) {
+				continue
+			}
+			if len(hits) == request.Size {
+				break
+			}
+			hits = append(hits, map[string]any{"_id": id, "_source": doc, "sort": []any{id}})
+		}
+		json.NewEncoder(w).Encode(map[string]any{"hits": map[string]any{"hits": hits}})
+	case "/_bulk":
+		lines := bufio.NewScanner(r.Body)
+		for lines.Scan() {
+			var action struct {
+				Index struct {
+					Index string `json:"_index"`
+					ID    string `json:"_id"`
+				} `json:"index"`
+			}
+			json.Unmarshal(lines.Bytes(), &action)
+			lines.Scan()
+			var source map[string]any
+			decoder := json.NewDecoder(strings.NewReader(lines.Text()))
+			decoder.UseNumber()
+			decoder.Decode(&source)
+			if action.Index.Index != "tickets-redacted" {
+				f.t.Errorf("Unexpected bulk target %q", action.Index.Index)
+			}
+			f.written[action.Index.ID] = source
+		}
+		w.Write([]byte(`{"errors":false,"items":[]}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestClient_Scan(t *testing.T) {
+	cluster := &fakeCluster{
+		t:       t,
+		written: make(map[string]map[string]any),
+		docs: []map[string]any{
+			{"id": "1", "message": "Contact john.doe@acme.com", "user": map[string]any{"notes": []any{"SSN 123-45-6789", "ok"}}, "count": 12345678901234567},
+			{"id": "2", "message": "nothing here"},
+			{"id": "3", "message": "Card 4111-1111-1111-1111"},
+		},
+		failPage: 2,
+	}
+	server := httptest.NewServer(cluster)
+	defer server.Close()
+
+	client := &Client{URL: server.URL, APIKey: "secret"}
+	checkpoint := filepath.Join(t.TempDir(), "scan.json")
+	opts := Options{
+		Fields:         []string{"message", "user.notes"},
+		BatchSize:      2,
+		RedactTo:       "tickets-redacted",
+		CheckpointFile: checkpoint,
+	}
+
+	// The second page fails: the first page is checkpointed
+	if _, err := client.Scan(context.Background(), "tickets", opts); err == nil {
+		t.Fatal("Expected the failing page to abort the scan")
+	}
+	if _, err := os.Stat(checkpoint); err != nil {
+		t.Fatalf("Expected a checkpoint after the first page, got %v", err)
+	}
+
+	var found []string
+	opts.OnDocument = func(id string, results map[string]*pii.PiiExtractionResult) {
+		found = append(found, id)
+	}
+	report, err := client.Scan(context.Background(), "tickets", opts)
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if strings.Join(found, ",") != "3" {
+		t.Errorf("Expected the resumed scan to continue after the checkpoint, got %v", found)
+	}
+	if report.Documents != 3 || report.DocumentsWithPII != 2 || report.Redacted != 3 {
+		t.Errorf("Expected totals including the first run, got %+v", report)
+	}
+	if stats := report.Fields["user.notes"]; stats == nil || stats.Types[pii.PiiTypeSSN] != 1 {
+		t.Errorf("Expected the SSN in user.notes, got %+v", stats)
+	}
+	if _, err := os.Stat(checkpoint); !os.IsNotExist(err) {
+		t.Error("Expected the checkpoint to be removed after completion")
+	}
+
+	first := cluster.written["1"]
+	if first["message"] != "Contact [EMAIL]" || first["user"].(map[string]any)["notes"].([]any)[0] != "SSN [SSN]" {
+		t.Errorf("Expected masked fields in the copy, got %v", first)
+	}
+	if count, _ := json.Marshal(first["count"]); string(count) != "12345678901234567" {
+		t.Errorf("Expected numbers to be copied exactly, got %s", count)
+	}
+	if cluster.written["2"]["message"] != "nothing here" {
+		t.Errorf("Expected documents without PII to be copied unchanged, got %v", cluster.written["2"])
+	}
+}
+
+func TestClient_ScanRequiresFields(t *testing.T) {
+	if _, err := (&Client{}).Scan(context.Background(), "tickets", Options{}); err == nil {
+		t.Error("Expected an error without fields")
+	}
+}
+
+// failingExtractor fails on texts containing "boom"
+type failingExtractor struct {
+	extractors.PiiExtractor
+}
+
+func (f failingExtractor) Extract(text string) (*pii.PiiExtractionResult, error) {
+	if strings.Contains(text, "boom") {
+		return nil, errors.New("extraction failed")
+	}
+	return f.PiiExtractor.Extract(text)
+}
+
+func TestClient_ScanFailingField(t *testing.T) {
+	cluster := &fakeCluster{
+		t:       t,
+		written: make(map[string]map[string]any),
+		docs: []map[string]any{
+			{"id": "1", "message": "boom john.doe@acme.com", "notes": []any{"boom", "SSN 123-45-6789"}, "title": "Contact jane@acme.com"},
+		},
+	}
+	server := httptest.NewServer(cluster)
+	defer server.Close()
+
+	client := &Client{URL: server.URL, APIKey: "secret"}
+	report, err := client.Scan(context.Background(), "tickets", Options{
+		Fields:    []string{"message", "notes", "title"},
+		Extractor: failingExtractor{regex.NewDefaultExtractor()},
+		RedactTo:  "tickets-redacted",
+	})
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if report.Fields["message"].Failed != 1 || report.Fields["title"].Failed != 0 {
+		t.Errorf("Expected the failed field to be counted, got %+v", report.Fields)
+	}
+
+	// The unscanned fields are not copied as they are
+	copied := cluster.written["1"]
+	if copied["message"] != Unscanned || copied["notes"].([]any)[1] != Unscanned || copied["title"] != "Contact [EMAIL]" {
+		t.Errorf("Expected the failed fields masked in the copy, got %v", copied)
+	}
+}
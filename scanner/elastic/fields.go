@@ -0,0 +1,100 @@
+package elastic
+
+import (
+	"strings"
+
+	"github.com/intMeric/pii-extractor/pii"
+	"github.com/intMeric/pii-extractor/redact"
+)
+
+// lookup returns the value at a dot-separated path of a document
+func lookup(source map[string]any, path string) (any, bool) {
+	var value any = source
+	for _, key := range strings.Split(path, ".") {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[key]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// fieldText returns the text of a string field, or of an array of strings joined by
+// newlines
+func fieldText(source map[string]any, path string) (string, bool) {
+	value, ok := lookup(source, path)
+	if !ok {
+		return "", false
+	}
+	switch value := value.(type) {
+	case string:
+		return value, true
+	case []any:
+		var texts []string
+		for _, item := range value {
+			if text, ok := item.(string); ok {
+				texts = append(texts, text)
+			}
+		}
+		return strings.Join(texts, "\n"), len(texts) > 0
+	default:
+		return "", false
+	}
+}
+
+// redactDocument masks in place the PII found in the fields of a document, at the offsets
+// of the results in the field text, and replaces the whole value of the fields whose
+// extraction failed with Unscanned, so that no unscanned text is copied
+func redactDocument(source map[string]any, results map[string]*pii.PiiExtractionResult, failed []string, redactor *redact.Redactor) {
+	for path, result := range results {
+		if result.IsEmpty() {
+			continue
+		}
+		text, _ := fieldText(source, path)
+		occurrences := result.Occurrences(text)
+		rewriteField(source, path, func(item string, offset int) string {
+			var spans []pii.Occurrence
+			for _, occurrence := range occurrences {
+				if occurrence.Start >= offset && occurrence.End <= offset+len(item) {
+					spans = append(spans, pii.Occurrence{Start: occurrence.Start - offset, End: occurrence.End - offset, Entity: occurrence.Entity})
+				}
+			}
+			return redactor.RedactSpans(item, spans)
+		})
+	}
+	for _, path := range failed {
+		rewriteField(source, path, func(string, int) string { return Unscanned })
+	}
+}
+
+// rewriteField replaces in place the text of a string field, or every string of an array
+// field, with rewrite(text, offset), offset being where the text starts in the field text
+// (see fieldText)
+func rewriteField(source map[string]any, path string, rewrite func(text string, offset int) string) {
+	keys := strings.Split(path, ".")
+	parent, ok := lookup(source, strings.Join(keys[:len(keys)-1], "."))
+	if len(keys) == 1 {
+		parent, ok = source, true
+	}
+	object, isObject := parent.(map[string]any)
+	if !ok || !isObject {
+		return
+	}
+
+	key := keys[len(keys)-1]
+	switch value := object[key].(type) {
+	case string:
+		object[key] = rewrite(value, 0)
+	case []any:
+		offset := 0
+		for i, item := range value {
+			if text, ok := item.(string); ok {
+				value[i] = rewrite(text, offset)
+				offset += len(text) + 1
+			}
+		}
+	}
+}
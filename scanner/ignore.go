@@ -0,0 +1,113 @@
+package scanner
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"strings"
+)
+
+// IgnoreFileName is the default name of ignore files
+const IgnoreFileName = ".piiignore"
+
+// ignoreRule is one pattern of an ignore file
+type ignoreRule struct {
+	base     string   // slash-separated directory of the ignore file, relative to the root
+	segments []string // pattern split on '/'
+	anchored bool     // pattern contains a slash and matches from base only
+	dirOnly  bool     // pattern ends with '/'
+	negate   bool     // pattern starts with '!'
+}
+
+// ignoreRules is the ordered list of rules in effect; the last matching rule wins
+type ignoreRules []ignoreRule
+
+// parseIgnoreFile reads the rules of an ignore file located in base. Like .gitignore,
+// blank lines and lines starting with '#' are skipped, '!' re-includes a path, a trailing
+// '/' matches directories only, a pattern containing '/' is relative to the ignore file
+// directory and '**' matches any number of directories.
+func parseIgnoreFile(name, base string) (ignoreRules, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var rules ignoreRules
+	lines := bufio.NewScanner(file)
+	for lines.Scan() {
+		line := strings.TrimSpace(lines.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rule := ignoreRule{base: base}
+		if strings.HasPrefix(line, "!") {
+			rule.negate = true
+			line = line[1:]
+		}
+		if strings.HasSuffix(line, "/") {
+			rule.dirOnly = true
+			line = strings.TrimRight(line, "/")
+		}
+		if strings.Contains(line, "/") {
+			rule.anchored = true
+			line = strings.TrimPrefix(line, "/")
+		}
+		if line == "" {
+			continue
+		}
+		rule.segments = strings.Split(line, "/")
+		rules = append(rules, rule)
+	}
+	return rules, lines.Err()
+}
+
+// ignored reports whether the slash-separated path, relative to the scan root, is ignored
+func (rules ignoreRules) ignored(rel string, isDir bool) bool {
+	ignored := false
+	for _, rule := range rules {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+		if rule.matches(rel) {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
+
+// matches reports whether the rule matches the slash-separated path relative to the root
+func (rule ignoreRule) matches(rel string) bool {
+	if rule.base != "" {
+		if !strings.HasPrefix(rel, rule.base+"/") {
+			return false
+		}
+		rel = rel[len(rule.base)+1:]
+	}
+	if !rule.anchored {
+		matched, _ := path.Match(rule.segments[0], path.Base(rel))
+		return matched
+	}
+	return matchSegments(rule.segments, strings.Split(rel, "/"))
+}
+
+// matchSegments matches path segments against pattern segments, where "**" matches zero
+// or more segments
+func matchSegments(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	matched, _ := path.Match(pattern[0], segments[0])
+	return matched && matchSegments(pattern[1:], segments[1:])
+}
@@ -0,0 +1,136 @@
+package objectstore
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// azureAPIVersion is the Blob service REST API version sent with every request
+const azureAPIVersion = "2021-08-06"
+
+// AzureBlob reads an Azure Blob Storage container
+type AzureBlob struct {
+	Account   string
+	Container string
+
+	// SAS is a shared access signature query string granting list and read permissions
+	// on the container, with or without the leading '?'; empty sends anonymous requests
+	// to public containers
+	SAS string
+
+	// Endpoint overrides "https://<account>.blob.core.windows.net", e.g. for Azurite
+	Endpoint string
+
+	// Client sends the requests; nil uses http.DefaultClient
+	Client *http.Client
+}
+
+// URL returns the container URL
+func (a *AzureBlob) URL() string {
+	return a.endpoint() + "/" + a.Container
+}
+
+func (a *AzureBlob) endpoint() string {
+	if a.Endpoint != "" {
+		return strings.TrimSuffix(a.Endpoint, "/")
+	}
+	return "https://" + a.Account + ".blob.core.windows.net"
+}
+
+// List lists the blobs of the container
+func (a *AzureBlob) List(ctx context.Context, prefix string, fn func(Object) error) error {
+	marker := ""
+	for {
+		query := url.Values{"restype": {"container"}, "comp": {"list"}, "prefix": {prefix}}
+		if marker != "" {
+			query.Set("marker", marker)
+		}
+		req, err := a.request(ctx, "", query)
+		if err != nil {
+			return err
+		}
+		resp, err := do(a.Client, req, "azure list "+a.URL())
+		if err != nil {
+			return err
+		}
+
+		var page struct {
+			Blobs []struct {
+				Name       string `xml:"Name"`
+				Properties struct {
+					ContentLength int64  `xml:"Content-Length"`
+					ContentType   string `xml:"Content-Type"`
+					ETag          string `xml:"Etag"`
+					LastModified  string `xml:"Last-Modified"`
+				} `xml:"Properties"`
+			} `xml:"Blobs>Blob"`
+			NextMarker string `xml:"NextMarker"`
+		}
+		err = xml.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return err
+		}
+
+		for _, blob := range page.Blobs {
+			modified, _ := time.Parse(time.RFC1123, blob.Properties.LastModified)
+			object := Object{
+				Key:          blob.Name,
+				Size:         blob.Properties.ContentLength,
+				ETag:         strings.Trim(blob.Properties.ETag, `"`),
+				LastModified: modified,
+				ContentType:  blob.Properties.ContentType,
+			}
+			if err := fn(object); err != nil {
+				return err
+			}
+		}
+		if page.NextMarker == "" {
+			return nil
+		}
+		marker = page.NextMarker
+	}
+}
+
+// Open streams the content of a blob
+func (a *AzureBlob) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := a.request(ctx, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := do(a.Client, req, "azure get "+a.URL()+"/"+key)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// request builds a GET request for a blob (empty for the container itself)
+func (a *AzureBlob) request(ctx context.Context, blob string, query url.Values) (*http.Request, error) {
+	u := a.URL()
+	if blob != "" {
+		u += "/" + (&url.URL{Path: blob}).EscapedPath()
+	}
+	rawQuery := query.Encode()
+	if sas := strings.TrimPrefix(a.SAS, "?"); sas != "" {
+		if rawQuery != "" {
+			rawQuery += "&"
+		}
+		rawQuery += sas
+	}
+	if rawQuery != "" {
+		u += "?" + rawQuery
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("x-ms-version", azureAPIVersion)
+	return req, nil
+}
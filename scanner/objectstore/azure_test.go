@@ -0,0 +1,62 @@
+package objectstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAzureBlob_ListAndOpen(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("sig") != "secret" || r.Header.Get("x-ms-version") == "" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/container":
+			if r.URL.Query().Get("comp") != "list" {
+				t.Errorf("Expected a list request, got %q", r.URL.RawQuery)
+			}
+			fmt.Fprint(w, `<?xml version="1.0" encoding="utf-8"?><EnumerationResults><Blobs><Blob><Name>a.txt</Name>`+
+				`<Properties><Last-Modified>Thu, 02 Jan 2025 03:04:05 GMT</Last-Modified><Etag>"0x8D"</Etag>`+
+				`<Content-Length>4</Content-Length><Content-Type>text/plain</Content-Type></Properties></Blob></Blobs>`+
+				`<NextMarker /></EnumerationResults>`)
+		case "/container/a.txt":
+			fmt.Fprint(w, "data")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	a := &AzureBlob{Container: "container", Endpoint: server.URL, SAS: "?sv=2021&sig=secret"}
+	var objects []Object
+	if err := a.List(context.Background(), "", func(object Object) error {
+		objects = append(objects, object)
+		return nil
+	}); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(objects) != 1 || objects[0].ETag != "0x8D" || objects[0].Size != 4 || objects[0].LastModified.Year() != 2025 {
+		t.Fatalf("Unexpected objects %+v", objects)
+	}
+
+	body, err := a.Open(context.Background(), "a.txt")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer body.Close()
+	if data, _ := io.ReadAll(body); string(data) != "data" {
+		t.Errorf("Expected the blob content, got %q", data)
+	}
+
+	a.SAS = ""
+	var statusErr *StatusError
+	if _, err := a.Open(context.Background(), "a.txt"); !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusForbidden {
+		t.Errorf("Expected a StatusError, got %v", err)
+	}
+}
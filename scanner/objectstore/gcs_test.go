@@ -0,0 +1,56 @@
+package objectstore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGCS_ListAndOpen(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.EscapedPath() {
+		case "/storage/v1/b/bucket/o":
+			if r.URL.Query().Get("pageToken") == "" {
+				fmt.Fprint(w, `{"items":[{"name":"dir/a.txt","size":"5","etag":"e1","updated":"2025-01-02T03:04:05Z"}],"nextPageToken":"p2"}`)
+				return
+			}
+			fmt.Fprint(w, `{"items":[{"name":"dir/b.txt","size":"7","contentType":"text/plain"}]}`)
+		case "/storage/v1/b/bucket/o/dir%2Fa.txt":
+			if r.URL.Query().Get("alt") != "media" {
+				t.Errorf("Expected alt=media, got %q", r.URL.RawQuery)
+			}
+			fmt.Fprint(w, "hello")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	g := &GCS{Bucket: "bucket", Endpoint: server.URL, Token: func(context.Context) (string, error) { return "token", nil }}
+	var objects []Object
+	if err := g.List(context.Background(), "dir/", func(object Object) error {
+		objects = append(objects, object)
+		return nil
+	}); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(objects) != 2 || objects[0].Size != 5 || objects[1].ContentType != "text/plain" {
+		t.Fatalf("Unexpected objects %+v", objects)
+	}
+
+	body, err := g.Open(context.Background(), "dir/a.txt")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer body.Close()
+	if data, _ := io.ReadAll(body); string(data) != "hello" {
+		t.Errorf("Expected the object media, got %q", data)
+	}
+}
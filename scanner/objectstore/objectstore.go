@@ -0,0 +1,62 @@
+// Package objectstore lists and reads objects from cloud buckets (Amazon S3 and
+// S3-compatible stores, Google Cloud Storage and Azure Blob Storage) over their REST
+// APIs, so scans can stream objects without syncing them to a local disk first.
+package objectstore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Object describes a stored object
+type Object struct {
+	Key          string    `json:"key"`
+	Size         int64     `json:"size"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified time.Time `json:"last_modified,omitempty"`
+	ContentType  string    `json:"content_type,omitempty"`
+}
+
+// Store is a bucket of objects
+type Store interface {
+	// URL identifies the bucket, such as "s3://bucket" or "gs://bucket"
+	URL() string
+
+	// List calls fn for every object whose key starts with prefix, in key order, until
+	// fn returns an error
+	List(ctx context.Context, prefix string, fn func(Object) error) error
+
+	// Open streams the content of an object
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+}
+
+// StatusError is returned when a store answers with an unexpected HTTP status
+type StatusError struct {
+	Operation  string
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s: unexpected status %d: %s", e.Operation, e.StatusCode, e.Body)
+}
+
+// do sends a request and returns the response when its status is 200 OK
+func do(client *http.Client, req *http.Request, operation string) (*http.Response, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, &StatusError{Operation: operation, StatusCode: resp.StatusCode, Body: string(body)}
+	}
+	return resp, nil
+}
@@ -0,0 +1,409 @@
+// Package scanner scans directory trees for PII: it walks the files honoring .piiignore
+// files and size, extension and binary filters, extracts them with parallel workers and
+// consolidates the findings into a single report.
+package scanner
+
+import (
+	"bytes"
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/intMeric/pii-extractor/extractors"
+	"github.com/intMeric/pii-extractor/extractors/regex"
+	"github.com/intMeric/pii-extractor/pii"
+	"github.com/intMeric/pii-extractor/progress"
+	"github.com/intMeric/pii-extractor/scanner/objectstore"
+	"github.com/intMeric/pii-extractor/stream"
+)
+
+// DefaultMaxFileSize is the size above which files are skipped when Options.MaxFileSize is zero
+const DefaultMaxFileSize = 10 << 20
+
+// sniffLength is the number of leading bytes inspected to detect binary files
+const sniffLength = 8000
+
+// SkipReason explains why a file was not scanned
+type SkipReason string
+
+const (
+	SkipIgnored   SkipReason = "ignored"   // Matched by an ignore file
+	SkipExtension SkipReason = "extension" // Extension not in Options.Extensions
+	SkipTooLarge  SkipReason = "too_large" // Larger than the maximum file size
+	SkipBinary    SkipReason = "binary"    // Binary content
+	SkipError     SkipReason = "error"     // Could not be read
+)
+
+// Options configures a directory scan
+type Options struct {
+	// Extractor runs on every file; nil uses the default regex extractor
+	Extractor extractors.PiiExtractor
+
+	// Workers is the number of files extracted in parallel (0 = number of CPUs)
+	Workers int
+
+	// FilesPerSecond caps the rate at which files are opened, to limit the I/O load on
+	// shared disks (0 = unlimited)
+	FilesPerSecond float64
+
+	// MaxFileSize is the size in bytes above which files are skipped (0 = DefaultMaxFileSize)
+	MaxFileSize int64
+
+	// Extensions restricts the scan to files with these extensions, such as ".txt" or
+	// ".csv", compared case-insensitively (empty = all)
+	Extensions []string
+
+	// IgnoreFile is the name of the ignore files honored in every directory
+	// (empty = IgnoreFileName)
+	IgnoreFile string
+
+	// Progress is called after every file, from the scanning goroutines one at a time
+	Progress func(Progress)
+
+	// Urgent receives the entities accepted by IsUrgent as soon as their file is
+	// extracted, while the rest of the scan goes on, so incident response can start before
+	// the report is complete. Sends block until received or the scan context is done; the
+	// channel is not closed by the scan.
+	Urgent chan<- UrgentFinding
+
+	// IsUrgent selects the entities sent to Urgent (nil = critical severity, see
+	// stream.Critical)
+	IsUrgent func(pii.PiiEntity) bool
+}
+
+// UrgentFinding is an entity sent to Options.Urgent, with the file it was found in
+type UrgentFinding struct {
+	Path   string        `json:"path"`
+	Entity pii.PiiEntity `json:"entity"`
+}
+
+// Progress reports the advance of a scan
+type Progress struct {
+	Path         string `json:"path"`          // File just processed
+	FilesDone    int    `json:"files_done"`    // Files scanned or failed so far
+	FilesTotal   int    `json:"files_total"`   // Files selected for scanning
+	BytesScanned int64  `json:"bytes_scanned"` // Bytes of the files scanned so far
+
+	Elapsed time.Duration `json:"elapsed"` // Time since the scan of the files started
+}
+
+// Update returns the progress counted in files, for progress bars and ETAs
+func (p Progress) Update() progress.Update {
+	return progress.Update{
+		Operation: "scan",
+		Unit:      progress.UnitFiles,
+		Done:      int64(p.FilesDone),
+		Total:     int64(p.FilesTotal),
+		Elapsed:   p.Elapsed,
+	}
+}
+
+// FileReport holds the findings of one file or object
+type FileReport struct {
+	Path   string                   `json:"path"` // Relative to the scanned directory, or object key
+	Size   int64                    `json:"size"`
+	Result *pii.PiiExtractionResult `json:"result,omitempty"`
+	Error  string                   `json:"error,omitempty"`
+	Object *objectstore.Object      `json:"object,omitempty"` // Object metadata of store scans
+}
+
+// SkippedFile is a file or directory left out of the scan
+type SkippedFile struct {
+	Path   string     `json:"path"`
+	Reason SkipReason `json:"reason"`
+	Error  string     `json:"error,omitempty"`
+}
+
+// Report is the consolidated outcome of a directory scan
+type Report struct {
+	Root         string                   `json:"root"`
+	Files        []FileReport             `json:"files"`   // Scanned files, in walk order
+	Skipped      []SkippedFile            `json:"skipped"` // Skipped files, sorted by path
+	Result       *pii.PiiExtractionResult `json:"result"`  // Findings of all files merged with pii.MergeResults
+	BytesScanned int64                    `json:"bytes_scanned"`
+	Duration     time.Duration            `json:"duration"`
+}
+
+// FilesWithPII returns the reports of the files where PII was found
+func (r *Report) FilesWithPII() []FileReport {
+	var files []FileReport
+	for _, file := range r.Files {
+		if file.Result != nil && !file.Result.IsEmpty() {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// Scan scans every file under dir
+func Scan(dir string, opts Options) (*Report, error) {
+	return ScanContext(context.Background(), dir, opts)
+}
+
+// ScanContext scans every file under dir under ctx. Unreadable files are reported as
+// skipped or failed; an error is returned only when dir cannot be walked or ctx is done.
+func ScanContext(ctx context.Context, dir string, opts Options) (*Report, error) {
+	start := time.Now()
+	opts = opts.withDefaults()
+
+	report := &Report{Root: dir}
+	paths, err := collect(dir, opts, report)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]FileReport, len(paths))
+	for i, path := range paths {
+		files[i].Path = path
+	}
+	read := func(_ context.Context, file FileReport) ([]byte, error) {
+		return os.ReadFile(filepath.Join(dir, filepath.FromSlash(file.Path)))
+	}
+	if err := scanAll(ctx, opts, report, files, read); err != nil {
+		return nil, err
+	}
+	report.Duration = time.Since(start)
+	return report, nil
+}
+
+// withDefaults returns the options with zero values replaced by their defaults
+func (opts Options) withDefaults() Options {
+	if opts.Extractor == nil {
+		opts.Extractor = regex.NewDefaultExtractor()
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = runtime.NumCPU()
+	}
+	if opts.MaxFileSize <= 0 {
+		opts.MaxFileSize = DefaultMaxFileSize
+	}
+	if opts.IgnoreFile == "" {
+		opts.IgnoreFile = IgnoreFileName
+	}
+	if opts.IsUrgent == nil {
+		opts.IsUrgent = stream.Critical
+	}
+	return opts
+}
+
+// scanAll reads and extracts the files with the worker pool and rate limit of opts, then
+// completes report with the file reports, binary skips and merged result
+func scanAll(ctx context.Context, opts Options, report *Report, files []FileReport, read func(context.Context, FileReport) ([]byte, error)) error {
+	binary := make([]bool, len(files))
+	jobs := make(chan int)
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		current = Progress{FilesTotal: len(files)}
+		start   = time.Now()
+	)
+	for range min(opts.Workers, max(len(files), 1)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				binary[i] = scanFile(ctx, &files[i], read, opts)
+				sendUrgent(ctx, opts, files[i])
+
+				mu.Lock()
+				current.Path = files[i].Path
+				current.FilesDone++
+				if files[i].Result != nil {
+					current.BytesScanned += files[i].Size
+				}
+				current.Elapsed = time.Since(start)
+				if opts.Progress != nil {
+					opts.Progress(current)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	var limit <-chan time.Time
+	if opts.FilesPerSecond > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / opts.FilesPerSecond))
+		defer ticker.Stop()
+		limit = ticker.C
+	}
+dispatch:
+	for i := range files {
+		if limit != nil && i > 0 {
+			select {
+			case <-limit:
+			case <-ctx.Done():
+				break dispatch
+			}
+		}
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var results []*pii.PiiExtractionResult
+	for i, file := range files {
+		if binary[i] {
+			report.Skipped = append(report.Skipped, SkippedFile{Path: file.Path, Reason: SkipBinary})
+			continue
+		}
+		report.Files = append(report.Files, file)
+		results = append(results, file.Result)
+	}
+	slices.SortFunc(report.Skipped, func(a, b SkippedFile) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+	report.Result = pii.MergeResults(results...)
+	report.BytesScanned = current.BytesScanned
+	return nil
+}
+
+// sendUrgent sends the urgent entities of a scanned file to Options.Urgent
+func sendUrgent(ctx context.Context, opts Options, file FileReport) {
+	if opts.Urgent == nil || file.Result == nil {
+		return
+	}
+	for _, entity := range file.Result.Entities {
+		if !opts.IsUrgent(entity) {
+			continue
+		}
+		select {
+		case opts.Urgent <- UrgentFinding{Path: file.Path, Entity: entity}:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// collect walks dir and returns the relative paths of the files to scan, recording the
+// skipped ones in report
+func collect(dir string, opts Options, report *Report) ([]string, error) {
+	var paths []string
+	rules := make(map[string]ignoreRules) // rules in effect per relative directory
+
+	err := filepath.WalkDir(dir, func(name string, entry fs.DirEntry, err error) error {
+		rel, relErr := filepath.Rel(dir, name)
+		if relErr != nil {
+			return relErr
+		}
+		rel = filepath.ToSlash(rel)
+		if err != nil {
+			if rel == "." {
+				return err
+			}
+			report.Skipped = append(report.Skipped, SkippedFile{Path: rel, Reason: SkipError, Error: err.Error()})
+			return nil
+		}
+
+		parent := "."
+		if rel != "." {
+			parent = pathDir(rel)
+			if rules[parent].ignored(rel, entry.IsDir()) {
+				report.Skipped = append(report.Skipped, SkippedFile{Path: rel, Reason: SkipIgnored})
+				if entry.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+		}
+
+		if entry.IsDir() {
+			inherited := rules[parent]
+			base := rel
+			if rel == "." {
+				inherited, base = nil, ""
+			}
+			own, err := parseIgnoreFile(filepath.Join(name, opts.IgnoreFile), base)
+			if err != nil && !os.IsNotExist(err) {
+				report.Skipped = append(report.Skipped, SkippedFile{Path: rel + "/" + opts.IgnoreFile, Reason: SkipError, Error: err.Error()})
+			}
+			rules[rel] = append(slices.Clip(inherited), own...)
+			return nil
+		}
+
+		if !entry.Type().IsRegular() || entry.Name() == opts.IgnoreFile {
+			return nil
+		}
+		if len(opts.Extensions) > 0 && !slices.ContainsFunc(opts.Extensions, func(ext string) bool {
+			return strings.EqualFold(ext, filepath.Ext(name))
+		}) {
+			report.Skipped = append(report.Skipped, SkippedFile{Path: rel, Reason: SkipExtension})
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			report.Skipped = append(report.Skipped, SkippedFile{Path: rel, Reason: SkipError, Error: err.Error()})
+			return nil
+		}
+		if info.Size() > opts.MaxFileSize {
+			report.Skipped = append(report.Skipped, SkippedFile{Path: rel, Reason: SkipTooLarge})
+			return nil
+		}
+		paths = append(paths, rel)
+		return nil
+	})
+	return paths, err
+}
+
+// scanFile reads and extracts one file, filling its report. Binary files are not
+// extracted and reported by returning true.
+func scanFile(ctx context.Context, file *FileReport, read func(context.Context, FileReport) ([]byte, error), opts Options) bool {
+	data, err := read(ctx, *file)
+	if err != nil {
+		file.Error = err.Error()
+		return false
+	}
+	file.Size = int64(len(data))
+	if isBinary(data) {
+		return true
+	}
+
+	result, err := extractors.ExtractWithContext(ctx, opts.Extractor, string(data))
+	if err != nil {
+		file.Error = err.Error()
+		return false
+	}
+	file.Result = result
+	return false
+}
+
+// isBinary reports whether data looks binary: a NUL byte or invalid UTF-8 in its first bytes
+func isBinary(data []byte) bool {
+	sniff := data[:min(len(data), sniffLength)]
+	if bytes.IndexByte(sniff, 0) >= 0 {
+		return true
+	}
+	if len(sniff) < len(data) {
+		// Drop a multi-byte rune cut at the end of the sniffed bytes
+		for i := 1; i < utf8.UTFMax && i <= len(sniff); i++ {
+			if utf8.RuneStart(sniff[len(sniff)-i]) {
+				if !utf8.FullRune(sniff[len(sniff)-i:]) {
+					sniff = sniff[:len(sniff)-i]
+				}
+				break
+			}
+		}
+	}
+	return !utf8.Valid(sniff)
+}
+
+// pathDir returns the parent of a slash-separated relative path, "." at the top level
+func pathDir(rel string) string {
+	if i := strings.LastIndexByte(rel, '/'); i >= 0 {
+		return rel[:i]
+	}
+	return "."
+}
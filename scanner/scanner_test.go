@@ -0,0 +1,163 @@
+package scanner
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// writeTree creates files under a temporary directory
+func writeTree(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestScan(t *testing.T) {
+	dir := writeTree(t, map[string]string{
+		".piiignore":             "# generated files\n*.log\nvendor/\n!keep.log\n",
+		"notes.txt":              "Contact john.doe@acme.com",
+		"keep.log":               "SSN 123-45-6789",
+		"debug.log":              "jane@acme.com",
+		"vendor/lib.txt":         "vendor@acme.com",
+		"docs/.piiignore":        "/drafts/**/*.md\n",
+		"docs/drafts/a/b/old.md": "old@acme.com",
+		"docs/readme.md":         "Mail john.doe@acme.com",
+		"image.png":              "\x89PNG\x00\x01",
+		"big.txt":                strings.Repeat("x", 2048),
+	})
+
+	var mu sync.Mutex
+	var progress []Progress
+	report, err := Scan(dir, Options{
+		Workers:     2,
+		MaxFileSize: 1024,
+		Progress: func(p Progress) {
+			mu.Lock()
+			defer mu.Unlock()
+			progress = append(progress, p)
+		},
+	})
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+
+	var scanned []string
+	for _, file := range report.Files {
+		scanned = append(scanned, file.Path)
+	}
+	if strings.Join(scanned, ",") != "docs/readme.md,keep.log,notes.txt" {
+		t.Errorf("Unexpected scanned files %v", scanned)
+	}
+
+	skipped := make(map[string]SkipReason)
+	for _, file := range report.Skipped {
+		skipped[file.Path] = file.Reason
+	}
+	want := map[string]SkipReason{
+		"big.txt":                SkipTooLarge,
+		"debug.log":              SkipIgnored,
+		"docs/drafts/a/b/old.md": SkipIgnored,
+		"image.png":              SkipBinary,
+		"vendor":                 SkipIgnored,
+	}
+	for path, reason := range want {
+		if skipped[path] != reason {
+			t.Errorf("Expected %s to be skipped as %s, got %q", path, reason, skipped[path])
+		}
+	}
+
+	emails := report.Result.GetEntitiesByType(pii.PiiTypeEmail)
+	if len(emails) != 1 || emails[0].GetCount() != 2 {
+		t.Errorf("Expected one merged email found twice, got %+v", emails)
+	}
+	if !report.Result.HasType(pii.PiiTypeSSN) || len(report.FilesWithPII()) != 3 {
+		t.Errorf("Expected PII in every scanned file, got %+v", report.Result.Stats)
+	}
+
+	if len(progress) != 4 || progress[3].FilesDone != 4 || progress[3].FilesTotal != 4 {
+		t.Errorf("Expected a progress call per selected file, got %+v", progress)
+	}
+	if update := progress[3].Update(); update.Fraction() != 1 || update.Elapsed <= 0 {
+		t.Errorf("Expected a complete update with the elapsed time, got %+v", update)
+	}
+}
+
+func TestScan_Urgent(t *testing.T) {
+	dir := writeTree(t, map[string]string{
+		"a.txt": "Contact john.doe@acme.com",
+		"b.txt": "SSN 123-45-6789, card 4111 1111 1111 1111",
+	})
+
+	urgent := make(chan UrgentFinding, 10)
+	if _, err := Scan(dir, Options{Workers: 2, Urgent: urgent}); err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	close(urgent)
+
+	types := make(map[pii.PiiType]bool)
+	for finding := range urgent {
+		if finding.Path != "b.txt" {
+			t.Errorf("Unexpected urgent finding in %s", finding.Path)
+		}
+		types[finding.Entity.Type] = true
+	}
+	if len(types) != 2 || !types[pii.PiiTypeSSN] || !types[pii.PiiTypeCreditCard] {
+		t.Errorf("Expected the SSN and the card sent early, got %v", types)
+	}
+}
+
+func TestScan_Extensions(t *testing.T) {
+	dir := writeTree(t, map[string]string{
+		"a.TXT": "john@acme.com",
+		"b.csv": "jane@acme.com",
+		"c.md":  "joe@acme.com",
+	})
+
+	report, err := Scan(dir, Options{Extensions: []string{".txt", ".csv"}, FilesPerSecond: 1000})
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if len(report.Files) != 2 || len(report.Skipped) != 1 || report.Skipped[0].Reason != SkipExtension {
+		t.Errorf("Expected c.md to be skipped by extension, got %+v / %+v", report.Files, report.Skipped)
+	}
+}
+
+func TestScan_Errors(t *testing.T) {
+	if _, err := Scan(filepath.Join(t.TempDir(), "missing"), Options{}); err == nil {
+		t.Error("Expected an error for a missing directory")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	dir := writeTree(t, map[string]string{"a.txt": "john@acme.com"})
+	if _, err := ScanContext(ctx, dir, Options{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestIsBinary(t *testing.T) {
+	// A multi-byte rune cut at the sniff boundary is not binary
+	text := strings.Repeat("a", sniffLength-1) + "é and more"
+	if isBinary([]byte(text)) {
+		t.Error("Expected text cut inside a rune not to be binary")
+	}
+	if !isBinary([]byte{0xff, 0xfe, 'a'}) {
+		t.Error("Expected invalid UTF-8 to be binary")
+	}
+}
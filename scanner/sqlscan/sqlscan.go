@@ -0,0 +1,250 @@
+// Package sqlscan samples rows of database tables through database/sql and reports the
+// PII types found in each column, producing a data classification map of the schema.
+package sqlscan
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/intMeric/pii-extractor/extractors"
+	"github.com/intMeric/pii-extractor/extractors/regex"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// DefaultSampleSize is the number of rows sampled per table when Options.SampleSize is zero
+const DefaultSampleSize = 1000
+
+// DefaultMinShare is the share of non-null sampled values a type must reach to classify
+// a column when Options.MinShare is zero
+const DefaultMinShare = 0.05
+
+// Table selects the columns of a table to scan. Empty Columns scans every column.
+type Table struct {
+	Name    string   `json:"name"`
+	Columns []string `json:"columns,omitempty"`
+}
+
+// Options configures a database scan
+type Options struct {
+	// Extractor runs on every sampled value; nil uses the default regex extractor
+	Extractor extractors.PiiExtractor
+
+	// SampleSize is the number of rows read per table (0 = DefaultSampleSize)
+	SampleSize int
+
+	// MinShare is the share of non-null sampled values a type must be found in to be
+	// part of the column classification (0 = DefaultMinShare)
+	MinShare float64
+
+	// Query builds the sampling query of a table from quoted identifiers; nil uses
+	// LimitQuery, which works with PostgreSQL, MySQL, SQLite and most other engines.
+	// Use TopQuery for SQL Server, or a query with TABLESAMPLE for random samples.
+	Query func(table string, columns []string, n int) string
+
+	// Quote quotes an identifier; nil uses ANSI double quotes (MySQL needs backquotes
+	// unless ANSI_QUOTES is enabled)
+	Quote func(identifier string) string
+}
+
+// ColumnReport is the PII distribution of one column over the sampled rows
+type ColumnReport struct {
+	Table          string              `json:"table"`
+	Column         string              `json:"column"`
+	RowsSampled    int                 `json:"rows_sampled"`
+	NonNullRows    int                 `json:"non_null_rows"`
+	RowsWithPII    int                 `json:"rows_with_pii"`
+	Types          map[pii.PiiType]int `json:"types"`          // Number of values containing each type
+	Classification []pii.PiiType       `json:"classification"` // Types reaching the minimum share, most frequent first
+}
+
+// Share returns the share of non-null sampled values containing the type
+func (c ColumnReport) Share(piiType pii.PiiType) float64 {
+	if c.NonNullRows == 0 {
+		return 0
+	}
+	return float64(c.Types[piiType]) / float64(c.NonNullRows)
+}
+
+// Report is the outcome of a database scan
+type Report struct {
+	Columns  []ColumnReport `json:"columns"`
+	Duration time.Duration  `json:"duration"`
+}
+
+// Map returns the classification of every column holding PII, keyed by table then column
+func (r *Report) Map() map[string]map[string][]pii.PiiType {
+	classification := make(map[string]map[string][]pii.PiiType)
+	for _, column := range r.Columns {
+		if len(column.Classification) == 0 {
+			continue
+		}
+		if classification[column.Table] == nil {
+			classification[column.Table] = make(map[string][]pii.PiiType)
+		}
+		classification[column.Table][column.Column] = column.Classification
+	}
+	return classification
+}
+
+// LimitQuery samples the first n rows with a LIMIT clause
+func LimitQuery(table string, columns []string, n int) string {
+	return "SELECT " + strings.Join(columns, ", ") + " FROM " + table + " LIMIT " + strconv.Itoa(n)
+}
+
+// TopQuery samples the first n rows with a TOP clause, for SQL Server
+func TopQuery(table string, columns []string, n int) string {
+	return "SELECT TOP " + strconv.Itoa(n) + " " + strings.Join(columns, ", ") + " FROM " + table
+}
+
+// QuoteANSI quotes an identifier with double quotes
+func QuoteANSI(identifier string) string {
+	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+}
+
+// QuoteMySQL quotes an identifier with backquotes
+func QuoteMySQL(identifier string) string {
+	return "`" + strings.ReplaceAll(identifier, "`", "``") + "`"
+}
+
+// Scan samples the rows of every table and classifies their columns. Table and column
+// names are quoted, so they are matched case-sensitively on most engines.
+func Scan(ctx context.Context, db *sql.DB, tables []Table, opts Options) (*Report, error) {
+	start := time.Now()
+	if opts.Extractor == nil {
+		opts.Extractor = regex.NewDefaultExtractor()
+	}
+	if opts.SampleSize <= 0 {
+		opts.SampleSize = DefaultSampleSize
+	}
+	if opts.MinShare <= 0 {
+		opts.MinShare = DefaultMinShare
+	}
+	if opts.Query == nil {
+		opts.Query = LimitQuery
+	}
+	if opts.Quote == nil {
+		opts.Quote = QuoteANSI
+	}
+
+	report := &Report{}
+	for _, table := range tables {
+		columns, err := scanTable(ctx, db, table, opts)
+		if err != nil {
+			return nil, fmt.Errorf("scan table %s: %w", table.Name, err)
+		}
+		report.Columns = append(report.Columns, columns...)
+	}
+	report.Duration = time.Since(start)
+	return report, nil
+}
+
+// scanTable samples one table
+func scanTable(ctx context.Context, db *sql.DB, table Table, opts Options) ([]ColumnReport, error) {
+	quotedColumns := []string{"*"}
+	if len(table.Columns) > 0 {
+		quotedColumns = make([]string, len(table.Columns))
+		for i, column := range table.Columns {
+			quotedColumns[i] = opts.Quote(column)
+		}
+	}
+
+	rows, err := db.QueryContext(ctx, opts.Query(quoteTable(table.Name, opts.Quote), quotedColumns, opts.SampleSize))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	names, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]ColumnReport, len(names))
+	for i, name := range names {
+		reports[i] = ColumnReport{Table: table.Name, Column: name, Types: make(map[pii.PiiType]int)}
+	}
+
+	values := make([]any, len(names))
+	targets := make([]any, len(names))
+	for i := range values {
+		targets[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(targets...); err != nil {
+			return nil, err
+		}
+		for i, value := range values {
+			column := &reports[i]
+			column.RowsSampled++
+			if value == nil {
+				continue
+			}
+			column.NonNullRows++
+
+			result, err := extractors.ExtractWithContext(ctx, opts.Extractor, text(value))
+			if err != nil {
+				return nil, err
+			}
+			if result.IsEmpty() {
+				continue
+			}
+			column.RowsWithPII++
+			for piiType := range result.Stats {
+				column.Types[piiType]++
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range reports {
+		reports[i].Classification = classify(reports[i], opts.MinShare)
+	}
+	return reports, nil
+}
+
+// classify returns the types found in at least minShare of the non-null values, most
+// frequent first
+func classify(column ColumnReport, minShare float64) []pii.PiiType {
+	types := []pii.PiiType{}
+	for piiType := range column.Types {
+		if column.Share(piiType) >= minShare {
+			types = append(types, piiType)
+		}
+	}
+	sort.Slice(types, func(i, j int) bool {
+		if column.Types[types[i]] != column.Types[types[j]] {
+			return column.Types[types[i]] > column.Types[types[j]]
+		}
+		return types[i] < types[j]
+	})
+	return types
+}
+
+// text returns the text of a scanned value
+func text(value any) string {
+	switch value := value.(type) {
+	case []byte:
+		return string(value)
+	case string:
+		return value
+	case time.Time:
+		return value.Format(time.RFC3339)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// quoteTable quotes every part of a possibly schema-qualified table name
+func quoteTable(name string, quote func(string) string) string {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = quote(part)
+	}
+	return strings.Join(parts, ".")
+}
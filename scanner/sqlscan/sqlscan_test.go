@@ -0,0 +1,102 @@
+package sqlscan
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// fakeDriver serves fixed rows and records the queries it receives
+type fakeDriver struct {
+	queries []string
+}
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) { return &fakeConn{driver: d}, nil }
+
+type fakeConn struct{ driver *fakeDriver }
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c *fakeConn) Close() error                        { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	c.driver.queries = append(c.driver.queries, query)
+	if strings.Contains(query, `"missing"`) {
+		return nil, errors.New("no such table")
+	}
+	return &fakeRows{
+		columns: []string{"id", "email", "notes"},
+		values: [][]driver.Value{
+			{int64(1), []byte("john@acme.com"), nil},
+			{int64(2), []byte("jane@acme.com"), "call 555-123-4567"},
+			{int64(3), nil, "nothing"},
+		},
+	}, nil
+}
+
+type fakeRows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+func TestScan(t *testing.T) {
+	fake := &fakeDriver{}
+	sql.Register("sqlscan-fake", fake)
+	db, err := sql.Open("sqlscan-fake", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	report, err := Scan(context.Background(), db, []Table{{Name: "crm.customers"}}, Options{SampleSize: 3, MinShare: 0.6})
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if fake.queries[0] != `SELECT * FROM "crm"."customers" LIMIT 3` {
+		t.Errorf("Unexpected sampling query %q", fake.queries[0])
+	}
+	if len(report.Columns) != 3 {
+		t.Fatalf("Expected 3 columns, got %+v", report.Columns)
+	}
+
+	email := report.Columns[1]
+	if email.RowsSampled != 3 || email.NonNullRows != 2 || email.Types[pii.PiiTypeEmail] != 2 || email.Share(pii.PiiTypeEmail) != 1 {
+		t.Errorf("Unexpected email column report %+v", email)
+	}
+	notes := report.Columns[2]
+	if notes.RowsWithPII != 1 || len(notes.Classification) != 0 {
+		t.Errorf("Expected phones in half the notes to stay below the minimum share, got %+v", notes)
+	}
+
+	classification := report.Map()
+	if len(classification) != 1 || len(classification["crm.customers"]) != 1 || classification["crm.customers"]["email"][0] != pii.PiiTypeEmail {
+		t.Errorf("Unexpected classification map %v", classification)
+	}
+
+	_, err = Scan(context.Background(), db, []Table{{Name: "users", Columns: []string{"mail"}}, {Name: "missing"}},
+		Options{SampleSize: 3, Query: TopQuery})
+	if err == nil {
+		t.Error("Expected an error for the missing table")
+	}
+	if fake.queries[1] != `SELECT TOP 3 "mail" FROM "users"` {
+		t.Errorf("Unexpected custom query %q", fake.queries[1])
+	}
+}
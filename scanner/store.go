@@ -0,0 +1,62 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/intMeric/pii-extractor/scanner/objectstore"
+)
+
+// ScanStore scans the objects of a bucket whose key starts with prefix, streaming each one
+// from the store instead of syncing the bucket first. Workers, FilesPerSecond, MaxFileSize
+// and Extensions apply as for directories; ignore files do not. Every file report carries
+// the metadata of its object.
+func ScanStore(ctx context.Context, store objectstore.Store, prefix string, opts Options) (*Report, error) {
+	start := time.Now()
+	opts = opts.withDefaults()
+
+	report := &Report{Root: store.URL() + "/" + prefix}
+	var files []FileReport
+	err := store.List(ctx, prefix, func(object objectstore.Object) error {
+		switch {
+		case strings.HasSuffix(object.Key, "/"):
+			// Folder placeholder objects hold no content
+		case len(opts.Extensions) > 0 && !slices.ContainsFunc(opts.Extensions, func(ext string) bool {
+			return strings.EqualFold(ext, path.Ext(object.Key))
+		}):
+			report.Skipped = append(report.Skipped, SkippedFile{Path: object.Key, Reason: SkipExtension})
+		case object.Size > opts.MaxFileSize:
+			report.Skipped = append(report.Skipped, SkippedFile{Path: object.Key, Reason: SkipTooLarge})
+		default:
+			files = append(files, FileReport{Path: object.Key, Object: &object})
+		}
+		return ctx.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	read := func(ctx context.Context, file FileReport) ([]byte, error) {
+		body, err := store.Open(ctx, file.Path)
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+		// The object may have grown since it was listed
+		data, err := io.ReadAll(io.LimitReader(body, opts.MaxFileSize+1))
+		if err == nil && int64(len(data)) > opts.MaxFileSize {
+			err = fmt.Errorf("object larger than %d bytes", opts.MaxFileSize)
+		}
+		return data, err
+	}
+	if err := scanAll(ctx, opts, report, files, read); err != nil {
+		return nil, err
+	}
+	report.Duration = time.Since(start)
+	return report, nil
+}
@@ -0,0 +1,69 @@
+package scanner
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/intMeric/pii-extractor/scanner/objectstore"
+)
+
+// memoryStore is an in-memory objectstore.Store
+type memoryStore map[string]string
+
+func (m memoryStore) URL() string { return "mem://bucket" }
+
+func (m memoryStore) List(ctx context.Context, prefix string, fn func(objectstore.Object) error) error {
+	for _, key := range []string{"logs/", "logs/a.txt", "logs/b.bin", "logs/big.txt", "logs/gone.txt", "other/c.txt"} {
+		content, ok := m[key]
+		if !ok && key != "logs/gone.txt" {
+			continue
+		}
+		if strings.HasPrefix(key, prefix) {
+			if err := fn(objectstore.Object{Key: key, Size: int64(len(content)), ETag: "etag-" + key}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (m memoryStore) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	content, ok := m[key]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return io.NopCloser(strings.NewReader(content)), nil
+}
+
+func TestScanStore(t *testing.T) {
+	store := memoryStore{
+		"logs/":        "",
+		"logs/a.txt":   "Contact john.doe@acme.com",
+		"logs/b.bin":   "\x00\x01",
+		"logs/big.txt": strings.Repeat("x", 100),
+		"other/c.txt":  "jane@acme.com",
+	}
+
+	report, err := ScanStore(context.Background(), store, "logs/", Options{MaxFileSize: 50, Workers: 2})
+	if err != nil {
+		t.Fatalf("ScanStore() error = %v", err)
+	}
+	if report.Root != "mem://bucket/logs/" {
+		t.Errorf("Unexpected root %q", report.Root)
+	}
+	if len(report.Files) != 2 {
+		t.Fatalf("Expected a.txt and gone.txt to be scanned, got %+v", report.Files)
+	}
+	if a := report.Files[0]; a.Path != "logs/a.txt" || a.Object == nil || a.Object.ETag != "etag-logs/a.txt" || a.Result.Total != 1 {
+		t.Errorf("Expected the findings and metadata of a.txt, got %+v", a)
+	}
+	if gone := report.Files[1]; gone.Error == "" {
+		t.Errorf("Expected a read error for the deleted object, got %+v", gone)
+	}
+	if len(report.Skipped) != 2 || report.Skipped[0].Reason != SkipBinary || report.Skipped[1].Reason != SkipTooLarge {
+		t.Errorf("Expected the binary and large objects to be skipped, got %+v", report.Skipped)
+	}
+}
@@ -0,0 +1,153 @@
+// Package sketch provides fixed-memory probabilistic summaries used to aggregate
+// PII values over large corpora without retaining every value.
+package sketch
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/bits"
+)
+
+// DefaultPrecision is the default HyperLogLog precision (2^14 registers, ~0.8% standard error)
+const DefaultPrecision = 14
+
+// HyperLogLog estimates the number of distinct values added to it using fixed memory
+type HyperLogLog struct {
+	precision uint8
+	registers []uint8
+}
+
+// NewHyperLogLog creates a sketch with 2^precision registers. Precision is clamped to [4, 18].
+func NewHyperLogLog(precision uint8) *HyperLogLog {
+	if precision < 4 {
+		precision = 4
+	}
+	if precision > 18 {
+		precision = 18
+	}
+	return &HyperLogLog{
+		precision: precision,
+		registers: make([]uint8, 1<<precision),
+	}
+}
+
+// Add records a value
+func (h *HyperLogLog) Add(value string) {
+	hash := hashString(value)
+	index := hash >> (64 - h.precision)
+	// Rank of the first set bit in the remaining bits, capped so an all-zero suffix still counts
+	rank := uint8(bits.LeadingZeros64(hash<<h.precision|1<<(h.precision-1))) + 1
+	if rank > h.registers[index] {
+		h.registers[index] = rank
+	}
+}
+
+// Estimate returns the approximate number of distinct values added
+func (h *HyperLogLog) Estimate() uint64 {
+	m := float64(len(h.registers))
+
+	sum := 0.0
+	zeros := 0
+	for _, register := range h.registers {
+		sum += math.Ldexp(1, -int(register))
+		if register == 0 {
+			zeros++
+		}
+	}
+
+	estimate := alpha(m) * m * m / sum
+
+	// Small range correction: linear counting is more accurate for low cardinalities
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+
+	return uint64(estimate + 0.5)
+}
+
+// Precision returns the sketch precision
+func (h *HyperLogLog) Precision() uint8 {
+	return h.precision
+}
+
+// ErrPrecisionMismatch is returned when merging sketches of different precisions
+var ErrPrecisionMismatch = errors.New("sketch: HyperLogLog precisions differ")
+
+// Merge adds the values of another sketch of the same precision, as if they had been added
+// to this one. Sketches built on separate shards merge into the sketch of the whole corpus.
+func (h *HyperLogLog) Merge(other *HyperLogLog) error {
+	if other.precision != h.precision {
+		return fmt.Errorf("%w: %d and %d", ErrPrecisionMismatch, h.precision, other.precision)
+	}
+	for i, register := range other.registers {
+		if register > h.registers[i] {
+			h.registers[i] = register
+		}
+	}
+	return nil
+}
+
+// Clone returns an independent copy of the sketch
+func (h *HyperLogLog) Clone() *HyperLogLog {
+	return &HyperLogLog{
+		precision: h.precision,
+		registers: append([]uint8(nil), h.registers...),
+	}
+}
+
+// MarshalBinary encodes the sketch as its precision followed by its registers
+func (h *HyperLogLog) MarshalBinary() ([]byte, error) {
+	return append([]byte{h.precision}, h.registers...), nil
+}
+
+// UnmarshalBinary decodes a sketch encoded by MarshalBinary
+func (h *HyperLogLog) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 || data[0] < 4 || data[0] > 18 || len(data) != 1+1<<data[0] {
+		return errors.New("sketch: invalid HyperLogLog encoding")
+	}
+	h.precision = data[0]
+	h.registers = append([]uint8(nil), data[1:]...)
+	return nil
+}
+
+// MarshalText encodes the sketch in base64, so it can be embedded in JSON documents
+func (h *HyperLogLog) MarshalText() ([]byte, error) {
+	data, _ := h.MarshalBinary()
+	return []byte(base64.StdEncoding.EncodeToString(data)), nil
+}
+
+// UnmarshalText decodes a sketch encoded by MarshalText
+func (h *HyperLogLog) UnmarshalText(text []byte) error {
+	data, err := base64.StdEncoding.DecodeString(string(text))
+	if err != nil {
+		return fmt.Errorf("sketch: invalid HyperLogLog encoding: %w", err)
+	}
+	return h.UnmarshalBinary(data)
+}
+
+// alpha is the bias correction constant for m registers
+func alpha(m float64) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	default:
+		return 0.7213 / (1 + 1.079/m)
+	}
+}
+
+// hashString hashes a value with FNV-64a followed by a splitmix64 finalizer for better bit dispersion
+func hashString(value string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(value))
+	x := h.Sum64()
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
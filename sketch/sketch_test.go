@@ -0,0 +1,107 @@
+package sketch
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestHyperLogLog_Estimate(t *testing.T) {
+	for _, distinct := range []int{0, 10, 1000, 100000} {
+		h := NewHyperLogLog(DefaultPrecision)
+		for i := 0; i < distinct; i++ {
+			value := fmt.Sprintf("user%d@example.com", i)
+			h.Add(value)
+			h.Add(value) // duplicates must not change the estimate
+		}
+
+		estimate := float64(h.Estimate())
+		if distinct == 0 {
+			if estimate != 0 {
+				t.Errorf("Expected 0 for an empty sketch, got %v", estimate)
+			}
+			continue
+		}
+		if relativeError := math.Abs(estimate-float64(distinct)) / float64(distinct); relativeError > 0.05 {
+			t.Errorf("Estimate %v for %d distinct values, relative error %.3f", estimate, distinct, relativeError)
+		}
+	}
+}
+
+func TestHyperLogLog_MergeShards(t *testing.T) {
+	whole := NewHyperLogLog(DefaultPrecision)
+	shards := []*HyperLogLog{NewHyperLogLog(DefaultPrecision), NewHyperLogLog(DefaultPrecision)}
+	for i := 0; i < 20000; i++ {
+		value := fmt.Sprintf("user%d@example.com", i)
+		whole.Add(value)
+		// Shards overlap on half of their values
+		shards[i%2].Add(value)
+		if i%4 == 0 {
+			shards[1-i%2].Add(value)
+		}
+	}
+
+	merged := shards[0].Clone()
+	if err := merged.Merge(shards[1]); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	if merged.Estimate() != whole.Estimate() {
+		t.Errorf("Expected the merged shards to estimate like the whole corpus, got %d and %d", merged.Estimate(), whole.Estimate())
+	}
+	if err := merged.Merge(NewHyperLogLog(10)); !errors.Is(err, ErrPrecisionMismatch) {
+		t.Errorf("Expected ErrPrecisionMismatch, got %v", err)
+	}
+}
+
+func TestHyperLogLog_MarshalText(t *testing.T) {
+	h := NewHyperLogLog(8)
+	for i := 0; i < 100; i++ {
+		h.Add(fmt.Sprint(i))
+	}
+	text, err := h.MarshalText()
+	if err != nil {
+		t.Fatalf("MarshalText() error = %v", err)
+	}
+
+	var decoded HyperLogLog
+	if err := decoded.UnmarshalText(text); err != nil {
+		t.Fatalf("UnmarshalText() error = %v", err)
+	}
+	if decoded.Precision() != 8 || decoded.Estimate() != h.Estimate() {
+		t.Errorf("Expected the decoded sketch to match, got precision %d and estimate %d", decoded.Precision(), decoded.Estimate())
+	}
+	if err := decoded.UnmarshalBinary([]byte{8, 1, 2}); err == nil {
+		t.Error("Expected an error for a truncated encoding")
+	}
+}
+
+func TestTopK_FrequentValues(t *testing.T) {
+	top := NewTopK(3)
+
+	// Heavy hitters interleaved with many unique values
+	for i := 0; i < 10000; i++ {
+		top.Add(fmt.Sprintf("noise%d", i), 1)
+		if i%10 == 0 {
+			top.Add("a@example.com", 3)
+			top.Add("b@example.com", 2)
+			top.Add("c@example.com", 1)
+		}
+	}
+
+	counters := top.Top()
+	if len(counters) != 3 {
+		t.Fatalf("Expected 3 counters, got %d", len(counters))
+	}
+	// Space-Saving never underestimates, and overestimates by at most Error
+	if counters[0].Count < 3000 || counters[0].Count-counters[0].Error > 3000 {
+		t.Errorf("Expected a count bounding the true count 3000, got %+v", counters[0])
+	}
+
+	expected := []string{"a@example.com", "b@example.com", "c@example.com"}
+	for i, value := range expected {
+		if counters[i].Value != value {
+			t.Errorf("Expected %s at position %d, got %+v", value, i, counters)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package sketch
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// Counter is a value with its estimated count. Error is the maximum overestimation of Count.
+type Counter struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+	Error int    `json:"error,omitempty"`
+}
+
+// TopK tracks the most frequent values using the Space-Saving algorithm. It monitors a fixed
+// number of counters, so memory does not grow with the number of distinct values. Any value
+// occurring more than total/capacity times is guaranteed to be tracked.
+type TopK struct {
+	k        int
+	counters counterHeap
+	index    map[string]*counterEntry
+}
+
+// NewTopK creates a tracker reporting the k most frequent values. It monitors 4*k counters
+// to keep the reported counts accurate.
+func NewTopK(k int) *TopK {
+	if k < 1 {
+		k = 1
+	}
+	return &TopK{
+		k:     k,
+		index: make(map[string]*counterEntry, 4*k),
+	}
+}
+
+// Add records count occurrences of value
+func (t *TopK) Add(value string, count int) {
+	if entry, exists := t.index[value]; exists {
+		entry.counter.Count += count
+		heap.Fix(&t.counters, entry.position)
+		return
+	}
+
+	if len(t.counters) < 4*t.k {
+		entry := &counterEntry{counter: Counter{Value: value, Count: count}}
+		heap.Push(&t.counters, entry)
+		t.index[value] = entry
+		return
+	}
+
+	// Replace the least frequent value; its count bounds the error of the new one
+	minimum := t.counters[0]
+	delete(t.index, minimum.counter.Value)
+	minimum.counter = Counter{
+		Value: value,
+		Count: minimum.counter.Count + count,
+		Error: minimum.counter.Count,
+	}
+	t.index[value] = minimum
+	heap.Fix(&t.counters, 0)
+}
+
+// Top returns the k most frequent values, most frequent first
+func (t *TopK) Top() []Counter {
+	counters := make([]Counter, len(t.counters))
+	for i, entry := range t.counters {
+		counters[i] = entry.counter
+	}
+	sort.Slice(counters, func(i, j int) bool {
+		if counters[i].Count != counters[j].Count {
+			return counters[i].Count > counters[j].Count
+		}
+		return counters[i].Value < counters[j].Value
+	})
+	if len(counters) > t.k {
+		counters = counters[:t.k]
+	}
+	return counters
+}
+
+// K returns the number of values reported by Top
+func (t *TopK) K() int {
+	return t.k
+}
+
+// counterEntry is a heap element tracking its own position for heap.Fix
+type counterEntry struct {
+	counter  Counter
+	position int
+}
+
+// counterHeap is a min-heap of counters ordered by count
+type counterHeap []*counterEntry
+
+func (h counterHeap) Len() int           { return len(h) }
+func (h counterHeap) Less(i, j int) bool { return h[i].counter.Count < h[j].counter.Count }
+
+func (h counterHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].position = i
+	h[j].position = j
+}
+
+func (h *counterHeap) Push(x any) {
+	entry := x.(*counterEntry)
+	entry.position = len(*h)
+	*h = append(*h, entry)
+}
+
+func (h *counterHeap) Pop() any {
+	old := *h
+	entry := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return entry
+}
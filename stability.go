@@ -0,0 +1,59 @@
+package piiextractor
+
+import "strings"
+
+// ModulePath is the import path of the module
+const ModulePath = "github.com/intMeric/pii-extractor"
+
+// StabilityTier is the compatibility guarantee of a package of the module
+type StabilityTier int
+
+const (
+	TierStable       StabilityTier = iota // no incompatible change within a major version
+	TierBeta                              // incompatible changes after a deprecation period of one minor release
+	TierExperimental                      // may change or disappear in any release
+)
+
+// String returns the name of the tier
+func (t StabilityTier) String() string {
+	switch t {
+	case TierStable:
+		return "stable"
+	case TierBeta:
+		return "beta"
+	case TierExperimental:
+		return "experimental"
+	default:
+		return "unknown"
+	}
+}
+
+// StablePackages are the import paths of the stable packages, relative to ModulePath
+var StablePackages = []string{
+	".",
+	"pii",
+	"extractors",
+	"extractors/regex",
+	"extractors/regex/patterns",
+	"extractors/llm",
+	"extractors/hybrid",
+	"redact",
+}
+
+// PackageStability returns the stability tier of a package of the module, given by its
+// full import path or its path relative to ModulePath
+func PackageStability(importPath string) StabilityTier {
+	path := strings.TrimPrefix(strings.TrimPrefix(importPath, ModulePath), "/")
+	if path == "" {
+		path = "."
+	}
+	if path == "experimental" || strings.HasPrefix(path, "experimental/") {
+		return TierExperimental
+	}
+	for _, stable := range StablePackages {
+		if path == stable {
+			return TierStable
+		}
+	}
+	return TierBeta
+}
@@ -0,0 +1,75 @@
+package grpcstream
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// ServiceName is the full name of the gRPC service
+const ServiceName = "piiextractor.v1.Extractor"
+
+// ExtractStreamMethod is the full method name of the bidirectional extraction stream
+const ExtractStreamMethod = "/" + ServiceName + "/ExtractStream"
+
+// Descriptors of proto/extractor.proto, built at runtime so that no generated code is
+// needed. Keep them in sync with the .proto file.
+var (
+	requestDescriptor protoreflect.MessageDescriptor
+	eventDescriptor   protoreflect.MessageDescriptor
+)
+
+func init() {
+	field := func(name string, number int32, kind descriptorpb.FieldDescriptorProto_Type) *descriptorpb.FieldDescriptorProto {
+		return &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(number),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     kind.Enum(),
+		}
+	}
+	const (
+		stringType = descriptorpb.FieldDescriptorProto_TYPE_STRING
+		int64Type  = descriptorpb.FieldDescriptorProto_TYPE_INT64
+	)
+
+	file, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:    proto.String("piiextractor/v1/extractor.proto"),
+		Package: proto.String("piiextractor.v1"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name:  proto.String("ExtractStreamRequest"),
+				Field: []*descriptorpb.FieldDescriptorProto{field("text", 1, stringType)},
+			},
+			{
+				Name: proto.String("EntityEvent"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("type", 1, stringType),
+					field("value", 2, stringType),
+					field("start", 3, int64Type),
+					field("end", 4, int64Type),
+					field("severity", 5, stringType),
+					field("country", 6, stringType),
+				},
+			},
+		},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("Extractor"),
+			Method: []*descriptorpb.MethodDescriptorProto{{
+				Name:            proto.String("ExtractStream"),
+				InputType:       proto.String(".piiextractor.v1.ExtractStreamRequest"),
+				OutputType:      proto.String(".piiextractor.v1.EntityEvent"),
+				ClientStreaming: proto.Bool(true),
+				ServerStreaming: proto.Bool(true),
+			}},
+		}},
+	}, nil)
+	if err != nil {
+		panic("grpcstream: invalid descriptor: " + err.Error())
+	}
+	requestDescriptor = file.Messages().ByName("ExtractStreamRequest")
+	eventDescriptor = file.Messages().ByName("EntityEvent")
+}
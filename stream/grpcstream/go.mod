@@ -0,0 +1,18 @@
+module github.com/intMeric/pii-extractor/stream/grpcstream
+
+go 1.23.0
+
+require (
+	github.com/intMeric/pii-extractor v0.0.0
+	google.golang.org/grpc v1.67.1
+	google.golang.org/protobuf v1.34.2
+)
+
+require (
+	golang.org/x/net v0.42.0 // indirect
+	golang.org/x/sys v0.34.0 // indirect
+	golang.org/x/text v0.27.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240814211410-ddb44dafa142 // indirect
+)
+
+replace github.com/intMeric/pii-extractor => ../..
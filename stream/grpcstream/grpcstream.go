@@ -0,0 +1,178 @@
+// Package grpcstream serves streaming PII extraction over a bidirectional gRPC stream:
+// clients push text chunks and receive entity events as soon as each entity is settled,
+// for real-time overlays in chat and support tooling. It is a separate module so that the
+// core library does not depend on gRPC.
+package grpcstream
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/intMeric/pii-extractor/extractors"
+	"github.com/intMeric/pii-extractor/pii"
+	"github.com/intMeric/pii-extractor/stream"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// Event is an entity occurrence reported on the stream
+type Event struct {
+	Type     string `json:"type"`
+	Value    string `json:"value"`
+	Start    int64  `json:"start"`
+	End      int64  `json:"end"`
+	Severity string `json:"severity"`
+	Country  string `json:"country,omitempty"`
+}
+
+// NewEvent converts a stream occurrence into an event
+func NewEvent(occurrence pii.Occurrence) Event {
+	return Event{
+		Type:     occurrence.Entity.Type.String(),
+		Value:    occurrence.Entity.GetValue(),
+		Start:    int64(occurrence.Start),
+		End:      int64(occurrence.End),
+		Severity: occurrence.Entity.GetSeverity().String(),
+		Country:  pii.EntityCountry(occurrence.Entity).String(),
+	}
+}
+
+// Server implements the Extractor service
+type Server struct {
+	// Extractor runs on every stream; it must be safe for concurrent use
+	Extractor extractors.PiiExtractor
+
+	// Options configure the stream of every call
+	Options []stream.Option
+}
+
+// Register registers the Extractor service on a gRPC server
+func Register(registrar grpc.ServiceRegistrar, srv *Server) {
+	registrar.RegisterService(&serviceDesc, srv)
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*any)(nil),
+	Streams: []grpc.StreamDesc{{
+		StreamName:    "ExtractStream",
+		ClientStreams: true,
+		ServerStreams: true,
+		Handler: func(srv any, ss grpc.ServerStream) error {
+			return srv.(*Server).ExtractStream(ss)
+		},
+	}},
+	Metadata: "piiextractor/v1/extractor.proto",
+}
+
+// ExtractStream serves one bidirectional extraction stream
+func (s *Server) ExtractStream(ss grpc.ServerStream) error {
+	ctx := ss.Context()
+	extraction := stream.New(s.Extractor, s.Options...)
+	textField := requestDescriptor.Fields().ByName("text")
+
+	for {
+		request := dynamicpb.NewMessage(requestDescriptor)
+		err := ss.RecvMsg(request)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		occurrences, err := extraction.Write(ctx, request.Get(textField).String())
+		if err != nil {
+			return err
+		}
+		if err := sendEvents(ss, occurrences); err != nil {
+			return err
+		}
+	}
+
+	occurrences, err := extraction.Close(ctx)
+	if err != nil {
+		return err
+	}
+	return sendEvents(ss, occurrences)
+}
+
+// sendEvents sends an event message per occurrence
+func sendEvents(ss grpc.ServerStream, occurrences []pii.Occurrence) error {
+	for _, occurrence := range occurrences {
+		if err := ss.SendMsg(encodeEvent(NewEvent(occurrence))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func encodeEvent(event Event) *dynamicpb.Message {
+	message := dynamicpb.NewMessage(eventDescriptor)
+	fields := eventDescriptor.Fields()
+	message.Set(fields.ByName("type"), protoreflect.ValueOfString(event.Type))
+	message.Set(fields.ByName("value"), protoreflect.ValueOfString(event.Value))
+	message.Set(fields.ByName("start"), protoreflect.ValueOfInt64(event.Start))
+	message.Set(fields.ByName("end"), protoreflect.ValueOfInt64(event.End))
+	message.Set(fields.ByName("severity"), protoreflect.ValueOfString(event.Severity))
+	message.Set(fields.ByName("country"), protoreflect.ValueOfString(event.Country))
+	return message
+}
+
+func decodeEvent(message *dynamicpb.Message) Event {
+	fields := eventDescriptor.Fields()
+	return Event{
+		Type:     message.Get(fields.ByName("type")).String(),
+		Value:    message.Get(fields.ByName("value")).String(),
+		Start:    message.Get(fields.ByName("start")).Int(),
+		End:      message.Get(fields.ByName("end")).Int(),
+		Severity: message.Get(fields.ByName("severity")).String(),
+		Country:  message.Get(fields.ByName("country")).String(),
+	}
+}
+
+// Client calls the Extractor service
+type Client struct {
+	conn grpc.ClientConnInterface
+}
+
+// NewClient creates a client on a gRPC connection
+func NewClient(conn grpc.ClientConnInterface) *Client {
+	return &Client{conn: conn}
+}
+
+// ClientStream is an open extraction stream
+type ClientStream struct {
+	stream grpc.ClientStream
+}
+
+// ExtractStream opens an extraction stream
+func (c *Client) ExtractStream(ctx context.Context, opts ...grpc.CallOption) (*ClientStream, error) {
+	cs, err := c.conn.NewStream(ctx, &serviceDesc.Streams[0], ExtractStreamMethod, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientStream{stream: cs}, nil
+}
+
+// Send pushes the next text chunk
+func (c *ClientStream) Send(text string) error {
+	request := dynamicpb.NewMessage(requestDescriptor)
+	request.Set(requestDescriptor.Fields().ByName("text"), protoreflect.ValueOfString(text))
+	return c.stream.SendMsg(request)
+}
+
+// CloseSend signals the end of the text; the server then flushes the remaining events
+func (c *ClientStream) CloseSend() error {
+	return c.stream.CloseSend()
+}
+
+// Recv returns the next event, or io.EOF once the server ended the stream
+func (c *ClientStream) Recv() (Event, error) {
+	message := dynamicpb.NewMessage(eventDescriptor)
+	if err := c.stream.RecvMsg(message); err != nil {
+		return Event{}, err
+	}
+	return decodeEvent(message), nil
+}
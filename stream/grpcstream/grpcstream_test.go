@@ -0,0 +1,86 @@
+package grpcstream
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/intMeric/pii-extractor/extractors/regex"
+	"github.com/intMeric/pii-extractor/stream"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestExtractStream(t *testing.T) {
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	Register(server, &Server{Extractor: regex.NewDefaultExtractor(), Options: []stream.Option{stream.WithOverlap(64)}})
+	go server.Serve(listener)
+	defer server.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return listener.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cs, err := NewClient(conn).ExtractStream(ctx)
+	if err != nil {
+		t.Fatalf("ExtractStream() error = %v", err)
+	}
+
+	first := "Contact john.doe@acme.com " + strings.Repeat("about the order ", 10)
+	if err := cs.Send(first); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	// The email is settled once enough text follows it, before the stream ends
+	event, err := cs.Recv()
+	if err != nil {
+		t.Fatalf("Recv() error = %v", err)
+	}
+	if event.Type != "email" || event.Value != "john.doe@acme.com" || event.Start != 8 || event.End != 25 {
+		t.Errorf("Unexpected first event %+v", event)
+	}
+
+	if err := cs.Send("or call 555-123-"); err != nil {
+		t.Fatal(err)
+	}
+	if err := cs.Send("4567, SSN 123-45-6789"); err != nil {
+		t.Fatal(err)
+	}
+	if err := cs.CloseSend(); err != nil {
+		t.Fatal(err)
+	}
+
+	text := first + "or call 555-123-4567, SSN 123-45-6789"
+	var events []Event
+	for {
+		event, err := cs.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Recv() error = %v", err)
+		}
+		events = append(events, event)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Expected the phone and SSN after closing, got %+v", events)
+	}
+	for _, event := range events {
+		if text[event.Start:event.End] != event.Value {
+			t.Errorf("Event offsets %d-%d do not cover %q", event.Start, event.End, event.Value)
+		}
+	}
+	if events[1].Type != "ssn" || events[1].Severity != "critical" || events[1].Country != "US" {
+		t.Errorf("Unexpected SSN event %+v", events[1])
+	}
+}
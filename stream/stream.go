@@ -0,0 +1,313 @@
+// Package stream extracts PII from text that arrives in chunks, such as chat messages
+// being typed or documents being uploaded, and reports every entity occurrence as soon as
+// no later chunk can extend it, without waiting for the whole text.
+package stream
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"unicode/utf8"
+
+	"github.com/intMeric/pii-extractor/extractors"
+	"github.com/intMeric/pii-extractor/pii"
+	"github.com/intMeric/pii-extractor/progress"
+)
+
+// DefaultOverlap is the number of trailing bytes held back by default. Occurrences ending
+// within the overlap could still grow with the next chunk, so they are reported later.
+const DefaultOverlap = 256
+
+// ErrClosed is returned by Write after Close
+var ErrClosed = errors.New("stream: write after close")
+
+// ErrBackpressure is returned when a call settled more occurrences than Limits.MaxEntities
+// under OverflowBackpressure. The occurrences returned are valid; the others are held
+// back, and Write refuses new text until Drain has returned them all.
+var ErrBackpressure = errors.New("stream: occurrence limit reached, drain before writing")
+
+// OverflowPolicy sets what a stream does when a call settles more occurrences than
+// Limits.MaxEntities
+type OverflowPolicy int
+
+const (
+	// OverflowCountOnly stops returning occurrences for the rest of the stream and only
+	// counts them (see Counts), so that pathological input cannot exhaust memory
+	OverflowCountOnly OverflowPolicy = iota
+	// OverflowBackpressure returns ErrBackpressure and holds the extra occurrences back
+	// until the caller drains them, slowing the producer down to the consumer
+	OverflowBackpressure
+)
+
+// Limits caps the memory a stream uses. Zero values are unlimited.
+type Limits struct {
+	// MaxPending is the most bytes of text extracted at once. Larger chunks are extracted
+	// in slices, and occurrences still growing at the end of a full window are cut instead
+	// of holding the text back. It is raised to four times the overlap if lower.
+	MaxPending int
+	// MaxEntities is the most occurrences returned by one Write, Drain or Close call
+	MaxEntities int
+	// MaxContexts is the most contexts kept on the entity of each returned occurrence
+	MaxContexts int
+	// Overflow is applied when a call settles more than MaxEntities occurrences
+	Overflow OverflowPolicy
+}
+
+// Option configures a Stream
+type Option func(*Stream)
+
+// WithOverlap sets the number of trailing bytes held back until more text arrives. It
+// must exceed the longest entity value expected to span two chunks.
+func WithOverlap(overlap int) Option {
+	return func(s *Stream) {
+		s.overlap = max(overlap, 1)
+	}
+}
+
+// WithLimits caps the memory used on pathological input, such as a document made of
+// millions of email addresses
+func WithLimits(limits Limits) Option {
+	return func(s *Stream) {
+		s.limits = limits
+	}
+}
+
+// WithProgress reports the bytes extracted after every chunk, out of total bytes expected
+// (zero when unknown), such as the Content-Length of an upload
+func WithProgress(total int64, report progress.Func) Option {
+	return func(s *Stream) {
+		s.progress = progress.NewTracker("stream", progress.UnitBytes, total, report)
+	}
+}
+
+// WithUrgent also sends the settled occurrences accepted by urgent to the channel, as soon
+// as they settle, so incident response can route critical findings separately from the
+// bulk of the results. A nil urgent accepts critical severity entities (see Critical).
+// Sends block until received or the context is done, so the channel should be buffered or
+// drained concurrently.
+func WithUrgent(findings chan<- pii.Occurrence, urgent func(pii.PiiEntity) bool) Option {
+	return func(s *Stream) {
+		if urgent == nil {
+			urgent = Critical
+		}
+		s.urgent, s.isUrgent = findings, urgent
+	}
+}
+
+// Critical reports whether an entity has critical severity, such as SSNs, credit cards and
+// secrets
+func Critical(entity pii.PiiEntity) bool {
+	return entity.GetSeverity() >= pii.SeverityCritical
+}
+
+// Stream extracts entity occurrences from text written in chunks. Offsets of the reported
+// occurrences are byte offsets in the whole text written so far. A Stream is not safe for
+// concurrent use.
+type Stream struct {
+	extractor extractors.PiiExtractor
+	overlap   int
+	limits    Limits
+
+	window  string // text not yet settled
+	queued  string // text written but not yet extracted, under MaxPending
+	offset  int    // offset of window in the whole text
+	runes   int    // rune offset of window in the whole text
+	emitted int    // end offset of the last settled occurrence
+	closed  bool
+
+	counts    map[pii.PiiType]int // settled occurrences per type
+	batch     int                 // occurrences returned by the current call
+	countOnly bool                // MaxEntities was exceeded under OverflowCountOnly
+	backlog   bool                // occurrences are held back under OverflowBackpressure
+
+	progress *progress.Tracker
+
+	urgent   chan<- pii.Occurrence
+	isUrgent func(pii.PiiEntity) bool
+}
+
+// New creates a stream running the extractor on the pending text after every chunk
+func New(extractor extractors.PiiExtractor, options ...Option) *Stream {
+	s := &Stream{extractor: extractor, overlap: DefaultOverlap, counts: make(map[pii.PiiType]int)}
+	for _, option := range options {
+		option(s)
+	}
+	if s.limits.MaxPending > 0 {
+		s.limits.MaxPending = max(s.limits.MaxPending, 4*s.overlap)
+	}
+	return s
+}
+
+// Offset returns the number of bytes written so far
+func (s *Stream) Offset() int {
+	return s.offset + len(s.window) + len(s.queued)
+}
+
+// Counts returns the number of occurrences settled so far per type, including those only
+// counted in count-only mode
+func (s *Stream) Counts() map[pii.PiiType]int {
+	return maps.Clone(s.counts)
+}
+
+// CountOnly reports whether the stream degraded to count-only mode after exceeding
+// Limits.MaxEntities
+func (s *Stream) CountOnly() bool {
+	return s.countOnly
+}
+
+// Write appends a chunk and returns the occurrences settled by it. Under
+// OverflowBackpressure, it returns ErrBackpressure without accepting the chunk while
+// occurrences are held back.
+func (s *Stream) Write(ctx context.Context, chunk string) ([]pii.Occurrence, error) {
+	if s.closed {
+		return nil, ErrClosed
+	}
+	if s.backlog {
+		return nil, ErrBackpressure
+	}
+	defer s.progress.Add(int64(len(chunk)))
+
+	s.batch = 0
+	// Text left queued by a failed call is extracted first
+	s.queued += chunk
+	return s.feed(ctx)
+}
+
+// Drain returns the occurrences held back by OverflowBackpressure, at most MaxEntities
+// at a time, with ErrBackpressure while more remain
+func (s *Stream) Drain(ctx context.Context) ([]pii.Occurrence, error) {
+	if !s.backlog {
+		return nil, nil
+	}
+	s.backlog = false
+	s.batch = 0
+	return s.feed(ctx)
+}
+
+// Close extracts the held back text and returns its occurrences. The stream cannot be
+// written to afterwards. Under OverflowBackpressure, it returns ErrBackpressure and stays
+// open while occurrences are held back; call it again to get the next ones.
+func (s *Stream) Close(ctx context.Context) ([]pii.Occurrence, error) {
+	if s.closed {
+		return nil, nil
+	}
+	s.backlog = false
+	s.batch = 0
+	occurrences, err := s.feed(ctx)
+	if err == nil {
+		var settled []pii.Occurrence
+		settled, err = s.settle(ctx, len(s.window))
+		occurrences = append(occurrences, settled...)
+	}
+	if !errors.Is(err, ErrBackpressure) {
+		s.closed = true
+	}
+	return occurrences, err
+}
+
+// feed moves the queued text into the window, at most MaxPending bytes at a time, and
+// settles the occurrences that can no longer change
+func (s *Stream) feed(ctx context.Context) ([]pii.Occurrence, error) {
+	var occurrences []pii.Occurrence
+	for {
+		piece := len(s.queued)
+		if s.limits.MaxPending > 0 {
+			piece = min(piece, max(s.limits.MaxPending-len(s.window), 0))
+			for piece > 0 && piece < len(s.queued) && !utf8.RuneStart(s.queued[piece]) {
+				piece--
+			}
+		}
+		s.window += s.queued[:piece]
+		s.queued = s.queued[piece:]
+
+		cutoff := len(s.window) - s.overlap
+		// Do not split a rune between the settled text and the held back text
+		for cutoff > 0 && !utf8.RuneStart(s.window[cutoff]) {
+			cutoff--
+		}
+		if cutoff > 0 {
+			settled, err := s.settle(ctx, cutoff)
+			occurrences = append(occurrences, settled...)
+			if err != nil {
+				return occurrences, err
+			}
+		}
+		if s.queued == "" {
+			return occurrences, nil
+		}
+	}
+}
+
+// settle extracts the window, reports the new occurrences ending before cutoff and drops
+// the text no longer needed. Occurrences crossing the cutoff stay in the window, unless
+// the window reached MaxPending.
+func (s *Stream) settle(ctx context.Context, cutoff int) ([]pii.Occurrence, error) {
+	result, err := extractors.ExtractWithContext(ctx, s.extractor, s.window)
+	if err != nil {
+		return nil, err
+	}
+	full := s.limits.MaxPending > 0 && len(s.window) >= s.limits.MaxPending
+
+	var settled, urgent []pii.Occurrence
+	keep := cutoff
+	locator := pii.NewLocator(s.window)
+	for _, occurrence := range result.Occurrences(s.window) {
+		if s.offset+occurrence.Start < s.emitted {
+			continue
+		}
+		if occurrence.End > cutoff {
+			if !full {
+				keep = min(keep, occurrence.Start)
+			}
+			continue
+		}
+		if s.limits.MaxEntities > 0 && s.batch >= s.limits.MaxEntities && !s.countOnly {
+			if s.limits.Overflow == OverflowBackpressure {
+				keep = min(keep, occurrence.Start)
+				s.backlog = true
+				break
+			}
+			s.countOnly = true
+		}
+
+		// The entity is located at this occurrence only, in the whole text
+		location := locator.Locate(occurrence.Start, occurrence.End)
+		occurrence.Entity = occurrence.Entity.WithLocations([]pii.Location{location}).ShiftLocations(s.offset, s.runes)
+		occurrence.Start += s.offset
+		occurrence.End += s.offset
+		s.emitted = occurrence.End
+		s.counts[occurrence.Entity.Type]++
+		if s.urgent != nil && s.isUrgent(occurrence.Entity) {
+			urgent = append(urgent, occurrence)
+		}
+		if s.countOnly {
+			continue
+		}
+		if s.limits.MaxContexts > 0 {
+			occurrence.Entity = occurrence.Entity.TrimContexts(s.limits.MaxContexts)
+		}
+		settled = append(settled, occurrence)
+		s.batch++
+	}
+
+	// Keep the overlap before the cutoff too, as left context for the patterns
+	keep = max(0, min(keep, cutoff-s.overlap))
+	for keep > 0 && !utf8.RuneStart(s.window[keep]) {
+		keep--
+	}
+	s.runes += utf8.RuneCountInString(s.window[:keep])
+	s.window = s.window[keep:]
+	s.offset += keep
+
+	for _, occurrence := range urgent {
+		select {
+		case s.urgent <- occurrence:
+		case <-ctx.Done():
+			return settled, ctx.Err()
+		}
+	}
+	if s.backlog {
+		return settled, ErrBackpressure
+	}
+	return settled, nil
+}
@@ -0,0 +1,219 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/intMeric/pii-extractor/extractors/regex"
+	"github.com/intMeric/pii-extractor/pii"
+	"github.com/intMeric/pii-extractor/progress"
+)
+
+// writeAll writes text in chunks of the given size and returns every reported occurrence
+func writeAll(t *testing.T, s *Stream, text string, size int) []pii.Occurrence {
+	t.Helper()
+	var all []pii.Occurrence
+	for start := 0; start < len(text); start += size {
+		occurrences, err := s.Write(context.Background(), text[start:min(start+size, len(text))])
+		if err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+		all = append(all, occurrences...)
+	}
+	occurrences, err := s.Close(context.Background())
+	if err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	return append(all, occurrences...)
+}
+
+func TestStream_MatchesWholeText(t *testing.T) {
+	text := strings.Repeat("filler text ", 20) + "mail john.doe@acme.com now. " +
+		strings.Repeat("more filler ", 30) + "SSN 123-45-6789 and card 4111 1111 1111 1111 é " +
+		strings.Repeat("tail ", 40) + "jane@acme.com"
+
+	result, err := regex.NewDefaultExtractor().Extract(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := result.Occurrences(text)
+
+	for _, size := range []int{1, 7, 64, 1000} {
+		got := writeAll(t, New(regex.NewDefaultExtractor(), WithOverlap(40)), text, size)
+		if len(got) != len(want) {
+			t.Fatalf("chunk size %d: got %d occurrences, want %d: %+v", size, len(got), len(want), got)
+		}
+		for i := range want {
+			if got[i].Start != want[i].Start || got[i].End != want[i].End || got[i].Entity.Type != want[i].Entity.Type {
+				t.Errorf("chunk size %d: occurrence %d = %+v, want %+v", size, i, got[i], want[i])
+			}
+			if text[got[i].Start:got[i].End] != got[i].Entity.GetValue() {
+				t.Errorf("chunk size %d: offsets %d-%d do not match %q", size, got[i].Start, got[i].End, got[i].Entity.GetValue())
+			}
+			locations := got[i].Entity.GetLocations()
+			if len(locations) != 1 || locations[0].Start != got[i].Start ||
+				string([]rune(text)[locations[0].RuneStart:locations[0].RuneEnd]) != got[i].Entity.GetValue() {
+				t.Errorf("chunk size %d: occurrence %d located at %+v", size, i, locations)
+			}
+		}
+	}
+}
+
+func TestStream_ReportsEarly(t *testing.T) {
+	s := New(regex.NewDefaultExtractor(), WithOverlap(20))
+	occurrences, err := s.Write(context.Background(), "Contact john.doe@acme.com"+strings.Repeat(" ", 30))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(occurrences) != 1 || occurrences[0].Start != 8 {
+		t.Errorf("Expected the email before the rest of the text, got %+v", occurrences)
+	}
+	if s.Offset() != 55 {
+		t.Errorf("Offset() = %d, want 55", s.Offset())
+	}
+
+	s.Close(context.Background())
+	if _, err := s.Write(context.Background(), "more"); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected ErrClosed, got %v", err)
+	}
+}
+
+func TestStream_Urgent(t *testing.T) {
+	urgent := make(chan pii.Occurrence, 10)
+	s := New(regex.NewDefaultExtractor(), WithOverlap(20), WithUrgent(urgent, nil))
+	occurrences, err := s.Write(context.Background(), "Mail john.doe@acme.com, SSN 123-45-6789"+strings.Repeat(" ", 30))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(occurrences) != 2 {
+		t.Fatalf("Expected the email and the SSN settled, got %+v", occurrences)
+	}
+	if len(urgent) != 1 {
+		t.Fatalf("Expected only the SSN sent early, got %d findings", len(urgent))
+	}
+	if finding := <-urgent; finding.Entity.Type != pii.PiiTypeSSN || finding.Start != 28 {
+		t.Errorf("Unexpected urgent finding %+v", finding)
+	}
+
+	// Sends give up when the context is done
+	blocked := New(regex.NewDefaultExtractor(), WithOverlap(1), WithUrgent(make(chan pii.Occurrence), nil))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := blocked.Write(ctx, "SSN 123-45-6789  "); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the canceled send to fail, got %v", err)
+	}
+}
+
+// emails returns n distinct email addresses separated by spaces
+func emails(n int) string {
+	var b strings.Builder
+	for i := range n {
+		fmt.Fprintf(&b, "user%d@acme.com ", i)
+	}
+	return b.String()
+}
+
+func TestStream_MaxPending(t *testing.T) {
+	text := strings.Repeat("filler ", 50) + emails(40) + strings.Repeat("tail ", 50)
+	result, _ := regex.NewDefaultExtractor().Extract(text)
+	want := result.Occurrences(text)
+
+	// One large chunk is extracted in slices and finds the same occurrences
+	got := writeAll(t, New(regex.NewDefaultExtractor(), WithOverlap(30), WithLimits(Limits{MaxPending: 200})), text, len(text))
+	if len(got) != len(want) {
+		t.Fatalf("Got %d occurrences, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i].Start != want[i].Start || got[i].End != want[i].End {
+			t.Errorf("Occurrence %d = %d-%d, want %d-%d", i, got[i].Start, got[i].End, want[i].Start, want[i].End)
+		}
+	}
+}
+
+func TestStream_CountOnly(t *testing.T) {
+	s := New(regex.NewDefaultExtractor(), WithOverlap(30), WithLimits(Limits{MaxEntities: 5, MaxContexts: 1}))
+	occurrences := writeAll(t, s, emails(20), 100)
+
+	if !s.CountOnly() {
+		t.Fatal("Expected the stream to degrade to count-only mode")
+	}
+	if len(occurrences) > 10 {
+		t.Errorf("Expected few occurrences returned before degrading, got %d", len(occurrences))
+	}
+	if counts := s.Counts(); counts[pii.PiiTypeEmail] != 20 {
+		t.Errorf("Expected all 20 emails counted, got %v", counts)
+	}
+	for _, occurrence := range occurrences {
+		if len(occurrence.Entity.GetContexts()) > 1 {
+			t.Errorf("Expected at most one context, got %v", occurrence.Entity.GetContexts())
+		}
+	}
+}
+
+func TestStream_Backpressure(t *testing.T) {
+	ctx := context.Background()
+	s := New(regex.NewDefaultExtractor(), WithOverlap(30), WithLimits(Limits{MaxEntities: 4, Overflow: OverflowBackpressure}))
+
+	all, err := s.Write(ctx, emails(10)+strings.Repeat(" ", 40))
+	if !errors.Is(err, ErrBackpressure) || len(all) != 4 {
+		t.Fatalf("Write() = %d occurrences, %v; want 4 and ErrBackpressure", len(all), err)
+	}
+	if _, err := s.Write(ctx, "more"); !errors.Is(err, ErrBackpressure) {
+		t.Errorf("Expected writes refused while backlogged, got %v", err)
+	}
+
+	for {
+		occurrences, err := s.Drain(ctx)
+		if len(occurrences) > 4 {
+			t.Errorf("Drain() returned %d occurrences, limit is 4", len(occurrences))
+		}
+		all = append(all, occurrences...)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, ErrBackpressure) {
+			t.Fatal(err)
+		}
+	}
+	if len(all) != 10 {
+		t.Fatalf("Expected the 10 emails after draining, got %d", len(all))
+	}
+	for i := 1; i < len(all); i++ {
+		if all[i].Start <= all[i-1].Start {
+			t.Errorf("Occurrences out of order: %d then %d", all[i-1].Start, all[i].Start)
+		}
+	}
+
+	// Close returns the rest in batches too
+	if _, err := s.Write(ctx, emails(6)); err != nil && !errors.Is(err, ErrBackpressure) {
+		t.Fatal(err)
+	}
+	for {
+		_, err := s.Close(ctx)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, ErrBackpressure) {
+			t.Fatal(err)
+		}
+	}
+	if s.Counts()[pii.PiiTypeEmail] != 16 {
+		t.Errorf("Expected 16 emails settled, got %v", s.Counts())
+	}
+}
+
+func TestStream_Progress(t *testing.T) {
+	text := strings.Repeat("filler text ", 50)
+	var last progress.Update
+	s := New(regex.NewDefaultExtractor(), WithProgress(int64(len(text)), func(update progress.Update) {
+		last = update
+	}))
+	writeAll(t, s, text, 100)
+
+	if last.Done != int64(len(text)) || last.Fraction() != 1 || last.Unit != progress.UnitBytes {
+		t.Errorf("Expected every byte to be reported, got %+v", last)
+	}
+}
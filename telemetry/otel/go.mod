@@ -0,0 +1,11 @@
+module github.com/intMeric/pii-extractor/telemetry/otel
+
+go 1.23.0
+
+require (
+	github.com/intMeric/pii-extractor v0.0.0
+	go.opentelemetry.io/otel v1.28.0
+	go.opentelemetry.io/otel/trace v1.28.0
+)
+
+replace github.com/intMeric/pii-extractor => ../..
@@ -0,0 +1,83 @@
+// Package otel adapts OpenTelemetry tracing to the pii-extractor telemetry hooks.
+// It lives in its own module so the core library does not depend on OpenTelemetry.
+package otel
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/intMeric/pii-extractor/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName is the instrumentation scope used when installing from a TracerProvider
+const InstrumentationName = "github.com/intMeric/pii-extractor"
+
+// Tracer adapts an OpenTelemetry tracer to telemetry.Tracer
+type Tracer struct {
+	tracer trace.Tracer
+}
+
+// NewTracer creates a telemetry tracer backed by an OpenTelemetry tracer
+func NewTracer(tracer trace.Tracer) *Tracer {
+	return &Tracer{tracer: tracer}
+}
+
+// Install registers a tracer from the given provider for all extractors
+func Install(provider trace.TracerProvider) {
+	telemetry.SetTracer(NewTracer(provider.Tracer(InstrumentationName)))
+}
+
+// Start implements telemetry.Tracer
+func (t *Tracer) Start(ctx context.Context, name string, attrs ...telemetry.Attribute) (context.Context, telemetry.Span) {
+	ctx, span := t.tracer.Start(ctx, name, trace.WithAttributes(convertAttributes(attrs)...))
+	return ctx, &Span{span: span}
+}
+
+// Span adapts an OpenTelemetry span to telemetry.Span
+type Span struct {
+	span trace.Span
+}
+
+// SetAttributes implements telemetry.Span
+func (s *Span) SetAttributes(attrs ...telemetry.Attribute) {
+	s.span.SetAttributes(convertAttributes(attrs)...)
+}
+
+// RecordError implements telemetry.Span
+func (s *Span) RecordError(err error) {
+	if err == nil {
+		return
+	}
+	s.span.RecordError(err)
+	s.span.SetStatus(codes.Error, err.Error())
+}
+
+// End implements telemetry.Span
+func (s *Span) End() {
+	s.span.End()
+}
+
+// convertAttributes maps telemetry attributes to OpenTelemetry key/values
+func convertAttributes(attrs []telemetry.Attribute) []attribute.KeyValue {
+	converted := make([]attribute.KeyValue, 0, len(attrs))
+	for _, attr := range attrs {
+		switch value := attr.Value.(type) {
+		case string:
+			converted = append(converted, attribute.String(attr.Key, value))
+		case int:
+			converted = append(converted, attribute.Int(attr.Key, value))
+		case int64:
+			converted = append(converted, attribute.Int64(attr.Key, value))
+		case float64:
+			converted = append(converted, attribute.Float64(attr.Key, value))
+		case bool:
+			converted = append(converted, attribute.Bool(attr.Key, value))
+		default:
+			converted = append(converted, attribute.String(attr.Key, fmt.Sprint(value)))
+		}
+	}
+	return converted
+}
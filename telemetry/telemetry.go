@@ -0,0 +1,110 @@
+package telemetry
+
+import (
+	"context"
+	"sync"
+)
+
+// Span names recorded by the extractors
+const (
+	SpanRegexExtract     = "pii.regex.extract"
+	SpanRegexScan        = "pii.regex.scan"
+	SpanRegexBatch       = "pii.regex.batch"
+	SpanLLMGenerate      = "pii.llm.generate"
+	SpanValidationRun    = "pii.validation.extract"
+	SpanValidationEntity = "pii.validation.validate_entity"
+	SpanEnsembleExtract  = "pii.ensemble.extract"
+	SpanEnsembleMember   = "pii.ensemble.member"
+	SpanEnsembleCombine  = "pii.ensemble.combine"
+	SpanPipelineRun      = "pii.pipeline.run"
+	SpanPipelineStage    = "pii.pipeline.stage"
+)
+
+// Attribute is a key/value pair attached to a span
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+// String creates a string attribute
+func String(key, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Int creates an integer attribute
+func Int(key string, value int) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Float64 creates a floating point attribute
+func Float64(key string, value float64) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Bool creates a boolean attribute
+func Bool(key string, value bool) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Span represents a single traced operation
+type Span interface {
+	// SetAttributes adds attributes to the span
+	SetAttributes(attrs ...Attribute)
+
+	// RecordError records an error on the span
+	RecordError(err error)
+
+	// End completes the span
+	End()
+}
+
+// Tracer creates spans. Implementations adapt a tracing backend such as OpenTelemetry.
+type Tracer interface {
+	// Start creates a span as a child of any span found in ctx
+	Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+}
+
+var (
+	globalTracer Tracer = noopTracer{}
+	mu           sync.RWMutex
+)
+
+// SetTracer installs the tracer used by all extractors. Passing nil disables tracing.
+func SetTracer(tracer Tracer) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if tracer == nil {
+		tracer = noopTracer{}
+	}
+	globalTracer = tracer
+}
+
+// GetTracer returns the installed tracer
+func GetTracer() Tracer {
+	mu.RLock()
+	defer mu.RUnlock()
+	return globalTracer
+}
+
+// StartSpan starts a span using the installed tracer
+func StartSpan(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return GetTracer().Start(ctx, name, attrs...)
+}
+
+// noopTracer is the default tracer that records nothing
+type noopTracer struct{}
+
+func (noopTracer) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+// noopSpan is the span returned by the default tracer
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(attrs ...Attribute) {}
+func (noopSpan) RecordError(err error)            {}
+func (noopSpan) End()                             {}
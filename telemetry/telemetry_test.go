@@ -0,0 +1,86 @@
+package telemetry_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/intMeric/pii-extractor/extractors/regex"
+	"github.com/intMeric/pii-extractor/telemetry"
+)
+
+// recordingTracer records the names and attributes of ended spans
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []*recordingSpan
+}
+
+type recordingSpan struct {
+	tracer *recordingTracer
+	name   string
+	attrs  map[string]any
+}
+
+func (r *recordingTracer) Start(ctx context.Context, name string, attrs ...telemetry.Attribute) (context.Context, telemetry.Span) {
+	span := &recordingSpan{tracer: r, name: name, attrs: make(map[string]any)}
+	span.SetAttributes(attrs...)
+	return ctx, span
+}
+
+func (s *recordingSpan) SetAttributes(attrs ...telemetry.Attribute) {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	for _, attr := range attrs {
+		s.attrs[attr.Key] = attr.Value
+	}
+}
+
+func (s *recordingSpan) RecordError(err error) {}
+
+func (s *recordingSpan) End() {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	s.tracer.spans = append(s.tracer.spans, s)
+}
+
+func TestRegexExtractorSpans(t *testing.T) {
+	tracer := &recordingTracer{}
+	telemetry.SetTracer(tracer)
+	defer telemetry.SetTracer(nil)
+
+	extractor := regex.NewDefaultExtractor()
+	if _, err := extractor.ExtractContext(context.Background(), "Contact john@acme.com"); err != nil {
+		t.Fatalf("ExtractContext() error = %v", err)
+	}
+
+	var extractSpans, emailMatches int
+	for _, span := range tracer.spans {
+		switch span.name {
+		case telemetry.SpanRegexExtract:
+			extractSpans++
+		case telemetry.SpanRegexScan:
+			if span.attrs["pii.type"] == "email" {
+				emailMatches += span.attrs["pii.matches"].(int)
+			}
+		}
+	}
+
+	if extractSpans != 1 {
+		t.Errorf("Expected 1 extract span, got %d", extractSpans)
+	}
+	if emailMatches != 1 {
+		t.Errorf("Expected the email scan span to report 1 match, got %d", emailMatches)
+	}
+}
+
+func TestSetTracerNilRestoresNoop(t *testing.T) {
+	telemetry.SetTracer(nil)
+
+	ctx := context.Background()
+	got, span := telemetry.StartSpan(ctx, "test")
+	if got != ctx {
+		t.Error("Expected the no-op tracer to return the original context")
+	}
+	span.SetAttributes(telemetry.String("key", "value"))
+	span.End()
+}
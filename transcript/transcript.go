@@ -0,0 +1,122 @@
+// Package transcript extracts PII from conversations, such as contact-center calls and chats,
+// message by message, and summarizes the PII each speaker disclosed, so that quality
+// assurance can tell whether the agent or the customer gave out a card number.
+package transcript
+
+import (
+	"context"
+	"maps"
+	"time"
+
+	"github.com/intMeric/pii-extractor/extractors"
+	"github.com/intMeric/pii-extractor/extractors/regex"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+// Annotations set on the entities of message results
+const (
+	AnnotationSpeaker = "transcript.speaker" // Speaker of the message
+	AnnotationMessage = "transcript.message" // Index of the message in the transcript
+)
+
+// Message is a turn of a conversation
+type Message struct {
+	Speaker   string    `json:"speaker"`
+	Timestamp time.Time `json:"timestamp"` // Zero when unknown
+	Text      string    `json:"text"`
+}
+
+// MessageResult holds the PII found in one message
+type MessageResult struct {
+	Message Message                  `json:"message"`
+	Result  *pii.PiiExtractionResult `json:"result"`
+}
+
+// SpeakerSummary is the PII exposure of one speaker
+type SpeakerSummary struct {
+	Speaker         string                   `json:"speaker"`
+	Messages        int                      `json:"messages"`          // Messages sent
+	MessagesWithPII int                      `json:"messages_with_pii"` // Messages disclosing PII
+	Result          *pii.PiiExtractionResult `json:"result"`            // PII disclosed, merged across messages
+	HighestSeverity pii.Severity             `json:"highest_severity"`  // Severity of the most sensitive disclosure, low when none
+	FirstDisclosure time.Time                `json:"first_disclosure"`  // Timestamp of the first message disclosing PII
+}
+
+// Result is the outcome of a transcript extraction
+type Result struct {
+	Messages []MessageResult          `json:"messages"` // In transcript order
+	Speakers []SpeakerSummary         `json:"speakers"` // In order of first message
+	Result   *pii.PiiExtractionResult `json:"result"`   // PII of the whole conversation, merged
+}
+
+// Speaker returns the summary of a speaker
+func (r *Result) Speaker(speaker string) (SpeakerSummary, bool) {
+	for _, summary := range r.Speakers {
+		if summary.Speaker == speaker {
+			return summary, true
+		}
+	}
+	return SpeakerSummary{}, false
+}
+
+// Extract runs extractor on every message, nil using the default regex extractor. The
+// entities of message results are annotated with their speaker and message index.
+// Cancellation of ctx is checked between messages.
+func Extract(ctx context.Context, extractor extractors.PiiExtractor, messages []Message) (*Result, error) {
+	if extractor == nil {
+		extractor = regex.NewDefaultExtractor()
+	}
+
+	result := &Result{Messages: make([]MessageResult, 0, len(messages))}
+	all := make([]*pii.PiiExtractionResult, 0, len(messages))
+	bySpeaker := make(map[string][]*pii.PiiExtractionResult)
+	summaries := make(map[string]*SpeakerSummary)
+	var order []string
+
+	for i, message := range messages {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		extracted, err := extractors.ExtractWithContext(ctx, extractor, message.Text)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, extracted)
+		bySpeaker[message.Speaker] = append(bySpeaker[message.Speaker], extracted)
+
+		summary, ok := summaries[message.Speaker]
+		if !ok {
+			summary = &SpeakerSummary{Speaker: message.Speaker}
+			summaries[message.Speaker] = summary
+			order = append(order, message.Speaker)
+		}
+		summary.Messages++
+		if !extracted.IsEmpty() {
+			if summary.MessagesWithPII == 0 {
+				summary.FirstDisclosure = message.Timestamp
+			}
+			summary.MessagesWithPII++
+		}
+
+		annotated := *extracted
+		annotated.Entities = make([]pii.PiiEntity, len(extracted.Entities))
+		for j, entity := range extracted.Entities {
+			entity.Annotations = maps.Clone(entity.Annotations)
+			entity.Annotate(AnnotationSpeaker, message.Speaker)
+			entity.Annotate(AnnotationMessage, i)
+			annotated.Entities[j] = entity
+		}
+		result.Messages = append(result.Messages, MessageResult{Message: message, Result: &annotated})
+	}
+
+	for _, speaker := range order {
+		summary := summaries[speaker]
+		summary.Result = pii.MergeResults(bySpeaker[speaker]...)
+		for _, entity := range summary.Result.Entities {
+			summary.HighestSeverity = max(summary.HighestSeverity, entity.GetSeverity())
+		}
+		result.Speakers = append(result.Speakers, *summary)
+	}
+	result.Result = pii.MergeResults(all...)
+	return result, nil
+}
@@ -0,0 +1,59 @@
+package transcript
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+func TestExtract(t *testing.T) {
+	start := time.Date(2025, 3, 4, 10, 0, 0, 0, time.UTC)
+	messages := []Message{
+		{Speaker: "agent", Timestamp: start, Text: "Thanks for calling, can I have your email?"},
+		{Speaker: "customer", Timestamp: start.Add(10 * time.Second), Text: "Sure, it's jane@example.com"},
+		{Speaker: "agent", Timestamp: start.Add(20 * time.Second), Text: "I have jane@example.com, and your card?"},
+		{Speaker: "customer", Timestamp: start.Add(30 * time.Second), Text: "4111 1111 1111 1111"},
+	}
+
+	result, err := Extract(context.Background(), nil, messages)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if len(result.Messages) != 4 || len(result.Speakers) != 2 || result.Speakers[0].Speaker != "agent" {
+		t.Fatalf("Unexpected structure: %d messages, speakers %+v", len(result.Messages), result.Speakers)
+	}
+
+	customer, ok := result.Speaker("customer")
+	if !ok {
+		t.Fatal("Expected a customer summary")
+	}
+	if customer.Messages != 2 || customer.MessagesWithPII != 2 || !customer.FirstDisclosure.Equal(start.Add(10*time.Second)) {
+		t.Errorf("Unexpected customer summary: %+v", customer)
+	}
+	if customer.Result.Stats[pii.PiiTypeCreditCard] != 1 || customer.HighestSeverity != pii.SeverityCritical {
+		t.Errorf("Expected the customer to disclose a card, got %v (%s)", customer.Result.Stats, customer.HighestSeverity)
+	}
+
+	agent, _ := result.Speaker("agent")
+	if agent.MessagesWithPII != 1 || agent.Result.Stats[pii.PiiTypeCreditCard] != 0 || agent.Result.Stats[pii.PiiTypeEmail] != 1 {
+		t.Errorf("Expected the agent to repeat only the email, got %+v", agent.Result.Stats)
+	}
+
+	entity := result.Messages[1].Result.Entities[0]
+	if entity.Annotations[AnnotationSpeaker] != "customer" || entity.Annotations[AnnotationMessage] != 1 {
+		t.Errorf("Expected speaker and message annotations, got %v", entity.Annotations)
+	}
+	if email := result.Result.GetEntitiesByType(pii.PiiTypeEmail); len(email) != 1 || email[0].GetCount() != 2 {
+		t.Errorf("Expected the conversation result to merge the email, got %+v", email)
+	}
+}
+
+func TestExtract_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := Extract(ctx, nil, []Message{{Speaker: "agent", Text: "hello"}}); err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
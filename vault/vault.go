@@ -0,0 +1,285 @@
+// Package vault keeps the raw values of scrubbed results envelope-encrypted, so that a
+// finding stored without its PII can still be revealed in a break-glass workflow.
+//
+// Each value is encrypted with its own AES-256-GCM data key, wrapped by a user-supplied
+// KMS. Values are only decrypted through an explicit Reveal call approved by a second
+// person, and every reveal is reported to the audit hook.
+package vault
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/intMeric/pii-extractor/extractors"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+var (
+	// ErrNotFound is returned when no value is sealed under an entity ID
+	ErrNotFound = errors.New("vault: no value sealed for this entity")
+	// ErrDualControl is returned when a reveal is not approved by a second person
+	ErrDualControl = errors.New("vault: reveal requires an approver other than the requester")
+)
+
+// KMS wraps and unwraps data keys, typically with a cloud KMS or HSM key the vault never
+// sees. Decrypt is where access policies of the key apply.
+type KMS interface {
+	Encrypt(ctx context.Context, plaintext []byte) ([]byte, error)
+	Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error)
+}
+
+// Entry is a sealed value. The ciphertext is bound to the entity ID, so entries cannot
+// be swapped between entities.
+type Entry struct {
+	ID         string      `json:"id"`
+	Type       pii.PiiType `json:"type"`
+	WrappedKey []byte      `json:"wrapped_key"` // Data key encrypted by the KMS
+	Nonce      []byte      `json:"nonce"`
+	Ciphertext []byte      `json:"ciphertext"`
+}
+
+// Store persists sealed entries by entity ID
+type Store interface {
+	Put(ctx context.Context, entry Entry) error
+	// Get returns ErrNotFound when no entry exists for id
+	Get(ctx context.Context, id string) (Entry, error)
+}
+
+// RevealRequest records who reveals a value and why
+type RevealRequest struct {
+	Requester string `json:"requester"`
+	Approver  string `json:"approver"` // Second person approving the reveal
+	Reason    string `json:"reason"`   // Ticket or incident justifying it
+}
+
+// Reveal is reported to the audit hook for every reveal attempt
+type Reveal struct {
+	RevealRequest
+	ID    string      `json:"id"`
+	Type  pii.PiiType `json:"type"`
+	Error error       `json:"-"` // Nil when the value was revealed
+}
+
+// Option configures a Vault
+type Option func(*Vault)
+
+// WithAudit sets a hook called for every reveal attempt, allowed or not
+func WithAudit(audit func(ctx context.Context, reveal Reveal)) Option {
+	return func(v *Vault) {
+		v.audit = audit
+	}
+}
+
+// WithSalt sets the secret salt of the entity IDs values are sealed under, required by
+// PostProcessor to scrub values (see pii.PiiExtractionResult.Scrub)
+func WithSalt(salt string) Option {
+	return func(v *Vault) {
+		v.salt = salt
+	}
+}
+
+// Vault seals and reveals raw entity values
+type Vault struct {
+	kms   KMS
+	store Store
+	audit func(ctx context.Context, reveal Reveal)
+	salt  string
+}
+
+// New creates a vault encrypting data keys with kms and storing entries in store
+func New(kms KMS, store Store, options ...Option) *Vault {
+	v := &Vault{kms: kms, store: store}
+	for _, option := range options {
+		option(v)
+	}
+	return v
+}
+
+// Seal encrypts and stores the raw value of every entity of the result under its ID,
+// computed with the salt of the vault when set. Entities without an ID otherwise get the
+// unsalted one of pii.NewPiiExtractionResult, and scrubbed entities are skipped since
+// their raw value is gone.
+func (v *Vault) Seal(ctx context.Context, result *pii.PiiExtractionResult) error {
+	for i := range result.Entities {
+		entity := &result.Entities[i]
+		if entity.IsScrubbed() || entity.Value == nil {
+			continue
+		}
+		if v.salt != "" || entity.ID == "" {
+			entity.ID = pii.EntityID(entity.Type, entity.GetValue(), v.salt)
+		}
+		entry, err := v.seal(ctx, entity.ID, entity.Type, entity.GetValue())
+		if err != nil {
+			return err
+		}
+		if err := v.store.Put(ctx, entry); err != nil {
+			return fmt.Errorf("vault: storing %s: %w", entity.ID, err)
+		}
+	}
+	return nil
+}
+
+// Reveal decrypts the raw value sealed under an entity ID. The request needs a
+// requester, an approver who is someone else, and a reason.
+func (v *Vault) Reveal(ctx context.Context, id string, request RevealRequest) (string, error) {
+	reveal := Reveal{RevealRequest: request, ID: id}
+	value, err := v.reveal(ctx, &reveal)
+	if v.audit != nil {
+		reveal.Error = err
+		v.audit(ctx, reveal)
+	}
+	return value, err
+}
+
+// PostProcessor returns a middleware post-processor sealing the values of results before
+// scrubbing them at level with the salt of the vault, so that extraction never returns
+// raw PII. From pii.ScrubValues on, extraction fails with pii.ErrNoSalt without WithSalt.
+func (v *Vault) PostProcessor(level pii.ScrubLevel) extractors.PostProcessor {
+	return func(ctx context.Context, _ string, result *pii.PiiExtractionResult) (*pii.PiiExtractionResult, error) {
+		if level >= pii.ScrubValues && v.salt == "" {
+			return nil, pii.ErrNoSalt
+		}
+		if err := v.Seal(ctx, result); err != nil {
+			return nil, err
+		}
+		return result.Scrub(level, v.salt)
+	}
+}
+
+func (v *Vault) reveal(ctx context.Context, reveal *Reveal) (string, error) {
+	requester := strings.TrimSpace(reveal.Requester)
+	approver := strings.TrimSpace(reveal.Approver)
+	if requester == "" || approver == "" || strings.EqualFold(requester, approver) {
+		return "", ErrDualControl
+	}
+	if strings.TrimSpace(reveal.Reason) == "" {
+		return "", errors.New("vault: reveal requires a reason")
+	}
+
+	entry, err := v.store.Get(ctx, reveal.ID)
+	if err != nil {
+		return "", err
+	}
+	reveal.Type = entry.Type
+
+	key, err := v.kms.Decrypt(ctx, entry.WrappedKey)
+	if err != nil {
+		return "", fmt.Errorf("vault: unwrapping data key: %w", err)
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := aead.Open(nil, entry.Nonce, entry.Ciphertext, []byte(entry.ID))
+	if err != nil {
+		return "", fmt.Errorf("vault: decrypting %s: %w", entry.ID, err)
+	}
+	return string(plaintext), nil
+}
+
+func (v *Vault) seal(ctx context.Context, id string, piiType pii.PiiType, value string) (Entry, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return Entry{}, err
+	}
+	wrapped, err := v.kms.Encrypt(ctx, key)
+	if err != nil {
+		return Entry{}, fmt.Errorf("vault: wrapping data key: %w", err)
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return Entry{}, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return Entry{}, err
+	}
+	return Entry{
+		ID:         id,
+		Type:       piiType,
+		WrappedKey: wrapped,
+		Nonce:      nonce,
+		Ciphertext: aead.Seal(nil, nonce, []byte(value), []byte(id)),
+	}, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("vault: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// MemoryStore is an in-memory Store, for tests and short-lived processes
+type MemoryStore struct {
+	entries map[string]Entry
+	mu      sync.RWMutex
+}
+
+// NewMemoryStore creates an empty memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: make(map[string]Entry)}
+}
+
+// Put implements Store
+func (s *MemoryStore) Put(_ context.Context, entry Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[entry.ID] = entry
+	return nil
+}
+
+// Get implements Store
+func (s *MemoryStore) Get(_ context.Context, id string) (Entry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entry, ok := s.entries[id]
+	if !ok {
+		return Entry{}, ErrNotFound
+	}
+	return entry, nil
+}
+
+// LocalKMS wraps data keys with a local AES-256 key. It is meant for tests and
+// development: in production the key encryption key belongs in a KMS or HSM.
+type LocalKMS struct {
+	aead cipher.AEAD
+}
+
+// NewLocalKMS creates a local KMS from a 32 byte key
+func NewLocalKMS(key []byte) (*LocalKMS, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("vault: local KMS key must be 32 bytes, got %d", len(key))
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	return &LocalKMS{aead: aead}, nil
+}
+
+// Encrypt implements KMS, prefixing the ciphertext with its nonce
+func (k *LocalKMS) Encrypt(_ context.Context, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, k.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return k.aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// Decrypt implements KMS
+func (k *LocalKMS) Decrypt(_ context.Context, ciphertext []byte) ([]byte, error) {
+	size := k.aead.NonceSize()
+	if len(ciphertext) < size {
+		return nil, errors.New("vault: ciphertext too short")
+	}
+	return k.aead.Open(nil, ciphertext[:size], ciphertext[size:], nil)
+}
@@ -0,0 +1,107 @@
+package vault
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/intMeric/pii-extractor/extractors"
+	"github.com/intMeric/pii-extractor/extractors/regex"
+	"github.com/intMeric/pii-extractor/pii"
+)
+
+func newTestVault(t *testing.T, options ...Option) (*Vault, *MemoryStore) {
+	t.Helper()
+	kms, err := NewLocalKMS(bytes.Repeat([]byte{7}, 32))
+	if err != nil {
+		t.Fatalf("NewLocalKMS() error = %v", err)
+	}
+	store := NewMemoryStore()
+	return New(kms, store, options...), store
+}
+
+func TestVault_SealAndReveal(t *testing.T) {
+	var audited []Reveal
+	v, store := newTestVault(t, WithSalt("tenant-secret"), WithAudit(func(ctx context.Context, reveal Reveal) {
+		audited = append(audited, reveal)
+	}))
+
+	extractor := extractors.WithMiddleware(
+		regex.NewExtractor(&extractors.ExtractorConfig{Method: extractors.MethodRegex}),
+		extractors.WithPostProcessor(v.PostProcessor(pii.ScrubValues)))
+	result, err := extractor.Extract("Contact john.doe@example.com")
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if result.Total != 1 || !result.Entities[0].IsScrubbed() {
+		t.Fatalf("Expected a scrubbed email, got %+v", result.Entities)
+	}
+	id := result.Entities[0].ID
+	if id != pii.EntityID(pii.PiiTypeEmail, "john.doe@example.com", "tenant-secret") {
+		t.Errorf("Expected the value sealed under its salted ID, got %s", id)
+	}
+
+	entry, err := store.Get(context.Background(), id)
+	if err != nil {
+		t.Fatalf("Expected the value to be sealed: %v", err)
+	}
+	if bytes.Contains(entry.Ciphertext, []byte("john.doe")) {
+		t.Error("Expected the stored value to be encrypted")
+	}
+
+	denied := []RevealRequest{
+		{Requester: "alice", Reason: "INC-42"},
+		{Requester: "alice", Approver: "Alice", Reason: "INC-42"},
+		{Requester: "alice", Approver: "bob"},
+	}
+	for _, request := range denied {
+		if _, err := v.Reveal(context.Background(), id, request); err == nil {
+			t.Errorf("Expected %+v to be denied", request)
+		}
+	}
+
+	value, err := v.Reveal(context.Background(), id, RevealRequest{Requester: "alice", Approver: "bob", Reason: "INC-42"})
+	if err != nil || value != "john.doe@example.com" {
+		t.Fatalf("Reveal() = %q, %v", value, err)
+	}
+	if len(audited) != 4 || audited[3].Error != nil || audited[3].Type != pii.PiiTypeEmail || !errors.Is(audited[1].Error, ErrDualControl) {
+		t.Errorf("Expected every attempt to be audited, got %+v", audited)
+	}
+}
+
+func TestVault_Tampering(t *testing.T) {
+	v, store := newTestVault(t)
+	result := pii.NewPiiExtractionResult([]pii.PiiEntity{
+		{Type: pii.PiiTypeSSN, Value: pii.NewSSN("123-45-6789")},
+		{Type: pii.PiiTypeEmail, Value: pii.NewEmail("a@b.io")},
+	})
+	if err := v.Seal(context.Background(), result); err != nil {
+		t.Fatalf("Seal() error = %v", err)
+	}
+
+	request := RevealRequest{Requester: "alice", Approver: "bob", Reason: "INC-42"}
+	if _, err := v.Reveal(context.Background(), "pii_unknown", request); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+
+	// An entry moved under another ID does not decrypt
+	first, _ := store.Get(context.Background(), result.Entities[0].ID)
+	first.ID = result.Entities[1].ID
+	store.Put(context.Background(), first)
+	if _, err := v.Reveal(context.Background(), first.ID, request); err == nil || !strings.Contains(err.Error(), "decrypting") {
+		t.Errorf("Expected a swapped entry to fail, got %v", err)
+	}
+}
+
+func TestVault_PostProcessorRequiresSalt(t *testing.T) {
+	v, store := newTestVault(t)
+	extractor := extractors.WithMiddleware(regex.NewDefaultExtractor(), extractors.WithPostProcessor(v.PostProcessor(pii.ScrubValues)))
+	if _, err := extractor.Extract("Contact john.doe@example.com"); !errors.Is(err, pii.ErrNoSalt) {
+		t.Errorf("Expected pii.ErrNoSalt without a salt, got %v", err)
+	}
+	if len(store.entries) != 0 {
+		t.Errorf("Expected nothing sealed without a salt, got %d entries", len(store.entries))
+	}
+}
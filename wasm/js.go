@@ -0,0 +1,39 @@
+//go:build js && wasm
+
+package wasm
+
+import "syscall/js"
+
+// GlobalName is the JavaScript global holding the exported functions
+const GlobalName = "piiExtractor"
+
+// Register installs the extract and redact functions on the JavaScript global object.
+// Both take the text and JSON-encoded Options and return a JSON string holding either
+// "result" or "error".
+func Register() {
+	js.Global().Set(GlobalName, js.ValueOf(map[string]any{
+		"extract": js.FuncOf(func(_ js.Value, args []js.Value) any {
+			text, options := arguments(args)
+			return call(options, func(options Options) (any, error) {
+				return Extract(text, options)
+			})
+		}),
+		"redact": js.FuncOf(func(_ js.Value, args []js.Value) any {
+			text, options := arguments(args)
+			return call(options, func(options Options) (any, error) {
+				return Redact(text, options)
+			})
+		}),
+	}))
+}
+
+// arguments returns the text and options arguments of a call
+func arguments(args []js.Value) (text, options string) {
+	if len(args) > 0 && args[0].Type() == js.TypeString {
+		text = args[0].String()
+	}
+	if len(args) > 1 && args[1].Type() == js.TypeString {
+		options = args[1].String()
+	}
+	return text, options
+}
@@ -0,0 +1,59 @@
+// JavaScript wrapper of the pii-extractor WebAssembly module.
+//
+// Load wasm_exec.js from the Go distribution first, which defines the global Go class:
+//
+//   <script src="wasm_exec.js"></script>
+//   <script type="module">
+//     import { load } from "./pii.js";
+//     const pii = await load("pii.wasm");
+//     const entities = pii.extract(input.value, { countries: ["US"], minSeverity: "medium" });
+//     const safe = pii.redact(input.value, { preset: "standard" });
+//   </script>
+//
+// Offsets of extracted entities are byte offsets in the UTF-8 encoded text.
+
+const GLOBAL_NAME = "piiExtractor";
+
+// load instantiates the module from a URL, a Response or bytes, and returns its API
+export async function load(source = "pii.wasm") {
+  if (typeof globalThis.Go !== "function") {
+    throw new Error("pii-extractor: load wasm_exec.js before pii.js");
+  }
+  const go = new globalThis.Go();
+  const { instance } = await instantiate(source, go.importObject);
+  // run resolves only when the program exits, which it never does
+  go.run(instance);
+
+  const api = globalThis[GLOBAL_NAME];
+  if (!api) {
+    throw new Error("pii-extractor: the module did not register its functions");
+  }
+  return {
+    extract: (text, options = {}) => unwrap(api.extract(String(text), JSON.stringify(options))),
+    redact: (text, options = {}) => unwrap(api.redact(String(text), JSON.stringify(options))),
+  };
+}
+
+async function instantiate(source, imports) {
+  if (source instanceof ArrayBuffer || ArrayBuffer.isView(source)) {
+    return WebAssembly.instantiate(source, imports);
+  }
+  const response = typeof source === "string" || source instanceof URL ? fetch(source) : source;
+  if (WebAssembly.instantiateStreaming) {
+    try {
+      return await WebAssembly.instantiateStreaming(response, imports);
+    } catch {
+      // Servers not sending application/wasm: fall back to bytes below
+    }
+  }
+  const bytes = await (await response).clone().arrayBuffer();
+  return WebAssembly.instantiate(bytes, imports);
+}
+
+function unwrap(json) {
+  const reply = JSON.parse(json);
+  if (reply.error) {
+    throw new Error(`pii-extractor: ${reply.error}`);
+  }
+  return reply.result;
+}
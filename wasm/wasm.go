@@ -0,0 +1,150 @@
+// Package wasm exposes the regex extraction core to JavaScript when compiled to
+// WebAssembly, so that user input can be checked for PII in the browser before it is
+// sent anywhere. It depends on neither gollm nor the network.
+//
+// Build the module with:
+//
+//	GOOS=js GOARCH=wasm go build -o pii.wasm ./cmd/pii-wasm
+//
+// and load it with the pii.js wrapper next to this file.
+package wasm
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/intMeric/pii-extractor/extractors"
+	"github.com/intMeric/pii-extractor/extractors/regex"
+	"github.com/intMeric/pii-extractor/pii"
+	"github.com/intMeric/pii-extractor/redact"
+)
+
+// Options configures a call from JavaScript
+type Options struct {
+	// Countries restricts country-specific detection (empty = all)
+	Countries []string `json:"countries,omitempty"`
+
+	// MinSeverity drops entities below "low", "medium", "high" or "critical"
+	MinSeverity string `json:"minSeverity,omitempty"`
+
+	// Preset names the masking preset used by Redact (default "strict")
+	Preset string `json:"preset,omitempty"`
+}
+
+// Entity is an occurrence of a detected value, with byte offsets in the UTF-8 text
+type Entity struct {
+	Type     string `json:"type"`
+	Value    string `json:"value"`
+	Start    int    `json:"start"`
+	End      int    `json:"end"`
+	Severity string `json:"severity"`
+	Country  string `json:"country,omitempty"`
+}
+
+// Extract detects the PII occurrences of text
+func Extract(text string, options Options) ([]Entity, error) {
+	result, err := extract(text, options)
+	if err != nil {
+		return nil, err
+	}
+
+	entities := []Entity{}
+	for _, occurrence := range result.Occurrences(text) {
+		entities = append(entities, Entity{
+			Type:     occurrence.Entity.Type.String(),
+			Value:    occurrence.Entity.GetValue(),
+			Start:    occurrence.Start,
+			End:      occurrence.End,
+			Severity: occurrence.Entity.GetSeverity().String(),
+			Country:  pii.EntityCountry(occurrence.Entity).String(),
+		})
+	}
+	return entities, nil
+}
+
+// Redact returns text with its PII masked by the options' preset
+func Redact(text string, options Options) (string, error) {
+	name := options.Preset
+	if name == "" {
+		name = redact.PresetStrict.Name
+	}
+	preset, ok := redact.Presets()[name]
+	if !ok {
+		return "", fmt.Errorf("unknown preset %q", name)
+	}
+
+	result, err := extract(text, options)
+	if err != nil {
+		return "", err
+	}
+	return redact.New(preset).Redact(text, result), nil
+}
+
+// extract runs a regex extractor configured by options
+func extract(text string, options Options) (*pii.PiiExtractionResult, error) {
+	config := &extractors.ExtractorConfig{Method: extractors.MethodRegex}
+	for _, code := range options.Countries {
+		country, ok := pii.ParseCountry(code)
+		if !ok {
+			return nil, fmt.Errorf("unknown country %q", code)
+		}
+		config.Countries = append(config.Countries, country)
+	}
+	minSeverity, err := parseSeverity(options.MinSeverity)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := regex.NewExtractor(config).Extract(text)
+	if err != nil {
+		return nil, err
+	}
+	if minSeverity == pii.SeverityLow {
+		return result, nil
+	}
+	var kept []pii.PiiEntity
+	for _, entity := range result.Entities {
+		if entity.GetSeverity() >= minSeverity {
+			kept = append(kept, entity)
+		}
+	}
+	return pii.NewPiiExtractionResult(kept), nil
+}
+
+// parseSeverity parses a severity name, empty meaning low
+func parseSeverity(name string) (pii.Severity, error) {
+	if name == "" {
+		return pii.SeverityLow, nil
+	}
+	for _, severity := range []pii.Severity{pii.SeverityLow, pii.SeverityMedium, pii.SeverityHigh, pii.SeverityCritical} {
+		if strings.EqualFold(name, severity.String()) {
+			return severity, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown severity %q", name)
+}
+
+// call decodes JSON options, runs fn and encodes its result or error as JSON, the form
+// exchanged with the JavaScript wrapper
+func call(optionsJSON string, fn func(Options) (any, error)) string {
+	var options Options
+	if optionsJSON != "" {
+		if err := json.Unmarshal([]byte(optionsJSON), &options); err != nil {
+			return encode(map[string]string{"error": "invalid options: " + err.Error()})
+		}
+	}
+	value, err := fn(options)
+	if err != nil {
+		return encode(map[string]string{"error": err.Error()})
+	}
+	return encode(map[string]any{"result": value})
+}
+
+func encode(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return `{"error":"encoding failed"}`
+	}
+	return string(data)
+}
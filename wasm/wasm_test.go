@@ -0,0 +1,42 @@
+package wasm
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestExtract(t *testing.T) {
+	text := "Mail john@acme.com, SSN 123-45-6789"
+	entities, err := Extract(text, Options{MinSeverity: "high"})
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if len(entities) != 1 || entities[0].Type != "ssn" || text[entities[0].Start:entities[0].End] != "123-45-6789" {
+		t.Errorf("Expected only the SSN with its offsets, got %+v", entities)
+	}
+
+	if _, err := Extract(text, Options{Countries: []string{"Atlantis"}}); err == nil {
+		t.Error("Expected an error for an unknown country")
+	}
+}
+
+func TestCall(t *testing.T) {
+	redacted := call(`{"preset":"strict"}`, func(options Options) (any, error) {
+		return Redact("Mail john@acme.com", options)
+	})
+	var reply struct {
+		Result string `json:"result"`
+		Error  string `json:"error"`
+	}
+	if err := json.Unmarshal([]byte(redacted), &reply); err != nil || reply.Result != "Mail [EMAIL]" {
+		t.Errorf("Unexpected reply %s", redacted)
+	}
+
+	failed := call(`{"minSeverity":"urgent"}`, func(options Options) (any, error) {
+		return Extract("", options)
+	})
+	reply.Result = ""
+	if err := json.Unmarshal([]byte(failed), &reply); err != nil || reply.Error != `unknown severity "urgent"` {
+		t.Errorf("Expected the error in the reply, got %s", failed)
+	}
+}